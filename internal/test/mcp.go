@@ -237,12 +237,24 @@ func NewMcpClient(t *testing.T, mcpHttpServer http.Handler, options ...McpClient
 				Params: req.Params,
 			})
 		},
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			ret.notifications.capture(&CapturedNotification{
+				Method: "notifications/resources/updated",
+				Params: req.Params,
+			})
+		},
 		LoggingMessageHandler: func(_ context.Context, req *mcp.LoggingMessageRequest) {
 			ret.notifications.capture(&CapturedNotification{
 				Method: "notifications/message",
 				Params: req.Params,
 			})
 		},
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			ret.notifications.capture(&CapturedNotification{
+				Method: "notifications/progress",
+				Params: req.Params,
+			})
+		},
 	}
 
 	ret.client = mcp.NewClient(cfg.clientInfo, clientOptions)
@@ -287,6 +299,16 @@ func (m *McpClient) CallTool(name string, args map[string]any) (*mcp.CallToolRes
 	})
 }
 
+// CallToolWithProgressToken helper function to call a tool by name with arguments requesting progress notifications
+func (m *McpClient) CallToolWithProgressToken(name string, args map[string]any, progressToken any) (*mcp.CallToolResult, error) {
+	params := &mcp.CallToolParams{
+		Name:      name,
+		Arguments: args,
+	}
+	params.SetProgressToken(progressToken)
+	return m.Session.CallTool(m.ctx, params)
+}
+
 // CallToolRaw sends a raw JSON-RPC tools/call request bypassing the go-sdk client.
 // This allows sending requests exactly as a non-go-sdk MCP client would, without
 // the go-sdk's automatic normalization (e.g., the go-sdk always adds "arguments": {}
@@ -337,6 +359,16 @@ func (m *McpClient) ReadResource(uri string) (*mcp.ReadResourceResult, error) {
 	return m.Session.ReadResource(m.ctx, &mcp.ReadResourceParams{URI: uri})
 }
 
+// SubscribeResource helper function to subscribe to the updates of a resource by URI
+func (m *McpClient) SubscribeResource(uri string) error {
+	return m.Session.Subscribe(m.ctx, &mcp.SubscribeParams{URI: uri})
+}
+
+// UnsubscribeResource helper function to unsubscribe from the updates of a resource by URI
+func (m *McpClient) UnsubscribeResource(uri string) error {
+	return m.Session.Unsubscribe(m.ctx, &mcp.UnsubscribeParams{URI: uri})
+}
+
 // ListResourceTemplates helper function to list available resource templates
 func (m *McpClient) ListResourceTemplates() (*mcp.ListResourceTemplatesResult, error) {
 	return m.Session.ListResourceTemplates(m.ctx, &mcp.ListResourceTemplatesParams{})
@@ -13,6 +13,7 @@ import (
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
 
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
@@ -169,6 +170,9 @@ func main() {
 			fmt.Fprintf(&toolsetResourceTemplates, "- **%s** - %s\n", template.ResourceTemplate.Name, template.ResourceTemplate.Description)
 			fmt.Fprintf(&toolsetResourceTemplates, "  - URI Template: `%s`\n", template.ResourceTemplate.URITemplate)
 			fmt.Fprintf(&toolsetResourceTemplates, "  - MIME Type: `%s`\n", template.ResourceTemplate.MIMEType)
+			if template.KubernetesWatcher != nil {
+				toolsetResourceTemplates.WriteString("  - Subscriptions: supported\n")
+			}
 		}
 		toolsetResourceTemplates.WriteString("</details>\n\n")
 	}
@@ -0,0 +1,23 @@
+package api
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// Capability is an optional feature of the cluster (e.g. a distribution or an add-on) identified by the API resources it serves.
+// Tools and prompts requiring a Capability are only registered when the cluster provides it (probed via discovery),
+// or when it's force-enabled in the configuration (enabled_capabilities).
+type Capability struct {
+	// Name identifies the capability in the configuration and logs (e.g. "kubevirt")
+	Name string
+	// GVKs are the API resources that the cluster must serve for the capability to be available
+	GVKs []schema.GroupVersionKind
+}
+
+// CapabilityKubeVirt is available in clusters with KubeVirt installed
+var CapabilityKubeVirt = Capability{Name: "kubevirt", GVKs: []schema.GroupVersionKind{
+	{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstance"},
+}}
+
+// Capabilities returns the names of the capabilities that tools and prompts can require
+func Capabilities() []string {
+	return []string{CapabilityKubeVirt.Name}
+}
@@ -36,6 +36,43 @@ type ClusterProvider interface {
 	GetKubeConfigPath() string
 }
 
+// BearerTokenFileProvider provides access to the bearer_token_file setting.
+type BearerTokenFileProvider interface {
+	// GetBearerTokenFile returns the path to the file holding the bearer token used to authenticate to the Kubernetes API (if configured).
+	// The file is periodically re-read so that rotated tokens are picked up.
+	GetBearerTokenFile() string
+}
+
+// KubeClientRateLimitProvider provides access to the client-side rate limits of the requests to the Kubernetes API.
+type KubeClientRateLimitProvider interface {
+	// GetKubeClientQPS returns the maximum sustained queries per second to the Kubernetes API (zero for the client-go default).
+	GetKubeClientQPS() float32
+	// GetKubeClientBurst returns the maximum burst of queries to the Kubernetes API (zero for the client-go default).
+	GetKubeClientBurst() int
+}
+
+// DefaultMaxConcurrentWatches is the maximum number of concurrent watches of each cluster when not configured.
+const DefaultMaxConcurrentWatches = 100
+
+// MaxConcurrentWatchesProvider provides access to the cap on the concurrent watches opened against the Kubernetes API.
+type MaxConcurrentWatchesProvider interface {
+	// GetMaxConcurrentWatches returns the maximum number of concurrent watches of each cluster (zero for DefaultMaxConcurrentWatches).
+	GetMaxConcurrentWatches() int
+}
+
+const (
+	// KubeClientContentTypeProtobuf exchanges the built-in kinds as protobuf with the Kubernetes API (default).
+	KubeClientContentTypeProtobuf = "protobuf"
+	// KubeClientContentTypeJSON exchanges all the kinds as JSON with the Kubernetes API.
+	KubeClientContentTypeJSON = "json"
+)
+
+// KubeClientContentTypeProvider provides access to the wire format of the requests to the Kubernetes API.
+type KubeClientContentTypeProvider interface {
+	// GetKubeClientContentType returns the wire format of the built-in kinds (empty for the default, protobuf).
+	GetKubeClientContentType() string
+}
+
 // ExtendedConfig is the interface that all configuration extensions must implement.
 // Each extended config manager registers a factory function to parse its config from TOML primitives
 type ExtendedConfig interface {
@@ -97,15 +134,34 @@ type RequireOAuthProvider interface {
 	IsRequireOAuth() bool
 }
 
+// ToolsetsProvider provides access to the names of the enabled toolsets.
+type ToolsetsProvider interface {
+	GetToolsets() []string
+}
+
+// ScanKindsAll is the scan_kinds value to scan every namespaced kind served by the cluster.
+const ScanKindsAll = "all"
+
+// ScanKindsProvider provides access to the kinds inspected by the operations that scan all the resources of a namespace.
+type ScanKindsProvider interface {
+	GetScanKinds() []string
+}
+
 type BaseConfig interface {
+	BearerTokenFileProvider
 	ClusterAuthProvider
 	ClusterProvider
 	ConfirmationRulesProvider
 	DeniedResourcesProvider
 	ExtendedConfigProvider
+	KubeClientContentTypeProvider
+	KubeClientRateLimitProvider
+	MaxConcurrentWatchesProvider
 	StsConfigProvider
 	CertificateAuthorityProvider
 	ValidationEnabledProvider
 	RequireTLSProvider
 	RequireOAuthProvider
+	ToolsetsProvider
+	ScanKindsProvider
 }
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Dependency is an add-on installed in the cluster (e.g. an operator providing CRDs) that a tool relies on.
+// Since add-ons can be uninstalled after the tools are registered, the tool call errors caused by API resources
+// not served by the cluster are mapped to an error explaining which Dependency must be installed.
+type Dependency struct {
+	// Name is the human-readable name of the add-on (e.g. KubeVirt)
+	Name string
+	// Groups are the API groups served by the add-on
+	Groups []string
+	// Features maps the API groups served only when an optional feature of the add-on is enabled to the feature name
+	Features map[string]string
+}
+
+var (
+	// DependencyKubeVirt is required by the tools managing KubeVirt resources
+	DependencyKubeVirt = Dependency{
+		Name: "KubeVirt",
+		Groups: []string{"kubevirt.io", "subresources.kubevirt.io", "clone.kubevirt.io", "export.kubevirt.io",
+			"instancetype.kubevirt.io", "migrations.kubevirt.io", "pool.kubevirt.io", "snapshot.kubevirt.io"},
+		Features: map[string]string{"export.kubevirt.io": "VMExport", "snapshot.kubevirt.io": "Snapshot"},
+	}
+	// DependencyCDI is required by the tools managing Containerized Data Importer resources (e.g. DataVolumes)
+	DependencyCDI = Dependency{Name: "the Containerized Data Importer (CDI)", Groups: []string{"cdi.kubevirt.io"}}
+	// DependencyTekton is required by the tools managing Tekton Pipelines resources
+	DependencyTekton = Dependency{Name: "Tekton Pipelines", Groups: []string{"tekton.dev"}}
+	// DependencyIstio is required by the Kiali tools (Kiali reports the Istio resources missing in the cluster)
+	DependencyIstio = Dependency{Name: "Istio", Groups: []string{"networking.istio.io", "security.istio.io", "telemetry.istio.io", "extensions.istio.io"}}
+)
+
+// noKindMatchPattern matches the message of a meta.NoKindMatchError once flattened to a string (e.g. by a remote service)
+var noKindMatchPattern = regexp.MustCompile(`no matches for kind "([^"]+)" in (?:group "([^"]*)"|versions? \[?"([^"/]*)/)`)
+
+// MissingDependencyError maps the errors caused by API resources not served by the cluster (no matches for kind)
+// to an error explaining which of the dependencies must be installed, other errors are returned as is.
+func MissingDependencyError(err error, dependencies ...Dependency) error {
+	if err == nil || len(dependencies) == 0 {
+		return err
+	}
+	var subject, group string
+	var noKindMatch *meta.NoKindMatchError
+	var noResourceMatch *meta.NoResourceMatchError
+	switch {
+	case errors.As(err, &noKindMatch):
+		subject, group = noKindMatch.GroupKind.Kind, noKindMatch.GroupKind.Group
+	case errors.As(err, &noResourceMatch):
+		gvr := schema.GroupVersionResource{Group: noResourceMatch.PartialResource.Group, Resource: noResourceMatch.PartialResource.Resource}
+		subject, group = FormatResourceName(&gvr), gvr.Group
+	default:
+		match := noKindMatchPattern.FindStringSubmatch(err.Error())
+		if match == nil {
+			return err
+		}
+		subject, group = match[1], match[2]+match[3]
+	}
+	for _, dependency := range dependencies {
+		if !slices.Contains(dependency.Groups, group) {
+			continue
+		}
+		if feature, ok := dependency.Features[group]; ok {
+			return fmt.Errorf("%s requires %s with the %s feature enabled to be installed in the cluster: %w", subject, dependency.Name, feature, err)
+		}
+		return fmt.Errorf("%s requires %s to be installed in the cluster: %w", subject, dependency.Name, err)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type DependenciesSuite struct {
+	suite.Suite
+}
+
+func (s *DependenciesSuite) TestMissingDependencyError() {
+	s.Run("maps no kind match errors", func() {
+		err := fmt.Errorf("failed to get VM: %w", &meta.NoKindMatchError{
+			GroupKind: schema.GroupKind{Group: "kubevirt.io", Kind: "VirtualMachine"}, SearchedVersions: []string{"v1"}})
+		mapped := MissingDependencyError(err, DependencyKubeVirt, DependencyCDI)
+		s.Equal(`VirtualMachine requires KubeVirt to be installed in the cluster: failed to get VM: no matches for kind "VirtualMachine" in version "kubevirt.io/v1"`, mapped.Error())
+		s.True(meta.IsNoMatchError(mapped), "expected the original error to be wrapped")
+	})
+	s.Run("maps no resource match errors wrapped in validation errors", func() {
+		err := fmt.Errorf("failed to create snapshot: %w", &ValidationError{
+			Code:    ErrorCodeResourceNotFound,
+			Message: "Resource virtualmachinesnapshots.snapshot.kubevirt.io does not exist in the cluster",
+			Err: &meta.NoResourceMatchError{PartialResource: schema.GroupVersionResource{
+				Group: "snapshot.kubevirt.io", Version: "v1beta1", Resource: "virtualmachinesnapshots"}},
+		})
+		s.ErrorContains(MissingDependencyError(err, DependencyKubeVirt),
+			"virtualmachinesnapshots.snapshot.kubevirt.io requires KubeVirt with the Snapshot feature enabled to be installed in the cluster: failed to create snapshot: Validation Error [RESOURCE_NOT_FOUND]")
+	})
+	s.Run("maps flattened no kind match errors", func() {
+		err := errors.New(`kiali error: no matches for kind "VirtualService" in version "networking.istio.io/v1"`)
+		s.Equal(`VirtualService requires Istio to be installed in the cluster: kiali error: no matches for kind "VirtualService" in version "networking.istio.io/v1"`,
+			MissingDependencyError(err, DependencyIstio).Error())
+		err = errors.New(`no matches for kind "DataVolume" in versions ["cdi.kubevirt.io/v1alpha1" "cdi.kubevirt.io/v1beta1"]`)
+		s.ErrorContains(MissingDependencyError(err, DependencyKubeVirt, DependencyCDI),
+			"DataVolume requires the Containerized Data Importer (CDI) to be installed in the cluster: ")
+	})
+	s.Run("returns other errors as is", func() {
+		err := errors.New("virtualmachines.kubevirt.io \"vm-1\" not found")
+		s.Same(err, MissingDependencyError(err, DependencyKubeVirt))
+		s.Nil(MissingDependencyError(nil, DependencyKubeVirt))
+	})
+	s.Run("returns errors of groups from other dependencies as is", func() {
+		err := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "tekton.dev", Kind: "Pipeline"}}
+		s.Same(err, MissingDependencyError(err, DependencyKubeVirt))
+		s.Same(err, MissingDependencyError(err))
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	suite.Run(t, new(DependenciesSuite))
+}
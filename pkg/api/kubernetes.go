@@ -25,9 +25,21 @@ type ListOptions struct {
 	AsTable bool
 }
 
+// TopOptions contains options for sorting and limiting the resource consumption metrics.
+// The Metrics API doesn't support sorting nor pagination, the metrics are sorted and limited once retrieved.
+type TopOptions struct {
+	// SortBy is the resource to sort the metrics by (cpu or memory), metrics aren't sorted if empty
+	SortBy string
+	// Descending sorts the metrics from the highest to the lowest consumption
+	Descending bool
+	// Limit is the maximum number of metrics returned (0 for no limit)
+	Limit int
+}
+
 // PodsTopOptions contains options for getting pod metrics.
 type PodsTopOptions struct {
 	metav1.ListOptions
+	TopOptions
 	AllNamespaces bool
 	Namespace     string
 	Name          string
@@ -36,9 +48,19 @@ type PodsTopOptions struct {
 // NodesTopOptions contains options for getting node metrics.
 type NodesTopOptions struct {
 	metav1.ListOptions
+	TopOptions
 	Name string
 }
 
+// NodesLogOptions contains options for getting node logs.
+type NodesLogOptions struct {
+	// Queries are the services (journal units) or files to return logs from
+	Queries []string
+	// Pattern is a regular expression used by the kubelet to filter the log lines
+	Pattern   string
+	TailLines int64
+}
+
 // KubernetesClient defines the interface for Kubernetes operations that tool and prompt handlers need.
 // This interface abstracts the concrete Kubernetes implementation to allow controlled access to the underlying resource APIs,
 // better decoupling, and testability.
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PartialFailure is a section of an aggregated result (e.g. a component, a kind or a cluster) that couldn't be gathered
+type PartialFailure struct {
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+}
+
+// PartialFailures are the sections that couldn't be gathered by a tool or prompt aggregating several sources.
+// Aggregations return the data collected along with their PartialFailures instead of failing the whole call or
+// silently omitting the sections, so that the missing data is not mistaken for healthy or empty.
+//
+// Typical usage:
+//
+//	var failures api.PartialFailures
+//	nodes, err := gatherNodes(ctx)
+//	failures.Add("Nodes", err) // no-op if err is nil
+//	...
+//	text += failures.Markdown()
+type PartialFailures []PartialFailure
+
+// Add records the section as failed with the error as reason, nil errors are ignored
+func (f *PartialFailures) Add(section string, err error) {
+	if err == nil {
+		return
+	}
+	*f = append(*f, PartialFailure{Section: section, Reason: err.Error()})
+}
+
+// Markdown renders the failed sections as a Markdown list preceded by a warning, or an empty string if there are none
+func (f PartialFailures) Markdown() string {
+	if len(f) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("**Partial results:** the following sections couldn't be gathered, their data is missing (not healthy nor empty):\n")
+	for _, failure := range f {
+		_, _ = fmt.Fprintf(&sb, "- **%s**: %s\n", failure.Section, failure.Reason)
+	}
+	return sb.String()
+}
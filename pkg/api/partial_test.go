@@ -0,0 +1,49 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PartialFailuresSuite struct {
+	suite.Suite
+}
+
+func TestPartialFailuresSuite(t *testing.T) {
+	suite.Run(t, new(PartialFailuresSuite))
+}
+
+func (s *PartialFailuresSuite) TestAdd() {
+	s.Run("nil error is ignored", func() {
+		var failures PartialFailures
+		failures.Add("Nodes", nil)
+		s.Empty(failures)
+	})
+	s.Run("error is recorded with its section", func() {
+		var failures PartialFailures
+		failures.Add("Nodes", errors.New("nodes is forbidden"))
+		failures.Add("Pods", nil)
+		failures.Add("Events", errors.New("timeout"))
+		s.Equal(PartialFailures{
+			{Section: "Nodes", Reason: "nodes is forbidden"},
+			{Section: "Events", Reason: "timeout"},
+		}, failures)
+	})
+}
+
+func (s *PartialFailuresSuite) TestMarkdown() {
+	s.Run("no failures renders nothing", func() {
+		s.Empty(PartialFailures{}.Markdown())
+	})
+	s.Run("failures are rendered as a list", func() {
+		failures := PartialFailures{
+			{Section: "Nodes", Reason: "nodes is forbidden"},
+			{Section: "Events", Reason: "timeout"},
+		}
+		s.Equal("**Partial results:** the following sections couldn't be gathered, their data is missing (not healthy nor empty):\n"+
+			"- **Nodes**: nodes is forbidden\n"+
+			"- **Events**: timeout\n", failures.Markdown())
+	})
+}
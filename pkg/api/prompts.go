@@ -9,6 +9,8 @@ type ServerPrompt struct {
 	Handler        PromptHandlerFunc
 	ClusterAware   *bool
 	ArgumentSchema map[string]PromptArgument
+	// RequiredCapabilities are the cluster capabilities that must be available for the prompt to be registered
+	RequiredCapabilities []Capability
 }
 
 // IsClusterAware indicates whether the prompt can accept a "cluster" or "context" parameter
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -14,6 +16,10 @@ type ServerTool struct {
 	Handler            ToolHandlerFunc
 	ClusterAware       *bool
 	TargetListProvider *bool
+	// RequiredCapabilities are the cluster capabilities that must be available for the tool to be registered
+	RequiredCapabilities []Capability
+	// Dependencies are the add-ons the tool relies on, used to explain the errors caused by their API resources missing
+	Dependencies []Dependency
 }
 
 // IsClusterAware indicates whether the tool can accept a "cluster" or "context" parameter
@@ -68,8 +74,26 @@ type ToolCallResult struct {
 	StructuredContent any
 	// Error (non-protocol) to send back to the LLM.
 	Error error
+	// Attachment describes the file the Content is returned as (instead of inline text) when it exceeds the configured
+	// threshold, nil if the Content must always be returned inline.
+	Attachment *ToolCallAttachment
 }
 
+// ToolCallAttachment describes the file a large tool result is returned as.
+type ToolCallAttachment struct {
+	// Filename of the file (e.g. support-bundle-default.txt)
+	Filename string
+	// MIMEType of the file (e.g. application/yaml)
+	MIMEType string
+}
+
+const (
+	// attachmentPreviewLines is the number of lines of an attached result kept inline as a preview
+	attachmentPreviewLines = 20
+	// attachmentPreviewBytes caps the size of the inline preview of an attached result
+	attachmentPreviewBytes = 2048
+)
+
 // NewToolCallResult creates a ToolCallResult with text content only.
 // Use this for tools that return human-readable text output.
 func NewToolCallResult(content string, err error) *ToolCallResult {
@@ -109,6 +133,51 @@ func NewToolCallResultStructured(structured any, err error) *ToolCallResult {
 	return NewToolCallResultFull(content, structured, err)
 }
 
+// NewToolCallResultAttachable creates a ToolCallResult with text content that is returned as a file (filename and
+// mimeType) instead of inline text when it exceeds the configured threshold.
+// Use this for tools that may return large outputs (bundles, exports, manifests) so they don't flood the chat.
+func NewToolCallResultAttachable(content, filename, mimeType string, err error) *ToolCallResult {
+	ret := NewToolCallResult(content, err)
+	ret.Attachment = &ToolCallAttachment{Filename: filename, MIMEType: mimeType}
+	return ret
+}
+
+// SplitAttachment shapes the result for the threshold (in bytes, zero or negative disables attachments).
+// If the result is attachable, successful and its Content exceeds the threshold, the Content is returned as the
+// attachment data and the inline text is replaced by a summary with a preview of its first lines.
+// Otherwise, the Content is returned inline and the data is nil.
+func (r *ToolCallResult) SplitAttachment(threshold int) (inline string, data []byte) {
+	if r.Attachment == nil || r.Error != nil || threshold <= 0 || len(r.Content) <= threshold {
+		return r.Content, nil
+	}
+	lines := strings.Count(r.Content, "\n")
+	if !strings.HasSuffix(r.Content, "\n") {
+		lines++
+	}
+	preview := r.Content
+	for i, n := 0, 0; i < len(preview); i++ {
+		if preview[i] == '\n' {
+			if n++; n == attachmentPreviewLines {
+				preview = preview[:i+1]
+				break
+			}
+		}
+	}
+	if len(preview) > attachmentPreviewBytes {
+		preview = strings.ToValidUTF8(preview[:attachmentPreviewBytes], "") + "\n"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# The result (%d bytes, %d lines) exceeds the inline limit of %d bytes, it is returned as the attached file %s (%s)\n",
+		len(r.Content), lines, threshold, r.Attachment.Filename, r.Attachment.MIMEType))
+	sb.WriteString("# Preview of the first lines:\n")
+	sb.WriteString(preview)
+	if !strings.HasSuffix(preview, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("# ...\n")
+	return sb.String(), []byte(r.Content)
+}
+
 // Resource represents the metadata of an MCP resource.
 type Resource struct {
 	URI         string
@@ -153,10 +222,33 @@ type ResourceTemplate struct {
 // Handlers should return a ResourceContent with exactly one of Text or Blob set.
 type ResourceTemplateHandler func(ctx context.Context, uri string) (*ResourceContent, error)
 
+// ResourceTemplateHandlerParams are the parameters of a KubernetesResourceTemplateHandler.
+type ResourceTemplateHandlerParams struct {
+	context.Context
+	BaseConfig
+	KubernetesClient
+	// URI is the actual resource URI that matches the template.
+	URI string
+}
+
+// KubernetesResourceTemplateHandler is called when a client reads a resource matching a template that is backed by the cluster.
+// The KubernetesClient is the (derived) client of the default cluster, so the access control and the credentials
+// of the request apply as for the tools.
+// Handlers should return a ResourceContent with exactly one of Text or Blob set.
+type KubernetesResourceTemplateHandler func(params ResourceTemplateHandlerParams) (*ResourceContent, error)
+
+// KubernetesResourceTemplateWatcher watches the cluster object identified by params.URI and calls onChange every time
+// it changes, until params.Context is cancelled (a nil error is returned then).
+type KubernetesResourceTemplateWatcher func(params ResourceTemplateHandlerParams, onChange func()) error
+
 // ServerResourceTemplate represents a resource template that can be registered with the MCP server.
+// Exactly one of Handler or KubernetesHandler must be set.
 type ServerResourceTemplate struct {
-	ResourceTemplate ResourceTemplate
-	Handler          ResourceTemplateHandler
+	ResourceTemplate  ResourceTemplate
+	Handler           ResourceTemplateHandler
+	KubernetesHandler KubernetesResourceTemplateHandler
+	// KubernetesWatcher (Optional) enables the subscriptions to the resources matching the template of a KubernetesHandler.
+	KubernetesWatcher KubernetesResourceTemplateWatcher
 }
 
 type ToolHandlerParams struct {
@@ -165,11 +257,37 @@ type ToolHandlerParams struct {
 	KubernetesClient
 	ToolCallRequest
 	ListOutput output.Output
+	// DefaultOutput is the configured output for the tools that accept an output argument, nil if not configured
+	DefaultOutput output.Output
 	Elicitor
+	ProgressReporter
+}
+
+// Progress returns a ProgressFunc reporting progress notifications for the tool call,
+// or nil if progress reporting is not available.
+func (p ToolHandlerParams) Progress() ProgressFunc {
+	if p.ProgressReporter == nil {
+		return nil
+	}
+	return func(progress, total float64, message string) {
+		p.ReportProgress(p.Context, progress, total, message)
+	}
 }
 
 type ToolHandlerFunc func(params ToolHandlerParams) (*ToolCallResult, error)
 
+// ProgressFunc reports the progress of a long-running operation.
+// The progress value must increase with every call, total is the value progress is expected to reach (0 if unknown).
+type ProgressFunc func(progress, total float64, message string)
+
+// ProgressReporter sends progress updates for long-running tool calls via the MCP progress notification protocol.
+// Updates are only sent if the client requested them by providing a progress token, otherwise they're discarded.
+//
+// See MCP specification: https://modelcontextprotocol.io/specification/2025-11-25/basic/utilities/progress
+type ProgressReporter interface {
+	ReportProgress(ctx context.Context, progress, total float64, message string)
+}
+
 // ErrElicitationNotSupported is returned when the MCP client does not support elicitation.
 var ErrElicitationNotSupported = errors.New("client does not support elicitation")
 
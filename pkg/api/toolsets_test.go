@@ -3,6 +3,8 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -64,6 +66,54 @@ func (s *ToolsetsSuite) TestNewToolCallResult() {
 	})
 }
 
+func (s *ToolsetsSuite) TestSplitAttachment() {
+	var lines []string
+	for i := 1; i <= 30; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	large := strings.Join(lines, "\n") + "\n"
+	s.Run("attachable result above the threshold", func() {
+		result := NewToolCallResultAttachable(large, "bundle.md", "text/markdown", nil)
+		inline, data := result.SplitAttachment(100)
+		s.Run("returns the content as the attachment data", func() {
+			s.Equal([]byte(large), data)
+		})
+		s.Run("returns a summary with a preview of the first lines inline", func() {
+			s.True(strings.HasPrefix(inline, fmt.Sprintf("# The result (%d bytes, 30 lines) exceeds the inline limit of 100 bytes, it is returned as the attached file bundle.md (text/markdown)\n", len(large))))
+			s.Contains(inline, "# Preview of the first lines:\nline 1\n")
+			s.Contains(inline, "line 20\n# ...\n")
+			s.NotContains(inline, "line 21")
+		})
+	})
+	s.Run("attachable result below the threshold is returned inline", func() {
+		inline, data := NewToolCallResultAttachable("small", "bundle.md", "text/markdown", nil).SplitAttachment(100)
+		s.Equal("small", inline)
+		s.Nil(data)
+	})
+	s.Run("attachable result is returned inline when attachments are disabled", func() {
+		inline, data := NewToolCallResultAttachable(large, "bundle.md", "text/markdown", nil).SplitAttachment(0)
+		s.Equal(large, inline)
+		s.Nil(data)
+	})
+	s.Run("errors are never attached", func() {
+		inline, data := NewToolCallResultAttachable(large, "bundle.md", "text/markdown", errors.New("failed")).SplitAttachment(100)
+		s.Equal(large, inline)
+		s.Nil(data)
+	})
+	s.Run("results that aren't attachable are returned inline", func() {
+		inline, data := NewToolCallResult(large, nil).SplitAttachment(100)
+		s.Equal(large, inline)
+		s.Nil(data)
+	})
+	s.Run("preview is capped for long lines", func() {
+		long := strings.Repeat("x", 5000)
+		inline, data := NewToolCallResultAttachable(long, "bundle.md", "text/markdown", nil).SplitAttachment(100)
+		s.Len(data, 5000)
+		s.Less(len(inline), 2500)
+		s.True(strings.HasSuffix(inline, "x\n# ...\n"))
+	})
+}
+
 func (s *ToolsetsSuite) TestNewToolCallResultStructured() {
 	s.Run("sets empty content when structured is nil", func() {
 		result := NewToolCallResultStructured(nil, nil)
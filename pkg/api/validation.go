@@ -40,6 +40,7 @@ type ValidationError struct {
 	Code    ValidationErrorCode
 	Message string
 	Field   string // optional, for field-level errors
+	Err     error  // optional, the underlying error
 }
 
 // Error implements the error interface.
@@ -54,6 +55,11 @@ func (e *ValidationError) Error() string {
 	return sb.String()
 }
 
+// Unwrap returns the underlying error (if any).
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
 // NewPermissionDeniedError creates an error for RBAC permission failures.
 func NewPermissionDeniedError(verb, resource, namespace string) *ValidationError {
 	var msg string
@@ -25,22 +25,69 @@ const (
 	DefaultDropInConfigDir = "conf.d"
 )
 
+const (
+	// LogFormatText is the klog text log format (default)
+	LogFormatText = "text"
+	// LogFormatJSON logs one JSON object per line
+	LogFormatJSON = "json"
+)
+
 // ToolOverride contains per-tool configuration overrides.
+// The readOnlyHint and destructiveHint annotations can't be overridden since they drive the read_only and
+// disable_destructive tool filtering.
 type ToolOverride struct {
 	Description string `toml:"description,omitempty"`
+	// Title overrides the human-readable title annotation of the tool
+	Title string `toml:"title,omitempty"`
+	// IdempotentHint overrides the idempotentHint annotation of the tool
+	IdempotentHint *bool `toml:"idempotent_hint,omitempty"`
+	// OpenWorldHint overrides the openWorldHint annotation of the tool
+	OpenWorldHint *bool `toml:"open_world_hint,omitempty"`
 }
 
 // StaticConfig is the configuration for the server.
 // It allows to configure server specific settings and tools to be enabled or disabled.
 type StaticConfig struct {
 	DeniedResources []api.GroupVersionKind `toml:"denied_resources"`
-
-	LogLevel   int    `toml:"log_level,omitzero"`
-	LogFile    string `toml:"log_file,omitempty"`
+	// ScanKinds are the kinds (Kind or Kind.group, e.g. Deployment or Certificate.cert-manager.io) inspected by the
+	// operations that scan all the resources of a namespace. The special value "all" scans every namespaced kind
+	// served by the cluster. Defaults to a built-in set of common kinds when empty.
+	ScanKinds []string `toml:"scan_kinds,omitempty"`
+
+	LogLevel int    `toml:"log_level,omitzero"`
+	LogFile  string `toml:"log_file,omitempty"`
+	// LogFormat is the format of the server logs: text (klog text format, the default) or json (one JSON object per
+	// line, for log aggregation systems). It's applied at startup, a SIGHUP reload keeps the initial format.
+	LogFormat  string `toml:"log_format,omitempty"`
 	Port       string `toml:"port,omitempty"`
 	SSEBaseURL string `toml:"sse_base_url,omitempty"`
 	KubeConfig string `toml:"kubeconfig,omitempty"`
-	ListOutput string `toml:"list_output,omitempty"`
+	// BearerTokenFile is the path to a file holding the bearer token used to authenticate to the Kubernetes API,
+	// overriding the kubeconfig (or in-cluster) credentials. The file is periodically re-read so rotated tokens are picked up.
+	BearerTokenFile string `toml:"bearer_token_file,omitempty"`
+	// KubeClientQPS is the maximum sustained queries per second sent to the Kubernetes API by each client
+	// (zero uses the client-go default).
+	KubeClientQPS float32 `toml:"kube_client_qps,omitzero"`
+	// KubeClientBurst is the maximum burst of queries sent to the Kubernetes API by each client
+	// (zero uses the client-go default).
+	KubeClientBurst int `toml:"kube_client_burst,omitzero"`
+	// MaxConcurrentWatches is the maximum number of watches (resources_wait, Job waits, resource subscriptions)
+	// running at the same time against each cluster (zero uses api.DefaultMaxConcurrentWatches).
+	MaxConcurrentWatches int `toml:"max_concurrent_watches,omitzero"`
+	// KubeClientContentType is the wire format of the built-in kinds exchanged with the Kubernetes API
+	// (protobuf or json, empty uses protobuf). Custom resources are always exchanged as JSON.
+	KubeClientContentType string `toml:"kube_client_content_type,omitempty"`
+	ListOutput            string `toml:"list_output,omitempty"`
+	// DefaultOutput is the output format used by resources_list and resources_get when the tool call doesn't specify one.
+	// When empty, resources_list uses ListOutput and resources_get uses yaml.
+	DefaultOutput string `toml:"default_output,omitempty"`
+	// ToolOutputAttachmentThreshold is the size (in bytes) above which the large results of the tools supporting it
+	// (support bundles, exports...) are returned as an attached file instead of inline text. Attachments are opt-in, zero
+	// (the default) disables them since not all the clients support embedded resources.
+	ToolOutputAttachmentThreshold int `toml:"tool_output_attachment_threshold,omitzero"`
+	// UserAgentSuffix is appended to the User-Agent of the requests sent to the Kubernetes API
+	// (e.g. a team or deployment name) to attribute them in the audit logs.
+	UserAgentSuffix string `toml:"user_agent_suffix,omitempty"`
 	// Stateless configures the MCP server to operate in stateless mode.
 	// When true, the server will not send notifications to clients (e.g., tools/list_changed, prompts/list_changed).
 	// This is useful for container deployments, load balancing, and serverless environments where
@@ -57,6 +104,9 @@ type StaticConfig struct {
 	EnabledTools  []string                `toml:"enabled_tools,omitempty"`
 	DisabledTools []string                `toml:"disabled_tools,omitempty"`
 	ToolOverrides map[string]ToolOverride `toml:"tool_overrides,omitempty"`
+	// EnabledCapabilities are the cluster capabilities (e.g. "kubevirt") considered available regardless of discovery,
+	// the tools and prompts requiring them are registered even if the cluster doesn't seem to provide them.
+	EnabledCapabilities []string `toml:"enabled_capabilities,omitempty"`
 	// Prompt configuration
 	Prompts []api.Prompt `toml:"prompts,omitempty"`
 
@@ -388,10 +438,38 @@ func (c *StaticConfig) GetDeniedResources() []api.GroupVersionKind {
 	return c.DeniedResources
 }
 
+func (c *StaticConfig) GetScanKinds() []string {
+	return c.ScanKinds
+}
+
 func (c *StaticConfig) GetKubeConfigPath() string {
 	return c.KubeConfig
 }
 
+func (c *StaticConfig) GetBearerTokenFile() string {
+	return c.BearerTokenFile
+}
+
+func (c *StaticConfig) GetKubeClientQPS() float32 {
+	return c.KubeClientQPS
+}
+
+func (c *StaticConfig) GetKubeClientBurst() int {
+	return c.KubeClientBurst
+}
+
+func (c *StaticConfig) GetMaxConcurrentWatches() int {
+	return c.MaxConcurrentWatches
+}
+
+func (c *StaticConfig) GetKubeClientContentType() string {
+	return c.KubeClientContentType
+}
+
+func (c *StaticConfig) GetToolsets() []string {
+	return c.Toolsets
+}
+
 func (c *StaticConfig) GetProviderConfig(strategy string) (api.ExtendedConfig, bool) {
 	cfg, ok := c.parsedClusterProviderConfigs[strategy]
 
@@ -488,18 +566,57 @@ func (c *StaticConfig) WithTokenExchangeStrategies(strategies []string) *StaticC
 func (c *StaticConfig) Validate(ctx context.Context) error {
 	// Normalize whitespace-padded fields before any checks use them.
 	c.CertificateAuthority = strings.TrimSpace(c.CertificateAuthority)
+	c.BearerTokenFile = strings.TrimSpace(c.BearerTokenFile)
 	c.TLSCert = strings.TrimSpace(c.TLSCert)
 	c.TLSKey = strings.TrimSpace(c.TLSKey)
 	c.StsAuthStyle = strings.TrimSpace(c.StsAuthStyle)
 	c.StsClientCertFile = strings.TrimSpace(c.StsClientCertFile)
 	c.StsClientKeyFile = strings.TrimSpace(c.StsClientKeyFile)
 	c.StsFederatedTokenFile = strings.TrimSpace(c.StsFederatedTokenFile)
+	c.UserAgentSuffix = strings.TrimSpace(c.UserAgentSuffix)
+	if strings.ContainsAny(c.UserAgentSuffix, "\r\n") {
+		return fmt.Errorf("invalid user_agent_suffix: must not contain line breaks")
+	}
+	if c.KubeClientQPS < 0 {
+		return fmt.Errorf("kube_client_qps must not be negative (got %v)", c.KubeClientQPS)
+	}
+	if c.KubeClientBurst < 0 {
+		return fmt.Errorf("kube_client_burst must not be negative (got %d)", c.KubeClientBurst)
+	}
+	if c.ToolOutputAttachmentThreshold < 0 {
+		return fmt.Errorf("tool_output_attachment_threshold must not be negative (got %d)", c.ToolOutputAttachmentThreshold)
+	}
+	if c.MaxConcurrentWatches < 0 {
+		return fmt.Errorf("max_concurrent_watches must not be negative (got %d)", c.MaxConcurrentWatches)
+	}
+	switch c.LogFormat {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("invalid log_format %q: valid values are %s, %s", c.LogFormat, LogFormatText, LogFormatJSON)
+	}
+	switch c.KubeClientContentType {
+	case "", api.KubeClientContentTypeProtobuf, api.KubeClientContentTypeJSON:
+	default:
+		return fmt.Errorf("invalid kube_client_content_type %q: valid values are %s, %s",
+			c.KubeClientContentType, api.KubeClientContentTypeProtobuf, api.KubeClientContentTypeJSON)
+	}
+	if err := c.validateScanKinds(); err != nil {
+		return err
+	}
 	if output.FromString(c.ListOutput) == nil {
 		return fmt.Errorf("invalid output name: %s, valid names are: %s", c.ListOutput, strings.Join(output.Names, ", "))
 	}
+	if c.DefaultOutput != "" && output.FromString(c.DefaultOutput) == nil {
+		return fmt.Errorf("invalid default_output: %s, valid names are: %s", c.DefaultOutput, strings.Join(output.Names, ", "))
+	}
 	if err := toolsets.Validate(c.Toolsets); err != nil {
 		return err
 	}
+	for _, capability := range c.EnabledCapabilities {
+		if !slices.Contains(api.Capabilities(), capability) {
+			return fmt.Errorf("invalid enabled_capabilities entry %q, valid values are: %s", capability, strings.Join(api.Capabilities(), ", "))
+		}
+	}
 	if c.ClusterProviderStrategy != "" && len(c.providerStrategies) > 0 {
 		if !slices.Contains(c.providerStrategies, c.ClusterProviderStrategy) {
 			return fmt.Errorf("invalid cluster-provider: %s, valid values are: %s", c.ClusterProviderStrategy, strings.Join(c.providerStrategies, ", "))
@@ -532,6 +649,11 @@ func (c *StaticConfig) Validate(ctx context.Context) error {
 			return fmt.Errorf("certificate-authority must be a valid file path: %w", err)
 		}
 	}
+	if c.BearerTokenFile != "" {
+		if _, err := os.Stat(c.BearerTokenFile); err != nil {
+			return fmt.Errorf("bearer_token_file must be a valid file path: %w", err)
+		}
+	}
 	if (c.TLSCert != "" && c.TLSKey == "") || (c.TLSCert == "" && c.TLSKey != "") {
 		return fmt.Errorf("both --tls-cert and --tls-key must be provided together")
 	}
@@ -583,6 +705,21 @@ func (c *StaticConfig) validateConfirmation() error {
 	return nil
 }
 
+// validateScanKinds validates that each scan_kinds entry is a Kind or Kind.group, or the single value "all"
+func (c *StaticConfig) validateScanKinds() error {
+	for i := range c.ScanKinds {
+		c.ScanKinds[i] = strings.TrimSpace(c.ScanKinds[i])
+		scanKind := c.ScanKinds[i]
+		if scanKind == api.ScanKindsAll && len(c.ScanKinds) > 1 {
+			return fmt.Errorf("invalid scan_kinds: %q can't be combined with other kinds", api.ScanKindsAll)
+		}
+		if scanKind == "" || strings.ContainsAny(scanKind, " /") {
+			return fmt.Errorf("invalid scan_kinds entry %q: expected Kind or Kind.group (e.g. Deployment, Certificate.cert-manager.io)", scanKind)
+		}
+	}
+	return nil
+}
+
 // validateSkipJWTVerification checks that the user has explicitly opted in to
 // skipping JWT signature verification when require_oauth is enabled but no
 // authorization_url is configured.
@@ -101,6 +101,11 @@ func (s *ConfigSuite) TestReadConfigValid() {
 		sse_base_url = "https://example.com"
 		kubeconfig = "./path/to/config"
 		list_output = "yaml"
+		default_output = "json"
+		user_agent_suffix = "team-a/prod"
+		kube_client_qps = 50.5
+		kube_client_burst = 100
+		kube_client_content_type = "json"
 		read_only = true
 		disable_destructive = true
 		stateless = true
@@ -154,6 +159,21 @@ func (s *ConfigSuite) TestReadConfigValid() {
 	s.Run("list_output parsed correctly", func() {
 		s.Equalf("yaml", config.ListOutput, "Expected ListOutput to be yaml, got %s", config.ListOutput)
 	})
+	s.Run("default_output parsed correctly", func() {
+		s.Equalf("json", config.DefaultOutput, "Expected DefaultOutput to be json, got %s", config.DefaultOutput)
+	})
+	s.Run("user_agent_suffix parsed correctly", func() {
+		s.Equalf("team-a/prod", config.UserAgentSuffix, "Expected UserAgentSuffix to be team-a/prod, got %s", config.UserAgentSuffix)
+	})
+	s.Run("kube_client_qps parsed correctly", func() {
+		s.Equalf(float32(50.5), config.KubeClientQPS, "Expected KubeClientQPS to be 50.5, got %v", config.KubeClientQPS)
+	})
+	s.Run("kube_client_burst parsed correctly", func() {
+		s.Equalf(100, config.KubeClientBurst, "Expected KubeClientBurst to be 100, got %d", config.KubeClientBurst)
+	})
+	s.Run("kube_client_content_type parsed correctly", func() {
+		s.Equalf("json", config.KubeClientContentType, "Expected KubeClientContentType to be json, got %s", config.KubeClientContentType)
+	})
 	s.Run("read_only parsed correctly", func() {
 		s.Truef(config.ReadOnly, "Expected ReadOnly to be true, got %v", config.ReadOnly)
 	})
@@ -1059,6 +1079,9 @@ func (s *ConfigSuite) TestToolOverridesParsed() {
 
 		[tool_overrides.resources_get]
 		description = "Custom resources get description"
+		title = "Custom resources get title"
+		idempotent_hint = false
+		open_world_hint = false
 	`)
 
 	config, err := Read(s.T().Context(), configPath, "")
@@ -1071,6 +1094,15 @@ func (s *ConfigSuite) TestToolOverridesParsed() {
 		s.Equal("Custom pods list description", config.ToolOverrides["pods_list"].Description)
 		s.Equal("Custom resources get description", config.ToolOverrides["resources_get"].Description)
 	})
+	s.Run("parses tool_overrides annotations", func() {
+		s.Equal("Custom resources get title", config.ToolOverrides["resources_get"].Title)
+		s.Require().NotNil(config.ToolOverrides["resources_get"].IdempotentHint)
+		s.False(*config.ToolOverrides["resources_get"].IdempotentHint)
+		s.Require().NotNil(config.ToolOverrides["resources_get"].OpenWorldHint)
+		s.False(*config.ToolOverrides["resources_get"].OpenWorldHint)
+		s.Empty(config.ToolOverrides["pods_list"].Title)
+		s.Nil(config.ToolOverrides["pods_list"].IdempotentHint)
+	})
 }
 
 func (s *ConfigSuite) TestToolOverridesMatchDefaultsWhenNotSpecified() {
@@ -1,11 +1,23 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // DefaultRateLimitBurst is the default burst size used when rate_limit_rps is
 // set but rate_limit_burst is not specified (zero value).
 const DefaultRateLimitBurst = 10
 
+// DefaultReadinessCheckTimeout is the default timeout for the /readyz Kubernetes API check
+// used when readiness_check_timeout is not specified (zero value).
+const DefaultReadinessCheckTimeout = 5 * time.Second
+
+// DefaultSSEKeepAliveInterval is the default interval of the SSE keep-alive comments
+// used when sse_keep_alive_interval is not specified (zero value).
+// It's shorter than the common idle timeouts of proxies and load balancers (60s).
+const DefaultSSEKeepAliveInterval = 30 * time.Second
+
 // HTTPConfig contains HTTP server configuration options for security.
 type HTTPConfig struct {
 	// ReadHeaderTimeout is the amount of time allowed to read request headers.
@@ -27,10 +39,24 @@ type HTTPConfig struct {
 	// Only effective when rate_limit_rps > 0.
 	// When zero, the rate limiting middleware applies DefaultRateLimitBurst.
 	RateLimitBurst int `toml:"rate_limit_burst,omitzero"`
+
+	// ReadinessCheckTarget is the cluster target (e.g. kubeconfig context) whose
+	// Kubernetes API is checked by the /readyz endpoint.
+	// When empty, the provider's default target is checked.
+	ReadinessCheckTarget string `toml:"readiness_check_target,omitempty"`
+
+	// ReadinessCheckTimeout is the maximum duration of the /readyz Kubernetes API check.
+	// When zero, DefaultReadinessCheckTimeout is applied.
+	ReadinessCheckTimeout Duration `toml:"readiness_check_timeout,omitempty"`
+
+	// SSEKeepAliveInterval is the interval of the keep-alive comments written to the idle SSE streams
+	// so that proxies don't drop them. A stream that can't be written is closed with its session.
+	// When zero, DefaultSSEKeepAliveInterval is applied.
+	SSEKeepAliveInterval Duration `toml:"sse_keep_alive_interval,omitempty"`
 }
 
 // Validate checks HTTPConfig for invalid values.
-// It rejects negative RateLimitRPS and negative RateLimitBurst.
+// It rejects negative RateLimitRPS, RateLimitBurst, ReadinessCheckTimeout and SSEKeepAliveInterval.
 func (c *HTTPConfig) Validate() error {
 	if c.RateLimitRPS < 0 {
 		return fmt.Errorf("rate_limit_rps must not be negative (got %v)", c.RateLimitRPS)
@@ -38,5 +64,11 @@ func (c *HTTPConfig) Validate() error {
 	if c.RateLimitBurst < 0 {
 		return fmt.Errorf("rate_limit_burst must not be negative (got %d)", c.RateLimitBurst)
 	}
+	if c.ReadinessCheckTimeout < 0 {
+		return fmt.Errorf("readiness_check_timeout must not be negative (got %s)", c.ReadinessCheckTimeout.Duration())
+	}
+	if c.SSEKeepAliveInterval < 0 {
+		return fmt.Errorf("sse_keep_alive_interval must not be negative (got %s)", c.SSEKeepAliveInterval.Duration())
+	}
 	return nil
 }
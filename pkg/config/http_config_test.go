@@ -63,6 +63,30 @@ max_body_bytes = 33554432
 		s.Equal(10*time.Second, cfg.HTTP.ReadHeaderTimeout.Duration())
 	})
 
+	s.Run("parses readiness check fields", func() {
+		tomlData := []byte(`
+[http]
+readiness_check_target = "cluster-a"
+readiness_check_timeout = "2s"
+`)
+		cfg, err := ReadToml(tomlData)
+		s.Require().NoError(err)
+
+		s.Equal("cluster-a", cfg.HTTP.ReadinessCheckTarget)
+		s.Equal(2*time.Second, cfg.HTTP.ReadinessCheckTimeout.Duration())
+	})
+
+	s.Run("parses SSE keep-alive interval", func() {
+		tomlData := []byte(`
+[http]
+sse_keep_alive_interval = "15s"
+`)
+		cfg, err := ReadToml(tomlData)
+		s.Require().NoError(err)
+
+		s.Equal(15*time.Second, cfg.HTTP.SSEKeepAliveInterval.Duration())
+	})
+
 	s.Run("returns error for invalid duration format", func() {
 		tomlData := []byte(`
 [http]
@@ -102,6 +126,20 @@ func (s *HTTPConfigSuite) TestValidate() {
 		cfg := HTTPConfig{RateLimitRPS: 10, RateLimitBurst: 0}
 		s.NoError(cfg.Validate())
 	})
+
+	s.Run("negative readiness check timeout is rejected", func() {
+		cfg := HTTPConfig{ReadinessCheckTimeout: Duration(-time.Second)}
+		err := cfg.Validate()
+		s.Error(err)
+		s.Contains(err.Error(), "readiness_check_timeout must not be negative")
+	})
+
+	s.Run("negative SSE keep-alive interval is rejected", func() {
+		cfg := HTTPConfig{SSEKeepAliveInterval: Duration(-time.Second)}
+		err := cfg.Validate()
+		s.Error(err)
+		s.Contains(err.Error(), "sse_keep_alive_interval must not be negative")
+	})
 }
 
 func (s *HTTPConfigSuite) TestDefaultRateLimitBurst() {
@@ -62,6 +62,185 @@ func (s *ValidateSuite) TestListOutput() {
 	})
 }
 
+func (s *ValidateSuite) TestDefaultOutput() {
+	s.Run("empty default_output is accepted", func() {
+		cfg := s.validConfig()
+		cfg.DefaultOutput = ""
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("json default_output is accepted", func() {
+		cfg := s.validConfig()
+		cfg.DefaultOutput = "json"
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("invalid default_output is rejected", func() {
+		cfg := s.validConfig()
+		cfg.DefaultOutput = "invalid-format"
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid default_output: invalid-format")
+	})
+}
+
+func (s *ValidateSuite) TestUserAgentSuffix() {
+	s.Run("user_agent_suffix is trimmed", func() {
+		cfg := s.validConfig()
+		cfg.UserAgentSuffix = "  team-a/prod  "
+		s.NoError(cfg.Validate(s.T().Context()))
+		s.Equal("team-a/prod", cfg.UserAgentSuffix)
+	})
+
+	s.Run("user_agent_suffix with line breaks is rejected", func() {
+		cfg := s.validConfig()
+		cfg.UserAgentSuffix = "team-a\r\nX-Injected: true"
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid user_agent_suffix")
+	})
+}
+
+func (s *ValidateSuite) TestKubeClientRateLimit() {
+	s.Run("positive qps and burst are accepted", func() {
+		cfg := s.validConfig()
+		cfg.KubeClientQPS = 50
+		cfg.KubeClientBurst = 100
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("negative kube_client_qps is rejected", func() {
+		cfg := s.validConfig()
+		cfg.KubeClientQPS = -1
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "kube_client_qps must not be negative")
+	})
+
+	s.Run("negative kube_client_burst is rejected", func() {
+		cfg := s.validConfig()
+		cfg.KubeClientBurst = -1
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "kube_client_burst must not be negative")
+	})
+}
+
+func (s *ValidateSuite) TestToolOutputAttachmentThreshold() {
+	s.Run("positive tool_output_attachment_threshold is accepted", func() {
+		cfg := s.validConfig()
+		cfg.ToolOutputAttachmentThreshold = 65536
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("negative tool_output_attachment_threshold is rejected", func() {
+		cfg := s.validConfig()
+		cfg.ToolOutputAttachmentThreshold = -1
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "tool_output_attachment_threshold must not be negative")
+	})
+}
+
+func (s *ValidateSuite) TestMaxConcurrentWatches() {
+	s.Run("positive max_concurrent_watches is accepted", func() {
+		cfg := s.validConfig()
+		cfg.MaxConcurrentWatches = 10
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("negative max_concurrent_watches is rejected", func() {
+		cfg := s.validConfig()
+		cfg.MaxConcurrentWatches = -1
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "max_concurrent_watches must not be negative")
+	})
+}
+
+func (s *ValidateSuite) TestKubeClientContentType() {
+	for _, contentType := range []string{"", "protobuf", "json"} {
+		s.Run("kube_client_content_type "+contentType+" is accepted", func() {
+			cfg := s.validConfig()
+			cfg.KubeClientContentType = contentType
+			s.NoError(cfg.Validate(s.T().Context()))
+		})
+	}
+
+	s.Run("unknown kube_client_content_type is rejected", func() {
+		cfg := s.validConfig()
+		cfg.KubeClientContentType = "cbor"
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid kube_client_content_type "cbor": valid values are protobuf, json`)
+	})
+}
+
+func (s *ValidateSuite) TestLogFormat() {
+	for _, logFormat := range []string{"", "text", "json"} {
+		s.Run("log_format "+logFormat+" is accepted", func() {
+			cfg := s.validConfig()
+			cfg.LogFormat = logFormat
+			s.NoError(cfg.Validate(s.T().Context()))
+		})
+	}
+
+	s.Run("unknown log_format is rejected", func() {
+		cfg := s.validConfig()
+		cfg.LogFormat = "logfmt"
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid log_format "logfmt": valid values are text, json`)
+	})
+}
+
+func (s *ValidateSuite) TestEnabledCapabilities() {
+	s.Run("known capabilities are accepted", func() {
+		cfg := s.validConfig()
+		cfg.EnabledCapabilities = []string{"kubevirt"}
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("unknown capability is rejected", func() {
+		cfg := s.validConfig()
+		cfg.EnabledCapabilities = []string{"istio"}
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid enabled_capabilities entry "istio", valid values are: kubevirt`)
+	})
+}
+
+func (s *ValidateSuite) TestScanKinds() {
+	s.Run("kinds with and without group are accepted", func() {
+		cfg := s.validConfig()
+		cfg.ScanKinds = []string{"Pod", " Deployment.apps ", "Certificate.cert-manager.io"}
+		s.NoError(cfg.Validate(s.T().Context()))
+		s.Equal([]string{"Pod", "Deployment.apps", "Certificate.cert-manager.io"}, cfg.ScanKinds)
+	})
+
+	s.Run("all is accepted", func() {
+		cfg := s.validConfig()
+		cfg.ScanKinds = []string{"all"}
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("all combined with other kinds is rejected", func() {
+		cfg := s.validConfig()
+		cfg.ScanKinds = []string{"Pod", "all"}
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid scan_kinds: \"all\" can't be combined with other kinds")
+	})
+
+	s.Run("invalid kind is rejected", func() {
+		cfg := s.validConfig()
+		cfg.ScanKinds = []string{"apps/v1/Deployment"}
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "invalid scan_kinds entry \"apps/v1/Deployment\"")
+	})
+}
+
 func (s *ValidateSuite) TestToolsets() {
 	s.Run("invalid toolset name is rejected", func() {
 		cfg := s.validConfig()
@@ -167,6 +346,32 @@ func (s *ValidateSuite) TestCertificateAuthority() {
 	})
 }
 
+func (s *ValidateSuite) TestBearerTokenFile() {
+	s.Run("non-existent file is rejected", func() {
+		cfg := s.validConfig()
+		cfg.BearerTokenFile = "/nonexistent/path/token"
+		err := cfg.Validate(s.T().Context())
+		s.Require().Error(err)
+		s.Contains(err.Error(), "bearer_token_file must be a valid file path")
+	})
+
+	s.Run("existing file is accepted", func() {
+		tokenPath := filepath.Join(s.T().TempDir(), "token")
+		s.Require().NoError(os.WriteFile(tokenPath, []byte("token"), 0600))
+
+		cfg := s.validConfig()
+		cfg.BearerTokenFile = tokenPath
+		s.NoError(cfg.Validate(s.T().Context()))
+	})
+
+	s.Run("whitespace-only is treated as empty", func() {
+		cfg := s.validConfig()
+		cfg.BearerTokenFile = "   "
+		s.NoError(cfg.Validate(s.T().Context()))
+		s.Equal("", cfg.BearerTokenFile, "whitespace should be trimmed from bearer_token_file")
+	})
+}
+
 func (s *ValidateSuite) TestTLSCertKey() {
 	s.Run("tls_cert without tls_key is rejected", func() {
 		tmpDir := s.T().TempDir()
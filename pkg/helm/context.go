@@ -0,0 +1,66 @@
+package helm
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+)
+
+// contextRESTClientGetter binds the REST config used by the Helm actions to the context of the operation.
+// Helm performs some of its Kubernetes API calls (e.g. the readiness wait or the hooks log retrieval) with a
+// background context, binding the context to the transport ensures they are aborted once the operation is cancelled.
+type contextRESTClientGetter struct {
+	genericclioptions.RESTClientGetter
+	ctx context.Context
+}
+
+func (g *contextRESTClientGetter) ToRESTConfig() (*rest.Config, error) {
+	restConfig, err := g.RESTClientGetter.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Wrap(func(delegate http.RoundTripper) http.RoundTripper {
+		return &contextRoundTripper{ctx: g.ctx, delegate: delegate}
+	})
+	return restConfig, nil
+}
+
+// contextRoundTripper cancels the requests (and their response bodies) when the context is done
+type contextRoundTripper struct {
+	ctx      context.Context
+	delegate http.RoundTripper
+}
+
+func (rt *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := rt.ctx.Err(); err != nil {
+		return nil, err
+	}
+	reqCtx, cancel := context.WithCancelCause(req.Context())
+	stop := context.AfterFunc(rt.ctx, func() { cancel(context.Cause(rt.ctx)) })
+	release := func() {
+		stop()
+		cancel(nil)
+	}
+	resp, err := rt.delegate.RoundTrip(req.WithContext(reqCtx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody releases the request context once the response body is closed
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
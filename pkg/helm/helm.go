@@ -2,6 +2,7 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -16,6 +17,8 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 )
 
 type Kubernetes interface {
@@ -33,7 +36,23 @@ func NewHelm(kubernetes Kubernetes, config *Config) *Helm {
 	return &Helm{kubernetes: kubernetes, config: config}
 }
 
-func (h *Helm) Install(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string) (string, error) {
+// DefaultTimeout is the time to wait for the release resources to be ready (or removed) when no timeout is provided
+const DefaultTimeout = 5 * time.Minute
+
+// progressInterval is the interval at which Install reports its progress while waiting for the release resources
+var progressInterval = 5 * time.Second
+
+// InstallOptions control how Install waits for the release resources to be ready
+type InstallOptions struct {
+	// Wait for the release resources to be ready before returning
+	Wait bool
+	// Timeout for the readiness wait, DefaultTimeout if zero
+	Timeout time.Duration
+	// Progress is periodically called with the elapsed time while waiting for the release resources (optional)
+	Progress api.ProgressFunc
+}
+
+func (h *Helm) Install(ctx context.Context, chart string, values map[string]interface{}, name string, namespace string, opts InstallOptions) (string, error) {
 	if err := validateChartReference(chart, h.config); err != nil {
 		return "", err
 	}
@@ -49,8 +68,11 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 		install.ReleaseName = name
 	}
 	install.Namespace = h.kubernetes.NamespaceOrDefault(namespace)
-	install.Wait = true
-	install.Timeout = 5 * time.Minute
+	install.Wait = opts.Wait
+	install.Timeout = opts.Timeout
+	if install.Timeout <= 0 {
+		install.Timeout = DefaultTimeout
+	}
 	install.DryRun = false
 
 	chartRequested, err := install.LocateChart(chart, cli.New())
@@ -62,8 +84,15 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 		return "", err
 	}
 
+	if install.Wait && opts.Progress != nil {
+		stopProgress := reportInstallProgress(install.ReleaseName, install.Timeout, opts.Progress)
+		defer stopProgress()
+	}
 	installedRelease, err := install.RunWithContext(ctx, chartLoaded, values)
-	if err != nil {
+	if err != nil && install.Wait && isWaitTimeout(err) {
+		return "", fmt.Errorf("release %s resources were not ready after %s (the release may still become ready, check its status with helm_list): %w",
+			install.ReleaseName, install.Timeout, err)
+	} else if err != nil {
 		return "", err
 	}
 	ret, err := yaml.Marshal(simplify(installedRelease))
@@ -73,6 +102,32 @@ func (h *Helm) Install(ctx context.Context, chart string, values map[string]inte
 	return string(ret), nil
 }
 
+// reportInstallProgress periodically reports the time elapsed installing the release until the returned function is called
+func reportInstallProgress(releaseName string, timeout time.Duration, progress api.ProgressFunc) func() {
+	start := time.Now()
+	ticker := time.NewTicker(progressInterval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				elapsed := time.Since(start)
+				progress(elapsed.Seconds(), timeout.Seconds(), fmt.Sprintf("Installing release %s and waiting for its resources to be ready (%s elapsed, timeout %s)",
+					releaseName, elapsed.Round(time.Second), timeout))
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-stopped
+	}
+}
+
 // List lists all the releases for the specified namespace (or current namespace if). Or allNamespaces is true, it lists all releases across all namespaces.
 func (h *Helm) List(ctx context.Context, namespace string, allNamespaces bool) (string, error) {
 	cfg, err := h.newAction(ctx, namespace, allNamespaces)
@@ -102,7 +157,7 @@ func (h *Helm) Uninstall(ctx context.Context, name string, namespace string) (st
 	uninstall := action.NewUninstall(cfg)
 	uninstall.IgnoreNotFound = true
 	uninstall.Wait = true
-	uninstall.Timeout = 5 * time.Minute
+	uninstall.Timeout = DefaultTimeout
 	uninstalledRelease, err := uninstall.Run(name)
 	if uninstalledRelease == nil && err == nil {
 		return fmt.Sprintf("Release %s not found", name), nil
@@ -128,13 +183,18 @@ func (h *Helm) newAction(ctx context.Context, namespace string, allNamespaces bo
 	}
 	cfg.RegistryClient = registryClient
 	logger := klog.FromContext(ctx)
-	return cfg, cfg.Init(h.kubernetes, applicableNamespace, storageDriver, func(format string, v ...any) {
+	return cfg, cfg.Init(&contextRESTClientGetter{RESTClientGetter: h.kubernetes, ctx: ctx}, applicableNamespace, storageDriver, func(format string, v ...any) {
 		if logger.V(5).Enabled() {
 			logger.V(5).Info(fmt.Sprintf(format, v...))
 		}
 	})
 }
 
+// isWaitTimeout returns true if the error was caused by the readiness wait timing out
+func isWaitTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || strings.Contains(err.Error(), "timed out waiting for the condition")
+}
+
 // validateChartReference blocks chart references using dangerous URL schemes.
 // Only oci:// and https:// URLs are allowed. Non-URL references (e.g. "stable/grafana")
 // are permitted as they resolve through Helm's local repo configuration.
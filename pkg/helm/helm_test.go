@@ -1,6 +1,11 @@
 package helm
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -8,6 +13,8 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/release"
 	helmtime "helm.sh/helm/v3/pkg/time"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
 )
 
 type HelmSuite struct {
@@ -216,6 +223,87 @@ func (s *HelmSuite) TestValidateChartReference() {
 	})
 }
 
+func (s *HelmSuite) TestIsWaitTimeout() {
+	s.Run("context deadline exceeded is a wait timeout", func() {
+		s.True(isWaitTimeout(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	})
+	s.Run("condition wait timeout is a wait timeout", func() {
+		s.True(isWaitTimeout(errors.New("timed out waiting for the condition")))
+	})
+	s.Run("other errors are not wait timeouts", func() {
+		s.False(isWaitTimeout(errors.New("chart not found")))
+	})
+}
+
+func (s *HelmSuite) TestReportInstallProgress() {
+	defaultInterval := progressInterval
+	progressInterval = 10 * time.Millisecond
+	s.T().Cleanup(func() { progressInterval = defaultInterval })
+	reports := make(chan string, 10)
+	var progresses []float64
+	stop := reportInstallProgress("my-release", time.Minute, func(progress, total float64, message string) {
+		progresses = append(progresses, progress)
+		s.Equal(float64(60), total)
+		reports <- message
+	})
+	first, second := <-reports, <-reports
+	stop()
+	s.Run("reports the release being installed", func() {
+		s.Contains(first, "Installing release my-release and waiting for its resources to be ready")
+		s.Contains(second, "timeout 1m0s")
+	})
+	s.Run("reports increasing progress", func() {
+		s.Require().GreaterOrEqual(len(progresses), 2)
+		s.Less(progresses[0], progresses[1])
+	})
+}
+
+func (s *HelmSuite) TestContextRESTClientGetter() {
+	requested := make(chan struct{})
+	aborted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		close(requested)
+		<-req.Context().Done()
+		close(aborted)
+	}))
+	s.T().Cleanup(server.Close)
+	ctx, cancel := context.WithCancel(s.T().Context())
+	getter := &contextRESTClientGetter{RESTClientGetter: genericclioptions.NewConfigFlags(false), ctx: ctx}
+	getter.RESTClientGetter.(*genericclioptions.ConfigFlags).APIServer = &server.URL
+	restConfig, err := getter.ToRESTConfig()
+	s.Require().NoError(err)
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	s.Require().NoError(err)
+	s.Run("requests made with a background context are aborted when the operation context is cancelled", func() {
+		errs := make(chan error, 1)
+		go func() {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/api/v1/pods?watch=true", nil)
+			resp, reqErr := httpClient.Do(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			errs <- reqErr
+		}()
+		<-requested
+		cancel()
+		select {
+		case err = <-errs:
+			s.ErrorIs(err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			s.Fail("request was not aborted after the context was cancelled")
+		}
+		select {
+		case <-aborted:
+		case <-time.After(5 * time.Second):
+			s.Fail("server did not observe the request being aborted")
+		}
+	})
+	s.Run("requests made after the operation context is cancelled fail immediately", func() {
+		_, err = httpClient.Get(server.URL + "/version")
+		s.ErrorIs(err, context.Canceled)
+	})
+}
+
 func TestHelm(t *testing.T) {
 	suite.Run(t, new(HelmSuite))
 }
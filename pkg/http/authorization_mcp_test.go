@@ -598,9 +598,14 @@ func (s *AuthorizationSuite) TestAuthorizationExemptEndpointsFromOAuth() {
 	// https://github.com/containers/kubernetes-mcp-server/issues/964
 	// When require_oauth is true, infrastructure/observability endpoints should
 	// still be accessible without an OAuth token.
+	s.MockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/version" {
+			_, _ = w.Write([]byte(`{"major":"1","minor":"34"}`))
+		}
+	}))
 	s.StartServer()
 
-	exemptEndpoints := []string{"/healthz", "/metrics", "/stats"}
+	exemptEndpoints := []string{"/healthz", "/readyz", "/metrics", "/stats"}
 	for _, endpoint := range exemptEndpoints {
 		s.Run(fmt.Sprintf("%s accessible without OAuth token", endpoint), func() {
 			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%s%s", s.StaticConfig.Port, endpoint), nil)
@@ -44,6 +44,7 @@ func (w *tlsErrorFilterWriter) Write(p []byte) (n int, err error) {
 
 const (
 	healthEndpoint     = "/healthz"
+	readyEndpoint      = "/readyz"
 	statsEndpoint      = "/stats"
 	metricsEndpoint    = "/metrics"
 	mcpEndpoint        = "/mcp"
@@ -53,7 +54,7 @@ const (
 
 var (
 	// infraPaths contains infrastructure endpoints which should not have oauth applied
-	infraPaths = []string{healthEndpoint, metricsEndpoint, statsEndpoint}
+	infraPaths = []string{healthEndpoint, readyEndpoint, metricsEndpoint, statsEndpoint}
 )
 
 // metricsMiddleware wraps an HTTP handler to record metrics for all requests
@@ -106,6 +107,19 @@ func statsHandler(mcpServer *mcp.Server) http.HandlerFunc {
 	}
 }
 
+// readyHandler returns an HTTP handler that reports whether the Kubernetes API is reachable.
+// The check uses the server's own credentials so it works for unauthenticated probes.
+func readyHandler(mcpServer *mcp.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := mcpServer.CheckReadiness(r.Context()); err != nil {
+			klogutil.LogInfo(klog.FromContext(r.Context()).V(1), "Readiness check failed", klogutil.Err(err))
+			http.Error(w, "Kubernetes API not reachable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 func Serve(ctx context.Context, mcpServer *mcp.Server, cfgState *config.StaticConfigState, oauthState *oauth.State) error {
 	logger := klog.FromContext(ctx)
 	// Only fields read below are startup-only; middleware reloads via cfgState.
@@ -151,6 +165,7 @@ func Serve(ctx context.Context, mcpServer *mcp.Server, cfgState *config.StaticCo
 	mux.HandleFunc(healthEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	mux.HandleFunc(readyEndpoint, readyHandler(mcpServer))
 	mux.HandleFunc(statsEndpoint, statsHandler(mcpServer))
 	mux.Handle(metricsEndpoint, mcpServer.GetMetrics().PrometheusHandler())
 	mux.Handle("/.well-known/", WellKnownHandler(cfgState, oauthState))
@@ -176,13 +191,13 @@ func Serve(ctx context.Context, mcpServer *mcp.Server, cfgState *config.StaticCo
 		if staticConfig.TLSCert != "" && staticConfig.TLSKey != "" {
 			logger.Info("HTTPS server starting",
 				"server.port", staticConfig.Port,
-				"endpoints", "/mcp, /sse, /message, /healthz, /stats, /metrics",
+				"endpoints", "/mcp, /sse, /message, /healthz, /readyz, /stats, /metrics",
 			)
 			err = httpServer.ListenAndServeTLS(staticConfig.TLSCert, staticConfig.TLSKey)
 		} else {
 			logger.Info("HTTP server starting",
 				"server.port", staticConfig.Port,
-				"endpoints", "/mcp, /sse, /message, /healthz, /stats, /metrics",
+				"endpoints", "/mcp, /sse, /message, /healthz, /readyz, /stats, /metrics",
 			)
 			err = httpServer.ListenAndServe()
 		}
@@ -1,11 +1,17 @@
 package http
 
 import (
+	"bufio"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
 )
 
 type McpTransportSuite struct {
@@ -45,6 +51,43 @@ func (s *McpTransportSuite) TestSseTransport() {
 	})
 }
 
+func (s *McpTransportSuite) TestSseTransportKeepAlive() {
+	s.StaticConfig.HTTP.SSEKeepAliveInterval = config.Duration(50 * time.Millisecond)
+	s.StartServer()
+
+	s.Run("Writes keep-alive comments to the idle SSE stream", func() {
+		req, err := http.NewRequestWithContext(s.T().Context(), http.MethodGet, fmt.Sprintf("http://127.0.0.1:%s/sse", s.StaticConfig.Port), nil)
+		s.Require().NoError(err)
+		resp, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err, "Expected no error opening the SSE stream")
+		defer func() { _ = resp.Body.Close() }()
+		reader := bufio.NewReader(resp.Body)
+		var lines []string
+		for len(lines) < 10 {
+			line, readErr := reader.ReadString('\n')
+			s.Require().NoError(readErr, "Expected no error reading the SSE stream")
+			lines = append(lines, strings.TrimSpace(line))
+			if strings.HasPrefix(line, ": keep-alive") {
+				break
+			}
+		}
+		s.Equal("event: endpoint", lines[0], "Expected the endpoint event first")
+		s.Equal(": keep-alive", lines[len(lines)-1], "Expected a keep-alive comment")
+	})
+	s.Run("Keep-alive comments are ignored by the clients", func() {
+		sseClient := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1.33.7"}, nil)
+		session, err := sseClient.Connect(s.T().Context(), &mcp.SSEClientTransport{
+			Endpoint: fmt.Sprintf("http://127.0.0.1:%s/sse", s.StaticConfig.Port),
+		}, nil)
+		s.Require().NoError(err, "Expected no error connecting SSE MCP client")
+		defer func() { _ = session.Close() }()
+		time.Sleep(200 * time.Millisecond)
+		tools, err := session.ListTools(s.T().Context(), &mcp.ListToolsParams{})
+		s.Require().NoError(err, "Expected no error listing tools after keep-alive comments")
+		s.Greater(len(tools.Tools), 0, "Expected at least one tool from SSE MCP client")
+	})
+}
+
 func (s *McpTransportSuite) TestStreamableHttpTransport() {
 	testCases := []bool{true, false}
 	for _, stateless := range testCases {
@@ -290,6 +290,95 @@ func TestHealthCheck(t *testing.T) {
 	})
 }
 
+func TestReadinessCheck(t *testing.T) {
+	versionHandler := func(authorization *string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/version" {
+				*authorization = req.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`{"major":"1","minor":"34"}`))
+			}
+		})
+	}
+	testCase(t, func(ctx *httpContext) {
+		var authorization string
+		ctx.mockServer.Handle(versionHandler(&authorization))
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get readiness check endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		t.Run("Exposes readiness check endpoint at /readyz", func(t *testing.T) {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d", resp.StatusCode)
+			}
+		})
+	})
+	testCase(t, func(ctx *httpContext) {
+		ctx.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/version" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get readiness check endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		t.Run("Readiness check with failing Kubernetes API returns HTTP 503 Service Unavailable", func(t *testing.T) {
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("Expected HTTP 503 Service Unavailable, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Readiness check failure is logged", func(t *testing.T) {
+			if !strings.Contains(ctx.LogBuffer.String(), "Readiness check failed") {
+				t.Errorf("Expected readiness check failure log, got: %s", ctx.LogBuffer.String())
+			}
+		})
+	})
+	testCaseWithContext(t, &httpContext{StaticConfig: &config.StaticConfig{HTTP: config.HTTPConfig{ReadinessCheckTimeout: config.Duration(100 * time.Millisecond)}}}, func(ctx *httpContext) {
+		ctx.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/version" {
+				time.Sleep(time.Second)
+			}
+		}))
+		resp, err := http.Get(fmt.Sprintf("http://%s/readyz", ctx.HttpAddress))
+		if err != nil {
+			t.Fatalf("Failed to get readiness check endpoint: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		t.Run("Readiness check exceeding the configured timeout returns HTTP 503 Service Unavailable", func(t *testing.T) {
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("Expected HTTP 503 Service Unavailable, got %d", resp.StatusCode)
+			}
+		})
+	})
+	// Readiness exposed even when require Authorization, using the server's own credentials
+	testCaseWithContext(t, &httpContext{StaticConfig: &config.StaticConfig{RequireOAuth: true, ClusterProviderStrategy: api.ClusterProviderKubeConfig}}, func(ctx *httpContext) {
+		var authorization string
+		ctx.mockServer.Handle(versionHandler(&authorization))
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/readyz", ctx.HttpAddress), nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer user-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to get readiness check endpoint with OAuth: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		t.Run("Readiness check with OAuth returns HTTP 200 OK", func(t *testing.T) {
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected HTTP 200 OK, got %d", resp.StatusCode)
+			}
+		})
+		t.Run("Readiness check does not use the user-provided credentials", func(t *testing.T) {
+			if authorization != "" {
+				t.Errorf("Expected no Authorization header in Kubernetes API request, got %s", authorization)
+			}
+		})
+	})
+}
+
 func TestMiddlewareLogging(t *testing.T) {
 	testCase(t, func(ctx *httpContext) {
 		_, _ = http.Get(fmt.Sprintf("http://%s/.well-known/oauth-protected-resource", ctx.HttpAddress))
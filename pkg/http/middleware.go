@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/logging"
 	"github.com/containers/kubernetes-mcp-server/pkg/telemetry"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -68,7 +69,7 @@ func getClientIP(r *http.Request, trustProxy bool) string {
 func getHTTPRoute(path string) string {
 	// Known routes for this server
 	switch path {
-	case "/healthz", "/mcp", "/sse", "/message", "/stats":
+	case "/healthz", "/readyz", "/mcp", "/sse", "/message", "/stats":
 		return path
 	}
 	// Check for well-known prefix
@@ -87,11 +88,17 @@ func RequestMiddleware(cfgState *config.StaticConfigState) func(http.Handler) ht
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip tracing for health checks
-			if r.URL.Path == "/healthz" {
+			if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			// Correlate the log lines of the request, the ID is also propagated to the MCP layer through the header
+			requestID := logging.RequestID(r.Header)
+			r.Header.Set(logging.RequestIDHeader, requestID)
+			w.Header().Set(logging.RequestIDHeader, requestID)
+			r = r.WithContext(klog.NewContext(r.Context(), klog.FromContext(r.Context()).WithValues("http.request.id", requestID)))
+
 			trustProxy := cfgState.Load().TrustProxyHeaders
 
 			// Skip all tracing work if telemetry is not enabled
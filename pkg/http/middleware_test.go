@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/logging"
 	"github.com/containers/kubernetes-mcp-server/pkg/telemetry"
 	"github.com/stretchr/testify/suite"
 	"go.opentelemetry.io/otel"
@@ -328,6 +329,51 @@ func TestMaxBodyMiddleware(t *testing.T) {
 	suite.Run(t, new(MaxBodyMiddlewareSuite))
 }
 
+type RequestIDMiddlewareSuite struct {
+	suite.Suite
+}
+
+func (s *RequestIDMiddlewareSuite) serve(path string, mutate func(*http.Request)) (*httptest.ResponseRecorder, string) {
+	var propagated string
+	handler := RequestMiddleware(cfgStateWithTrustProxy(false))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		propagated = r.Header.Get(logging.RequestIDHeader)
+	}))
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	if mutate != nil {
+		mutate(req)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr, propagated
+}
+
+func (s *RequestIDMiddlewareSuite) TestRequestID() {
+	s.Run("generates a request ID when none is provided", func() {
+		rr, propagated := s.serve("/mcp", nil)
+		s.NotEmpty(propagated)
+		s.Equal(propagated, rr.Header().Get(logging.RequestIDHeader))
+	})
+	s.Run("reuses the request ID provided by the client", func() {
+		rr, propagated := s.serve("/mcp", func(r *http.Request) { r.Header.Set(logging.RequestIDHeader, "req-42") })
+		s.Equal("req-42", propagated)
+		s.Equal("req-42", rr.Header().Get(logging.RequestIDHeader))
+	})
+	s.Run("replaces an invalid request ID", func() {
+		rr, propagated := s.serve("/mcp", func(r *http.Request) { r.Header.Set(logging.RequestIDHeader, "bad id\n") })
+		s.NotEqual("bad id\n", propagated)
+		s.Equal(propagated, rr.Header().Get(logging.RequestIDHeader))
+	})
+	s.Run("skips health checks", func() {
+		rr, propagated := s.serve("/healthz", nil)
+		s.Empty(propagated)
+		s.Empty(rr.Header().Get(logging.RequestIDHeader))
+	})
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	suite.Run(t, new(RequestIDMiddlewareSuite))
+}
+
 // TrustProxyHeadersSuite verifies that RequestMiddleware only honors
 // X-Forwarded-* and X-Real-IP headers when trust_proxy_headers is enabled.
 // Assertions read url.scheme and client.address from OpenTelemetry span
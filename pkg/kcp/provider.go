@@ -35,6 +35,8 @@ type kcpClusterProvider struct {
 	managers            map[string]*kubernetes.Manager
 	workspaceWatcher    *WorkspaceWatcher
 	clusterStateWatcher *watcher.ClusterState
+	// watches keeps the watch limiter of each workspace across the rebuilds of the managers
+	watches kubernetes.WatchLimiters
 }
 
 var _ kubernetes.Provider = &kcpClusterProvider{}
@@ -114,6 +116,7 @@ func (p *kcpClusterProvider) reset(ctx context.Context) error {
 		p.managers[ws] = nil
 	}
 	// Store the base manager for the default workspace
+	p.watches.Apply(p.defaultWorkspace, baseManager)
 	p.managers[p.defaultWorkspace] = baseManager
 
 	// Setup watchers
@@ -189,6 +192,7 @@ func (p *kcpClusterProvider) managerForWorkspace(ctx context.Context, workspace
 		return nil, fmt.Errorf("failed to create manager for workspace %s: %w", workspace, err)
 	}
 
+	p.watches.Apply(workspace, m)
 	p.managers[workspace] = m
 	return m, nil
 }
@@ -267,6 +271,19 @@ func (p *kcpClusterProvider) GetDerivedKubernetes(ctx context.Context, workspace
 	return m.Derived(ctx)
 }
 
+func (p *kcpClusterProvider) CheckReadiness(ctx context.Context, workspace string) error {
+	if workspace == "" {
+		workspace = p.defaultWorkspace
+	}
+
+	m, err := p.managerForWorkspace(ctx, workspace)
+	if err != nil {
+		return err
+	}
+
+	return m.CheckReadiness(ctx)
+}
+
 func (p *kcpClusterProvider) GetDefaultTarget() string {
 	return p.defaultWorkspace
 }
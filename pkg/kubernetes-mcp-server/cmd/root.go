@@ -57,6 +57,9 @@ kubernetes-mcp-server --cluster-provider kubeconfig
 
 # start with kcp cluster provider for multi-workspace support
 kubernetes-mcp-server --cluster-provider kcp
+
+# validate the configuration and the connectivity to the Kubernetes API and quit
+kubernetes-mcp-server --config config.toml --validate
 `))
 )
 
@@ -64,6 +67,7 @@ const (
 	flagVersion              = "version"
 	flagLogLevel             = "log-level"
 	flagLogFile              = "log-file"
+	flagLogFormat            = "log-format"
 	flagConfig               = "config"
 	flagConfigDir            = "config-dir"
 	flagPort                 = "port"
@@ -85,12 +89,14 @@ const (
 	flagTLSCert              = "tls-cert"
 	flagTLSKey               = "tls-key"
 	flagRequireTLS           = "require-tls"
+	flagValidate             = "validate"
 )
 
 type MCPServerOptions struct {
 	Version              bool
 	LogLevel             int
 	LogFile              string
+	LogFormat            string
 	Port                 string
 	SSEBaseUrl           string
 	Kubeconfig           string
@@ -110,12 +116,15 @@ type MCPServerOptions struct {
 	TLSCert              string
 	TLSKey               string
 	RequireTLS           bool
+	ValidateOnly         bool
 
 	ConfigPath   string
 	ConfigDir    string
 	StaticConfig *config.StaticConfig
 
 	logSink *logging.Sink
+	// requireOAuthIgnored is true when require_oauth was disabled because the STDIO transport doesn't support it
+	requireOAuthIgnored bool
 	genericiooptions.IOStreams
 }
 
@@ -148,6 +157,9 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 					}
 				}
 			}()
+			if o.ValidateOnly {
+				return o.SelfCheck(ctx)
+			}
 			if err := o.Validate(ctx); err != nil {
 				return err
 			}
@@ -162,6 +174,7 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().BoolVar(&o.Version, flagVersion, o.Version, "Print version information and quit")
 	cmd.Flags().IntVar(&o.LogLevel, flagLogLevel, o.LogLevel, "Set the log level (from 0 to 9)")
 	cmd.Flags().StringVar(&o.LogFile, flagLogFile, o.LogFile, "Defines the server log file path. Required for logging in stdio mode; overrides stdout in HTTP mode. Set to \"stderr\" to log to the standard error stream.")
+	cmd.Flags().StringVar(&o.LogFormat, flagLogFormat, o.LogFormat, "Set the log format (text or json)")
 	cmd.Flags().StringVar(&o.ConfigPath, flagConfig, o.ConfigPath, "Path of the config file.")
 	cmd.Flags().StringVar(&o.ConfigDir, flagConfigDir, o.ConfigDir, "Path to drop-in configuration directory (files loaded in lexical order). Defaults to "+config.DefaultDropInConfigDir+" relative to the config file if --config is set.")
 	cmd.Flags().StringVar(&o.Port, flagPort, o.Port, "Start a streamable HTTP and SSE HTTP server on the specified port (e.g. 8080)")
@@ -189,6 +202,7 @@ func NewMCPServer(streams genericiooptions.IOStreams) *cobra.Command {
 	cmd.Flags().StringVar(&o.TLSCert, flagTLSCert, o.TLSCert, "Path to TLS certificate file for HTTPS. Must be used together with --tls-key.")
 	cmd.Flags().StringVar(&o.TLSKey, flagTLSKey, o.TLSKey, "Path to TLS private key file for HTTPS. Must be used together with --tls-cert.")
 	cmd.Flags().BoolVar(&o.RequireTLS, flagRequireTLS, o.RequireTLS, "Require TLS for server and all outbound connections")
+	cmd.Flags().BoolVar(&o.ValidateOnly, flagValidate, o.ValidateOnly, "Validate the configuration, the kubeconfig and the connectivity to the Kubernetes API, report all the problems found and quit (exits with a non-zero status if any problem is found)")
 
 	return cmd
 }
@@ -213,6 +227,7 @@ func (m *MCPServerOptions) Complete(ctx context.Context, cmd *cobra.Command) err
 	if m.StaticConfig.RequireOAuth && m.StaticConfig.Port == "" {
 		// RequireOAuth is not relevant flow for STDIO transport
 		m.StaticConfig.RequireOAuth = false
+		m.requireOAuthIgnored = true
 	}
 
 	return nil
@@ -225,6 +240,9 @@ func (m *MCPServerOptions) loadFlags(cmd *cobra.Command) {
 	if cmd.Flag(flagLogFile).Changed {
 		m.StaticConfig.LogFile = m.LogFile
 	}
+	if cmd.Flag(flagLogFormat).Changed {
+		m.StaticConfig.LogFormat = m.LogFormat
+	}
 	if cmd.Flag(flagPort).Changed {
 		m.StaticConfig.Port = m.Port
 	}
@@ -340,7 +340,7 @@ func TestListOutput(t *testing.T) {
 		rootCmd := NewMCPServer(ioStreams)
 		rootCmd.SetArgs([]string{"--help"})
 		o, err := captureOutput(rootCmd.Execute) // --help doesn't use logger/klog, cobra prints directly to stdout
-		if !strings.Contains(o, "Output format for resource list operations (one of: yaml, table)") {
+		if !strings.Contains(o, "Output format for resource list operations (one of: yaml, table, json)") {
 			t.Fatalf("Expected all available outputs, got %s %v", o, err)
 		}
 	})
@@ -757,6 +757,45 @@ func (s *CmdSuite) TestLogFile() {
 	})
 }
 
+func (s *CmdSuite) TestLogFormat() {
+	s.Run("--log-format=json writes JSON log lines", func() {
+		logPath := filepath.Join(s.T().TempDir(), "server.log")
+
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-level=1", "--log-file", logPath, "--log-format=json"})
+		s.Require().NoError(rootCmd.Execute())
+
+		logContent, err := os.ReadFile(logPath)
+		s.Require().NoError(err)
+		s.Contains(string(logContent), `"msg":"Starting kubernetes-mcp-server"`)
+	})
+
+	s.Run("log_format from TOML config is used", func() {
+		logPath := filepath.Join(s.T().TempDir(), "server.log")
+		configPath := filepath.Join(s.T().TempDir(), "config.toml")
+		s.Require().NoError(os.WriteFile(configPath, []byte(fmt.Sprintf("log_level = 1\nlog_file = %q\nlog_format = \"json\"\n", logPath)), 0o600))
+
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--config", configPath})
+		s.Require().NoError(rootCmd.Execute())
+
+		logContent, err := os.ReadFile(logPath)
+		s.Require().NoError(err)
+		s.Contains(string(logContent), `"msg":"Starting kubernetes-mcp-server"`)
+	})
+
+	s.Run("invalid --log-format returns error", func() {
+		ioStreams, _ := testStream()
+		rootCmd := NewMCPServer(ioStreams)
+		rootCmd.SetArgs([]string{"--version", "--log-format=xml"})
+		err := rootCmd.Execute()
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid log_format "xml"`)
+	})
+}
+
 func TestTLSValidation(t *testing.T) {
 	t.Run("tls-cert without tls-key returns error", func(t *testing.T) {
 		tempDir := t.TempDir()
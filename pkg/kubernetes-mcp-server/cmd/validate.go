@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// selfCheckTimeout bounds each Kubernetes API connectivity check so that unreachable clusters don't hang the validation
+const selfCheckTimeout = 10 * time.Second
+
+// selfCheckResult is the outcome of one of the checks performed by the --validate mode
+type selfCheckResult struct {
+	name string
+	err  error
+	// warning results are reported but don't fail the validation
+	warning bool
+	// skipped explains why the check wasn't performed
+	skipped string
+}
+
+func (r selfCheckResult) status() string {
+	switch {
+	case r.skipped != "":
+		return "SKIP"
+	case r.err == nil:
+		return "OK"
+	case r.warning:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// SelfCheck validates the effective configuration, the Kubernetes cluster provider and the connectivity to the
+// Kubernetes API of its targets, printing the outcome of every check.
+// Unlike Validate, it doesn't stop at the first problem, an error is returned if any problem was found.
+func (m *MCPServerOptions) SelfCheck(ctx context.Context) error {
+	results := []selfCheckResult{
+		{name: "configuration", err: m.Validate(ctx)},
+		m.checkOAuthTransport(),
+	}
+	provider, err := kubernetes.NewProvider(ctx, m.StaticConfig)
+	results = append(results, selfCheckResult{name: "kubernetes cluster provider", err: err})
+	if err == nil {
+		defer provider.Close()
+		results = append(results, checkTargets(ctx, provider)...)
+		results = append(results, m.checkDeniedResources(ctx, provider)...)
+	}
+
+	problems := 0
+	for _, r := range results {
+		switch {
+		case r.skipped != "":
+			_, _ = fmt.Fprintf(m.Out, "[%s] %s: %s\n", r.status(), r.name, r.skipped)
+		case r.err != nil:
+			_, _ = fmt.Fprintf(m.Out, "[%s] %s: %v\n", r.status(), r.name, r.err)
+		default:
+			_, _ = fmt.Fprintf(m.Out, "[%s] %s\n", r.status(), r.name)
+		}
+		if r.err != nil && !r.warning {
+			problems++
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("validation failed: %d problem(s) found", problems)
+	}
+	_, _ = fmt.Fprintln(m.Out, "Validation succeeded")
+	return nil
+}
+
+// checkOAuthTransport reports require_oauth being ignored because the server doesn't serve HTTP (STDIO transport)
+func (m *MCPServerOptions) checkOAuthTransport() selfCheckResult {
+	result := selfCheckResult{name: "require_oauth transport"}
+	if m.requireOAuthIgnored {
+		result.err = errors.New("require_oauth is enabled but ignored by the STDIO transport, set --port to serve over HTTP")
+	} else if !m.StaticConfig.RequireOAuth {
+		result.skipped = "require_oauth is not enabled"
+	}
+	return result
+}
+
+// checkTargets verifies the Kubernetes API of every target is reachable.
+// Only the default target is required to be reachable, the rest are reported as warnings.
+func checkTargets(ctx context.Context, provider kubernetes.Provider) []selfCheckResult {
+	targets, err := provider.GetTargets(ctx)
+	if err != nil {
+		return []selfCheckResult{{name: "kubernetes targets", err: err}}
+	}
+	defaultTarget := provider.GetDefaultTarget()
+	if !slices.Contains(targets, defaultTarget) {
+		targets = append(targets, defaultTarget)
+	}
+	slices.Sort(targets)
+	results := make([]selfCheckResult, 0, len(targets))
+	for _, target := range targets {
+		name := "kubernetes api"
+		if target != "" {
+			name = fmt.Sprintf("kubernetes api (%s %s)", provider.GetTargetParameterName(), target)
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+		err = provider.CheckReadiness(checkCtx, target)
+		cancel()
+		results = append(results, selfCheckResult{name: name, err: err, warning: target != defaultTarget})
+	}
+	return results
+}
+
+// checkDeniedResources verifies the denied_resources reference resources served by the default target (e.g. no typos)
+func (m *MCPServerOptions) checkDeniedResources(ctx context.Context, provider kubernetes.Provider) []selfCheckResult {
+	deniedResources := m.StaticConfig.DeniedResources
+	if len(deniedResources) == 0 {
+		return nil
+	}
+	if m.StaticConfig.RequireOAuth {
+		return []selfCheckResult{{name: "denied_resources", skipped: "require_oauth is enabled, the Kubernetes API is only accessed with the credentials of each request"}}
+	}
+	k, err := provider.GetDerivedKubernetes(ctx, provider.GetDefaultTarget())
+	if err != nil {
+		return []selfCheckResult{{name: "denied_resources", err: err}}
+	}
+	results := make([]selfCheckResult, 0, len(deniedResources))
+	for _, gvk := range deniedResources {
+		gv := schema.GroupVersion{Group: gvk.Group, Version: gvk.Version}
+		result := selfCheckResult{name: fmt.Sprintf("denied_resources %s", gv)}
+		if gvk.Kind != "" {
+			result.name = fmt.Sprintf("denied_resources %s", gv.WithKind(gvk.Kind))
+		}
+		if gvk.Kind == "" {
+			if _, err = k.DiscoveryClient().ServerResourcesForGroupVersion(gv.String()); err != nil {
+				result.err = fmt.Errorf("group version %s is not served by the cluster: %w", gv, err)
+			}
+		} else if _, err = k.RESTMapper().RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version); err != nil {
+			result.err = fmt.Errorf("kind %s is not served by the cluster: %w", gv.WithKind(gvk.Kind), err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
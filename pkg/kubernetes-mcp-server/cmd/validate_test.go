@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ValidateSuite struct {
+	suite.Suite
+	mockServer *test.MockServer
+	kubeconfig string
+}
+
+func (s *ValidateSuite) SetupTest() {
+	s.mockServer = test.NewMockServer()
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/version" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"36","gitVersion":"v1.36.0"}`))
+		}
+	}))
+	s.kubeconfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ValidateSuite) TearDownTest() {
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ValidateSuite) execute(args ...string) (string, error) {
+	ioStreams, out := testStream()
+	rootCmd := NewMCPServer(ioStreams)
+	rootCmd.SetArgs(append([]string{"--validate"}, args...))
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func (s *ValidateSuite) writeConfig(content string) string {
+	configPath := filepath.Join(s.T().TempDir(), "config.toml")
+	s.Require().NoError(os.WriteFile(configPath, []byte(content), 0o644))
+	return configPath
+}
+
+func (s *ValidateSuite) TestValid() {
+	configPath := s.writeConfig(`
+		denied_resources = [
+			{ group = "apps", version = "v1", kind = "Deployment" },
+			{ group = "apps", version = "v1" },
+		]
+	`)
+	out, err := s.execute("--kubeconfig", s.kubeconfig, "--config", configPath)
+	s.Run("succeeds", func() {
+		s.NoError(err)
+		s.Contains(out, "Validation succeeded")
+	})
+	s.Run("reports the configuration", func() {
+		s.Contains(out, "[OK] configuration\n")
+	})
+	s.Run("reports the connectivity to the Kubernetes API", func() {
+		s.Contains(out, "[OK] kubernetes cluster provider\n")
+		s.Regexp(`\[OK\] kubernetes api \(context \S+\)\n`, out)
+	})
+	s.Run("reports the denied_resources", func() {
+		s.Contains(out, "[OK] denied_resources apps/v1, Kind=Deployment\n")
+		s.Contains(out, "[OK] denied_resources apps/v1\n")
+	})
+	s.Run("skips the OAuth transport when require_oauth is not enabled", func() {
+		s.Contains(out, "[SKIP] require_oauth transport: require_oauth is not enabled\n")
+	})
+}
+
+func (s *ValidateSuite) TestReportsAllProblems() {
+	configPath := s.writeConfig(`
+		require_oauth = true
+		toolsets = [ "core", "not-a-toolset" ]
+		denied_resources = [
+			{ group = "apps", version = "v1", kind = "Deploymnet" },
+			{ group = "not.a.group", version = "v1" },
+		]
+	`)
+	out, err := s.execute("--kubeconfig", s.kubeconfig, "--config", configPath)
+	s.Run("fails with the number of problems", func() {
+		s.Require().Error(err)
+		s.Equal("validation failed: 4 problem(s) found", err.Error())
+		s.NotContains(out, "Validation succeeded")
+	})
+	s.Run("reports the invalid toolset", func() {
+		s.Contains(out, "[FAIL] configuration: invalid toolset name: not-a-toolset")
+	})
+	s.Run("reports require_oauth ignored by the STDIO transport", func() {
+		s.Contains(out, "[FAIL] require_oauth transport: require_oauth is enabled but ignored by the STDIO transport")
+	})
+	s.Run("reports the denied_resources not served by the cluster", func() {
+		s.Contains(out, "[FAIL] denied_resources apps/v1, Kind=Deploymnet: kind apps/v1, Kind=Deploymnet is not served by the cluster")
+		s.Contains(out, "[FAIL] denied_resources not.a.group/v1: group version not.a.group/v1 is not served by the cluster")
+	})
+	s.Run("still checks the connectivity to the Kubernetes API", func() {
+		s.Regexp(`\[OK\] kubernetes api \(context \S+\)\n`, out)
+	})
+}
+
+func (s *ValidateSuite) TestInvalidKubeconfig() {
+	out, err := s.execute("--kubeconfig", filepath.Join(s.T().TempDir(), "missing-kubeconfig"))
+	s.Require().Error(err)
+	s.Equal("validation failed: 1 problem(s) found", err.Error())
+	s.Contains(out, "[FAIL] kubernetes cluster provider: ")
+}
+
+func (s *ValidateSuite) TestUnreachableKubernetesAPI() {
+	s.mockServer.Close()
+	out, err := s.execute("--kubeconfig", s.kubeconfig)
+	s.Require().Error(err)
+	s.Regexp(`\[FAIL\] kubernetes api \(context \S+\): `, out)
+	s.mockServer = nil
+}
+
+func TestValidate(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}
@@ -106,6 +106,7 @@ func (rt *AccessControlRoundTripper) RoundTrip(req *http.Request) (*http.Respons
 			return nil, &api.ValidationError{
 				Code:    api.ErrorCodeResourceNotFound,
 				Message: fmt.Sprintf("Resource %s does not exist in the cluster", api.FormatResourceName(&gvr)),
+				Err:     err,
 			}
 		}
 		return nil, fmt.Errorf("failed to make request: AccessControlRoundTripper failed to get kind for gvr %v: %w", gvr, err)
@@ -152,7 +153,17 @@ func (rt *AccessControlRoundTripper) RoundTrip(req *http.Request) (*http.Respons
 		}
 	}
 
-	return rt.delegate.RoundTrip(req)
+	resp, err := rt.delegate.RoundTrip(req)
+	// Turn the opaque Forbidden errors into actionable ones with the RBAC resources granting the missing permission
+	if err == nil && resp.StatusCode == http.StatusForbidden {
+		withRBACHint(resp, &forbiddenPermission{
+			Verb:      verb,
+			APIGroup:  gvr.Group,
+			Resource:  parseURLToResource(kubernetesPath),
+			Namespace: namespace,
+		})
+	}
+	return resp, err
 }
 
 // isAllowed checks the resource is in denied list or not.
@@ -160,11 +171,16 @@ func (rt *AccessControlRoundTripper) RoundTrip(req *http.Request) (*http.Respons
 func (rt *AccessControlRoundTripper) isAllowed(
 	gvk schema.GroupVersionKind,
 ) bool {
-	if rt.deniedResourcesProvider == nil {
+	return isResourceAllowed(rt.deniedResourcesProvider, gvk)
+}
+
+// isResourceAllowed checks the resource is not in the denied list of the provider (if any).
+func isResourceAllowed(deniedResourcesProvider api.DeniedResourcesProvider, gvk schema.GroupVersionKind) bool {
+	if deniedResourcesProvider == nil {
 		return true
 	}
 
-	for _, val := range rt.deniedResourcesProvider.GetDeniedResources() {
+	for _, val := range deniedResourcesProvider.GetDeniedResources() {
 		// If kind is empty, that means Group/Version pair is denied entirely
 		if val.Kind == "" {
 			if gvk.Group == val.Group && gvk.Version == val.Version {
@@ -251,6 +267,19 @@ func parseURLToNamespaceAndName(path string) (namespace, name string) {
 	return namespace, name
 }
 
+// parseURLToResource returns the resource of the request including the subresource (e.g. pods/log)
+func parseURLToResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	resourceIdx := findResourceTypeIndex(parts)
+	if resourceIdx < 0 {
+		return ""
+	}
+	if resourceIdx+2 < len(parts) {
+		return parts[resourceIdx] + "/" + parts[resourceIdx+2]
+	}
+	return parts[resourceIdx]
+}
+
 func findResourceTypeIndex(parts []string) int {
 	if len(parts) == 0 {
 		return -1
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/stretchr/testify/suite"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes"
@@ -329,6 +332,63 @@ func (s *AccessControlRoundTripperTestSuite) TestValidationDisabledBypassesValid
 	})
 }
 
+func (s *AccessControlRoundTripperTestSuite) TestRoundTripForForbiddenResponses() {
+	delegateCalled := false
+	mockDelegate := &mockRoundTripper{
+		called: &delegateCalled,
+		onRequest: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			switch r.URL.Path {
+			case "/api/v1/namespaces/default/pods/my-pod/log":
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Forbidden","code":403,` +
+					`"message":"pods \"my-pod\" is forbidden: User \"system:serviceaccount:mcp:server\" cannot get resource \"pods/log\" in API group \"\" in the namespace \"default\""}`))
+			case "/api/v1/nodes":
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Forbidden","code":403,"message":"forbidden by policy"}`))
+			default:
+				_, _ = w.Write([]byte(`not a status`))
+			}
+		},
+	}
+	rt := &AccessControlRoundTripper{
+		delegate:           mockDelegate,
+		restMapperProvider: func() meta.RESTMapper { return s.restMapper },
+	}
+	readStatus := func(resp *http.Response) *metav1.Status {
+		status := &metav1.Status{}
+		s.Require().NoError(json.NewDecoder(resp.Body).Decode(status))
+		return status
+	}
+
+	s.Run("Forbidden error of the RBAC authorizer includes the Role and RoleBinding granting the permission", func() {
+		resp, err := rt.RoundTrip(httptest.NewRequest("GET", "/api/v1/namespaces/default/pods/my-pod/log", nil))
+		s.Require().NoError(err)
+		s.Equal(http.StatusForbidden, resp.StatusCode)
+		status := readStatus(resp)
+		s.Equal(metav1.StatusReasonForbidden, status.Reason)
+		s.Contains(status.Message, "cannot get resource \"pods/log\" in API group \"\" in the namespace \"default\"\n\n"+
+			"The following RBAC resources grant the missing permission:\n")
+		s.Contains(status.Message, "kind: Role\nmetadata:\n  name: mcp-get-pods-log\n  namespace: default\n")
+		s.Contains(status.Message, "  resources:\n  - pods/log\n  verbs:\n  - get\n")
+		s.Contains(status.Message, "subjects:\n- kind: ServiceAccount\n  name: server\n  namespace: mcp\n")
+	})
+	s.Run("Forbidden error with an unknown message includes the permission of the request", func() {
+		resp, err := rt.RoundTrip(httptest.NewRequest("GET", "/api/v1/nodes", nil))
+		s.Require().NoError(err)
+		status := readStatus(resp)
+		s.Contains(status.Message, "forbidden by policy\n\nThe following RBAC resources grant the missing permission "+
+			"(replace the subject with the user or service account used by the server):\n")
+		s.Contains(status.Message, "kind: ClusterRole\nmetadata:\n  name: mcp-list-nodes\n")
+	})
+	s.Run("Forbidden response without Status is left untouched", func() {
+		resp, err := rt.RoundTrip(httptest.NewRequest("GET", "/api/v1/namespaces/default/pods", nil))
+		s.Require().NoError(err)
+		body, err := io.ReadAll(resp.Body)
+		s.Require().NoError(err)
+		s.Equal("not a status", string(body))
+	})
+}
+
 func (s *AccessControlRoundTripperTestSuite) TestRoundTripForDeniedAPIResources() {
 	delegateCalled := false
 	mockDelegate := &mockRoundTripper{
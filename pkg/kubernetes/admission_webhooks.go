@@ -0,0 +1,148 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// admissionWebhook is the configuration shared by the validating and mutating webhooks
+type admissionWebhook struct {
+	name              string
+	clientConfig      admissionregistrationv1.WebhookClientConfig
+	rules             []admissionregistrationv1.RuleWithOperations
+	failurePolicy     *admissionregistrationv1.FailurePolicyType
+	timeoutSeconds    *int32
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+}
+
+// AdmissionWebhooks returns the webhooks of the ValidatingWebhookConfigurations and MutatingWebhookConfigurations
+// (admissionregistration.k8s.io/v1) with their target rules, failurePolicy and backing Service.
+// Webhooks backed by a Service without ready endpoints (or that doesn't exist) are flagged with a Warning entry, every
+// request matching their rules fails (failurePolicy Fail) or is admitted without them once the call fails (Ignore).
+// If a name is provided, only the configurations or webhooks with that name are returned.
+func (c *Core) AdmissionWebhooks(ctx context.Context, name string) ([]map[string]any, error) {
+	validating, err := c.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+	mutating, err := c.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+	readyEndpoints := make(map[string]string) // Service namespace/name -> warning (empty if it has ready endpoints)
+	ret := make([]map[string]any, 0)
+	add := func(configuration string, webhook admissionWebhook) {
+		if name != "" && !strings.HasSuffix(configuration, "/"+name) && webhook.name != name {
+			return
+		}
+		failurePolicy := ptr.Deref(webhook.failurePolicy, admissionregistrationv1.Fail)
+		entry := map[string]any{
+			"Configuration":  configuration,
+			"Webhook":        webhook.name,
+			"Rules":          admissionWebhookRules(webhook.rules),
+			"FailurePolicy":  string(failurePolicy),
+			"TimeoutSeconds": ptr.Deref(webhook.timeoutSeconds, 10),
+		}
+		if selector := metav1.FormatLabelSelector(webhook.namespaceSelector); webhook.namespaceSelector != nil && selector != "<none>" {
+			entry["NamespaceSelector"] = selector
+		}
+		if selector := metav1.FormatLabelSelector(webhook.objectSelector); webhook.objectSelector != nil && selector != "<none>" {
+			entry["ObjectSelector"] = selector
+		}
+		if service := webhook.clientConfig.Service; service != nil {
+			entry["Service"] = fmt.Sprintf("%s/%s:%d%s", service.Namespace, service.Name, ptr.Deref(service.Port, 443), ptr.Deref(service.Path, ""))
+			key := service.Namespace + "/" + service.Name
+			warning, checked := readyEndpoints[key]
+			if !checked {
+				warning = c.admissionWebhookServiceWarning(ctx, service.Namespace, service.Name)
+				readyEndpoints[key] = warning
+			}
+			switch {
+			case warning != "" && failurePolicy == admissionregistrationv1.Ignore:
+				entry["Warning"] = warning + ", the requests matching its rules are admitted without it (failurePolicy Ignore)"
+			case warning != "":
+				entry["Warning"] = warning + ", the requests matching its rules are rejected (failurePolicy Fail)"
+			}
+		} else {
+			entry["URL"] = ptr.Deref(webhook.clientConfig.URL, "")
+		}
+		ret = append(ret, entry)
+	}
+	for _, configuration := range validating.Items {
+		for _, webhook := range configuration.Webhooks {
+			add("ValidatingWebhookConfiguration/"+configuration.Name, admissionWebhook{
+				name: webhook.Name, clientConfig: webhook.ClientConfig, rules: webhook.Rules, failurePolicy: webhook.FailurePolicy,
+				timeoutSeconds: webhook.TimeoutSeconds, namespaceSelector: webhook.NamespaceSelector, objectSelector: webhook.ObjectSelector,
+			})
+		}
+	}
+	for _, configuration := range mutating.Items {
+		for _, webhook := range configuration.Webhooks {
+			add("MutatingWebhookConfiguration/"+configuration.Name, admissionWebhook{
+				name: webhook.Name, clientConfig: webhook.ClientConfig, rules: webhook.Rules, failurePolicy: webhook.FailurePolicy,
+				timeoutSeconds: webhook.TimeoutSeconds, namespaceSelector: webhook.NamespaceSelector, objectSelector: webhook.ObjectSelector,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// admissionWebhookRules returns the rules in a compact form, e.g. CREATE,UPDATE apps/v1/deployments (Namespaced)
+func admissionWebhookRules(rules []admissionregistrationv1.RuleWithOperations) []string {
+	ret := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		operations := make([]string, 0, len(rule.Operations))
+		for _, operation := range rule.Operations {
+			operations = append(operations, string(operation))
+		}
+		var resources []string
+		for _, group := range rule.APIGroups {
+			if group == "" {
+				group = "core"
+			}
+			for _, version := range rule.APIVersions {
+				for _, resource := range rule.Resources {
+					resources = append(resources, group+"/"+version+"/"+resource)
+				}
+			}
+		}
+		formatted := strings.Join(operations, ",") + " " + strings.Join(resources, ",")
+		if scope := ptr.Deref(rule.Scope, admissionregistrationv1.AllScopes); scope != admissionregistrationv1.AllScopes {
+			formatted += " (" + string(scope) + ")"
+		}
+		ret = append(ret, formatted)
+	}
+	return ret
+}
+
+// admissionWebhookServiceWarning returns a warning if the Service backing the webhook doesn't exist or has no ready
+// endpoints, empty if it has ready endpoints (or they can't be checked)
+func (c *Core) admissionWebhookServiceWarning(ctx context.Context, namespace, name string) string {
+	if _, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return fmt.Sprintf("Service %s/%s not found, calls to the webhook fail", namespace, name)
+	} else if err != nil {
+		return ""
+	}
+	endpointSlices, err := c.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: name}.String(),
+	})
+	if err != nil {
+		return ""
+	}
+	for _, slice := range endpointSlices.Items {
+		if slices.ContainsFunc(slice.Endpoints, func(endpoint discoveryv1.Endpoint) bool { return endpointReady(&endpoint) }) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("Service %s/%s has no ready endpoints, calls to the webhook fail", namespace, name)
+}
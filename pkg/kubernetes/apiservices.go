@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var apiServicesGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// APIServicesStatus returns the status of the aggregated APIServices (apiregistration.k8s.io/v1) that are not Available,
+// the most common cause of partial discovery failures (e.g. metrics.k8s.io when the Metrics Server is down).
+// If a name is provided, the status of that APIService is returned regardless of its availability.
+// The second return value is the total number of APIServices that were checked.
+func (c *Core) APIServicesStatus(ctx context.Context, name string) ([]map[string]any, int, error) {
+	var apiServices []unstructured.Unstructured
+	if name != "" {
+		apiService, err := c.DynamicClient().Resource(apiServicesGVR).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		apiServices = append(apiServices, *apiService)
+	} else {
+		list, err := c.DynamicClient().Resource(apiServicesGVR).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		apiServices = list.Items
+	}
+	ret := make([]map[string]any, 0)
+	for _, apiService := range apiServices {
+		available, conditions := apiServiceConditions(&apiService)
+		if name == "" && available {
+			continue
+		}
+		ret = append(ret, map[string]any{
+			"Name":       apiService.GetName(),
+			"Service":    apiServiceBackend(&apiService),
+			"Available":  available,
+			"Conditions": conditions,
+		})
+	}
+	return ret, len(apiServices), nil
+}
+
+// apiServiceConditions returns whether the APIService has a true Available condition along with its status conditions
+func apiServiceConditions(apiService *unstructured.Unstructured) (bool, []map[string]string) {
+	available := false
+	items, _, _ := unstructured.NestedSlice(apiService.Object, "status", "conditions")
+	conditions := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		condition, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		conditionStatus, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+		entry := map[string]string{
+			"Type":    conditionType,
+			"Status":  conditionStatus,
+			"Reason":  reason,
+			"Message": message,
+		}
+		if lastTransitionTime, _, _ := unstructured.NestedString(condition, "lastTransitionTime"); lastTransitionTime != "" {
+			entry["LastTransitionTime"] = lastTransitionTime
+		}
+		conditions = append(conditions, entry)
+		if conditionType == "Available" && conditionStatus == string(metav1.ConditionTrue) {
+			available = true
+		}
+	}
+	return available, conditions
+}
+
+// apiServiceBackend returns the namespace/name of the Service backing the APIService, or Local if served by the kube-apiserver
+func apiServiceBackend(apiService *unstructured.Unstructured) string {
+	namespace, _, _ := unstructured.NestedString(apiService.Object, "spec", "service", "namespace")
+	name, found, _ := unstructured.NestedString(apiService.Object, "spec", "service", "name")
+	if !found || name == "" {
+		return "Local"
+	}
+	return namespace + "/" + name
+}
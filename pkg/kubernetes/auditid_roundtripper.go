@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"net/http"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/logging"
+)
+
+// AuditIDRoundTripper sends the request ID of the context (the correlation ID of the MCP tool call) in the Audit-ID
+// header, the Kubernetes API server uses it as the ID of the audit events of the request.
+type AuditIDRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+var _ http.RoundTripper = &AuditIDRoundTripper{}
+
+func (a *AuditIDRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return a.delegate
+}
+
+func (a *AuditIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := logging.RequestIDFromContext(req.Context())
+	if requestID == "" {
+		return a.delegate.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+
+	req.Header.Set(string(AuditIDHeader), requestID)
+	return a.delegate.RoundTrip(req)
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// checkpointStoreSize is the maximum number of checkpoints kept, the oldest ones are evicted first
+	checkpointStoreSize = 32
+	// checkpointTTL is the time a checkpoint is kept since its creation
+	checkpointTTL = time.Hour
+	// checkpointMaxBytes is the maximum size of a serialized checkpoint
+	checkpointMaxBytes = 4 << 20
+)
+
+// ErrorCheckpointTooLarge is returned when a checkpoint exceeds the maximum size
+var ErrorCheckpointTooLarge = errors.New("checkpoint too large")
+
+// checkpointStore keeps the serialized resource checkpoints in memory, bounded in number, size and lifetime.
+// It's shared by a Manager and all its derived clients, every checkpoint is bound to the identity of the client that
+// saved it (see derivedCacheKey) so that the checkpoints of a user can't be loaded by another one.
+type checkpointStore struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	maxBytes int
+	now      func() time.Time
+	entries  map[string]*checkpointEntry
+}
+
+type checkpointEntry struct {
+	identity string
+	data     []byte
+	created  time.Time
+	expires  time.Time
+}
+
+func newCheckpointStore(size int, ttl time.Duration, maxBytes int) *checkpointStore {
+	return &checkpointStore{
+		size:     size,
+		ttl:      ttl,
+		maxBytes: maxBytes,
+		now:      time.Now,
+		entries:  make(map[string]*checkpointEntry),
+	}
+}
+
+// save stores the checkpoint for the identity and returns its token and expiration time
+func (s *checkpointStore) save(identity string, data []byte) (string, time.Time, error) {
+	if len(data) > s.maxBytes {
+		return "", time.Time{}, fmt.Errorf("%w: %d bytes, the maximum is %d bytes", ErrorCheckpointTooLarge, len(data), s.maxBytes)
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate checkpoint token: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.removeExpired(now)
+	for len(s.entries) >= s.size {
+		s.removeOldest()
+	}
+	entry := &checkpointEntry{identity: identity, data: data, created: now, expires: now.Add(s.ttl)}
+	s.entries[hex.EncodeToString(token)] = entry
+	return hex.EncodeToString(token), entry.expires, nil
+}
+
+// load returns the checkpoint of the token, false if not found, expired or saved by another identity
+func (s *checkpointStore) load(identity, token string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[token]
+	if !ok || entry.identity != identity {
+		return nil, false
+	}
+	if !s.now().Before(entry.expires) {
+		delete(s.entries, token)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (s *checkpointStore) removeExpired(now time.Time) {
+	for token, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, token)
+		}
+	}
+}
+
+func (s *checkpointStore) removeOldest() {
+	var oldestToken string
+	var oldest *checkpointEntry
+	for token, entry := range s.entries {
+		if oldest == nil || entry.created.Before(oldest.created) {
+			oldestToken, oldest = token, entry
+		}
+	}
+	delete(s.entries, oldestToken)
+}
+
+func (s *checkpointStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckpointStoreSuite struct {
+	suite.Suite
+	now   time.Time
+	store *checkpointStore
+}
+
+func (s *CheckpointStoreSuite) SetupTest() {
+	s.now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.store = newCheckpointStore(2, time.Hour, 16)
+	s.store.now = func() time.Time { return s.now }
+}
+
+func (s *CheckpointStoreSuite) TestSaveAndLoad() {
+	token, expires, err := s.store.save("alice", []byte("state"))
+	s.Require().NoError(err)
+	s.Len(token, 32)
+	s.Equal(s.now.Add(time.Hour), expires)
+	s.Run("loads the checkpoint with the same identity", func() {
+		data, ok := s.store.load("alice", token)
+		s.True(ok)
+		s.Equal([]byte("state"), data)
+	})
+	s.Run("doesn't load the checkpoint with another identity", func() {
+		_, ok := s.store.load("bob", token)
+		s.False(ok)
+	})
+	s.Run("doesn't load unknown tokens", func() {
+		_, ok := s.store.load("alice", "unknown")
+		s.False(ok)
+	})
+}
+
+func (s *CheckpointStoreSuite) TestExpiration() {
+	token, _, err := s.store.save("alice", []byte("state"))
+	s.Require().NoError(err)
+	s.now = s.now.Add(59 * time.Minute)
+	_, ok := s.store.load("alice", token)
+	s.True(ok, "checkpoint should be available before its expiration")
+	s.now = s.now.Add(time.Minute)
+	_, ok = s.store.load("alice", token)
+	s.False(ok, "checkpoint should expire after the TTL")
+	s.Equal(0, s.store.len(), "expired checkpoint should be removed")
+}
+
+func (s *CheckpointStoreSuite) TestEvictsOldest() {
+	first, _, err := s.store.save("alice", []byte("first"))
+	s.Require().NoError(err)
+	s.now = s.now.Add(time.Second)
+	second, _, err := s.store.save("alice", []byte("second"))
+	s.Require().NoError(err)
+	s.now = s.now.Add(time.Second)
+	third, _, err := s.store.save("alice", []byte("third"))
+	s.Require().NoError(err)
+	s.Equal(2, s.store.len())
+	_, ok := s.store.load("alice", first)
+	s.False(ok, "oldest checkpoint should be evicted")
+	_, ok = s.store.load("alice", second)
+	s.True(ok)
+	_, ok = s.store.load("alice", third)
+	s.True(ok)
+}
+
+func (s *CheckpointStoreSuite) TestTooLarge() {
+	_, _, err := s.store.save("alice", []byte("more than sixteen bytes"))
+	s.ErrorIs(err, ErrorCheckpointTooLarge)
+	s.Equal(0, s.store.len())
+}
+
+func TestCheckpointStore(t *testing.T) {
+	suite.Run(t, new(CheckpointStoreSuite))
+}
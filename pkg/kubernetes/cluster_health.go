@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterHealthEndpoints are the API server health endpoints checked by ClusterComponentsHealth
+var ClusterHealthEndpoints = []string{"/readyz", "/livez"}
+
+// ClusterHealthEndpoint is the result of an API server health endpoint queried in verbose mode
+type ClusterHealthEndpoint struct {
+	// Endpoint is the path of the health endpoint (e.g. /readyz)
+	Endpoint string
+	// Healthy is true if all the checks of the endpoint passed
+	Healthy bool
+	// Checks is the number of checks performed by the endpoint
+	Checks int
+	// Failing are the failing checks and the reason (e.g. "etcd failed: reason withheld")
+	Failing []string `json:",omitempty"`
+	// Unavailable is the reason why the endpoint couldn't be queried (e.g. forbidden in managed clusters)
+	Unavailable string `json:",omitempty"`
+}
+
+// ClusterComponentsHealthResult is the result of ClusterComponentsHealth
+type ClusterComponentsHealthResult struct {
+	// Endpoints are the results of the API server health endpoints
+	Endpoints []ClusterHealthEndpoint
+	// Components is the health of the control-plane components (scheduler, controller-manager, etcd) reported by the
+	// deprecated ComponentStatus API, empty if not served
+	Components []map[string]any
+	// ComponentsUnavailable is the reason why the ComponentStatus API couldn't be queried
+	ComponentsUnavailable string
+}
+
+// ClusterComponentsHealth queries the verbose API server health endpoints (/readyz and /livez) and, where available,
+// the health of the control-plane components (ComponentStatus API).
+// Endpoints restricted by the cluster (e.g. managed clusters) are reported as unavailable instead of failing.
+func (c *Core) ClusterComponentsHealth(ctx context.Context) (*ClusterComponentsHealthResult, error) {
+	ret := &ClusterComponentsHealthResult{}
+	for _, endpoint := range ClusterHealthEndpoints {
+		ret.Endpoints = append(ret.Endpoints, c.clusterHealthEndpoint(ctx, endpoint))
+	}
+	componentStatuses, err := c.CoreV1().ComponentStatuses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		ret.ComponentsUnavailable = clusterHealthUnavailableReason(err)
+		return ret, nil
+	}
+	for _, componentStatus := range componentStatuses.Items {
+		component := map[string]any{"Name": componentStatus.Name, "Healthy": false}
+		for _, condition := range componentStatus.Conditions {
+			if condition.Type != v1.ComponentHealthy {
+				continue
+			}
+			component["Healthy"] = condition.Status == v1.ConditionTrue
+			if condition.Message != "" {
+				component["Message"] = condition.Message
+			}
+			if condition.Error != "" {
+				component["Error"] = condition.Error
+			}
+		}
+		ret.Components = append(ret.Components, component)
+	}
+	return ret, nil
+}
+
+// clusterHealthEndpoint queries the health endpoint in verbose mode and parses the individual checks
+// ([+]ping ok, [-]etcd failed: reason withheld). The body is returned along with the error when checks fail.
+func (c *Core) clusterHealthEndpoint(ctx context.Context, endpoint string) ClusterHealthEndpoint {
+	ret := ClusterHealthEndpoint{Endpoint: endpoint}
+	var statusCode int
+	result := c.DiscoveryClient().RESTClient().Get().AbsPath(endpoint).Param("verbose", "").Do(ctx).StatusCode(&statusCode)
+	body, err := result.Raw()
+	if err != nil && statusCode != http.StatusInternalServerError {
+		ret.Unavailable = clusterHealthUnavailableReason(err)
+		return ret
+	}
+	for _, line := range strings.Split(string(body), "\n") {
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			ret.Checks++
+		case strings.HasPrefix(line, "[-]"):
+			ret.Checks++
+			ret.Failing = append(ret.Failing, strings.TrimPrefix(line, "[-]"))
+		}
+	}
+	ret.Healthy = err == nil && len(ret.Failing) == 0
+	if err != nil && len(ret.Failing) == 0 {
+		ret.Failing = append(ret.Failing, err.Error())
+	}
+	return ret
+}
+
+// clusterHealthUnavailableReason describes why a health endpoint or API couldn't be queried
+func clusterHealthUnavailableReason(err error) string {
+	switch {
+	case apierrors.IsForbidden(err):
+		return fmt.Sprintf("access is restricted, as is common in managed clusters where the control plane is operated by the provider (%v)", err)
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return "not served by the API server"
+	}
+	return err.Error()
+}
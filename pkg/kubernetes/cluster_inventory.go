@@ -0,0 +1,157 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+const (
+	// clusterInventoryConcurrency bounds the number of concurrent list requests of ClusterInventory
+	clusterInventoryConcurrency = 8
+	// clusterInventoryPageSize is the page size used to count the objects of the resources that don't report the
+	// remaining item count
+	clusterInventoryPageSize = 500
+)
+
+// clusterInventoryAliases are the built-in resources served in several API groups, each alias is skipped in favor of its
+// canonical resource (when served) so that the same objects aren't counted twice
+var clusterInventoryAliases = map[schema.GroupResource]schema.GroupResource{
+	{Group: "events.k8s.io", Resource: "events"}: {Group: "", Resource: "events"},
+	{Group: "extensions", Resource: "ingresses"}: {Group: "networking.k8s.io", Resource: "ingresses"},
+}
+
+// ClusterInventoryCount is the number of objects of a kind in the cluster
+type ClusterInventoryCount struct {
+	GroupVersionKind schema.GroupVersionKind
+	Resource         string
+	Namespaced       bool
+	Count            int64
+	// Err is the error (e.g. forbidden) that prevented counting the objects of this kind
+	Err error
+}
+
+// ClusterInventoryResult is the number of objects of every kind served by the cluster
+type ClusterInventoryResult struct {
+	// Counts are sorted by decreasing count, then by group and kind
+	Counts []ClusterInventoryCount
+	// Denied are the kinds skipped because of the denied_resources configuration
+	Denied []string
+	// FailedGroups are the API groups whose discovery failed (e.g. unavailable aggregated APIs)
+	FailedGroups []string
+}
+
+// ClusterInventory counts the objects of every listable kind served by the cluster (preferred versions only, a kind served
+// in several API groups is counted once), in all the namespaces. The objects are counted with metadata-only list requests (PartialObjectMetadataList) limited to a single
+// item, the count is computed from the remaining item count reported by the API server. Resources that don't report it
+// are paged through, still metadata-only.
+// Kinds denied by the configuration are skipped, kinds that can't be listed (e.g. forbidden) are reported with their
+// error instead of failing the whole inventory.
+func (c *Core) ClusterInventory(ctx context.Context, denied api.DeniedResourcesProvider) (*ClusterInventoryResult, error) {
+	ret := &ClusterInventoryResult{}
+	resourceLists, err := c.DiscoveryClient().ServerPreferredResources()
+	var groupErr *discovery.ErrGroupDiscoveryFailed
+	if errors.As(err, &groupErr) {
+		for gv := range groupErr.Groups {
+			ret.FailedGroups = append(ret.FailedGroups, gv.String())
+		}
+		slices.Sort(ret.FailedGroups)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to discover the cluster resources: %w", err)
+	}
+	cfg := rest.CopyConfig(c.RESTConfig())
+	httpClient, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	defer httpClient.CloseIdleConnections()
+	metadataClient, err := metadata.NewForConfigAndClient(cfg, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	served := make(map[schema.GroupResource]bool)
+	for _, resourceList := range resourceLists {
+		gv, _ := schema.ParseGroupVersion(resourceList.GroupVersion)
+		for _, resource := range resourceList.APIResources {
+			served[gv.WithResource(resource.Name).GroupResource()] = true
+		}
+	}
+	for _, resourceList := range resourceLists {
+		gv, parseErr := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			if canonical, ok := clusterInventoryAliases[gv.WithResource(resource.Name).GroupResource()]; ok && served[canonical] {
+				continue
+			}
+			gvk := gv.WithKind(resource.Kind)
+			if !isResourceAllowed(denied, gvk) {
+				ret.Denied = append(ret.Denied, gvk.GroupKind().String())
+				continue
+			}
+			ret.Counts = append(ret.Counts, ClusterInventoryCount{
+				GroupVersionKind: gvk,
+				Resource:         resource.Name,
+				Namespaced:       resource.Namespaced,
+			})
+		}
+	}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(clusterInventoryConcurrency)
+	for i := range ret.Counts {
+		count := &ret.Counts[i]
+		g.Go(func() error {
+			gvr := count.GroupVersionKind.GroupVersion().WithResource(count.Resource)
+			count.Count, count.Err = clusterInventoryCount(gCtx, metadataClient.Resource(gvr))
+			// Only the cancellation of the inventory stops it, the errors of a kind are reported with its count
+			return ctx.Err()
+		})
+	}
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(ret.Counts, func(a, b ClusterInventoryCount) int {
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			strings.Compare(a.GroupVersionKind.GroupKind().String(), b.GroupVersionKind.GroupKind().String()),
+		)
+	})
+	slices.Sort(ret.Denied)
+	return ret, nil
+}
+
+// clusterInventoryCount counts the objects of the resource in all the namespaces with metadata-only list requests
+func clusterInventoryCount(ctx context.Context, client metadata.Getter) (int64, error) {
+	list, err := client.List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return 0, err
+	}
+	count := int64(len(list.Items))
+	if list.RemainingItemCount != nil {
+		return count + *list.RemainingItemCount, nil
+	}
+	for continueToken := list.Continue; continueToken != ""; continueToken = list.Continue {
+		list, err = client.List(ctx, metav1.ListOptions{Limit: clusterInventoryPageSize, Continue: continueToken})
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(list.Items))
+	}
+	return count, nil
+}
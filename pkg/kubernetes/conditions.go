@@ -0,0 +1,253 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// ConditionPolarityPositive is the polarity of conditions that are healthy when True (e.g. Ready, Available)
+	ConditionPolarityPositive = "Positive"
+	// ConditionPolarityNegative is the polarity of conditions that are healthy when False (e.g. Degraded, MemoryPressure)
+	ConditionPolarityNegative = "Negative"
+	// ConditionPolarityUnknown is the polarity of conditions whose status doesn't tell whether the resource is healthy
+	ConditionPolarityUnknown = "Unknown"
+)
+
+// ResourceConditions are the explained status.conditions of a resource
+type ResourceConditions struct {
+	APIVersion         string                 `json:"apiVersion"`
+	Kind               string                 `json:"kind"`
+	Namespace          string                 `json:"namespace,omitempty"`
+	Name               string                 `json:"name"`
+	Generation         int64                  `json:"generation,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+	Problems           int                    `json:"problems"`
+	Conditions         []ConditionExplanation `json:"conditions"`
+	NextActions        []string               `json:"nextActions,omitempty"`
+}
+
+// ConditionExplanation is a condition along with its polarity and whether it indicates a problem
+type ConditionExplanation struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+	Polarity           string `json:"polarity"`
+	Problem            bool   `json:"problem,omitempty"`
+	// Stale is set when the condition was computed for an older generation of the resource than the current one
+	Stale bool `json:"stale,omitempty"`
+}
+
+// kindConditionPolarities override the well-known polarities for the conditions whose meaning depends on the kind
+var kindConditionPolarities = map[string]map[string]string{
+	// A ClusterOperator is Progressing while rolling out a change, which is expected and not healthy when False.
+	// Upgradeable=False only blocks the minor upgrades of the cluster, the operator is still healthy.
+	"ClusterOperator": {"Progressing": ConditionPolarityUnknown, "Upgradeable": ConditionPolarityUnknown},
+	// A suspended Job is a user decision
+	"Job":     {"Suspended": ConditionPolarityUnknown},
+	"CronJob": {"Suspended": ConditionPolarityUnknown},
+}
+
+// conditionPolarities are the polarities of the well-known condition types of Kubernetes and of widespread operators
+var conditionPolarities = map[string]string{
+	// Positive
+	"Ready":                     ConditionPolarityPositive,
+	"Available":                 ConditionPolarityPositive,
+	"Progressing":               ConditionPolarityPositive,
+	"Initialized":               ConditionPolarityPositive,
+	"ContainersReady":           ConditionPolarityPositive,
+	"PodScheduled":              ConditionPolarityPositive,
+	"PodReadyToStartContainers": ConditionPolarityPositive,
+	"Established":               ConditionPolarityPositive,
+	"NamesAccepted":             ConditionPolarityPositive,
+	"Complete":                  ConditionPolarityPositive,
+	"SuccessCriteriaMet":        ConditionPolarityPositive,
+	"AbleToScale":               ConditionPolarityPositive,
+	"ScalingActive":             ConditionPolarityPositive,
+	"DisruptionAllowed":         ConditionPolarityPositive,
+	"Accepted":                  ConditionPolarityPositive,
+	"Programmed":                ConditionPolarityPositive,
+	"ResolvedRefs":              ConditionPolarityPositive,
+	"Synced":                    ConditionPolarityPositive,
+	"Reconciled":                ConditionPolarityPositive,
+	"Healthy":                   ConditionPolarityPositive,
+	"Upgradeable":               ConditionPolarityPositive,
+	"Succeeded":                 ConditionPolarityPositive,
+	"Valid":                     ConditionPolarityPositive,
+	"Issued":                    ConditionPolarityPositive,
+	"KubeletReady":              ConditionPolarityPositive,
+	"RecommendationProvided":    ConditionPolarityPositive,
+	"InstallSucceeded":          ConditionPolarityPositive,
+	// Negative
+	"Degraded":                     ConditionPolarityNegative,
+	"Failed":                       ConditionPolarityNegative,
+	"FailureTarget":                ConditionPolarityNegative,
+	"ReplicaFailure":               ConditionPolarityNegative,
+	"MemoryPressure":               ConditionPolarityNegative,
+	"DiskPressure":                 ConditionPolarityNegative,
+	"PIDPressure":                  ConditionPolarityNegative,
+	"NetworkUnavailable":           ConditionPolarityNegative,
+	"ScalingLimited":               ConditionPolarityNegative,
+	"Stalled":                      ConditionPolarityNegative,
+	"Terminating":                  ConditionPolarityNegative,
+	"DisruptionTarget":             ConditionPolarityNegative,
+	"FileSystemResizePending":      ConditionPolarityNegative,
+	"NamespaceContentRemaining":    ConditionPolarityNegative,
+	"NamespaceFinalizersRemaining": ConditionPolarityNegative,
+	"CatalogSourcesUnhealthy":      ConditionPolarityNegative,
+	"ResolutionFailed":             ConditionPolarityNegative,
+	"InstallPlanPending":           ConditionPolarityNegative,
+	"InstallPlanFailed":            ConditionPolarityNegative,
+	// Unknown, True while an operation is in progress, which is their normal transient state
+	"Reconciling": ConditionPolarityUnknown,
+	"Resizing":    ConditionPolarityUnknown,
+}
+
+// conditionSuffixPolarities are used to infer the polarity of the condition types that aren't well known
+var conditionSuffixPolarities = []struct {
+	suffix   string
+	polarity string
+}{
+	{"Failure", ConditionPolarityNegative},
+	{"Failed", ConditionPolarityNegative},
+	{"Error", ConditionPolarityNegative},
+	{"Errors", ConditionPolarityNegative},
+	{"Pressure", ConditionPolarityNegative},
+	{"Degraded", ConditionPolarityNegative},
+	{"Unavailable", ConditionPolarityNegative},
+	{"Unhealthy", ConditionPolarityNegative},
+	{"Stalled", ConditionPolarityNegative},
+	{"Pending", ConditionPolarityNegative},
+	{"Remaining", ConditionPolarityNegative},
+	{"Ready", ConditionPolarityPositive},
+	{"Available", ConditionPolarityPositive},
+	{"Healthy", ConditionPolarityPositive},
+	{"Succeeded", ConditionPolarityPositive},
+	{"Synced", ConditionPolarityPositive},
+	{"Reconciled", ConditionPolarityPositive},
+	{"Accepted", ConditionPolarityPositive},
+	{"Established", ConditionPolarityPositive},
+	{"Initialized", ConditionPolarityPositive},
+	{"Scheduled", ConditionPolarityPositive},
+	{"Valid", ConditionPolarityPositive},
+}
+
+// ConditionPolarity returns whether the condition type of the kind is healthy when True (Positive), when False (Negative),
+// or whether its status doesn't tell (Unknown)
+func ConditionPolarity(kind, conditionType string) string {
+	if polarity, ok := kindConditionPolarities[kind][conditionType]; ok {
+		return polarity
+	}
+	if polarity, ok := conditionPolarities[conditionType]; ok {
+		return polarity
+	}
+	for _, s := range conditionSuffixPolarities {
+		if strings.HasSuffix(conditionType, s.suffix) {
+			return s.polarity
+		}
+	}
+	return ConditionPolarityUnknown
+}
+
+// ExplainConditions returns the status.conditions of the resource along with their polarity and whether they indicate a problem:
+// False (or Unknown) on a positive condition, True (or Unknown) on a negative one
+func ExplainConditions(obj *unstructured.Unstructured) []ConditionExplanation {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	generation := obj.GetGeneration()
+	var ret []ConditionExplanation
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		explanation := ConditionExplanation{}
+		explanation.Type, _, _ = unstructured.NestedString(condition, "type")
+		explanation.Status, _, _ = unstructured.NestedString(condition, "status")
+		explanation.Reason, _, _ = unstructured.NestedString(condition, "reason")
+		explanation.Message, _, _ = unstructured.NestedString(condition, "message")
+		explanation.LastTransitionTime, _, _ = unstructured.NestedString(condition, "lastTransitionTime")
+		explanation.Polarity = ConditionPolarity(obj.GetKind(), explanation.Type)
+		switch explanation.Polarity {
+		case ConditionPolarityPositive:
+			explanation.Problem = explanation.Status != "True"
+		case ConditionPolarityNegative:
+			explanation.Problem = explanation.Status != "False"
+		}
+		if observedGeneration, found, _ := unstructured.NestedInt64(condition, "observedGeneration"); found && observedGeneration < generation {
+			explanation.Stale = true
+		}
+		ret = append(ret, explanation)
+	}
+	return ret
+}
+
+// ConditionsExplain returns the explained status.conditions of the resource along with the next actions to investigate its problems
+func (c *Core) ConditionsExplain(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*ResourceConditions, error) {
+	obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ResourceConditions{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+		Conditions: ExplainConditions(obj),
+	}
+	ret.ObservedGeneration, _, _ = unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	for _, condition := range ret.Conditions {
+		if condition.Problem {
+			ret.Problems++
+			ret.addNextAction(conditionNextAction(ret.Kind, condition))
+		}
+	}
+	if ret.ObservedGeneration > 0 && ret.ObservedGeneration < ret.Generation {
+		ret.addNextAction(fmt.Sprintf("The controller hasn't observed the latest generation (%d) yet (observed %d), the conditions may be outdated: "+
+			"check that the controller is running", ret.Generation, ret.ObservedGeneration))
+	}
+	return ret, nil
+}
+
+func (r *ResourceConditions) addNextAction(action string) {
+	for _, a := range r.NextActions {
+		if a == action {
+			return
+		}
+	}
+	r.NextActions = append(r.NextActions, action)
+}
+
+// conditionNextAction suggests how to investigate the problem indicated by the condition
+func conditionNextAction(kind string, condition ConditionExplanation) string {
+	switch {
+	case condition.Status == "Unknown":
+		return fmt.Sprintf("%s is Unknown, the controller responsible for the condition can't determine it (e.g. it isn't running or the Node stopped reporting)", condition.Type)
+	case kind == "Pod" && condition.Type == "PodScheduled":
+		return "Use pods_scheduling_info to find why the Pod can't be scheduled"
+	case kind == "Pod" && (condition.Type == "Ready" || condition.Type == "ContainersReady" || condition.Type == "Initialized"):
+		return "Use pods_readiness_explain to find the containers, probes or readiness gates blocking the Pod"
+	case kind == "Deployment" && condition.Type == "Progressing":
+		return "The rollout is stuck: use resources_rollout_history to compare the latest revision and check the Pods of its ReplicaSet"
+	case condition.Type == "ReplicaFailure":
+		return "Pods can't be created (e.g. quota exceeded or rejected by an admission webhook): check the events with events_list"
+	case kind == "Node" && condition.Type == "Ready":
+		return "Use nodes_log to check the kubelet logs of the Node"
+	case kind == "Node" && strings.HasSuffix(condition.Type, "Pressure"):
+		return "Use nodes_stats_summary and pods_top to find the workloads consuming the Node resources"
+	case kind == "Namespace" && strings.HasPrefix(condition.Type, "Namespace"):
+		return "Use resources_terminating to find the resources and finalizers blocking the Namespace deletion"
+	case kind == "Job" && (condition.Type == "Failed" || condition.Type == "FailureTarget"):
+		return "Use pods_log on the Pods of the Job to find why they failed"
+	case condition.Type == "Degraded":
+		return "Check the logs of the operator managing the resource to find the cause of the degradation"
+	default:
+		return "Check the reason and message of the conditions and the events of the resource with events_list"
+	}
+}
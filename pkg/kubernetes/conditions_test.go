@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ConditionsSuite struct {
+	suite.Suite
+}
+
+func (s *ConditionsSuite) TestConditionPolarity() {
+	s.Run("well-known positive condition", func() {
+		s.Equal(ConditionPolarityPositive, ConditionPolarity("Deployment", "Available"))
+	})
+	s.Run("well-known negative condition", func() {
+		s.Equal(ConditionPolarityNegative, ConditionPolarity("Node", "MemoryPressure"))
+	})
+	s.Run("kind specific polarity overrides the well-known one", func() {
+		s.Equal(ConditionPolarityPositive, ConditionPolarity("Deployment", "Progressing"))
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("ClusterOperator", "Progressing"))
+		s.Equal(ConditionPolarityPositive, ConditionPolarity("Subscription", "Upgradeable"))
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("ClusterOperator", "Upgradeable"))
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("Job", "Suspended"))
+	})
+	s.Run("in-progress conditions have no polarity", func() {
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("Kustomization", "Reconciling"))
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("PersistentVolumeClaim", "Resizing"))
+	})
+	s.Run("polarity is inferred from the suffix", func() {
+		s.Equal(ConditionPolarityNegative, ConditionPolarity("Certificate", "RenewalFailed"))
+		s.Equal(ConditionPolarityNegative, ConditionPolarity("Cluster", "EtcdPressure"))
+		s.Equal(ConditionPolarityPositive, ConditionPolarity("Cluster", "ControlPlaneReady"))
+		s.Equal(ConditionPolarityPositive, ConditionPolarity("Gateway", "ListenersAccepted"))
+	})
+	s.Run("unknown condition", func() {
+		s.Equal(ConditionPolarityUnknown, ConditionPolarity("Widget", "CustomCheck"))
+	})
+}
+
+func (s *ConditionsSuite) TestExplainConditions() {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "config.openshift.io/v1",
+		"kind":       "ClusterOperator",
+		"metadata":   map[string]interface{}{"name": "network", "generation": int64(3)},
+		"status": map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Available", "status": "False", "reason": "Starting", "message": "Not ready yet",
+				"lastTransitionTime": "2026-01-01T00:00:00Z", "observedGeneration": int64(3)},
+			map[string]interface{}{"type": "Degraded", "status": "Unknown", "observedGeneration": int64(2)},
+			map[string]interface{}{"type": "Progressing", "status": "True"},
+			map[string]interface{}{"type": "Upgradeable", "status": "False"},
+			map[string]interface{}{"type": "Reconciling", "status": "True"},
+			"not a condition",
+		}},
+	}}
+	conditions := ExplainConditions(obj)
+	s.Require().Len(conditions, 5)
+	s.Run("flags False on a positive condition", func() {
+		s.Equal(ConditionExplanation{
+			Type: "Available", Status: "False", Reason: "Starting", Message: "Not ready yet", LastTransitionTime: "2026-01-01T00:00:00Z",
+			Polarity: ConditionPolarityPositive, Problem: true,
+		}, conditions[0])
+	})
+	s.Run("flags Unknown on a negative condition", func() {
+		s.Equal(ConditionPolarityNegative, conditions[1].Polarity)
+		s.True(conditions[1].Problem)
+	})
+	s.Run("flags the conditions of an older generation as stale", func() {
+		s.False(conditions[0].Stale)
+		s.True(conditions[1].Stale)
+	})
+	s.Run("doesn't flag conditions of unknown polarity", func() {
+		for _, condition := range conditions[2:] {
+			s.Equal(ConditionPolarityUnknown, condition.Polarity, condition.Type)
+			s.False(condition.Problem, condition.Type)
+		}
+	})
+	s.Run("resource without conditions", func() {
+		s.Empty(ExplainConditions(&unstructured.Unstructured{Object: map[string]interface{}{"kind": "ConfigMap"}}))
+	})
+}
+
+func TestConditions(t *testing.T) {
+	suite.Run(t, new(ConditionsSuite))
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// configArchiveGeneratedConfigMaps are the ConfigMaps published by the cluster in every namespace
+var configArchiveGeneratedConfigMaps = []string{"kube-root-ca.crt", "openshift-service-ca.crt"}
+
+// configArchiveGeneratedSecretTypes are the types of the Secrets generated by the cluster that can't be restored
+var configArchiveGeneratedSecretTypes = []string{"kubernetes.io/service-account-token"}
+
+// ConfigArchiveExport returns the ConfigMaps (and the Secrets if includeSecrets is true) of the namespace cleaned of
+// cluster-specific fields (status, uid, resourceVersion, managedFields...) and of their namespace so that they can be
+// restored to any namespace with ConfigArchiveImport.
+// The resources generated by the cluster (e.g. kube-root-ca.crt, ServiceAccount token Secrets) are skipped.
+func (c *Core) ConfigArchiveExport(ctx context.Context, namespace string, includeSecrets bool) ([]*unstructured.Unstructured, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	kinds := []string{"ConfigMap"}
+	if includeSecrets {
+		kinds = append(kinds, "Secret")
+	}
+	var ret []*unstructured.Unstructured
+	for _, kind := range kinds {
+		gvr, err := c.resourceFor(&schema.GroupVersionKind{Version: "v1", Kind: kind})
+		if err != nil {
+			return nil, err
+		}
+		list, err := c.DynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+			if (kind == "ConfigMap" && slices.Contains(configArchiveGeneratedConfigMaps, obj.GetName())) ||
+				(kind == "Secret" && slices.Contains(configArchiveGeneratedSecretTypes, secretType)) {
+				continue
+			}
+			// The list items may lack apiVersion and kind, required to restore them from the archive
+			obj.SetAPIVersion("v1")
+			obj.SetKind(kind)
+			cleaned := cleanManifest(obj, "")
+			unstructured.RemoveNestedField(cleaned.Object, "metadata", "namespace")
+			ret = append(ret, cleaned)
+		}
+	}
+	return ret, nil
+}
+
+// ConfigArchiveImport creates or updates the ConfigMaps and Secrets of the provided multi-document YAML archive
+// (see ConfigArchiveExport) in the provided namespace (or the default one), rewriting the namespace of every
+// resource in the archive. Archives containing resources other than ConfigMaps and Secrets are rejected.
+func (c *Core) ConfigArchiveImport(ctx context.Context, archive, namespace string) ([]*unstructured.Unstructured, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	resources, _, err := c.parseResources(archive, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*unstructured.Unstructured
+	for _, obj := range resources {
+		if len(obj.Object) == 0 {
+			// empty documents (e.g. trailing separator)
+			continue
+		}
+		if obj.GetAPIVersion() != "v1" || (obj.GetKind() != "ConfigMap" && obj.GetKind() != "Secret") {
+			return nil, fmt.Errorf("unsupported resource %s %s %s in archive, only v1 ConfigMaps and Secrets can be imported", obj.GetAPIVersion(), obj.GetKind(), obj.GetName())
+		}
+		obj.SetNamespace(namespace)
+		ret = append(ret, obj)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("the archive contains no ConfigMaps or Secrets")
+	}
+	return c.resourcesCreateOrUpdate(ctx, ret, version.BinaryName)
+}
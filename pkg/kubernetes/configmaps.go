@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// ConfigMapsSetKey sets (or removes if remove is true) a single data key of a ConfigMap by using a JSON merge patch.
+// The rest of the ConfigMap is left untouched.
+func (c *Core) ConfigMapsSetKey(ctx context.Context, namespace, name, key, value string, remove bool) (*unstructured.Unstructured, error) {
+	gvr, err := c.resourceFor(&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"})
+	if err != nil {
+		return nil, err
+	}
+	var data any = value
+	if remove {
+		// A null value removes the key when using JSON merge patch (RFC 7386)
+		data = nil
+	}
+	patch, err := json.Marshal(map[string]any{"data": map[string]any{key: data}})
+	if err != nil {
+		return nil, err
+	}
+	return c.DynamicClient().Resource(*gvr).Namespace(c.NamespaceOrDefault(namespace)).
+		Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: version.BinaryName})
+}
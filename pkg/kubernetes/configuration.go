@@ -1,6 +1,10 @@
 package kubernetes
 
 import (
+	"net/url"
+	"path/filepath"
+	"sort"
+
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -8,7 +12,28 @@ import (
 	"k8s.io/client-go/tools/clientcmd/api/latest"
 )
 
-const inClusterKubeConfigDefaultContext = "in-cluster"
+const (
+	inClusterKubeConfigDefaultContext = "in-cluster"
+	// inClusterKubeConfigOrigin is the origin of the synthetic kubeconfig context of the in-cluster deployments
+	inClusterKubeConfigOrigin = "in-cluster service account"
+)
+
+// ContextDescription is the redacted description of a kubeconfig context, it never includes credentials
+type ContextDescription struct {
+	Name      string
+	Current   bool
+	Cluster   string
+	Server    string
+	Namespace string
+	User      string
+	// AuthMethods are the authentication methods configured for the user (token, token-file, client-certificate,
+	// basic, exec: <command> or auth-provider: <name>), none if there are no credentials
+	AuthMethods []string
+	// Source is the kubeconfig file that defines the context
+	Source string
+	// InCluster is true for the synthetic context of the in-cluster deployments (service account of the Pod)
+	InCluster bool
+}
 
 // InClusterConfig is a variable that holds the function to get the in-cluster config
 // Exposed for testing
@@ -60,6 +85,76 @@ func (c *Core) ConfigurationContextsList() (map[string]string, error) {
 	return contexts, nil
 }
 
+// ConfigurationContextsDescribe returns the redacted description of the kubeconfig contexts sorted by name: the
+// cluster server, namespace, user and authentication method of each context, without exposing tokens, passwords,
+// keys or exec arguments.
+// In-cluster deployments report a single synthetic context for the service account of the Pod.
+func (c *Core) ConfigurationContextsDescribe() ([]ContextDescription, error) {
+	cfg, err := c.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	descriptions := make([]ContextDescription, 0, len(cfg.Contexts))
+	for name, context := range cfg.Contexts {
+		description := ContextDescription{
+			Name:        name,
+			Current:     name == cfg.CurrentContext,
+			Cluster:     context.Cluster,
+			Server:      "unknown",
+			Namespace:   context.Namespace,
+			User:        context.AuthInfo,
+			AuthMethods: []string{"unknown"},
+			Source:      context.LocationOfOrigin,
+			InCluster:   context.LocationOfOrigin == inClusterKubeConfigOrigin,
+		}
+		if cluster, ok := cfg.Clusters[context.Cluster]; ok && cluster.Server != "" {
+			description.Server = redactServer(cluster.Server)
+		}
+		if authInfo, ok := cfg.AuthInfos[context.AuthInfo]; ok {
+			description.AuthMethods = authMethods(authInfo)
+		}
+		descriptions = append(descriptions, description)
+	}
+	sort.Slice(descriptions, func(i, j int) bool { return descriptions[i].Name < descriptions[j].Name })
+	return descriptions, nil
+}
+
+// redactServer removes the password of the server URL user info, if any
+func redactServer(server string) string {
+	u, err := url.Parse(server)
+	if err != nil || u.User == nil {
+		return server
+	}
+	return u.Redacted()
+}
+
+// authMethods returns the names of the authentication methods of the kubeconfig user, never their values
+func authMethods(authInfo *clientcmdapi.AuthInfo) []string {
+	var methods []string
+	if authInfo.Exec != nil {
+		methods = append(methods, "exec: "+filepath.Base(authInfo.Exec.Command))
+	}
+	if authInfo.AuthProvider != nil {
+		methods = append(methods, "auth-provider: "+authInfo.AuthProvider.Name)
+	}
+	if len(authInfo.ClientCertificateData) > 0 || authInfo.ClientCertificate != "" {
+		methods = append(methods, "client-certificate")
+	}
+	if authInfo.Token != "" {
+		methods = append(methods, "token")
+	}
+	if authInfo.TokenFile != "" {
+		methods = append(methods, "token-file")
+	}
+	if authInfo.Username != "" || authInfo.Password != "" {
+		methods = append(methods, "basic")
+	}
+	if len(methods) == 0 {
+		methods = append(methods, "none")
+	}
+	return methods
+}
+
 // ConfigurationView returns the current kubeconfig content as a kubeconfig YAML
 // If minify is true, keeps only the current-context and the relevant pieces of the configuration for that context.
 // If minify is false, all contexts, clusters, auth-infos, and users are returned in the configuration.
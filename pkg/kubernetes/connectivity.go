@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	ConnectivityProtocolTCP  = "tcp"
+	ConnectivityProtocolHTTP = "http"
+
+	ConnectivityReachable   = "Reachable"
+	ConnectivityUnreachable = "Unreachable"
+	// ConnectivityUnknown is the result when the probe couldn't run in the source container (e.g. no shell or no probe tool)
+	ConnectivityUnknown = "Unknown"
+
+	// connectivityProbeExitCode prefixes the exit code of the probe in its output, the probe script always succeeds so
+	// that its output (and the reason of a failure) can be read
+	connectivityProbeExitCode = "connectivity-test-exit-code="
+	// connectivityProbeUnavailable is the exit code of the probe when no probe tool is available in the container
+	connectivityProbeUnavailable = 127
+
+	// DefaultConnectivityTestTimeout is the default timeout of the connectivity probe
+	DefaultConnectivityTestTimeout = 5 * time.Second
+)
+
+// connectivityProbes are the sh scripts probing the target ($1 host or URL, $2 port, $3 timeout in seconds) with the
+// tools commonly available in the container images, the target is passed as arguments and never interpolated.
+// Any HTTP response means the target is reachable: wget exits with an error on 4xx and 5xx responses, so its status
+// line is extracted to report the same output and exit code as curl.
+var connectivityProbes = map[string]string{
+	ConnectivityProtocolTCP: `(if command -v nc >/dev/null 2>&1; then nc -z -w "$3" "$1" "$2"; ` +
+		`elif command -v bash >/dev/null 2>&1; then timeout "$3" bash -c 'echo > "/dev/tcp/$0/$1"' "$1" "$2"; ` +
+		`else echo "neither nc nor bash is available in the container" >&2; exit 127; fi); echo "` + connectivityProbeExitCode + `$?"`,
+	ConnectivityProtocolHTTP: `(if command -v curl >/dev/null 2>&1; then curl -sS -o /dev/null --max-time "$3" -w "HTTP %{http_code}\n" "$1"; ` +
+		`elif command -v wget >/dev/null 2>&1; then out=$(wget -S -q -T "$3" -O /dev/null "$1" 2>&1); rc=$?; ` +
+		`status=$(echo "$out" | sed -n 's/.*HTTP\/[0-9.]* \([0-9][0-9]*\).*/HTTP \1/p' | tail -n 1); ` +
+		`if [ -n "$status" ]; then echo "$status"; else echo "$out" >&2; exit $rc; fi; ` +
+		`else echo "neither curl nor wget is available in the container" >&2; exit 127; fi); echo "` + connectivityProbeExitCode + `$?"`,
+}
+
+var connectivityProbeExitCodeRegexp = regexp.MustCompile(connectivityProbeExitCode + `(\d+)`)
+
+// ConnectivityTestOptions is the source and target of a connectivity test
+type ConnectivityTestOptions struct {
+	Namespace string
+	Pod       string
+	// Container of the source Pod where the probe runs (the default container if empty)
+	Container string
+	// Target is pod/<name>, service/<name> or a host (host:port)
+	Target string
+	// TargetNamespace of a pod/<name> or service/<name> target (the source namespace if empty)
+	TargetNamespace string
+	// Port of the target (optional for host:port targets)
+	Port     int32
+	Protocol string
+	// Timeout of the probe, rounded up to the second (DefaultConnectivityTestTimeout if zero)
+	Timeout time.Duration
+}
+
+// ConnectivityTestResult is the result of a connectivity test with, if the target wasn't reached, the NetworkPolicies
+// that apply to the egress of the source Pod and to the ingress of the target Pod
+type ConnectivityTestResult struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Address  string `json:"address"`
+	Protocol string `json:"protocol"`
+	// Result is Reachable, Unreachable or Unknown (the probe couldn't run)
+	Result string `json:"result"`
+	// Output is the output of the probe (e.g. the HTTP status code or the connection error)
+	Output string `json:"output,omitempty"`
+	// TargetPod is the Pod whose ingress NetworkPolicies are analyzed (a backend Pod for a Service target)
+	TargetPod     string         `json:"targetPod,omitempty"`
+	SourceEgress  map[string]any `json:"sourceEgress,omitempty"`
+	TargetIngress map[string]any `json:"targetIngress,omitempty"`
+	// Notes are the hints to interpret the result
+	Notes []string `json:"notes,omitempty"`
+}
+
+// ConnectivityTest probes the connectivity from a Pod to a Pod, a Service or a host by running a TCP (nc or bash) or
+// HTTP (curl or wget) check in the source container.
+// If the target isn't reached, the NetworkPolicies analysis (see NetworkPoliciesAnalyze) of the egress of the source
+// Pod and of the ingress of the target Pod (or of a backend Pod of the target Service) is included to explain why.
+func (c *Core) ConnectivityTest(ctx context.Context, options ConnectivityTestOptions) (*ConnectivityTestResult, error) {
+	options.Namespace = c.NamespaceOrDefault(options.Namespace)
+	options.TargetNamespace = cmp.Or(options.TargetNamespace, options.Namespace)
+	probe, ok := connectivityProbes[options.Protocol]
+	if !ok {
+		return nil, fmt.Errorf("unsupported protocol %q, supported protocols are %s and %s", options.Protocol, ConnectivityProtocolTCP, ConnectivityProtocolHTTP)
+	}
+	ret := &ConnectivityTestResult{
+		Source:   options.Namespace + "/" + options.Pod,
+		Target:   options.Target,
+		Protocol: options.Protocol,
+	}
+	host, port, targetPod, err := c.connectivityTarget(ctx, &options, ret)
+	if err != nil {
+		return nil, err
+	}
+	ret.Address = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	ret.TargetPod = targetPod
+	probeTarget := host
+	if options.Protocol == ConnectivityProtocolHTTP {
+		probeTarget = "http://" + ret.Address + "/"
+	}
+	timeoutSeconds := max(int64(math.Ceil(cmp.Or(options.Timeout, DefaultConnectivityTestTimeout).Seconds())), 1)
+	command := []string{"sh", "-c", probe, "sh", probeTarget, strconv.Itoa(int(port)), strconv.FormatInt(timeoutSeconds, 10)}
+	stdout, stderr, err := c.PodsExec(ctx, options.Namespace, options.Pod, options.Container, command)
+	ret.Output = strings.TrimSpace(connectivityProbeExitCodeRegexp.ReplaceAllString(strings.TrimSpace(stdout+"\n"+stderr), ""))
+	match := connectivityProbeExitCodeRegexp.FindStringSubmatch(stdout)
+	switch {
+	case err != nil:
+		ret.Result = ConnectivityUnknown
+		ret.Output = strings.TrimSpace(ret.Output + "\n" + err.Error())
+		ret.Notes = append(ret.Notes, "The probe couldn't run in the source container, it requires sh and nc or bash (tcp), or curl or wget (http). "+
+			"Images without a shell (e.g. distroless) can be probed with an ephemeral debug container")
+	case match == nil || match[1] == strconv.Itoa(connectivityProbeUnavailable):
+		ret.Result = ConnectivityUnknown
+		ret.Notes = append(ret.Notes, "No probe tool is available in the source container, it requires nc or bash (tcp), or curl or wget (http)")
+	case match[1] == "0":
+		ret.Result = ConnectivityReachable
+	default:
+		ret.Result = ConnectivityUnreachable
+	}
+	if ret.Result == ConnectivityReachable {
+		return ret, nil
+	}
+	// The target isn't reached (or the probe couldn't tell), the NetworkPolicies explain if the traffic is blocked
+	source, err := c.NetworkPoliciesAnalyze(ctx, options.Namespace, options.Pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze the network policies of pod %s: %w", options.Pod, err)
+	}
+	ret.SourceEgress, _ = source["Egress"].(map[string]any)
+	if targetPod != "" {
+		target, err := c.NetworkPoliciesAnalyze(ctx, options.TargetNamespace, targetPod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze the network policies of pod %s: %w", targetPod, err)
+		}
+		ret.TargetIngress, _ = target["Ingress"].(map[string]any)
+	}
+	if isolated, _ := ret.SourceEgress["Isolated"].(bool); isolated {
+		ret.Notes = append(ret.Notes, "The egress of the source Pod is restricted by NetworkPolicies, the target must match one of the allowed egress rules (DNS must be allowed too for a host name)")
+	}
+	if isolated, _ := ret.TargetIngress["Isolated"].(bool); isolated {
+		ret.Notes = append(ret.Notes, "The ingress of the target Pod is restricted by NetworkPolicies, the source Pod must match one of the allowed ingress rules")
+	}
+	if ret.Result == ConnectivityUnreachable && ret.SourceEgress["Isolated"] != true && ret.TargetIngress["Isolated"] != true {
+		ret.Notes = append(ret.Notes, "No NetworkPolicy restricts this traffic, check that the target is listening on the port and is ready, "+
+			"and the policies outside of the Kubernetes NetworkPolicies (e.g. the CNI specific policies or the firewalls)")
+	}
+	return ret, nil
+}
+
+// connectivityTarget resolves the host and port to probe and the Pod whose ingress applies (none for a host target)
+func (c *Core) connectivityTarget(ctx context.Context, options *ConnectivityTestOptions, ret *ConnectivityTestResult) (string, int32, string, error) {
+	kind, name, found := strings.Cut(options.Target, "/")
+	switch {
+	case found && (kind == "pod" || kind == "pods"):
+		pod, err := c.CoreV1().Pods(options.TargetNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, "", err
+		}
+		if pod.Status.PodIP == "" {
+			return "", 0, "", fmt.Errorf("pod %s has no IP address (phase %s)", name, pod.Status.Phase)
+		}
+		if options.Port == 0 {
+			return "", 0, "", fmt.Errorf("port is required for a pod target")
+		}
+		return pod.Status.PodIP, options.Port, pod.Name, nil
+	case found && (kind == "service" || kind == "services" || kind == "svc"):
+		service, err := c.CoreV1().Services(options.TargetNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, "", err
+		}
+		port := options.Port
+		if port == 0 {
+			if len(service.Spec.Ports) != 1 {
+				return "", 0, "", fmt.Errorf("port is required for service %s with %d ports", name, len(service.Spec.Ports))
+			}
+			port = service.Spec.Ports[0].Port
+		}
+		// Headless Services have no ClusterIP, their DNS name resolves to the Pod IPs
+		host := service.Spec.ClusterIP
+		if host == "" || host == v1.ClusterIPNone {
+			host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+		}
+		return host, port, c.connectivityServiceBackend(ctx, service, ret), nil
+	case found:
+		return "", 0, "", fmt.Errorf("unsupported target %q, the target must be pod/<name>, service/<name> or a host (host:port)", options.Target)
+	}
+	host, port := options.Target, options.Port
+	if h, p, err := net.SplitHostPort(options.Target); err == nil {
+		parsed, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("invalid port in target %q: %w", options.Target, err)
+		}
+		host, port = h, int32(parsed)
+	}
+	if port == 0 {
+		return "", 0, "", fmt.Errorf("port is required for host target %s", options.Target)
+	}
+	// The host is passed as an argument to nc, curl or wget, which would parse it as an option
+	if strings.HasPrefix(host, "-") {
+		return "", 0, "", fmt.Errorf("invalid host %q in target %q", host, options.Target)
+	}
+	return host, port, "", nil
+}
+
+// connectivityServiceBackend returns a Pod selected by the Service (a running one if any) whose ingress
+// NetworkPolicies apply to the traffic sent to the Service
+func (c *Core) connectivityServiceBackend(ctx context.Context, service *v1.Service, ret *ConnectivityTestResult) string {
+	if len(service.Spec.Selector) == 0 {
+		ret.Notes = append(ret.Notes, fmt.Sprintf("Service %s has no selector, the ingress NetworkPolicies of its backends aren't analyzed", service.Name))
+		return ""
+	}
+	pods, err := c.CoreV1().Pods(service.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		ret.Notes = append(ret.Notes, fmt.Sprintf("The Pods of Service %s couldn't be listed, the ingress NetworkPolicies of its backends aren't analyzed: %v", service.Name, err))
+		return ""
+	}
+	if len(pods.Items) == 0 {
+		ret.Notes = append(ret.Notes, fmt.Sprintf("Service %s selects no Pods, the traffic sent to the Service has no backend", service.Name))
+		return ""
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == v1.PodRunning {
+			return pod.Name
+		}
+	}
+	return pods.Items[0].Name
+}
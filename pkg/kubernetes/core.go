@@ -1,6 +1,11 @@
 package kubernetes
 
-import "github.com/containers/kubernetes-mcp-server/pkg/api"
+import (
+	"errors"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
 
 type Core struct {
 	api.KubernetesClient
@@ -11,3 +16,46 @@ func NewCore(client api.KubernetesClient) *Core {
 		KubernetesClient: client,
 	}
 }
+
+// client returns the Kubernetes client behind the handler params (nil for other api.KubernetesClient
+// implementations), it holds the server-side state shared with the Manager (watch slots and checkpoints)
+func (c *Core) client() *Kubernetes {
+	client := c.KubernetesClient
+	for {
+		switch v := client.(type) {
+		case *Kubernetes:
+			return v
+		case api.ToolHandlerParams:
+			client = v.KubernetesClient
+		case api.PromptHandlerParams:
+			client = v.KubernetesClient
+		case api.ResourceTemplateHandlerParams:
+			client = v.KubernetesClient
+		default:
+			return nil
+		}
+	}
+}
+
+// acquireWatch reserves one of the concurrent watch slots of the cluster (see Kubernetes.AcquireWatch), the watches
+// of other api.KubernetesClient implementations are unlimited
+func (c *Core) acquireWatch() (func(), error) {
+	if k := c.client(); k != nil {
+		return k.AcquireWatch()
+	}
+	return func() {}, nil
+}
+
+func (c *Core) saveCheckpoint(data []byte) (string, time.Time, error) {
+	if k := c.client(); k != nil {
+		return k.saveCheckpoint(data)
+	}
+	return "", time.Time{}, errors.New("checkpoints are not supported by this client")
+}
+
+func (c *Core) loadCheckpoint(token string) ([]byte, bool) {
+	if k := c.client(); k != nil {
+		return k.loadCheckpoint(token)
+	}
+	return nil, false
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// DefaultCustomResourcesQueryLimit is the default maximum number of resources returned by CustomResourcesQuery
+	DefaultCustomResourcesQueryLimit = 100
+	// MaxCustomResourcesQueryLimit caps the number of resources returned by CustomResourcesQuery
+	MaxCustomResourcesQueryLimit = 500
+	// customResourcesQueryPageSize is the number of resources retrieved from the Kubernetes API per list request
+	customResourcesQueryPageSize = 500
+)
+
+// QueryFilter is a parsed JSONPath predicate (e.g. .status.phase==Failed) evaluated against every listed resource
+type QueryFilter struct {
+	// JSONPath expression to evaluate (e.g. {.status.phase})
+	JSONPath string
+	// Operator is one of ==, != or empty (the JSONPath must resolve to a non-empty value)
+	Operator string
+	// Value to compare the JSONPath results with
+	Value string
+}
+
+// ParseQueryFilter parses a <jsonpath>==<value>, <jsonpath>!=<value> or <jsonpath> predicate.
+// The JSONPath may be enclosed in braces, which is required for expressions containing operators
+// (e.g. {.status.conditions[?(@.type=="Ready")].status}==False).
+func ParseQueryFilter(expression string) (*QueryFilter, error) {
+	path, rest := expression, ""
+	if strings.HasPrefix(path, "{") {
+		if end := strings.Index(path, "}"); end > 0 {
+			path, rest = path[:end+1], path[end+1:]
+		}
+	} else if i := strings.IndexAny(path, "=!<>"); i >= 0 {
+		path, rest = "{"+path[:i]+"}", path[i:]
+	} else {
+		path = "{" + path + "}"
+	}
+	filter := &QueryFilter{JSONPath: path}
+	switch {
+	case rest == "":
+	case strings.HasPrefix(rest, "=="), strings.HasPrefix(rest, "!="):
+		filter.Operator, filter.Value = rest[:2], rest[2:]
+	default:
+		return nil, fmt.Errorf("invalid filter %s: expected <jsonpath>==<value>, <jsonpath>!=<value> or <jsonpath>", expression)
+	}
+	if err := jsonpath.New("filter").Parse(filter.JSONPath); err != nil || filter.JSONPath == "{}" {
+		return nil, fmt.Errorf("invalid filter %s: invalid jsonpath %s", expression, filter.JSONPath)
+	}
+	return filter, nil
+}
+
+// Matches evaluates the predicate against the provided object.
+// For JSONPath expressions resolving to multiple values (e.g. .spec.containers[*].image), == matches if any of them
+// is equal to the value and != matches if none of them is.
+func (f *QueryFilter) Matches(obj *unstructured.Unstructured) (bool, error) {
+	path := jsonpath.New("filter").AllowMissingKeys(true)
+	if err := path.Parse(f.JSONPath); err != nil {
+		return false, err
+	}
+	results, err := path.FindResults(obj.Object)
+	if err != nil {
+		return false, err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.IsValid() && value.CanInterface() && value.Interface() != nil {
+				values = append(values, fmt.Sprint(value.Interface()))
+			}
+		}
+	}
+	switch f.Operator {
+	case "==":
+		for _, value := range values {
+			if value == f.Value {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "!=":
+		for _, value := range values {
+			if value == f.Value {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	for _, value := range values {
+		if value != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CustomResourcesQueryResult is the outcome of CustomResourcesQuery
+type CustomResourcesQueryResult struct {
+	// Resource is the fully qualified resource that was queried (the provided one may be partial, e.g. singular)
+	Resource schema.GroupVersionResource
+	// Items are the resources matching the query (at most the requested limit)
+	Items *unstructured.UnstructuredList
+	// Scanned is the number of resources evaluated against the filter
+	Scanned int
+	// Truncated is true if more resources matched the query than the returned ones
+	Truncated bool
+}
+
+// CustomResourcesQuery lists the resources of the provided (possibly partial, e.g. without version) group/version/resource
+// in the provided namespace (all namespaces if empty or if the resource is cluster scoped) matching the label selector
+// and the optional filter. At most limit resources are returned (capped to MaxCustomResourcesQueryLimit).
+// Resources are listed in pages so that the filter can be evaluated on large collections.
+func (c *Core) CustomResourcesQuery(ctx context.Context, gvr schema.GroupVersionResource, namespace, labelSelector string, filter *QueryFilter, limit int) (*CustomResourcesQueryResult, error) {
+	if limit <= 0 {
+		limit = DefaultCustomResourcesQueryLimit
+	}
+	limit = min(limit, MaxCustomResourcesQueryLimit)
+	resolved, err := c.RESTMapper().ResourceFor(gvr)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := c.RESTMapper().KindFor(resolved)
+	if err != nil {
+		return nil, err
+	}
+	if namespaced, nsErr := c.isNamespaced(&gvk); nsErr != nil || !namespaced {
+		namespace = ""
+	}
+	ret := &CustomResourcesQueryResult{
+		Resource: resolved,
+		Items:    &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "List"}},
+	}
+	ri := c.DynamicClient().Resource(resolved).Namespace(namespace)
+	options := metav1.ListOptions{LabelSelector: labelSelector, Limit: customResourcesQueryPageSize}
+	for {
+		list, listErr := ri.List(ctx, options)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for i := range list.Items {
+			ret.Scanned++
+			if filter != nil {
+				matches, matchErr := filter.Matches(&list.Items[i])
+				if matchErr != nil {
+					return nil, fmt.Errorf("failed to evaluate filter on %s %s: %w", gvk.Kind, list.Items[i].GetName(), matchErr)
+				}
+				if !matches {
+					continue
+				}
+			}
+			if len(ret.Items.Items) == limit {
+				ret.Truncated = true
+				return ret, nil
+			}
+			ret.Items.Items = append(ret.Items.Items, list.Items[i])
+		}
+		if options.Continue = list.GetContinue(); options.Continue == "" {
+			return ret, nil
+		}
+	}
+}
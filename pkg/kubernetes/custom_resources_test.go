@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type CustomResourcesSuite struct {
+	suite.Suite
+}
+
+func (s *CustomResourcesSuite) TestParseQueryFilter() {
+	s.Run("equality without braces", func() {
+		filter, err := ParseQueryFilter(".status.phase==Failed")
+		s.Require().NoError(err)
+		s.Equal(&QueryFilter{JSONPath: "{.status.phase}", Operator: "==", Value: "Failed"}, filter)
+	})
+	s.Run("inequality with braces", func() {
+		filter, err := ParseQueryFilter(`{.status.conditions[?(@.type=="Ready")].status}!=True`)
+		s.Require().NoError(err)
+		s.Equal(&QueryFilter{JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Operator: "!=", Value: "True"}, filter)
+	})
+	s.Run("empty value", func() {
+		filter, err := ParseQueryFilter(".spec.nodeName==")
+		s.Require().NoError(err)
+		s.Equal(&QueryFilter{JSONPath: "{.spec.nodeName}", Operator: "=="}, filter)
+	})
+	s.Run("existence", func() {
+		filter, err := ParseQueryFilter(".metadata.deletionTimestamp")
+		s.Require().NoError(err)
+		s.Equal(&QueryFilter{JSONPath: "{.metadata.deletionTimestamp}"}, filter)
+	})
+	s.Run("invalid expressions return error", func() {
+		for _, expression := range []string{"", "{}", "==Failed", ".status.phase=Failed", ".status.phase>1", "{.status[}==x"} {
+			_, err := ParseQueryFilter(expression)
+			s.Errorf(err, "expected error for %q", expression)
+		}
+	})
+}
+
+func (s *CustomResourcesSuite) TestQueryFilterMatches() {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "a-resource"},
+		"spec": map[string]interface{}{
+			"suspend":  true,
+			"replicas": int64(3),
+			"containers": []interface{}{
+				map[string]interface{}{"image": "nginx:1"},
+				map[string]interface{}{"image": "busybox"},
+			},
+		},
+		"status": map[string]interface{}{"phase": "Failed"},
+	}}
+	for expression, expected := range map[string]bool{
+		".status.phase==Failed":                true,
+		".status.phase==Running":               false,
+		".status.phase!=Running":               true,
+		".spec.suspend==true":                  true,
+		".spec.replicas==3":                    true,
+		".spec.containers[*].image==busybox":   true,
+		".spec.containers[*].image!=busybox":   false,
+		".spec.containers[*].image==alpine":    false,
+		".status.missing==Failed":              false,
+		".status.missing!=Failed":              true,
+		".status.phase":                        true,
+		".status.missing":                      false,
+		"{.metadata.name}==a-resource":         true,
+		"{.spec.containers[0].image}==nginx:1": true,
+		"{.spec.containers[1].image}!=busybox": false,
+		"{.spec.containers[*].image}==nginx:1": true,
+	} {
+		s.Run(expression, func() {
+			filter, err := ParseQueryFilter(expression)
+			s.Require().NoError(err)
+			matches, err := filter.Matches(obj)
+			s.Require().NoError(err)
+			s.Equal(expected, matches)
+		})
+	}
+}
+
+func TestCustomResources(t *testing.T) {
+	suite.Run(t, new(CustomResourcesSuite))
+}
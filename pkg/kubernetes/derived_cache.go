@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// derivedCacheSize is the maximum number of derived clients (identities) kept in the cache
+	derivedCacheSize = 64
+	// derivedCacheTTL is the maximum time a derived client is reused since its creation
+	derivedCacheTTL = 5 * time.Minute
+)
+
+// derivedCache is an LRU cache with TTL of the derived (per-request auth header) Kubernetes clients.
+// Entries are keyed by a hash of the auth material so that repeated calls from the same identity reuse the
+// clients, transports and discovery caches, while credentials are never kept or logged as cache keys.
+type derivedCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type derivedCacheEntry struct {
+	key        string
+	kubernetes *Kubernetes
+	expires    time.Time
+}
+
+func newDerivedCache(size int, ttl time.Duration) *derivedCache {
+	return &derivedCache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// derivedCacheKey returns the cache key for the provided auth material (e.g. server and bearer token)
+func derivedCacheKey(authMaterial ...string) string {
+	h := sha256.New()
+	for _, m := range authMaterial {
+		h.Write([]byte(m))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached client for the key or nil if not found or expired
+func (c *derivedCache) get(key string) *Kubernetes {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*derivedCacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.removeElement(element)
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return entry.kubernetes
+}
+
+// add caches the client for the key, evicting the least recently used entries if the cache is full
+func (c *derivedCache) add(key string, k *Kubernetes) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+	c.entries[key] = c.lru.PushFront(&derivedCacheEntry{key: key, kubernetes: k, expires: c.now().Add(c.ttl)})
+	for c.lru.Len() > c.size {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// remove invalidates the cached client for the key (e.g. the credentials are no longer valid)
+func (c *derivedCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// close invalidates all the cached clients
+func (c *derivedCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.lru.Len() > 0 {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+func (c *derivedCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// removeElement removes the entry and releases its idle connections, in-flight requests are not affected
+func (c *derivedCache) removeElement(element *list.Element) {
+	entry := c.lru.Remove(element).(*derivedCacheEntry)
+	delete(c.entries, entry.key)
+	entry.kubernetes.close()
+}
+
+// unauthorizedRoundTripper notifies when the Kube API rejects the credentials (401 Unauthorized)
+type unauthorizedRoundTripper struct {
+	delegate       http.RoundTripper
+	onUnauthorized func()
+}
+
+var _ http.RoundTripper = &unauthorizedRoundTripper{}
+
+func (u *unauthorizedRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return u.delegate
+}
+
+func (u *unauthorizedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := u.delegate.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		u.onUnauthorized()
+	}
+	return resp, err
+}
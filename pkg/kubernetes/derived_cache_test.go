@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DerivedCacheSuite struct {
+	suite.Suite
+}
+
+func (s *DerivedCacheSuite) TestKey() {
+	s.Run("is stable for the same auth material", func() {
+		s.Equal(derivedCacheKey("https://cluster", "a-token"), derivedCacheKey("https://cluster", "a-token"))
+	})
+	s.Run("differs for different auth material", func() {
+		s.NotEqual(derivedCacheKey("https://cluster", "a-token"), derivedCacheKey("https://cluster", "another-token"))
+		s.NotEqual(derivedCacheKey("https://cluster", "a-token"), derivedCacheKey("https://another-cluster", "a-token"))
+		s.NotEqual(derivedCacheKey("ab", "c"), derivedCacheKey("a", "bc"))
+	})
+	s.Run("doesn't contain the credentials", func() {
+		s.NotContains(derivedCacheKey("https://cluster", "a-secret-token"), "a-secret-token")
+	})
+}
+
+func (s *DerivedCacheSuite) TestLRU() {
+	cache := newDerivedCache(2, time.Minute)
+	first, second, third := &Kubernetes{}, &Kubernetes{}, &Kubernetes{}
+	cache.add("first", first)
+	cache.add("second", second)
+	s.Same(first, cache.get("first"), "expected first to be cached")
+	cache.add("third", third)
+	s.Run("evicts the least recently used entry when full", func() {
+		s.Equal(2, cache.len())
+		s.Nil(cache.get("second"))
+	})
+	s.Run("keeps the recently used entries", func() {
+		s.Same(first, cache.get("first"))
+		s.Same(third, cache.get("third"))
+	})
+	s.Run("remove invalidates the entry", func() {
+		cache.remove("first")
+		s.Nil(cache.get("first"))
+		s.Equal(1, cache.len())
+	})
+	s.Run("close invalidates all the entries", func() {
+		cache.close()
+		s.Equal(0, cache.len())
+	})
+}
+
+func (s *DerivedCacheSuite) TestTTL() {
+	now := time.Now()
+	cache := newDerivedCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.add("key", &Kubernetes{})
+	s.Run("returns entries before the TTL", func() {
+		now = now.Add(59 * time.Second)
+		s.NotNil(cache.get("key"))
+	})
+	s.Run("expires entries after the TTL", func() {
+		now = now.Add(time.Second)
+		s.Nil(cache.get("key"))
+		s.Equal(0, cache.len())
+	})
+}
+
+func TestDerivedCache(t *testing.T) {
+	suite.Run(t, new(DerivedCacheSuite))
+}
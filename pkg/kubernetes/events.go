@@ -2,52 +2,149 @@ package kubernetes
 
 import (
 	"context"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func (c *Core) EventsList(ctx context.Context, namespace string, options api.ListOptions) ([]map[string]any, error) {
 	var eventMap []map[string]any
+	events, err := c.events(ctx, namespace, options)
+	if err != nil {
+		return eventMap, err
+	}
+	for i := range events {
+		eventMap = append(eventMap, eventToMap(&events[i]))
+	}
+	return eventMap, nil
+}
+
+// events returns the typed core/v1 Events of the namespace
+func (c *Core) events(ctx context.Context, namespace string, options api.ListOptions) ([]v1.Event, error) {
 	raw, err := c.ResourcesList(ctx, &schema.GroupVersionKind{
 		Group: "", Version: "v1", Kind: "Event",
 	}, namespace, options)
 	if err != nil {
-		return eventMap, err
+		return nil, err
 	}
 	unstructuredList := raw.(*unstructured.UnstructuredList)
-	if len(unstructuredList.Items) == 0 {
-		return eventMap, nil
-	}
+	events := make([]v1.Event, 0, len(unstructuredList.Items))
 	for _, item := range unstructuredList.Items {
-		event := &v1.Event{}
-		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, event); err != nil {
-			return eventMap, err
-		}
-		timestamp := event.EventTime.Time
-		if timestamp.IsZero() && event.Series != nil {
-			timestamp = event.Series.LastObservedTime.Time
-		} else if timestamp.IsZero() && event.Count > 1 {
-			timestamp = event.LastTimestamp.Time
-		} else if timestamp.IsZero() {
-			timestamp = event.FirstTimestamp.Time
-		}
-		eventMap = append(eventMap, map[string]any{
-			"Namespace": event.Namespace,
-			"Timestamp": timestamp.String(),
-			"Type":      event.Type,
-			"Reason":    event.Reason,
-			"InvolvedObject": map[string]string{
-				"apiVersion": event.InvolvedObject.APIVersion,
-				"Kind":       event.InvolvedObject.Kind,
-				"Name":       event.InvolvedObject.Name,
-			},
-			"Message": strings.TrimSpace(event.Message),
+		event := v1.Event{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// eventToMap returns the fields of the event printed by the events tools
+func eventToMap(event *v1.Event) map[string]any {
+	return map[string]any{
+		"Namespace": event.Namespace,
+		"Timestamp": eventTime(event).String(),
+		"Type":      event.Type,
+		"Reason":    event.Reason,
+		"InvolvedObject": map[string]string{
+			"apiVersion": event.InvolvedObject.APIVersion,
+			"Kind":       event.InvolvedObject.Kind,
+			"Name":       event.InvolvedObject.Name,
+		},
+		"Message": strings.TrimSpace(event.Message),
+	}
+}
+
+// ResourceEvents holds the most recent warning events of a listed resource
+type ResourceEvents struct {
+	Kind      string           `json:"kind"`
+	Namespace string           `json:"namespace,omitempty"`
+	Name      string           `json:"name"`
+	Events    []map[string]any `json:"events"`
+}
+
+// ResourcesWarningEvents returns the most recent warning events (up to limit per object) of the first maxObjects
+// objects in the list (as returned by ResourcesList, either a regular list or a Table).
+// Only the objects with warning events are included in the result.
+func (c *Core) ResourcesWarningEvents(ctx context.Context, gvk *schema.GroupVersionKind, list runtime.Unstructured, maxObjects, limit int) ([]ResourceEvents, error) {
+	ret := make([]ResourceEvents, 0)
+	objects := listedObjects(list)
+	if len(objects) > maxObjects {
+		objects = objects[:maxObjects]
+	}
+	for _, obj := range objects {
+		selector := fields.Set{"type": v1.EventTypeWarning}
+		if uid := obj.GetUID(); uid != "" {
+			selector["involvedObject.uid"] = string(uid)
+		} else {
+			selector["involvedObject.kind"] = gvk.Kind
+			selector["involvedObject.name"] = obj.GetName()
+		}
+		events, err := c.events(ctx, obj.GetNamespace(), api.ListOptions{ListOptions: metav1.ListOptions{
+			FieldSelector: selector.String(),
+		}})
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			continue
+		}
+		// Most recent first
+		sort.SliceStable(events, func(i, j int) bool {
+			return eventTime(&events[i]).After(eventTime(&events[j]))
 		})
+		if len(events) > limit {
+			events = events[:limit]
+		}
+		resourceEvents := ResourceEvents{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		for i := range events {
+			resourceEvents.Events = append(resourceEvents.Events, eventToMap(&events[i]))
+		}
+		ret = append(ret, resourceEvents)
 	}
-	return eventMap, nil
+	return ret, nil
+}
+
+// listedObjects returns the objects of a list or the objects of the rows of a Table (if included in the rows)
+func listedObjects(list runtime.Unstructured) []*unstructured.Unstructured {
+	var objects []*unstructured.Unstructured
+	switch l := list.(type) {
+	case *unstructured.UnstructuredList:
+		for i := range l.Items {
+			objects = append(objects, &l.Items[i])
+		}
+	case *unstructured.Unstructured:
+		rows, _, _ := unstructured.NestedSlice(l.Object, "rows")
+		for _, r := range rows {
+			row, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			if obj, ok := row["object"].(map[string]any); ok {
+				objects = append(objects, &unstructured.Unstructured{Object: obj})
+			}
+		}
+	}
+	return objects
+}
+
+// eventTime returns the time the event was last observed
+func eventTime(event *v1.Event) time.Time {
+	timestamp := event.EventTime.Time
+	if timestamp.IsZero() && event.Series != nil {
+		timestamp = event.Series.LastObservedTime.Time
+	} else if timestamp.IsZero() && event.Count > 1 {
+		timestamp = event.LastTimestamp.Time
+	} else if timestamp.IsZero() {
+		timestamp = event.FirstTimestamp.Time
+	}
+	return timestamp
 }
@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestListedObjects(t *testing.T) {
+	t.Run("returns the items of a list", func(t *testing.T) {
+		list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			{Object: map[string]any{"metadata": map[string]any{"name": "pod-1", "uid": "uid-1"}}},
+			{Object: map[string]any{"metadata": map[string]any{"name": "pod-2", "uid": "uid-2"}}},
+		}}
+		objects := listedObjects(list)
+		if len(objects) != 2 || objects[0].GetName() != "pod-1" || objects[1].GetUID() != "uid-2" {
+			t.Errorf("listedObjects() = %v; want pod-1 and pod-2", objects)
+		}
+	})
+	t.Run("returns the objects of the table rows", func(t *testing.T) {
+		table := &unstructured.Unstructured{Object: map[string]any{
+			"kind": "Table",
+			"rows": []any{
+				map[string]any{"cells": []any{"pod-1"}, "object": map[string]any{"kind": "PartialObjectMetadata", "metadata": map[string]any{"name": "pod-1", "namespace": "ns", "uid": "uid-1"}}},
+				map[string]any{"cells": []any{"no-object"}},
+			},
+		}}
+		objects := listedObjects(table)
+		if len(objects) != 1 || objects[0].GetName() != "pod-1" || objects[0].GetNamespace() != "ns" || objects[0].GetUID() != "uid-1" {
+			t.Errorf("listedObjects() = %v; want pod-1 in ns", objects)
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// HPAStatus returns a summary of the HorizontalPodAutoscalers (autoscaling/v2) in the provided namespace
+// (or only the one with the provided name) including the per-metric target and current values,
+// replica counts, scaling conditions and the related events.
+func (c *Core) HPAStatus(ctx context.Context, namespace, name string) ([]map[string]any, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	options := metav1.ListOptions{}
+	if name != "" {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+	hpas, err := c.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && len(hpas.Items) == 0 {
+		return nil, fmt.Errorf("horizontalpodautoscaler %s not found in namespace %s", name, namespace)
+	}
+	ret := make([]map[string]any, 0, len(hpas.Items))
+	for _, hpa := range hpas.Items {
+		status := hpaSummary(&hpa)
+		// Events are best effort (e.g. Events might be denied), the HPA status is still useful without them
+		events, eventsErr := c.EventsList(ctx, hpa.Namespace, api.ListOptions{ListOptions: metav1.ListOptions{
+			FieldSelector: fields.Set{"involvedObject.kind": "HorizontalPodAutoscaler", "involvedObject.name": hpa.Name}.String(),
+		}})
+		if eventsErr == nil && len(events) > 0 {
+			status["Events"] = events
+		}
+		ret = append(ret, status)
+	}
+	return ret, nil
+}
+
+// HPASet sets the minReplicas and/or maxReplicas (nil values are left untouched) of the HorizontalPodAutoscaler
+// with a merge patch. The resulting bounds are validated against the current ones before patching so that
+// minReplicas is at least 1 and not greater than maxReplicas.
+// Returns a summary of the updated HorizontalPodAutoscaler.
+func (c *Core) HPASet(ctx context.Context, namespace, name string, minReplicas, maxReplicas *int32) (map[string]any, error) {
+	if minReplicas == nil && maxReplicas == nil {
+		return nil, errors.New("at least one of min or max is required")
+	}
+	hpas := c.AutoscalingV2().HorizontalPodAutoscalers(c.NamespaceOrDefault(namespace))
+	hpa, err := hpas.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	newMin, newMax := ptr.Deref(hpa.Spec.MinReplicas, 1), hpa.Spec.MaxReplicas
+	spec := map[string]int32{}
+	if minReplicas != nil {
+		newMin = *minReplicas
+		spec["minReplicas"] = newMin
+	}
+	if maxReplicas != nil {
+		newMax = *maxReplicas
+		spec["maxReplicas"] = newMax
+	}
+	if newMin < 1 {
+		return nil, fmt.Errorf("invalid min %d: must be at least 1", newMin)
+	}
+	if newMin > newMax {
+		return nil, fmt.Errorf("invalid min %d: must not be greater than max %d", newMin, newMax)
+	}
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return nil, err
+	}
+	hpa, err = hpas.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: version.BinaryName})
+	if err != nil {
+		return nil, err
+	}
+	return hpaSummary(hpa), nil
+}
+
+// hpaSummary returns the replica bounds and counts, metrics and conditions of the HPA
+func hpaSummary(hpa *autoscalingv2.HorizontalPodAutoscaler) map[string]any {
+	status := map[string]any{
+		"Name":            hpa.Name,
+		"Namespace":       hpa.Namespace,
+		"ScaleTargetRef":  hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		"MaxReplicas":     hpa.Spec.MaxReplicas,
+		"CurrentReplicas": hpa.Status.CurrentReplicas,
+		"DesiredReplicas": hpa.Status.DesiredReplicas,
+		"Metrics":         hpaMetrics(hpa),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		status["MinReplicas"] = *hpa.Spec.MinReplicas
+	}
+	if hpa.Status.LastScaleTime != nil {
+		status["LastScaleTime"] = hpa.Status.LastScaleTime.Format(time.RFC3339)
+	}
+	conditions := make([]map[string]string, 0, len(hpa.Status.Conditions))
+	for _, condition := range hpa.Status.Conditions {
+		conditions = append(conditions, map[string]string{
+			"Type":    string(condition.Type),
+			"Status":  string(condition.Status),
+			"Reason":  condition.Reason,
+			"Message": condition.Message,
+		})
+	}
+	status["Conditions"] = conditions
+	return status
+}
+
+// hpaMetrics pairs each metric in the HPA spec with its current value reported in the HPA status
+func hpaMetrics(hpa *autoscalingv2.HorizontalPodAutoscaler) []map[string]string {
+	current := make(map[string]string, len(hpa.Status.CurrentMetrics))
+	for _, metric := range hpa.Status.CurrentMetrics {
+		key, value := hpaMetricStatus(&metric)
+		current[key] = value
+	}
+	metrics := make([]map[string]string, 0, len(hpa.Spec.Metrics))
+	for _, metric := range hpa.Spec.Metrics {
+		key, target := hpaMetricSpec(&metric)
+		value, found := current[key]
+		if !found {
+			value = "<unknown>"
+		}
+		metrics = append(metrics, map[string]string{
+			"Type":    string(metric.Type),
+			"Name":    key,
+			"Target":  target,
+			"Current": value,
+		})
+	}
+	return metrics
+}
+
+func hpaMetricSpec(metric *autoscalingv2.MetricSpec) (key string, target string) {
+	switch {
+	case metric.Resource != nil:
+		return string(metric.Resource.Name), hpaMetricTarget(metric.Resource.Target)
+	case metric.ContainerResource != nil:
+		return metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name), hpaMetricTarget(metric.ContainerResource.Target)
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name, hpaMetricTarget(metric.Pods.Target)
+	case metric.Object != nil:
+		return metric.Object.DescribedObject.Kind + "/" + metric.Object.DescribedObject.Name + "/" + metric.Object.Metric.Name, hpaMetricTarget(metric.Object.Target)
+	case metric.External != nil:
+		return metric.External.Metric.Name, hpaMetricTarget(metric.External.Target)
+	}
+	return string(metric.Type), "<unknown>"
+}
+
+func hpaMetricStatus(metric *autoscalingv2.MetricStatus) (key string, value string) {
+	switch {
+	case metric.Resource != nil:
+		return string(metric.Resource.Name), hpaMetricValue(metric.Resource.Current)
+	case metric.ContainerResource != nil:
+		return metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name), hpaMetricValue(metric.ContainerResource.Current)
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name, hpaMetricValue(metric.Pods.Current)
+	case metric.Object != nil:
+		return metric.Object.DescribedObject.Kind + "/" + metric.Object.DescribedObject.Name + "/" + metric.Object.Metric.Name, hpaMetricValue(metric.Object.Current)
+	case metric.External != nil:
+		return metric.External.Metric.Name, hpaMetricValue(metric.External.Current)
+	}
+	return string(metric.Type), "<unknown>"
+}
+
+func hpaMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%% (average utilization)", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String() + " (average value)"
+	case target.Value != nil:
+		return target.Value.String() + " (value)"
+	}
+	return "<unknown>"
+}
+
+func hpaMetricValue(current autoscalingv2.MetricValueStatus) string {
+	switch {
+	case current.AverageUtilization != nil:
+		return fmt.Sprintf("%d%% (average utilization)", *current.AverageUtilization)
+	case current.AverageValue != nil:
+		return current.AverageValue.String() + " (average value)"
+	case current.Value != nil:
+		return current.Value.String() + " (value)"
+	}
+	return "<unknown>"
+}
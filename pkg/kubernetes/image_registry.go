@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxImageNewerTags caps the number of newer tags returned by ImageNewerTags
+	MaxImageNewerTags = 10
+	// imageRegistryTimeout bounds every request to an image registry
+	imageRegistryTimeout = 10 * time.Second
+	// maxImageRegistryResponseBytes bounds the responses read from an image registry
+	maxImageRegistryResponseBytes = 4 * 1024 * 1024
+	// dockerHubRegistryHost is the host of the registry API of docker.io
+	dockerHubRegistryHost = "registry-1.docker.io"
+)
+
+var (
+	imageTagVersionRegex   = regexp.MustCompile(`^(v?)(\d+(?:\.\d+)*)(-.+)?$`)
+	wwwAuthenticateParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// ImageNewerTagsOptions are the options of ImageNewerTags
+type ImageNewerTagsOptions struct {
+	// Scheme of the registry API requests (https if empty, plain http is only meant for tests)
+	Scheme string
+}
+
+// ImageNewerTags queries the registry of the image (anonymously, with the OCI distribution API) for the tags with a
+// newer version than the tag of the image. Only the tags with the same format are considered (e.g. for 1.27.3-alpine:
+// 1.27.4-alpine or 1.28.0-alpine, but not 1.28.0 or 1.28-alpine). The lookup is best-effort, private registries
+// requiring credentials and registries without the tags list API return an error.
+// Returns up to MaxImageNewerTags tags, newest first.
+func ImageNewerTags(ctx context.Context, image string, options ImageNewerTagsOptions) ([]string, error) {
+	registry, repository, tag, _ := parseImageReference(image)
+	current, ok := parseImageTagVersion(tag)
+	if !ok {
+		return nil, fmt.Errorf("tag %q is not a version, newer tags can't be determined", tag)
+	}
+	tags, err := imageRegistryTags(ctx, cmp.Or(options.Scheme, "https"), registry, repository)
+	if err != nil {
+		return nil, err
+	}
+	var newer []*imageTagVersion
+	for _, candidate := range tags {
+		if version, ok := parseImageTagVersion(candidate); ok && version.sameFormat(current) && version.compare(current) > 0 {
+			newer = append(newer, version)
+		}
+	}
+	slices.SortFunc(newer, func(a, b *imageTagVersion) int { return b.compare(a) })
+	ret := make([]string, 0, min(len(newer), MaxImageNewerTags))
+	for _, version := range newer[:min(len(newer), MaxImageNewerTags)] {
+		ret = append(ret, version.tag)
+	}
+	return ret, nil
+}
+
+// imageRegistryTags lists the tags of the repository, the anonymous bearer token requested by the registry
+// (e.g. docker.io, quay.io, ghcr.io) is retrieved from its token service
+func imageRegistryTags(ctx context.Context, scheme, registry, repository string) ([]string, error) {
+	host := registry
+	if host == defaultImageRegistry {
+		host = dockerHubRegistryHost
+	}
+	tagsURL := fmt.Sprintf("%s://%s/v2/%s/tags/list", scheme, host, repository)
+	client := &http.Client{Timeout: imageRegistryTimeout}
+	res, err := imageRegistryGet(ctx, client, tagsURL, "")
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge := res.Header.Get("WWW-Authenticate")
+		_ = res.Body.Close()
+		token, tokenErr := imageRegistryToken(ctx, client, scheme, challenge, repository)
+		if tokenErr != nil {
+			return nil, fmt.Errorf("failed to authenticate to registry %s: %w", registry, tokenErr)
+		}
+		if res, err = imageRegistryGet(ctx, client, tagsURL, token); err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry %s returned %s listing the tags of %s (private repositories are not supported)", registry, res.Status, repository)
+	}
+	var tagsList struct {
+		Tags []string `json:"tags"`
+	}
+	if err = json.NewDecoder(io.LimitReader(res.Body, maxImageRegistryResponseBytes)).Decode(&tagsList); err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of %s returned by registry %s: %w", repository, registry, err)
+	}
+	return tagsList.Tags, nil
+}
+
+func imageRegistryGet(ctx context.Context, client *http.Client, requestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return client.Do(req)
+}
+
+// imageRegistryToken requests an anonymous pull token from the token service of the Bearer WWW-Authenticate challenge.
+// The realm of the token service is only followed over https (or the scheme of the registry requests, for the tests).
+func imageRegistryToken(ctx context.Context, client *http.Client, scheme, challenge, repository string) (string, error) {
+	authScheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(authScheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	values := map[string]string{}
+	for _, match := range wwwAuthenticateParamRe.FindAllStringSubmatch(params, -1) {
+		values[strings.ToLower(match[1])] = match[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("authentication challenge %q has no realm", challenge)
+	}
+	tokenURL, err := url.Parse(values["realm"])
+	if err != nil {
+		return "", fmt.Errorf("invalid authentication realm %q: %w", values["realm"], err)
+	}
+	if (tokenURL.Scheme != "https" && tokenURL.Scheme != scheme) || tokenURL.Host == "" {
+		return "", fmt.Errorf("invalid authentication realm %q: the token service must be an https URL", values["realm"])
+	}
+	query := tokenURL.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", cmp.Or(values["scope"], "repository:"+repository+":pull"))
+	tokenURL.RawQuery = query.Encode()
+	res, err := imageRegistryGet(ctx, client, tokenURL.String(), "")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token service returned %s", res.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(io.LimitReader(res.Body, maxImageRegistryResponseBytes)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse the token service response: %w", err)
+	}
+	if ret := cmp.Or(token.Token, token.AccessToken); ret != "" {
+		return ret, nil
+	}
+	return "", fmt.Errorf("token service returned no token")
+}
+
+// imageTagVersion is a version-like image tag: an optional v prefix, dot-separated numbers and an optional suffix
+// (e.g. v1.27.3-alpine)
+type imageTagVersion struct {
+	tag     string
+	prefix  string
+	numbers []int
+	suffix  string
+}
+
+func parseImageTagVersion(tag string) (*imageTagVersion, bool) {
+	match := imageTagVersionRegex.FindStringSubmatch(tag)
+	if match == nil {
+		return nil, false
+	}
+	version := &imageTagVersion{tag: tag, prefix: match[1], suffix: match[3]}
+	for _, number := range strings.Split(match[2], ".") {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return nil, false
+		}
+		version.numbers = append(version.numbers, n)
+	}
+	return version, true
+}
+
+// sameFormat reports whether both tags have the same prefix, number of components and suffix
+func (v *imageTagVersion) sameFormat(other *imageTagVersion) bool {
+	return v.prefix == other.prefix && len(v.numbers) == len(other.numbers) && v.suffix == other.suffix
+}
+
+// compare compares the numbers of the versions (both must have the same format)
+func (v *imageTagVersion) compare(other *imageTagVersion) int {
+	return slices.Compare(v.numbers, other.numbers)
+}
@@ -0,0 +1,144 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultImagesInventoryLimit is the default maximum number of images returned by ImagesInventory
+	DefaultImagesInventoryLimit = 100
+	// MaxImagesInventoryLimit caps the number of images returned by ImagesInventory
+	MaxImagesInventoryLimit = 500
+	// defaultImageRegistry is the registry of the image references without an explicit registry (e.g. nginx:1.27)
+	defaultImageRegistry = "docker.io"
+)
+
+// ImagesInventoryResult is the result of ImagesInventory
+type ImagesInventoryResult struct {
+	// Images are the distinct images in use sorted by reference, up to the limit
+	Images []map[string]any
+	// Total is the number of distinct images in use (including those beyond the limit)
+	Total int
+	// Pods is the number of scanned Pods
+	Pods int
+}
+
+// ImagesInventory returns the distinct container images (including init and ephemeral containers) used by the Pods
+// in the provided namespace, with the number of Pods and the workloads using each of them.
+// Images using the latest tag (explicitly or implicitly) are flagged, and so are the images from registries not in
+// allowedRegistries (if provided, entries are registries, e.g. quay.io, or repository prefixes, e.g. quay.io/my-org).
+func (c *Core) ImagesInventory(ctx context.Context, namespace string, allowedRegistries []string, limit int) (*ImagesInventoryResult, error) {
+	pods, err := c.CoreV1().Pods(c.NamespaceOrDefault(namespace)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	podCount := map[string]int{}
+	workloads := map[string][]string{}
+	for _, pod := range pods.Items {
+		workload := podWorkload(&pod)
+		for _, image := range podImages(&pod.Spec) {
+			podCount[image]++
+			if !slices.Contains(workloads[image], workload) {
+				workloads[image] = append(workloads[image], workload)
+			}
+		}
+	}
+	references := make([]string, 0, len(podCount))
+	for image := range podCount {
+		references = append(references, image)
+	}
+	slices.Sort(references)
+	ret := &ImagesInventoryResult{Images: []map[string]any{}, Total: len(references), Pods: len(pods.Items)}
+	for _, image := range references[:min(limit, len(references))] {
+		registry, repository, tag, digest := parseImageReference(image)
+		inventory := map[string]any{
+			"Image":     image,
+			"Registry":  registry,
+			"Pods":      podCount[image],
+			"Workloads": workloads[image],
+		}
+		var issues []string
+		if digest == "" && (tag == "" || tag == "latest") {
+			issues = append(issues, "uses the latest tag (not pinned to a version or digest)")
+		}
+		if len(allowedRegistries) > 0 && !imageRegistryAllowed(registry+"/"+repository, allowedRegistries) {
+			issues = append(issues, "registry "+registry+" is not in the allowed registries")
+		}
+		if len(issues) > 0 {
+			inventory["Issues"] = issues
+		}
+		ret.Images = append(ret.Images, inventory)
+	}
+	return ret, nil
+}
+
+// podImages returns the distinct images of the init, regular and ephemeral containers of the Pod
+func podImages(spec *v1.PodSpec) []string {
+	var images []string
+	appendImage := func(image string) {
+		if !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	for _, container := range spec.InitContainers {
+		appendImage(container.Image)
+	}
+	for _, container := range spec.Containers {
+		appendImage(container.Image)
+	}
+	for _, container := range spec.EphemeralContainers {
+		appendImage(container.Image)
+	}
+	return images
+}
+
+// podWorkload returns the Kind/name of the workload managing the Pod without querying the API:
+// Pods of ReplicaSets created by a Deployment are attributed to the Deployment (derived from the pod-template-hash),
+// Pods without a controller are reported as standalone Pods
+func podWorkload(pod *v1.Pod) string {
+	controller := controllerOf(pod.OwnerReferences)
+	if controller == nil {
+		return "Pod/" + pod.Name
+	}
+	if hash := pod.Labels["pod-template-hash"]; controller.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(controller.Name, "-"+hash) {
+		return "Deployment/" + strings.TrimSuffix(controller.Name, "-"+hash)
+	}
+	return controller.Kind + "/" + controller.Name
+}
+
+// parseImageReference splits a container image reference (e.g. quay.io/org/app:1.0@sha256:...) into its components,
+// references without registry default to docker.io (and to the library repository for single component names)
+func parseImageReference(image string) (registry, repository, tag, digest string) {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	registry, repository = defaultImageRegistry, name
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, repository = first, rest
+	}
+	if registry == defaultImageRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return registry, repository, tag, digest
+}
+
+// imageRegistryAllowed reports whether the image name (registry/repository) matches any of the allowed registries
+// or repository prefixes
+func imageRegistryAllowed(name string, allowedRegistries []string) bool {
+	for _, allowed := range allowedRegistries {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if name == allowed || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+type ImagesSuite struct {
+	suite.Suite
+}
+
+func (s *ImagesSuite) TestParseImageReference() {
+	for _, tc := range []struct {
+		image, registry, repository, tag, digest string
+	}{
+		{"nginx", "docker.io", "library/nginx", "", ""},
+		{"nginx:1.27", "docker.io", "library/nginx", "1.27", ""},
+		{"bitnami/redis:latest", "docker.io", "bitnami/redis", "latest", ""},
+		{"quay.io/org/app:1.0@sha256:abc", "quay.io", "org/app", "1.0", "sha256:abc"},
+		{"quay.io/org/app@sha256:abc", "quay.io", "org/app", "", "sha256:abc"},
+		{"localhost:5000/app", "localhost:5000", "app", "", ""},
+		{"localhost/app:dev", "localhost", "app", "dev", ""},
+		{"registry.example.com:5000/team/app:2", "registry.example.com:5000", "team/app", "2", ""},
+	} {
+		s.Run(tc.image, func() {
+			registry, repository, tag, digest := parseImageReference(tc.image)
+			s.Equal(tc.registry, registry)
+			s.Equal(tc.repository, repository)
+			s.Equal(tc.tag, tag)
+			s.Equal(tc.digest, digest)
+		})
+	}
+}
+
+func (s *ImagesSuite) TestImageRegistryAllowed() {
+	allowed := []string{"quay.io/my-org/", "registry.example.com"}
+	s.True(imageRegistryAllowed("quay.io/my-org/app", allowed))
+	s.True(imageRegistryAllowed("registry.example.com/team/app", allowed))
+	s.False(imageRegistryAllowed("quay.io/other-org/app", allowed))
+	s.False(imageRegistryAllowed("quay.io/my-org-fork/app", allowed))
+	s.False(imageRegistryAllowed("docker.io/library/nginx", allowed))
+}
+
+func (s *ImagesSuite) TestPodWorkload() {
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: ptr.To(true)}}
+	}
+	s.Run("attributes ReplicaSet Pods to the Deployment", func() {
+		s.Equal("Deployment/web", podWorkload(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-5d8f7c-abcde", Labels: map[string]string{"pod-template-hash": "5d8f7c"}, OwnerReferences: controlledBy("ReplicaSet", "web-5d8f7c"),
+		}}))
+	})
+	s.Run("reports other controllers", func() {
+		s.Equal("StatefulSet/db", podWorkload(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", OwnerReferences: controlledBy("StatefulSet", "db")}}))
+	})
+	s.Run("reports standalone Pods", func() {
+		s.Equal("Pod/debug", podWorkload(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug"}}))
+	})
+}
+
+func (s *ImagesSuite) TestCompareWorkloadImages() {
+	template := &v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "init", Image: "busybox:1.36"}},
+		Containers:     []v1.Container{{Name: "app", Image: "quay.io/org/app:2.0"}, {Name: "proxy", Image: "envoy:1.30"}},
+	}
+	pod := func(name, appImage, appImageID string, waiting string, withProxy bool) v1.Pod {
+		ret := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PodSpec{
+				InitContainers: []v1.Container{{Name: "init", Image: "busybox:1.36"}},
+				Containers:     []v1.Container{{Name: "app", Image: appImage}},
+			},
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{{Name: "init", ImageID: "docker.io/library/busybox@sha256:b"}},
+				ContainerStatuses:     []v1.ContainerStatus{{Name: "app", ImageID: appImageID}},
+			},
+		}
+		if waiting != "" {
+			ret.Status.ContainerStatuses[0].State.Waiting = &v1.ContainerStateWaiting{Reason: waiting}
+		}
+		if withProxy {
+			ret.Spec.Containers = append(ret.Spec.Containers, v1.Container{Name: "proxy", Image: "envoy:1.30"})
+			ret.Status.ContainerStatuses = append(ret.Status.ContainerStatuses, v1.ContainerStatus{Name: "proxy", ImageID: "docker-pullable://envoy@sha256:e"})
+		}
+		return ret
+	}
+	s.Run("all Pods up to date", func() {
+		ret := compareWorkloadImages(template, []v1.Pod{
+			pod("web-1", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2", "", true),
+			pod("web-2", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2", "", true),
+		})
+		s.Equal(2, ret.UpToDatePods)
+		s.Require().Len(ret.Containers, 3)
+		s.True(ret.Containers[0].Init)
+		s.Equal("app", ret.Containers[1].Container)
+		s.Empty(ret.Containers[1].Issues)
+		s.Require().Len(ret.Containers[1].Running, 1)
+		s.Equal(&WorkloadRunningImage{Image: "quay.io/org/app:2.0", Digest: "sha256:a2", UpToDate: true, Pods: []string{"web-1", "web-2"}}, ret.Containers[1].Running[0])
+		s.Equal(&WorkloadRunningImage{Image: "envoy:1.30", Digest: "sha256:e", UpToDate: true, Pods: []string{"web-1", "web-2"}}, ret.Containers[2].Running[0])
+	})
+	s.Run("mid-rollout", func() {
+		ret := compareWorkloadImages(template, []v1.Pod{
+			pod("web-old", "quay.io/org/app:1.0", "quay.io/org/app@sha256:a1", "", true),
+			pod("web-new", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2", "", true),
+			pod("web-pulling", "quay.io/org/app:2.0", "", "ImagePullBackOff", true),
+		})
+		s.Equal(1, ret.UpToDatePods)
+		s.Equal([]string{
+			"1 of 3 Pods run an image other than quay.io/org/app:2.0",
+			"1 Pods haven't started quay.io/org/app:2.0 yet",
+		}, ret.Containers[1].Issues)
+		s.Require().Len(ret.Containers[1].Running, 3)
+		s.Equal(&WorkloadRunningImage{Image: "quay.io/org/app:1.0", Digest: "sha256:a1", Pods: []string{"web-old"}}, ret.Containers[1].Running[0])
+		s.Equal(&WorkloadRunningImage{Image: "quay.io/org/app:2.0", UpToDate: true, Pods: []string{"web-pulling"}, Waiting: "ImagePullBackOff"}, ret.Containers[1].Running[2])
+	})
+	s.Run("Pods created before a container was added are outdated", func() {
+		ret := compareWorkloadImages(template, []v1.Pod{pod("web-1", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2", "", false)})
+		s.Equal(0, ret.UpToDatePods)
+		s.Equal([]string{"1 of 1 Pods don't have the container (created from an older Pod template)"}, ret.Containers[2].Issues)
+	})
+	s.Run("different digests of the same tag are flagged", func() {
+		ret := compareWorkloadImages(template, []v1.Pod{
+			pod("web-1", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2", "", true),
+			pod("web-2", "quay.io/org/app:2.0", "quay.io/org/app@sha256:a2-repushed", "", true),
+		})
+		s.Equal(2, ret.UpToDatePods)
+		s.Require().Len(ret.Containers[1].Issues, 1)
+		s.Contains(ret.Containers[1].Issues[0], "the Pods run 2 different digests of quay.io/org/app:2.0")
+	})
+}
+
+func (s *ImagesSuite) TestParseImageTagVersion() {
+	for _, tc := range []struct {
+		tag     string
+		valid   bool
+		numbers []int
+	}{
+		{"1.27", true, []int{1, 27}},
+		{"v1.27.3", true, []int{1, 27, 3}},
+		{"1.27.3-alpine", true, []int{1, 27, 3}},
+		{"20240101", true, []int{20240101}},
+		{"latest", false, nil},
+		{"", false, nil},
+		{"stable-alpine", false, nil},
+	} {
+		s.Run(tc.tag, func() {
+			version, ok := parseImageTagVersion(tc.tag)
+			s.Equal(tc.valid, ok)
+			if ok {
+				s.Equal(tc.numbers, version.numbers)
+			}
+		})
+	}
+}
+
+func (s *ImagesSuite) TestImageNewerTags() {
+	options := ImageNewerTagsOptions{Scheme: "http"}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/token":
+			if req.URL.Query().Get("scope") != "repository:org/app:pull" || req.URL.Query().Get("service") != "test-registry" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"anonymous-token"}`))
+		case req.URL.Path == "/v2/org/insecure-realm/tags/list":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ftp://`+strings.TrimPrefix(server.URL, "http://")+`/token",service="test-registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case req.URL.Path == "/v2/org/app/tags/list" && req.Header.Get("Authorization") != "Bearer anonymous-token":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="test-registry",scope="repository:org/app:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case req.URL.Path == "/v2/org/app/tags/list":
+			_, _ = w.Write([]byte(`{"name":"org/app","tags":["1.9.0","1.10.0","1.10.1","1.10.1-alpine","1.11","2.0.0","latest","v3.0.0","1.10.0-rc1"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+	s.Run("returns newer tags with the same format, newest first", func() {
+		tags, err := ImageNewerTags(s.T().Context(), registry+"/org/app:1.10.0", options)
+		s.Require().NoError(err)
+		s.Equal([]string{"2.0.0", "1.10.1"}, tags)
+	})
+	s.Run("returns no tags for the newest version", func() {
+		tags, err := ImageNewerTags(s.T().Context(), registry+"/org/app:2.0.0", options)
+		s.Require().NoError(err)
+		s.Empty(tags)
+	})
+	s.Run("returns error for tags that are not versions", func() {
+		_, err := ImageNewerTags(s.T().Context(), registry+"/org/app:latest", options)
+		s.Require().Error(err)
+		s.Equal(`tag "latest" is not a version, newer tags can't be determined`, err.Error())
+	})
+	s.Run("returns error for unknown repositories", func() {
+		_, err := ImageNewerTags(s.T().Context(), registry+"/org/missing:1.0", options)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "returned 404 Not Found listing the tags of org/missing")
+	})
+	s.Run("returns error for a realm that isn't https nor the scheme of the registry", func() {
+		_, err := ImageNewerTags(s.T().Context(), registry+"/org/insecure-realm:1.0", options)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "the token service must be an https URL")
+	})
+	s.Run("uses https by default", func() {
+		_, err := ImageNewerTags(s.T().Context(), registry+"/org/app:1.10.0", ImageNewerTagsOptions{})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "https://"+registry+"/v2/org/app/tags/list")
+	})
+}
+
+func TestImages(t *testing.T) {
+	suite.Run(t, new(ImagesSuite))
+}
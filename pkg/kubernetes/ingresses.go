@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// IngressRoute is a resolved Ingress rule path (or the default backend)
+type IngressRoute struct {
+	Host     string
+	Path     string
+	PathType string
+	// Backend is the Service (name:port) or resource (Kind/name) the route points to
+	Backend        string
+	ReadyEndpoints int
+	// Problem describes why the backend is broken (empty if the route is healthy)
+	Problem string
+}
+
+// IngressDescription is the routing information of an Ingress resolved down to the ready endpoints of each backend
+type IngressDescription struct {
+	Name         string
+	Namespace    string
+	IngressClass string
+	TLSHosts     []string
+	Addresses    []string
+	Routes       []IngressRoute
+}
+
+// IngressDescribe resolves each host/path of an Ingress (networking.k8s.io/v1) to its backing Service and then
+// to the Service ready endpoints, flagging broken backends (missing Service, wrong port, no ready endpoints).
+func (c *Core) IngressDescribe(ctx context.Context, namespace, name string) (*IngressDescription, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	ingress, err := c.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := &IngressDescription{
+		Name:         ingress.Name,
+		Namespace:    ingress.Namespace,
+		IngressClass: ptr.Deref(ingress.Spec.IngressClassName, ingress.Annotations["kubernetes.io/ingress.class"]),
+	}
+	for _, tls := range ingress.Spec.TLS {
+		ret.TLSHosts = append(ret.TLSHosts, tls.Hosts...)
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			ret.Addresses = append(ret.Addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			ret.Addresses = append(ret.Addresses, lb.Hostname)
+		}
+	}
+	resolver := &ingressBackendResolver{core: c, ctx: ctx, namespace: namespace, services: map[string]*v1.Service{}}
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			route := IngressRoute{Host: host, Path: path.Path, PathType: string(ptr.Deref(path.PathType, ""))}
+			if route.Path == "" {
+				route.Path = "/"
+			}
+			if err = resolver.resolve(&route, &path.Backend); err != nil {
+				return nil, err
+			}
+			ret.Routes = append(ret.Routes, route)
+		}
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		route := IngressRoute{Host: "*", Path: "(default backend)"}
+		if err = resolver.resolve(&route, ingress.Spec.DefaultBackend); err != nil {
+			return nil, err
+		}
+		ret.Routes = append(ret.Routes, route)
+	}
+	return ret, nil
+}
+
+type ingressBackendResolver struct {
+	core      *Core
+	ctx       context.Context
+	namespace string
+	// services caches the retrieved Services (nil for missing Services)
+	services map[string]*v1.Service
+}
+
+func (r *ingressBackendResolver) resolve(route *IngressRoute, backend *networkingv1.IngressBackend) error {
+	if backend.Resource != nil {
+		route.Backend = backend.Resource.Kind + "/" + backend.Resource.Name
+		route.Problem = "resource backend, endpoints can't be resolved"
+		return nil
+	}
+	if backend.Service == nil {
+		route.Problem = "no backend defined"
+		return nil
+	}
+	port := backend.Service.Port.Name
+	if port == "" {
+		port = strconv.Itoa(int(backend.Service.Port.Number))
+	}
+	route.Backend = backend.Service.Name + ":" + port
+	service, found := r.services[backend.Service.Name]
+	if !found {
+		var err error
+		service, err = r.core.CoreV1().Services(r.namespace).Get(r.ctx, backend.Service.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			service = nil
+		} else if err != nil {
+			return err
+		}
+		r.services[backend.Service.Name] = service
+	}
+	if service == nil {
+		route.Problem = fmt.Sprintf("Service %s not found", backend.Service.Name)
+		return nil
+	}
+	var servicePort *v1.ServicePort
+	for i := range service.Spec.Ports {
+		p := &service.Spec.Ports[i]
+		if (backend.Service.Port.Name != "" && p.Name == backend.Service.Port.Name) ||
+			(backend.Service.Port.Name == "" && p.Port == backend.Service.Port.Number) {
+			servicePort = p
+			break
+		}
+	}
+	if servicePort == nil {
+		route.Problem = fmt.Sprintf("Service %s has no port %s", service.Name, port)
+		return nil
+	}
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return nil
+	}
+	endpointSlices, err := r.core.DiscoveryV1().EndpointSlices(r.namespace).List(r.ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: service.Name}.String(),
+	})
+	if err != nil {
+		return err
+	}
+	notReady := 0
+	for _, slice := range endpointSlices.Items {
+		if !endpointSliceHasPort(&slice, servicePort.Name) {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpointReady(&endpoint) {
+				route.ReadyEndpoints++
+			} else {
+				notReady++
+			}
+		}
+	}
+	route.Problem = serviceEndpointsWarning(service, route.ReadyEndpoints, notReady)
+	return nil
+}
+
+// endpointSliceHasPort checks if the EndpointSlice exposes the Service port with the provided name
+// (EndpointSlice port names match the Service port names)
+func endpointSliceHasPort(slice *discoveryv1.EndpointSlice, name string) bool {
+	for _, port := range slice.Ports {
+		if ptr.Deref(port.Name, "") == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const (
+	// DefaultJobRunTimeout is the default time to wait for a Job created by JobsRun to complete or fail
+	DefaultJobRunTimeout = 5 * time.Minute
+	// MaxJobRunTimeout bounds the time JobsRun waits for a Job to complete or fail
+	MaxJobRunTimeout = 30 * time.Minute
+	// jobRunTTLSecondsAfterFinished is the time the Jobs created from an image are kept after they finish
+	jobRunTTLSecondsAfterFinished = int32(3600)
+)
+
+// JobStatus values reported by JobsRun
+const (
+	JobStatusComplete = "Complete"
+	JobStatusFailed   = "Failed"
+	JobStatusRunning  = "Running"
+)
+
+// JobRunOptions are the settings of the Job created by JobsRun.
+// Either Manifest (a batch/v1 Job in YAML or JSON) or Image must be provided.
+type JobRunOptions struct {
+	// Namespace of the Job (the default namespace is used if empty)
+	Namespace string
+	// Name of the Job (random name if empty and not provided by the Manifest)
+	Name string
+	// Image of the single container of the Job Pod
+	Image string
+	// Command overrides the entrypoint of the Image (the image entrypoint is used if empty)
+	Command []string
+	// Manifest is the inline batch/v1 Job to create
+	Manifest string
+	// Timeout is the maximum time to wait for the Job to finish (DefaultJobRunTimeout if zero, capped to MaxJobRunTimeout)
+	Timeout time.Duration
+	// Tail is the number of log lines to retrieve from the Job Pod (DefaultTailLines if zero)
+	Tail int64
+}
+
+// JobRunResult is the outcome of JobsRun
+type JobRunResult struct {
+	// Job is the last observed state of the Job
+	Job *batchv1.Job
+	// Status is one of JobStatusComplete, JobStatusFailed or JobStatusRunning (didn't finish within the timeout)
+	Status string
+	// Pod is the name of the most recent Pod of the Job (empty if no Pod was created)
+	Pod string
+	// Logs of the Pod
+	Logs string
+	// LogsError explains why the logs of the Pod couldn't be retrieved
+	LogsError error
+}
+
+// JobsRun creates a Job and waits (bounded by the timeout) for it to complete or fail.
+// Returns the status of the Job and the logs of its most recent Pod. A Job still running once the timeout expires is
+// not an error, it's reported with JobStatusRunning and can be checked later.
+func (c *Core) JobsRun(ctx context.Context, opts JobRunOptions, progress api.ProgressFunc) (*JobRunResult, error) {
+	job, err := c.jobRunJob(opts)
+	if err != nil {
+		return nil, err
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultJobRunTimeout
+	}
+	timeout = min(timeout, MaxJobRunTimeout)
+	// Reserve the watch before creating the Job so that it isn't left behind if the wait is rejected
+	release, err := c.acquireWatch()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	created, err := c.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{FieldManager: version.BinaryName})
+	if err != nil {
+		return nil, err
+	}
+	ret := &JobRunResult{}
+	if ret.Job, err = c.jobsWait(ctx, created, timeout, progress); err != nil {
+		return nil, err
+	}
+	ret.Status = jobStatus(ret.Job)
+	ret.Pod, ret.Logs, ret.LogsError = c.jobLogs(ctx, ret.Job, opts.Tail)
+	return ret, nil
+}
+
+// jobRunJob returns the Job to create from the provided Manifest or Image
+func (c *Core) jobRunJob(opts JobRunOptions) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	switch {
+	case opts.Manifest != "" && opts.Image != "":
+		return nil, errors.New("either a Job manifest or an image must be provided, not both")
+	case opts.Manifest != "":
+		if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(opts.Manifest), 4096).Decode(job); err != nil {
+			return nil, fmt.Errorf("invalid Job manifest: %w", err)
+		}
+		if job.APIVersion != batchv1.SchemeGroupVersion.String() || job.Kind != "Job" {
+			return nil, fmt.Errorf("invalid Job manifest: expected apiVersion %s and kind Job, got %s %s", batchv1.SchemeGroupVersion, job.APIVersion, job.Kind)
+		}
+	case opts.Image != "":
+		labels := map[string]string{
+			AppKubernetesManagedBy: version.BinaryName,
+			AppKubernetesPartOf:    version.BinaryName + "-run-sandbox",
+		}
+		job.Labels = labels
+		job.Spec = batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(0)),
+			TTLSecondsAfterFinished: ptr.To(jobRunTTLSecondsAfterFinished),
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers:    []v1.Container{{Name: "job", Image: opts.Image, Command: opts.Command}},
+				},
+			},
+		}
+	default:
+		return nil, errors.New("either a Job manifest or an image must be provided")
+	}
+	if opts.Name != "" {
+		job.Name = opts.Name
+	}
+	if job.Name == "" && job.GenerateName == "" {
+		job.Name = version.BinaryName + "-job-" + rand.String(5)
+	}
+	if opts.Namespace != "" || job.Namespace == "" {
+		job.Namespace = c.NamespaceOrDefault(opts.Namespace)
+	}
+	return job, nil
+}
+
+// jobsWait watches the Job until it completes, fails, or the timeout expires (the last observed state is returned)
+func (c *Core) jobsWait(ctx context.Context, job *batchv1.Job, timeout time.Duration, progress api.ProgressFunc) (*batchv1.Job, error) {
+	if jobStatus(job) != JobStatusRunning {
+		return job, nil
+	}
+	jobs := c.BatchV1().Jobs(job.Namespace)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", job.Name).String()
+	lw := &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return jobs.Watch(ctx, options)
+		},
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	reportProgress := func() {
+		if progress != nil {
+			progress(time.Since(start).Seconds(), timeout.Seconds(), fmt.Sprintf("Waiting for Job %s: %d active, %d succeeded, %d failed",
+				job.Name, job.Status.Active, job.Status.Succeeded, job.Status.Failed))
+		}
+	}
+	reportProgress()
+	watcher, err := watchtools.NewRetryWatcherWithContext(waitCtx, job.ResourceVersion, lw)
+	if err != nil {
+		return nil, err
+	}
+	_, err = watchtools.UntilWithoutRetry(waitCtx, watcher, func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Deleted:
+			return false, fmt.Errorf("job %s was deleted while waiting", job.Name)
+		case watch.Error:
+			return false, apierrors.FromObject(event.Object)
+		}
+		if j, ok := event.Object.(*batchv1.Job); ok {
+			job = j
+		}
+		reportProgress()
+		return jobStatus(job) != JobStatusRunning, nil
+	})
+	if err != nil && !errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+		return nil, err
+	}
+	return job, nil
+}
+
+// jobStatus returns JobStatusComplete or JobStatusFailed if the Job has finished, JobStatusRunning otherwise
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return JobStatusComplete
+		case batchv1.JobFailed:
+			return JobStatusFailed
+		}
+	}
+	return JobStatusRunning
+}
+
+// jobLogs returns the name and the logs of the most recent Pod of the Job
+func (c *Core) jobLogs(ctx context.Context, job *batchv1.Job, tail int64) (string, string, error) {
+	if job.Spec.Selector == nil {
+		return "", "", errors.New("job has no selector")
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", "", err
+	}
+	pods, err := c.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", "", errors.New("no Pods were created for the Job")
+	}
+	latest := &pods.Items[0]
+	for i := range pods.Items {
+		if latest.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			latest = &pods.Items[i]
+		}
+	}
+	logs, err := c.PodsLog(ctx, latest.Namespace, latest.Name, "", false, tail)
+	return latest.Name, logs, err
+}
@@ -2,8 +2,10 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -28,6 +30,7 @@ const (
 	CustomAuthorizationHeader = HeaderKey("kubernetes-authorization")
 	OAuthAuthorizationHeader  = HeaderKey("Authorization")
 	UserAgentHeader           = HeaderKey("User-Agent")
+	AuditIDHeader             = HeaderKey("Audit-ID")
 
 	CustomUserAgent = "kubernetes-mcp-server/bearer-token-auth"
 )
@@ -50,6 +53,12 @@ type Kubernetes struct {
 	discoveryClient discovery.CachedDiscoveryInterface
 	dynamicClient   dynamic.Interface
 	metricsV1beta1  *metricsv1beta1.MetricsV1beta1Client
+	// watches caps the concurrent watches, shared with the Manager that created the client (unlimited if nil)
+	watches *watchLimiter
+	// checkpoints keeps the resource checkpoints, shared with the Manager that created the client (unsupported if nil)
+	checkpoints *checkpointStore
+	// identity is the hash of the auth material of a derived client, empty for the server credentials
+	identity string
 }
 
 var _ api.KubernetesClient = (*Kubernetes)(nil)
@@ -81,6 +90,9 @@ func NewKubernetes(
 			ConfirmationRulesProvider: baseConfig,
 		})
 	})
+	k.restConfig.Wrap(func(original http.RoundTripper) http.RoundTripper {
+		return &AuditIDRoundTripper{delegate: original}
+	})
 	k.restConfig.Wrap(func(original http.RoundTripper) http.RoundTripper {
 		return &UserAgentRoundTripper{delegate: original}
 	})
@@ -141,6 +153,38 @@ func (k *Kubernetes) MetricsV1beta1Client() *metricsv1beta1.MetricsV1beta1Client
 	return k.metricsV1beta1
 }
 
+// AcquireWatch reserves one of the concurrent watch slots of the cluster, the returned function releases it
+// (calling it more than once is safe). An error is returned if all the slots are in use.
+func (k *Kubernetes) AcquireWatch() (func(), error) {
+	if k.watches == nil {
+		return func() {}, nil
+	}
+	return k.watches.acquire()
+}
+
+// Identity returns the hash of the auth material of a derived client, empty for the server credentials
+func (k *Kubernetes) Identity() string {
+	return k.identity
+}
+
+// saveCheckpoint keeps the serialized resource checkpoint in memory for a limited time and returns the token to
+// load it and its expiration time. An error is returned if the checkpoint is too large.
+func (k *Kubernetes) saveCheckpoint(data []byte) (string, time.Time, error) {
+	if k.checkpoints == nil {
+		return "", time.Time{}, errors.New("checkpoints are not supported by this client")
+	}
+	return k.checkpoints.save(k.identity, data)
+}
+
+// loadCheckpoint returns the serialized resource checkpoint of the token, false if it doesn't exist, expired or
+// was saved with other credentials
+func (k *Kubernetes) loadCheckpoint(token string) ([]byte, bool) {
+	if k.checkpoints == nil {
+		return nil, false
+	}
+	return k.checkpoints.load(k.identity, token)
+}
+
 func (k *Kubernetes) configuredNamespace() string {
 	if ns, _, nsErr := k.ToRawKubeConfigLoader().Namespace(); nsErr == nil {
 		return ns
@@ -27,7 +27,14 @@ type DerivedClientCleanupSuite struct {
 func (s *DerivedClientCleanupSuite) SetupTest() {
 	s.activeConns = make(map[net.Conn]struct{})
 
-	handler := test.NewDiscoveryClientHandler()
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") == "Bearer revoked-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		discoveryHandler.ServeHTTP(w, req)
+	})
 	s.server = httptest.NewUnstartedServer(handler)
 	s.server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
 		s.mu.Lock()
@@ -63,7 +70,7 @@ func (s *DerivedClientCleanupSuite) activeConnCount() int {
 	return len(s.activeConns)
 }
 
-func (s *DerivedClientCleanupSuite) TestClosesIdleConnectionsWhenContextIsCancelled() {
+func (s *DerivedClientCleanupSuite) TestClosesIdleConnectionsWhenManagerIsClosed() {
 	// https://github.com/containers/kubernetes-mcp-server/issues/830
 	// https://github.com/containers/kubernetes-mcp-server/pull/850
 	baseConns := s.activeConnCount()
@@ -88,26 +95,50 @@ func (s *DerivedClientCleanupSuite) TestClosesIdleConnectionsWhenContextIsCancel
 		"expected connections from derived client API calls",
 	)
 
-	// Cancel the context - with a correct fix, this should trigger cleanup
+	// Cached derived clients outlive the request context, closing the manager releases them
 	cancel()
+	s.manager.Close()
 
 	s.Eventually(func() bool {
 		return s.activeConnCount() <= baseConns
 	}, 2*time.Second, 100*time.Millisecond,
-		"expected derived client connections to be closed after context cancellation",
+		"expected derived client connections to be closed after the manager is closed",
 	)
 }
 
-func (s *DerivedClientCleanupSuite) TestMultipleDerivedClientsCleanedUpAfterContextCancellation() {
+func (s *DerivedClientCleanupSuite) TestClosesIdleConnectionsWhenDerivedClientExpires() {
+	ctx := context.WithValue(s.T().Context(), OAuthAuthorizationHeader, "Bearer test-token")
+	derived, err := s.manager.Derived(ctx)
+	s.Require().NoError(err)
+	_, err = derived.DiscoveryClient().ServerGroups()
+	s.Require().NoError(err, "discovery call should succeed against mock server")
+	s.Require().Eventually(func() bool {
+		return s.activeConnCount() > 0
+	}, 2*time.Second, 10*time.Millisecond,
+		"expected connections from derived client API calls",
+	)
+
+	s.manager.derived.now = func() time.Time { return time.Now().Add(derivedCacheTTL) }
+	expired, err := s.manager.Derived(ctx)
+	s.Require().NoError(err)
+
+	s.Run("creates a new derived client", func() {
+		s.NotSame(derived, expired)
+	})
+	s.Run("closes the idle connections of the expired client", func() {
+		s.Eventually(func() bool {
+			return s.activeConnCount() == 0
+		}, 2*time.Second, 100*time.Millisecond,
+			"expected expired derived client connections to be closed",
+		)
+	})
+}
+
+func (s *DerivedClientCleanupSuite) TestReusesDerivedClientsForTheSameIdentity() {
 	// https://github.com/containers/kubernetes-mcp-server/issues/830
-	// https://github.com/containers/kubernetes-mcp-server/pull/850
-	// client-go caches base http.Transport instances by TLS config, so all
-	// derived clients connecting to the same server share the same transport
-	// and TCP connection pool. This test verifies that when ALL derived client
-	// contexts are cancelled, the shared pool's idle connections are cleaned up.
+	// Repeated requests from the same identity must not create (and leak) a new set of clients each time.
 	iterations := 5
-	cancelFuncs := make([]context.CancelFunc, 0, iterations)
-
+	var first *Kubernetes
 	for i := 0; i < iterations; i++ {
 		ctx, cancel := context.WithCancel(context.Background())
 		ctx = context.WithValue(ctx, OAuthAuthorizationHeader, "Bearer test-token")
@@ -115,27 +146,41 @@ func (s *DerivedClientCleanupSuite) TestMultipleDerivedClientsCleanedUpAfterCont
 		derived, err := s.manager.Derived(ctx)
 		s.Require().NoError(err)
 		s.NotEqual(derived, s.manager.kubernetes)
+		if first == nil {
+			first = derived
+		}
+		s.Same(first, derived, "expected the derived client to be reused for the same bearer token")
 
 		_, _ = derived.DiscoveryClient().ServerGroups()
-		cancelFuncs = append(cancelFuncs, cancel)
+		cancel()
 	}
+	s.Equal(1, s.manager.derived.len())
 
-	s.Require().Eventually(func() bool {
-		return s.activeConnCount() > 1
-	}, 2*time.Second, 10*time.Millisecond,
-		"expected multiple connections to exist before cleanup",
-	)
+	s.Run("different bearer token creates a different derived client", func() {
+		ctx := context.WithValue(s.T().Context(), OAuthAuthorizationHeader, "Bearer another-token")
+		derived, err := s.manager.Derived(ctx)
+		s.Require().NoError(err)
+		s.NotSame(first, derived)
+		s.Equal(2, s.manager.derived.len())
+	})
+}
 
-	for _, cancel := range cancelFuncs {
-		cancel()
-	}
+func (s *DerivedClientCleanupSuite) TestInvalidatesDerivedClientOnUnauthorized() {
+	ctx := context.WithValue(s.T().Context(), OAuthAuthorizationHeader, "Bearer revoked-token")
+	derived, err := s.manager.Derived(ctx)
+	s.Require().NoError(err)
 
-	s.Eventually(func() bool {
-		return s.activeConnCount() == 0
-	}, 2*time.Second, 100*time.Millisecond,
-		"expected all shared transport connections to be closed after all "+
-			"derived client contexts are cancelled",
-	)
+	_, err = derived.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	s.Require().Error(err, "expected the Kube API to reject the revoked token")
+
+	s.Run("removes the derived client from the cache", func() {
+		s.Equal(0, s.manager.derived.len())
+	})
+	s.Run("creates a new derived client for the next request", func() {
+		next, err := s.manager.Derived(ctx)
+		s.Require().NoError(err)
+		s.NotSame(derived, next)
+	})
 }
 
 func TestDerivedClientCleanup(t *testing.T) {
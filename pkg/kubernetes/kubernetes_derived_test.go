@@ -144,8 +144,11 @@ users:
 				// Outer layer: UserAgentRoundTripper
 				uaRT, ok := transport.(*UserAgentRoundTripper)
 				s.Require().True(ok, "expected outermost wrapper to be *UserAgentRoundTripper")
+				// Middle layer: AuditIDRoundTripper
+				auditRT, ok := uaRT.delegate.(*AuditIDRoundTripper)
+				s.Require().True(ok, "expected middle wrapper to be *AuditIDRoundTripper")
 				// Inner layer: AccessControlRoundTripper
-				acRT, ok := uaRT.delegate.(*AccessControlRoundTripper)
+				acRT, ok := auditRT.delegate.(*AccessControlRoundTripper)
 				s.Require().True(ok, "expected inner wrapper to be *AccessControlRoundTripper")
 				// Innermost: should be the original transport, NOT another wrapper (regression test for PR #861)
 				_, isUA := acRT.delegate.(*UserAgentRoundTripper)
@@ -308,6 +311,74 @@ users:
 	})
 }
 
+func (s *DerivedTestSuite) TestKubeClientRateLimit() {
+	// Ensure the environment overrides (used by other test packages) don't interfere
+	s.T().Setenv("KUBE_CLIENT_QPS", "")
+	s.T().Setenv("KUBE_CLIENT_BURST", "")
+	kubeconfigPath := filepath.Join(s.T().TempDir(), "config")
+	kubeconfigContent := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://test-cluster.example.com
+  name: test-cluster
+- cluster:
+    server: https://other-cluster.example.com
+  name: other-cluster
+contexts:
+- context:
+    cluster: test-cluster
+    user: test-user
+  name: test-context
+- context:
+    cluster: other-cluster
+    user: test-user
+  name: other-context
+current-context: test-context
+users:
+- name: test-user
+  user:
+    token: a-token
+`
+	s.Require().NoError(os.WriteFile(kubeconfigPath, []byte(kubeconfigContent), 0644), "failed to create kubeconfig file")
+	testStaticConfig := test.Must(config.ReadToml([]byte(`
+		kubeconfig = "` + strings.ReplaceAll(kubeconfigPath, `\`, `\\`) + `"
+		kube_client_qps = 42.5
+		kube_client_burst = 84
+	`)))
+	testManager, err := NewKubeconfigManager(s.T().Context(), testStaticConfig, "")
+	s.Require().NoErrorf(err, "failed to create test manager: %v", err)
+	s.Run("base client uses the configured QPS and Burst", func() {
+		s.Equal(float32(42.5), testManager.kubernetes.RESTConfig().QPS)
+		s.Equal(84, testManager.kubernetes.RESTConfig().Burst)
+	})
+	s.Run("derived client uses the configured QPS and Burst", func() {
+		ctx := context.WithValue(s.T().Context(), HeaderKey("Authorization"), "Bearer aiTana-julIA")
+		derived, err := testManager.Derived(ctx)
+		s.Require().NoErrorf(err, "failed to create derived kubernetes: %v", err)
+		s.NotEqual(derived, testManager.kubernetes, "expected new derived client, got original client")
+		s.Equal(float32(42.5), derived.RESTConfig().QPS)
+		s.Equal(84, derived.RESTConfig().Burst)
+	})
+	s.Run("client for another context uses the configured QPS and Burst", func() {
+		contextManager, err := NewKubeconfigManager(s.T().Context(), testStaticConfig, "other-context")
+		s.Require().NoErrorf(err, "failed to create context manager: %v", err)
+		s.Equal("https://other-cluster.example.com", contextManager.kubernetes.RESTConfig().Host)
+		s.Equal(float32(42.5), contextManager.kubernetes.RESTConfig().QPS)
+		s.Equal(84, contextManager.kubernetes.RESTConfig().Burst)
+	})
+	s.Run("unset QPS and Burst keep the client-go defaults", func() {
+		defaultConfig := test.Must(config.ReadToml([]byte(`
+			kubeconfig = "` + strings.ReplaceAll(kubeconfigPath, `\`, `\\`) + `"
+		`)))
+		defaultManager, err := NewKubeconfigManager(s.T().Context(), defaultConfig, "")
+		s.Require().NoErrorf(err, "failed to create test manager: %v", err)
+		s.Zero(defaultManager.kubernetes.RESTConfig().QPS)
+		s.Zero(defaultManager.kubernetes.RESTConfig().Burst)
+	})
+}
+
 func TestDerived(t *testing.T) {
 	suite.Run(t, new(DerivedTestSuite))
 }
@@ -0,0 +1,203 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// KustomizeOptions are the settings used by ResourcesApplyKustomize to render a kustomization
+type KustomizeOptions struct {
+	// Kustomization is the inline content of the kustomization.yaml file (mutually exclusive with URL)
+	Kustomization string
+	// Files are the additional files referenced by the inline kustomization keyed by their path relative to it
+	Files map[string]string
+	// URL of a remote kustomization directory (e.g. a git repository), requires AllowRemote
+	URL string
+	// AllowRemote enables loading the URL and any remote resource referenced by the kustomization.
+	// When disabled, the kustomization can only reference the provided files.
+	AllowRemote bool
+	// Namespace for namespaced resources whose rendered manifest omits metadata.namespace (the default namespace is used if empty)
+	Namespace string
+	// DeniedResources are checked for every rendered object before any of them is applied
+	DeniedResources api.DeniedResourcesProvider
+}
+
+// ResourcesApplyKustomize renders the provided kustomization and creates or updates the resulting resources.
+// No resource is applied if any of the rendered resources is denied.
+// Returns the namespace that was injected into namespaced resources that didn't specify one (empty if none).
+func (c *Core) ResourcesApplyKustomize(ctx context.Context, opts KustomizeOptions) ([]*unstructured.Unstructured, string, error) {
+	rendered, err := KustomizeBuild(opts)
+	if err != nil {
+		return nil, "", err
+	}
+	parsedResources, injectedNamespace, err := c.parseResources(rendered, opts.Namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, obj := range parsedResources {
+		if gvk := obj.GroupVersionKind(); !isResourceAllowed(opts.DeniedResources, gvk) {
+			return nil, "", fmt.Errorf("resource not allowed: %s (%s)", gvk.String(), obj.GetName())
+		}
+	}
+	applied, err := c.resourcesCreateOrUpdate(ctx, parsedResources, version.BinaryName)
+	return applied, injectedNamespace, err
+}
+
+// KustomizeBuild renders the provided kustomization and returns the resulting multi-document YAML manifest.
+// The kustomization is built from a temporary directory and can't load local files other than the provided ones.
+func KustomizeBuild(opts KustomizeOptions) (string, error) {
+	if (opts.Kustomization == "") == (opts.URL == "") {
+		return "", errors.New("exactly one of kustomization or url must be provided")
+	}
+	if opts.URL != "" && !opts.AllowRemote {
+		return "", errors.New("loading remote kustomizations is disabled")
+	}
+	if opts.URL != "" && len(opts.Files) > 0 {
+		return "", errors.New("files can only be provided with an inline kustomization")
+	}
+	dir, err := os.MkdirTemp("", "kustomize-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	kustomization := opts.Kustomization
+	if opts.URL != "" {
+		remote, marshalErr := yaml.Marshal(types.Kustomization{Resources: []string{opts.URL}})
+		if marshalErr != nil {
+			return "", marshalErr
+		}
+		kustomization = string(remote)
+	}
+	if err = writeKustomizeFiles(dir, kustomization, opts.Files); err != nil {
+		return "", err
+	}
+	if !opts.AllowRemote {
+		if err = kustomizeCheckLocal(dir); err != nil {
+			return "", err
+		}
+	}
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to build kustomization: %w", err)
+	}
+	if resMap.Size() == 0 {
+		return "", errors.New("the kustomization did not render any resources")
+	}
+	rendered, err := resMap.AsYaml()
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
+func writeKustomizeFiles(dir, kustomization string, files map[string]string) error {
+	if err := os.WriteFile(filepath.Join(dir, konfig.DefaultKustomizationFileName()), []byte(kustomization), 0600); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid file name %s, only relative paths within the kustomization are allowed", name)
+		}
+		if slices.Contains(konfig.RecognizedKustomizationFileNames(), filepath.Clean(name)) {
+			return fmt.Errorf("invalid file name %s, the kustomization must be provided inline", name)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kustomizeCheckLocal verifies the kustomizations in dir (and its subdirectories) only reference local files.
+// Resources, components, bases and crds that don't exist locally would be loaded from a remote (git) location,
+// the rest of the referenced files are only loaded remotely if they are http(s) URLs.
+func kustomizeCheckLocal(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !slices.Contains(konfig.RecognizedKustomizationFileNames(), d.Name()) {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var k types.Kustomization
+		if err = yaml.Unmarshal(content, &k); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", strings.TrimPrefix(path, dir+string(filepath.Separator)), err)
+		}
+		for _, ref := range slices.Concat(k.Resources, k.Components, k.Bases, k.Crds) {
+			if _, statErr := os.Stat(filepath.Join(filepath.Dir(path), ref)); isRemoteReference(ref) || statErr != nil {
+				return remoteReferenceError(ref)
+			}
+		}
+		for _, ref := range kustomizeFileReferences(&k) {
+			if isRemoteReference(ref) {
+				return remoteReferenceError(ref)
+			}
+		}
+		return nil
+	})
+}
+
+// kustomizeFileReferences returns the (non resource) file references of a kustomization
+func kustomizeFileReferences(k *types.Kustomization) []string {
+	refs := slices.Concat(k.Configurations, k.Generators, k.Transformers, k.Validators)
+	for _, patch := range slices.Concat(k.Patches, k.PatchesJson6902) {
+		refs = append(refs, patch.Path)
+	}
+	for _, patch := range k.PatchesStrategicMerge {
+		refs = append(refs, string(patch))
+	}
+	for _, replacement := range k.Replacements {
+		refs = append(refs, replacement.Path)
+	}
+	for _, path := range k.OpenAPI {
+		refs = append(refs, path)
+	}
+	sources := make([]types.KvPairSources, 0, len(k.ConfigMapGenerator)+len(k.SecretGenerator))
+	for _, generator := range k.ConfigMapGenerator {
+		sources = append(sources, generator.KvPairSources)
+	}
+	for _, generator := range k.SecretGenerator {
+		sources = append(sources, generator.KvPairSources)
+	}
+	for _, source := range sources {
+		refs = append(refs, source.EnvSource)
+		refs = append(refs, source.EnvSources...)
+		for _, file := range source.FileSources {
+			// files can be specified as key=path
+			refs = append(refs, file[strings.Index(file, "=")+1:])
+		}
+	}
+	return refs
+}
+
+// isRemoteReference returns whether the reference is a URL that kustomize would download
+func isRemoteReference(ref string) bool {
+	u, err := url.Parse(ref)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
+
+func remoteReferenceError(ref string) error {
+	return fmt.Errorf("%s is not one of the provided files and loading remote kustomizations is disabled", ref)
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type KustomizeSuite struct {
+	suite.Suite
+}
+
+func (s *KustomizeSuite) TestKustomizeBuild() {
+	configMap := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  key: value\n"
+	s.Run("renders inline kustomization with provided files", func() {
+		rendered, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "resources:\n- base/configmap.yaml\nnamePrefix: dev-\ncommonLabels:\n  env: dev\n",
+			Files:         map[string]string{"base/configmap.yaml": configMap},
+		})
+		s.Require().NoError(err)
+		s.Contains(rendered, "name: dev-settings")
+		s.Contains(rendered, "env: dev")
+	})
+	s.Run("renders generators and patches", func() {
+		rendered, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "resources:\n- configmap.yaml\n" +
+				"configMapGenerator:\n- name: generated\n  files:\n  - config.properties=app.properties\n" +
+				"patches:\n- path: patch.yaml\n",
+			Files: map[string]string{
+				"configmap.yaml":  configMap,
+				"app.properties":  "level=debug",
+				"patch.yaml":      "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  key: patched\n",
+				"unused/file.txt": "ignored",
+			},
+		})
+		s.Require().NoError(err)
+		s.Contains(rendered, "config.properties: level=debug")
+		s.Contains(rendered, "key: patched")
+	})
+	s.Run("requires exactly one of kustomization or url", func() {
+		_, err := KustomizeBuild(KustomizeOptions{})
+		s.ErrorContains(err, "exactly one of kustomization or url must be provided")
+		_, err = KustomizeBuild(KustomizeOptions{Kustomization: "resources: []", URL: "https://example.com/repo", AllowRemote: true})
+		s.ErrorContains(err, "exactly one of kustomization or url must be provided")
+	})
+	s.Run("fails if no resources are rendered", func() {
+		_, err := KustomizeBuild(KustomizeOptions{Kustomization: "namePrefix: dev-\n"})
+		s.ErrorContains(err, "the kustomization did not render any resources")
+	})
+	s.Run("rejects files outside of the kustomization", func() {
+		_, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "resources:\n- configmap.yaml\n",
+			Files:         map[string]string{"../configmap.yaml": configMap},
+		})
+		s.ErrorContains(err, "invalid file name ../configmap.yaml")
+	})
+	s.Run("rejects resources outside of the kustomization", func() {
+		_, err := KustomizeBuild(KustomizeOptions{Kustomization: "resources:\n- /etc/hosts\n"})
+		s.Error(err)
+		_, err = KustomizeBuild(KustomizeOptions{Kustomization: "resources:\n- ../../../../../../etc/hosts\n", AllowRemote: true})
+		s.ErrorContains(err, "failed to build kustomization")
+	})
+}
+
+func (s *KustomizeSuite) TestKustomizeBuildRemoteDisabled() {
+	s.Run("rejects url", func() {
+		_, err := KustomizeBuild(KustomizeOptions{URL: "https://github.com/kubernetes-sigs/kustomize//examples/helloWorld"})
+		s.ErrorContains(err, "loading remote kustomizations is disabled")
+	})
+	s.Run("rejects remote resources", func() {
+		_, err := KustomizeBuild(KustomizeOptions{Kustomization: "resources:\n- github.com/kubernetes-sigs/kustomize//examples/helloWorld\n"})
+		s.ErrorContains(err, "github.com/kubernetes-sigs/kustomize//examples/helloWorld is not one of the provided files and loading remote kustomizations is disabled")
+	})
+	s.Run("rejects remote resources in nested kustomizations", func() {
+		_, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "resources:\n- base\n",
+			Files:         map[string]string{"base/kustomization.yaml": "resources:\n- https://example.com/configmap.yaml\n"},
+		})
+		s.ErrorContains(err, "https://example.com/configmap.yaml is not one of the provided files")
+	})
+	s.Run("rejects remote patches", func() {
+		_, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "resources:\n- configmap.yaml\npatches:\n- path: https://example.com/patch.yaml\n",
+			Files:         map[string]string{"configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"},
+		})
+		s.ErrorContains(err, "https://example.com/patch.yaml is not one of the provided files")
+	})
+	s.Run("rejects remote generator files", func() {
+		_, err := KustomizeBuild(KustomizeOptions{
+			Kustomization: "configMapGenerator:\n- name: generated\n  files:\n  - key=https://example.com/app.properties\n",
+		})
+		s.ErrorContains(err, "https://example.com/app.properties is not one of the provided files")
+	})
+}
+
+func TestKustomize(t *testing.T) {
+	suite.Run(t, new(KustomizeSuite))
+}
@@ -0,0 +1,70 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LeaseStatus is the leader election state of a Lease (coordination.k8s.io/v1)
+type LeaseStatus struct {
+	Namespace      string
+	Name           string
+	HolderIdentity string
+	// RenewTime is the last time the holder renewed the Lease (nil if never renewed)
+	RenewTime            *time.Time
+	LeaseDurationSeconds *int32
+	LeaseTransitions     int32
+	// Stale is true if the Lease is held but it wasn't renewed within its duration, the holder lost the leadership
+	// or stopped renewing it (e.g. hung, crashed or partitioned from the API server)
+	Stale bool
+}
+
+// LeasesList returns the leader election state of the Leases in the provided namespace, or in all the namespaces if
+// the namespace is empty, sorted by namespace and name.
+// A held Lease is flagged as stale when its renewTime is older than its leaseDurationSeconds.
+func (c *Core) LeasesList(ctx context.Context, namespace string) ([]LeaseStatus, error) {
+	leases, err := c.CoordinationV1().Leases(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ret := make([]LeaseStatus, 0, len(leases.Items))
+	for _, lease := range leases.Items {
+		ret = append(ret, leaseStatus(&lease, now))
+	}
+	slices.SortFunc(ret, func(a, b LeaseStatus) int {
+		if a.Namespace != b.Namespace {
+			return strings.Compare(a.Namespace, b.Namespace)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return ret, nil
+}
+
+func leaseStatus(lease *coordinationv1.Lease, now time.Time) LeaseStatus {
+	ret := LeaseStatus{
+		Namespace:            lease.Namespace,
+		Name:                 lease.Name,
+		LeaseDurationSeconds: lease.Spec.LeaseDurationSeconds,
+	}
+	if lease.Spec.HolderIdentity != nil {
+		ret.HolderIdentity = *lease.Spec.HolderIdentity
+	}
+	if lease.Spec.LeaseTransitions != nil {
+		ret.LeaseTransitions = *lease.Spec.LeaseTransitions
+	}
+	if lease.Spec.RenewTime != nil {
+		ret.RenewTime = &lease.Spec.RenewTime.Time
+	}
+	// A released Lease (no holder) is not stale, it's waiting for a candidate to acquire it
+	if ret.HolderIdentity != "" && ret.LeaseDurationSeconds != nil {
+		expiry := time.Duration(*ret.LeaseDurationSeconds) * time.Second
+		ret.Stale = ret.RenewTime == nil || now.Sub(*ret.RenewTime) > expiry
+	}
+	return ret
+}
@@ -0,0 +1,207 @@
+package kubernetes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultLogsSearchSinceMinutes is the default time window of LogsSearch
+	DefaultLogsSearchSinceMinutes = 60
+	// DefaultLogsSearchContextLines is the default number of lines returned before and after each match
+	DefaultLogsSearchContextLines = 2
+	// MaxLogsSearchContextLines caps the number of lines returned before and after each match
+	MaxLogsSearchContextLines = 10
+	// MaxLogsSearchMatches caps the number of matching lines returned by LogsSearch
+	MaxLogsSearchMatches = 200
+	// MaxLogsSearchScanBytes bounds the logs scanned for each container (the most recent lines of larger logs are not
+	// scanned and the time from which they were skipped is reported in a warning)
+	MaxLogsSearchScanBytes = 8 * 1024 * 1024
+	// MaxLogsSearchContainers caps the number of containers a workload search fans out to
+	MaxLogsSearchContainers = 20
+	// maxLogsSearchLineBytes bounds the length of a single log line
+	maxLogsSearchLineBytes = 1024 * 1024
+)
+
+// LogsSearchOptions are the options of LogsSearch
+type LogsSearchOptions struct {
+	// Container restricts the search to a container (all the containers of the Pods if empty)
+	Container string
+	// Pattern is the regular expression the returned lines must match
+	Pattern *regexp.Regexp
+	// SinceMinutes is the time window of the logs to search
+	SinceMinutes int64
+	// ContextLines is the number of lines to return before and after each match
+	ContextLines int
+}
+
+// LogsSearchResult is the result of LogsSearch
+type LogsSearchResult struct {
+	// Output contains the matching lines (prefixed with >) and their context, prefixed with [pod/container],
+	// non-contiguous blocks are separated by --
+	Output string
+	// Matches is the number of matching lines returned
+	Matches int
+	// Containers is the number of containers searched
+	Containers int
+	// LimitReached is true if further matches were dropped after MaxLogsSearchMatches
+	LimitReached bool
+	// Warnings are the containers whose logs couldn't be retrieved or were only partially scanned, and the containers
+	// left out of the search past MaxLogsSearchContainers
+	Warnings []string
+}
+
+// LogsSearch retrieves the logs of the target within the time window and returns only the lines matching the pattern
+// with their surrounding context lines. The target is a Pod name (optionally prefixed with pod/) or a workload
+// (deployment/name, statefulset/name or daemonset/name), in which case the logs of all its Pods are searched.
+// At most MaxLogsSearchContainers containers are searched, the logs of each container are scanned up to
+// MaxLogsSearchScanBytes and at most MaxLogsSearchMatches are returned.
+func (c *Core) LogsSearch(ctx context.Context, namespace, target string, options LogsSearchOptions) (*LogsSearchResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pods, err := c.logsSearchPods(ctx, namespace, target)
+	if err != nil {
+		return nil, err
+	}
+	type podContainer struct {
+		pod       *v1.Pod
+		container string
+	}
+	var containers []podContainer
+	for i := range pods {
+		for _, container := range containerNames(pods[i]) {
+			if options.Container == "" || container == options.Container {
+				containers = append(containers, podContainer{pod: &pods[i], container: container})
+			}
+		}
+	}
+	if len(containers) == 0 && options.Container != "" {
+		return nil, fmt.Errorf("container %s not found in %s", options.Container, target)
+	}
+	ret := &LogsSearchResult{}
+	sb := strings.Builder{}
+	for _, pc := range containers {
+		if ret.LimitReached {
+			break
+		}
+		if ret.Containers >= MaxLogsSearchContainers {
+			ret.Warnings = append(ret.Warnings, fmt.Sprintf("only %d of the %d containers of %s were searched, "+
+				"provide the container parameter or search a single Pod to narrow the search", ret.Containers, len(containers), target))
+			break
+		}
+		ret.Containers++
+		c.logsSearchContainer(ctx, pc.pod, pc.container, options, ret, &sb)
+	}
+	ret.Output = sb.String()
+	return ret, nil
+}
+
+// logsSearchPods resolves the Pods of the target (pod/name, name or kind/name of a workload)
+func (c *Core) logsSearchPods(ctx context.Context, namespace, target string) ([]v1.Pod, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		kind, name = "pod", target
+	}
+	if strings.EqualFold(kind, "pod") {
+		pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return []v1.Pod{*pod}, nil
+	}
+	selector, err := c.workloadSelector(ctx, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// containerNames returns the names of the init and regular containers of the Pod
+func containerNames(pod v1.Pod) []string {
+	var containers []string
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, container.Name)
+	}
+	return containers
+}
+
+// logsSearchContainer scans the logs of the container appending the matching lines and their context to sb.
+// Errors are reported as warnings so that a single failing container doesn't prevent the rest of the search.
+// Once MaxLogsSearchMatches is reached the scan continues to complete the context of the last match and stops at the
+// next matching line, which is dropped and sets LimitReached.
+func (c *Core) logsSearchContainer(ctx context.Context, pod *v1.Pod, container string, options LogsSearchOptions, ret *LogsSearchResult, sb *strings.Builder) {
+	prefix := fmt.Sprintf("[%s/%s]", pod.Name, container)
+	sinceSeconds := options.SinceMinutes * 60
+	raw, err := c.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container:    container,
+		SinceSeconds: &sinceSeconds,
+		Timestamps:   true,
+	}).Stream(ctx)
+	if err != nil {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("%s error retrieving logs: %v", prefix, err))
+		return
+	}
+	defer func() { _ = raw.Close() }()
+	limited := &io.LimitedReader{R: raw, N: MaxLogsSearchScanBytes}
+	scanner := bufio.NewScanner(limited)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogsSearchLineBytes)
+	var before []string
+	after := 0
+	lastWritten, lineNumber := -1, 0
+	var lastScanned time.Time
+	for scanner.Scan() {
+		line := scanner.Text()
+		if timestamp, rest, found := strings.Cut(line, " "); found {
+			if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+				line, lastScanned = rest, t
+			}
+		}
+		matched := options.Pattern.MatchString(line)
+		switch {
+		case matched && ret.Matches >= MaxLogsSearchMatches:
+			ret.LimitReached = true
+			return
+		case matched:
+			if sb.Len() > 0 && (lastWritten < 0 || lineNumber-len(before) > lastWritten+1) {
+				sb.WriteString("--\n")
+			}
+			for _, contextLine := range before {
+				_, _ = fmt.Fprintf(sb, "%s   %s\n", prefix, contextLine)
+			}
+			_, _ = fmt.Fprintf(sb, "%s > %s\n", prefix, line)
+			before, after, lastWritten = nil, options.ContextLines, lineNumber
+			ret.Matches++
+		case after > 0:
+			_, _ = fmt.Fprintf(sb, "%s   %s\n", prefix, line)
+			after, lastWritten = after-1, lineNumber
+		case ret.Matches < MaxLogsSearchMatches && options.ContextLines > 0:
+			before = append(before, line)
+			if len(before) > options.ContextLines {
+				before = before[1:]
+			}
+		}
+		lineNumber++
+	}
+	if err = scanner.Err(); err != nil {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("%s error reading logs: %v", prefix, err))
+	} else if limited.N <= 0 && lastScanned.IsZero() {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("%s only the first %d bytes of the logs in the time window were scanned, reduce sinceMinutes to search the most recent lines", prefix, MaxLogsSearchScanBytes))
+	} else if limited.N <= 0 {
+		ret.Warnings = append(ret.Warnings, fmt.Sprintf("%s only the first %d bytes of the logs in the time window were scanned, the lines logged after %s were not searched, "+
+			"set sinceMinutes to %d or less to search them", prefix, MaxLogsSearchScanBytes, lastScanned.UTC().Format(time.RFC3339), max(int64(time.Since(lastScanned)/time.Minute), 1)))
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -18,6 +21,12 @@ import (
 
 type Manager struct {
 	kubernetes *Kubernetes
+	// derived caches the clients created for the per-request auth headers
+	derived *derivedCache
+	// watches caps the concurrent watches of the manager and its derived clients
+	watches *watchLimiter
+	// checkpoints keeps the resource checkpoints of the manager and its derived clients
+	checkpoints *checkpointStore
 
 	config api.BaseConfig
 }
@@ -128,9 +137,16 @@ func NewInClusterManager(ctx context.Context, config api.BaseConfig) (*Manager,
 	clientCmdConfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{
 		Token: restConfig.BearerToken,
 	}
+	// Reference the projected service account token file instead of its one-time read content so that it's rotated
+	if restConfig.BearerTokenFile != "" {
+		clientCmdConfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{
+			TokenFile: restConfig.BearerTokenFile,
+		}
+	}
 	clientCmdConfig.Contexts[inClusterKubeConfigDefaultContext] = &clientcmdapi.Context{
-		Cluster:  "cluster",
-		AuthInfo: "user",
+		LocationOfOrigin: inClusterKubeConfigOrigin,
+		Cluster:          "cluster",
+		AuthInfo:         "user",
 	}
 	clientCmdConfig.CurrentContext = inClusterKubeConfigDefaultContext
 
@@ -148,11 +164,38 @@ func NewManager(ctx context.Context, config api.BaseConfig, restConfig *rest.Con
 		return nil, errors.New("clientCmdConfig cannot be nil")
 	}
 
+	// Apply the configured QPS and Burst, the derived and per-context clients inherit them from this rest.Config
+	if qps := config.GetKubeClientQPS(); qps > 0 {
+		restConfig.QPS = qps
+	}
+	if burst := config.GetKubeClientBurst(); burst > 0 {
+		restConfig.Burst = burst
+	}
+	// The typed clients negotiate protobuf for the built-in kinds unless a content type is set, the dynamic clients
+	// (unstructured objects and custom resources) always use JSON
+	if config.GetKubeClientContentType() == api.KubeClientContentTypeJSON {
+		restConfig.ContentType = runtime.ContentTypeJSON
+		restConfig.AcceptContentTypes = runtime.ContentTypeJSON
+	}
 	// Apply QPS and Burst from environment variables if set (primarily for testing)
 	applyRateLimitFromEnv(restConfig)
 
+	// Authenticate with the configured token file, client-go re-reads it periodically (the token is never read once and cached)
+	if bearerTokenFile := config.GetBearerTokenFile(); bearerTokenFile != "" {
+		restConfig.BearerToken = ""
+		restConfig.BearerTokenFile = bearerTokenFile
+		// The token replaces the rest of the kubeconfig token-based credentials
+		restConfig.Username = ""
+		restConfig.Password = ""
+		restConfig.ExecProvider = nil
+		restConfig.AuthProvider = nil
+	}
+
 	k8s := &Manager{
-		config: config,
+		config:      config,
+		derived:     newDerivedCache(derivedCacheSize, derivedCacheTTL),
+		watches:     newWatchLimiter(config.GetMaxConcurrentWatches()),
+		checkpoints: newCheckpointStore(checkpointStoreSize, checkpointTTL, checkpointMaxBytes),
 	}
 	var err error
 	// TODO: Won't work because not all client-go clients use the shared context (e.g. discovery client uses context.TODO())
@@ -163,6 +206,8 @@ func NewManager(ctx context.Context, config api.BaseConfig, restConfig *rest.Con
 	if err != nil {
 		return nil, err
 	}
+	k8s.kubernetes.watches = k8s.watches
+	k8s.kubernetes.checkpoints = k8s.checkpoints
 	return k8s, nil
 }
 
@@ -186,9 +231,11 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 	}
 
 	logger.V(5).Info("Authorization header found (Bearer), using provided bearer token")
-	userAgent := CustomUserAgent
-	if ua, ok := ctx.Value(UserAgentHeader).(string); ok && ua != "" {
-		userAgent = ua
+	bearerToken := strings.TrimPrefix(authorization, "Bearer ")
+	cacheKey := derivedCacheKey(m.kubernetes.RESTConfig().Host, bearerToken)
+	if derived := m.derived.get(cacheKey); derived != nil {
+		logger.V(5).Info("Reusing cached derived client for the provided bearer token")
+		return derived, nil
 	}
 	derivedCfg := &rest.Config{
 		Host:    m.kubernetes.RESTConfig().Host,
@@ -200,14 +247,24 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 			CAFile:     m.kubernetes.RESTConfig().CAFile,
 			CAData:     m.kubernetes.RESTConfig().CAData,
 		},
-		BearerToken: strings.TrimPrefix(authorization, "Bearer "),
-		// pass custom UserAgent to identify the client
-		UserAgent:   userAgent,
+		BearerToken: bearerToken,
+		// The cached client is shared by the requests with the same token, the per-request User-Agent is set by the
+		// UserAgentRoundTripper from the request context, this one is only used for requests without it
+		UserAgent:   CustomUserAgent,
 		QPS:         m.kubernetes.RESTConfig().QPS,
 		Burst:       m.kubernetes.RESTConfig().Burst,
 		Timeout:     m.kubernetes.RESTConfig().Timeout,
 		Impersonate: rest.ImpersonationConfig{},
+		// Honor the configured kube_client_content_type
+		ContentConfig: rest.ContentConfig{
+			ContentType:        m.kubernetes.RESTConfig().ContentType,
+			AcceptContentTypes: m.kubernetes.RESTConfig().AcceptContentTypes,
+		},
 	}
+	// Rejected credentials (e.g. expired or revoked token) must not be reused
+	derivedCfg.Wrap(func(original http.RoundTripper) http.RoundTripper {
+		return &unauthorizedRoundTripper{delegate: original, onUnauthorized: func() { m.derived.remove(cacheKey) }}
+	})
 	clientCmdApiConfig, err := m.kubernetes.clientCmdConfig.RawConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -217,17 +274,39 @@ func (m *Manager) Derived(ctx context.Context) (*Kubernetes, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create derived client: %w", err)
 	}
-	context.AfterFunc(ctx, derived.close)
+	derived.watches = m.watches
+	derived.checkpoints = m.checkpoints
+	derived.identity = cacheKey
+	// Cached clients release their idle connections when evicted, expired, invalidated or when the manager is closed
+	m.derived.add(cacheKey, derived)
 	return derived, nil
 }
 
+// CheckReadiness verifies the Kubernetes API is reachable by requesting its /version endpoint.
+// The server's own (kubeconfig or in-cluster) credentials are used, never the per-request ones.
+func (m *Manager) CheckReadiness(ctx context.Context) error {
+	return m.kubernetes.DiscoveryClient().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
 // Close releases HTTP transport resources held by this manager.
 func (m *Manager) Close() {
 	if m != nil {
+		m.derived.close()
 		m.kubernetes.close()
 	}
 }
 
+// HasGVKs uses the cluster's REST mapper to verify that every requested GVK is available.
+func (m *Manager) HasGVKs(gvks []schema.GroupVersionKind) bool {
+	mapper := m.kubernetes.RESTMapper()
+	for _, gvk := range gvks {
+		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // Invalidate invalidates the cached discovery information.
 func (m *Manager) Invalidate() {
 	m.kubernetes.DiscoveryClient().Invalidate()
@@ -1,14 +1,21 @@
 package kubernetes
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -53,6 +60,25 @@ func (s *ManagerTestSuite) TestNewInClusterManager() {
 				s.Contains(manager.kubernetes.RESTConfig().UserAgent, "("+runtime.GOOS+"/"+runtime.GOARCH+")")
 			})
 		})
+		s.Run("with service account token file", func() {
+			InClusterConfig = func() (*rest.Config, error) {
+				return &rest.Config{BearerToken: "one-time-read", BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token"}, nil
+			}
+			manager, err := NewInClusterManager(s.T().Context(), &config.StaticConfig{})
+			s.Require().NoError(err)
+			s.Run("rest config keeps the token file", func() {
+				s.Equal("/var/run/secrets/kubernetes.io/serviceaccount/token", manager.kubernetes.RESTConfig().BearerTokenFile)
+			})
+			s.Run("kubeconfig references the token file", func() {
+				rawConfig, err := manager.kubernetes.ToRawKubeConfigLoader().RawConfig()
+				s.Require().NoError(err)
+				s.Equal("/var/run/secrets/kubernetes.io/serviceaccount/token", rawConfig.AuthInfos["user"].TokenFile)
+				s.Empty(rawConfig.AuthInfos["user"].Token)
+			})
+			InClusterConfig = func() (*rest.Config, error) {
+				return &rest.Config{}, nil
+			}
+		})
 		s.Run("with explicit kubeconfig", func() {
 			manager, err := NewInClusterManager(s.T().Context(), &config.StaticConfig{
 				KubeConfig: s.mockServer.KubeconfigFile(s.T()),
@@ -265,6 +291,216 @@ func (s *ManagerTestSuite) TestNewManager() {
 	})
 }
 
+func (s *ManagerTestSuite) TestBearerTokenFile() {
+	InClusterConfig = func() (*rest.Config, error) {
+		return nil, rest.ErrNotInCluster
+	}
+	var authorization atomic.Value
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/version" {
+			authorization.Store(req.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"36"}`))
+		}
+	}))
+	tokenFile := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(tokenFile, []byte("initial-token"), 0o600))
+	manager, err := NewKubeconfigManager(s.T().Context(), &config.StaticConfig{
+		KubeConfig:      s.mockServer.KubeconfigFile(s.T()),
+		BearerTokenFile: tokenFile,
+	}, "")
+	s.Require().NoError(err)
+	s.Require().NotNil(manager)
+	s.Run("rest config references the token file instead of its content", func() {
+		s.Equal(tokenFile, manager.kubernetes.RESTConfig().BearerTokenFile)
+		s.Empty(manager.kubernetes.RESTConfig().BearerToken)
+	})
+	s.Run("authenticates with the token in the file", func() {
+		s.Require().NoError(manager.CheckReadiness(s.T().Context()))
+		s.Equal("Bearer initial-token", authorization.Load())
+	})
+	s.Run("picks up the rotated token", func() {
+		// Simulate the rotation of the token by replacing the file (as kubelet does for projected volumes)
+		rotated := tokenFile + ".rotated"
+		s.Require().NoError(os.WriteFile(rotated, []byte("rotated-token"), 0o600))
+		s.Require().NoError(os.Rename(rotated, tokenFile))
+		// client-go caches the token read from the file for a minute, clients created after the rotation read it again
+		httpClient, err := rest.HTTPClientFor(manager.kubernetes.RESTConfig())
+		s.Require().NoError(err)
+		res, err := httpClient.Get(manager.kubernetes.RESTConfig().Host + "/version")
+		s.Require().NoError(err)
+		_ = res.Body.Close()
+		s.Equal("Bearer rotated-token", authorization.Load())
+	})
+}
+
+func (s *ManagerTestSuite) TestKubeClientContentType() {
+	InClusterConfig = func() (*rest.Config, error) {
+		return nil, rest.ErrNotInCluster
+	}
+	var accept sync.Map
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods", "/api/v1/namespaces/derived/pods":
+			accept.Store(req.URL.Path, req.Header.Get("Accept"))
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		case "/api/v1/nodes":
+			accept.Store(req.URL.Path, req.Header.Get("Accept"))
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[]}`))
+		}
+	}))
+	list := func(contentType string) {
+		accept.Clear()
+		manager, err := NewKubeconfigManager(s.T().Context(), &config.StaticConfig{
+			KubeConfig:            s.mockServer.KubeconfigFile(s.T()),
+			KubeClientContentType: contentType,
+		}, "")
+		s.Require().NoError(err)
+		_, err = manager.kubernetes.CoreV1().Pods("default").List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+		_, err = manager.kubernetes.DynamicClient().Resource(schema.GroupVersionResource{Version: "v1", Resource: "nodes"}).List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+		derived, err := manager.Derived(context.WithValue(s.T().Context(), OAuthAuthorizationHeader, "Bearer test-token"))
+		s.Require().NoError(err)
+		s.Require().NotSame(manager.kubernetes, derived)
+		_, err = derived.CoreV1().Pods("derived").List(s.T().Context(), metav1.ListOptions{})
+		s.Require().NoError(err)
+	}
+	s.Run("default content type", func() {
+		list("")
+		s.Run("typed clients negotiate protobuf", func() {
+			value, _ := accept.Load("/api/v1/namespaces/default/pods")
+			s.Equal("application/vnd.kubernetes.protobuf,application/json", value)
+		})
+		s.Run("derived typed clients negotiate protobuf", func() {
+			value, _ := accept.Load("/api/v1/namespaces/derived/pods")
+			s.Equal("application/vnd.kubernetes.protobuf,application/json", value)
+		})
+		s.Run("dynamic clients use JSON", func() {
+			value, _ := accept.Load("/api/v1/nodes")
+			s.Equal("application/json", value)
+		})
+	})
+	s.Run("protobuf content type negotiates protobuf in typed clients", func() {
+		list("protobuf")
+		value, _ := accept.Load("/api/v1/namespaces/default/pods")
+		s.Equal("application/vnd.kubernetes.protobuf,application/json", value)
+	})
+	s.Run("json content type", func() {
+		list("json")
+		s.Run("typed clients use JSON", func() {
+			value, _ := accept.Load("/api/v1/namespaces/default/pods")
+			s.Equal("application/json", value)
+		})
+		s.Run("derived typed clients use JSON", func() {
+			value, _ := accept.Load("/api/v1/namespaces/derived/pods")
+			s.Equal("application/json", value)
+		})
+		s.Run("dynamic clients use JSON", func() {
+			value, _ := accept.Load("/api/v1/nodes")
+			s.Equal("application/json", value)
+		})
+	})
+}
+
+func (s *ManagerTestSuite) TestDerivedUserAgent() {
+	InClusterConfig = func() (*rest.Config, error) {
+		return nil, rest.ErrNotInCluster
+	}
+	var userAgent atomic.Value
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v1/namespaces/default/pods" {
+			userAgent.Store(req.Header.Get("User-Agent"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		}
+	}))
+	manager, err := NewKubeconfigManager(s.T().Context(), &config.StaticConfig{KubeConfig: s.mockServer.KubeconfigFile(s.T())}, "")
+	s.Require().NoError(err)
+	list := func(ctx context.Context) string {
+		ctx = context.WithValue(ctx, OAuthAuthorizationHeader, "Bearer test-token")
+		derived, err := manager.Derived(ctx)
+		s.Require().NoError(err)
+		_, err = derived.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		s.Require().NoError(err)
+		return userAgent.Load().(string)
+	}
+	s.Run("requests with the same token use their own User-Agent", func() {
+		s.Equal("first-client/1.0", list(context.WithValue(s.T().Context(), UserAgentHeader, "first-client/1.0")))
+		s.Equal("second-client/2.0", list(context.WithValue(s.T().Context(), UserAgentHeader, "second-client/2.0")))
+	})
+	s.Run("requests without User-Agent don't reuse the one of a previous request", func() {
+		s.Equal(CustomUserAgent, list(s.T().Context()))
+	})
+}
+
+func (s *ManagerTestSuite) TestMaxConcurrentWatches() {
+	InClusterConfig = func() (*rest.Config, error) {
+		return nil, rest.ErrNotInCluster
+	}
+	s.Run("defaults to DefaultMaxConcurrentWatches", func() {
+		manager, err := NewKubeconfigManager(s.T().Context(), &config.StaticConfig{KubeConfig: s.mockServer.KubeconfigFile(s.T())}, "")
+		s.Require().NoError(err)
+		s.Equal(api.DefaultMaxConcurrentWatches, manager.watches.max)
+	})
+	manager, err := NewKubeconfigManager(s.T().Context(), &config.StaticConfig{
+		KubeConfig:           s.mockServer.KubeconfigFile(s.T()),
+		MaxConcurrentWatches: 2,
+	}, "")
+	s.Require().NoError(err)
+	derived, err := manager.Derived(context.WithValue(s.T().Context(), OAuthAuthorizationHeader, "Bearer test-token"))
+	s.Require().NoError(err)
+	s.Require().NotSame(manager.kubernetes, derived)
+	s.Run("manager and derived clients share the limit", func() {
+		releaseManager, err := manager.kubernetes.AcquireWatch()
+		s.Require().NoError(err)
+		releaseDerived, err := derived.AcquireWatch()
+		s.Require().NoError(err)
+		s.Equal(2, manager.watches.inUse())
+		s.Run("rejects watches above the limit", func() {
+			_, err := derived.AcquireWatch()
+			s.Require().Error(err)
+			s.ErrorIs(err, ErrorTooManyWatches)
+			s.Contains(err.Error(), "all the 2 watches allowed by the server are in use")
+			s.Equal(2, manager.watches.inUse())
+		})
+		s.Run("releasing more than once frees a single slot", func() {
+			releaseManager()
+			releaseManager()
+			s.Equal(1, manager.watches.inUse())
+			release, err := manager.kubernetes.AcquireWatch()
+			s.Require().NoError(err)
+			release()
+		})
+		releaseDerived()
+		s.Equal(0, manager.watches.inUse())
+	})
+	s.Run("counter is accurate under concurrency", func() {
+		var wg sync.WaitGroup
+		var acquired, rejected atomic.Int32
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := derived.AcquireWatch()
+				if err != nil {
+					rejected.Add(1)
+					return
+				}
+				acquired.Add(1)
+				s.LessOrEqual(manager.watches.inUse(), 2)
+				release()
+			}()
+		}
+		wg.Wait()
+		s.Equal(int32(50), acquired.Load()+rejected.Load())
+		s.Equal(0, manager.watches.inUse())
+	})
+}
+
 func TestManager(t *testing.T) {
 	suite.Run(t, new(ManagerTestSuite))
 }
@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	istioInjectionLabel   = "istio-injection"
+	istioRevisionLabel    = "istio.io/rev"
+	istioDataplaneLabel   = "istio.io/dataplane-mode"
+	istioSidecarInjectKey = "sidecar.istio.io/inject"
+	// IstioProxyContainer is the name of the sidecar container injected by Istio
+	IstioProxyContainer   = "istio-proxy"
+	meshInjectionEnabled  = "enabled"
+	meshInjectionAmbient  = "ambient"
+	meshInjectionDisabled = "disabled"
+	meshInjectionNone     = "none"
+	// MeshPodCompliant is the status of the pods running the sidecar
+	MeshPodCompliant = "Compliant"
+	// MeshPodNonCompliant is the status of the pods missing the sidecar despite being in an injected namespace or opted in
+	MeshPodNonCompliant = "NonCompliant"
+	// MeshPodExcluded is the status of the pods explicitly excluded from the injection
+	MeshPodExcluded = "Excluded"
+	// MeshPodAmbient is the status of the pods of an ambient mode namespace, which don't require the sidecar
+	MeshPodAmbient = "Ambient"
+	// meshInjectionPageSize is the page size used to list the pods, so that large clusters aren't listed at once
+	meshInjectionPageSize = 500
+)
+
+// MeshInjectionStatus is the Istio sidecar injection status of the namespaces and of their pods
+type MeshInjectionStatus struct {
+	Namespaces []MeshNamespaceInjection
+	// Pods in the mesh (namespace with injection enabled or opted in), only the NonCompliant ones if requested
+	Pods []MeshPodInjection
+	// Missing is the number of pods missing the sidecar
+	Missing int
+}
+
+// MeshNamespaceInjection is the injection mode of a namespace (enabled, revision <rev>, ambient, disabled or none)
+// along with the sidecar counts of its pods
+type MeshNamespaceInjection struct {
+	Name           string
+	Injection      string
+	Pods           int
+	WithSidecar    int
+	MissingSidecar int
+}
+
+// MeshPodInjection is the sidecar injection status of a pod in the mesh
+type MeshPodInjection struct {
+	Namespace string
+	Name      string
+	Status    string
+	Sidecar   bool
+	Reason    string
+}
+
+// MeshInjectionStatus returns the Istio sidecar injection status of the namespace (all namespaces if empty) and of
+// its running pods, flagging the pods missing the istio-proxy sidecar despite being in an injected namespace.
+// The pods are listed in pages and only the pods in the mesh are retained.
+func (c *Core) MeshInjectionStatus(ctx context.Context, namespace string, nonCompliantOnly bool) (*MeshInjectionStatus, error) {
+	var namespaces []v1.Namespace
+	if namespace != "" {
+		ns, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		namespaces = append(namespaces, *ns)
+	} else {
+		list, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		namespaces = list.Items
+	}
+	slices.SortFunc(namespaces, func(a, b v1.Namespace) int { return strings.Compare(a.Name, b.Name) })
+	ret := &MeshInjectionStatus{Namespaces: make([]MeshNamespaceInjection, 0, len(namespaces))}
+	namespaceIndex := make(map[string]int, len(namespaces))
+	for i, ns := range namespaces {
+		namespaceIndex[ns.Name] = i
+		ret.Namespaces = append(ret.Namespaces, MeshNamespaceInjection{Name: ns.Name, Injection: namespaceInjection(&ns)})
+	}
+	options := metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed", Limit: meshInjectionPageSize}
+	for {
+		pods, err := c.CoreV1().Pods(namespace).List(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		for _, pod := range pods.Items {
+			i, ok := namespaceIndex[pod.Namespace]
+			if !ok {
+				continue
+			}
+			ns := &ret.Namespaces[i]
+			status := podInjectionStatus(&pod, ns.Injection)
+			ns.Pods++
+			if status.Sidecar {
+				ns.WithSidecar++
+			}
+			if status.Status == MeshPodNonCompliant {
+				ns.MissingSidecar++
+				ret.Missing++
+			}
+			if status.Status == "" || (nonCompliantOnly && status.Status != MeshPodNonCompliant) {
+				continue
+			}
+			ret.Pods = append(ret.Pods, status)
+		}
+		if options.Continue = pods.Continue; options.Continue == "" {
+			break
+		}
+	}
+	slices.SortStableFunc(ret.Pods, func(a, b MeshPodInjection) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	return ret, nil
+}
+
+// namespaceInjection returns the injection mode of the namespace: enabled, revision <rev>, ambient, disabled or none.
+// The istio-injection label takes precedence over the istio.io/rev label, as in the Istio injection webhook selectors.
+func namespaceInjection(ns *v1.Namespace) string {
+	switch value, ok := ns.Labels[istioInjectionLabel]; {
+	case ok && value == meshInjectionEnabled:
+		return meshInjectionEnabled
+	case ok:
+		return meshInjectionDisabled
+	}
+	if revision, ok := ns.Labels[istioRevisionLabel]; ok {
+		return "revision " + revision
+	}
+	if ns.Labels[istioDataplaneLabel] == meshInjectionAmbient {
+		return meshInjectionAmbient
+	}
+	return meshInjectionNone
+}
+
+// podInjectionStatus evaluates whether the pod is expected to have the sidecar, the status is empty for the pods
+// outside the mesh (namespace without injection and no sidecar.istio.io/inject=true opt-in)
+func podInjectionStatus(pod *v1.Pod, injection string) MeshPodInjection {
+	status := MeshPodInjection{Namespace: pod.Namespace, Name: pod.Name, Sidecar: hasIstioProxy(pod)}
+	inject, ok := pod.Labels[istioSidecarInjectKey]
+	if !ok {
+		inject = pod.Annotations[istioSidecarInjectKey]
+	}
+	switch {
+	case status.Sidecar:
+		status.Status = MeshPodCompliant
+	case injection == meshInjectionDisabled || (injection == meshInjectionNone && inject != "true"):
+		return status
+	case inject == "false":
+		status.Status, status.Reason = MeshPodExcluded, istioSidecarInjectKey+"=false"
+	case pod.Spec.HostNetwork:
+		status.Status, status.Reason = MeshPodExcluded, "host network pods are not injected"
+	case injection == meshInjectionAmbient:
+		status.Status, status.Reason = MeshPodAmbient, "ambient mode, no sidecar required"
+	case injection == meshInjectionNone:
+		status.Status, status.Reason = MeshPodNonCompliant, fmt.Sprintf("%s=true but no %s container", istioSidecarInjectKey, IstioProxyContainer)
+	default:
+		status.Status, status.Reason = MeshPodNonCompliant, fmt.Sprintf("no %s container, likely created before injection was enabled", IstioProxyContainer)
+	}
+	return status
+}
+
+// hasIstioProxy checks for the sidecar container, native sidecars are injected as init containers
+func hasIstioProxy(pod *v1.Pod) bool {
+	for _, container := range slices.Concat(pod.Spec.Containers, pod.Spec.InitContainers) {
+		if container.Name == IstioProxyContainer {
+			return true
+		}
+	}
+	return false
+}
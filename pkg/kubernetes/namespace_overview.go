@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// NamespaceOverviewKinds are the kinds listed by NamespaceOverview when scan_kinds is not configured
+// (the kinds listed by kubectl get all)
+var NamespaceOverviewKinds = []string{
+	"Pod",
+	"Service",
+	"DaemonSet.apps",
+	"Deployment.apps",
+	"ReplicaSet.apps",
+	"StatefulSet.apps",
+	"Job.batch",
+}
+
+// NamespaceOverviewList is the list of the resources of a kind in the namespace
+type NamespaceOverviewList struct {
+	GroupVersionKind schema.GroupVersionKind
+	List             runtime.Unstructured
+	// Err is the error (e.g. forbidden) that prevented listing the resources of this kind
+	Err error
+}
+
+// NamespaceOverview lists the resources of the provided kinds (NamespaceOverviewKinds if none is provided) in the
+// namespace, similar to kubectl get all.
+// Kinds without resources are omitted, a kind that can't be listed is reported with its error instead of failing the
+// whole overview.
+func (c *Core) NamespaceOverview(ctx context.Context, namespace string, kinds []string, options api.ListOptions) []NamespaceOverviewList {
+	if len(kinds) == 0 {
+		kinds = NamespaceOverviewKinds
+	}
+	namespace = c.NamespaceOrDefault(namespace)
+	ret := make([]NamespaceOverviewList, 0)
+	for _, gvr := range c.NamespacedScanResources(kinds) {
+		gvk, err := c.RESTMapper().KindFor(gvr)
+		if err != nil {
+			continue
+		}
+		list, err := c.ResourcesList(ctx, &gvk, namespace, options)
+		if err != nil {
+			ret = append(ret, NamespaceOverviewList{GroupVersionKind: gvk, Err: err})
+			continue
+		}
+		if namespaceOverviewListEmpty(list) {
+			continue
+		}
+		ret = append(ret, NamespaceOverviewList{GroupVersionKind: gvk, List: list})
+	}
+	return ret
+}
+
+// namespaceOverviewListEmpty returns true if the list (a regular list or a Table) has no items
+func namespaceOverviewListEmpty(list runtime.Unstructured) bool {
+	switch l := list.(type) {
+	case *unstructured.UnstructuredList:
+		return len(l.Items) == 0
+	case *unstructured.Unstructured:
+		rows, _, _ := unstructured.NestedSlice(l.Object, "rows")
+		return len(rows) == 0
+	}
+	return false
+}
@@ -2,18 +2,103 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// podSecurityLabelPrefix is the prefix of the namespace labels configuring the PodSecurity admission modes
+const podSecurityLabelPrefix = "pod-security.kubernetes.io/"
+
+// PodSecurityLevels are the Pod Security Standards levels supported by the PodSecurity admission
+var PodSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
+// NamespacesCreateOptions are the optional settings of the namespace created by NamespacesCreate
+type NamespacesCreateOptions struct {
+	// Labels to set on the namespace
+	Labels map[string]string
+	// Annotations to set on the namespace
+	Annotations map[string]string
+	// PodSecurity maps the PodSecurity admission modes (enforce, audit, warn) to the Pod Security Standards level
+	// (privileged, baseline, restricted), set as the pod-security.kubernetes.io/<mode> labels
+	PodSecurity map[string]string
+}
+
 func (c *Core) NamespacesList(ctx context.Context, options api.ListOptions) (runtime.Unstructured, error) {
 	return c.ResourcesList(ctx, &schema.GroupVersionKind{
 		Group: "", Version: "v1", Kind: "Namespace",
 	}, "", options)
 }
 
+// NamespacesCreate creates a namespace with the provided labels, annotations and PodSecurity admission labels.
+// The labels and PodSecurity levels are validated before the namespace is created.
+func (c *Core) NamespacesCreate(ctx context.Context, name string, opts NamespacesCreateOptions) (*unstructured.Unstructured, error) {
+	labels, err := namespaceLabels(opts)
+	if err != nil {
+		return nil, err
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid namespace name %s: %s", name, strings.Join(errs, ", "))
+	}
+	gvr, err := c.resourceFor(&schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Namespace"})
+	if err != nil {
+		return nil, err
+	}
+	namespace := &unstructured.Unstructured{}
+	namespace.SetAPIVersion("v1")
+	namespace.SetKind("Namespace")
+	namespace.SetName(name)
+	namespace.SetLabels(labels)
+	namespace.SetAnnotations(opts.Annotations)
+	return c.DynamicClient().Resource(*gvr).Create(ctx, namespace, metav1.CreateOptions{FieldManager: version.BinaryName})
+}
+
+// namespaceLabels returns the provided labels merged with the PodSecurity admission labels, an error is returned if
+// a label, annotation key or PodSecurity level is invalid or if a PodSecurity mode is set with a different level in
+// the labels
+func namespaceLabels(opts NamespacesCreateOptions) (map[string]string, error) {
+	var invalid []string
+	labels := make(map[string]string, len(opts.Labels)+len(opts.PodSecurity))
+	for key, value := range opts.Labels {
+		if errs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...); len(errs) > 0 {
+			invalid = append(invalid, fmt.Sprintf("label %s=%s (%s)", key, value, strings.Join(errs, ", ")))
+			continue
+		}
+		labels[key] = value
+	}
+	for key := range opts.Annotations {
+		if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			invalid = append(invalid, fmt.Sprintf("annotation %s (%s)", key, strings.Join(errs, ", ")))
+		}
+	}
+	for mode, level := range opts.PodSecurity {
+		key := podSecurityLabelPrefix + mode
+		switch {
+		case !slices.Contains(PodSecurityModes, mode):
+			invalid = append(invalid, fmt.Sprintf("PodSecurity mode %s (expected enforce, audit or warn)", mode))
+		case !slices.Contains(PodSecurityLevels, level):
+			invalid = append(invalid, fmt.Sprintf("PodSecurity %s level %s (expected %s)", mode, level, strings.Join(PodSecurityLevels, ", ")))
+		case opts.Labels[key] != "" && opts.Labels[key] != level:
+			invalid = append(invalid, fmt.Sprintf("PodSecurity %s level %s conflicts with label %s=%s", mode, level, key, opts.Labels[key]))
+		default:
+			labels[key] = level
+		}
+	}
+	if len(invalid) > 0 {
+		slices.Sort(invalid)
+		return nil, fmt.Errorf("invalid namespace metadata: %s", strings.Join(invalid, "; "))
+	}
+	return labels, nil
+}
+
 func (c *Core) ProjectsList(ctx context.Context, options api.ListOptions) (runtime.Unstructured, error) {
 	return c.ResourcesList(ctx, &schema.GroupVersionKind{
 		Group: "project.openshift.io", Version: "v1", Kind: "Project",
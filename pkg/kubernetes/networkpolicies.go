@@ -0,0 +1,217 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NetworkPoliciesAnalyze returns the NetworkPolicies (networking.k8s.io/v1) that select the provided Pod
+// (or all the NetworkPolicies in the namespace if no Pod is provided) with a summary of their rules
+// and the resulting ingress/egress isolation (including whether a default-deny is in effect).
+func (c *Core) NetworkPoliciesAnalyze(ctx context.Context, namespace, pod string) (map[string]any, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	var podLabels labels.Set
+	if pod != "" {
+		p, err := c.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		podLabels = p.Labels
+	}
+	policies, err := c.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var selecting []networkingv1.NetworkPolicy
+	for _, policy := range policies.Items {
+		selector, selectorErr := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if selectorErr != nil {
+			return nil, fmt.Errorf("invalid podSelector in NetworkPolicy %s: %w", policy.Name, selectorErr)
+		}
+		if pod == "" || selector.Matches(podLabels) {
+			selecting = append(selecting, policy)
+		}
+	}
+	target := "Namespace " + namespace
+	if pod != "" {
+		target = fmt.Sprintf("Pod %s/%s", namespace, pod)
+	}
+	summaries := make([]map[string]any, 0, len(selecting))
+	for _, policy := range selecting {
+		summaries = append(summaries, networkPolicySummary(&policy))
+	}
+	ret := map[string]any{
+		"Target":          target,
+		"NetworkPolicies": summaries,
+	}
+	if pod != "" {
+		ret["Ingress"] = networkPolicyIsolation(selecting, networkingv1.PolicyTypeIngress)
+		ret["Egress"] = networkPolicyIsolation(selecting, networkingv1.PolicyTypeEgress)
+	} else {
+		ret["NamespaceDefaultDeny"] = networkPolicyNamespaceDefaultDeny(selecting)
+	}
+	return ret, nil
+}
+
+// networkPolicyTypes returns the effective policy types (if not specified, Ingress always applies and Egress
+// only applies if the policy has egress rules)
+func networkPolicyTypes(policy *networkingv1.NetworkPolicy) []networkingv1.PolicyType {
+	if len(policy.Spec.PolicyTypes) > 0 {
+		return policy.Spec.PolicyTypes
+	}
+	types := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	if len(policy.Spec.Egress) > 0 {
+		types = append(types, networkingv1.PolicyTypeEgress)
+	}
+	return types
+}
+
+func networkPolicyHasType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	for _, t := range networkPolicyTypes(policy) {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// networkPolicyIsolation summarizes the effect of all the policies selecting a Pod for a direction:
+// a Pod is isolated if any policy of that type selects it, and only the union of the rules is allowed.
+func networkPolicyIsolation(policies []networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) map[string]any {
+	isolation := map[string]any{"Isolated": false}
+	var allowed []string
+	isolatedBy := make([]string, 0)
+	for i := range policies {
+		policy := &policies[i]
+		if !networkPolicyHasType(policy, policyType) {
+			continue
+		}
+		isolatedBy = append(isolatedBy, policy.Name)
+		if policyType == networkingv1.PolicyTypeIngress {
+			for _, rule := range policy.Spec.Ingress {
+				allowed = append(allowed, policy.Name+": "+networkPolicyRule("from", rule.From, rule.Ports))
+			}
+		} else {
+			for _, rule := range policy.Spec.Egress {
+				allowed = append(allowed, policy.Name+": "+networkPolicyRule("to", rule.To, rule.Ports))
+			}
+		}
+	}
+	if len(isolatedBy) == 0 {
+		isolation["Summary"] = fmt.Sprintf("No NetworkPolicy of type %s selects the Pod, all %s traffic is allowed", policyType, strings.ToLower(string(policyType)))
+		return isolation
+	}
+	isolation["Isolated"] = true
+	isolation["IsolatedBy"] = isolatedBy
+	isolation["DefaultDeny"] = len(allowed) == 0
+	if len(allowed) == 0 {
+		isolation["Summary"] = fmt.Sprintf("Default deny: the Pod is selected by %s policies without rules, all %s traffic is blocked", policyType, strings.ToLower(string(policyType)))
+	} else {
+		isolation["Summary"] = fmt.Sprintf("Only %s traffic matching at least one of the allowed rules is permitted, everything else is blocked", strings.ToLower(string(policyType)))
+		isolation["Allowed"] = allowed
+	}
+	return isolation
+}
+
+// networkPolicyNamespaceDefaultDeny returns the default-deny policies that select every Pod in the namespace
+func networkPolicyNamespaceDefaultDeny(policies []networkingv1.NetworkPolicy) map[string][]string {
+	defaultDeny := map[string][]string{}
+	for i := range policies {
+		policy := &policies[i]
+		if len(policy.Spec.PodSelector.MatchLabels) > 0 || len(policy.Spec.PodSelector.MatchExpressions) > 0 {
+			continue
+		}
+		if networkPolicyHasType(policy, networkingv1.PolicyTypeIngress) && len(policy.Spec.Ingress) == 0 {
+			defaultDeny[string(networkingv1.PolicyTypeIngress)] = append(defaultDeny[string(networkingv1.PolicyTypeIngress)], policy.Name)
+		}
+		if networkPolicyHasType(policy, networkingv1.PolicyTypeEgress) && len(policy.Spec.Egress) == 0 {
+			defaultDeny[string(networkingv1.PolicyTypeEgress)] = append(defaultDeny[string(networkingv1.PolicyTypeEgress)], policy.Name)
+		}
+	}
+	return defaultDeny
+}
+
+func networkPolicySummary(policy *networkingv1.NetworkPolicy) map[string]any {
+	types := make([]string, 0, 2)
+	for _, t := range networkPolicyTypes(policy) {
+		types = append(types, string(t))
+	}
+	ingress := make([]string, 0, len(policy.Spec.Ingress))
+	for _, rule := range policy.Spec.Ingress {
+		ingress = append(ingress, networkPolicyRule("from", rule.From, rule.Ports))
+	}
+	egress := make([]string, 0, len(policy.Spec.Egress))
+	for _, rule := range policy.Spec.Egress {
+		egress = append(egress, networkPolicyRule("to", rule.To, rule.Ports))
+	}
+	return map[string]any{
+		"Name":        policy.Name,
+		"PodSelector": labelSelectorString(&policy.Spec.PodSelector),
+		"PolicyTypes": types,
+		"Ingress":     ingress,
+		"Egress":      egress,
+	}
+}
+
+// networkPolicyRule renders a human-readable description of a rule (empty peers or ports mean all)
+func networkPolicyRule(direction string, peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort) string {
+	peerDescriptions := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			description := "ipBlock " + peer.IPBlock.CIDR
+			if len(peer.IPBlock.Except) > 0 {
+				description += " except " + strings.Join(peer.IPBlock.Except, ",")
+			}
+			peerDescriptions = append(peerDescriptions, description)
+		case peer.PodSelector != nil && peer.NamespaceSelector != nil:
+			peerDescriptions = append(peerDescriptions, fmt.Sprintf("pods (%s) in namespaces (%s)",
+				labelSelectorString(peer.PodSelector), labelSelectorString(peer.NamespaceSelector)))
+		case peer.PodSelector != nil:
+			peerDescriptions = append(peerDescriptions, fmt.Sprintf("pods (%s) in the same namespace", labelSelectorString(peer.PodSelector)))
+		case peer.NamespaceSelector != nil:
+			peerDescriptions = append(peerDescriptions, fmt.Sprintf("all pods in namespaces (%s)", labelSelectorString(peer.NamespaceSelector)))
+		}
+	}
+	peersDescription := "anywhere"
+	if len(peerDescriptions) > 0 {
+		peersDescription = strings.Join(peerDescriptions, " or ")
+	}
+	portDescriptions := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := "TCP"
+		if port.Protocol != nil {
+			protocol = string(*port.Protocol)
+		}
+		switch {
+		case port.Port == nil:
+			portDescriptions = append(portDescriptions, "all "+protocol+" ports")
+		case port.EndPort != nil:
+			portDescriptions = append(portDescriptions, fmt.Sprintf("%s/%s-%d", protocol, port.Port.String(), *port.EndPort))
+		default:
+			portDescriptions = append(portDescriptions, protocol+"/"+port.Port.String())
+		}
+	}
+	portsDescription := "all ports"
+	if len(portDescriptions) > 0 {
+		portsDescription = strings.Join(portDescriptions, ", ")
+	}
+	return fmt.Sprintf("%s %s on %s", direction, peersDescription, portsDescription)
+}
+
+// labelSelectorString renders a label selector, an empty selector matches everything
+func labelSelectorString(selector *metav1.LabelSelector) string {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "<invalid>"
+	}
+	if s.Empty() {
+		return "all"
+	}
+	return s.String()
+}
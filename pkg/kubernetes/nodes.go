@@ -2,8 +2,10 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,7 +13,7 @@ import (
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
-func (c *Core) NodesLog(ctx context.Context, name string, query string, tailLines int64) (string, error) {
+func (c *Core) NodesLog(ctx context.Context, name string, options api.NodesLogOptions) (string, error) {
 	// Use the node proxy API to access logs from the kubelet
 	// https://kubernetes.io/docs/concepts/cluster-administration/system-logs/#log-query
 	// Common log paths:
@@ -23,13 +25,41 @@ func (c *Core) NodesLog(ctx context.Context, name string, query string, tailLine
 		return "", fmt.Errorf("failed to get node %s: %w", name, err)
 	}
 
+	if len(options.Queries) == 1 {
+		return c.nodeLog(ctx, name, options.Queries[0], options)
+	}
+
+	// Multiple queries are requested one by one and delimited with a header (like tail does for multiple files)
+	var sb strings.Builder
+	for i, query := range options.Queries {
+		log, err := c.nodeLog(ctx, name, query, options)
+		if err != nil {
+			return "", fmt.Errorf("query %s: %w", query, err)
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("==> " + query + " <==\n")
+		sb.WriteString(log)
+		if log != "" && !strings.HasSuffix(log, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+func (c *Core) nodeLog(ctx context.Context, name, query string, options api.NodesLogOptions) (string, error) {
 	req := c.CoreV1().RESTClient().
 		Get().
 		AbsPath("api", "v1", "nodes", name, "proxy", "logs")
 	req.Param("query", query)
+	// Filtering is performed by the kubelet (journalctl --grep for services)
+	if options.Pattern != "" {
+		req.Param("pattern", options.Pattern)
+	}
 	// Query parameters for tail
-	if tailLines > 0 {
-		req.Param("tailLines", fmt.Sprintf("%d", tailLines))
+	if options.TailLines > 0 {
+		req.Param("tailLines", fmt.Sprintf("%d", options.TailLines))
 	}
 
 	result := req.Do(ctx)
@@ -45,7 +75,9 @@ func (c *Core) NodesLog(ctx context.Context, name string, query string, tailLine
 	return string(rawData), nil
 }
 
-func (c *Core) NodesStatsSummary(ctx context.Context, name string) (string, error) {
+// NodesStatsSummary returns the kubelet stats summary of the node.
+// If fields are provided, only the selected dot-separated paths (e.g. node.cpu or pods[].containers[].memory) are returned.
+func (c *Core) NodesStatsSummary(ctx context.Context, name string, fields []string) (string, error) {
 	// Use the node proxy API to access stats summary from the kubelet
 	// https://kubernetes.io/docs/reference/instrumentation/understand-psi-metrics/
 	// This endpoint provides CPU, memory, filesystem, and network statistics
@@ -66,8 +98,88 @@ func (c *Core) NodesStatsSummary(ctx context.Context, name string) (string, erro
 	if err != nil {
 		return "", fmt.Errorf("failed to read node stats summary response: %w", err)
 	}
+	if len(fields) == 0 {
+		return string(rawData), nil
+	}
 
-	return string(rawData), nil
+	var summary map[string]any
+	if err = json.Unmarshal(rawData, &summary); err != nil {
+		return "", fmt.Errorf("failed to decode node stats summary response: %w", err)
+	}
+	selected, found := selectFields(summary, newFieldTree(fields))
+	if !found {
+		return "", fmt.Errorf("none of the fields %s were found in the node stats summary", strings.Join(fields, ", "))
+	}
+	selectedData, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode node stats summary fields: %w", err)
+	}
+	return string(selectedData), nil
+}
+
+// fieldTree is the set of paths to select from a decoded JSON document keyed by their first segment.
+// A nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+// newFieldTree parses dot-separated paths, the [] array suffix is optional since the arrays are traversed implicitly.
+func newFieldTree(paths []string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		segments := strings.Split(strings.ReplaceAll(strings.TrimSpace(path), "[]", ""), ".")
+		for i, segment := range segments {
+			child, exists := node[segment]
+			if exists && child == nil {
+				// a parent path already selects the whole value
+				break
+			}
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+			if !exists {
+				child = fieldTree{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+// selectFields returns the projection of the value containing only the fields of the tree.
+// Arrays are traversed by applying the projection to each of their elements.
+// The returned bool is false when none of the fields exist in the value.
+func selectFields(value any, tree fieldTree) (any, bool) {
+	if tree == nil {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		selected := map[string]any{}
+		for key, subtree := range tree {
+			if field, ok := v[key]; ok {
+				if projection, found := selectFields(field, subtree); found {
+					selected[key] = projection
+				}
+			}
+		}
+		return selected, len(selected) > 0
+	case []any:
+		selected := make([]any, 0, len(v))
+		found := false
+		for _, item := range v {
+			projection, itemFound := selectFields(item, tree)
+			if !itemFound {
+				projection = map[string]any{}
+			}
+			selected = append(selected, projection)
+			found = found || itemFound
+		}
+		return selected, found || len(v) == 0
+	default:
+		return nil, false
+	}
 }
 
 func (c *Core) NodesTop(ctx context.Context, options api.NodesTopOptions) (*metrics.NodeMetricsList, error) {
@@ -75,6 +187,9 @@ func (c *Core) NodesTop(ctx context.Context, options api.NodesTopOptions) (*metr
 	if !c.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version) {
 		return nil, errors.New("metrics API is not available")
 	}
+	if err := validateTopOptions(options.TopOptions); err != nil {
+		return nil, err
+	}
 	versionedMetrics := &metricsv1beta1api.NodeMetricsList{}
 	var err error
 	if options.Name != "" {
@@ -90,5 +205,12 @@ func (c *Core) NodesTop(ctx context.Context, options api.NodesTopOptions) (*metr
 		}
 	}
 	convertedMetrics := &metrics.NodeMetricsList{}
-	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_NodeMetricsList_To_metrics_NodeMetricsList(versionedMetrics, convertedMetrics, nil)
+	if err = metricsv1beta1api.Convert_v1beta1_NodeMetricsList_To_metrics_NodeMetricsList(versionedMetrics, convertedMetrics, nil); err != nil {
+		return nil, err
+	}
+	var remaining int64
+	if convertedMetrics.Items, remaining = sortAndLimit(convertedMetrics.Items, nodeMetricsUsage, options.TopOptions); remaining > 0 {
+		convertedMetrics.RemainingItemCount = &remaining
+	}
+	return convertedMetrics, nil
 }
@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodesCapacityResult is the scheduling capacity of the cluster nodes, based on the resources requested by the Pods
+// (what the scheduler accounts for) instead of the actual usage reported by the Metrics Server
+type NodesCapacityResult struct {
+	// Nodes is the capacity of every node, sorted by name
+	Nodes []NodeCapacity
+	// Total is the aggregated capacity of the schedulable nodes
+	Total NodeCapacity
+}
+
+// NodeCapacity compares the allocatable resources of a node with the resources requested by the Pods bound to it
+type NodeCapacity struct {
+	Name string
+	// Schedulable is false if the node is cordoned or not Ready, its headroom can't be used by new Pods
+	Schedulable bool
+	// Pods is the number of non-terminated Pods bound to the node
+	Pods            int64
+	AllocatablePods int64
+	CPU             ResourceCapacity
+	Memory          ResourceCapacity
+	// OverCommitted lists the resources (cpu, memory) whose requests or limits exceed the allocatable amount
+	OverCommitted []string
+}
+
+// ResourceCapacity is the allocatable, requested and limited amount of a resource
+type ResourceCapacity struct {
+	Allocatable resource.Quantity
+	Requests    resource.Quantity
+	Limits      resource.Quantity
+}
+
+// Headroom is the amount of the resource that can still be requested by new Pods (never negative)
+func (r *ResourceCapacity) Headroom() resource.Quantity {
+	headroom := r.Allocatable.DeepCopy()
+	headroom.Sub(r.Requests)
+	if headroom.Sign() < 0 {
+		return *resource.NewQuantity(0, r.Allocatable.Format)
+	}
+	return headroom
+}
+
+func (r *ResourceCapacity) add(other ResourceCapacity) {
+	r.Allocatable.Add(other.Allocatable)
+	r.Requests.Add(other.Requests)
+	r.Limits.Add(other.Limits)
+}
+
+// NodesCapacity aggregates, for every node matching the label selector, the resources requested (and limited) by the
+// non-terminated Pods bound to it and compares them with the node allocatable resources, the same accounting the
+// scheduler uses to decide whether a new Pod fits (kubectl describe node "Allocated resources").
+func (c *Core) NodesCapacity(ctx context.Context, labelSelector string) (*NodesCapacityResult, error) {
+	nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	podsOptions := metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"}
+	if len(nodes.Items) == 1 {
+		podsOptions.FieldSelector = "spec.nodeName=" + nodes.Items[0].Name + "," + podsOptions.FieldSelector
+	}
+	pods, err := c.CoreV1().Pods("").List(ctx, podsOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	requests := map[string]v1.ResourceList{}
+	limits := map[string]v1.ResourceList{}
+	podCount := map[string]int64{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if requests[pod.Spec.NodeName] == nil {
+			requests[pod.Spec.NodeName], limits[pod.Spec.NodeName] = v1.ResourceList{}, v1.ResourceList{}
+		}
+		addResourceList(requests[pod.Spec.NodeName], podRequests(pod))
+		addResourceList(limits[pod.Spec.NodeName], podLimits(pod))
+		podCount[pod.Spec.NodeName]++
+	}
+	ret := &NodesCapacityResult{Total: NodeCapacity{Name: "total", Schedulable: true}}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		capacity := NodeCapacity{
+			Name:            node.Name,
+			Schedulable:     !node.Spec.Unschedulable && nodeReady(node),
+			Pods:            podCount[node.Name],
+			AllocatablePods: node.Status.Allocatable.Pods().Value(),
+		}
+		for _, r := range []struct {
+			name     v1.ResourceName
+			capacity *ResourceCapacity
+		}{{v1.ResourceCPU, &capacity.CPU}, {v1.ResourceMemory, &capacity.Memory}} {
+			r.capacity.Allocatable = node.Status.Allocatable[r.name].DeepCopy()
+			r.capacity.Requests = requests[node.Name][r.name].DeepCopy()
+			r.capacity.Limits = limits[node.Name][r.name].DeepCopy()
+			if r.capacity.Requests.Cmp(r.capacity.Allocatable) > 0 || r.capacity.Limits.Cmp(r.capacity.Allocatable) > 0 {
+				capacity.OverCommitted = append(capacity.OverCommitted, string(r.name))
+			}
+		}
+		ret.Nodes = append(ret.Nodes, capacity)
+		if capacity.Schedulable {
+			ret.Total.Pods += capacity.Pods
+			ret.Total.AllocatablePods += capacity.AllocatablePods
+			ret.Total.CPU.add(capacity.CPU)
+			ret.Total.Memory.add(capacity.Memory)
+		}
+	}
+	slices.SortFunc(ret.Nodes, func(a, b NodeCapacity) int { return strings.Compare(a.Name, b.Name) })
+	return ret, nil
+}
+
+func nodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
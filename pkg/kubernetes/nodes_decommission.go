@@ -0,0 +1,196 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+const (
+	// DefaultNodesDecommissionTimeout is the default time to wait for the Pods of the Node to be evicted
+	DefaultNodesDecommissionTimeout = 5 * time.Minute
+	// MaxNodesDecommissionTimeout bounds the time NodesDecommission waits for the Pods of the Node to be evicted
+	MaxNodesDecommissionTimeout = 30 * time.Minute
+)
+
+// nodesDecommissionInterval is the interval between the eviction retries (blocked by a PodDisruptionBudget) and the
+// checks of the evicted Pods termination
+var nodesDecommissionInterval = 5 * time.Second
+
+// NodesDecommissionOptions are the settings of the drain performed by NodesDecommission
+type NodesDecommissionOptions struct {
+	// GracePeriodSeconds overrides the termination grace period of the evicted Pods (the Pods' own if negative)
+	GracePeriodSeconds int64
+	// Timeout is the maximum time to wait for the Pods to be evicted (DefaultNodesDecommissionTimeout if zero, capped
+	// to MaxNodesDecommissionTimeout)
+	Timeout time.Duration
+	// Force evicts the Pods that are not managed by a controller too (they are not recreated on another Node)
+	Force bool
+}
+
+// NodesDecommissionResult is the outcome of NodesDecommission, it reports the steps completed so far if one fails
+type NodesDecommissionResult struct {
+	// Cordoned is true if the Node was marked unschedulable (false if it already was)
+	Cordoned bool
+	// Evicted are the Pods (namespace/name) evicted from the Node
+	Evicted []string
+	// Skipped are the Pods (namespace/name) left on the Node and the reason (DaemonSet and static Pods)
+	Skipped []string
+	// Deleted is true once the Node has been deleted
+	Deleted bool
+}
+
+// NodesDecommission cordons the Node, drains it and deletes it, similar to kubectl cordon, kubectl drain
+// --ignore-daemonsets and kubectl delete node.
+// Pods are evicted through the Eviction API so that PodDisruptionBudgets are honored (blocked evictions are retried
+// until the timeout expires), DaemonSet and static Pods are left on the Node. The Node is only deleted once all the
+// evicted Pods are gone, if a step fails the Node is left cordoned.
+func (c *Core) NodesDecommission(ctx context.Context, name string, opts NodesDecommissionOptions, progress api.ProgressFunc) (*NodesDecommissionResult, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultNodesDecommissionTimeout
+	}
+	timeout = min(timeout, MaxNodesDecommissionTimeout)
+	report := func(step float64, message string) {
+		if progress != nil {
+			progress(step, 3, message)
+		}
+	}
+	ret := &NodesDecommissionResult{}
+	node, err := c.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return ret, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+
+	report(0, fmt.Sprintf("Cordoning node %s", name))
+	if !node.Spec.Unschedulable {
+		if _, err = c.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, []byte(`{"spec":{"unschedulable":true}}`),
+			metav1.PatchOptions{FieldManager: version.BinaryName}); err != nil {
+			return ret, fmt.Errorf("failed to cordon node %s: %w", name, err)
+		}
+		ret.Cordoned = true
+	}
+
+	report(1, fmt.Sprintf("Draining node %s", name))
+	pods, err := c.nodesDecommissionPods(ctx, name, opts.Force, ret)
+	if err != nil {
+		return ret, fmt.Errorf("failed to drain node %s: %w", name, err)
+	}
+	drainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for _, pod := range pods {
+		if err = c.nodesDecommissionEvict(drainCtx, &pod, opts.GracePeriodSeconds); err != nil {
+			return ret, fmt.Errorf("failed to drain node %s: %w", name, err)
+		}
+		ret.Evicted = append(ret.Evicted, pod.Namespace+"/"+pod.Name)
+		report(1, fmt.Sprintf("Draining node %s: %d/%d Pods evicted", name, len(ret.Evicted), len(pods)))
+	}
+	for _, pod := range pods {
+		if err = c.nodesDecommissionWaitForDeletion(drainCtx, &pod); err != nil {
+			return ret, fmt.Errorf("failed to drain node %s: %w", name, err)
+		}
+	}
+
+	report(2, fmt.Sprintf("Deleting node %s", name))
+	if err = c.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return ret, fmt.Errorf("failed to delete node %s: %w", name, err)
+	}
+	ret.Deleted = true
+	report(3, fmt.Sprintf("Node %s decommissioned", name))
+	return ret, nil
+}
+
+// nodesDecommissionPods returns the Pods of the Node to evict, the DaemonSet and static Pods are recorded as skipped.
+// Pods not managed by a controller are only evicted if force is true.
+func (c *Core) nodesDecommissionPods(ctx context.Context, name string, force bool, ret *NodesDecommissionResult) ([]v1.Pod, error) {
+	pods, err := c.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var toEvict []v1.Pod
+	var unmanaged []string
+	for _, pod := range pods.Items {
+		controller := metav1.GetControllerOf(&pod)
+		finished := pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+		switch {
+		case pod.Annotations[v1.MirrorPodAnnotationKey] != "":
+			ret.Skipped = append(ret.Skipped, pod.Namespace+"/"+pod.Name+" (static Pod)")
+			continue
+		case controller != nil && controller.Kind == "DaemonSet":
+			ret.Skipped = append(ret.Skipped, pod.Namespace+"/"+pod.Name+" (DaemonSet)")
+			continue
+		case controller == nil && !finished:
+			unmanaged = append(unmanaged, pod.Namespace+"/"+pod.Name)
+		}
+		toEvict = append(toEvict, pod)
+	}
+	if len(unmanaged) > 0 && !force {
+		return nil, fmt.Errorf("the following Pods are not managed by a controller and won't be recreated on another node, "+
+			"set force to evict them anyway: %s", strings.Join(unmanaged, ", "))
+	}
+	return toEvict, nil
+}
+
+// nodesDecommissionEvict evicts the Pod, evictions blocked by a PodDisruptionBudget are retried until ctx is done
+func (c *Core) nodesDecommissionEvict(ctx context.Context, pod *v1.Pod, gracePeriodSeconds int64) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	if gracePeriodSeconds >= 0 {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds}
+	}
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, nodesDecommissionInterval, true, func(ctx context.Context) (bool, error) {
+		lastErr = c.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+		switch {
+		case lastErr == nil, apierrors.IsNotFound(lastErr):
+			return true, nil
+		case apierrors.IsTooManyRequests(lastErr):
+			// the eviction would violate a PodDisruptionBudget, retry once other Pods are available again
+			return false, nil
+		default:
+			return false, lastErr
+		}
+	})
+	if err != nil && errors.Is(err, ctx.Err()) && lastErr != nil {
+		return fmt.Errorf("timed out evicting pod %s/%s: %w", pod.Namespace, pod.Name, lastErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// nodesDecommissionWaitForDeletion waits until the evicted Pod is gone (or replaced by a Pod with the same name)
+func (c *Core) nodesDecommissionWaitForDeletion(ctx context.Context, pod *v1.Pod) error {
+	err := wait.PollUntilContextCancel(ctx, nodesDecommissionInterval, true, func(ctx context.Context) (bool, error) {
+		current, err := c.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return current.UID != pod.UID, nil
+	})
+	if err != nil && errors.Is(err, ctx.Err()) {
+		return fmt.Errorf("timed out waiting for the evicted pod %s/%s to terminate", pod.Namespace, pod.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to wait for the evicted pod %s/%s to terminate: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// NodeTaintEffects are the valid effects of a Node taint
+var NodeTaintEffects = []string{string(v1.TaintEffectNoSchedule), string(v1.TaintEffectPreferNoSchedule), string(v1.TaintEffectNoExecute)}
+
+// NodesTaintResult is the outcome of NodesTaint and NodesUntaint
+type NodesTaintResult struct {
+	// Changed is false if the Node already had (NodesTaint) or didn't have (NodesUntaint) the taint
+	Changed bool
+	// Taints are the taints of the Node after the operation
+	Taints []v1.Taint
+}
+
+// NodesTaint adds the taint to the Node, similar to kubectl taint nodes <name> key=value:effect.
+// A taint with the same key and effect is replaced only if overwrite is true, adding a taint that is already present
+// (same key, value and effect) is a no-op.
+func (c *Core) NodesTaint(ctx context.Context, name string, taint v1.Taint, overwrite bool) (*NodesTaintResult, error) {
+	if err := validateNodeTaint(taint.Key, taint.Value, string(taint.Effect), true); err != nil {
+		return nil, err
+	}
+	return c.nodesUpdateTaints(ctx, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		i := slices.IndexFunc(taints, func(t v1.Taint) bool { return t.Key == taint.Key && t.Effect == taint.Effect })
+		switch {
+		case i < 0:
+			return append(taints, taint), nil
+		case taints[i].Value == taint.Value:
+			return nil, nil
+		case !overwrite:
+			return nil, fmt.Errorf("node %s already has the taint %s with a different value, set overwrite to replace it", name, FormatNodeTaint(taints[i]))
+		}
+		taints = slices.Clone(taints)
+		taints[i] = taint
+		return taints, nil
+	})
+}
+
+// NodesUntaint removes the taints with the provided key (and effect, if not empty) from the Node, similar to
+// kubectl taint nodes <name> key[:effect]-. Removing a taint that is not present is a no-op.
+func (c *Core) NodesUntaint(ctx context.Context, name, key, effect string) (*NodesTaintResult, error) {
+	if err := validateNodeTaint(key, "", effect, false); err != nil {
+		return nil, err
+	}
+	return c.nodesUpdateTaints(ctx, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		remaining := slices.DeleteFunc(slices.Clone(taints), func(t v1.Taint) bool {
+			return t.Key == key && (effect == "" || string(t.Effect) == effect)
+		})
+		if len(remaining) == len(taints) {
+			return nil, nil
+		}
+		return remaining, nil
+	})
+}
+
+// nodesUpdateTaints replaces the taints of the Node with the ones returned by update (nil if unchanged), the patch is
+// conditioned by the resourceVersion of the Node and retried on conflict so that concurrent changes aren't lost
+func (c *Core) nodesUpdateTaints(ctx context.Context, name string, update func([]v1.Taint) ([]v1.Taint, error)) (*NodesTaintResult, error) {
+	ret := &NodesTaintResult{}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		taints, err := update(node.Spec.Taints)
+		if err != nil {
+			return err
+		}
+		if taints == nil {
+			ret.Changed, ret.Taints = false, node.Spec.Taints
+			return nil
+		}
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": node.ResourceVersion},
+			"spec":     map[string]interface{}{"taints": taints},
+		})
+		if err != nil {
+			return err
+		}
+		patched, err := c.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: version.BinaryName})
+		if err != nil {
+			return err
+		}
+		ret.Changed, ret.Taints = true, patched.Spec.Taints
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func validateNodeTaint(key, value, effect string, effectRequired bool) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid taint key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("invalid taint value %q: %s", value, strings.Join(errs, "; "))
+	}
+	if (effect != "" || effectRequired) && !slices.Contains(NodeTaintEffects, effect) {
+		return fmt.Errorf("invalid taint effect %q, must be one of %s", effect, strings.Join(NodeTaintEffects, ", "))
+	}
+	return nil
+}
+
+// FormatNodeTaint returns the taint in the kubectl format (key=value:effect)
+func FormatNodeTaint(taint v1.Taint) string {
+	if taint.Value == "" {
+		return taint.Key + ":" + string(taint.Effect)
+	}
+	return taint.Key + "=" + taint.Value + ":" + string(taint.Effect)
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	labelutil "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -67,6 +69,23 @@ func (c *Core) PodsListInNamespace(ctx context.Context, namespace string, option
 	}, namespace, options)
 }
 
+// PodsListOnNode lists the Pods scheduled on the provided node in all namespaces (spec.nodeName field selector),
+// combined with the provided field selector if any. Returns an error if the node doesn't exist.
+func (c *Core) PodsListOnNode(ctx context.Context, node string, options api.ListOptions) (runtime.Unstructured, error) {
+	if _, err := c.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("node %s not found, list the nodes with resources_list (apiVersion v1, kind Node) to get their names", node)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", node, err)
+	}
+	nodeSelector := fields.OneTermEqualSelector("spec.nodeName", node).String()
+	if options.FieldSelector == "" {
+		options.FieldSelector = nodeSelector
+	} else {
+		options.FieldSelector = nodeSelector + "," + options.FieldSelector
+	}
+	return c.PodsListInAllNamespaces(ctx, options)
+}
+
 func (c *Core) PodsGet(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
 	return c.ResourcesGet(ctx, &schema.GroupVersionKind{
 		Group: "", Version: "v1", Kind: "Pod",
@@ -230,7 +249,7 @@ func (c *Core) PodsRun(ctx context.Context, namespace, name, image string, port
 		}
 		toCreate = append(toCreate, u)
 	}
-	return c.resourcesCreateOrUpdate(ctx, toCreate)
+	return c.resourcesCreateOrUpdate(ctx, toCreate, version.BinaryName)
 }
 
 func (c *Core) PodsTop(ctx context.Context, options api.PodsTopOptions) (*metrics.PodMetricsList, error) {
@@ -238,6 +257,9 @@ func (c *Core) PodsTop(ctx context.Context, options api.PodsTopOptions) (*metric
 	if !c.supportsGroupVersion(metrics.GroupName + "/" + metricsv1beta1api.SchemeGroupVersion.Version) {
 		return nil, errors.New("metrics API is not available")
 	}
+	if err := validateTopOptions(options.TopOptions); err != nil {
+		return nil, err
+	}
 	namespace := options.Namespace
 	if options.AllNamespaces && namespace == "" {
 		namespace = ""
@@ -259,7 +281,14 @@ func (c *Core) PodsTop(ctx context.Context, options api.PodsTopOptions) (*metric
 		}
 	}
 	convertedMetrics := &metrics.PodMetricsList{}
-	return convertedMetrics, metricsv1beta1api.Convert_v1beta1_PodMetricsList_To_metrics_PodMetricsList(versionedMetrics, convertedMetrics, nil)
+	if err = metricsv1beta1api.Convert_v1beta1_PodMetricsList_To_metrics_PodMetricsList(versionedMetrics, convertedMetrics, nil); err != nil {
+		return nil, err
+	}
+	var remaining int64
+	if convertedMetrics.Items, remaining = sortAndLimit(convertedMetrics.Items, podMetricsUsage, options.TopOptions); remaining > 0 {
+		convertedMetrics.RemainingItemCount = &remaining
+	}
+	return convertedMetrics, nil
 }
 
 func (c *Core) PodsExec(ctx context.Context, namespace, name, container string, command []string) (string, string, error) {
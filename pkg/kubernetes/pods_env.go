@@ -0,0 +1,373 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
+)
+
+// PodEnv is the effective environment of a Pod container with the source of each variable
+type PodEnv struct {
+	Pod       string   `json:"pod"`
+	Namespace string   `json:"namespace"`
+	Container string   `json:"container"`
+	Variables []EnvVar `json:"variables"`
+	// Missing lists the references (ConfigMaps, Secrets, keys, fields) that couldn't be resolved
+	Missing []string `json:"missing,omitempty"`
+}
+
+// EnvVar is an environment variable as seen by the container
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	// Source describes where the value comes from (e.g. value, configMapKeyRef my-cm/key, envFrom secret my-secret)
+	Source string `json:"source"`
+	// Redacted is set when the value comes from a Secret and Secret values can't be revealed
+	Redacted bool `json:"redacted,omitempty"`
+	// Missing is the reason why the value couldn't be resolved
+	Missing string `json:"missing,omitempty"`
+	// Note gives additional details about the value (e.g. a resource limit that defaults to the node allocatable)
+	Note string `json:"note,omitempty"`
+}
+
+// envVarReference matches the $(VAR_NAME) references expanded by the kubelet (escaped as $$(VAR_NAME))
+var envVarReference = regexp.MustCompile(`\$\$|\$\(([^)]+)\)`)
+
+// PodsEnv resolves the effective environment variables of a Pod container (the first container if none is provided):
+// envFrom sources, literal values (with $(VAR_NAME) references expanded), ConfigMap and Secret key references,
+// and downward API fieldRef and resourceFieldRef values.
+// Secret values are only returned if revealSecrets is true, otherwise they're redacted.
+func (c *Core) PodsEnv(ctx context.Context, namespace, name, container string, revealSecrets bool) (*PodEnv, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ctr, err := podContainer(pod, container)
+	if err != nil {
+		return nil, err
+	}
+	r := &envResolver{core: c, ctx: ctx, pod: pod, container: ctr, revealSecrets: revealSecrets,
+		configMaps: map[string]*v1.ConfigMap{}, secrets: map[string]*v1.Secret{}, errors: map[string]error{}}
+	ret := &PodEnv{Pod: pod.Name, Namespace: pod.Namespace, Container: ctr.Name, Variables: make([]EnvVar, 0)}
+	// Variables defined later (env over envFrom, later entries over earlier ones) take precedence
+	index := map[string]int{}
+	set := func(envVar EnvVar) {
+		if i, ok := index[envVar.Name]; ok {
+			ret.Variables[i] = envVar
+			return
+		}
+		index[envVar.Name] = len(ret.Variables)
+		ret.Variables = append(ret.Variables, envVar)
+	}
+	for _, envFrom := range ctr.EnvFrom {
+		vars, missing := r.resolveEnvFrom(envFrom)
+		for _, envVar := range vars {
+			set(envVar)
+		}
+		if missing != "" {
+			ret.Missing = append(ret.Missing, missing)
+		}
+	}
+	for _, env := range ctr.Env {
+		envVar := r.resolveEnv(env, ret.Variables, index)
+		set(envVar)
+		if envVar.Missing != "" {
+			ret.Missing = append(ret.Missing, fmt.Sprintf("%s: %s", envVar.Name, envVar.Missing))
+		}
+	}
+	if len(r.revealed) > 0 {
+		klogutil.LogInfo(klog.FromContext(ctx), "Secret values resolved for Pod environment",
+			klogutil.Field("kubernetes.namespace.name", pod.Namespace),
+			klogutil.Field("kubernetes.pod.name", pod.Name),
+			klogutil.Field("kubernetes.secret.names", r.revealed),
+		)
+	}
+	return ret, nil
+}
+
+// podContainer returns the container (or init container) of the Pod with the provided name, or the first container
+func podContainer(pod *v1.Pod, name string) (*v1.Container, error) {
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("pod %s has no containers", pod.Name)
+		}
+		return &pod.Spec.Containers[0], nil
+	}
+	for _, containers := range [][]v1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for i := range containers {
+			if containers[i].Name == name {
+				return &containers[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("container %s not found in pod %s", name, pod.Name)
+}
+
+// envResolver resolves the references of a container environment caching the retrieved ConfigMaps and Secrets
+type envResolver struct {
+	core          *Core
+	ctx           context.Context
+	pod           *v1.Pod
+	container     *v1.Container
+	revealSecrets bool
+	configMaps    map[string]*v1.ConfigMap
+	secrets       map[string]*v1.Secret
+	errors        map[string]error
+	revealed      []string
+}
+
+func (r *envResolver) configMap(name string) (*v1.ConfigMap, error) {
+	key := "ConfigMap/" + name
+	if cm, ok := r.configMaps[name]; ok {
+		return cm, nil
+	} else if err, ok := r.errors[key]; ok {
+		return nil, err
+	}
+	cm, err := r.core.CoreV1().ConfigMaps(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.errors[key] = err
+		return nil, err
+	}
+	r.configMaps[name] = cm
+	return cm, nil
+}
+
+func (r *envResolver) secret(name string) (*v1.Secret, error) {
+	key := "Secret/" + name
+	if secret, ok := r.secrets[name]; ok {
+		return secret, nil
+	} else if err, ok := r.errors[key]; ok {
+		return nil, err
+	}
+	secret, err := r.core.CoreV1().Secrets(r.pod.Namespace).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		r.errors[key] = err
+		return nil, err
+	}
+	r.secrets[name] = secret
+	return secret, nil
+}
+
+// secretValue returns the EnvVar for a Secret value, redacting it unless Secret values can be revealed
+func (r *envResolver) secretValue(envVar EnvVar, secretName string, value []byte) EnvVar {
+	if !r.revealSecrets {
+		envVar.Redacted = true
+		return envVar
+	}
+	envVar.Value = string(value)
+	if !slices.Contains(r.revealed, secretName) {
+		r.revealed = append(r.revealed, secretName)
+	}
+	return envVar
+}
+
+func (r *envResolver) resolveEnvFrom(envFrom v1.EnvFromSource) ([]EnvVar, string) {
+	var vars []EnvVar
+	switch {
+	case envFrom.ConfigMapRef != nil:
+		optional := envFrom.ConfigMapRef.Optional != nil && *envFrom.ConfigMapRef.Optional
+		source := "envFrom configMap " + envFrom.ConfigMapRef.Name
+		cm, err := r.configMap(envFrom.ConfigMapRef.Name)
+		if err != nil {
+			return nil, missingReference(source, err, optional)
+		}
+		for _, key := range slices.Sorted(maps.Keys(cm.Data)) {
+			vars = append(vars, EnvVar{Name: envFrom.Prefix + key, Value: cm.Data[key], Source: source})
+		}
+	case envFrom.SecretRef != nil:
+		optional := envFrom.SecretRef.Optional != nil && *envFrom.SecretRef.Optional
+		source := "envFrom secret " + envFrom.SecretRef.Name
+		secret, err := r.secret(envFrom.SecretRef.Name)
+		if err != nil {
+			return nil, missingReference(source, err, optional)
+		}
+		for _, key := range slices.Sorted(maps.Keys(secret.Data)) {
+			vars = append(vars, r.secretValue(EnvVar{Name: envFrom.Prefix + key, Source: source}, secret.Name, secret.Data[key]))
+		}
+	}
+	return vars, ""
+}
+
+func (r *envResolver) resolveEnv(env v1.EnvVar, defined []EnvVar, index map[string]int) EnvVar {
+	envVar := EnvVar{Name: env.Name}
+	if env.ValueFrom == nil {
+		envVar.Source = "value"
+		envVar.Value = expandEnvVarReferences(env.Value, defined, index)
+		return envVar
+	}
+	switch ref := env.ValueFrom; {
+	case ref.ConfigMapKeyRef != nil:
+		envVar.Source = fmt.Sprintf("configMapKeyRef %s/%s", ref.ConfigMapKeyRef.Name, ref.ConfigMapKeyRef.Key)
+		optional := ref.ConfigMapKeyRef.Optional != nil && *ref.ConfigMapKeyRef.Optional
+		cm, err := r.configMap(ref.ConfigMapKeyRef.Name)
+		if err != nil {
+			envVar.Missing = missingReference("ConfigMap "+ref.ConfigMapKeyRef.Name, err, optional)
+			return envVar
+		}
+		value, ok := cm.Data[ref.ConfigMapKeyRef.Key]
+		if !ok {
+			envVar.Missing = missingKey("ConfigMap", ref.ConfigMapKeyRef.Name, ref.ConfigMapKeyRef.Key, optional)
+			return envVar
+		}
+		envVar.Value = value
+	case ref.SecretKeyRef != nil:
+		envVar.Source = fmt.Sprintf("secretKeyRef %s/%s", ref.SecretKeyRef.Name, ref.SecretKeyRef.Key)
+		optional := ref.SecretKeyRef.Optional != nil && *ref.SecretKeyRef.Optional
+		secret, err := r.secret(ref.SecretKeyRef.Name)
+		if err != nil {
+			envVar.Missing = missingReference("Secret "+ref.SecretKeyRef.Name, err, optional)
+			return envVar
+		}
+		value, ok := secret.Data[ref.SecretKeyRef.Key]
+		if !ok {
+			envVar.Missing = missingKey("Secret", ref.SecretKeyRef.Name, ref.SecretKeyRef.Key, optional)
+			return envVar
+		}
+		return r.secretValue(envVar, secret.Name, value)
+	case ref.FieldRef != nil:
+		envVar.Source = "fieldRef " + ref.FieldRef.FieldPath
+		value, err := podFieldValue(r.pod, ref.FieldRef.FieldPath)
+		if err != nil {
+			envVar.Missing = err.Error()
+			return envVar
+		}
+		envVar.Value = value
+	case ref.ResourceFieldRef != nil:
+		envVar.Source = "resourceFieldRef " + ref.ResourceFieldRef.Resource
+		value, note, err := r.containerResourceValue(ref.ResourceFieldRef)
+		if err != nil {
+			envVar.Missing = err.Error()
+			return envVar
+		}
+		envVar.Value = value
+		envVar.Note = note
+	default:
+		envVar.Source = "valueFrom"
+		envVar.Missing = "unsupported valueFrom source"
+	}
+	return envVar
+}
+
+// missingReference describes a ConfigMap or Secret reference that couldn't be retrieved
+func missingReference(source string, err error, optional bool) string {
+	if !apierrors.IsNotFound(err) {
+		return fmt.Sprintf("%s could not be retrieved: %v", source, err)
+	}
+	if optional {
+		return fmt.Sprintf("%s (optional): %v", source, err)
+	}
+	return fmt.Sprintf("%s: %v, the container will fail to start", source, err)
+}
+
+// missingKey describes a ConfigMap or Secret key reference that doesn't exist
+func missingKey(kind, name, key string, optional bool) string {
+	if optional {
+		return fmt.Sprintf("key %s not found in %s %s (optional)", key, kind, name)
+	}
+	return fmt.Sprintf("key %s not found in %s %s, the container will fail to start", key, kind, name)
+}
+
+// expandEnvVarReferences expands the $(VAR_NAME) references to previously defined variables like the kubelet does.
+// References to undefined variables are left unchanged and $$ escapes a $.
+func expandEnvVarReferences(value string, defined []EnvVar, index map[string]int) string {
+	return envVarReference.ReplaceAllStringFunc(value, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		name := match[2 : len(match)-1]
+		if i, ok := index[name]; ok && !defined[i].Redacted && defined[i].Missing == "" {
+			return defined[i].Value
+		}
+		return match
+	})
+}
+
+// podFieldValue returns the value of the downward API field of the Pod
+func podFieldValue(pod *v1.Pod, fieldPath string) (string, error) {
+	if path, subscript, ok := strings.Cut(fieldPath, "['"); ok && strings.HasSuffix(subscript, "']") {
+		key := strings.TrimSuffix(subscript, "']")
+		switch path {
+		case "metadata.labels":
+			return pod.Labels[key], nil
+		case "metadata.annotations":
+			return pod.Annotations[key], nil
+		}
+	}
+	switch fieldPath {
+	case "metadata.name":
+		return pod.Name, nil
+	case "metadata.namespace":
+		return pod.Namespace, nil
+	case "metadata.uid":
+		return string(pod.UID), nil
+	case "spec.nodeName":
+		return pod.Spec.NodeName, nil
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, nil
+	case "status.hostIP":
+		return pod.Status.HostIP, nil
+	case "status.hostIPs":
+		ips := make([]string, 0, len(pod.Status.HostIPs))
+		for _, ip := range pod.Status.HostIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), nil
+	case "status.podIP":
+		return pod.Status.PodIP, nil
+	case "status.podIPs":
+		ips := make([]string, 0, len(pod.Status.PodIPs))
+		for _, ip := range pod.Status.PodIPs {
+			ips = append(ips, ip.IP)
+		}
+		return strings.Join(ips, ","), nil
+	}
+	return "", fmt.Errorf("unsupported fieldRef %s", fieldPath)
+}
+
+// containerResourceValue returns the value of the container resource (limits or requests) divided by the divisor
+// and rounded up, like the kubelet does
+func (r *envResolver) containerResourceValue(ref *v1.ResourceFieldSelector) (string, string, error) {
+	ctr := r.container
+	if ref.ContainerName != "" && ref.ContainerName != ctr.Name {
+		var err error
+		if ctr, err = podContainer(r.pod, ref.ContainerName); err != nil {
+			return "", "", err
+		}
+	}
+	kind, resourceName, ok := strings.Cut(ref.Resource, ".")
+	if !ok || (kind != "limits" && kind != "requests") {
+		return "", "", fmt.Errorf("unsupported resourceFieldRef %s", ref.Resource)
+	}
+	resources := ctr.Resources.Limits
+	if kind == "requests" {
+		resources = ctr.Resources.Requests
+	}
+	quantity, ok := resources[v1.ResourceName(resourceName)]
+	if !ok {
+		if kind == "limits" {
+			return "", fmt.Sprintf("%s limit not set, the kubelet provides the node allocatable %s", resourceName, resourceName), nil
+		}
+		quantity = resource.Quantity{}
+	}
+	divisor := ref.Divisor
+	if divisor.IsZero() {
+		divisor = resource.MustParse("1")
+	}
+	if resourceName == string(v1.ResourceCPU) {
+		return fmt.Sprint(int64(math.Ceil(float64(quantity.MilliValue()) / float64(divisor.MilliValue())))), "", nil
+	}
+	return fmt.Sprint(int64(math.Ceil(float64(quantity.Value()) / float64(divisor.Value())))), "", nil
+}
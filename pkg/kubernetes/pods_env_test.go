@@ -0,0 +1,89 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PodsEnvSuite struct {
+	suite.Suite
+}
+
+func (s *PodsEnvSuite) TestExpandEnvVarReferences() {
+	defined := []EnvVar{
+		{Name: "HOST", Value: "db"},
+		{Name: "PASSWORD", Redacted: true},
+		{Name: "MISSING", Missing: "key not found"},
+	}
+	index := map[string]int{"HOST": 0, "PASSWORD": 1, "MISSING": 2}
+	s.Run("expands defined variables", func() {
+		s.Equal("postgres://db:5432", expandEnvVarReferences("postgres://$(HOST):5432", defined, index))
+	})
+	s.Run("leaves undefined, redacted and missing variables unchanged", func() {
+		s.Equal("$(UNDEFINED) $(PASSWORD) $(MISSING)", expandEnvVarReferences("$(UNDEFINED) $(PASSWORD) $(MISSING)", defined, index))
+	})
+	s.Run("unescapes $$", func() {
+		s.Equal("$(HOST) costs $5", expandEnvVarReferences("$$(HOST) costs $$5", defined, index))
+	})
+}
+
+func (s *PodsEnvSuite) TestPodFieldValue() {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pod", Namespace: "ns-1", Labels: map[string]string{"app": "web"}},
+		Spec:       v1.PodSpec{NodeName: "node-1"},
+		Status:     v1.PodStatus{PodIPs: []v1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}}},
+	}
+	for fieldPath, expected := range map[string]string{
+		"metadata.name":          "a-pod",
+		"metadata.namespace":     "ns-1",
+		"metadata.labels['app']": "web",
+		"spec.nodeName":          "node-1",
+		"status.podIPs":          "10.0.0.1,fd00::1",
+	} {
+		s.Run(fieldPath, func() {
+			value, err := podFieldValue(pod, fieldPath)
+			s.Require().NoError(err)
+			s.Equal(expected, value)
+		})
+	}
+	s.Run("unsupported field returns error", func() {
+		_, err := podFieldValue(pod, "spec.unknown")
+		s.EqualError(err, "unsupported fieldRef spec.unknown")
+	})
+}
+
+func (s *PodsEnvSuite) TestContainerResourceValue() {
+	ctr := &v1.Container{Name: "app", Resources: v1.ResourceRequirements{
+		Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+		Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m")},
+	}}
+	r := &envResolver{pod: &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{*ctr}}}, container: ctr}
+	s.Run("rounds up cpu to cores", func() {
+		value, _, err := r.containerResourceValue(&v1.ResourceFieldSelector{Resource: "requests.cpu"})
+		s.Require().NoError(err)
+		s.Equal("1", value)
+	})
+	s.Run("applies the divisor", func() {
+		value, _, err := r.containerResourceValue(&v1.ResourceFieldSelector{Resource: "limits.memory", Divisor: resource.MustParse("1Mi")})
+		s.Require().NoError(err)
+		s.Equal("1024", value)
+	})
+	s.Run("notes unset limits default to node allocatable", func() {
+		value, note, err := r.containerResourceValue(&v1.ResourceFieldSelector{Resource: "limits.cpu"})
+		s.Require().NoError(err)
+		s.Empty(value)
+		s.Equal("cpu limit not set, the kubelet provides the node allocatable cpu", note)
+	})
+	s.Run("unknown container returns error", func() {
+		_, _, err := r.containerResourceValue(&v1.ResourceFieldSelector{Resource: "limits.cpu", ContainerName: "other"})
+		s.EqualError(err, "container other not found in pod ")
+	})
+}
+
+func TestPodsEnv(t *testing.T) {
+	suite.Run(t, new(PodsEnvSuite))
+}
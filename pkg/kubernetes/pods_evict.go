@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// podsEvictMultiplePDBsMessage is the error message of the API server for the evictions of Pods selected by more than one
+// PodDisruptionBudget, which the Eviction API doesn't support
+const podsEvictMultiplePDBsMessage = "more than one PodDisruptionBudget"
+
+// PodsEvictResult is the outcome of a Pod eviction, either evicted or blocked by a PodDisruptionBudget
+type PodsEvictResult struct {
+	Namespace string
+	Name      string
+	Evicted   bool
+	// Message is the reason reported by the API server for the blocked evictions
+	Message string
+	// BlockedBy are the PodDisruptionBudgets selecting the Pod when the eviction is blocked (empty if they can't be listed)
+	BlockedBy []PodDisruptionBudgetStatus
+}
+
+// PodDisruptionBudgetStatus is the state of a PodDisruptionBudget relevant to an eviction
+type PodDisruptionBudgetStatus struct {
+	Name               string
+	DisruptionsAllowed int32
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+	ExpectedPods       int32
+}
+
+// PodsEvict evicts the Pod through the Eviction API (policy/v1) so that its PodDisruptionBudgets are honored.
+// An eviction blocked by a PodDisruptionBudget (429 Too Many Requests, or 500 Internal Error if the Pod is selected by
+// more than one PodDisruptionBudget) is not an error: the result reports it with the PodDisruptionBudgets that select the Pod.
+func (c *Core) PodsEvict(ctx context.Context, namespace, name string) (*PodsEvictResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := &PodsEvictResult{Namespace: namespace, Name: name}
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	err = c.CoreV1().Pods(namespace).EvictV1(ctx, eviction)
+	if err == nil {
+		ret.Evicted = true
+		return ret, nil
+	}
+	if !apierrors.IsTooManyRequests(err) && !(apierrors.IsInternalError(err) && strings.Contains(err.Error(), podsEvictMultiplePDBsMessage)) {
+		return nil, err
+	}
+	ret.Message = err.Error()
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) && statusErr.Status().Details != nil {
+		for _, cause := range statusErr.Status().Details.Causes {
+			if cause.Type == policyv1.DisruptionBudgetCause && cause.Message != "" {
+				ret.Message = cause.Message
+			}
+		}
+	}
+	// Best effort, the PodDisruptionBudgets might not be readable with the current permissions
+	pdbs, listErr := c.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if listErr != nil {
+		return ret, nil
+	}
+	for _, pdb := range pdbs.Items {
+		selector, selectorErr := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if selectorErr != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		ret.BlockedBy = append(ret.BlockedBy, PodDisruptionBudgetStatus{
+			Name:               pdb.Name,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			ExpectedPods:       pdb.Status.ExpectedPods,
+		})
+	}
+	return ret, nil
+}
+
+// String returns a human-readable summary of the PodDisruptionBudget state
+func (s PodDisruptionBudgetStatus) String() string {
+	return fmt.Sprintf("%s (disruptions allowed: %d, healthy pods: %d, desired healthy: %d, expected pods: %d)",
+		s.Name, s.DisruptionsAllowed, s.CurrentHealthy, s.DesiredHealthy, s.ExpectedPods)
+}
@@ -0,0 +1,313 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/ptr"
+)
+
+// PodReadiness explains whether a Pod is Ready, and if not, what is blocking its readiness
+type PodReadiness struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Ready     bool   `json:"ready"`
+	// Blockers are the plain-language reasons preventing the Pod from being Ready, the most relevant first
+	Blockers   []string             `json:"blockers,omitempty"`
+	Conditions []PodConditionStatus `json:"conditions"`
+	Containers []ContainerReadiness `json:"containers"`
+}
+
+// PodConditionStatus is the state of a Pod condition (status.conditions)
+type PodConditionStatus struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// ContainerReadiness is the readiness state of a Pod container (or init container)
+type ContainerReadiness struct {
+	Name string `json:"name"`
+	// Type is init, sidecar (restartable init container) or container
+	Type           string `json:"type"`
+	State          string `json:"state"`
+	Ready          bool   `json:"ready"`
+	Started        *bool  `json:"started,omitempty"`
+	RestartCount   int32  `json:"restartCount"`
+	StartupProbe   string `json:"startupProbe,omitempty"`
+	ReadinessProbe string `json:"readinessProbe,omitempty"`
+	// LastProbeFailure is the message of the most recent probe failure event (Unhealthy) of the container
+	LastProbeFailure string `json:"lastProbeFailure,omitempty"`
+}
+
+const (
+	containerTypeInit      = "init"
+	containerTypeSidecar   = "sidecar"
+	containerTypeContainer = "container"
+	// podUnhealthyEventReason is the reason of the events emitted by the kubelet for failed probes
+	podUnhealthyEventReason = "Unhealthy"
+)
+
+// PodsReadiness interprets the Pod conditions (PodScheduled, Initialized, ContainersReady, Ready and the readiness
+// gates) and the state of its containers and probes to explain what is blocking its readiness.
+// The probe failure messages are retrieved from the Pod events (best effort).
+func (c *Core) PodsReadiness(ctx context.Context, namespace, name string) (*PodReadiness, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	probeFailures := map[string]string{}
+	// Events are best effort (e.g. Events might be denied), the readiness can still be explained without them
+	events, eventsErr := c.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: fields.Set{
+		"involvedObject.kind": "Pod", "involvedObject.name": name, "reason": podUnhealthyEventReason,
+	}.String()})
+	if eventsErr == nil {
+		latest := map[string]time.Time{}
+		for _, event := range events.Items {
+			container := containerFromFieldPath(event.InvolvedObject.FieldPath)
+			last := eventTime(&event)
+			if event.InvolvedObject.UID != pod.UID || container == "" || last.Before(latest[container]) {
+				continue
+			}
+			latest[container] = last
+			probeFailures[container] = event.Message
+		}
+	}
+	return explainPodReadiness(pod, probeFailures), nil
+}
+
+// explainPodReadiness interprets the Pod status, probeFailures are the latest probe failure messages by container name
+func explainPodReadiness(pod *v1.Pod, probeFailures map[string]string) *PodReadiness {
+	ret := &PodReadiness{
+		Pod:        pod.Name,
+		Namespace:  pod.Namespace,
+		Phase:      string(pod.Status.Phase),
+		Conditions: []PodConditionStatus{},
+		Containers: []ContainerReadiness{},
+	}
+	conditions := map[v1.PodConditionType]*v1.PodCondition{}
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		conditions[condition.Type] = condition
+		status := PodConditionStatus{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		}
+		if !condition.LastTransitionTime.IsZero() {
+			status.LastTransitionTime = condition.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+		ret.Conditions = append(ret.Conditions, status)
+	}
+	isTrue := func(conditionType v1.PodConditionType) bool {
+		return conditions[conditionType] != nil && conditions[conditionType].Status == v1.ConditionTrue
+	}
+	ret.Ready = isTrue(v1.PodReady)
+
+	initStatuses := containerStatusesByName(pod.Status.InitContainerStatuses)
+	statuses := containerStatusesByName(pod.Status.ContainerStatuses)
+	var initBlocker string
+	for _, container := range pod.Spec.InitContainers {
+		readiness := containerReadiness(&container, initStatuses[container.Name], probeFailures[container.Name])
+		readiness.Type = containerTypeInit
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			readiness.Type = containerTypeSidecar
+		}
+		ret.Containers = append(ret.Containers, readiness)
+		// Init containers run sequentially, only the first one not completed (or sidecar not started) blocks the Pod
+		if initBlocker == "" {
+			initBlocker = initContainerBlocker(&readiness, initStatuses[container.Name])
+		}
+	}
+	var containerBlockers []string
+	for _, container := range pod.Spec.Containers {
+		readiness := containerReadiness(&container, statuses[container.Name], probeFailures[container.Name])
+		readiness.Type = containerTypeContainer
+		ret.Containers = append(ret.Containers, readiness)
+		if blocker := containerBlocker(&readiness, statuses[container.Name]); blocker != "" {
+			containerBlockers = append(containerBlockers, blocker)
+		}
+	}
+	if ret.Ready {
+		return ret
+	}
+
+	switch {
+	case pod.DeletionTimestamp != nil:
+		ret.Blockers = append(ret.Blockers, "the Pod is being deleted (Terminating), it's no longer Ready to receive traffic")
+	case pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed:
+		ret.Blockers = append(ret.Blockers, fmt.Sprintf("the Pod has terminated (phase %s), it will not be Ready again", pod.Status.Phase))
+	}
+	if conditions[v1.PodScheduled] != nil && !isTrue(v1.PodScheduled) || pod.Spec.NodeName == "" && pod.Status.Phase == v1.PodPending {
+		ret.Blockers = append(ret.Blockers, strings.TrimSuffix("the Pod is not scheduled to a node: "+conditionReason(conditions[v1.PodScheduled]), ": ")+
+			" (use pods_scheduling_info to find out why)")
+		return ret
+	}
+	if initBlocker != "" {
+		ret.Blockers = append(ret.Blockers, initBlocker)
+	} else if !isTrue(v1.PodInitialized) && conditions[v1.PodInitialized] != nil {
+		ret.Blockers = append(ret.Blockers, strings.TrimSuffix("the Pod is not initialized: "+conditionReason(conditions[v1.PodInitialized]), ": "))
+	}
+	// The containers don't start until the init containers complete
+	if initBlocker == "" {
+		ret.Blockers = append(ret.Blockers, containerBlockers...)
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		condition := conditions[gate.ConditionType]
+		switch {
+		case condition == nil:
+			ret.Blockers = append(ret.Blockers, fmt.Sprintf("readiness gate %s is not satisfied, the condition is not set on the Pod (it's set by an external controller, e.g. a load balancer controller)", gate.ConditionType))
+		case condition.Status != v1.ConditionTrue:
+			ret.Blockers = append(ret.Blockers, strings.TrimSuffix(fmt.Sprintf("readiness gate %s is not satisfied (%s): %s", gate.ConditionType, condition.Status, conditionReason(condition)), ": "))
+		}
+	}
+	if len(ret.Blockers) == 0 {
+		ret.Blockers = append(ret.Blockers, strings.TrimSuffix("the Pod Ready condition is not True: "+conditionReason(conditions[v1.PodReady]), ": "))
+	}
+	return ret
+}
+
+func containerReadiness(container *v1.Container, status *v1.ContainerStatus, probeFailure string) ContainerReadiness {
+	ret := ContainerReadiness{
+		Name:             container.Name,
+		State:            "Waiting: no status reported yet",
+		StartupProbe:     describeProbe(container.StartupProbe),
+		ReadinessProbe:   describeProbe(container.ReadinessProbe),
+		LastProbeFailure: probeFailure,
+	}
+	if status == nil {
+		return ret
+	}
+	ret.Ready = status.Ready
+	ret.Started = status.Started
+	ret.RestartCount = status.RestartCount
+	switch {
+	case status.State.Running != nil:
+		ret.State = "Running"
+	case status.State.Terminated != nil:
+		ret.State = strings.TrimSuffix(fmt.Sprintf("Terminated: %s (exit code %d): %s",
+			status.State.Terminated.Reason, status.State.Terminated.ExitCode, status.State.Terminated.Message), ": ")
+	case status.State.Waiting != nil:
+		ret.State = strings.TrimSuffix(fmt.Sprintf("Waiting: %s: %s", status.State.Waiting.Reason, status.State.Waiting.Message), ": ")
+	}
+	return ret
+}
+
+// initContainerBlocker returns why the init container blocks the Pod initialization (empty if it doesn't)
+func initContainerBlocker(readiness *ContainerReadiness, status *v1.ContainerStatus) string {
+	kind := "init container"
+	if readiness.Type == containerTypeSidecar {
+		kind = "sidecar (restartable init container)"
+		// Sidecars must be started (startup probe passed) for the next init containers and the containers to start
+		if status != nil && status.State.Running != nil && ptr.Deref(status.Started, false) {
+			return ""
+		}
+	} else if status != nil && status.State.Terminated != nil && status.State.Terminated.ExitCode == 0 {
+		return ""
+	}
+	switch {
+	case status == nil:
+		return fmt.Sprintf("%s %s has not started yet", kind, readiness.Name)
+	case status.State.Running != nil && readiness.Type == containerTypeSidecar:
+		return withProbeFailure(fmt.Sprintf("%s %s has not passed its startup probe yet (%s)", kind, readiness.Name, readiness.StartupProbe), readiness)
+	case status.State.Running != nil:
+		return fmt.Sprintf("%s %s is still running, the containers start once all the init containers complete", kind, readiness.Name)
+	case status.State.Terminated != nil:
+		return fmt.Sprintf("%s %s failed, %s%s", kind, readiness.Name, strings.ToLower(readiness.State[:1])+readiness.State[1:], restarts(readiness))
+	default:
+		return fmt.Sprintf("%s %s is %s%s", kind, readiness.Name, strings.ToLower(readiness.State[:1])+readiness.State[1:], restarts(readiness))
+	}
+}
+
+// containerBlocker returns why the container is not Ready (empty if it's Ready)
+func containerBlocker(readiness *ContainerReadiness, status *v1.ContainerStatus) string {
+	if readiness.Ready {
+		return ""
+	}
+	switch {
+	case status == nil:
+		return fmt.Sprintf("container %s has not started yet", readiness.Name)
+	case status.State.Waiting != nil || status.State.Terminated != nil:
+		return fmt.Sprintf("container %s is not running, %s%s", readiness.Name, strings.ToLower(readiness.State[:1])+readiness.State[1:], restarts(readiness))
+	case readiness.StartupProbe != "" && !ptr.Deref(status.Started, false):
+		return withProbeFailure(fmt.Sprintf("container %s has not passed its startup probe yet (%s), the readiness probe runs once it succeeds", readiness.Name, readiness.StartupProbe), readiness)
+	case readiness.ReadinessProbe != "":
+		return withProbeFailure(fmt.Sprintf("failing readiness probe on container %s (%s)", readiness.Name, readiness.ReadinessProbe), readiness)
+	default:
+		return fmt.Sprintf("container %s is running but not Ready yet (it has no readiness probe, it should become Ready shortly)", readiness.Name)
+	}
+}
+
+func withProbeFailure(blocker string, readiness *ContainerReadiness) string {
+	if readiness.LastProbeFailure == "" {
+		return blocker
+	}
+	return fmt.Sprintf("%s, last failure: %s", blocker, strings.TrimSpace(readiness.LastProbeFailure))
+}
+
+func restarts(readiness *ContainerReadiness) string {
+	if readiness.RestartCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (restarted %d times)", readiness.RestartCount)
+}
+
+// describeProbe returns a short description of the probe handler and timing (empty if there is no probe)
+func describeProbe(probe *v1.Probe) string {
+	if probe == nil {
+		return ""
+	}
+	var handler string
+	switch {
+	case probe.HTTPGet != nil:
+		scheme := strings.ToLower(string(cmp.Or(probe.HTTPGet.Scheme, v1.URISchemeHTTP)))
+		handler = fmt.Sprintf("HTTP GET %s://:%s%s", scheme, probe.HTTPGet.Port.String(), probe.HTTPGet.Path)
+	case probe.TCPSocket != nil:
+		handler = fmt.Sprintf("TCP socket :%s", probe.TCPSocket.Port.String())
+	case probe.GRPC != nil:
+		handler = fmt.Sprintf("gRPC :%d", probe.GRPC.Port)
+	case probe.Exec != nil:
+		handler = fmt.Sprintf("exec %s", strings.Join(probe.Exec.Command, " "))
+	default:
+		handler = "unknown handler"
+	}
+	// Unset timing fields take the API defaults
+	return fmt.Sprintf("%s, period %ds, failure threshold %d", handler,
+		cmp.Or(probe.PeriodSeconds, 10), cmp.Or(probe.FailureThreshold, 3))
+}
+
+func containerStatusesByName(statuses []v1.ContainerStatus) map[string]*v1.ContainerStatus {
+	ret := make(map[string]*v1.ContainerStatus, len(statuses))
+	for i := range statuses {
+		ret[statuses[i].Name] = &statuses[i]
+	}
+	return ret
+}
+
+func conditionReason(condition *v1.PodCondition) string {
+	if condition == nil {
+		return ""
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%s: %s", condition.Reason, condition.Message), ": ")
+}
+
+// containerFromFieldPath returns the container name of an event fieldPath (e.g. spec.containers{app})
+func containerFromFieldPath(fieldPath string) string {
+	for _, prefix := range []string{"spec.containers{", "spec.initContainers{"} {
+		if name, ok := strings.CutPrefix(fieldPath, prefix); ok {
+			return strings.TrimSuffix(name, "}")
+		}
+	}
+	return ""
+}
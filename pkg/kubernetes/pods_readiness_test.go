@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+type PodsReadinessSuite struct {
+	suite.Suite
+}
+
+func readinessPod(conditions map[v1.PodConditionType]v1.ConditionStatus) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-pod", Namespace: "default"},
+		Spec: v1.PodSpec{
+			NodeName: "node-1",
+			Containers: []v1.Container{{
+				Name: "app",
+				ReadinessProbe: &v1.Probe{ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080)},
+				}},
+			}},
+		},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}, Started: ptr.To(true)}},
+		},
+	}
+	for _, conditionType := range []v1.PodConditionType{v1.PodScheduled, v1.PodInitialized, v1.ContainersReady, v1.PodReady} {
+		if status, ok := conditions[conditionType]; ok {
+			pod.Status.Conditions = append(pod.Status.Conditions, v1.PodCondition{Type: conditionType, Status: status})
+		}
+	}
+	return pod
+}
+
+func (s *PodsReadinessSuite) TestExplainPodReadiness() {
+	s.Run("ready pod has no blockers", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{
+			v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.ContainersReady: v1.ConditionTrue, v1.PodReady: v1.ConditionTrue,
+		})
+		pod.Status.ContainerStatuses[0].Ready = true
+		readiness := explainPodReadiness(pod, nil)
+		s.True(readiness.Ready)
+		s.Empty(readiness.Blockers)
+		s.Len(readiness.Conditions, 4)
+	})
+	s.Run("unscheduled pod is blocked by scheduling", func() {
+		pod := readinessPod(nil)
+		pod.Spec.NodeName = ""
+		pod.Status = v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{
+			Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available: 3 Insufficient cpu.",
+		}}}
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal([]string{"the Pod is not scheduled to a node: Unschedulable: 0/3 nodes are available: 3 Insufficient cpu. (use pods_scheduling_info to find out why)"}, readiness.Blockers)
+	})
+	s.Run("running init container blocks the containers", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionFalse, v1.PodReady: v1.ConditionFalse})
+		pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}, {Name: "warmup"}}
+		pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+			{Name: "migrate", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			{Name: "warmup", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+		}
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "PodInitializing"}}}}
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal([]string{"init container migrate is still running, the containers start once all the init containers complete"}, readiness.Blockers)
+		s.Equal("init", readiness.Containers[0].Type)
+	})
+	s.Run("failed init container reports its exit code", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionFalse, v1.PodReady: v1.ConditionFalse})
+		pod.Spec.InitContainers = []v1.Container{{Name: "migrate"}}
+		pod.Status.InitContainerStatuses = []v1.ContainerStatus{
+			{Name: "migrate", RestartCount: 4, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 2}}},
+		}
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal([]string{"init container migrate failed, terminated: Error (exit code 2) (restarted 4 times)"}, readiness.Blockers)
+	})
+	s.Run("sidecar not started blocks the containers", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionFalse, v1.PodReady: v1.ConditionFalse})
+		pod.Spec.InitContainers = []v1.Container{{
+			Name:          "proxy",
+			RestartPolicy: ptr.To(v1.ContainerRestartPolicyAlways),
+			StartupProbe:  &v1.Probe{ProbeHandler: v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(15021)}}, PeriodSeconds: 1},
+		}}
+		pod.Status.InitContainerStatuses = []v1.ContainerStatus{{Name: "proxy", Started: ptr.To(false), State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}}
+		readiness := explainPodReadiness(pod, map[string]string{"proxy": "Startup probe failed: dial tcp 10.0.0.1:15021: connect: connection refused"})
+		s.Equal([]string{"sidecar (restartable init container) proxy has not passed its startup probe yet (TCP socket :15021, period 1s, failure threshold 3), " +
+			"last failure: Startup probe failed: dial tcp 10.0.0.1:15021: connect: connection refused"}, readiness.Blockers)
+		s.Equal("sidecar", readiness.Containers[0].Type)
+	})
+	s.Run("failing readiness probe reports the last failure", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{
+			v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.ContainersReady: v1.ConditionFalse, v1.PodReady: v1.ConditionFalse,
+		})
+		readiness := explainPodReadiness(pod, map[string]string{"app": "Readiness probe failed: HTTP probe failed with statuscode: 503"})
+		s.False(readiness.Ready)
+		s.Equal([]string{"failing readiness probe on container app (HTTP GET http://:8080/ready, period 10s, failure threshold 3), " +
+			"last failure: Readiness probe failed: HTTP probe failed with statuscode: 503"}, readiness.Blockers)
+	})
+	s.Run("startup probe not passed", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.PodReady: v1.ConditionFalse})
+		pod.Spec.Containers[0].StartupProbe = &v1.Probe{ProbeHandler: v1.ProbeHandler{Exec: &v1.ExecAction{Command: []string{"cat", "/tmp/started"}}}, FailureThreshold: 30}
+		pod.Status.ContainerStatuses[0].Started = ptr.To(false)
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal([]string{"container app has not passed its startup probe yet (exec cat /tmp/started, period 10s, failure threshold 30), the readiness probe runs once it succeeds"}, readiness.Blockers)
+	})
+	s.Run("crash looping container", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.PodReady: v1.ConditionFalse})
+		pod.Status.ContainerStatuses[0].RestartCount = 7
+		pod.Status.ContainerStatuses[0].State = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+			Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container=app",
+		}}
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal([]string{"container app is not running, waiting: CrashLoopBackOff: back-off 5m0s restarting failed container=app (restarted 7 times)"}, readiness.Blockers)
+	})
+	s.Run("unsatisfied readiness gates", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{
+			v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.ContainersReady: v1.ConditionTrue, v1.PodReady: v1.ConditionFalse,
+		})
+		pod.Status.ContainerStatuses[0].Ready = true
+		pod.Spec.ReadinessGates = []v1.PodReadinessGate{{ConditionType: "target-health.elbv2.k8s.aws/my-tg"}}
+		readiness := explainPodReadiness(pod, nil)
+		s.Len(readiness.Blockers, 1)
+		s.Contains(readiness.Blockers[0], "readiness gate target-health.elbv2.k8s.aws/my-tg is not satisfied, the condition is not set on the Pod")
+	})
+	s.Run("terminating pod", func() {
+		pod := readinessPod(map[v1.PodConditionType]v1.ConditionStatus{v1.PodScheduled: v1.ConditionTrue, v1.PodInitialized: v1.ConditionTrue, v1.PodReady: v1.ConditionFalse})
+		pod.DeletionTimestamp = ptr.To(metav1.Now())
+		readiness := explainPodReadiness(pod, nil)
+		s.Equal("the Pod is being deleted (Terminating), it's no longer Ready to receive traffic", readiness.Blockers[0])
+	})
+}
+
+func (s *PodsReadinessSuite) TestDescribeProbe() {
+	s.Empty(describeProbe(nil))
+	s.Equal("HTTP GET https://:metrics/healthz, period 5s, failure threshold 1", describeProbe(&v1.Probe{
+		ProbeHandler:  v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Scheme: v1.URISchemeHTTPS, Path: "/healthz", Port: intstr.FromString("metrics")}},
+		PeriodSeconds: 5, FailureThreshold: 1,
+	}))
+	s.Equal("gRPC :9090, period 10s, failure threshold 3", describeProbe(&v1.Probe{ProbeHandler: v1.ProbeHandler{GRPC: &v1.GRPCAction{Port: 9090}}}))
+}
+
+func (s *PodsReadinessSuite) TestContainerFromFieldPath() {
+	s.Equal("app", containerFromFieldPath("spec.containers{app}"))
+	s.Equal("migrate", containerFromFieldPath("spec.initContainers{migrate}"))
+	s.Empty(containerFromFieldPath(""))
+}
+
+func TestPodsReadiness(t *testing.T) {
+	suite.Run(t, new(PodsReadinessSuite))
+}
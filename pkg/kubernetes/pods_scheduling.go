@@ -0,0 +1,380 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/klog/v2"
+)
+
+// PodSchedulingInfo explains whether the cluster nodes can accommodate a Pod, and if not, why
+type PodSchedulingInfo struct {
+	Pod                  string            `json:"pod"`
+	Namespace            string            `json:"namespace"`
+	Phase                string            `json:"phase"`
+	NodeName             string            `json:"nodeName,omitempty"`
+	SchedulerMessage     string            `json:"schedulerMessage,omitempty"`
+	Requests             map[string]string `json:"requests,omitempty"`
+	Limits               map[string]string `json:"limits,omitempty"`
+	NodeSelector         map[string]string `json:"nodeSelector,omitempty"`
+	RequiredNodeAffinity []string          `json:"requiredNodeAffinity,omitempty"`
+	Tolerations          []string          `json:"tolerations,omitempty"`
+	Summary              string            `json:"summary"`
+	Notes                []string          `json:"notes,omitempty"`
+	Nodes                []NodeFit         `json:"nodes"`
+}
+
+// NodeFit is the result of evaluating a node as a candidate to run a Pod
+type NodeFit struct {
+	Name        string            `json:"name"`
+	Fits        bool              `json:"fits"`
+	Reasons     []string          `json:"reasons,omitempty"`
+	Taints      []string          `json:"taints,omitempty"`
+	Allocatable map[string]string `json:"allocatable"`
+	Available   map[string]string `json:"available"`
+}
+
+// nodeMismatch is a reason why a node can't accommodate a Pod.
+// The summary groups nodes by reason (like the scheduler does), the detail is specific to the node.
+type nodeMismatch struct {
+	reason string
+	detail string
+}
+
+// PodsSchedulingInfo compares the scheduling requirements of a Pod (resource requests, node selector,
+// required node affinity and tolerations) against every node in the cluster.
+// Pod (anti-)affinity, topology spread constraints, host ports and volume topology are not evaluated.
+func (c *Core) PodsSchedulingInfo(ctx context.Context, namespace, name string) (*PodSchedulingInfo, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	info := newPodSchedulingInfo(pod)
+	// Resources requested by the non-terminated Pods already bound to each node
+	requested := map[string]v1.ResourceList{}
+	podCount := map[string]int64{}
+	scheduled, err := c.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+	if err != nil {
+		info.Notes = append(info.Notes, fmt.Sprintf("The resources requested by the Pods running on the nodes could not be retrieved (%v), available resources are the node allocatable resources", err))
+	} else {
+		for i := range scheduled.Items {
+			scheduledPod := &scheduled.Items[i]
+			if scheduledPod.Spec.NodeName == "" || (scheduledPod.Namespace == pod.Namespace && scheduledPod.Name == pod.Name) {
+				continue
+			}
+			if requested[scheduledPod.Spec.NodeName] == nil {
+				requested[scheduledPod.Spec.NodeName] = v1.ResourceList{}
+			}
+			addResourceList(requested[scheduledPod.Spec.NodeName], podRequests(scheduledPod))
+			podCount[scheduledPod.Spec.NodeName]++
+		}
+	}
+	mismatches := map[string]int{}
+	fitting := 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		fit, nodeMismatches := evaluateNodeFit(klog.FromContext(ctx), pod, node, requested[node.Name], podCount[node.Name])
+		for _, m := range nodeMismatches {
+			mismatches[m.reason]++
+		}
+		if fit.Fits {
+			fitting++
+		}
+		info.Nodes = append(info.Nodes, fit)
+	}
+	slices.SortFunc(info.Nodes, func(a, b NodeFit) int { return strings.Compare(a.Name, b.Name) })
+	info.Summary = schedulingSummary(fitting, len(nodes.Items), mismatches)
+	return info, nil
+}
+
+func newPodSchedulingInfo(pod *v1.Pod) *PodSchedulingInfo {
+	info := &PodSchedulingInfo{
+		Pod:          pod.Name,
+		Namespace:    pod.Namespace,
+		Phase:        string(pod.Status.Phase),
+		NodeName:     pod.Spec.NodeName,
+		Requests:     formatResourceList(podRequests(pod), nil),
+		Limits:       formatResourceList(podLimits(pod), nil),
+		NodeSelector: pod.Spec.NodeSelector,
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse {
+			info.SchedulerMessage = strings.TrimSuffix(fmt.Sprintf("%s: %s", condition.Reason, condition.Message), ": ")
+		}
+	}
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil && pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			info.RequiredNodeAffinity = append(info.RequiredNodeAffinity, formatNodeSelectorTerm(term))
+		}
+	}
+	for _, toleration := range pod.Spec.Tolerations {
+		info.Tolerations = append(info.Tolerations, formatToleration(toleration))
+	}
+	if pod.Spec.NodeName != "" {
+		info.Notes = append(info.Notes, fmt.Sprintf("The Pod is already scheduled to node %s", pod.Spec.NodeName))
+	}
+	if pod.Spec.Affinity != nil && (pod.Spec.Affinity.PodAffinity != nil || pod.Spec.Affinity.PodAntiAffinity != nil) {
+		info.Notes = append(info.Notes, "The Pod affinity and anti-affinity rules are not evaluated")
+	}
+	if len(pod.Spec.TopologySpreadConstraints) > 0 {
+		info.Notes = append(info.Notes, "The Pod topology spread constraints are not evaluated")
+	}
+	return info
+}
+
+// evaluateNodeFit checks the node against the Pod scheduling requirements the same way the scheduler filters do
+func evaluateNodeFit(logger klog.Logger, pod *v1.Pod, node *v1.Node, requested v1.ResourceList, podCount int64) (NodeFit, []nodeMismatch) {
+	var mismatches []nodeMismatch
+	if node.Spec.Unschedulable && !toleratesTaint(logger, pod, &v1.Taint{Key: v1.TaintNodeUnschedulable, Effect: v1.TaintEffectNoSchedule}) {
+		mismatches = append(mismatches, nodeMismatch{reason: "node(s) were unschedulable", detail: "node is unschedulable (cordoned)"})
+	}
+	if len(pod.Spec.NodeSelector) > 0 && !labels.SelectorFromSet(pod.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		mismatches = append(mismatches, nodeMismatch{reason: "node(s) didn't match Pod's node selector", detail: "node labels don't match the Pod nodeSelector"})
+	}
+	if !matchesRequiredNodeAffinity(pod, node) {
+		mismatches = append(mismatches, nodeMismatch{reason: "node(s) didn't match Pod's node affinity", detail: "node labels don't match the Pod required node affinity"})
+	}
+	fit := NodeFit{Name: node.Name}
+	for _, taint := range node.Spec.Taints {
+		fit.Taints = append(fit.Taints, taint.ToString())
+		if taint.Effect == v1.TaintEffectPreferNoSchedule || toleratesTaint(logger, pod, &taint) {
+			continue
+		}
+		mismatches = append(mismatches, nodeMismatch{
+			reason: fmt.Sprintf("node(s) had untolerated taint {%s: %s}", taint.Key, taint.Value),
+			detail: fmt.Sprintf("untolerated taint %s", taint.ToString()),
+		})
+	}
+	requests := podRequests(pod)
+	available := v1.ResourceList{}
+	for resourceName, allocatable := range node.Status.Allocatable {
+		free := allocatable.DeepCopy()
+		if used, ok := requested[resourceName]; ok {
+			free.Sub(used)
+		}
+		available[resourceName] = free
+	}
+	if allocatablePods, ok := node.Status.Allocatable[v1.ResourcePods]; ok {
+		available[v1.ResourcePods] = *resource.NewQuantity(allocatablePods.Value()-podCount, resource.DecimalSI)
+		if podCount+1 > allocatablePods.Value() {
+			mismatches = append(mismatches, nodeMismatch{
+				reason: "Too many pods",
+				detail: fmt.Sprintf("too many pods: %d of %d allocatable pods already running", podCount, allocatablePods.Value()),
+			})
+		}
+	}
+	for _, resourceName := range slices.Sorted(maps.Keys(requests)) {
+		request := requests[resourceName]
+		if request.IsZero() {
+			continue
+		}
+		free, ok := available[resourceName]
+		if ok && request.Cmp(free) <= 0 {
+			continue
+		}
+		allocatable := node.Status.Allocatable[resourceName]
+		mismatches = append(mismatches, nodeMismatch{
+			reason: fmt.Sprintf("Insufficient %s", resourceName),
+			detail: fmt.Sprintf("insufficient %s: requests %s, available %s (allocatable %s)", resourceName, request.String(), free.String(), allocatable.String()),
+		})
+	}
+	fit.Fits = len(mismatches) == 0
+	for _, m := range mismatches {
+		fit.Reasons = append(fit.Reasons, m.detail)
+	}
+	// Only the resources relevant to the Pod are reported
+	relevant := func(resourceName v1.ResourceName) bool {
+		_, requestedByPod := requests[resourceName]
+		return requestedByPod || resourceName == v1.ResourceCPU || resourceName == v1.ResourceMemory || resourceName == v1.ResourcePods
+	}
+	fit.Allocatable = formatResourceList(node.Status.Allocatable, relevant)
+	fit.Available = formatResourceList(available, relevant)
+	return fit, mismatches
+}
+
+func toleratesTaint(logger klog.Logger, pod *v1.Pod, taint *v1.Taint) bool {
+	for i := range pod.Spec.Tolerations {
+		if pod.Spec.Tolerations[i].ToleratesTaint(logger, taint, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRequiredNodeAffinity returns true if the node matches any of the Pod's required node affinity terms (or if there are none)
+func matchesRequiredNodeAffinity(pod *v1.Pod, node *v1.Node) bool {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if matchesNodeSelectorTerm(term, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorTerm returns true if the node matches all the requirements of the term (empty terms match no objects)
+func matchesNodeSelectorTerm(term v1.NodeSelectorTerm, node *v1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	labelSelector, err := nodeSelectorRequirementsAsSelector(term.MatchExpressions)
+	if err != nil || !labelSelector.Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	fieldSelector, err := nodeSelectorRequirementsAsSelector(term.MatchFields)
+	return err == nil && fieldSelector.Matches(labels.Set{"metadata.name": node.Name})
+}
+
+func nodeSelectorRequirementsAsSelector(requirements []v1.NodeSelectorRequirement) (labels.Selector, error) {
+	selector := labels.NewSelector()
+	for _, requirement := range requirements {
+		var op selection.Operator
+		switch requirement.Operator {
+		case v1.NodeSelectorOpIn:
+			op = selection.In
+		case v1.NodeSelectorOpNotIn:
+			op = selection.NotIn
+		case v1.NodeSelectorOpExists:
+			op = selection.Exists
+		case v1.NodeSelectorOpDoesNotExist:
+			op = selection.DoesNotExist
+		case v1.NodeSelectorOpGt:
+			op = selection.GreaterThan
+		case v1.NodeSelectorOpLt:
+			op = selection.LessThan
+		default:
+			return nil, fmt.Errorf("%q is not a valid node selector operator", requirement.Operator)
+		}
+		r, err := labels.NewRequirement(requirement.Key, op, requirement.Values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*r)
+	}
+	return selector, nil
+}
+
+// podRequests returns the effective resource requests of the Pod as computed by the scheduler:
+// the largest of the app containers (plus sidecars) and of each init container (plus the sidecars started before it),
+// overridden by the Pod-level requests (if any), plus the Pod overhead.
+func podRequests(pod *v1.Pod) v1.ResourceList {
+	requests := v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+	sidecars := v1.ResourceList{}
+	initRequests := v1.ResourceList{}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			addResourceList(requests, container.Resources.Requests)
+			addResourceList(sidecars, container.Resources.Requests)
+			maxResourceList(initRequests, sidecars)
+			continue
+		}
+		initContainer := sidecars.DeepCopy()
+		addResourceList(initContainer, container.Resources.Requests)
+		maxResourceList(initRequests, initContainer)
+	}
+	maxResourceList(requests, initRequests)
+	if pod.Spec.Resources != nil {
+		maps.Copy(requests, pod.Spec.Resources.Requests)
+	}
+	addResourceList(requests, pod.Spec.Overhead)
+	return requests
+}
+
+// podLimits returns the aggregated limits of the app containers and sidecars, overridden by the Pod-level limits (if any)
+func podLimits(pod *v1.Pod) v1.ResourceList {
+	limits := v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			addResourceList(limits, container.Resources.Limits)
+		}
+	}
+	if pod.Spec.Resources != nil {
+		maps.Copy(limits, pod.Spec.Resources.Limits)
+	}
+	return limits
+}
+
+func addResourceList(list, toAdd v1.ResourceList) {
+	for name, quantity := range toAdd {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(list, other v1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func formatResourceList(list v1.ResourceList, include func(v1.ResourceName) bool) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	formatted := make(map[string]string, len(list))
+	for name, quantity := range list {
+		if include == nil || include(name) {
+			formatted[string(name)] = quantity.String()
+		}
+	}
+	return formatted
+}
+
+func formatNodeSelectorTerm(term v1.NodeSelectorTerm) string {
+	var requirements []string
+	for _, requirement := range slices.Concat(term.MatchExpressions, term.MatchFields) {
+		requirements = append(requirements, strings.TrimSpace(fmt.Sprintf("%s %s %s", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ","))))
+	}
+	return strings.Join(requirements, ", ")
+}
+
+func formatToleration(toleration v1.Toleration) string {
+	key := cmp.Or(toleration.Key, "*")
+	effect := cmp.Or(string(toleration.Effect), "*")
+	if toleration.Operator == v1.TolerationOpExists {
+		return fmt.Sprintf("%s:%s (exists)", key, effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", key, toleration.Value, effect)
+}
+
+func schedulingSummary(fitting, total int, mismatches map[string]int) string {
+	summary := fmt.Sprintf("%d/%d nodes can accommodate the Pod", fitting, total)
+	if len(mismatches) == 0 {
+		return summary
+	}
+	reasons := slices.SortedFunc(maps.Keys(mismatches), func(a, b string) int {
+		return cmp.Or(cmp.Compare(mismatches[b], mismatches[a]), strings.Compare(a, b))
+	})
+	for i, reason := range reasons {
+		reasons[i] = fmt.Sprintf("%d %s", mismatches[reason], reason)
+	}
+	return fmt.Sprintf("%s: %s", summary, strings.Join(reasons, ", "))
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+type PodsSchedulingSuite struct {
+	suite.Suite
+}
+
+func containerRequests(cpu, memory string) v1.ResourceRequirements {
+	return v1.ResourceRequirements{Requests: v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}}
+}
+
+func schedulingNode(name string, cpu, memory string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+			v1.ResourcePods:   resource.MustParse("110"),
+		}},
+	}
+}
+
+func (s *PodsSchedulingSuite) TestPodRequests() {
+	s.Run("sums app containers", func() {
+		pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "a", Resources: containerRequests("100m", "64Mi")},
+			{Name: "b", Resources: containerRequests("200m", "64Mi")},
+		}}}
+		s.Equal(map[string]string{"cpu": "300m", "memory": "128Mi"}, formatResourceList(podRequests(pod), nil))
+	})
+	s.Run("uses the largest init container if greater than app containers", func() {
+		pod := &v1.Pod{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Resources: containerRequests("1", "32Mi")}},
+			Containers:     []v1.Container{{Name: "app", Resources: containerRequests("100m", "64Mi")}},
+		}}
+		s.Equal(map[string]string{"cpu": "1", "memory": "64Mi"}, formatResourceList(podRequests(pod), nil))
+	})
+	s.Run("adds sidecars to app containers and to the init containers started after them", func() {
+		pod := &v1.Pod{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Name: "sidecar", RestartPolicy: ptr.To(v1.ContainerRestartPolicyAlways), Resources: containerRequests("100m", "16Mi")},
+				{Name: "init", Resources: containerRequests("500m", "16Mi")},
+			},
+			Containers: []v1.Container{{Name: "app", Resources: containerRequests("200m", "64Mi")}},
+		}}
+		s.Equal(map[string]string{"cpu": "600m", "memory": "80Mi"}, formatResourceList(podRequests(pod), nil))
+	})
+	s.Run("adds pod overhead", func() {
+		pod := &v1.Pod{Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app", Resources: containerRequests("200m", "64Mi")}},
+			Overhead:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")},
+		}}
+		s.Equal(map[string]string{"cpu": "250m", "memory": "64Mi"}, formatResourceList(podRequests(pod), nil))
+	})
+}
+
+func (s *PodsSchedulingSuite) TestEvaluateNodeFit() {
+	logger := klog.Background()
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Resources: containerRequests("1", "1Gi")}}}}
+	s.Run("node with enough resources fits", func() {
+		fit, mismatches := evaluateNodeFit(logger, pod, schedulingNode("node-1", "4", "8Gi"), nil, 0)
+		s.True(fit.Fits)
+		s.Empty(mismatches)
+		s.Equal(map[string]string{"cpu": "4", "memory": "8Gi", "pods": "110"}, fit.Available)
+	})
+	s.Run("node without enough free resources doesn't fit", func() {
+		used := v1.ResourceList{v1.ResourceCPU: resource.MustParse("3500m"), v1.ResourceMemory: resource.MustParse("1Gi")}
+		fit, mismatches := evaluateNodeFit(logger, pod, schedulingNode("node-1", "4", "8Gi"), used, 10)
+		s.False(fit.Fits)
+		s.Require().Len(mismatches, 1)
+		s.Equal("Insufficient cpu", mismatches[0].reason)
+		s.Equal([]string{"insufficient cpu: requests 1, available 500m (allocatable 4)"}, fit.Reasons)
+		s.Equal("100", fit.Available["pods"])
+	})
+	s.Run("node with untolerated NoSchedule taint doesn't fit", func() {
+		node := schedulingNode("control-plane", "4", "8Gi")
+		node.Spec.Taints = []v1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule}}
+		fit, mismatches := evaluateNodeFit(logger, pod, node, nil, 0)
+		s.False(fit.Fits)
+		s.Equal([]string{"node-role.kubernetes.io/control-plane:NoSchedule"}, fit.Taints)
+		s.Require().Len(mismatches, 1)
+		s.Equal("node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }", mismatches[0].reason)
+	})
+	s.Run("node with tolerated or PreferNoSchedule taints fits", func() {
+		node := schedulingNode("gpu", "4", "8Gi")
+		node.Spec.Taints = []v1.Taint{
+			{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule},
+		}
+		tolerating := pod.DeepCopy()
+		tolerating.Spec.Tolerations = []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpEqual, Value: "true", Effect: v1.TaintEffectNoSchedule}}
+		fit, _ := evaluateNodeFit(logger, tolerating, node, nil, 0)
+		s.True(fit.Fits)
+	})
+	s.Run("cordoned node doesn't fit", func() {
+		node := schedulingNode("node-1", "4", "8Gi")
+		node.Spec.Unschedulable = true
+		fit, _ := evaluateNodeFit(logger, pod, node, nil, 0)
+		s.False(fit.Fits)
+		s.Contains(fit.Reasons, "node is unschedulable (cordoned)")
+	})
+	s.Run("node not matching nodeSelector doesn't fit", func() {
+		selecting := pod.DeepCopy()
+		selecting.Spec.NodeSelector = map[string]string{"disktype": "ssd"}
+		fit, _ := evaluateNodeFit(logger, selecting, schedulingNode("node-1", "4", "8Gi"), nil, 0)
+		s.False(fit.Fits)
+		s.Contains(fit.Reasons, "node labels don't match the Pod nodeSelector")
+	})
+	s.Run("full node doesn't fit", func() {
+		fit, _ := evaluateNodeFit(logger, pod, schedulingNode("node-1", "4", "8Gi"), nil, 110)
+		s.False(fit.Fits)
+		s.Contains(fit.Reasons, "too many pods: 110 of 110 allocatable pods already running")
+	})
+}
+
+func (s *PodsSchedulingSuite) TestMatchesRequiredNodeAffinity() {
+	affinity := func(terms ...v1.NodeSelectorTerm) *v1.Pod {
+		return &v1.Pod{Spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: terms},
+		}}}}
+	}
+	node := schedulingNode("node-1", "4", "8Gi")
+	node.Labels["topology.kubernetes.io/zone"] = "zone-a"
+	node.Labels["cores"] = "8"
+	s.Run("pod without affinity matches", func() {
+		s.True(matchesRequiredNodeAffinity(&v1.Pod{}, node))
+	})
+	s.Run("matching expression matches", func() {
+		s.True(matchesRequiredNodeAffinity(affinity(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+			{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{"zone-a", "zone-b"}},
+			{Key: "cores", Operator: v1.NodeSelectorOpGt, Values: []string{"4"}},
+		}}), node))
+	})
+	s.Run("any matching term matches", func() {
+		s.True(matchesRequiredNodeAffinity(affinity(
+			v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "gpu", Operator: v1.NodeSelectorOpExists}}},
+			v1.NodeSelectorTerm{MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-1"}}}},
+		), node))
+	})
+	s.Run("no matching term doesn't match", func() {
+		s.False(matchesRequiredNodeAffinity(affinity(
+			v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpNotIn, Values: []string{"zone-a"}}}},
+			v1.NodeSelectorTerm{},
+		), node))
+	})
+}
+
+func (s *PodsSchedulingSuite) TestSchedulingSummary() {
+	s.Equal("2/2 nodes can accommodate the Pod", schedulingSummary(2, 2, map[string]int{}))
+	s.Equal("0/3 nodes can accommodate the Pod: 2 Insufficient cpu, 1 Insufficient memory, 1 node(s) were unschedulable",
+		schedulingSummary(0, 3, map[string]int{"node(s) were unschedulable": 1, "Insufficient cpu": 2, "Insufficient memory": 1}))
+}
+
+func TestPodsScheduling(t *testing.T) {
+	suite.Run(t, new(PodsSchedulingSuite))
+}
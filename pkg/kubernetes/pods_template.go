@@ -0,0 +1,276 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// maxControllerChainDepth bounds the owner references followed to find the top-level controller of a Pod
+const maxControllerChainDepth = 10
+
+// serviceAccountTokenVolumePrefix is the prefix of the projected ServiceAccount token volume injected by the admission
+const serviceAccountTokenVolumePrefix = "kube-api-access-"
+
+// PodTemplateDrift reports whether a Pod matches the current Pod template of its controller
+type PodTemplateDrift struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	// Controllers is the controller chain of the Pod, from its direct owner to the top-level controller (e.g. ReplicaSet/web-5d4f, Deployment/web)
+	Controllers []string `json:"controllers"`
+	// Template is the controller whose current Pod template is the desired state of the Pod
+	Template string `json:"template"`
+	// UpToDate is true when the Pod matches the Pod template
+	UpToDate bool `json:"upToDate"`
+	// Differences describes the fields of the Pod that don't match the Pod template
+	Differences []string `json:"differences,omitempty"`
+	// Diff is the unified diff between the compared fields of the live Pod and of the Pod template
+	Diff string `json:"-"`
+}
+
+// podTemplateFields are the Pod fields propagated by the controllers from their Pod template that are compared
+type podTemplateFields struct {
+	Labels             map[string]string      `json:"labels,omitempty"`
+	ServiceAccountName string                 `json:"serviceAccountName,omitempty"`
+	NodeSelector       map[string]string      `json:"nodeSelector,omitempty"`
+	Volumes            []v1.Volume            `json:"volumes,omitempty"`
+	InitContainers     []podTemplateContainer `json:"initContainers,omitempty"`
+	Containers         []podTemplateContainer `json:"containers,omitempty"`
+}
+
+// podTemplateContainer are the container fields that are compared
+type podTemplateContainer struct {
+	Name         string                  `json:"name"`
+	Image        string                  `json:"image"`
+	Command      []string                `json:"command,omitempty"`
+	Args         []string                `json:"args,omitempty"`
+	Env          []v1.EnvVar             `json:"env,omitempty"`
+	EnvFrom      []v1.EnvFromSource      `json:"envFrom,omitempty"`
+	Ports        []v1.ContainerPort      `json:"ports,omitempty"`
+	Resources    v1.ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts []v1.VolumeMount        `json:"volumeMounts,omitempty"`
+}
+
+// PodsTemplateDrift compares a Pod with the current Pod template of its top-level controller (e.g. the Deployment of
+// its ReplicaSet) to detect the Pods left behind by a rollout or edited manually.
+// Only the fields propagated from the template are compared (labels, ServiceAccount, nodeSelector, volumes and the
+// containers image, command, args, environment, ports, resources and volume mounts). The ServiceAccount token volume
+// injected by the API server is ignored.
+func (c *Core) PodsTemplateDrift(ctx context.Context, namespace, name string) (*PodTemplateDrift, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	controllers, err := c.controllerChain(ctx, namespace, pod.OwnerReferences)
+	if err != nil {
+		return nil, err
+	}
+	if len(controllers) == 0 {
+		return nil, fmt.Errorf("pod %s is not managed by a controller", pod.Name)
+	}
+	ret := &PodTemplateDrift{Pod: pod.Name, Namespace: pod.Namespace}
+	var templateOwner *unstructured.Unstructured
+	var template map[string]interface{}
+	for _, controller := range controllers {
+		ret.Controllers = append(ret.Controllers, controller.GetKind()+"/"+controller.GetName())
+		// The top-level controller defining a Pod template wins (a Deployment over its ReplicaSets)
+		if t, found, _ := unstructured.NestedMap(controller.Object, "spec", "template"); found {
+			templateOwner, template = controller, t
+		}
+	}
+	if templateOwner == nil {
+		return nil, fmt.Errorf("the controllers of pod %s (%s) don't define a Pod template", pod.Name, strings.Join(ret.Controllers, ", "))
+	}
+	ret.Template = templateOwner.GetKind() + "/" + templateOwner.GetName()
+	podTemplate := &v1.PodTemplateSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplate); err != nil {
+		return nil, fmt.Errorf("failed to read the Pod template of %s: %w", ret.Template, err)
+	}
+	desired := podTemplateFieldsOf(podTemplate.Labels, &podTemplate.Spec, podTemplate.Labels)
+	live := podTemplateFieldsOf(pod.Labels, &pod.Spec, podTemplate.Labels)
+	ret.Differences = podTemplateDifferences(desired, live)
+	ret.UpToDate = len(ret.Differences) == 0
+	if !ret.UpToDate {
+		liveYaml, err := yaml.Marshal(live)
+		if err != nil {
+			return nil, err
+		}
+		desiredYaml, err := yaml.Marshal(desired)
+		if err != nil {
+			return nil, err
+		}
+		ret.Diff, err = unifiedDiff(pod.Name, "live/Pod/"+pod.Name, string(liveYaml), "desired/"+ret.Template, string(desiredYaml))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// controllerChain follows the controller owner references up to the top-level controller
+func (c *Core) controllerChain(ctx context.Context, namespace string, ownerReferences []metav1.OwnerReference) ([]*unstructured.Unstructured, error) {
+	var chain []*unstructured.Unstructured
+	visited := map[string]bool{}
+	for len(chain) < maxControllerChainDepth {
+		ref := controllerOf(ownerReferences)
+		if ref == nil || visited[string(ref.UID)] {
+			break
+		}
+		visited[string(ref.UID)] = true
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid controller apiVersion %s: %w", ref.APIVersion, err)
+		}
+		gvk := gv.WithKind(ref.Kind)
+		gvr, err := c.resourceFor(&gvk)
+		if err != nil {
+			return nil, err
+		}
+		controllerNamespace := namespace
+		if namespaced, nErr := c.isNamespaced(&gvk); nErr == nil && !namespaced {
+			controllerNamespace = ""
+		}
+		controller, err := c.DynamicClient().Resource(*gvr).Namespace(controllerNamespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get controller %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		chain = append(chain, controller)
+		ownerReferences = controller.GetOwnerReferences()
+	}
+	return chain, nil
+}
+
+// controllerOf returns the owner reference flagged as the managing controller
+func controllerOf(ownerReferences []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range ownerReferences {
+		if ownerReferences[i].Controller != nil && *ownerReferences[i].Controller {
+			return &ownerReferences[i]
+		}
+	}
+	return nil
+}
+
+// podTemplateFieldsOf returns the compared fields of the Pod spec, only the labels defined by the template are kept
+// (the controllers add their own labels, e.g. pod-template-hash)
+func podTemplateFieldsOf(labels map[string]string, spec *v1.PodSpec, templateLabels map[string]string) *podTemplateFields {
+	fields := &podTemplateFields{
+		Labels:             map[string]string{},
+		ServiceAccountName: spec.ServiceAccountName,
+		NodeSelector:       spec.NodeSelector,
+	}
+	for key := range templateLabels {
+		if value, ok := labels[key]; ok {
+			fields.Labels[key] = value
+		}
+	}
+	// The API server sets the default ServiceAccount of the Pods that don't define one
+	if fields.ServiceAccountName == "" {
+		fields.ServiceAccountName = "default"
+	}
+	for _, volume := range spec.Volumes {
+		if !strings.HasPrefix(volume.Name, serviceAccountTokenVolumePrefix) {
+			fields.Volumes = append(fields.Volumes, volume)
+		}
+	}
+	fields.InitContainers = podTemplateContainers(spec.InitContainers)
+	fields.Containers = podTemplateContainers(spec.Containers)
+	return fields
+}
+
+func podTemplateContainers(containers []v1.Container) []podTemplateContainer {
+	var ret []podTemplateContainer
+	for _, container := range containers {
+		c := podTemplateContainer{
+			Name:      container.Name,
+			Image:     container.Image,
+			Command:   container.Command,
+			Args:      container.Args,
+			Env:       container.Env,
+			EnvFrom:   container.EnvFrom,
+			Ports:     container.Ports,
+			Resources: container.Resources,
+		}
+		for _, volumeMount := range container.VolumeMounts {
+			if !strings.HasPrefix(volumeMount.Name, serviceAccountTokenVolumePrefix) {
+				c.VolumeMounts = append(c.VolumeMounts, volumeMount)
+			}
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+// podTemplateDifferences describes the fields of the live Pod that don't match the desired ones
+func podTemplateDifferences(desired, live *podTemplateFields) []string {
+	var differences []string
+	for _, key := range slices.Sorted(maps.Keys(desired.Labels)) {
+		if value, ok := live.Labels[key]; !ok {
+			differences = append(differences, fmt.Sprintf("label %s is missing (template %s)", key, desired.Labels[key]))
+		} else if value != desired.Labels[key] {
+			differences = append(differences, fmt.Sprintf("label %s differs (template %s, Pod %s)", key, desired.Labels[key], value))
+		}
+	}
+	if desired.ServiceAccountName != live.ServiceAccountName {
+		differences = append(differences, fmt.Sprintf("serviceAccountName differs (template %s, Pod %s)", desired.ServiceAccountName, live.ServiceAccountName))
+	}
+	if !equality.Semantic.DeepEqual(desired.NodeSelector, live.NodeSelector) {
+		differences = append(differences, "nodeSelector differs")
+	}
+	if !equality.Semantic.DeepEqual(desired.Volumes, live.Volumes) {
+		differences = append(differences, "volumes differ")
+	}
+	differences = append(differences, podTemplateContainerDifferences("init container", desired.InitContainers, live.InitContainers)...)
+	differences = append(differences, podTemplateContainerDifferences("container", desired.Containers, live.Containers)...)
+	return differences
+}
+
+func podTemplateContainerDifferences(kind string, desired, live []podTemplateContainer) []string {
+	var differences []string
+	liveByName := map[string]*podTemplateContainer{}
+	for i := range live {
+		liveByName[live[i].Name] = &live[i]
+	}
+	for i := range desired {
+		d := &desired[i]
+		l, ok := liveByName[d.Name]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("%s %s is missing from the Pod", kind, d.Name))
+			continue
+		}
+		delete(liveByName, d.Name)
+		if d.Image != l.Image {
+			differences = append(differences, fmt.Sprintf("%s %s image differs (template %s, Pod %s)", kind, d.Name, d.Image, l.Image))
+		}
+		for _, field := range []struct {
+			name          string
+			desired, live interface{}
+		}{
+			{"command", d.Command, l.Command},
+			{"args", d.Args, l.Args},
+			{"env", d.Env, l.Env},
+			{"envFrom", d.EnvFrom, l.EnvFrom},
+			{"ports", d.Ports, l.Ports},
+			{"resources", d.Resources, l.Resources},
+			{"volumeMounts", d.VolumeMounts, l.VolumeMounts},
+		} {
+			if !equality.Semantic.DeepEqual(field.desired, field.live) {
+				differences = append(differences, fmt.Sprintf("%s %s %s differs", kind, d.Name, field.name))
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(liveByName)) {
+		differences = append(differences, fmt.Sprintf("%s %s is not defined in the template", kind, name))
+	}
+	return differences
+}
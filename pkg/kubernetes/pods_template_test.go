@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type PodsTemplateSuite struct {
+	suite.Suite
+}
+
+func (s *PodsTemplateSuite) TestPodTemplateDifferences() {
+	templateLabels := map[string]string{"app": "web"}
+	templateSpec := func() *v1.PodSpec {
+		return &v1.PodSpec{Containers: []v1.Container{{
+			Name:  "web",
+			Image: "nginx:1.26",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			},
+		}}}
+	}
+	desired := podTemplateFieldsOf(templateLabels, templateSpec(), templateLabels)
+	s.Run("Pod matching the template has no differences", func() {
+		podSpec := templateSpec()
+		podSpec.ServiceAccountName = "default"
+		podSpec.Containers[0].Resources.Requests[v1.ResourceCPU] = resource.MustParse("1000m")
+		podSpec.Volumes = []v1.Volume{{Name: "kube-api-access-x1y2z"}}
+		podSpec.Containers[0].VolumeMounts = []v1.VolumeMount{{Name: "kube-api-access-x1y2z", MountPath: "/var/run/secrets/kubernetes.io/serviceaccount"}}
+		live := podTemplateFieldsOf(map[string]string{"app": "web", "pod-template-hash": "hash"}, podSpec, templateLabels)
+		s.Empty(podTemplateDifferences(desired, live))
+	})
+	s.Run("Pod edited manually reports the differences", func() {
+		podSpec := templateSpec()
+		podSpec.Containers[0].Image = "nginx:1.25"
+		podSpec.Containers[0].Resources.Requests[v1.ResourceCPU] = resource.MustParse("2")
+		podSpec.Containers = append(podSpec.Containers, v1.Container{Name: "sidecar", Image: "proxy"})
+		live := podTemplateFieldsOf(map[string]string{"app": "api"}, podSpec, templateLabels)
+		s.Equal([]string{
+			"label app differs (template web, Pod api)",
+			"container web image differs (template nginx:1.26, Pod nginx:1.25)",
+			"container web resources differs",
+			"container sidecar is not defined in the template",
+		}, podTemplateDifferences(desired, live))
+	})
+	s.Run("Pod missing a template label and container reports the differences", func() {
+		podSpec := &v1.PodSpec{}
+		live := podTemplateFieldsOf(map[string]string{}, podSpec, templateLabels)
+		s.Equal([]string{
+			"label app is missing (template web)",
+			"container web is missing from the Pod",
+		}, podTemplateDifferences(desired, live))
+	})
+}
+
+func TestPodsTemplate(t *testing.T) {
+	suite.Run(t, new(PodsTemplateSuite))
+}
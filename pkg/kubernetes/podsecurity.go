@@ -0,0 +1,415 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodSecurityModes are the PodSecurity admission modes configured with the pod-security.kubernetes.io/<mode> labels
+var PodSecurityModes = []string{"enforce", "audit", "warn"}
+
+// PodSecurityCheckResult is the result of PodSecurityCheck
+type PodSecurityCheckResult struct {
+	// Namespace is the checked namespace
+	Namespace string
+	// Modes maps the PodSecurity admission modes configured in the namespace to their level and version
+	Modes map[string]map[string]string
+	// Level is the Pod Security Standards level the Pods were evaluated against
+	Level string
+	// Pods is the number of evaluated Pods
+	Pods int
+	// Violations are the Pods that don't comply with the evaluated level and the reasons why
+	Violations []map[string]any
+}
+
+// PodSecurityCheck evaluates the Pods in the namespace against the Pod Security Standards level (baseline or
+// restricted), defaults to the level enforced in the namespace (privileged, which allows everything, if not set).
+// The checks follow the latest version of the Pod Security Standards.
+func (c *Core) PodSecurityCheck(ctx context.Context, namespace, level string) (*PodSecurityCheckResult, error) {
+	if level != "" && !slices.Contains(PodSecurityLevels, level) {
+		return nil, fmt.Errorf("invalid level %s (expected %s)", level, strings.Join(PodSecurityLevels, ", "))
+	}
+	ns, err := c.CoreV1().Namespaces().Get(ctx, c.NamespaceOrDefault(namespace), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret := &PodSecurityCheckResult{Namespace: ns.Name, Modes: map[string]map[string]string{}, Level: level, Violations: []map[string]any{}}
+	for _, mode := range PodSecurityModes {
+		modeLevel, found := ns.Labels[podSecurityLabelPrefix+mode]
+		if !found {
+			continue
+		}
+		modeVersion := ns.Labels[podSecurityLabelPrefix+mode+"-version"]
+		if modeVersion == "" {
+			modeVersion = "latest"
+		}
+		ret.Modes[mode] = map[string]string{"Level": modeLevel, "Version": modeVersion}
+	}
+	if ret.Level == "" {
+		ret.Level = "privileged"
+		if enforce, ok := ret.Modes["enforce"]; ok && slices.Contains(PodSecurityLevels, enforce["Level"]) {
+			ret.Level = enforce["Level"]
+		}
+	}
+	pods, err := c.CoreV1().Pods(ns.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ret.Pods = len(pods.Items)
+	for _, pod := range pods.Items {
+		if violations := PodSecurityViolations(&pod, ret.Level); len(violations) > 0 {
+			ret.Violations = append(ret.Violations, map[string]any{
+				"Pod":        pod.Name,
+				"Workload":   podWorkload(&pod),
+				"Violations": violations,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// PodSecurityViolations returns the reasons why the Pod doesn't comply with the Pod Security Standards level,
+// the restricted level includes the baseline checks and the privileged level allows everything
+func PodSecurityViolations(pod *v1.Pod, level string) []string {
+	var checks []podSecurityCheck
+	switch level {
+	case "baseline":
+		checks = podSecurityBaselineChecks
+	case "restricted":
+		checks = slices.Concat(podSecurityBaselineChecks, podSecurityRestrictedChecks)
+	}
+	var violations []string
+	for _, check := range checks {
+		if violation := check(pod); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// podSecurityCheck returns the violation of a Pod Security Standards control by the Pod, or an empty string
+type podSecurityCheck func(pod *v1.Pod) string
+
+var podSecurityBaselineChecks = []podSecurityCheck{
+	podSecurityHostProcess,
+	podSecurityHostNamespaces,
+	podSecurityPrivileged,
+	podSecurityBaselineCapabilities,
+	podSecurityHostPathVolumes,
+	podSecurityHostPorts,
+	podSecurityAppArmor,
+	podSecuritySELinux,
+	podSecurityProcMount,
+	podSecuritySeccompBaseline,
+	podSecuritySysctls,
+}
+
+var podSecurityRestrictedChecks = []podSecurityCheck{
+	podSecurityVolumeTypes,
+	podSecurityAllowPrivilegeEscalation,
+	podSecurityRunAsNonRoot,
+	podSecurityRunAsUser,
+	podSecuritySeccompRestricted,
+	podSecurityRestrictedCapabilities,
+}
+
+var (
+	// podSecurityBaselineAllowedCapabilities are the capabilities that can be added in the baseline level
+	podSecurityBaselineAllowedCapabilities = []v1.Capability{"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL",
+		"MKNOD", "NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT"}
+	// podSecurityAllowedSELinuxTypes are the SELinux types that can be set in the baseline level
+	podSecurityAllowedSELinuxTypes = []string{"", "container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+	// podSecuritySafeSysctls are the sysctls that can be set in the baseline level
+	podSecuritySafeSysctls = []string{"kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range", "net.ipv4.ip_unprivileged_port_start",
+		"net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range", "net.ipv4.ip_local_reserved_ports", "net.ipv4.tcp_keepalive_time",
+		"net.ipv4.tcp_fin_timeout", "net.ipv4.tcp_keepalive_intvl", "net.ipv4.tcp_keepalive_probes"}
+	// podSecurityRestrictedVolumeTypes are the only volume types allowed in the restricted level
+	podSecurityRestrictedVolumeTypes = []string{"configMap", "csi", "downwardAPI", "emptyDir", "ephemeral", "persistentVolumeClaim", "projected", "secret"}
+)
+
+// podSecurityContainer is a regular, init or ephemeral container of the Pod
+type podSecurityContainer struct {
+	name            string
+	securityContext *v1.SecurityContext
+	ports           []v1.ContainerPort
+}
+
+func podSecurityContainers(pod *v1.Pod) []podSecurityContainer {
+	var containers []podSecurityContainer
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		containers = append(containers, podSecurityContainer{container.Name, container.SecurityContext, container.Ports})
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		containers = append(containers, podSecurityContainer{container.Name, container.SecurityContext, container.Ports})
+	}
+	return containers
+}
+
+// podSecurityContainersViolation returns the violation for the containers matching the predicate (if any)
+func podSecurityContainersViolation(pod *v1.Pod, reason, must string, violates func(c *podSecurityContainer) bool) string {
+	var names []string
+	for _, container := range podSecurityContainers(pod) {
+		if violates(&container) {
+			names = append(names, fmt.Sprintf("%q", container.name))
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s (container %s must %s)", reason, names[0], must)
+	}
+	return fmt.Sprintf("%s (containers %s must %s)", reason, strings.Join(names, ", "), must)
+}
+
+func podSecurityIsWindows(pod *v1.Pod) bool {
+	return pod.Spec.OS != nil && pod.Spec.OS.Name == v1.Windows
+}
+
+func podSecurityHostProcess(pod *v1.Pod) string {
+	podHostProcess := pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.WindowsOptions != nil &&
+		pod.Spec.SecurityContext.WindowsOptions.HostProcess != nil && *pod.Spec.SecurityContext.WindowsOptions.HostProcess
+	if podHostProcess {
+		return "hostProcess (pod must not set securityContext.windowsOptions.hostProcess=true)"
+	}
+	return podSecurityContainersViolation(pod, "hostProcess", "not set securityContext.windowsOptions.hostProcess=true", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && c.securityContext.WindowsOptions != nil &&
+			c.securityContext.WindowsOptions.HostProcess != nil && *c.securityContext.WindowsOptions.HostProcess
+	})
+}
+
+func podSecurityHostNamespaces(pod *v1.Pod) string {
+	var hostNamespaces []string
+	if pod.Spec.HostNetwork {
+		hostNamespaces = append(hostNamespaces, "hostNetwork=true")
+	}
+	if pod.Spec.HostPID {
+		hostNamespaces = append(hostNamespaces, "hostPID=true")
+	}
+	if pod.Spec.HostIPC {
+		hostNamespaces = append(hostNamespaces, "hostIPC=true")
+	}
+	if len(hostNamespaces) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("host namespaces (%s)", strings.Join(hostNamespaces, ", "))
+}
+
+func podSecurityPrivileged(pod *v1.Pod) string {
+	return podSecurityContainersViolation(pod, "privileged", "not set securityContext.privileged=true", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && c.securityContext.Privileged != nil && *c.securityContext.Privileged
+	})
+}
+
+func podSecurityBaselineCapabilities(pod *v1.Pod) string {
+	var added []string
+	violation := podSecurityContainersViolation(pod, "non-default capabilities", "not include the added capabilities in securityContext.capabilities.add", func(c *podSecurityContainer) bool {
+		violates := false
+		if c.securityContext != nil && c.securityContext.Capabilities != nil {
+			for _, capability := range c.securityContext.Capabilities.Add {
+				if !slices.Contains(podSecurityBaselineAllowedCapabilities, capability) {
+					violates = true
+					if !slices.Contains(added, string(capability)) {
+						added = append(added, string(capability))
+					}
+				}
+			}
+		}
+		return violates
+	})
+	if violation == "" {
+		return ""
+	}
+	return strings.Replace(violation, "the added capabilities", strings.Join(added, ", "), 1)
+}
+
+func podSecurityHostPathVolumes(pod *v1.Pod) string {
+	var volumes []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			volumes = append(volumes, fmt.Sprintf("%q", volume.Name))
+		}
+	}
+	if len(volumes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("hostPath volumes (volumes %s)", strings.Join(volumes, ", "))
+}
+
+func podSecurityHostPorts(pod *v1.Pod) string {
+	return podSecurityContainersViolation(pod, "hostPort", "not set hostPort", func(c *podSecurityContainer) bool {
+		for _, port := range c.ports {
+			if port.HostPort != 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func podSecurityAppArmor(pod *v1.Pod) string {
+	unconfined := func(profile *v1.AppArmorProfile) bool {
+		return profile != nil && profile.Type == v1.AppArmorProfileTypeUnconfined
+	}
+	if pod.Spec.SecurityContext != nil && unconfined(pod.Spec.SecurityContext.AppArmorProfile) {
+		return "appArmorProfile (pod must not set securityContext.appArmorProfile.type to \"Unconfined\")"
+	}
+	return podSecurityContainersViolation(pod, "appArmorProfile", "not set securityContext.appArmorProfile.type to \"Unconfined\"", func(c *podSecurityContainer) bool {
+		annotation := pod.Annotations[v1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix+c.name]
+		return (c.securityContext != nil && unconfined(c.securityContext.AppArmorProfile)) ||
+			(annotation != "" && annotation != v1.DeprecatedAppArmorBetaProfileRuntimeDefault && !strings.HasPrefix(annotation, v1.DeprecatedAppArmorBetaProfileNamePrefix))
+	})
+}
+
+func podSecuritySELinux(pod *v1.Pod) string {
+	forbidden := func(options *v1.SELinuxOptions) bool {
+		return options != nil && (!slices.Contains(podSecurityAllowedSELinuxTypes, options.Type) || options.User != "" || options.Role != "")
+	}
+	if pod.Spec.SecurityContext != nil && forbidden(pod.Spec.SecurityContext.SELinuxOptions) {
+		return "seLinuxOptions (pod must not set securityContext.seLinuxOptions user, role or a custom type)"
+	}
+	return podSecurityContainersViolation(pod, "seLinuxOptions", "not set securityContext.seLinuxOptions user, role or a custom type", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && forbidden(c.securityContext.SELinuxOptions)
+	})
+}
+
+func podSecurityProcMount(pod *v1.Pod) string {
+	return podSecurityContainersViolation(pod, "procMount", "not set securityContext.procMount to a value other than \"Default\"", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && c.securityContext.ProcMount != nil && *c.securityContext.ProcMount != v1.DefaultProcMount
+	})
+}
+
+func podSecuritySeccompBaseline(pod *v1.Pod) string {
+	unconfined := func(profile *v1.SeccompProfile) bool {
+		return profile != nil && profile.Type == v1.SeccompProfileTypeUnconfined
+	}
+	if pod.Spec.SecurityContext != nil && unconfined(pod.Spec.SecurityContext.SeccompProfile) {
+		return "seccompProfile (pod must not set securityContext.seccompProfile.type to \"Unconfined\")"
+	}
+	return podSecurityContainersViolation(pod, "seccompProfile", "not set securityContext.seccompProfile.type to \"Unconfined\"", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && unconfined(c.securityContext.SeccompProfile)
+	})
+}
+
+func podSecuritySysctls(pod *v1.Pod) string {
+	if pod.Spec.SecurityContext == nil {
+		return ""
+	}
+	var forbidden []string
+	for _, sysctl := range pod.Spec.SecurityContext.Sysctls {
+		if !slices.Contains(podSecuritySafeSysctls, sysctl.Name) {
+			forbidden = append(forbidden, sysctl.Name)
+		}
+	}
+	if len(forbidden) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("forbidden sysctls (%s)", strings.Join(forbidden, ", "))
+}
+
+func podSecurityVolumeTypes(pod *v1.Pod) string {
+	var restricted []string
+	for _, volume := range pod.Spec.Volumes {
+		if volumeType := podSecurityVolumeType(&volume.VolumeSource); !slices.Contains(podSecurityRestrictedVolumeTypes, volumeType) {
+			restricted = append(restricted, fmt.Sprintf("volume %q uses restricted volume type %q", volume.Name, volumeType))
+		}
+	}
+	if len(restricted) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("restricted volume types (%s)", strings.Join(restricted, ", "))
+}
+
+// podSecurityVolumeType returns the type of the volume source as named in the Pod spec (e.g. hostPath)
+func podSecurityVolumeType(source *v1.VolumeSource) string {
+	switch {
+	case source.ConfigMap != nil:
+		return "configMap"
+	case source.CSI != nil:
+		return "csi"
+	case source.DownwardAPI != nil:
+		return "downwardAPI"
+	case source.EmptyDir != nil:
+		return "emptyDir"
+	case source.Ephemeral != nil:
+		return "ephemeral"
+	case source.PersistentVolumeClaim != nil:
+		return "persistentVolumeClaim"
+	case source.Projected != nil:
+		return "projected"
+	case source.Secret != nil:
+		return "secret"
+	case source.HostPath != nil:
+		return "hostPath"
+	case source.NFS != nil:
+		return "nfs"
+	case source.ISCSI != nil:
+		return "iscsi"
+	case source.Image != nil:
+		return "image"
+	}
+	return "unknown"
+}
+
+func podSecurityAllowPrivilegeEscalation(pod *v1.Pod) string {
+	if podSecurityIsWindows(pod) {
+		return ""
+	}
+	return podSecurityContainersViolation(pod, "allowPrivilegeEscalation != false", "set securityContext.allowPrivilegeEscalation=false", func(c *podSecurityContainer) bool {
+		return c.securityContext == nil || c.securityContext.AllowPrivilegeEscalation == nil || *c.securityContext.AllowPrivilegeEscalation
+	})
+}
+
+func podSecurityRunAsNonRoot(pod *v1.Pod) string {
+	podRunAsNonRoot := pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.RunAsNonRoot != nil
+	if podRunAsNonRoot && !*pod.Spec.SecurityContext.RunAsNonRoot {
+		return "runAsNonRoot != true (pod must not set securityContext.runAsNonRoot=false)"
+	}
+	return podSecurityContainersViolation(pod, "runAsNonRoot != true", "set securityContext.runAsNonRoot=true (or the pod securityContext)", func(c *podSecurityContainer) bool {
+		if c.securityContext != nil && c.securityContext.RunAsNonRoot != nil {
+			return !*c.securityContext.RunAsNonRoot
+		}
+		return !podRunAsNonRoot
+	})
+}
+
+func podSecurityRunAsUser(pod *v1.Pod) string {
+	if pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.RunAsUser != nil && *pod.Spec.SecurityContext.RunAsUser == 0 {
+		return "runAsUser=0 (pod must not set securityContext.runAsUser=0)"
+	}
+	return podSecurityContainersViolation(pod, "runAsUser=0", "not set securityContext.runAsUser=0", func(c *podSecurityContainer) bool {
+		return c.securityContext != nil && c.securityContext.RunAsUser != nil && *c.securityContext.RunAsUser == 0
+	})
+}
+
+func podSecuritySeccompRestricted(pod *v1.Pod) string {
+	if podSecurityIsWindows(pod) {
+		return ""
+	}
+	podSeccomp := pod.Spec.SecurityContext != nil && pod.Spec.SecurityContext.SeccompProfile != nil
+	return podSecurityContainersViolation(pod, "seccompProfile", "set securityContext.seccompProfile.type to \"RuntimeDefault\" or \"Localhost\" (or the pod securityContext)", func(c *podSecurityContainer) bool {
+		return !podSeccomp && (c.securityContext == nil || c.securityContext.SeccompProfile == nil)
+	})
+}
+
+func podSecurityRestrictedCapabilities(pod *v1.Pod) string {
+	if podSecurityIsWindows(pod) {
+		return ""
+	}
+	return podSecurityContainersViolation(pod, "unrestricted capabilities", "set securityContext.capabilities.drop=[\"ALL\"] and only add NET_BIND_SERVICE", func(c *podSecurityContainer) bool {
+		if c.securityContext == nil || c.securityContext.Capabilities == nil || !slices.Contains(c.securityContext.Capabilities.Drop, "ALL") {
+			return true
+		}
+		for _, capability := range c.securityContext.Capabilities.Add {
+			if capability != "NET_BIND_SERVICE" {
+				return true
+			}
+		}
+		return false
+	})
+}
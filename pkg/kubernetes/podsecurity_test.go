@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+type PodSecuritySuite struct {
+	suite.Suite
+}
+
+func restrictedPod() *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+		Spec: v1.PodSpec{
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []v1.Container{{
+				Name: "app",
+				SecurityContext: &v1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}, Add: []v1.Capability{"NET_BIND_SERVICE"}},
+				},
+			}},
+			Volumes: []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}}},
+		},
+	}
+}
+
+func (s *PodSecuritySuite) TestRestrictedPod() {
+	for _, level := range PodSecurityLevels {
+		s.Run(level, func() {
+			s.Empty(PodSecurityViolations(restrictedPod(), level))
+		})
+	}
+}
+
+func (s *PodSecuritySuite) TestDefaultPod() {
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}}}}
+	s.Run("complies with baseline", func() {
+		s.Empty(PodSecurityViolations(pod, "baseline"))
+	})
+	s.Run("violates restricted", func() {
+		s.Equal([]string{
+			`allowPrivilegeEscalation != false (container "app" must set securityContext.allowPrivilegeEscalation=false)`,
+			`runAsNonRoot != true (container "app" must set securityContext.runAsNonRoot=true (or the pod securityContext))`,
+			`seccompProfile (container "app" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost" (or the pod securityContext))`,
+			`unrestricted capabilities (container "app" must set securityContext.capabilities.drop=["ALL"] and only add NET_BIND_SERVICE)`,
+		}, PodSecurityViolations(pod, "restricted"))
+	})
+}
+
+func (s *PodSecuritySuite) TestBaselineViolations() {
+	for _, tc := range []struct {
+		name      string
+		mutate    func(pod *v1.Pod)
+		violation string
+	}{
+		{"privileged", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].SecurityContext.Privileged = ptr.To(true)
+		}, `privileged (container "app" must not set securityContext.privileged=true)`},
+		{"host namespaces", func(pod *v1.Pod) {
+			pod.Spec.HostNetwork = true
+			pod.Spec.HostPID = true
+		}, "host namespaces (hostNetwork=true, hostPID=true)"},
+		{"capabilities", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].SecurityContext.Capabilities.Add = []v1.Capability{"NET_ADMIN", "SYS_ADMIN"}
+		}, `non-default capabilities (container "app" must not include NET_ADMIN, SYS_ADMIN in securityContext.capabilities.add)`},
+		{"hostPath volumes", func(pod *v1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{Name: "host", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/"}}})
+		}, `hostPath volumes (volumes "host")`},
+		{"hostPort", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: 80, HostPort: 80}}
+		}, `hostPort (container "app" must not set hostPort)`},
+		{"seccomp unconfined", func(pod *v1.Pod) {
+			pod.Spec.SecurityContext.SeccompProfile.Type = v1.SeccompProfileTypeUnconfined
+		}, `seccompProfile (pod must not set securityContext.seccompProfile.type to "Unconfined")`},
+		{"sysctls", func(pod *v1.Pod) {
+			pod.Spec.SecurityContext.Sysctls = []v1.Sysctl{{Name: "net.ipv4.tcp_syncookies"}, {Name: "kernel.msgmax"}}
+		}, "forbidden sysctls (kernel.msgmax)"},
+		{"procMount", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].SecurityContext.ProcMount = ptr.To(v1.UnmaskedProcMount)
+		}, `procMount (container "app" must not set securityContext.procMount to a value other than "Default")`},
+	} {
+		s.Run(tc.name, func() {
+			pod := restrictedPod()
+			tc.mutate(pod)
+			s.Contains(PodSecurityViolations(pod, "baseline"), tc.violation)
+			s.Contains(PodSecurityViolations(pod, "restricted"), tc.violation)
+			s.Empty(PodSecurityViolations(pod, "privileged"))
+		})
+	}
+}
+
+func (s *PodSecuritySuite) TestRestrictedViolations() {
+	for _, tc := range []struct {
+		name      string
+		mutate    func(pod *v1.Pod)
+		violation string
+	}{
+		{"runAsUser=0", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].SecurityContext.RunAsUser = ptr.To(int64(0))
+		}, `runAsUser=0 (container "app" must not set securityContext.runAsUser=0)`},
+		{"runAsNonRoot=false", func(pod *v1.Pod) {
+			pod.Spec.SecurityContext.RunAsNonRoot = ptr.To(false)
+		}, "runAsNonRoot != true (pod must not set securityContext.runAsNonRoot=false)"},
+		{"allowPrivilegeEscalation", func(pod *v1.Pod) {
+			pod.Spec.InitContainers = []v1.Container{{Name: "init"}}
+		}, `allowPrivilegeEscalation != false (container "init" must set securityContext.allowPrivilegeEscalation=false)`},
+		{"volume types", func(pod *v1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{Name: "nfs", VolumeSource: v1.VolumeSource{NFS: &v1.NFSVolumeSource{}}})
+		}, `restricted volume types (volume "nfs" uses restricted volume type "nfs")`},
+		{"capabilities", func(pod *v1.Pod) {
+			pod.Spec.Containers[0].SecurityContext.Capabilities.Add = []v1.Capability{"CHOWN"}
+		}, `unrestricted capabilities (container "app" must set securityContext.capabilities.drop=["ALL"] and only add NET_BIND_SERVICE)`},
+	} {
+		s.Run(tc.name, func() {
+			pod := restrictedPod()
+			tc.mutate(pod)
+			s.Contains(PodSecurityViolations(pod, "restricted"), tc.violation)
+			s.Empty(PodSecurityViolations(pod, "baseline"))
+		})
+	}
+}
+
+func TestPodSecurity(t *testing.T) {
+	suite.Run(t, new(PodSecuritySuite))
+}
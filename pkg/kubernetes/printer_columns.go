@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metatable "k8s.io/apimachinery/pkg/api/meta/table"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var customResourceDefinitionsGVR = apiextensionsv1.SchemeGroupVersion.WithResource("customresourcedefinitions")
+
+// defaultPrinterColumns are the columns used by the API server for custom resources
+// whose definition doesn't declare any additionalPrinterColumns.
+var defaultPrinterColumns = []apiextensionsv1.CustomResourceColumnDefinition{
+	{Name: "Age", Type: "date", JSONPath: ".metadata.creationTimestamp"},
+}
+
+// customResourceTable formats a list of custom resources as a Table (client-side) using the additionalPrinterColumns
+// declared in the CustomResourceDefinition for the listed version, mimicking the API server table rendering.
+// Returns nil if the resource isn't backed by an accessible CustomResourceDefinition.
+func (c *Core) customResourceTable(ctx context.Context, gvr *schema.GroupVersionResource, list *unstructured.UnstructuredList) *metav1.Table {
+	if gvr.Group == "" {
+		return nil
+	}
+	u, err := c.DynamicClient().Resource(customResourceDefinitionsGVR).Get(ctx, gvr.GroupResource().String(), metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil
+	}
+	columns := defaultPrinterColumns
+	for _, v := range crd.Spec.Versions {
+		if v.Name == gvr.Version && len(v.AdditionalPrinterColumns) > 0 {
+			columns = v.AdditionalPrinterColumns
+		}
+	}
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string", Format: "name"}},
+	}
+	paths := make([]*jsonpath.JSONPath, 0, len(columns))
+	for _, col := range columns {
+		path := jsonpath.New(col.Name).AllowMissingKeys(true)
+		if err = path.Parse(fmt.Sprintf("{%s}", col.JSONPath)); err != nil {
+			return nil
+		}
+		paths = append(paths, path)
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{
+			Name: col.Name, Type: col.Type, Format: col.Format, Description: col.Description, Priority: col.Priority,
+		})
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		cells := []interface{}{item.GetName()}
+		for ci, path := range paths {
+			cells = append(cells, printerColumnCell(path, columns[ci].Type, item.Object))
+		}
+		raw, _ := json.Marshal(item.Object)
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells, Object: runtime.RawExtension{Raw: raw}})
+	}
+	return table
+}
+
+// printerColumnCell evaluates the JSONPath of a printer column and converts the result to the column type.
+func printerColumnCell(path *jsonpath.JSONPath, columnType string, obj map[string]interface{}) interface{} {
+	results, err := path.FindResults(obj)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return nil
+	}
+	value := results[0][0].Interface()
+	switch columnType {
+	case "date":
+		if s, ok := value.(string); ok {
+			var timestamp metav1.Time
+			if err = timestamp.UnmarshalQueryParameter(s); err != nil {
+				return "<invalid>"
+			}
+			return metatable.ConvertToHumanReadableDateType(timestamp)
+		}
+	case "integer":
+		switch v := value.(type) {
+		case int64:
+			return v
+		case float64:
+			return int64(v)
+		}
+	case "number":
+		switch v := value.(type) {
+		case int64:
+			return float64(v)
+		case float64:
+			return v
+		}
+	case "boolean":
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	return nil
+}
@@ -29,6 +29,9 @@ type Provider interface {
 	GetDerivedKubernetes(ctx context.Context, target string) (*Kubernetes, error)
 	GetDefaultTarget() string
 	GetTargetParameterName() string
+	// CheckReadiness verifies the Kubernetes API of the provided target (or the default target if empty)
+	// is reachable using the server's own credentials, regardless of any user-provided credentials.
+	CheckReadiness(ctx context.Context, target string) error
 	// WatchTargets sets up a watcher for changes in the cluster targets and calls the provided McpReload function when changes are detected
 	WatchTargets(ctx context.Context, reload McpReload)
 	Close()
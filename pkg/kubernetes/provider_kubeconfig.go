@@ -26,6 +26,8 @@ type kubeConfigClusterProvider struct {
 	managers            map[string]*Manager
 	kubeconfigWatcher   *watcher.Kubeconfig
 	clusterStateWatcher *watcher.ClusterState
+	// watches keeps the watch limiter of each context across the rebuilds of the managers
+	watches WatchLimiters
 }
 
 var _ Provider = &kubeConfigClusterProvider{}
@@ -81,6 +83,8 @@ func (p *kubeConfigClusterProvider) reset(ctx context.Context) error {
 		}
 	}
 
+	p.watches.Apply(defaultContext, m)
+
 	for _, old := range p.managers {
 		if old != nil {
 			old.Close()
@@ -126,6 +130,7 @@ func (p *kubeConfigClusterProvider) managerForContext(ctx context.Context, kubeC
 	if err != nil {
 		return nil, err
 	}
+	p.watches.Apply(kubeContext, m)
 
 	p.managers[kubeContext] = m
 
@@ -171,6 +176,17 @@ func (p *kubeConfigClusterProvider) GetDerivedKubernetes(ctx context.Context, co
 	return m.Derived(ctx)
 }
 
+func (p *kubeConfigClusterProvider) CheckReadiness(ctx context.Context, context string) error {
+	if context == "" {
+		context = p.GetDefaultTarget()
+	}
+	m, err := p.managerForContext(ctx, context)
+	if err != nil {
+		return err
+	}
+	return m.CheckReadiness(ctx)
+}
+
 func (p *kubeConfigClusterProvider) GetDefaultTarget() string {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -197,6 +213,14 @@ func (p *kubeConfigClusterProvider) Close() {
 	}
 }
 
-func (p *kubeConfigClusterProvider) HasGVKs(_ context.Context, _ []schema.GroupVersionKind) bool {
-	return true
+// HasGVKs verifies the GVKs against the default context cluster when the kubeconfig has a single context.
+// Other contexts can't be probed cheaply (their managers are lazily initialized) so all GVKs are assumed to be
+// available when there are multiple contexts.
+func (p *kubeConfigClusterProvider) HasGVKs(_ context.Context, gvks []schema.GroupVersionKind) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.managers) > 1 || p.managers[p.defaultContext] == nil {
+		return true
+	}
+	return p.managers[p.defaultContext].HasGVKs(gvks)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/containers/kubernetes-mcp-server/internal/test"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
@@ -115,6 +116,52 @@ func (s *ProviderKubeconfigTestSuite) TestEmptyCurrentContext() {
 	})
 }
 
+func (s *ProviderKubeconfigTestSuite) TestWatchesSurviveReset() {
+	provider, err := NewProvider(s.T().Context(), &config.StaticConfig{
+		KubeConfig:           s.mockServer.KubeconfigFile(s.T()),
+		MaxConcurrentWatches: 1,
+	})
+	s.Require().NoError(err)
+	k, err := provider.GetDerivedKubernetes(s.T().Context(), "fake-context")
+	s.Require().NoError(err)
+	release, err := k.AcquireWatch()
+	s.Require().NoError(err)
+	s.Require().NoError(provider.(*kubeConfigClusterProvider).reset(s.T().Context()))
+	k, err = provider.GetDerivedKubernetes(s.T().Context(), "fake-context")
+	s.Require().NoError(err)
+	s.Run("the rebuilt manager accounts for the watches of the previous one", func() {
+		_, err := k.AcquireWatch()
+		s.ErrorIs(err, ErrorTooManyWatches)
+	})
+	s.Run("the slots released by the watches of the previous manager are available", func() {
+		release()
+		release, err := k.AcquireWatch()
+		s.Require().NoError(err)
+		release()
+	})
+}
+
+func (s *ProviderKubeconfigTestSuite) TestHasGVKs() {
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	missing := []schema.GroupVersionKind{{Group: "nonexistent.example.com", Version: "v1", Kind: "FakeResource"}}
+	s.Run("with multiple contexts returns true for GVKs missing in the default context", func() {
+		s.True(s.provider.HasGVKs(s.T().Context(), missing))
+	})
+	s.Run("with single context", func() {
+		provider, err := NewProvider(s.T().Context(), &config.StaticConfig{KubeConfig: s.mockServer.KubeconfigFile(s.T())})
+		s.Require().NoError(err, "Expected no error creating provider with single context")
+		s.Run("returns true when all GVKs exist on the cluster", func() {
+			s.True(provider.HasGVKs(s.T().Context(), []schema.GroupVersionKind{
+				{Group: "", Version: "v1", Kind: "Pod"},
+				{Group: "apps", Version: "v1", Kind: "Deployment"},
+			}))
+		})
+		s.Run("returns false when a GVK does not exist on the cluster", func() {
+			s.False(provider.HasGVKs(s.T().Context(), missing))
+		})
+	})
+}
+
 func (s *ProviderKubeconfigTestSuite) TestGetTargetParameterName() {
 	s.Equal("context", s.provider.GetTargetParameterName(), "Expected context as target parameter name")
 }
@@ -22,6 +22,8 @@ type singleClusterProvider struct {
 	manager             *Manager
 	kubeconfigWatcher   *watcher.Kubeconfig
 	clusterStateWatcher *watcher.ClusterState
+	// watches keeps the watch limiter across the rebuilds of the manager
+	watches WatchLimiters
 }
 
 var _ Provider = &singleClusterProvider{}
@@ -69,6 +71,7 @@ func (p *singleClusterProvider) reset(ctx context.Context) error {
 		}
 		return err
 	}
+	p.watches.Apply("", p.manager)
 
 	p.Close()
 	p.kubeconfigWatcher = watcher.NewKubeconfig(ctx, p.manager.kubernetes.clientCmdConfig)
@@ -96,6 +99,14 @@ func (p *singleClusterProvider) GetDerivedKubernetes(ctx context.Context, target
 	return p.manager.Derived(ctx)
 }
 
+func (p *singleClusterProvider) CheckReadiness(ctx context.Context, target string) error {
+	if target != "" {
+		return fmt.Errorf("unable to get manager for other context/cluster with %s strategy", p.strategy)
+	}
+
+	return p.manager.CheckReadiness(ctx)
+}
+
 func (p *singleClusterProvider) GetDefaultTarget() string {
 	return ""
 }
@@ -133,11 +144,5 @@ func (p *singleClusterProvider) HasGVKs(ctx context.Context, gvks []schema.Group
 		klogutil.LogWarn(klog.FromContext(ctx), "HasGVKs called with nil manager, assuming all GVKs are available")
 		return true
 	}
-	mapper := p.manager.kubernetes.RESTMapper()
-	for _, gvk := range gvks {
-		if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
-			return false
-		}
-	}
-	return true
+	return p.manager.HasGVKs(gvks)
 }
@@ -90,6 +90,28 @@ func (s *ProviderSingleTestSuite) TestGetTargetParameterName() {
 	s.Empty(s.provider.GetTargetParameterName(), "Expected empty string as target parameter name")
 }
 
+func (s *ProviderSingleTestSuite) TestWatchesSurviveReset() {
+	provider, err := NewProvider(s.T().Context(), &config.StaticConfig{MaxConcurrentWatches: 1})
+	s.Require().NoError(err)
+	k, err := provider.GetDerivedKubernetes(s.T().Context(), "")
+	s.Require().NoError(err)
+	release, err := k.AcquireWatch()
+	s.Require().NoError(err)
+	s.Require().NoError(provider.(*singleClusterProvider).reset(s.T().Context()))
+	k, err = provider.GetDerivedKubernetes(s.T().Context(), "")
+	s.Require().NoError(err)
+	s.Run("the rebuilt manager accounts for the watches of the previous one", func() {
+		_, err := k.AcquireWatch()
+		s.ErrorIs(err, ErrorTooManyWatches)
+	})
+	s.Run("the slots released by the watches of the previous manager are available", func() {
+		release()
+		release, err := k.AcquireWatch()
+		s.Require().NoError(err)
+		release()
+	})
+}
+
 func (s *ProviderSingleTestSuite) TestHasGVKs() {
 	handler := test.NewDiscoveryClientHandler()
 	s.mockServer.Handle(handler)
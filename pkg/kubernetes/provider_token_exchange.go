@@ -180,6 +180,10 @@ func (p *tokenExchangingProvider) GetTargets(ctx context.Context) ([]string, err
 	return p.provider.GetTargets(ctx)
 }
 
+func (p *tokenExchangingProvider) CheckReadiness(ctx context.Context, target string) error {
+	return p.provider.CheckReadiness(ctx, target)
+}
+
 func (p *tokenExchangingProvider) GetDefaultTarget() string {
 	return p.provider.GetDefaultTarget()
 }
@@ -63,6 +63,7 @@ func (fakeDerivedProvider) Close()                                       {}
 func (fakeDerivedProvider) GetDerivedKubernetes(context.Context, string) (*Kubernetes, error) {
 	return &Kubernetes{}, nil
 }
+func (fakeDerivedProvider) CheckReadiness(context.Context, string) error { return nil }
 func (fakeDerivedProvider) HasGVKs(context.Context, []schema.GroupVersionKind) bool {
 	return true
 }
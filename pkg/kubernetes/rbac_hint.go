@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// rbacHintSubjectPlaceholder is the subject of the RBAC hints when the user of the request is unknown
+const rbacHintSubjectPlaceholder = "<user or service account used by the server>"
+
+// forbiddenMessagePattern matches the message of the Forbidden errors of the Kubernetes RBAC authorizer, e.g.
+// pods "x" is forbidden: User "u" cannot get resource "pods/log" in API group "" in the namespace "ns"
+var forbiddenMessagePattern = regexp.MustCompile(
+	`User "([^"]*)" cannot (\S+) resource "([^"]+)" in API group "([^"]*)"(?: in the namespace "([^"]+)"| at the cluster scope)`)
+
+// forbiddenPermission is the permission missing for a request to be authorized
+type forbiddenPermission struct {
+	User      string
+	Verb      string
+	APIGroup  string
+	Resource  string // including the subresource (e.g. pods/log)
+	Namespace string // empty for cluster-scoped permissions
+}
+
+// parseForbiddenMessage completes the permission with the details reported by the Forbidden error message (if any)
+func (p *forbiddenPermission) parseForbiddenMessage(message string) {
+	match := forbiddenMessagePattern.FindStringSubmatch(message)
+	if match == nil {
+		return
+	}
+	p.User, p.Verb, p.Resource, p.APIGroup, p.Namespace = match[1], match[2], match[3], match[4], match[5]
+}
+
+// rbacHint returns a ready-to-apply Role and RoleBinding (ClusterRole and ClusterRoleBinding for cluster-scoped
+// permissions) that grant the permission
+func (p *forbiddenPermission) rbacHint() string {
+	name := "mcp-" + p.Verb + "-" + strings.ReplaceAll(p.Resource, "/", "-")
+	if p.APIGroup != "" {
+		name += "." + p.APIGroup
+	}
+	rule := rbacv1.PolicyRule{APIGroups: []string{p.APIGroup}, Resources: []string{p.Resource}, Verbs: []string{p.Verb}}
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: p.Namespace}
+	typeMeta := func(kind string) metav1.TypeMeta {
+		return metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: kind}
+	}
+	var role, binding any
+	if p.Namespace == "" {
+		role = &rbacv1.ClusterRole{TypeMeta: typeMeta("ClusterRole"), ObjectMeta: objectMeta, Rules: []rbacv1.PolicyRule{rule}}
+		binding = &rbacv1.ClusterRoleBinding{
+			TypeMeta: typeMeta("ClusterRoleBinding"), ObjectMeta: objectMeta, Subjects: []rbacv1.Subject{p.subject()},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+		}
+	} else {
+		role = &rbacv1.Role{TypeMeta: typeMeta("Role"), ObjectMeta: objectMeta, Rules: []rbacv1.PolicyRule{rule}}
+		binding = &rbacv1.RoleBinding{
+			TypeMeta: typeMeta("RoleBinding"), ObjectMeta: objectMeta, Subjects: []rbacv1.Subject{p.subject()},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		}
+	}
+	hint := strings.Builder{}
+	hint.WriteString("The following RBAC resources grant the missing permission")
+	if p.User == "" {
+		hint.WriteString(" (replace the subject with the user or service account used by the server)")
+	}
+	hint.WriteString(":\n")
+	for i, obj := range []any{role, binding} {
+		if i > 0 {
+			hint.WriteString("---\n")
+		}
+		marshalled, err := yaml.Marshal(obj)
+		if err != nil {
+			return ""
+		}
+		hint.Write(marshalled)
+	}
+	return hint.String()
+}
+
+// subject returns the RBAC subject of the user (a ServiceAccount for service account users)
+func (p *forbiddenPermission) subject() rbacv1.Subject {
+	if p.User == "" {
+		return rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: rbacHintSubjectPlaceholder}
+	}
+	if serviceAccount, ok := strings.CutPrefix(p.User, "system:serviceaccount:"); ok {
+		if namespace, name, found := strings.Cut(serviceAccount, ":"); found {
+			return rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}
+		}
+	}
+	return rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: p.User}
+}
+
+// withRBACHint appends the RBAC hint for the missing permission to the message of the Forbidden response.
+// The response is left untouched if its body isn't a Status, otherwise it's replaced by the Status in JSON format
+// (clients negotiating protobuf accept JSON too).
+func withRBACHint(resp *http.Response, permission *forbiddenPermission) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), body)
+	if err != nil {
+		return
+	}
+	status, ok := obj.(*metav1.Status)
+	if !ok {
+		return
+	}
+	permission.parseForbiddenMessage(status.Message)
+	hint := permission.rbacHint()
+	if hint == "" {
+		return
+	}
+	status.Message += "\n\n" + hint
+	status.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	enriched, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(enriched))
+	resp.ContentLength = int64(len(enriched))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(enriched)))
+	resp.Header.Set("Content-Type", runtime.ContentTypeJSON)
+}
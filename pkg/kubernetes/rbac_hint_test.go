@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+type RBACHintSuite struct {
+	suite.Suite
+}
+
+func (s *RBACHintSuite) TestParseForbiddenMessage() {
+	s.Run("namespaced permission", func() {
+		permission := &forbiddenPermission{Verb: "list", Resource: "pods"}
+		permission.parseForbiddenMessage(`deployments.apps "web" is forbidden: User "alice" cannot patch resource "deployments/scale" in API group "apps" in the namespace "team-a"`)
+		s.Equal(&forbiddenPermission{User: "alice", Verb: "patch", APIGroup: "apps", Resource: "deployments/scale", Namespace: "team-a"}, permission)
+	})
+	s.Run("cluster-scoped permission", func() {
+		permission := &forbiddenPermission{}
+		permission.parseForbiddenMessage(`nodes is forbidden: User "alice" cannot list resource "nodes" in API group "" at the cluster scope`)
+		s.Equal(&forbiddenPermission{User: "alice", Verb: "list", Resource: "nodes"}, permission)
+	})
+	s.Run("unknown message keeps the permission of the request", func() {
+		permission := &forbiddenPermission{Verb: "get", Resource: "pods", Namespace: "default"}
+		permission.parseForbiddenMessage("admission webhook denied the request")
+		s.Equal(&forbiddenPermission{Verb: "get", Resource: "pods", Namespace: "default"}, permission)
+	})
+}
+
+func (s *RBACHintSuite) TestSubject() {
+	s.Run("service account user", func() {
+		s.Equal(rbacv1.Subject{Kind: "ServiceAccount", Name: "server", Namespace: "mcp"},
+			(&forbiddenPermission{User: "system:serviceaccount:mcp:server"}).subject())
+	})
+	s.Run("regular user", func() {
+		s.Equal(rbacv1.Subject{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: "alice"},
+			(&forbiddenPermission{User: "alice"}).subject())
+	})
+	s.Run("unknown user", func() {
+		s.Equal(rbacv1.Subject{APIGroup: "rbac.authorization.k8s.io", Kind: "User", Name: rbacHintSubjectPlaceholder},
+			(&forbiddenPermission{}).subject())
+	})
+}
+
+func (s *RBACHintSuite) TestRBACHint() {
+	s.Run("namespaced permission returns Role and RoleBinding", func() {
+		hint := (&forbiddenPermission{User: "alice", Verb: "patch", APIGroup: "apps", Resource: "deployments/scale", Namespace: "team-a"}).rbacHint()
+		s.Equal(`The following RBAC resources grant the missing permission:
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: mcp-patch-deployments-scale.apps
+  namespace: team-a
+rules:
+- apiGroups:
+  - apps
+  resources:
+  - deployments/scale
+  verbs:
+  - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: mcp-patch-deployments-scale.apps
+  namespace: team-a
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: mcp-patch-deployments-scale.apps
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: alice
+`, hint)
+	})
+	s.Run("cluster-scoped permission returns ClusterRole and ClusterRoleBinding", func() {
+		hint := (&forbiddenPermission{Verb: "list", Resource: "nodes"}).rbacHint()
+		s.Contains(hint, "(replace the subject with the user or service account used by the server)")
+		s.Contains(hint, "kind: ClusterRole\nmetadata:\n  name: mcp-list-nodes\nrules:")
+		s.Contains(hint, "kind: ClusterRoleBinding\nmetadata:\n  name: mcp-list-nodes\nroleRef:")
+		s.NotContains(hint, "namespace:")
+	})
+}
+
+func TestRBACHint(t *testing.T) {
+	suite.Run(t, new(RBACHintSuite))
+}
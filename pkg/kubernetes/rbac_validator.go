@@ -42,11 +42,16 @@ func (v *RBACValidator) Validate(ctx context.Context, req *api.HTTPValidationReq
 	}
 
 	if !allowed {
-		return api.NewPermissionDeniedError(
+		permissionDenied := api.NewPermissionDeniedError(
 			req.Verb,
 			api.FormatResourceName(req.GVR),
 			req.Namespace,
 		)
+		permission := &forbiddenPermission{Verb: req.Verb, APIGroup: req.GVR.Group, Resource: req.GVR.Resource, Namespace: req.Namespace}
+		if hint := permission.rbacHint(); hint != "" {
+			permissionDenied.Message += "\n\n" + hint
+		}
+		return permissionDenied
 	}
 
 	return nil
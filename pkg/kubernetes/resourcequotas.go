@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// resourceQuotaStandardNames are the resource names tracked by the quota system besides the object counts
+// (count/<resource>.<group>), the per StorageClass limits and the extended resources
+var resourceQuotaStandardNames = []string{
+	"cpu", "memory", "ephemeral-storage", "storage",
+	"requests.cpu", "requests.memory", "requests.ephemeral-storage", "requests.storage",
+	"limits.cpu", "limits.memory", "limits.ephemeral-storage",
+	"pods", "services", "services.nodeports", "services.loadbalancers", "replicationcontrollers", "resourcequotas",
+	"secrets", "configmaps", "persistentvolumeclaims",
+}
+
+// ResourceQuotaSetResult is the outcome of ResourceQuotaSet
+type ResourceQuotaSetResult struct {
+	// Created is true if the ResourceQuota didn't exist and has been created
+	Created bool
+	// ResourceQuota is the created or updated ResourceQuota
+	ResourceQuota *v1.ResourceQuota
+}
+
+// ResourceQuotaSet creates the ResourceQuota with the provided hard limits in the provided namespace, or sets them in
+// the existing ResourceQuota with a merge patch (the other hard limits are left untouched).
+// The resource names and quantities are validated before anything is changed in the cluster.
+func (c *Core) ResourceQuotaSet(ctx context.Context, namespace, name string, hard map[string]string) (*ResourceQuotaSetResult, error) {
+	if len(hard) == 0 {
+		return nil, errors.New("at least one hard limit is required")
+	}
+	limits, err := resourceQuotaHard(hard)
+	if err != nil {
+		return nil, err
+	}
+	namespace = c.NamespaceOrDefault(namespace)
+	quotas := c.CoreV1().ResourceQuotas(namespace)
+	_, err = quotas.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		created, createErr := quotas.Create(ctx, &v1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       v1.ResourceQuotaSpec{Hard: limits},
+		}, metav1.CreateOptions{FieldManager: version.BinaryName})
+		if createErr != nil {
+			return nil, createErr
+		}
+		return &ResourceQuotaSetResult{Created: true, ResourceQuota: created}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"hard": limits}})
+	if err != nil {
+		return nil, err
+	}
+	updated, err := quotas.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: version.BinaryName})
+	if err != nil {
+		return nil, resourceModifiedError(err)
+	}
+	return &ResourceQuotaSetResult{ResourceQuota: updated}, nil
+}
+
+// ResourceQuotaUsage returns the hard limit and current usage of every resource of the ResourceQuota, the usage is
+// <pending> until the quota controller computes it
+func ResourceQuotaUsage(quota *v1.ResourceQuota) []map[string]string {
+	names := make([]string, 0, len(quota.Spec.Hard))
+	for name := range quota.Spec.Hard {
+		names = append(names, string(name))
+	}
+	slices.Sort(names)
+	ret := make([]map[string]string, 0, len(names))
+	for _, name := range names {
+		hard := quota.Spec.Hard[v1.ResourceName(name)]
+		used := "<pending>"
+		if quantity, ok := quota.Status.Used[v1.ResourceName(name)]; ok {
+			used = quantity.String()
+		}
+		ret = append(ret, map[string]string{"Resource": name, "Hard": hard.String(), "Used": used})
+	}
+	return ret
+}
+
+// resourceQuotaHard parses the hard limits, returning an error listing every invalid resource name and quantity
+func resourceQuotaHard(hard map[string]string) (v1.ResourceList, error) {
+	limits := make(v1.ResourceList, len(hard))
+	var invalid []string
+	for name, value := range hard {
+		if reason := resourceQuotaNameInvalid(name); reason != "" {
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", name, reason))
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s=%s (invalid quantity, e.g. 10, 500m, 2Gi)", name, value))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			invalid = append(invalid, fmt.Sprintf("%s=%s (must not be negative)", name, value))
+			continue
+		}
+		limits[v1.ResourceName(name)] = quantity
+	}
+	if len(invalid) > 0 {
+		slices.Sort(invalid)
+		return nil, fmt.Errorf("invalid hard limits: %s", strings.Join(invalid, ", "))
+	}
+	return limits, nil
+}
+
+// resourceQuotaNameInvalid returns the reason why the name isn't a resource tracked by the quota system, empty if it is
+func resourceQuotaNameInvalid(name string) string {
+	switch {
+	case slices.Contains(resourceQuotaStandardNames, name):
+		return ""
+	case strings.HasPrefix(name, "count/"):
+		// object count quota, e.g. count/deployments.apps or count/pods
+		if counted := strings.TrimPrefix(name, "count/"); counted != "" && len(validation.IsQualifiedName(counted)) == 0 {
+			return ""
+		}
+		return "object count quotas must have the form count/<resource>.<group>"
+	case strings.Contains(name, ".storageclass.storage.k8s.io/"):
+		// per StorageClass quota, e.g. gold.storageclass.storage.k8s.io/requests.storage
+		class, limit, _ := strings.Cut(name, ".storageclass.storage.k8s.io/")
+		if class != "" && (limit == "requests.storage" || limit == "persistentvolumeclaims") {
+			return ""
+		}
+		return "StorageClass quotas must have the form <class>.storageclass.storage.k8s.io/requests.storage or persistentvolumeclaims"
+	case strings.HasPrefix(name, "hugepages-") || strings.HasPrefix(name, "requests.hugepages-") || strings.HasPrefix(name, "limits.hugepages-"):
+		return ""
+	case strings.HasPrefix(name, "requests.") && strings.Contains(name, "/"):
+		// extended resource, e.g. requests.nvidia.com/gpu
+		if len(validation.IsQualifiedName(strings.TrimPrefix(name, "requests."))) == 0 {
+			return ""
+		}
+	}
+	return "not a resource tracked by ResourceQuotas, e.g. pods, requests.cpu, limits.memory, count/deployments.apps"
+}
@@ -0,0 +1,46 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceQuotasSuite struct {
+	suite.Suite
+}
+
+func (s *ResourceQuotasSuite) TestResourceQuotaHard() {
+	s.Run("accepts the resources tracked by the quota system", func() {
+		limits, err := resourceQuotaHard(map[string]string{
+			"pods":                   "10",
+			"requests.cpu":           "500m",
+			"limits.memory":          "2Gi",
+			"count/deployments.apps": "5",
+			"gold.storageclass.storage.k8s.io/requests.storage": "100Gi",
+			"requests.nvidia.com/gpu":                           "2",
+			"requests.hugepages-2Mi":                            "1Gi",
+		})
+		s.Require().NoError(err)
+		s.Len(limits, 7)
+		cpu := limits["requests.cpu"]
+		s.Equal("500m", cpu.String())
+	})
+	s.Run("rejects unknown resource names", func() {
+		_, err := resourceQuotaHard(map[string]string{"cpus": "1", "count/": "1", "requests.gpu": "1"})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "cpus (not a resource tracked by ResourceQuotas")
+		s.Contains(err.Error(), "count/ (object count quotas must have the form count/<resource>.<group>)")
+		s.Contains(err.Error(), "requests.gpu (not a resource tracked by ResourceQuotas")
+	})
+	s.Run("rejects invalid and negative quantities", func() {
+		_, err := resourceQuotaHard(map[string]string{"pods": "ten", "requests.memory": "-1Gi"})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "pods=ten (invalid quantity")
+		s.Contains(err.Error(), "requests.memory=-1Gi (must not be negative)")
+	})
+}
+
+func TestResourceQuotas(t *testing.T) {
+	suite.Run(t, new(ResourceQuotasSuite))
+}
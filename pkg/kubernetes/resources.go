@@ -4,17 +4,22 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/version"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -25,6 +30,9 @@ const (
 	AppKubernetesPartOf    = "app.kubernetes.io/part-of"
 )
 
+// manifestSeparator splits a multi-document YAML manifest
+var manifestSeparator = regexp.MustCompile(`\r?\n---\r?\n`)
+
 func (c *Core) ResourcesList(ctx context.Context, gvk *schema.GroupVersionKind, namespace string, options api.ListOptions) (runtime.Unstructured, error) {
 	gvr, err := c.resourceFor(gvk)
 	if err != nil {
@@ -55,22 +63,189 @@ func (c *Core) ResourcesGet(ctx context.Context, gvk *schema.GroupVersionKind, n
 	return c.DynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
+// ResourcesGetAsTable returns the resource in a table format (as printed by kubectl get).
+// The resource is retrieved first so that a missing resource is reported as an error instead of an empty table.
+func (c *Core) ResourcesGetAsTable(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (runtime.Unstructured, error) {
+	obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	options := api.ListOptions{AsTable: true}
+	options.FieldSelector = "metadata.name=" + obj.GetName()
+	return c.ResourcesList(ctx, gvk, obj.GetNamespace(), options)
+}
+
 func (c *Core) ResourcesCreateOrUpdate(ctx context.Context, resource string) ([]*unstructured.Unstructured, error) {
-	separator := regexp.MustCompile(`\r?\n---\r?\n`)
-	resources := separator.Split(resource, -1)
+	result, err := c.ResourcesCreateOrUpdateWithOptions(ctx, resource, ResourcesCreateOrUpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
+// ResourcesCreateOrUpdateOptions are the optional settings applied to every resource by ResourcesCreateOrUpdateWithOptions
+type ResourcesCreateOrUpdateOptions struct {
+	// Namespace for namespaced resources whose manifest omits metadata.namespace (the default namespace is used if empty)
+	Namespace string
+	// Owner to add to metadata.ownerReferences so that the resources are garbage collected when the owner is deleted.
+	// The owner must exist and, if namespaced, live in the same namespace as the owned resources.
+	// The owner UID is resolved from the cluster when not provided.
+	Owner *metav1.OwnerReference
+	// EnsureNamespace creates the target namespaces of the namespaced resources that don't exist yet before applying them
+	EnsureNamespace bool
+	// ShowDiff fetches the resources before applying them to report the changes in ResourcesCreateOrUpdateResult.Diff
+	ShowDiff bool
+	// FieldManager is the Server-Side Apply field manager that owns the applied fields (the server binary name if empty)
+	FieldManager string
+}
+
+// ResourcesCreateOrUpdateResult is the outcome of ResourcesCreateOrUpdateWithOptions
+type ResourcesCreateOrUpdateResult struct {
+	// Resources are the created or updated resources
+	Resources []*unstructured.Unstructured
+	// InjectedNamespace is the namespace set on the namespaced resources that didn't specify one (empty if none)
+	InjectedNamespace string
+	// CreatedNamespaces are the namespaces created because of ResourcesCreateOrUpdateOptions.EnsureNamespace
+	CreatedNamespaces []string
+	// Diff is the unified diff between the previous and the applied state of every resource, newly created and
+	// unchanged resources are reported with a comment line (empty unless ResourcesCreateOrUpdateOptions.ShowDiff)
+	Diff string
+}
+
+// ResourcesCreateOrUpdateWithOptions creates or updates the provided resources applying the provided options.
+func (c *Core) ResourcesCreateOrUpdateWithOptions(ctx context.Context, resource string, opts ResourcesCreateOrUpdateOptions) (*ResourcesCreateOrUpdateResult, error) {
+	parsedResources, injectedNamespace, err := c.parseResources(resource, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Owner != nil {
+		for _, obj := range parsedResources {
+			if err = c.setOwnerReference(ctx, obj, opts.Owner); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result := &ResourcesCreateOrUpdateResult{InjectedNamespace: injectedNamespace}
+	if opts.EnsureNamespace {
+		if result.CreatedNamespaces, err = c.ensureNamespaces(ctx, parsedResources); err != nil {
+			return nil, err
+		}
+	}
+	var previous []*unstructured.Unstructured
+	if opts.ShowDiff {
+		if previous, err = c.resourcesGetPrevious(ctx, parsedResources); err != nil {
+			return nil, err
+		}
+	}
+	fieldManager := opts.FieldManager
+	if fieldManager == "" {
+		fieldManager = version.BinaryName
+	}
+	if result.Resources, err = c.resourcesCreateOrUpdate(ctx, parsedResources, fieldManager); err != nil {
+		return result, err
+	}
+	if opts.ShowDiff {
+		result.Diff, err = resourcesAppliedDiff(previous, result.Resources)
+	}
+	return result, err
+}
+
+// ensureNamespaces creates the namespaces of the provided resources that don't exist yet.
+// Namespaces that are part of the provided resources are left for the apply to create.
+// The namespaces are created right away (an existing namespace is reported as AlreadyExists), so that only the
+// permission to create namespaces is required.
+// Returns the names of the created namespaces.
+func (c *Core) ensureNamespaces(ctx context.Context, resources []*unstructured.Unstructured) ([]string, error) {
+	var created []string
+	ensured := map[string]bool{}
+	for _, obj := range resources {
+		namespace := obj.GetNamespace()
+		if namespace == "" || ensured[namespace] || slices.ContainsFunc(resources, func(r *unstructured.Unstructured) bool {
+			return r.GetAPIVersion() == "v1" && r.GetKind() == "Namespace" && r.GetName() == namespace
+		}) {
+			continue
+		}
+		ensured[namespace] = true
+		ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		_, err := c.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{FieldManager: version.BinaryName})
+		switch {
+		case err == nil:
+			created = append(created, namespace)
+		case !apierrors.IsAlreadyExists(err):
+			return nil, fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+		}
+	}
+	return created, nil
+}
+
+// parseResources decodes the provided YAML or JSON multi-document manifest.
+// Namespaced resources without namespace are set to the provided or default namespace (cluster-scoped are left untouched),
+// the injected namespace is returned (empty if none).
+func (c *Core) parseResources(resource, namespace string) ([]*unstructured.Unstructured, string, error) {
+	resources := manifestSeparator.Split(resource, -1)
 	var parsedResources []*unstructured.Unstructured
+	injectedNamespace := ""
 	for _, r := range resources {
 		var obj unstructured.Unstructured
 		if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(r)).Decode(&obj); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		// remove the status from the resource, disallowing agent from directly editing (only controllers should be allowed to do this)
 		delete(obj.Object, "status")
 
+		gvk := obj.GroupVersionKind()
+		if namespaced, nsErr := c.isNamespaced(&gvk); nsErr == nil && namespaced && obj.GetNamespace() == "" {
+			injectedNamespace = c.NamespaceOrDefault(namespace)
+			obj.SetNamespace(injectedNamespace)
+		}
+
 		parsedResources = append(parsedResources, &obj)
 	}
-	return c.resourcesCreateOrUpdate(ctx, parsedResources)
+	return parsedResources, injectedNamespace, nil
+}
+
+// setOwnerReference validates the owner against the cluster and adds it to the object's metadata.ownerReferences
+func (c *Core) setOwnerReference(ctx context.Context, obj *unstructured.Unstructured, owner *metav1.OwnerReference) error {
+	ownerGv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid owner apiVersion %s: %w", owner.APIVersion, err)
+	}
+	ownerGvk := ownerGv.WithKind(owner.Kind)
+	ownerGvr, err := c.resourceFor(&ownerGvk)
+	if err != nil {
+		return err
+	}
+	ownerNamespaced, err := c.isNamespaced(&ownerGvk)
+	if err != nil {
+		return err
+	}
+	ownerNamespace := ""
+	if ownerNamespaced {
+		objGvk := obj.GroupVersionKind()
+		if namespaced, nsErr := c.isNamespaced(&objGvk); nsErr != nil || !namespaced {
+			return fmt.Errorf("cluster-scoped resource %s %s can't be owned by namespaced %s %s", objGvk.Kind, obj.GetName(), owner.Kind, owner.Name)
+		}
+		// Namespaced owners must live in the same namespace as the owned resource
+		ownerNamespace = c.NamespaceOrDefault(obj.GetNamespace())
+	}
+	ownerObj, err := c.DynamicClient().Resource(*ownerGvr).Namespace(ownerNamespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get owner %s %s: %w", owner.Kind, owner.Name, err)
+	}
+	if owner.UID != "" && owner.UID != ownerObj.GetUID() {
+		return fmt.Errorf("owner %s %s uid mismatch: provided %s, found %s", owner.Kind, owner.Name, owner.UID, ownerObj.GetUID())
+	}
+	ownerReference := *owner
+	ownerReference.UID = ownerObj.GetUID()
+	ownerReferences := obj.GetOwnerReferences()
+	for _, existing := range ownerReferences {
+		if existing.UID == ownerReference.UID {
+			return nil
+		}
+	}
+	obj.SetOwnerReferences(append(ownerReferences, ownerReference))
+	return nil
 }
 
 func (c *Core) ResourcesDelete(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, gracePeriodSeconds *int64) error {
@@ -111,20 +286,24 @@ func (c *Core) ResourcesScale(
 		resourceClient = c.DynamicClient().Resource(*gvr)
 	}
 
-	scale, err := resourceClient.Get(ctx, name, metav1.GetOptions{}, "scale")
-	if err != nil {
-		return nil, err
-	}
-
-	if shouldScale {
-		if err := unstructured.SetNestedField(scale.Object, desiredScale, "spec", "replicas"); err != nil {
-			return scale, fmt.Errorf("failed to set .spec.replicas on scale object %v: %w", scale, err)
+	var scale *unstructured.Unstructured
+	// The desired scale doesn't depend on the current one, re-read and re-apply it if the resource was modified meanwhile
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err = resourceClient.Get(ctx, name, metav1.GetOptions{}, "scale")
+		if err != nil || !shouldScale {
+			return err
+		}
+		if err = unstructured.SetNestedField(scale.Object, desiredScale, "spec", "replicas"); err != nil {
+			return fmt.Errorf("failed to set .spec.replicas on scale object %v: %w", scale, err)
 		}
-
 		scale, err = resourceClient.Update(ctx, scale, metav1.UpdateOptions{}, "scale")
 		if err != nil {
-			return scale, fmt.Errorf("failed to update scale: %w", err)
+			return fmt.Errorf("failed to update scale: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return scale, resourceModifiedError(err)
 	}
 
 	return scale, nil
@@ -145,8 +324,7 @@ func (c *Core) resourcesListAsTable(ctx context.Context, gvk *schema.GroupVersio
 		url = append(url, "namespaces", namespace)
 	}
 	url = append(url, gvr.Resource)
-	var table metav1.Table
-	err := c.CoreV1().RESTClient().
+	raw, err := c.CoreV1().RESTClient().
 		Get().
 		SetHeader("Accept", strings.Join([]string{
 			fmt.Sprintf("application/json;as=Table;v=%s;g=%s", metav1.SchemeGroupVersion.Version, metav1.GroupName),
@@ -155,10 +333,29 @@ func (c *Core) resourcesListAsTable(ctx context.Context, gvk *schema.GroupVersio
 		}, ",")).
 		AbsPath(url...).
 		SpecificallyVersionedParams(&options.ListOptions, ParameterCodec, schema.GroupVersion{Version: "v1"}).
-		Do(ctx).Into(&table)
+		Do(ctx).Raw()
 	if err != nil {
 		return nil, err
 	}
+	// Servers that can't render tables (e.g. some aggregated APIs) ignore the Table media type and return a regular list.
+	// In that case, fall back to the regular list so that it's formatted client-side.
+	var typeMeta metav1.TypeMeta
+	if err = json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	table := &metav1.Table{}
+	if typeMeta.Kind != "" && typeMeta.Kind != "Table" {
+		list, listErr := c.DynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, options.ListOptions)
+		if listErr != nil {
+			return nil, listErr
+		}
+		// Custom resources are rendered with the additionalPrinterColumns declared in their definition (if available)
+		if table = c.customResourceTable(ctx, gvr, list); table == nil {
+			return list, nil
+		}
+	} else if err = json.Unmarshal(raw, table); err != nil {
+		return nil, err
+	}
 	// Add metav1.Table apiVersion and kind to the unstructured object (server may not return these fields)
 	table.SetGroupVersionKind(metav1.SchemeGroupVersion.WithKind("Table"))
 	// Add additional columns for fields that aren't returned by the server
@@ -173,29 +370,26 @@ func (c *Core) resourcesListAsTable(ctx context.Context, gvk *schema.GroupVersio
 			gvk.Kind,
 		}, row.Cells...)
 	}
-	unstructuredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&table)
+	unstructuredObject, err := runtime.DefaultUnstructuredConverter.ToUnstructured(table)
 	return &unstructured.Unstructured{Object: unstructuredObject}, err
 }
 
-func (c *Core) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (c *Core) resourcesCreateOrUpdate(ctx context.Context, resources []*unstructured.Unstructured, fieldManager string) ([]*unstructured.Unstructured, error) {
 	for i, obj := range resources {
 		gvk := obj.GroupVersionKind()
-		gvr, rErr := c.resourceFor(&gvk)
+		ri, rErr := c.resourceInterfaceFor(obj)
 		if rErr != nil {
 			return nil, rErr
 		}
-
-		namespace := obj.GetNamespace()
-		// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
-		if namespaced, nsErr := c.isNamespaced(&gvk); nsErr == nil && namespaced {
-			namespace = c.NamespaceOrDefault(namespace)
-		}
-		resources[i], rErr = c.DynamicClient().Resource(*gvr).Namespace(namespace).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
-			FieldManager: version.BinaryName,
-			Force:        true,
-		})
+		applyOptions := metav1.ApplyOptions{FieldManager: fieldManager, Force: true}
+		resources[i], rErr = ri.Apply(ctx, obj.GetName(), obj, applyOptions)
+		if apierrors.IsConflict(rErr) && metadataOnlyManifest(obj) {
+			// Labels and annotations don't depend on the rest of the resource, re-apply them on top of its latest version
+			obj.SetResourceVersion("")
+			resources[i], rErr = ri.Apply(ctx, obj.GetName(), obj, applyOptions)
+		}
 		if rErr != nil {
-			return nil, rErr
+			return nil, resourceModifiedError(rErr)
 		}
 		// Clear the cache to ensure the next operation is performed on the latest exposed APIs (will change after the CRD creation)
 		if gvk.Kind == "CustomResourceDefinition" {
@@ -205,6 +399,22 @@ func (c *Core) resourcesCreateOrUpdate(ctx context.Context, resources []*unstruc
 	return resources, nil
 }
 
+// resourceInterfaceFor returns the dynamic client for the resource of the provided object, namespaced resources without
+// a namespace are addressed in the default configured one
+func (c *Core) resourceInterfaceFor(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	gvr, err := c.resourceFor(&gvk)
+	if err != nil {
+		return nil, err
+	}
+	namespace := obj.GetNamespace()
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := c.isNamespaced(&gvk); nsErr == nil && namespaced {
+		namespace = c.NamespaceOrDefault(namespace)
+	}
+	return c.DynamicClient().Resource(*gvr).Namespace(namespace), nil
+}
+
 func (c *Core) resourceFor(gvk *schema.GroupVersionKind) (*schema.GroupVersionResource, error) {
 	m, err := c.RESTMapper().RESTMapping(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind}, gvk.Version)
 	if err != nil {
@@ -0,0 +1,237 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ResourceAdded is the change of a resource created since the checkpoint
+	ResourceAdded = "Added"
+	// ResourceDeleted is the change of a resource deleted since the checkpoint
+	ResourceDeleted = "Deleted"
+	// ResourceModified is the change of a resource whose manifest (spec, labels, annotations...) changed since the checkpoint
+	ResourceModified = "Modified"
+	// ResourceStatusChanged is the change of a resource whose manifest is unchanged but whose status (or server
+	// managed metadata) changed since the checkpoint
+	ResourceStatusChanged = "StatusChanged"
+)
+
+// ResourcesCheckpointResult is the summary of a checkpoint captured by ResourcesCheckpoint
+type ResourcesCheckpointResult struct {
+	// Token identifies the checkpoint in ResourcesSinceCheckpoint
+	Token     string
+	Namespace string
+	Expires   time.Time
+	// Counts is the number of captured resources by kind
+	Counts map[string]int
+	// Skipped are the resource types that couldn't be listed (e.g. denied or forbidden)
+	Skipped []string
+}
+
+// ResourcesSinceCheckpointResult are the changes of the resources captured by a checkpoint
+type ResourcesSinceCheckpointResult struct {
+	Namespace string
+	Created   time.Time
+	// Changes are sorted by kind and name
+	Changes   []ResourceChange
+	Unchanged int
+}
+
+// ResourceChange is the change of a resource since a checkpoint
+type ResourceChange struct {
+	// Change is one of ResourceAdded, ResourceDeleted, ResourceModified or ResourceStatusChanged
+	Change     string
+	APIVersion string
+	Kind       string
+	Name       string
+	// Diff is the unified diff of the manifest for ResourceModified changes
+	Diff string
+}
+
+// resourcesCheckpoint is the state of the resources stored by ResourcesCheckpoint
+type resourcesCheckpoint struct {
+	Namespace     string                        `json:"namespace"`
+	LabelSelector string                        `json:"labelSelector,omitempty"`
+	Created       time.Time                     `json:"created"`
+	Resources     []schema.GroupVersionResource `json:"resources"`
+	Objects       []checkpointObject            `json:"objects"`
+}
+
+type checkpointObject struct {
+	APIVersion      string `json:"apiVersion"`
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion"`
+	// Manifest is the normalized YAML of the object (see normalizeForDiff), Secret values are replaced by their digest
+	Manifest string `json:"manifest"`
+}
+
+func (o *checkpointObject) key() string {
+	return o.APIVersion + "/" + o.Kind + "/" + o.Name
+}
+
+// ResourcesCheckpoint captures the resourceVersion and the manifest of the resources of the provided kinds
+// (see NamespacedScanResources) in the namespace, optionally filtered by a label selector, and keeps them in memory
+// for a limited time so that ResourcesSinceCheckpoint can later report what changed.
+// Resource types that can't be listed (e.g. denied or forbidden) are skipped. Secret values are never kept, only
+// their digest is stored to detect the changes.
+func (c *Core) ResourcesCheckpoint(ctx context.Context, namespace string, kinds []string, labelSelector string) (*ResourcesCheckpointResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	resources := c.NamespacedScanResources(kinds)
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("none of the kinds %v is a namespaced resource served by the cluster", kinds)
+	}
+	checkpoint := &resourcesCheckpoint{
+		Namespace:     namespace,
+		LabelSelector: labelSelector,
+		Created:       time.Now().UTC(),
+	}
+	ret := &ResourcesCheckpointResult{Namespace: namespace, Counts: map[string]int{}}
+	for _, gvr := range resources {
+		objects, err := c.checkpointObjects(ctx, gvr, namespace, labelSelector)
+		if err != nil {
+			ret.Skipped = append(ret.Skipped, gvr.GroupResource().String())
+			continue
+		}
+		checkpoint.Resources = append(checkpoint.Resources, gvr)
+		checkpoint.Objects = append(checkpoint.Objects, objects...)
+		for _, obj := range objects {
+			ret.Counts[obj.Kind]++
+		}
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize checkpoint: %w", err)
+	}
+	ret.Token, ret.Expires, err = c.saveCheckpoint(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save checkpoint of %d resources (narrow it down with kinds or a label selector): %w", len(checkpoint.Objects), err)
+	}
+	return ret, nil
+}
+
+// ResourcesSinceCheckpoint compares the current state of the resources captured by the checkpoint with their state
+// at the time of the checkpoint, the resource types are listed again with the namespace and label selector of the
+// checkpoint.
+func (c *Core) ResourcesSinceCheckpoint(ctx context.Context, token string) (*ResourcesSinceCheckpointResult, error) {
+	data, ok := c.loadCheckpoint(token)
+	if !ok {
+		return nil, fmt.Errorf("checkpoint %s not found: it expired (checkpoints are kept for %s), was evicted by newer checkpoints, "+
+			"or was captured in another cluster or with other credentials", token, checkpointTTL)
+	}
+	checkpoint := &resourcesCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", token, err)
+	}
+	previous := make(map[string]*checkpointObject, len(checkpoint.Objects))
+	for i := range checkpoint.Objects {
+		previous[checkpoint.Objects[i].key()] = &checkpoint.Objects[i]
+	}
+	ret := &ResourcesSinceCheckpointResult{Namespace: checkpoint.Namespace, Created: checkpoint.Created}
+	for _, gvr := range checkpoint.Resources {
+		objects, err := c.checkpointObjects(ctx, gvr, checkpoint.Namespace, checkpoint.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", gvr.GroupResource().String(), err)
+		}
+		for i := range objects {
+			current := &objects[i]
+			old, found := previous[current.key()]
+			delete(previous, current.key())
+			switch {
+			case !found:
+				ret.Changes = append(ret.Changes, current.change(ResourceAdded))
+			case old.ResourceVersion == current.ResourceVersion:
+				ret.Unchanged++
+			case old.Manifest == current.Manifest:
+				ret.Changes = append(ret.Changes, current.change(ResourceStatusChanged))
+			default:
+				change := current.change(ResourceModified)
+				name := current.Kind + "." + checkpoint.Namespace + "." + current.Name
+				if change.Diff, err = unifiedDiff(name, "checkpoint/"+name, old.Manifest, "current/"+name, current.Manifest); err != nil {
+					return nil, err
+				}
+				ret.Changes = append(ret.Changes, change)
+			}
+		}
+	}
+	for _, old := range previous {
+		ret.Changes = append(ret.Changes, old.change(ResourceDeleted))
+	}
+	sort.SliceStable(ret.Changes, func(i, j int) bool {
+		if ret.Changes[i].Kind != ret.Changes[j].Kind {
+			return ret.Changes[i].Kind < ret.Changes[j].Kind
+		}
+		return ret.Changes[i].Name < ret.Changes[j].Name
+	})
+	return ret, nil
+}
+
+func (o *checkpointObject) change(change string) ResourceChange {
+	return ResourceChange{Change: change, APIVersion: o.APIVersion, Kind: o.Kind, Name: o.Name}
+}
+
+// checkpointObjects lists the resources and returns their checkpoint representation
+func (c *Core) checkpointObjects(ctx context.Context, gvr schema.GroupVersionResource, namespace, labelSelector string) ([]checkpointObject, error) {
+	list, err := c.DynamicClient().Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]checkpointObject, 0, len(list.Items))
+	for i := range list.Items {
+		obj := &list.Items[i]
+		normalized := normalizeForDiff(obj)
+		if obj.GetKind() == "Secret" {
+			digestSecretValues(normalized)
+		}
+		manifest, err := yaml.Marshal(normalized.Object)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, checkpointObject{
+			APIVersion:      obj.GetAPIVersion(),
+			Kind:            obj.GetKind(),
+			Name:            obj.GetName(),
+			ResourceVersion: obj.GetResourceVersion(),
+			Manifest:        string(manifest),
+		})
+	}
+	return ret, nil
+}
+
+// secretDigestKey keys the digests of the Secret values, it's random for each process (checkpoints are kept in memory)
+// so that a digest can't be matched against the digests of guessed values
+var secretDigestKey = func() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}()
+
+// digestSecretValues replaces the values of the Secret by their HMAC-SHA256 digest so that changes can be detected
+// without keeping or revealing the values
+func digestSecretValues(secret *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		values, _, _ := unstructured.NestedStringMap(secret.Object, field)
+		if len(values) == 0 {
+			continue
+		}
+		for key, value := range values {
+			mac := hmac.New(sha256.New, secretDigestKey)
+			mac.Write([]byte(value))
+			values[key] = "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+		}
+		_ = unstructured.SetNestedStringMap(secret.Object, values, field)
+	}
+}
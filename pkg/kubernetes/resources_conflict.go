@@ -0,0 +1,53 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrResourceModified is wrapped by the errors of the writes rejected because the resourceVersion they were based on
+// is stale (the resource was modified since it was read)
+var ErrResourceModified = errors.New("the resource was modified since you read it; re-fetch it and retry the change")
+
+// metadataOnlyManifestFields are the metadata fields of a manifest that only changes labels and annotations
+var metadataOnlyManifestFields = []string{"name", "namespace", "resourceVersion", "labels", "annotations"}
+
+// resourceModifiedError returns an actionable error for the optimistic concurrency Conflict errors, the rest of the
+// errors (including the Server-Side Apply field manager conflicts) are returned as they are.
+// The returned error wraps both ErrResourceModified and the original error.
+func resourceModifiedError(err error) error {
+	if !apierrors.IsConflict(err) {
+		return err
+	}
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) && statusErr.Status().Details != nil {
+		for _, cause := range statusErr.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrResourceModified, err)
+}
+
+// metadataOnlyManifest returns true if the manifest only sets labels and annotations, these changes don't depend on
+// the rest of the resource and can be safely re-applied on top of its latest version
+func metadataOnlyManifest(obj *unstructured.Unstructured) bool {
+	for field := range obj.Object {
+		if field != "apiVersion" && field != "kind" && field != "metadata" {
+			return false
+		}
+	}
+	metadata, _, _ := unstructured.NestedMap(obj.Object, "metadata")
+	for field := range metadata {
+		if !slices.Contains(metadataOnlyManifestFields, field) {
+			return false
+		}
+	}
+	return len(obj.GetLabels()) > 0 || len(obj.GetAnnotations()) > 0
+}
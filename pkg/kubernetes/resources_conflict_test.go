@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type ResourcesConflictSuite struct {
+	suite.Suite
+}
+
+func (s *ResourcesConflictSuite) TestResourceModifiedError() {
+	s.Run("stale resourceVersion conflict is actionable", func() {
+		conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm",
+			errors.New("the object has been modified; please apply your changes to the latest version and try again"))
+		err := resourceModifiedError(conflict)
+		s.ErrorIs(err, ErrResourceModified)
+		s.True(apierrors.IsConflict(err), "expected the original Conflict error to be wrapped")
+		s.Equal(`the resource was modified since you read it; re-fetch it and retry the change: `+
+			`Operation cannot be fulfilled on configmaps "cm": the object has been modified; please apply your changes to the latest version and try again`,
+			err.Error())
+	})
+	s.Run("field manager conflict is returned as is", func() {
+		conflict := &apierrors.StatusError{ErrStatus: metav1.Status{
+			Status: metav1.StatusFailure, Code: 409, Reason: metav1.StatusReasonConflict,
+			Message: "Apply failed with 1 conflict: conflict with \"kubectl\": .data.key",
+			Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.key"}}},
+		}}
+		s.Same(conflict, resourceModifiedError(conflict))
+	})
+	s.Run("other errors are returned as they are", func() {
+		notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm")
+		s.Same(notFound, resourceModifiedError(notFound))
+		s.Nil(resourceModifiedError(nil))
+	})
+}
+
+func (s *ResourcesConflictSuite) TestMetadataOnlyManifest() {
+	manifest := func(obj map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: obj}
+	}
+	s.Run("labels and annotations only", func() {
+		s.True(metadataOnlyManifest(manifest(map[string]any{
+			"apiVersion": "v1", "kind": "ConfigMap",
+			"metadata": map[string]any{"name": "cm", "namespace": "ns", "resourceVersion": "1",
+				"labels": map[string]any{"app": "web"}, "annotations": map[string]any{"owner": "team-a"}},
+		})))
+	})
+	s.Run("manifest with other fields", func() {
+		s.False(metadataOnlyManifest(manifest(map[string]any{
+			"apiVersion": "v1", "kind": "ConfigMap",
+			"metadata": map[string]any{"name": "cm", "labels": map[string]any{"app": "web"}},
+			"data":     map[string]any{"key": "value"},
+		})))
+	})
+	s.Run("manifest with other metadata fields", func() {
+		s.False(metadataOnlyManifest(manifest(map[string]any{
+			"apiVersion": "v1", "kind": "ConfigMap",
+			"metadata": map[string]any{"name": "cm", "labels": map[string]any{"app": "web"}, "finalizers": []any{"f"}},
+		})))
+	})
+	s.Run("manifest without labels and annotations", func() {
+		s.False(metadataOnlyManifest(manifest(map[string]any{
+			"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]any{"name": "cm"},
+		})))
+	})
+}
+
+func TestResourcesConflict(t *testing.T) {
+	suite.Run(t, new(ResourcesConflictSuite))
+}
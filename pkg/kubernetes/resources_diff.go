@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// ResourcesDiff returns a unified diff between the live state of the provided resources and the state they would have
+// if applied with resources_create_or_update.
+// The desired state is computed by the API server with a server-side apply dry-run so that defaults, admission
+// and merge semantics are taken into account. Fields that always change or are managed by the server
+// (managedFields, status, resourceVersion, generation...) are ignored.
+func (c *Core) ResourcesDiff(ctx context.Context, resource, namespace string) (string, error) {
+	parsedResources, _, err := c.parseResources(resource, namespace)
+	if err != nil {
+		return "", err
+	}
+	sb := strings.Builder{}
+	for _, obj := range parsedResources {
+		gvk := obj.GroupVersionKind()
+		gvr, rErr := c.resourceFor(&gvk)
+		if rErr != nil {
+			return "", rErr
+		}
+		ri := c.DynamicClient().Resource(*gvr).Namespace(obj.GetNamespace())
+		live, getErr := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if getErr != nil && !apierrors.IsNotFound(getErr) {
+			return "", getErr
+		}
+		desired, applyErr := ri.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+			FieldManager: version.BinaryName,
+			Force:        true,
+			DryRun:       []string{metav1.DryRunAll},
+		})
+		if applyErr != nil {
+			return "", applyErr
+		}
+		diff, dErr := diffObjects(diffName(obj), live, desired)
+		if dErr != nil {
+			return "", dErr
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), nil
+}
+
+// resourcesGetPrevious returns the current state of the provided resources before they are applied, nil for the
+// resources that don't exist yet
+func (c *Core) resourcesGetPrevious(ctx context.Context, resources []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	previous := make([]*unstructured.Unstructured, len(resources))
+	for i, obj := range resources {
+		ri, err := c.resourceInterfaceFor(obj)
+		if err != nil {
+			return nil, err
+		}
+		previous[i], err = ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			previous[i] = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get %s before applying it: %w", diffName(obj), err)
+		}
+	}
+	return previous, nil
+}
+
+// resourcesAppliedDiff returns the unified diff between the previous and the applied state of every resource, newly
+// created (nil previous) and unchanged resources are reported with a comment line
+func resourcesAppliedDiff(previous, applied []*unstructured.Unstructured) (string, error) {
+	sb := strings.Builder{}
+	for i, obj := range applied {
+		name := diffName(obj)
+		if previous[i] == nil {
+			sb.WriteString("# " + name + " didn't exist and has been created\n")
+			continue
+		}
+		diff, err := diffObjectsAs(name, "previous", previous[i], "applied", obj)
+		if err != nil {
+			return "", err
+		}
+		if diff == "" {
+			diff = "# " + name + " is unchanged\n"
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), nil
+}
+
+// diffName returns the name of the object in the diff, same naming as kubectl diff
+// (e.g. apps.v1.Deployment.default.a-deployment)
+func diffName(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	name := strings.ReplaceAll(gvk.GroupVersion().String(), "/", ".") + "." + gvk.Kind
+	if obj.GetNamespace() != "" {
+		name += "." + obj.GetNamespace()
+	}
+	return name + "." + obj.GetName()
+}
+
+// diffObjects returns the unified diff between the normalized YAML representations of the live and desired objects.
+// A nil live object (not found) is represented as an empty document.
+func diffObjects(name string, live, desired *unstructured.Unstructured) (string, error) {
+	return diffObjectsAs(name, "live", live, "desired", desired)
+}
+
+// diffObjectsAs returns the unified diff between the normalized YAML representations of the from and to objects,
+// labeled with the provided prefixes. A nil from object is represented as an empty document.
+func diffObjectsAs(name, fromPrefix string, from *unstructured.Unstructured, toPrefix string, to *unstructured.Unstructured) (string, error) {
+	fromYaml := ""
+	if from != nil {
+		normalized, err := yaml.Marshal(normalizeForDiff(from).Object)
+		if err != nil {
+			return "", err
+		}
+		fromYaml = string(normalized)
+	}
+	toYaml, err := yaml.Marshal(normalizeForDiff(to).Object)
+	if err != nil {
+		return "", err
+	}
+	fromFile := fromPrefix + "/" + name
+	if from == nil {
+		fromFile = "/dev/null"
+	}
+	return unifiedDiff(name, fromFile, fromYaml, toPrefix+"/"+name, string(toYaml))
+}
+
+// unifiedDiff returns the unified diff between the from and to YAML documents
+func unifiedDiff(name, fromFile, fromYaml, toFile, toYaml string) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromYaml),
+		B:        difflib.SplitLines(toYaml),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute diff for %s: %w", name, err)
+	}
+	return diff, nil
+}
+
+// normalizeForDiff returns a copy of the object without the fields that are managed by the server
+func normalizeForDiff(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	normalized := obj.DeepCopy()
+	delete(normalized.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+		unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+	}
+	return normalized
+}
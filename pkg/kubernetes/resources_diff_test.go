@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ResourcesDiffSuite struct {
+	suite.Suite
+}
+
+func (s *ResourcesDiffSuite) TestDiffObjects() {
+	newConfigMap := func(value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "a-configmap",
+				"namespace":       "default",
+				"resourceVersion": value,
+				"uid":             value,
+				"managedFields":   []interface{}{map[string]interface{}{"manager": value}},
+			},
+			"data": map[string]interface{}{"key": value},
+		}}
+	}
+	s.Run("ignores server managed fields", func() {
+		diff, err := diffObjects("v1.ConfigMap.default.a-configmap", newConfigMap("same"), newConfigMap("same"))
+		s.Require().NoError(err)
+		s.Empty(diff)
+	})
+	s.Run("returns unified diff for changed fields", func() {
+		diff, err := diffObjects("v1.ConfigMap.default.a-configmap", newConfigMap("live"), newConfigMap("desired"))
+		s.Require().NoError(err)
+		s.Contains(diff, "--- live/v1.ConfigMap.default.a-configmap\n+++ desired/v1.ConfigMap.default.a-configmap\n")
+		s.Contains(diff, "-  key: live\n+  key: desired\n")
+		s.NotContains(diff, "managedFields")
+		s.NotContains(diff, "resourceVersion")
+	})
+	s.Run("diffs against empty document when live object doesn't exist", func() {
+		diff, err := diffObjects("v1.ConfigMap.default.a-configmap", nil, newConfigMap("desired"))
+		s.Require().NoError(err)
+		s.Contains(diff, "--- /dev/null\n")
+		s.Contains(diff, "+  key: desired\n")
+	})
+}
+
+func (s *ResourcesDiffSuite) TestResourcesAppliedDiff() {
+	newConfigMap := func(name, value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default", "resourceVersion": value},
+			"data":       map[string]interface{}{"key": value},
+		}}
+	}
+	diff, err := resourcesAppliedDiff(
+		[]*unstructured.Unstructured{newConfigMap("changed", "previous"), nil, newConfigMap("unchanged", "same")},
+		[]*unstructured.Unstructured{newConfigMap("changed", "applied"), newConfigMap("created", "applied"), newConfigMap("unchanged", "same")},
+	)
+	s.Require().NoError(err)
+	s.Run("returns unified diff for updated resources", func() {
+		s.Contains(diff, "--- previous/v1.ConfigMap.default.changed\n+++ applied/v1.ConfigMap.default.changed\n")
+		s.Contains(diff, "-  key: previous\n+  key: applied\n")
+	})
+	s.Run("notes newly created resources", func() {
+		s.Contains(diff, "# v1.ConfigMap.default.created didn't exist and has been created\n")
+		s.NotContains(diff, "/dev/null")
+	})
+	s.Run("notes unchanged resources", func() {
+		s.Contains(diff, "# v1.ConfigMap.default.unchanged is unchanged\n")
+	})
+}
+
+func TestResourcesDiff(t *testing.T) {
+	suite.Run(t, new(ResourcesDiffSuite))
+}
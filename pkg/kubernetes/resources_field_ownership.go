@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// FieldOwnership is the readable form of the metadata.managedFields of a resource
+type FieldOwnership struct {
+	Managers []ManagedFields `json:"managers"`
+	// SharedFields are the fields owned by more than one manager (field path -> managers), a manager that sets a
+	// different value takes the field over (Update) or conflicts with the other owners (Server-Side Apply)
+	SharedFields map[string][]string `json:"sharedFields,omitempty"`
+}
+
+// ManagedFields are the fields owned by a field manager
+type ManagedFields struct {
+	Manager string `json:"manager"`
+	// Operation is Apply for Server-Side Apply and Update for the rest of the writes (create, update, patch)
+	Operation   string `json:"operation"`
+	APIVersion  string `json:"apiVersion"`
+	Subresource string `json:"subresource,omitempty"`
+	Time        string `json:"time,omitempty"`
+	// Fields are the paths of the owned fields (e.g. .spec.replicas or .spec.containers[name="app"].image)
+	Fields []string `json:"fields"`
+}
+
+// ResourcesFieldOwnership returns the fields owned by each field manager of the resource, parsed from its
+// metadata.managedFields, and the fields owned by more than one manager.
+// A field owned by a controller (Update operation) and by an applier is a common cause of changes being reverted.
+func (c *Core) ResourcesFieldOwnership(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*FieldOwnership, error) {
+	obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &FieldOwnership{Managers: []ManagedFields{}}
+	owners := map[string][]string{}
+	for _, entry := range obj.GetManagedFields() {
+		managed := ManagedFields{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			APIVersion:  entry.APIVersion,
+			Subresource: entry.Subresource,
+			Fields:      []string{},
+		}
+		if entry.Time != nil {
+			managed.Time = entry.Time.UTC().Format(time.RFC3339)
+		}
+		if entry.FieldsV1 != nil {
+			set := &fieldpath.Set{}
+			if err = set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+				return nil, fmt.Errorf("failed to parse the managed fields of %s: %w", entry.Manager, err)
+			}
+			set.Leaves().Iterate(func(path fieldpath.Path) {
+				managed.Fields = append(managed.Fields, path.String())
+			})
+			slices.Sort(managed.Fields)
+		}
+		for _, field := range managed.Fields {
+			if !slices.Contains(owners[field], entry.Manager) {
+				owners[field] = append(owners[field], entry.Manager)
+			}
+		}
+		ret.Managers = append(ret.Managers, managed)
+	}
+	for field, managers := range owners {
+		if len(managers) > 1 {
+			if ret.SharedFields == nil {
+				ret.SharedFields = map[string][]string{}
+			}
+			ret.SharedFields[field] = managers
+		}
+	}
+	return ret, nil
+}
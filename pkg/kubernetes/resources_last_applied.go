@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+)
+
+// LastAppliedConfigAnnotation is the annotation where kubectl (client-side) apply stores the applied manifest
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// LastAppliedConfiguration is the last configuration applied to a resource with client-side apply and its drift
+type LastAppliedConfiguration struct {
+	// Applied is the YAML representation of the last applied configuration
+	Applied string
+	// Diff is the unified diff between the last applied configuration and the live resource
+	// (empty if the live resource matches the last applied configuration)
+	Diff string
+}
+
+// ResourcesLastApplied returns the last configuration applied to the resource with client-side apply
+// (kubectl.kubernetes.io/last-applied-configuration annotation) and its diff against the live resource.
+// Only the fields of the last applied configuration are compared, so that the fields defaulted or managed by the
+// server don't show up as drift.
+// Returns a nil configuration if the resource doesn't have the annotation (not managed with client-side apply).
+func (c *Core) ResourcesLastApplied(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*LastAppliedConfiguration, error) {
+	live, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	annotation, found := live.GetAnnotations()[LastAppliedConfigAnnotation]
+	if !found || strings.TrimSpace(annotation) == "" {
+		return nil, nil
+	}
+	applied := map[string]any{}
+	if err = json.Unmarshal([]byte(annotation), &applied); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s annotation: %w", LastAppliedConfigAnnotation, err)
+	}
+	appliedYaml, err := yaml.Marshal(applied)
+	if err != nil {
+		return nil, err
+	}
+	liveObject := live.DeepCopy()
+	// The annotation is set by kubectl on the live object only, it's never part of the applied configuration
+	unstructured.RemoveNestedField(liveObject.Object, "metadata", "annotations", LastAppliedConfigAnnotation)
+	projected, _ := projectOnto(applied, liveObject.Object).(map[string]any)
+	liveYaml, err := yaml.Marshal(projected)
+	if err != nil {
+		return nil, err
+	}
+	// Same naming as kubectl diff (e.g. apps.v1.Deployment.default.a-deployment)
+	diffName := strings.ReplaceAll(gvk.GroupVersion().String(), "/", ".") + "." + gvk.Kind
+	if live.GetNamespace() != "" {
+		diffName += "." + live.GetNamespace()
+	}
+	diffName += "." + live.GetName()
+	diff, err := unifiedDiff(diffName, "last-applied/"+diffName, string(appliedYaml), "live/"+diffName, string(liveYaml))
+	if err != nil {
+		return nil, err
+	}
+	return &LastAppliedConfiguration{Applied: string(appliedYaml), Diff: diff}, nil
+}
+
+// projectOnto returns the live value restricted to the fields present in the applied value.
+// Maps are projected key by key (keys missing in the live value are omitted), lists are projected item by item
+// (additional live items are kept as they are) and any other live value is returned as is.
+func projectOnto(applied, live any) any {
+	switch a := applied.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+		projected := make(map[string]any, len(a))
+		for key, value := range a {
+			if liveValue, found := l[key]; found {
+				projected[key] = projectOnto(value, liveValue)
+			}
+		}
+		return projected
+	case []any:
+		l, ok := live.([]any)
+		if !ok {
+			return live
+		}
+		projected := make([]any, len(l))
+		for i, liveValue := range l {
+			if i < len(a) {
+				projected[i] = projectOnto(a[i], liveValue)
+			} else {
+				projected[i] = liveValue
+			}
+		}
+		return projected
+	default:
+		return live
+	}
+}
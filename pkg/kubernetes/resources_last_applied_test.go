@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesLastAppliedSuite struct {
+	suite.Suite
+}
+
+func (s *ResourcesLastAppliedSuite) TestProjectOnto() {
+	s.Run("keeps only the applied fields of maps", func() {
+		applied := map[string]any{"spec": map[string]any{"replicas": int64(1)}}
+		live := map[string]any{"spec": map[string]any{"replicas": int64(3), "revisionHistoryLimit": int64(10)}, "status": map[string]any{}}
+		s.Equal(map[string]any{"spec": map[string]any{"replicas": int64(3)}}, projectOnto(applied, live))
+	})
+	s.Run("omits applied fields missing in live", func() {
+		applied := map[string]any{"data": map[string]any{"removed": "value", "kept": "value"}}
+		live := map[string]any{"data": map[string]any{"kept": "changed"}}
+		s.Equal(map[string]any{"data": map[string]any{"kept": "changed"}}, projectOnto(applied, live))
+	})
+	s.Run("projects list items and keeps additional live items", func() {
+		applied := []any{map[string]any{"name": "app", "image": "app:1"}}
+		live := []any{
+			map[string]any{"name": "app", "image": "app:2", "imagePullPolicy": "IfNotPresent"},
+			map[string]any{"name": "sidecar", "image": "sidecar:1"},
+		}
+		s.Equal([]any{
+			map[string]any{"name": "app", "image": "app:2"},
+			map[string]any{"name": "sidecar", "image": "sidecar:1"},
+		}, projectOnto(applied, live))
+	})
+	s.Run("returns live value when types differ", func() {
+		s.Equal("live", projectOnto(map[string]any{"key": "value"}, "live"))
+		s.Equal(int64(1), projectOnto([]any{"value"}, int64(1)))
+	})
+}
+
+func TestResourcesLastApplied(t *testing.T) {
+	suite.Run(t, new(ResourcesLastAppliedSuite))
+}
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// referencesPodSpecFields are the kinds supported by ResourcesReferences and the path of their Pod spec
+var referencesPodSpecFields = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                        {"spec"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// ResourceReferences holds the objects referenced by a resource
+type ResourceReferences struct {
+	// Objects are the referenced ServiceAccount, ConfigMaps, Secrets and PersistentVolumeClaims
+	Objects []*unstructured.Unstructured
+	// Missing are the referenced objects that couldn't be retrieved and the reason (e.g. not found, not allowed)
+	Missing []string
+}
+
+// ResourcesReferencesSupported returns true if ResourcesReferences can extract the references of the kind
+func ResourcesReferencesSupported(gvk schema.GroupVersionKind) bool {
+	_, ok := referencesPodSpecFields[gvk.GroupKind()]
+	return ok
+}
+
+// ResourcesReferences retrieves the objects referenced by the Pod spec (volumes, envFrom, env valueFrom,
+// imagePullSecrets and serviceAccountName) of a Pod or of a workload with a Pod template.
+// The objects are retrieved from the namespace of the resource, those that can't be retrieved are reported as missing.
+func (c *Core) ResourcesReferences(ctx context.Context, obj *unstructured.Unstructured) (*ResourceReferences, error) {
+	fields, ok := referencesPodSpecFields[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return nil, fmt.Errorf("expanding the references of %s is not supported", obj.GroupVersionKind().GroupKind())
+	}
+	ret := &ResourceReferences{}
+	podSpec, found, err := unstructured.NestedMap(obj.Object, fields...)
+	if err != nil || !found {
+		return ret, nil
+	}
+	var spec v1.PodSpec
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(podSpec, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s pod spec: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	for _, ref := range podTemplateReferences(&spec) {
+		referenced, getErr := c.ResourcesGet(ctx, &ref.gvk, obj.GetNamespace(), ref.name)
+		switch {
+		case apierrors.IsNotFound(getErr):
+			ret.Missing = append(ret.Missing, fmt.Sprintf("%s %s: not found", ref.gvk.Kind, ref.name))
+		case getErr != nil:
+			ret.Missing = append(ret.Missing, fmt.Sprintf("%s %s: %v", ref.gvk.Kind, ref.name, getErr))
+		default:
+			ret.Objects = append(ret.Objects, referenced)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// ResourcesRolloutPause sets (paused true) or unsets (paused false) spec.paused on the Deployment with a merge patch,
+// same as kubectl rollout pause/resume. Changes to the Pod template of a paused Deployment don't trigger a rollout
+// until it is resumed, so several changes can be rolled out at once.
+// Returns the Deployment and whether it was changed (false if it was already in the requested state).
+func (c *Core) ResourcesRolloutPause(ctx context.Context, namespace, name string, paused bool) (*unstructured.Unstructured, bool, error) {
+	gvr, err := c.resourceFor(&schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	if err != nil {
+		return nil, false, err
+	}
+	deployments := c.DynamicClient().Resource(*gvr).Namespace(c.NamespaceOrDefault(namespace))
+	deployment, err := deployments.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	if current, _, _ := unstructured.NestedBool(deployment.Object, "spec", "paused"); current == paused {
+		return deployment, false, nil
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+	deployment, err = deployments.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: version.BinaryName})
+	if err != nil {
+		return nil, false, err
+	}
+	return deployment, true, nil
+}
@@ -0,0 +1,233 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// deploymentRevisionAnnotation is the revision of a Deployment and of its ReplicaSets, set by the Deployment controller
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// changeCauseAnnotation is the cause of a change, recorded by the users (or the deprecated kubectl --record flag)
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	// restartedAtAnnotation is set on the Pod template by kubectl rollout restart
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// RolloutHistory is the rollout history of a Deployment at the Pod template level, one revision per ReplicaSet
+type RolloutHistory struct {
+	Deployment           string `json:"deployment"`
+	Namespace            string `json:"namespace"`
+	CurrentRevision      int64  `json:"currentRevision"`
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// Revisions are sorted by revision, the oldest first
+	Revisions []RolloutRevision `json:"revisions"`
+}
+
+// RolloutRevision is a revision of a Deployment (a ReplicaSet) with the containers of its Pod template
+type RolloutRevision struct {
+	Revision      int64  `json:"revision"`
+	ReplicaSet    string `json:"replicaSet"`
+	Current       bool   `json:"current,omitempty"`
+	Created       string `json:"created"`
+	Replicas      int32  `json:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas"`
+	ChangeCause   string `json:"changeCause,omitempty"`
+	// Containers are the init containers and containers of the Pod template
+	Containers []RevisionContainer `json:"containers"`
+	// Changes are the Pod template changes since the previous revision
+	Changes []string `json:"changes,omitempty"`
+}
+
+// RevisionContainer is a container of the Pod template of a revision
+type RevisionContainer struct {
+	Name     string            `json:"name"`
+	Init     bool              `json:"init,omitempty"`
+	Image    string            `json:"image"`
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+// ResourcesRolloutHistory returns the revisions of the Deployment (the ReplicaSets it controls, sorted by their
+// revision annotation) with the images and resources of their Pod template and the changes since the previous revision,
+// to answer what a previous version of the Deployment was running.
+// Only the revisions kept by the Deployment (revisionHistoryLimit) are available.
+func (c *Core) ResourcesRolloutHistory(ctx context.Context, namespace, name string) (*RolloutHistory, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	deployment, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of deployment %s: %w", name, err)
+	}
+	replicaSets, err := c.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	ret := &RolloutHistory{
+		Deployment:           deployment.Name,
+		Namespace:            deployment.Namespace,
+		RevisionHistoryLimit: deployment.Spec.RevisionHistoryLimit,
+		Revisions:            []RolloutRevision{},
+	}
+	ret.CurrentRevision, _ = strconv.ParseInt(deployment.Annotations[deploymentRevisionAnnotation], 10, 64)
+	var owned []*appsv1.ReplicaSet
+	for i := range replicaSets.Items {
+		if controller := metav1.GetControllerOf(&replicaSets.Items[i]); controller != nil && controller.UID == deployment.UID {
+			owned = append(owned, &replicaSets.Items[i])
+		}
+	}
+	revision := func(rs *appsv1.ReplicaSet) int64 {
+		r, _ := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		return r
+	}
+	slices.SortFunc(owned, func(a, b *appsv1.ReplicaSet) int {
+		return cmp.Or(cmp.Compare(revision(a), revision(b)), a.CreationTimestamp.Compare(b.CreationTimestamp.Time))
+	})
+	for i, rs := range owned {
+		r := RolloutRevision{
+			Revision:      revision(rs),
+			ReplicaSet:    rs.Name,
+			Current:       ret.CurrentRevision != 0 && revision(rs) == ret.CurrentRevision,
+			Created:       rs.CreationTimestamp.UTC().Format(time.RFC3339),
+			Replicas:      rs.Status.Replicas,
+			ReadyReplicas: rs.Status.ReadyReplicas,
+			ChangeCause:   rs.Annotations[changeCauseAnnotation],
+			Containers:    revisionContainers(&rs.Spec.Template.Spec),
+		}
+		if i > 0 {
+			r.Changes = podTemplateChanges(&owned[i-1].Spec.Template, &rs.Spec.Template)
+		}
+		ret.Revisions = append(ret.Revisions, r)
+	}
+	return ret, nil
+}
+
+func revisionContainers(spec *v1.PodSpec) []RevisionContainer {
+	ret := make([]RevisionContainer, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, container := range spec.InitContainers {
+		ret = append(ret, revisionContainer(&container, true))
+	}
+	for _, container := range spec.Containers {
+		ret = append(ret, revisionContainer(&container, false))
+	}
+	return ret
+}
+
+func revisionContainer(container *v1.Container, init bool) RevisionContainer {
+	return RevisionContainer{
+		Name:     container.Name,
+		Init:     init,
+		Image:    container.Image,
+		Requests: formatResourceList(container.Resources.Requests, nil),
+		Limits:   formatResourceList(container.Resources.Limits, nil),
+	}
+}
+
+// podTemplateChanges describes the changes between the Pod templates of two revisions: the containers added, removed
+// or whose image, resources, command, arguments or environment changed, restarts (kubectl rollout restart) and any
+// other change of the Pod template
+func podTemplateChanges(previous, current *v1.PodTemplateSpec) []string {
+	var changes []string
+	changes = append(changes, containersChanges("init container", previous.Spec.InitContainers, current.Spec.InitContainers)...)
+	changes = append(changes, containersChanges("container", previous.Spec.Containers, current.Spec.Containers)...)
+	previousRestart, currentRestart := previous.Annotations[restartedAtAnnotation], current.Annotations[restartedAtAnnotation]
+	if currentRestart != "" && currentRestart != previousRestart {
+		changes = append(changes, fmt.Sprintf("restarted at %s (kubectl rollout restart)", currentRestart))
+	}
+	// The rest of the Pod template, without the containers and the fields set by the controllers and kubectl
+	rest := func(template *v1.PodTemplateSpec) *v1.PodTemplateSpec {
+		template = template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		delete(template.Annotations, restartedAtAnnotation)
+		template.Spec.InitContainers, template.Spec.Containers = nil, nil
+		return template
+	}
+	previousRest, currentRest := rest(previous), rest(current)
+	if !equality.Semantic.DeepEqual(previousRest.ObjectMeta, currentRest.ObjectMeta) {
+		changes = append(changes, "Pod template labels or annotations changed")
+	}
+	if !equality.Semantic.DeepEqual(previousRest.Spec, currentRest.Spec) {
+		changes = append(changes, "Pod spec changed (fields other than the containers, e.g. volumes, affinity, tolerations or service account)")
+	}
+	return changes
+}
+
+func containersChanges(kind string, previous, current []v1.Container) []string {
+	var changes []string
+	previousByName := make(map[string]*v1.Container, len(previous))
+	for i := range previous {
+		previousByName[previous[i].Name] = &previous[i]
+	}
+	currentNames := make(map[string]bool, len(current))
+	for i := range current {
+		container := &current[i]
+		currentNames[container.Name] = true
+		old, ok := previousByName[container.Name]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("%s %s added (image %s)", kind, container.Name, container.Image))
+			continue
+		}
+		var containerChanges []string
+		if old.Image != container.Image {
+			changes = append(changes, fmt.Sprintf("%s %s image changed: %s -> %s", kind, container.Name, old.Image, container.Image))
+		}
+		if !equality.Semantic.DeepEqual(old.Resources.Requests, container.Resources.Requests) {
+			changes = append(changes, fmt.Sprintf("%s %s requests changed: %s -> %s", kind, container.Name,
+				formatResources(old.Resources.Requests), formatResources(container.Resources.Requests)))
+		}
+		if !equality.Semantic.DeepEqual(old.Resources.Limits, container.Resources.Limits) {
+			changes = append(changes, fmt.Sprintf("%s %s limits changed: %s -> %s", kind, container.Name,
+				formatResources(old.Resources.Limits), formatResources(container.Resources.Limits)))
+		}
+		if !equality.Semantic.DeepEqual(old.Command, container.Command) || !equality.Semantic.DeepEqual(old.Args, container.Args) {
+			containerChanges = append(containerChanges, "command or args")
+		}
+		if !equality.Semantic.DeepEqual(old.Env, container.Env) || !equality.Semantic.DeepEqual(old.EnvFrom, container.EnvFrom) {
+			containerChanges = append(containerChanges, "environment")
+		}
+		// The rest of the container (e.g. probes, ports or volume mounts)
+		oldRest, currentRest := old.DeepCopy(), container.DeepCopy()
+		for _, c := range []*v1.Container{oldRest, currentRest} {
+			c.Image, c.Resources, c.Command, c.Args, c.Env, c.EnvFrom = "", v1.ResourceRequirements{}, nil, nil, nil, nil
+		}
+		if !equality.Semantic.DeepEqual(oldRest, currentRest) {
+			containerChanges = append(containerChanges, "other fields (e.g. probes, ports or volume mounts)")
+		}
+		if len(containerChanges) > 0 {
+			changes = append(changes, fmt.Sprintf("%s %s changed: %s", kind, container.Name, strings.Join(containerChanges, ", ")))
+		}
+	}
+	for _, old := range previous {
+		if !currentNames[old.Name] {
+			changes = append(changes, fmt.Sprintf("%s %s removed", kind, old.Name))
+		}
+	}
+	return changes
+}
+
+// formatResources returns the resources as a sorted name=quantity list (e.g. cpu=100m, memory=128Mi)
+func formatResources(list v1.ResourceList) string {
+	if len(list) == 0 {
+		return "<none>"
+	}
+	formatted := formatResourceList(list, nil)
+	resources := make([]string, 0, len(formatted))
+	for _, name := range slices.Sorted(maps.Keys(formatted)) {
+		resources = append(resources, fmt.Sprintf("%s=%s", name, formatted[name]))
+	}
+	return strings.Join(resources, ", ")
+}
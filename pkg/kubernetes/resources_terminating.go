@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultTerminatingThreshold is the default time after which a resource pending deletion is considered stuck
+const DefaultTerminatingThreshold = 5 * time.Minute
+
+// TerminatingResource is a resource pending deletion (non-nil deletionTimestamp) and the finalizers blocking it
+type TerminatingResource struct {
+	APIVersion        string   `json:"apiVersion"`
+	Kind              string   `json:"kind"`
+	Namespace         string   `json:"namespace,omitempty"`
+	Name              string   `json:"name"`
+	DeletionTimestamp string   `json:"deletionTimestamp"`
+	TerminatingFor    string   `json:"terminatingFor"`
+	Finalizers        []string `json:"finalizers"`
+	// Conditions explain why a Namespace is stuck (e.g. NamespaceContentRemaining, NamespaceFinalizersRemaining)
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// ResourcesTerminating returns the resources that have been pending deletion for longer than the provided threshold
+// (a zero threshold returns all the resources pending deletion).
+// If a namespace is provided, the resources of the provided kinds (see NamespacedScanResources) in that namespace are
+// scanned (resource types that can't be listed, e.g. denied or forbidden, are skipped), otherwise the cluster
+// Namespaces are scanned.
+func (c *Core) ResourcesTerminating(ctx context.Context, namespace string, kinds []string, threshold time.Duration) ([]TerminatingResource, error) {
+	now := time.Now()
+	ret := make([]TerminatingResource, 0)
+	if namespace == "" {
+		namespaces, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces.Items {
+			if terminating := terminatingNamespace(&ns, now, threshold); terminating != nil {
+				ret = append(ret, *terminating)
+			}
+		}
+		return ret, nil
+	}
+	if ns, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err == nil {
+		if terminating := terminatingNamespace(ns, now, threshold); terminating != nil {
+			ret = append(ret, *terminating)
+		}
+	}
+	for _, gvr := range c.NamespacedScanResources(kinds) {
+		list, listErr := c.DynamicClient().Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if listErr != nil {
+			continue
+		}
+		for i := range list.Items {
+			if terminating := terminatingResource(&list.Items[i], now, threshold); terminating != nil {
+				ret = append(ret, *terminating)
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].DeletionTimestamp < ret[j].DeletionTimestamp
+	})
+	return ret, nil
+}
+
+func terminatingResource(obj *unstructured.Unstructured, now time.Time, threshold time.Duration) *TerminatingResource {
+	deletionTimestamp := obj.GetDeletionTimestamp()
+	if deletionTimestamp == nil || now.Sub(deletionTimestamp.Time) < threshold {
+		return nil
+	}
+	return &TerminatingResource{
+		APIVersion:        obj.GetAPIVersion(),
+		Kind:              obj.GetKind(),
+		Namespace:         obj.GetNamespace(),
+		Name:              obj.GetName(),
+		DeletionTimestamp: deletionTimestamp.UTC().Format(time.RFC3339),
+		TerminatingFor:    duration.HumanDuration(now.Sub(deletionTimestamp.Time)),
+		Finalizers:        append([]string{}, obj.GetFinalizers()...),
+	}
+}
+
+func terminatingNamespace(ns *v1.Namespace, now time.Time, threshold time.Duration) *TerminatingResource {
+	if ns.DeletionTimestamp == nil || now.Sub(ns.DeletionTimestamp.Time) < threshold {
+		return nil
+	}
+	terminating := &TerminatingResource{
+		APIVersion:        "v1",
+		Kind:              "Namespace",
+		Name:              ns.Name,
+		DeletionTimestamp: ns.DeletionTimestamp.UTC().Format(time.RFC3339),
+		TerminatingFor:    duration.HumanDuration(now.Sub(ns.DeletionTimestamp.Time)),
+		Finalizers:        append([]string{}, ns.Finalizers...),
+	}
+	// Namespace spec finalizers (e.g. kubernetes) are removed by the namespace controller once the content is deleted
+	for _, finalizer := range ns.Spec.Finalizers {
+		terminating.Finalizers = append(terminating.Finalizers, "spec:"+string(finalizer))
+	}
+	for _, condition := range ns.Status.Conditions {
+		if condition.Status == v1.ConditionTrue {
+			terminating.Conditions = append(terminating.Conditions, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+	return terminating
+}
+
+// ResourceFinalizers are the finalizers of a resource and, if it's pending deletion, for how long
+type ResourceFinalizers struct {
+	APIVersion        string   `json:"apiVersion"`
+	Kind              string   `json:"kind"`
+	Namespace         string   `json:"namespace,omitempty"`
+	Name              string   `json:"name"`
+	DeletionTimestamp string   `json:"deletionTimestamp,omitempty"`
+	TerminatingFor    string   `json:"terminatingFor,omitempty"`
+	Finalizers        []string `json:"finalizers"`
+}
+
+// ResourcesFinalizers returns the finalizers (metadata.finalizers) of the resource and its deletion status
+func (c *Core) ResourcesFinalizers(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string) (*ResourceFinalizers, error) {
+	obj, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ResourceFinalizers{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Finalizers: append([]string{}, obj.GetFinalizers()...),
+	}
+	if deletionTimestamp := obj.GetDeletionTimestamp(); deletionTimestamp != nil {
+		ret.DeletionTimestamp = deletionTimestamp.UTC().Format(time.RFC3339)
+		ret.TerminatingFor = duration.HumanDuration(time.Since(deletionTimestamp.Time))
+	}
+	return ret, nil
+}
+
+// ResourcesRemoveFinalizers removes the provided finalizers (or all of them if none is provided) from the metadata
+// of the resource. The patch includes the observed resourceVersion so that it fails if the resource changed meanwhile.
+// Returns the updated resource and the removed finalizers.
+func (c *Core) ResourcesRemoveFinalizers(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, finalizers []string) (*unstructured.Unstructured, []string, error) {
+	gvr, err := c.resourceFor(gvk)
+	if err != nil {
+		return nil, nil, err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := c.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = c.NamespaceOrDefault(namespace)
+	}
+	ri := c.DynamicClient().Resource(*gvr).Namespace(namespace)
+	var updated *unstructured.Unstructured
+	var removed []string
+	// The finalizers to remove are computed from the current ones, re-read them if the resource was modified meanwhile
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		obj, err := ri.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		remaining := make([]string, 0)
+		removed = make([]string, 0)
+		for _, finalizer := range obj.GetFinalizers() {
+			if len(finalizers) == 0 || slices.Contains(finalizers, finalizer) {
+				removed = append(removed, finalizer)
+			} else {
+				remaining = append(remaining, finalizer)
+			}
+		}
+		if len(removed) == 0 {
+			return fmt.Errorf("%s %s has none of the finalizers to remove (current finalizers: %v)", gvk.Kind, name, obj.GetFinalizers())
+		}
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"finalizers":      remaining,
+				"resourceVersion": obj.GetResourceVersion(),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		updated, err = ri.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, nil, resourceModifiedError(err)
+	}
+	return updated, removed, nil
+}
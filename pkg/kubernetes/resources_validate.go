@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	kubectlopenapi "k8s.io/kubectl/pkg/util/openapi"
+	kubectlvalidation "k8s.io/kubectl/pkg/validation"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// ResourcesValidateResult is the validation report of the resources of a manifest
+type ResourcesValidateResult struct {
+	// Valid is true if every resource passed the schema validation and the server-side dry-run
+	Valid bool
+	// Resources are the validation results of each resource, in manifest order
+	Resources []*ResourceValidation
+}
+
+// ResourceValidation is the validation result of a single resource of a manifest
+type ResourceValidation struct {
+	// Resource identifies the resource (e.g. apps.v1.Deployment.default.a-deployment), or its position in the
+	// manifest if it couldn't be parsed
+	Resource string
+	// Valid is true if the resource has no errors
+	Valid bool
+	// Errors are the problems reported by the client-side checks ("manifest:" and "schema:" prefixes) and by the
+	// API server during the dry-run ("dry-run:" prefix, including the admission webhook denials)
+	Errors []string `json:",omitempty"`
+	// Warnings are the warnings returned by the API server during the dry-run (e.g. deprecated APIs, admission warnings)
+	// and the checks that couldn't be performed
+	Warnings []string `json:",omitempty"`
+}
+
+// ResourcesValidate checks whether the resources of the provided manifest would be accepted by the cluster, without
+// applying them. Each resource is validated in two stages:
+//   - client-side: the document is parsed, its apiVersion, kind and name are checked, and it is validated against the
+//     OpenAPI schema published by the cluster (unknown fields, missing required fields, wrong types)
+//   - server-side: the resource is applied with a server-side apply dry-run (same options as resources_create_or_update)
+//     so that the API server validation, defaulting and admission (webhooks, policies, quotas) run without persisting
+//     anything
+//
+// The server-side stage is skipped for the resources that fail the client-side checks.
+func (c *Core) ResourcesValidate(ctx context.Context, resource, namespace string) (*ResourcesValidateResult, error) {
+	warnings := &warningRecorder{}
+	cfg := rest.CopyConfig(c.RESTConfig())
+	cfg.WarningHandler = warnings
+	httpClient, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	defer httpClient.CloseIdleConnections()
+	dynamicClient, err := dynamic.NewForConfigAndClient(cfg, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	var schemaValidator kubectlvalidation.Schema
+	schemaUnavailable := ""
+	parser := kubectlopenapi.NewOpenAPIParser(c.DiscoveryClient())
+	if _, err = parser.Parse(); err != nil {
+		schemaUnavailable = fmt.Sprintf("schema validation skipped, the OpenAPI schema of the cluster couldn't be retrieved: %v", err)
+	} else {
+		schemaValidator = kubectlvalidation.NewSchemaValidation(&openAPIResourcesAdapter{parser: parser})
+	}
+	ret := &ResourcesValidateResult{Valid: true}
+	documents := manifestSeparator.Split(resource, -1)
+	for i, document := range documents {
+		if strings.TrimSpace(document) == "" && len(documents) > 1 {
+			continue
+		}
+		validation := &ResourceValidation{Resource: fmt.Sprintf("document %d", i+1)}
+		ret.Resources = append(ret.Resources, validation)
+		obj, ok := c.resourceValidateManifest(validation, document, namespace)
+		if ok && schemaValidator != nil {
+			validation.Errors = append(validation.Errors, resourceValidateSchema(schemaValidator, obj)...)
+		} else if ok {
+			validation.Warnings = append(validation.Warnings, schemaUnavailable)
+		}
+		if ok && len(validation.Errors) == 0 {
+			gvk := obj.GroupVersionKind()
+			gvr, _ := c.resourceFor(&gvk)
+			_, applyErr := dynamicClient.Resource(*gvr).Namespace(obj.GetNamespace()).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+				FieldManager: version.BinaryName,
+				Force:        true,
+				DryRun:       []string{metav1.DryRunAll},
+			})
+			if applyErr != nil {
+				validation.Errors = append(validation.Errors, "dry-run: "+applyErr.Error())
+			}
+			validation.Warnings = append(validation.Warnings, warnings.take()...)
+		}
+		validation.Valid = len(validation.Errors) == 0
+		ret.Valid = ret.Valid && validation.Valid
+	}
+	return ret, nil
+}
+
+// resourceValidateManifest parses the document and checks the fields required to apply it (apiVersion and kind served
+// by the cluster, name), the namespace is defaulted for namespaced resources.
+// Returns false (and records the errors) if the resource can't be validated any further.
+func (c *Core) resourceValidateManifest(validation *ResourceValidation, document, namespace string) (*unstructured.Unstructured, bool) {
+	var object map[string]interface{}
+	if err := yaml.NewYAMLToJSONDecoder(strings.NewReader(document)).Decode(&object); err != nil {
+		validation.Errors = append(validation.Errors, "manifest: failed to parse the document: "+err.Error())
+		return nil, false
+	}
+	if len(object) == 0 {
+		validation.Errors = append(validation.Errors, "manifest: the document is empty")
+		return nil, false
+	}
+	// the status is ignored by resources_create_or_update
+	delete(object, "status")
+	obj := &unstructured.Unstructured{Object: object}
+	if obj.GetAPIVersion() == "" {
+		validation.Errors = append(validation.Errors, "manifest: apiVersion is required")
+	}
+	if obj.GetKind() == "" {
+		validation.Errors = append(validation.Errors, "manifest: kind is required")
+	}
+	if obj.GetName() == "" {
+		validation.Errors = append(validation.Errors, "manifest: metadata.name is required (metadata.generateName is not supported)")
+	}
+	if len(validation.Errors) > 0 {
+		return nil, false
+	}
+	gvk := obj.GroupVersionKind()
+	if _, err := c.resourceFor(&gvk); err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("manifest: %s %s is not served by the cluster: %v", gvk.GroupVersion(), gvk.Kind, err))
+		return nil, false
+	}
+	if namespaced, err := c.isNamespaced(&gvk); err == nil && namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace(c.NamespaceOrDefault(namespace))
+	}
+	validation.Resource = diffName(obj)
+	return obj, true
+}
+
+// resourceValidateSchema validates the resource against the OpenAPI schema, returning one error per problem
+func resourceValidateSchema(validator kubectlvalidation.Schema, obj *unstructured.Unstructured) []string {
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return []string{"schema: " + err.Error()}
+	}
+	err = validator.ValidateBytes(data)
+	if err == nil {
+		return nil
+	}
+	var errs []error
+	var agg utilerrors.Aggregate
+	if errors.As(err, &agg) {
+		errs = agg.Errors()
+	} else {
+		errs = []error{err}
+	}
+	ret := make([]string, 0, len(errs))
+	for _, e := range errs {
+		ret = append(ret, "schema: "+e.Error())
+	}
+	return ret
+}
+
+// warningRecorder is a rest.WarningHandler that collects the warnings returned by the API server
+type warningRecorder struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+var _ rest.WarningHandler = (*warningRecorder)(nil)
+
+func (w *warningRecorder) HandleWarningHeader(code int, _ string, message string) {
+	if code != 299 || message == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.warnings = append(w.warnings, message)
+}
+
+// take returns the warnings recorded since the last call
+func (w *warningRecorder) take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ret := w.warnings
+	w.warnings = nil
+	return ret
+}
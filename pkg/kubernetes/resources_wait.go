@@ -0,0 +1,242 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// DefaultWaitTimeout is the default time to wait for a resource condition (same as kubectl wait)
+const DefaultWaitTimeout = 30 * time.Second
+
+// WaitFor is a parsed kubectl wait --for expression
+type WaitFor struct {
+	// Delete waits for the resource to be deleted
+	Delete bool
+	// Condition is the status condition type to wait for (e.g. Available, Ready)
+	Condition string
+	// JSONPath expression to evaluate (e.g. {.status.phase})
+	JSONPath string
+	// Value is the expected condition status (True by default) or JSONPath value (any value if empty)
+	Value string
+}
+
+// ParseWaitFor parses a kubectl wait --for compatible expression:
+// delete, condition=<type>[=<status>], or jsonpath=<expression>[=<value>]
+func ParseWaitFor(expression string) (*WaitFor, error) {
+	switch {
+	case strings.EqualFold(expression, "delete"):
+		return &WaitFor{Delete: true}, nil
+	case strings.HasPrefix(expression, "condition="):
+		condition, value, found := strings.Cut(strings.TrimPrefix(expression, "condition="), "=")
+		if condition == "" {
+			return nil, fmt.Errorf("invalid wait expression %s: condition type is required", expression)
+		}
+		if !found {
+			value = string(metav1.ConditionTrue)
+		}
+		return &WaitFor{Condition: condition, Value: value}, nil
+	case strings.HasPrefix(expression, "jsonpath="):
+		path, value := strings.TrimPrefix(expression, "jsonpath="), ""
+		if strings.HasPrefix(path, "{") {
+			if end := strings.Index(path, "}"); end > 0 {
+				path, value = path[:end+1], strings.TrimPrefix(path[end+1:], "=")
+			}
+		} else {
+			path, value, _ = strings.Cut(path, "=")
+			path = "{" + path + "}"
+		}
+		if err := jsonpath.New("wait").Parse(path); err != nil || path == "{}" {
+			return nil, fmt.Errorf("invalid wait expression %s: invalid jsonpath %s", expression, path)
+		}
+		return &WaitFor{JSONPath: path, Value: value}, nil
+	default:
+		return nil, fmt.Errorf("invalid wait expression %s: expected delete, condition=<type>[=<status>] or jsonpath=<expression>[=<value>]", expression)
+	}
+}
+
+// ResourcesWait blocks until the resource satisfies the provided wait condition or the timeout expires.
+// If a progress function is provided, every observed change of the resource is reported (e.g. 3/5 replicas ready).
+// Returns the last observed state of the resource (nil when waiting for deletion).
+func (c *Core) ResourcesWait(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, waitFor *WaitFor, timeout time.Duration, progress api.ProgressFunc) (*unstructured.Unstructured, error) {
+	gvr, err := c.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := c.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = c.NamespaceOrDefault(namespace)
+	}
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+	ri := c.DynamicClient().Resource(*gvr).Namespace(namespace)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	deadline := time.Now().Add(timeout)
+	lw := &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			// The API server closes the watch once the wait times out, even if the client-side cancellation is lost
+			options.TimeoutSeconds = ptr.To(max(int64(math.Ceil(time.Until(deadline).Seconds())), 1))
+			return ri.Watch(ctx, options)
+		},
+	}
+	waitCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	list, err := ri.List(waitCtx, metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	var last *unstructured.Unstructured
+	if len(list.Items) > 0 {
+		last = &list.Items[0]
+	}
+	switch {
+	case waitFor.Delete && last == nil:
+		// Already deleted (or never existed) resources satisfy the condition
+		return nil, nil
+	case !waitFor.Delete && last == nil:
+		// Fail fast instead of waiting for the timeout if the resource doesn't exist
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	case !waitFor.Delete && waitFor.isSatisfiedBy(last):
+		return last, nil
+	}
+	start := time.Now()
+	reportProgress := func(obj *unstructured.Unstructured) {
+		if progress != nil {
+			progress(time.Since(start).Seconds(), timeout.Seconds(), waitProgressMessage(gvk.Kind, name, obj, waitFor))
+		}
+	}
+	reportProgress(last)
+	release, err := c.acquireWatch()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	watcher, err := watchtools.NewRetryWatcherWithContext(waitCtx, list.GetResourceVersion(), lw)
+	if err != nil {
+		return nil, err
+	}
+	_, err = watchtools.UntilWithoutRetry(waitCtx, watcher, func(event watch.Event) (bool, error) {
+		if u, ok := event.Object.(*unstructured.Unstructured); ok {
+			last = u
+		}
+		switch {
+		case event.Type == watch.Deleted && waitFor.Delete:
+			return true, nil
+		case event.Type == watch.Deleted:
+			return false, fmt.Errorf("%s %s was deleted while waiting", gvk.Kind, name)
+		case event.Type == watch.Error:
+			return false, apierrors.FromObject(event.Object)
+		case waitFor.Delete:
+			return false, nil
+		}
+		reportProgress(last)
+		return waitFor.isSatisfiedBy(last), nil
+	})
+	if err != nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+		return last, fmt.Errorf("timed out after %s waiting for %s %s", timeout, gvk.Kind, name)
+	} else if err != nil {
+		return last, err
+	}
+	if waitFor.Delete {
+		return nil, nil
+	}
+	return last, nil
+}
+
+// waitProgressMessage describes the observed state of the resource being waited for
+func waitProgressMessage(kind, name string, obj *unstructured.Unstructured, waitFor *WaitFor) string {
+	prefix := fmt.Sprintf("Waiting for %s %s", kind, name)
+	switch {
+	case waitFor.Delete:
+		return prefix + " to be deleted"
+	case obj == nil:
+		return prefix
+	}
+	if ready, total, found := readyReplicas(obj); found {
+		return fmt.Sprintf("%s: %d/%d ready", prefix, ready, total)
+	}
+	if waitFor.Condition != "" {
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			if condition, ok := c.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(condition["type"]), waitFor.Condition) {
+				return fmt.Sprintf("%s: condition %v is %v", prefix, condition["type"], condition["status"])
+			}
+		}
+		return fmt.Sprintf("%s: condition %s not reported yet", prefix, waitFor.Condition)
+	}
+	return prefix
+}
+
+// readyReplicas returns the number of ready and desired replicas (or containers for Pods) of the resource
+func readyReplicas(obj *unstructured.Unstructured) (ready, total int64, found bool) {
+	switch obj.GetKind() {
+	case "Pod":
+		containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "containers")
+		statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+		for _, s := range statuses {
+			if status, ok := s.(map[string]interface{}); ok && status["ready"] == true {
+				ready++
+			}
+		}
+		return ready, int64(len(containers)), len(containers) > 0
+	case "DaemonSet":
+		ready, _, _ = unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		total, found, _ = unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		return ready, total, found
+	}
+	ready, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	if total, found, _ = unstructured.NestedInt64(obj.Object, "spec", "replicas"); !found {
+		total, found, _ = unstructured.NestedInt64(obj.Object, "status", "replicas")
+	}
+	return ready, total, found
+}
+
+// isSatisfiedBy evaluates the wait condition (other than delete) against the provided object
+func (w *WaitFor) isSatisfiedBy(obj *unstructured.Unstructured) bool {
+	if w.Condition != "" {
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || !strings.EqualFold(fmt.Sprint(condition["type"]), w.Condition) {
+				continue
+			}
+			// Conditions observed for a previous generation are stale
+			if observedGeneration, found, _ := unstructured.NestedInt64(condition, "observedGeneration"); found && observedGeneration < obj.GetGeneration() {
+				return false
+			}
+			return strings.EqualFold(fmt.Sprint(condition["status"]), w.Value)
+		}
+		return false
+	}
+	path := jsonpath.New("wait").AllowMissingKeys(true)
+	if err := path.Parse(w.JSONPath); err != nil {
+		return false
+	}
+	results, err := path.FindResults(obj.Object)
+	if err != nil || len(results) != 1 || len(results[0]) != 1 {
+		return false
+	}
+	if w.Value == "" {
+		return true
+	}
+	return fmt.Sprint(results[0][0].Interface()) == w.Value
+}
@@ -0,0 +1,132 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type ResourcesWaitSuite struct {
+	suite.Suite
+}
+
+func (s *ResourcesWaitSuite) TestParseWaitFor() {
+	s.Run("delete", func() {
+		waitFor, err := ParseWaitFor("delete")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{Delete: true}, waitFor)
+	})
+	s.Run("condition defaults to True", func() {
+		waitFor, err := ParseWaitFor("condition=Available")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{Condition: "Available", Value: "True"}, waitFor)
+	})
+	s.Run("condition with status", func() {
+		waitFor, err := ParseWaitFor("condition=Ready=False")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{Condition: "Ready", Value: "False"}, waitFor)
+	})
+	s.Run("jsonpath with braces", func() {
+		waitFor, err := ParseWaitFor("jsonpath={.status.phase}=Running")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{JSONPath: "{.status.phase}", Value: "Running"}, waitFor)
+	})
+	s.Run("jsonpath without braces", func() {
+		waitFor, err := ParseWaitFor("jsonpath=.status.phase=Running")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{JSONPath: "{.status.phase}", Value: "Running"}, waitFor)
+	})
+	s.Run("jsonpath without value", func() {
+		waitFor, err := ParseWaitFor("jsonpath={.status.loadBalancer.ingress}")
+		s.Require().NoError(err)
+		s.Equal(&WaitFor{JSONPath: "{.status.loadBalancer.ingress}"}, waitFor)
+	})
+	s.Run("invalid expressions return error", func() {
+		for _, expression := range []string{"", "ready", "condition=", "jsonpath=", "jsonpath={.status[}"} {
+			_, err := ParseWaitFor(expression)
+			s.Errorf(err, "expected error for %q", expression)
+		}
+	})
+}
+
+func (s *ResourcesWaitSuite) TestIsSatisfiedBy() {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "a-resource", "generation": int64(2)},
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True", "observedGeneration": int64(2)},
+				map[string]interface{}{"type": "Progressing", "status": "True", "observedGeneration": int64(1)},
+			},
+		},
+	}}
+	s.Run("matching condition", func() {
+		s.True((&WaitFor{Condition: "available", Value: "True"}).isSatisfiedBy(obj))
+	})
+	s.Run("condition with different status", func() {
+		s.False((&WaitFor{Condition: "Available", Value: "False"}).isSatisfiedBy(obj))
+	})
+	s.Run("stale condition from a previous generation", func() {
+		s.False((&WaitFor{Condition: "Progressing", Value: "True"}).isSatisfiedBy(obj))
+	})
+	s.Run("missing condition", func() {
+		s.False((&WaitFor{Condition: "Ready", Value: "True"}).isSatisfiedBy(obj))
+	})
+	s.Run("matching jsonpath value", func() {
+		s.True((&WaitFor{JSONPath: "{.status.phase}", Value: "Running"}).isSatisfiedBy(obj))
+	})
+	s.Run("different jsonpath value", func() {
+		s.False((&WaitFor{JSONPath: "{.status.phase}", Value: "Pending"}).isSatisfiedBy(obj))
+	})
+	s.Run("existing jsonpath without value", func() {
+		s.True((&WaitFor{JSONPath: "{.status.phase}"}).isSatisfiedBy(obj))
+	})
+	s.Run("missing jsonpath", func() {
+		s.False((&WaitFor{JSONPath: "{.status.podIP}"}).isSatisfiedBy(obj))
+	})
+}
+
+func (s *ResourcesWaitSuite) TestWaitProgressMessage() {
+	s.Run("reports ready replicas", func() {
+		deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":   "Deployment",
+			"spec":   map[string]interface{}{"replicas": int64(5)},
+			"status": map[string]interface{}{"readyReplicas": int64(3)},
+		}}
+		s.Equal("Waiting for Deployment web: 3/5 ready", waitProgressMessage("Deployment", "web", deployment, &WaitFor{Condition: "Available", Value: "True"}))
+	})
+	s.Run("reports ready DaemonSet pods", func() {
+		daemonSet := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":   "DaemonSet",
+			"status": map[string]interface{}{"numberReady": int64(1), "desiredNumberScheduled": int64(2)},
+		}}
+		s.Equal("Waiting for DaemonSet agent: 1/2 ready", waitProgressMessage("DaemonSet", "agent", daemonSet, &WaitFor{Condition: "Ready", Value: "True"}))
+	})
+	s.Run("reports ready Pod containers", func() {
+		pod := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind": "Pod",
+			"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}}},
+			"status": map[string]interface{}{"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "a", "ready": true},
+				map[string]interface{}{"name": "b", "ready": false},
+			}},
+		}}
+		s.Equal("Waiting for Pod app: 1/2 ready", waitProgressMessage("Pod", "app", pod, &WaitFor{Condition: "Ready", Value: "True"}))
+	})
+	s.Run("reports condition status", func() {
+		job := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":   "Job",
+			"status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Complete", "status": "False"}}},
+		}}
+		s.Equal("Waiting for Job migrate: condition Complete is False", waitProgressMessage("Job", "migrate", job, &WaitFor{Condition: "complete", Value: "True"}))
+		s.Equal("Waiting for Job migrate: condition Failed not reported yet", waitProgressMessage("Job", "migrate", job, &WaitFor{Condition: "Failed", Value: "True"}))
+	})
+	s.Run("reports deletion", func() {
+		s.Equal("Waiting for Pod app to be deleted", waitProgressMessage("Pod", "app", nil, &WaitFor{Delete: true}))
+	})
+}
+
+func TestResourcesWait(t *testing.T) {
+	suite.Run(t, new(ResourcesWaitSuite))
+}
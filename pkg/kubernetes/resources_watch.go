@@ -0,0 +1,86 @@
+package kubernetes
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/utils/ptr"
+)
+
+// watchTimeoutSeconds is the server-side timeout of every watch request of ResourcesWatch
+var watchTimeoutSeconds int64 = 300
+
+// ResourcesWatch watches the resource and calls onEvent every time it's added, modified or deleted, until the
+// context is cancelled (a nil error is returned then).
+// The watch is resumed after transient failures. If the watched resourceVersion expires, the resource is listed
+// again and onEvent is called with watch.Modified since changes might have been missed.
+// The watch runs in the background for as long as the context lives, callers must hold a watch slot (see
+// Kubernetes.AcquireWatch) until it returns.
+func (c *Core) ResourcesWatch(ctx context.Context, gvk *schema.GroupVersionKind, namespace, name string, onEvent func(watch.EventType)) error {
+	gvr, err := c.resourceFor(gvk)
+	if err != nil {
+		return err
+	}
+	// If it's a namespaced resource and namespace wasn't provided, try to use the default configured one
+	if namespaced, nsErr := c.isNamespaced(gvk); nsErr == nil && namespaced {
+		namespace = c.NamespaceOrDefault(namespace)
+	}
+	ri := c.DynamicClient().Resource(*gvr).Namespace(namespace)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			// Bound every watch request server-side so that connections are recycled (resumed by the RetryWatcher)
+			// instead of being held open indefinitely
+			options.TimeoutSeconds = ptr.To(watchTimeoutSeconds)
+			return ri.Watch(ctx, options)
+		},
+	}
+	for resumed := false; ; resumed = true {
+		list, err := ri.List(ctx, metav1.ListOptions{FieldSelector: fieldSelector})
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if resumed {
+			onEvent(watch.Modified)
+		}
+		watcher, err := watchtools.NewRetryWatcherWithContext(ctx, list.GetResourceVersion(), lw)
+		if err != nil {
+			return err
+		}
+		err = watchEvents(ctx, watcher, onEvent)
+		watcher.Stop()
+		if ctx.Err() != nil {
+			return nil
+		} else if !apierrors.IsResourceExpired(err) && !apierrors.IsGone(err) {
+			return err
+		}
+	}
+}
+
+// watchEvents calls onEvent for every change reported by the watcher until the context is cancelled or the watcher fails
+func watchEvents(ctx context.Context, watcher watch.Interface, onEvent func(watch.EventType)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			switch {
+			case !ok:
+				return nil
+			case event.Type == watch.Error:
+				return apierrors.FromObject(event.Object)
+			case event.Type != watch.Bookmark:
+				onEvent(event.Type)
+			}
+		}
+	}
+}
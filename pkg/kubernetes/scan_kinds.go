@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// DefaultScanKinds are the kinds inspected by the operations that scan all the resources of a namespace
+// when scan_kinds is not configured
+var DefaultScanKinds = []string{
+	"Pod",
+	"Service",
+	"ConfigMap",
+	"Secret",
+	"PersistentVolumeClaim",
+	"ServiceAccount",
+	"Deployment.apps",
+	"StatefulSet.apps",
+	"DaemonSet.apps",
+	"ReplicaSet.apps",
+	"Job.batch",
+	"CronJob.batch",
+	"Ingress.networking.k8s.io",
+	"NetworkPolicy.networking.k8s.io",
+	"HorizontalPodAutoscaler.autoscaling",
+	"PodDisruptionBudget.policy",
+	"Role.rbac.authorization.k8s.io",
+	"RoleBinding.rbac.authorization.k8s.io",
+}
+
+// NamespacedScanResources resolves the provided kinds (Kind or Kind.group, see api.ScanKindsProvider) to the
+// namespaced resources that can be listed in the cluster.
+// DefaultScanKinds are used if no kinds are provided, api.ScanKindsAll resolves every namespaced resource served by
+// the cluster. Kinds that aren't served by the cluster (e.g. CRDs not installed) or aren't namespaced are skipped.
+func (c *Core) NamespacedScanResources(kinds []string) []schema.GroupVersionResource {
+	if len(kinds) == 0 {
+		kinds = DefaultScanKinds
+	}
+	var ret []schema.GroupVersionResource
+	if slices.Contains(kinds, api.ScanKindsAll) {
+		// Partial discovery failures (e.g. an unavailable aggregated API) are ignored, the rest of the resources are returned
+		resourceLists, _ := c.DiscoveryClient().ServerPreferredNamespacedResources()
+		for _, resourceList := range resourceLists {
+			gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+			if err != nil {
+				continue
+			}
+			for _, resource := range resourceList.APIResources {
+				if slices.Contains(resource.Verbs, "list") {
+					ret = append(ret, gv.WithResource(resource.Name))
+				}
+			}
+		}
+		return ret
+	}
+	for _, kind := range kinds {
+		gk := schema.ParseGroupKind(kind)
+		gvk, err := c.RESTMapper().KindFor(schema.GroupVersionResource{Group: gk.Group, Resource: strings.ToLower(gk.Kind)})
+		if err != nil {
+			continue
+		}
+		mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil || mapping.Scope.Name() != meta.RESTScopeNameNamespace || slices.Contains(ret, mapping.Resource) {
+			continue
+		}
+		ret = append(ret, mapping.Resource)
+	}
+	return ret
+}
@@ -0,0 +1,181 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
+)
+
+// DecodedSecret is a readable representation of a Secret with its data values decoded.
+// Values that aren't valid UTF-8 text are kept base64 encoded in BinaryData.
+type DecodedSecret struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Type       v1.SecretType     `json:"type"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// SecretsGetDecoded retrieves a Secret and returns its data values decoded.
+// Every access is logged since the returned values are sensitive.
+func (c *Core) SecretsGetDecoded(ctx context.Context, namespace, name string) (*DecodedSecret, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	decoded := &DecodedSecret{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Type:      secret.Type,
+	}
+	keys := make([]string, 0, len(secret.Data))
+	for key, value := range secret.Data {
+		keys = append(keys, key)
+		if utf8.Valid(value) {
+			if decoded.Data == nil {
+				decoded.Data = map[string]string{}
+			}
+			decoded.Data[key] = string(value)
+		} else {
+			if decoded.BinaryData == nil {
+				decoded.BinaryData = map[string][]byte{}
+			}
+			decoded.BinaryData[key] = value
+		}
+	}
+	sort.Strings(keys)
+	klogutil.LogInfo(klog.FromContext(ctx), "Secret contents decoded",
+		klogutil.Field("kubernetes.namespace.name", secret.Namespace),
+		klogutil.Field("kubernetes.secret.name", secret.Name),
+		klogutil.Field("kubernetes.secret.keys", keys),
+	)
+	return decoded, nil
+}
+
+// DefaultCertificateExpiryWindow is the default window in which certificates are flagged as expiring soon
+const DefaultCertificateExpiryWindow = 30 * 24 * time.Hour
+
+// TLSCertificate is a readable representation of a decoded x509 certificate (never includes private keys)
+type TLSCertificate struct {
+	// Key is the Secret data key the certificate was read from (e.g. tls.crt)
+	Key       string   `json:"key"`
+	Subject   string   `json:"subject"`
+	Issuer    string   `json:"issuer"`
+	SANs      []string `json:"sans,omitempty"`
+	IsCA      bool     `json:"isCA,omitempty"`
+	NotBefore string   `json:"notBefore"`
+	NotAfter  string   `json:"notAfter"`
+	// Warning is set for expired, not yet valid, or soon to expire certificates
+	Warning string `json:"warning,omitempty"`
+}
+
+// TLSSecretCertificates contains the certificates decoded from a kubernetes.io/tls Secret
+type TLSSecretCertificates struct {
+	Name         string           `json:"name"`
+	Namespace    string           `json:"namespace"`
+	Certificates []TLSCertificate `json:"certificates"`
+}
+
+// SecretsTLSCertificates retrieves a kubernetes.io/tls Secret and decodes the certificate chain in tls.crt
+// (and ca.crt if present), flagging the certificates that expire within the provided window.
+// Every access is logged since Secrets are sensitive.
+func (c *Core) SecretsTLSCertificates(ctx context.Context, namespace, name string, expiryWindow time.Duration) (*TLSSecretCertificates, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Type != v1.SecretTypeTLS {
+		return nil, fmt.Errorf("secret %s is of type %s, expected %s", secret.Name, secret.Type, v1.SecretTypeTLS)
+	}
+	if expiryWindow <= 0 {
+		expiryWindow = DefaultCertificateExpiryWindow
+	}
+	ret := &TLSSecretCertificates{Name: secret.Name, Namespace: secret.Namespace, Certificates: make([]TLSCertificate, 0)}
+	now := time.Now()
+	for _, key := range []string{v1.TLSCertKey, v1.ServiceAccountRootCAKey} {
+		data, found := secret.Data[key]
+		if !found || len(data) == 0 {
+			continue
+		}
+		certificates, parseErr := parseCertificates(data)
+		if parseErr != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", key, parseErr)
+		}
+		for _, certificate := range certificates {
+			ret.Certificates = append(ret.Certificates, tlsCertificate(key, certificate, now, expiryWindow))
+		}
+	}
+	if len(ret.Certificates) == 0 {
+		return nil, fmt.Errorf("secret %s has no certificates in %s", secret.Name, v1.TLSCertKey)
+	}
+	klogutil.LogInfo(klog.FromContext(ctx), "Secret certificates decoded",
+		klogutil.Field("kubernetes.namespace.name", secret.Namespace),
+		klogutil.Field("kubernetes.secret.name", secret.Name),
+	)
+	return ret, nil
+}
+
+// parseCertificates decodes all the PEM encoded certificates in data (other PEM blocks, e.g. keys, are ignored)
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificates found")
+	}
+	return certificates, nil
+}
+
+func tlsCertificate(key string, certificate *x509.Certificate, now time.Time, expiryWindow time.Duration) TLSCertificate {
+	ret := TLSCertificate{
+		Key:       key,
+		Subject:   certificate.Subject.String(),
+		Issuer:    certificate.Issuer.String(),
+		SANs:      append([]string{}, certificate.DNSNames...),
+		IsCA:      certificate.IsCA,
+		NotBefore: certificate.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:  certificate.NotAfter.UTC().Format(time.RFC3339),
+	}
+	for _, ip := range certificate.IPAddresses {
+		ret.SANs = append(ret.SANs, ip.String())
+	}
+	ret.SANs = append(ret.SANs, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		ret.SANs = append(ret.SANs, uri.String())
+	}
+	switch {
+	case now.After(certificate.NotAfter):
+		ret.Warning = fmt.Sprintf("EXPIRED %s ago", duration.HumanDuration(now.Sub(certificate.NotAfter)))
+	case now.Before(certificate.NotBefore):
+		ret.Warning = fmt.Sprintf("NOT YET VALID, valid in %s", duration.HumanDuration(certificate.NotBefore.Sub(now)))
+	case certificate.NotAfter.Sub(now) < expiryWindow:
+		ret.Warning = fmt.Sprintf("EXPIRING SOON, expires in %s", duration.HumanDuration(certificate.NotAfter.Sub(now)))
+	}
+	return ret
+}
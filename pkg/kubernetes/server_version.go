@@ -0,0 +1,23 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// ServerVersion retrieves the version of the Kubernetes API server.
+// Unlike discovery.ServerVersion, the request is bound to the provided context so that it's aborted when cancelled.
+func (c *Core) ServerVersion(ctx context.Context) (*version.Info, error) {
+	body, err := c.DiscoveryClient().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var info version.Info
+	if err = json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("unable to parse the server version: %w", err)
+	}
+	return &info, nil
+}
@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// ServiceEndpoints returns the selector, ports and backing EndpointSlices (discovery.k8s.io/v1) of a Service
+// with its ready and not-ready endpoints.
+// A Warning entry is included for the most common causes of a Service not routing traffic (e.g. zero ready endpoints).
+func (c *Core) ServiceEndpoints(ctx context.Context, namespace, name string) (map[string]any, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	service, err := c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	endpointSliceList, err := c.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{discoveryv1.LabelServiceName: name}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]map[string]any, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		p := map[string]any{
+			"Name":       port.Name,
+			"Port":       port.Port,
+			"TargetPort": port.TargetPort.String(),
+			"Protocol":   string(port.Protocol),
+		}
+		if port.NodePort != 0 {
+			p["NodePort"] = port.NodePort
+		}
+		ports = append(ports, p)
+	}
+	ready, notReady := 0, 0
+	endpointSlices := make([]map[string]any, 0, len(endpointSliceList.Items))
+	for _, slice := range endpointSliceList.Items {
+		slicePorts := make([]string, 0, len(slice.Ports))
+		for _, port := range slice.Ports {
+			if port.Port == nil {
+				continue
+			}
+			slicePort := fmt.Sprintf("%d/%s", *port.Port, ptr.Deref(port.Protocol, v1.ProtocolTCP))
+			if name := ptr.Deref(port.Name, ""); name != "" {
+				slicePort = name + " " + slicePort
+			}
+			slicePorts = append(slicePorts, slicePort)
+		}
+		readyEndpoints, notReadyEndpoints := make([]map[string]any, 0), make([]map[string]any, 0)
+		for _, endpoint := range slice.Endpoints {
+			e := map[string]any{"Addresses": endpoint.Addresses}
+			if endpoint.TargetRef != nil {
+				e["TargetRef"] = endpoint.TargetRef.Kind + "/" + endpoint.TargetRef.Name
+			}
+			if endpoint.NodeName != nil {
+				e["NodeName"] = *endpoint.NodeName
+			}
+			if endpointReady(&endpoint) {
+				readyEndpoints = append(readyEndpoints, e)
+				ready++
+			} else {
+				if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+					e["Terminating"] = true
+				}
+				notReadyEndpoints = append(notReadyEndpoints, e)
+				notReady++
+			}
+		}
+		endpointSlices = append(endpointSlices, map[string]any{
+			"Name":        slice.Name,
+			"AddressType": string(slice.AddressType),
+			"Ports":       slicePorts,
+			"Ready":       readyEndpoints,
+			"NotReady":    notReadyEndpoints,
+		})
+	}
+	ret := map[string]any{
+		"Name":              service.Name,
+		"Namespace":         service.Namespace,
+		"Type":              string(service.Spec.Type),
+		"ClusterIP":         service.Spec.ClusterIP,
+		"Selector":          service.Spec.Selector,
+		"Ports":             ports,
+		"EndpointSlices":    endpointSlices,
+		"ReadyEndpoints":    ready,
+		"NotReadyEndpoints": notReady,
+	}
+	if warning := serviceEndpointsWarning(service, ready, notReady); warning != "" {
+		ret["Warning"] = warning
+	}
+	return ret, nil
+}
+
+// endpointReady checks the endpoint ready condition, a nil ready condition must be interpreted as ready
+func endpointReady(endpoint *discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+func serviceEndpointsWarning(service *v1.Service, ready, notReady int) string {
+	switch {
+	case service.Spec.Type == v1.ServiceTypeExternalName:
+		return ""
+	case ready > 0:
+		return ""
+	case len(service.Spec.Selector) == 0:
+		return "Service has no selector and no ready endpoints, its EndpointSlices must be managed manually"
+	case notReady > 0:
+		return "Service has zero ready endpoints (all the selected Pods are not ready), requests to it will fail (e.g. 503 errors)"
+	default:
+		return "Service has zero endpoints, no Pods match its selector, requests to it will fail (e.g. 503 errors)"
+	}
+}
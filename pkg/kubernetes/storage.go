@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/ptr"
+)
+
+// storageClassDefaultAnnotation marks the StorageClass used by the PersistentVolumeClaims without a storageClassName
+const storageClassDefaultAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// StorageOverview returns the StorageClasses of the cluster (provisioner, default flag, allowVolumeExpansion,
+// reclaimPolicy and volumeBindingMode) and the PersistentVolumeClaims in the provided namespace (all namespaces if
+// empty) with their binding status.
+// Claims that are not Bound are flagged with an Issue entry explaining the likely cause and, if any, the message of
+// their latest Warning event (e.g. ProvisioningFailed).
+func (c *Core) StorageOverview(ctx context.Context, namespace string) (storageClasses, claims []map[string]any, err error) {
+	storageClassList, err := c.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	claimList, err := c.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+	storageClasses = make([]map[string]any, 0, len(storageClassList.Items))
+	for _, storageClass := range storageClassList.Items {
+		storageClasses = append(storageClasses, map[string]any{
+			"Name":                 storageClass.Name,
+			"Provisioner":          storageClass.Provisioner,
+			"Default":              storageClassDefault(&storageClass),
+			"AllowVolumeExpansion": ptr.Deref(storageClass.AllowVolumeExpansion, false),
+			"ReclaimPolicy":        string(ptr.Deref(storageClass.ReclaimPolicy, v1.PersistentVolumeReclaimDelete)),
+			"VolumeBindingMode":    string(ptr.Deref(storageClass.VolumeBindingMode, storagev1.VolumeBindingImmediate)),
+		})
+	}
+	// non-nil even if there are no StorageClasses, so that the missing default StorageClass is diagnosed
+	classes := append([]storagev1.StorageClass{}, storageClassList.Items...)
+	claims = make([]map[string]any, 0, len(claimList.Items))
+	for _, claim := range claimList.Items {
+		entry := map[string]any{
+			"Namespace":    claim.Namespace,
+			"Name":         claim.Name,
+			"Status":       string(claim.Status.Phase),
+			"StorageClass": ptr.Deref(claim.Spec.StorageClassName, ""),
+			"AccessModes":  claim.Spec.AccessModes,
+		}
+		if claim.Spec.VolumeName != "" {
+			entry["Volume"] = claim.Spec.VolumeName
+		}
+		if capacity, ok := claim.Status.Capacity[v1.ResourceStorage]; ok {
+			entry["Capacity"] = capacity.String()
+		} else if requested, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			entry["Requested"] = requested.String()
+		}
+		if issue := PersistentVolumeClaimIssue(&claim, classes); issue != "" {
+			entry["Issue"] = issue
+			if warning := c.persistentVolumeClaimLastWarning(ctx, &claim); warning != "" {
+				entry["LastWarning"] = warning
+			}
+		}
+		claims = append(claims, entry)
+	}
+	return storageClasses, claims, nil
+}
+
+// PersistentVolumeClaimIssue returns the likely cause of the PersistentVolumeClaim not being Bound, empty if it is.
+// The StorageClasses of the cluster are used to explain why a Pending claim isn't provisioned, the cause is generic if
+// they are nil (e.g. they can't be listed).
+func PersistentVolumeClaimIssue(claim *v1.PersistentVolumeClaim, storageClasses []storagev1.StorageClass) string {
+	switch claim.Status.Phase {
+	case v1.ClaimBound:
+		return ""
+	case v1.ClaimLost:
+		return fmt.Sprintf("PVC lost, the bound PersistentVolume %s no longer exists", claim.Spec.VolumeName)
+	}
+	if storageClasses == nil {
+		return "PVC not bound"
+	}
+	var storageClass *storagev1.StorageClass
+	switch {
+	case claim.Spec.StorageClassName == nil:
+		index := slices.IndexFunc(storageClasses, func(sc storagev1.StorageClass) bool { return storageClassDefault(&sc) })
+		if index < 0 {
+			return "PVC not bound, it has no storageClassName and there is no default StorageClass, set a storageClassName " +
+				"or mark a StorageClass as default (" + storageClassDefaultAnnotation + " annotation)"
+		}
+		storageClass = &storageClasses[index]
+	case *claim.Spec.StorageClassName == "":
+		return "PVC not bound, its storageClassName is empty so it can only bind to an existing PersistentVolume without " +
+			"a class, none matches its requested size and access modes"
+	default:
+		index := slices.IndexFunc(storageClasses, func(sc storagev1.StorageClass) bool { return sc.Name == *claim.Spec.StorageClassName })
+		if index < 0 {
+			return fmt.Sprintf("PVC not bound, StorageClass %s not found", *claim.Spec.StorageClassName)
+		}
+		storageClass = &storageClasses[index]
+	}
+	if ptr.Deref(storageClass.VolumeBindingMode, storagev1.VolumeBindingImmediate) == storagev1.VolumeBindingWaitForFirstConsumer {
+		return fmt.Sprintf("PVC not bound, StorageClass %s uses volumeBindingMode WaitForFirstConsumer, the volume is "+
+			"provisioned once a Pod using the claim is scheduled", storageClass.Name)
+	}
+	return fmt.Sprintf("PVC not bound, the provisioner %s of StorageClass %s hasn't provisioned the volume, check that "+
+		"it's running and the events of the claim", storageClass.Provisioner, storageClass.Name)
+}
+
+// storageClassDefault returns true if the StorageClass is marked as the default one
+func storageClassDefault(storageClass *storagev1.StorageClass) bool {
+	return storageClass.Annotations[storageClassDefaultAnnotation] == "true" ||
+		storageClass.Annotations["storageclass.beta.kubernetes.io/is-default-class"] == "true"
+}
+
+// persistentVolumeClaimLastWarning returns the message of the latest Warning event of the claim, empty if there is
+// none (or the events can't be listed)
+func (c *Core) persistentVolumeClaimLastWarning(ctx context.Context, claim *v1.PersistentVolumeClaim) string {
+	events, err := c.CoreV1().Events(claim.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "PersistentVolumeClaim",
+			"involvedObject.name": claim.Name,
+			"type":                v1.EventTypeWarning,
+		}.String(),
+	})
+	if err != nil || len(events.Items) == 0 {
+		return ""
+	}
+	last := slices.MaxFunc(events.Items, func(a, b v1.Event) int {
+		return eventTime(&a).Compare(eventTime(&b))
+	})
+	return strings.TrimSpace(last.Reason + ": " + last.Message)
+}
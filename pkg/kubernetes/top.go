@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/metrics"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// TopSortByValues are the supported values of api.TopOptions.SortBy
+var TopSortByValues = []string{"cpu", "memory"}
+
+// validateTopOptions checks that the sort and limit options are supported
+func validateTopOptions(options api.TopOptions) error {
+	if options.SortBy != "" && !slices.Contains(TopSortByValues, options.SortBy) {
+		return fmt.Errorf("invalid sort by %q, valid values are: cpu, memory", options.SortBy)
+	}
+	if options.Limit < 0 {
+		return fmt.Errorf("invalid limit %d, must not be negative", options.Limit)
+	}
+	return nil
+}
+
+// sortAndLimit sorts the items by the usage returned for each item (if options.SortBy is provided) and keeps the first
+// options.Limit items (if provided). Returns the number of items that were left out.
+func sortAndLimit[T any](items []T, usage func(item *T, resourceName v1.ResourceName) *resource.Quantity, options api.TopOptions) ([]T, int64) {
+	if options.SortBy != "" {
+		resourceName := v1.ResourceName(options.SortBy)
+		sort.SliceStable(items, func(i, j int) bool {
+			cmp := usage(&items[i], resourceName).Cmp(*usage(&items[j], resourceName))
+			if options.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+	if options.Limit > 0 && len(items) > options.Limit {
+		return items[:options.Limit], int64(len(items) - options.Limit)
+	}
+	return items, 0
+}
+
+// nodeMetricsUsage returns the usage of the resource reported for the node
+func nodeMetricsUsage(m *metrics.NodeMetrics, resourceName v1.ResourceName) *resource.Quantity {
+	usage := m.Usage[resourceName]
+	return &usage
+}
+
+// podMetricsUsage returns the usage of the resource summed over the containers of the pod
+func podMetricsUsage(m *metrics.PodMetrics, resourceName v1.ResourceName) *resource.Quantity {
+	usage := resource.Quantity{}
+	for _, container := range m.Containers {
+		usage.Add(container.Usage[resourceName])
+	}
+	return &usage
+}
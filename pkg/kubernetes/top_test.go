@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+type TopSuite struct {
+	suite.Suite
+}
+
+func podMetrics(name string, containerMemory ...string) metrics.PodMetrics {
+	m := metrics.PodMetrics{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, memory := range containerMemory {
+		m.Containers = append(m.Containers, metrics.ContainerMetrics{
+			Usage: v1.ResourceList{v1.ResourceMemory: resource.MustParse(memory)},
+		})
+	}
+	return m
+}
+
+func podMetricsNames(items []metrics.PodMetrics) []string {
+	names := make([]string, 0, len(items))
+	for _, m := range items {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+func (s *TopSuite) TestSortAndLimit() {
+	items := func() []metrics.PodMetrics {
+		return []metrics.PodMetrics{
+			podMetrics("small", "100Mi"),
+			podMetrics("large", "300Mi", "500Mi"),
+			podMetrics("medium", "400Mi"),
+		}
+	}
+	s.Run("keeps the order without sort by nor limit", func() {
+		sorted, remaining := sortAndLimit(items(), podMetricsUsage, api.TopOptions{})
+		s.Equal([]string{"small", "large", "medium"}, podMetricsNames(sorted))
+		s.Zero(remaining)
+	})
+	s.Run("sorts by the usage summed over the containers in descending order", func() {
+		sorted, _ := sortAndLimit(items(), podMetricsUsage, api.TopOptions{SortBy: "memory", Descending: true})
+		s.Equal([]string{"large", "medium", "small"}, podMetricsNames(sorted))
+	})
+	s.Run("sorts in ascending order", func() {
+		sorted, _ := sortAndLimit(items(), podMetricsUsage, api.TopOptions{SortBy: "memory"})
+		s.Equal([]string{"small", "medium", "large"}, podMetricsNames(sorted))
+	})
+	s.Run("limits after sorting and returns the number of items left out", func() {
+		sorted, remaining := sortAndLimit(items(), podMetricsUsage, api.TopOptions{SortBy: "memory", Descending: true, Limit: 1})
+		s.Equal([]string{"large"}, podMetricsNames(sorted))
+		s.Equal(int64(2), remaining)
+	})
+	s.Run("ignores a limit greater than the number of items", func() {
+		sorted, remaining := sortAndLimit(items(), podMetricsUsage, api.TopOptions{Limit: 10})
+		s.Len(sorted, 3)
+		s.Zero(remaining)
+	})
+}
+
+func (s *TopSuite) TestValidateTopOptions() {
+	s.NoError(validateTopOptions(api.TopOptions{SortBy: "cpu", Limit: 10}))
+	s.ErrorContains(validateTopOptions(api.TopOptions{SortBy: "disk"}), `invalid sort by "disk"`)
+	s.ErrorContains(validateTopOptions(api.TopOptions{Limit: -1}), "invalid limit -1")
+}
+
+func TestTop(t *testing.T) {
+	suite.Run(t, new(TopSuite))
+}
@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+)
+
+// ErrorTooManyWatches is returned when a watch is requested while all the concurrent watch slots are in use
+var ErrorTooManyWatches = errors.New("too many concurrent watches")
+
+// watchLimiter caps the number of concurrent watches, it's shared by a Manager and all its derived clients so that
+// the cap applies to all the users of the cluster.
+// The providers keep it across the rebuilds of their managers (see WatchLimiters).
+type watchLimiter struct {
+	mu     sync.Mutex
+	max    int
+	active int
+}
+
+func newWatchLimiter(maxWatches int) *watchLimiter {
+	if maxWatches <= 0 {
+		maxWatches = api.DefaultMaxConcurrentWatches
+	}
+	return &watchLimiter{max: maxWatches}
+}
+
+// acquire reserves a watch slot, the returned function releases it and can safely be called more than once
+func (l *watchLimiter) acquire() (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active >= l.max {
+		return nil, fmt.Errorf("%w: all the %d watches allowed by the server are in use, "+
+			"retry once the running waits or resource subscriptions complete (the limit is set by max_concurrent_watches)", ErrorTooManyWatches, l.max)
+	}
+	l.active++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.active--
+		})
+	}, nil
+}
+
+// inUse returns the number of watch slots currently in use
+func (l *watchLimiter) inUse() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active
+}
+
+// setMax updates the cap, the watches already running keep their slots
+func (l *watchLimiter) setMax(maxWatches int) {
+	if maxWatches <= 0 {
+		maxWatches = api.DefaultMaxConcurrentWatches
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.max = maxWatches
+}
+
+// WatchLimiters keeps the watch limiter of each target (cluster, context or workspace) of a provider.
+// The managers are rebuilt on provider resets (e.g. kubeconfig changes) while the watches started through the previous
+// ones keep running, sharing the limiter of the target with the new manager keeps them accounted for.
+// The zero value is ready to use.
+type WatchLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*watchLimiter
+}
+
+// Apply makes the manager (and its derived clients) use the watch limiter of the target, the cap is updated with the
+// configuration of the manager
+func (w *WatchLimiters) Apply(target string, m *Manager) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.limiters == nil {
+		w.limiters = make(map[string]*watchLimiter)
+	}
+	l, ok := w.limiters[target]
+	if !ok {
+		l = m.watches
+		w.limiters[target] = l
+	}
+	l.setMax(m.config.GetMaxConcurrentWatches())
+	m.watches = l
+	m.kubernetes.watches = l
+}
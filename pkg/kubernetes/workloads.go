@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// MaxWorkloadLogBytes bounds the total size of the aggregated logs returned by WorkloadLogs.
+// When exceeded, the oldest lines are dropped.
+const MaxWorkloadLogBytes = 256 * 1024
+
+// WorkloadLogs retrieves the logs of every container (or only the provided container) of the Pods currently
+// selected by a Deployment, StatefulSet or DaemonSet.
+// Lines from all the Pods are interleaved by timestamp and prefixed with the pod/container name.
+func (c *Core) WorkloadLogs(ctx context.Context, namespace, kind, name, container string, tail int64) (string, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	selector, err := c.workloadSelector(ctx, namespace, kind, name)
+	if err != nil {
+		return "", err
+	}
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+	if tail <= 0 {
+		tail = DefaultTailLines
+	}
+	var lines []workloadLogLine
+	for _, pod := range pods.Items {
+		for _, podContainer := range pod.Spec.Containers {
+			if container != "" && podContainer.Name != container {
+				continue
+			}
+			lines = append(lines, c.workloadContainerLogs(ctx, &pod, podContainer.Name, tail)...)
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return formatWorkloadLogs(lines, MaxWorkloadLogBytes), nil
+}
+
+type workloadLogLine struct {
+	timestamp time.Time
+	text      string
+}
+
+func (c *Core) workloadSelector(ctx context.Context, namespace, kind, name string) (labels.Selector, error) {
+	var selector *metav1.LabelSelector
+	switch strings.ToLower(kind) {
+	case "deployment":
+		deployment, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = deployment.Spec.Selector
+	case "statefulset":
+		statefulSet, err := c.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = statefulSet.Spec.Selector
+	case "daemonset":
+		daemonSet, err := c.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = daemonSet.Spec.Selector
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s (supported: Deployment, StatefulSet, DaemonSet)", kind)
+	}
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return nil, fmt.Errorf("%s %s has no pod selector", kind, name)
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// workloadContainerLogs retrieves the timestamped logs of a container, errors are reported inline so that a single
+// failing container doesn't prevent the rest of the workload logs from being returned.
+func (c *Core) workloadContainerLogs(ctx context.Context, pod *v1.Pod, container string, tail int64) []workloadLogLine {
+	prefix := fmt.Sprintf("[%s/%s] ", pod.Name, container)
+	raw, err := c.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tail,
+		Timestamps: true,
+	}).Stream(ctx)
+	if err != nil {
+		return []workloadLogLine{{text: prefix + "error retrieving logs: " + err.Error()}}
+	}
+	defer func() { _ = raw.Close() }()
+	data, err := io.ReadAll(io.LimitReader(raw, MaxWorkloadLogBytes))
+	if err != nil {
+		return []workloadLogLine{{text: prefix + "error reading logs: " + err.Error()}}
+	}
+	var lines []workloadLogLine
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxWorkloadLogBytes)
+	for scanner.Scan() {
+		line := workloadLogLine{text: prefix + scanner.Text()}
+		if ts, _, found := strings.Cut(scanner.Text(), " "); found {
+			line.timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatWorkloadLogs joins the lines keeping the most recent ones that fit in maxBytes.
+func formatWorkloadLogs(lines []workloadLogLine, maxBytes int) string {
+	size := 0
+	first := len(lines)
+	for first > 0 && size+len(lines[first-1].text)+1 <= maxBytes {
+		first--
+		size += len(lines[first].text) + 1
+	}
+	sb := strings.Builder{}
+	if first > 0 {
+		_, _ = fmt.Fprintf(&sb, "# %d older lines omitted (output limited to %d bytes)\n", first, maxBytes)
+	}
+	for _, line := range lines[first:] {
+		sb.WriteString(line.text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,262 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// exportedMetadataFields are the metadata fields set by the cluster that must not be carried over to a new object
+var exportedMetadataFields = []string{
+	"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds",
+	"managedFields", "selfLink", "ownerReferences",
+}
+
+// exportedAnnotations are the annotations set by the cluster (or kubectl) that must not be carried over to a new object
+var exportedAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/selected-node",
+}
+
+// WorkloadExport holds the manifests required to recreate a workload in another namespace
+type WorkloadExport struct {
+	// Workload is the cleaned manifest of the Deployment, StatefulSet or DaemonSet
+	Workload *unstructured.Unstructured
+	// Dependencies are the cleaned manifests of the ServiceAccount, ConfigMaps, Secrets and PersistentVolumeClaims
+	// referenced by the workload Pod template
+	Dependencies []*unstructured.Unstructured
+	// Missing are the referenced dependencies that couldn't be retrieved and the reason (e.g. not found, not allowed)
+	Missing []string
+}
+
+// WorkloadExport retrieves a Deployment, StatefulSet or DaemonSet and the namespaced resources referenced by its
+// Pod template. All the manifests are cleaned of cluster-specific fields (status, uid, resourceVersion,
+// managedFields...) and moved to targetNamespace so that they can be applied to it.
+func (c *Core) WorkloadExport(ctx context.Context, namespace, kind, name, targetNamespace string) (*WorkloadExport, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	gvk := &schema.GroupVersionKind{Group: "apps", Version: "v1"}
+	switch strings.ToLower(kind) {
+	case "deployment":
+		gvk.Kind = "Deployment"
+	case "statefulset":
+		gvk.Kind = "StatefulSet"
+	case "daemonset":
+		gvk.Kind = "DaemonSet"
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s (supported: Deployment, StatefulSet, DaemonSet)", kind)
+	}
+	workload, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	template, found, err := unstructured.NestedMap(workload.Object, "spec", "template")
+	if err != nil || !found {
+		return nil, fmt.Errorf("%s %s has no pod template", gvk.Kind, name)
+	}
+	var podTemplate v1.PodTemplateSpec
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s pod template: %w", gvk.Kind, name, err)
+	}
+	ret := &WorkloadExport{Workload: cleanManifest(workload, targetNamespace)}
+	for _, ref := range podTemplateReferences(&podTemplate.Spec) {
+		dependency, getErr := c.ResourcesGet(ctx, &ref.gvk, namespace, ref.name)
+		switch {
+		case apierrors.IsNotFound(getErr):
+			ret.Missing = append(ret.Missing, fmt.Sprintf("%s %s: not found", ref.gvk.Kind, ref.name))
+		case getErr != nil:
+			ret.Missing = append(ret.Missing, fmt.Sprintf("%s %s: %v", ref.gvk.Kind, ref.name, getErr))
+		default:
+			ret.Dependencies = append(ret.Dependencies, cleanManifest(dependency, targetNamespace))
+		}
+	}
+	return ret, nil
+}
+
+// WorkloadBundle holds the portable manifests required to redeploy a workload elsewhere
+type WorkloadBundle struct {
+	// Objects are the cleaned manifests in the order they must be applied: ServiceAccount, ConfigMaps, Secrets,
+	// PersistentVolumeClaims, Services and finally the workload
+	Objects []*unstructured.Unstructured
+	// Missing are the referenced dependencies that couldn't be retrieved and the reason (e.g. not found, not allowed)
+	Missing []string
+	// OmittedSecrets are the names of the referenced Secrets left out of the bundle
+	OmittedSecrets []string
+}
+
+// WorkloadBundle exports a Deployment, StatefulSet or DaemonSet (see WorkloadExport) together with the Services of
+// its namespace selecting its Pods. The Secrets are only included if includeSecrets is true.
+// If targetNamespace is empty the namespace is removed from the manifests so that the bundle can be applied to any namespace.
+func (c *Core) WorkloadBundle(ctx context.Context, namespace, kind, name, targetNamespace string, includeSecrets bool) (*WorkloadBundle, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	export, err := c.WorkloadExport(ctx, namespace, kind, name, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+	ret := &WorkloadBundle{Missing: export.Missing}
+	for _, dependency := range export.Dependencies {
+		if dependency.GetKind() == "Secret" && !includeSecrets {
+			ret.OmittedSecrets = append(ret.OmittedSecrets, dependency.GetName())
+			continue
+		}
+		ret.Objects = append(ret.Objects, dependency)
+	}
+	podLabels, _, _ := unstructured.NestedStringMap(export.Workload.Object, "spec", "template", "metadata", "labels")
+	gvr, err := c.resourceFor(&schema.GroupVersionKind{Version: "v1", Kind: "Service"})
+	if err != nil {
+		return nil, err
+	}
+	services, err := c.DynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		selector, _, _ := unstructured.NestedStringMap(service.Object, "spec", "selector")
+		if len(selector) == 0 || !labels.SelectorFromSet(selector).Matches(labels.Set(podLabels)) {
+			continue
+		}
+		// The list items may lack apiVersion and kind, required to apply them
+		service.SetAPIVersion("v1")
+		service.SetKind("Service")
+		ret.Objects = append(ret.Objects, cleanManifest(service, targetNamespace))
+	}
+	ret.Objects = append(ret.Objects, export.Workload)
+	if targetNamespace == "" {
+		for _, obj := range ret.Objects {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "namespace")
+		}
+	}
+	return ret, nil
+}
+
+type podTemplateReference struct {
+	gvk  schema.GroupVersionKind
+	name string
+}
+
+// podTemplateReferences returns the ServiceAccount, ConfigMaps, Secrets and PersistentVolumeClaims referenced by
+// the Pod spec (sorted by kind and name, without duplicates)
+func podTemplateReferences(spec *v1.PodSpec) []podTemplateReference {
+	var serviceAccounts, configMaps, secrets, pvcs []string
+	if spec.ServiceAccountName != "" && spec.ServiceAccountName != "default" {
+		serviceAccounts = append(serviceAccounts, spec.ServiceAccountName)
+	}
+	for _, pullSecret := range spec.ImagePullSecrets {
+		secrets = append(secrets, pullSecret.Name)
+	}
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			configMaps = append(configMaps, volume.ConfigMap.Name)
+		case volume.Secret != nil:
+			secrets = append(secrets, volume.Secret.SecretName)
+		case volume.PersistentVolumeClaim != nil:
+			pvcs = append(pvcs, volume.PersistentVolumeClaim.ClaimName)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMaps = append(configMaps, source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					secrets = append(secrets, source.Secret.Name)
+				}
+			}
+		}
+	}
+	for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				configMaps = append(configMaps, envFrom.ConfigMapRef.Name)
+			}
+			if envFrom.SecretRef != nil {
+				secrets = append(secrets, envFrom.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps = append(configMaps, env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secrets = append(secrets, env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	var refs []podTemplateReference
+	for _, group := range []struct {
+		kind  string
+		names []string
+	}{
+		{"ServiceAccount", serviceAccounts},
+		{"ConfigMap", configMaps},
+		{"Secret", secrets},
+		{"PersistentVolumeClaim", pvcs},
+	} {
+		slices.Sort(group.names)
+		for _, name := range slices.Compact(group.names) {
+			if name != "" {
+				refs = append(refs, podTemplateReference{gvk: schema.GroupVersionKind{Version: "v1", Kind: group.kind}, name: name})
+			}
+		}
+	}
+	return refs
+}
+
+// cleanManifest returns a copy of the object without the fields that are specific to the cluster and namespace it
+// was retrieved from, moved to the provided namespace
+func cleanManifest(obj *unstructured.Unstructured, namespace string) *unstructured.Unstructured {
+	cleaned := obj.DeepCopy()
+	delete(cleaned.Object, "status")
+	for _, field := range exportedMetadataFields {
+		unstructured.RemoveNestedField(cleaned.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(cleaned.Object, "spec", "template", "metadata", "creationTimestamp")
+	annotations := cleaned.GetAnnotations()
+	for _, annotation := range exportedAnnotations {
+		delete(annotations, annotation)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	cleaned.SetAnnotations(annotations)
+	switch cleaned.GetKind() {
+	case "PersistentVolumeClaim":
+		// bound to a PersistentVolume of the source claim, a new volume must be provisioned
+		unstructured.RemoveNestedField(cleaned.Object, "spec", "volumeName")
+	case "ServiceAccount":
+		// token Secrets are generated for the source ServiceAccount
+		delete(cleaned.Object, "secrets")
+	case "Service":
+		// IPs and node ports are allocated by the cluster, headless Services must remain headless
+		if clusterIP, _, _ := unstructured.NestedString(cleaned.Object, "spec", "clusterIP"); clusterIP != v1.ClusterIPNone {
+			unstructured.RemoveNestedField(cleaned.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(cleaned.Object, "spec", "clusterIPs")
+		}
+		unstructured.RemoveNestedField(cleaned.Object, "spec", "healthCheckNodePort")
+		if ports, found, _ := unstructured.NestedSlice(cleaned.Object, "spec", "ports"); found {
+			for _, port := range ports {
+				if portMap, ok := port.(map[string]any); ok {
+					delete(portMap, "nodePort")
+				}
+			}
+			_ = unstructured.SetNestedSlice(cleaned.Object, ports, "spec", "ports")
+		}
+	}
+	if namespace != "" {
+		cleaned.SetNamespace(namespace)
+	}
+	return cleaned
+}
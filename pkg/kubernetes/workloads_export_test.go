@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type WorkloadExportSuite struct {
+	suite.Suite
+}
+
+func (s *WorkloadExportSuite) TestCleanManifest() {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata": map[string]any{
+			"name":              "data",
+			"namespace":         "source",
+			"uid":               "1234",
+			"resourceVersion":   "42",
+			"creationTimestamp": "2025-01-01T00:00:00Z",
+			"managedFields":     []any{map[string]any{"manager": "kubectl"}},
+			"ownerReferences":   []any{map[string]any{"kind": "StatefulSet", "name": "db"}},
+			"labels":            map[string]any{"app": "db"},
+			"annotations": map[string]any{
+				"pv.kubernetes.io/bind-completed": "yes",
+				"example.com/keep":                "true",
+			},
+		},
+		"spec":   map[string]any{"volumeName": "pvc-1234", "storageClassName": "standard"},
+		"status": map[string]any{"phase": "Bound"},
+	}}
+	cleaned := cleanManifest(obj, "target")
+	s.Run("moves the object to the target namespace", func() {
+		s.Equal("target", cleaned.GetNamespace())
+	})
+	s.Run("removes cluster-specific metadata", func() {
+		s.Empty(cleaned.GetUID())
+		s.Empty(cleaned.GetResourceVersion())
+		_, found, _ := unstructured.NestedFieldNoCopy(cleaned.Object, "metadata", "creationTimestamp")
+		s.False(found)
+		s.Nil(cleaned.GetManagedFields())
+		s.Nil(cleaned.GetOwnerReferences())
+	})
+	s.Run("removes cluster-specific annotations", func() {
+		s.Equal(map[string]string{"example.com/keep": "true"}, cleaned.GetAnnotations())
+	})
+	s.Run("keeps labels and spec", func() {
+		s.Equal(map[string]string{"app": "db"}, cleaned.GetLabels())
+		storageClassName, _, _ := unstructured.NestedString(cleaned.Object, "spec", "storageClassName")
+		s.Equal("standard", storageClassName)
+	})
+	s.Run("removes status and bound volume", func() {
+		s.NotContains(cleaned.Object, "status")
+		_, found, _ := unstructured.NestedString(cleaned.Object, "spec", "volumeName")
+		s.False(found)
+	})
+	s.Run("doesn't modify the original object", func() {
+		s.Equal("source", obj.GetNamespace())
+		s.Contains(obj.Object, "status")
+	})
+}
+
+func (s *WorkloadExportSuite) TestCleanManifestService() {
+	service := func(clusterIP string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": "web", "namespace": "source"},
+			"spec": map[string]any{
+				"type":                "LoadBalancer",
+				"clusterIP":           clusterIP,
+				"clusterIPs":          []any{clusterIP},
+				"healthCheckNodePort": int64(31000),
+				"selector":            map[string]any{"app": "web"},
+				"ports":               []any{map[string]any{"port": int64(80), "targetPort": int64(8080), "nodePort": int64(30080)}},
+			},
+		}}
+	}
+	s.Run("removes the allocated IPs and node ports", func() {
+		cleaned := cleanManifest(service("10.96.0.10"), "")
+		s.Equal(map[string]any{
+			"type":     "LoadBalancer",
+			"selector": map[string]any{"app": "web"},
+			"ports":    []any{map[string]any{"port": int64(80), "targetPort": int64(8080)}},
+		}, cleaned.Object["spec"])
+	})
+	s.Run("keeps headless Services headless", func() {
+		clusterIP, _, _ := unstructured.NestedString(cleanManifest(service("None"), "").Object, "spec", "clusterIP")
+		s.Equal("None", clusterIP)
+	})
+}
+
+func (s *WorkloadExportSuite) TestPodTemplateReferences() {
+	spec := &v1.PodSpec{
+		ServiceAccountName: "app",
+		ImagePullSecrets:   []v1.LocalObjectReference{{Name: "registry"}},
+		Volumes: []v1.Volume{
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}}},
+			{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "app-data"}}},
+			{Name: "projected", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+				{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "app-tls"}}},
+			}}}},
+		},
+		InitContainers: []v1.Container{{Name: "init", EnvFrom: []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-credentials"}}}}}},
+		Containers: []v1.Container{{Name: "app", Env: []v1.EnvVar{
+			{Name: "LEVEL", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}, Key: "level"}}},
+			{Name: "TOKEN", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "app-credentials"}, Key: "token"}}},
+		}}},
+	}
+	var refs []string
+	for _, ref := range podTemplateReferences(spec) {
+		refs = append(refs, ref.gvk.Kind+"/"+ref.name)
+	}
+	s.Equal([]string{
+		"ServiceAccount/app",
+		"ConfigMap/app-config",
+		"Secret/app-credentials",
+		"Secret/app-tls",
+		"Secret/registry",
+		"PersistentVolumeClaim/app-data",
+	}, refs)
+	s.Run("ignores the default ServiceAccount", func() {
+		s.Empty(podTemplateReferences(&v1.PodSpec{ServiceAccountName: "default"}))
+	})
+}
+
+func TestWorkloadExport(t *testing.T) {
+	suite.Run(t, new(WorkloadExportSuite))
+}
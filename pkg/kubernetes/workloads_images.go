@@ -0,0 +1,226 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// WorkloadImagesResult compares the container images in the Pod template of a workload with the ones its Pods run
+type WorkloadImagesResult struct {
+	// Rollout summarizes whether the Pods run the images of the Pod template (e.g. "complete", "in progress (1 of 3 Pods up to date)")
+	Rollout string
+	// RolledOut is true if the workload controller observed the latest spec and all the Pods run the desired images
+	RolledOut bool
+	// Pods is the number of Pods of the workload
+	Pods int
+	// UpToDatePods is the number of Pods running the desired image in every container
+	UpToDatePods int
+	// Containers are the images of each container of the Pod template
+	Containers []*WorkloadContainerImages
+}
+
+// WorkloadContainerImages compares the desired image of a container with the images running in the Pods
+type WorkloadContainerImages struct {
+	// Container is the name of the container
+	Container string
+	// Init is true for init containers
+	Init bool `json:",omitempty"`
+	// Desired is the image of the container in the Pod template
+	Desired string
+	// Running are the distinct images (and digests) the Pods run for the container
+	Running []*WorkloadRunningImage `json:",omitempty"`
+	// Issues explain the differences between the desired and the running images
+	Issues []string `json:",omitempty"`
+	// NewerTags are the tags of the image registry with a newer version than the desired one (only if requested)
+	NewerTags []string `json:",omitempty"`
+	// RegistryLookupError explains why the image registry couldn't be queried for newer tags
+	RegistryLookupError string `json:",omitempty"`
+}
+
+// WorkloadRunningImage is an image (and digest) run by the Pods of a workload for a container
+type WorkloadRunningImage struct {
+	// Image is the image reference of the container in the Pod spec
+	Image string
+	// Digest is the digest of the image pulled by the kubelet (empty until the container has started)
+	Digest string `json:",omitempty"`
+	// UpToDate is true if Image is the desired image
+	UpToDate bool
+	// Pods are the names of the Pods running the image
+	Pods []string
+	// Waiting is the reason why the containers of the Pods aren't running yet (e.g. ImagePullBackOff)
+	Waiting string `json:",omitempty"`
+}
+
+// WorkloadImages reports, for each container of a Deployment, StatefulSet or DaemonSet, the image in its Pod template
+// versus the images (tags and digests) its Pods are actually running, detecting rollouts in progress, Pods stuck on
+// an old image and Pods running different digests of the same (mutable) tag. No external call is performed.
+func (c *Core) WorkloadImages(ctx context.Context, namespace, kind, name string) (*WorkloadImagesResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	gvk := &schema.GroupVersionKind{Group: "apps", Version: "v1"}
+	switch strings.ToLower(kind) {
+	case "deployment":
+		gvk.Kind = "Deployment"
+	case "statefulset":
+		gvk.Kind = "StatefulSet"
+	case "daemonset":
+		gvk.Kind = "DaemonSet"
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s (supported: Deployment, StatefulSet, DaemonSet)", kind)
+	}
+	workload, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var spec struct {
+		Selector *metav1.LabelSelector `json:"selector"`
+		Template v1.PodTemplateSpec    `json:"template"`
+	}
+	specMap, _, _ := unstructured.NestedMap(workload.Object, "spec")
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s spec: %w", gvk.Kind, name, err)
+	}
+	if spec.Selector == nil || (len(spec.Selector.MatchLabels) == 0 && len(spec.Selector.MatchExpressions) == 0) {
+		return nil, fmt.Errorf("%s %s has no pod selector", gvk.Kind, name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	ret := compareWorkloadImages(&spec.Template.Spec, pods.Items)
+	observedGeneration, _, _ := unstructured.NestedInt64(workload.Object, "status", "observedGeneration")
+	observed := observedGeneration >= workload.GetGeneration()
+	ret.RolledOut = observed && ret.Pods > 0 && ret.UpToDatePods == ret.Pods
+	switch {
+	case !observed:
+		ret.Rollout = fmt.Sprintf("pending (the %s controller hasn't observed the latest spec yet)", gvk.Kind)
+	case ret.Pods == 0:
+		ret.Rollout = "no Pods"
+	case ret.RolledOut:
+		ret.Rollout = "complete"
+	default:
+		ret.Rollout = fmt.Sprintf("in progress (%d of %d Pods up to date)", ret.UpToDatePods, ret.Pods)
+	}
+	return ret, nil
+}
+
+// compareWorkloadImages compares the images of the containers of the Pod template with the ones of the Pods.
+// A Pod is up to date if the image of each of its containers is the one in the template and the kubelet isn't
+// still pulling or failing to pull it.
+func compareWorkloadImages(template *v1.PodSpec, pods []v1.Pod) *WorkloadImagesResult {
+	ret := &WorkloadImagesResult{Pods: len(pods)}
+	type desiredContainer struct {
+		container v1.Container
+		init      bool
+	}
+	var desired []desiredContainer
+	for _, container := range template.InitContainers {
+		desired = append(desired, desiredContainer{container, true})
+	}
+	for _, container := range template.Containers {
+		desired = append(desired, desiredContainer{container, false})
+	}
+	upToDate := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		upToDate[pod.Name] = true
+	}
+	for _, d := range desired {
+		images := &WorkloadContainerImages{Container: d.container.Name, Init: d.init, Desired: d.container.Image}
+		outdated, waiting, missing := 0, 0, 0
+		for _, pod := range pods {
+			image, status := podContainerImage(&pod, d.container.Name)
+			if image == "" {
+				// Pods created before the container was added to the template
+				missing++
+				upToDate[pod.Name] = false
+				continue
+			}
+			running := &WorkloadRunningImage{Image: image, UpToDate: image == d.container.Image}
+			if status != nil {
+				running.Digest = imageDigest(status.ImageID)
+				if status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.ImageID == "" {
+					running.Waiting = status.State.Waiting.Reason
+				}
+			}
+			if i := slices.IndexFunc(images.Running, func(r *WorkloadRunningImage) bool {
+				return r.Image == running.Image && r.Digest == running.Digest && r.Waiting == running.Waiting
+			}); i >= 0 {
+				running = images.Running[i]
+			} else {
+				images.Running = append(images.Running, running)
+			}
+			running.Pods = append(running.Pods, pod.Name)
+			switch {
+			case !running.UpToDate:
+				outdated++
+				upToDate[pod.Name] = false
+			case running.Waiting != "":
+				waiting++
+				upToDate[pod.Name] = false
+			}
+		}
+		if outdated > 0 {
+			images.Issues = append(images.Issues, fmt.Sprintf("%d of %d Pods run an image other than %s", outdated, len(pods), d.container.Image))
+		}
+		if missing > 0 {
+			images.Issues = append(images.Issues, fmt.Sprintf("%d of %d Pods don't have the container (created from an older Pod template)", missing, len(pods)))
+		}
+		if waiting > 0 {
+			images.Issues = append(images.Issues, fmt.Sprintf("%d Pods haven't started %s yet", waiting, d.container.Image))
+		}
+		if _, _, _, digest := parseImageReference(d.container.Image); digest == "" {
+			var digests []string
+			for _, running := range images.Running {
+				if running.UpToDate && running.Digest != "" && !slices.Contains(digests, running.Digest) {
+					digests = append(digests, running.Digest)
+				}
+			}
+			if len(digests) > 1 {
+				images.Issues = append(images.Issues, fmt.Sprintf("the Pods run %d different digests of %s (the tag was pushed again, pin the image to a digest to run the same content everywhere)", len(digests), d.container.Image))
+			}
+		}
+		ret.Containers = append(ret.Containers, images)
+	}
+	for _, pod := range pods {
+		if upToDate[pod.Name] {
+			ret.UpToDatePods++
+		}
+	}
+	return ret
+}
+
+// podContainerImage returns the image of the container in the Pod spec and its status (nil if not reported yet)
+func podContainerImage(pod *v1.Pod, name string) (string, *v1.ContainerStatus) {
+	image := ""
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if container.Name == name {
+			image = container.Image
+		}
+	}
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if status.Name == name {
+			return image, &status
+		}
+	}
+	return image, nil
+}
+
+// imageDigest returns the digest of the image ID reported by the kubelet
+// (e.g. docker.io/library/nginx@sha256:... or docker-pullable://nginx@sha256:...)
+func imageDigest(imageID string) string {
+	if _, digest, found := strings.Cut(imageID, "@"); found {
+		return digest
+	}
+	return imageID
+}
@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+// ContainerResourceUnset is the ContainerResourcesUpdate value that removes a request or limit
+const ContainerResourceUnset = "none"
+
+// ContainerResourcesUpdate are the requests and limits to set on a container, empty values are left unchanged and
+// ContainerResourceUnset removes the value
+type ContainerResourcesUpdate struct {
+	CPURequest    string
+	CPULimit      string
+	MemoryRequest string
+	MemoryLimit   string
+}
+
+func (u *ContainerResourcesUpdate) isEmpty() bool {
+	return u == nil || (u.CPURequest == "" && u.CPULimit == "" && u.MemoryRequest == "" && u.MemoryLimit == "")
+}
+
+// WorkloadContainerResourcesResult reports the resources of a container in the Pod template of a workload
+type WorkloadContainerResourcesResult struct {
+	// Container is the name of the container
+	Container string
+	// Changed is true if the resources of the container were updated
+	Changed bool
+	// Previous are the resources of the container before the update (only if changed)
+	Previous *v1.ResourceRequirements `json:",omitempty"`
+	// Resources are the current resources of the container
+	Resources v1.ResourceRequirements
+	// Rollout explains how the change is rolled out to the Pods (only if changed)
+	Rollout string `json:",omitempty"`
+}
+
+// WorkloadContainerResources returns the requests and limits of a container in the Pod template of a Deployment,
+// StatefulSet or DaemonSet and, if an update is provided, patches them (strategic merge patch targeting only the
+// container and the provided values). Changing the Pod template triggers a rollout of the workload Pods.
+// The quantities are validated and requests can't exceed the limits.
+func (c *Core) WorkloadContainerResources(ctx context.Context, namespace, kind, name, container string, update *ContainerResourcesUpdate) (*WorkloadContainerResourcesResult, error) {
+	namespace = c.NamespaceOrDefault(namespace)
+	gvk := &schema.GroupVersionKind{Group: "apps", Version: "v1"}
+	switch strings.ToLower(kind) {
+	case "deployment":
+		gvk.Kind = "Deployment"
+	case "statefulset":
+		gvk.Kind = "StatefulSet"
+	case "daemonset":
+		gvk.Kind = "DaemonSet"
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %s (supported: Deployment, StatefulSet, DaemonSet)", kind)
+	}
+	workload, err := c.ResourcesGet(ctx, gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	template, _, _ := unstructured.NestedMap(workload.Object, "spec", "template")
+	var podTemplate v1.PodTemplateSpec
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s pod template: %w", gvk.Kind, name, err)
+	}
+	containersField, current := "containers", (*v1.Container)(nil)
+	for i := range podTemplate.Spec.Containers {
+		if podTemplate.Spec.Containers[i].Name == container {
+			current = &podTemplate.Spec.Containers[i]
+		}
+	}
+	for i := range podTemplate.Spec.InitContainers {
+		if current == nil && podTemplate.Spec.InitContainers[i].Name == container {
+			containersField, current = "initContainers", &podTemplate.Spec.InitContainers[i]
+		}
+	}
+	if current == nil {
+		var names []string
+		for _, templateContainer := range slices.Concat(podTemplate.Spec.InitContainers, podTemplate.Spec.Containers) {
+			names = append(names, templateContainer.Name)
+		}
+		return nil, fmt.Errorf("container %s not found in %s %s (available containers: %s)", container, gvk.Kind, name, strings.Join(names, ", "))
+	}
+	ret := &WorkloadContainerResourcesResult{Container: container, Resources: current.Resources}
+	if update.isEmpty() {
+		return ret, nil
+	}
+	desired, patch, err := containerResourcesPatch(current.Resources, update)
+	if err != nil {
+		return nil, err
+	}
+	if patch == nil {
+		return ret, nil
+	}
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			containersField: []interface{}{map[string]interface{}{"name": container, "resources": patch}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	gvr, err := c.resourceFor(gvk)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := c.DynamicClient().Resource(*gvr).Namespace(namespace).
+		Patch(ctx, name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{FieldManager: version.BinaryName})
+	if err != nil {
+		return nil, err
+	}
+	previous := current.Resources
+	ret.Changed, ret.Previous, ret.Resources = true, &previous, desired
+	ret.Rollout = fmt.Sprintf("triggered, the Pods are replaced according to the update strategy of the %s", gvk.Kind)
+	if paused, _, _ := unstructured.NestedBool(patched.Object, "spec", "paused"); paused {
+		ret.Rollout = "pending, the Deployment is paused and the change will be rolled out when it's resumed (resources_rollout_resume)"
+	} else if strategy, _, _ := unstructured.NestedString(patched.Object, "spec", "updateStrategy", "type"); strategy == "OnDelete" {
+		ret.Rollout = fmt.Sprintf("pending, the %s has the OnDelete update strategy and the Pods only pick up the change when they are deleted", gvk.Kind)
+	}
+	return ret, nil
+}
+
+// containerResourcesPatch applies the update to the resources of the container, returning the desired resources and
+// the strategic merge patch of the changed values (null removes a value), nil if nothing changes
+func containerResourcesPatch(current v1.ResourceRequirements, update *ContainerResourcesUpdate) (v1.ResourceRequirements, map[string]interface{}, error) {
+	desired := *current.DeepCopy()
+	patch := map[string]interface{}{}
+	for _, change := range []struct {
+		field    string
+		resource v1.ResourceName
+		value    string
+	}{
+		{"requests", v1.ResourceCPU, update.CPURequest},
+		{"limits", v1.ResourceCPU, update.CPULimit},
+		{"requests", v1.ResourceMemory, update.MemoryRequest},
+		{"limits", v1.ResourceMemory, update.MemoryLimit},
+	} {
+		if change.value == "" {
+			continue
+		}
+		list := &desired.Requests
+		if change.field == "limits" {
+			list = &desired.Limits
+		}
+		existing, found := (*list)[change.resource]
+		var patchValue interface{}
+		if change.value == ContainerResourceUnset {
+			if !found {
+				continue
+			}
+			delete(*list, change.resource)
+		} else {
+			quantity, err := resource.ParseQuantity(change.value)
+			if err != nil {
+				return desired, nil, fmt.Errorf("invalid %s %s %q: %w (e.g. 250m or 1 for cpu, 128Mi or 2Gi for memory)", change.resource, strings.TrimSuffix(change.field, "s"), change.value, err)
+			}
+			if quantity.Sign() < 0 {
+				return desired, nil, fmt.Errorf("invalid %s %s %q: must not be negative", change.resource, strings.TrimSuffix(change.field, "s"), change.value)
+			}
+			if found && existing.Cmp(quantity) == 0 {
+				continue
+			}
+			if *list == nil {
+				*list = v1.ResourceList{}
+			}
+			(*list)[change.resource] = quantity
+			patchValue = quantity.String()
+		}
+		fieldPatch, _ := patch[change.field].(map[string]interface{})
+		if fieldPatch == nil {
+			fieldPatch = map[string]interface{}{}
+			patch[change.field] = fieldPatch
+		}
+		fieldPatch[string(change.resource)] = patchValue
+	}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		request, hasRequest := desired.Requests[name]
+		limit, hasLimit := desired.Limits[name]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			return desired, nil, fmt.Errorf("the %s request (%s) can't exceed the %s limit (%s)", name, request.String(), name, limit.String())
+		}
+	}
+	if len(patch) == 0 {
+		return desired, nil, nil
+	}
+	return desired, patch, nil
+}
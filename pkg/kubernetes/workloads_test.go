@@ -0,0 +1,25 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FormatWorkloadLogsSuite struct {
+	suite.Suite
+}
+
+func (s *FormatWorkloadLogsSuite) TestFormatWorkloadLogs() {
+	lines := []workloadLogLine{{text: "[p/c] one"}, {text: "[p/c] two"}, {text: "[p/c] three"}}
+	s.Run("returns all lines when within limit", func() {
+		s.Equal("[p/c] one\n[p/c] two\n[p/c] three\n", formatWorkloadLogs(lines, 1024))
+	})
+	s.Run("keeps the most recent lines when exceeding limit", func() {
+		s.Equal("# 1 older lines omitted (output limited to 22 bytes)\n[p/c] two\n[p/c] three\n", formatWorkloadLogs(lines, 22))
+	})
+}
+
+func TestFormatWorkloadLogs(t *testing.T) {
+	suite.Run(t, new(FormatWorkloadLogsSuite))
+}
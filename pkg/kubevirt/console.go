@@ -0,0 +1,94 @@
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// VMI subresource names for console connections
+	subresourceVNC     = "vnc"
+	subresourceConsole = "console"
+)
+
+// ConsoleInfo holds the connection details of the consoles of a running VirtualMachineInstance
+type ConsoleInfo struct {
+	Namespace string          `json:"namespace" yaml:"namespace"`
+	Name      string          `json:"name" yaml:"name"`
+	Phase     string          `json:"phase" yaml:"phase"`
+	VNC       ConsoleEndpoint `json:"vnc" yaml:"vnc"`
+	Serial    ConsoleEndpoint `json:"serial" yaml:"serial"`
+	Notes     []string        `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// ConsoleEndpoint holds the connection details of a single console
+type ConsoleEndpoint struct {
+	Available bool `json:"available" yaml:"available"`
+	// URL is the subresource endpoint, clients connect to it with a WebSocket upgrade using the cluster credentials
+	URL string `json:"url" yaml:"url"`
+	// Command is the virtctl command to connect to the console
+	Command string `json:"command" yaml:"command"`
+	// Reason explains why the console isn't available
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// GetConsoleInfo returns the VNC and serial console connection details of a running VirtualMachineInstance.
+// The consoles are served by the subresources API of the provided API server host, the streams aren't proxied.
+func GetConsoleInfo(ctx context.Context, client dynamic.Interface, host, namespace, name string) (*ConsoleInfo, error) {
+	vmi, err := client.Resource(VirtualMachineInstanceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("VirtualMachineInstance not found - VM may not be running: %w", err)
+	}
+	phase, _, _ := unstructured.NestedString(vmi.Object, "status", "phase")
+	if phase != "Running" {
+		return nil, fmt.Errorf("VirtualMachineInstance %s/%s is not running (phase: %s) - consoles are only available for running VMs", namespace, name, phase)
+	}
+	subresourcePath := fmt.Sprintf("%s/apis/%s/%s/namespaces/%s/%s/%s/",
+		strings.TrimSuffix(host, "/"), VirtualMachineInstanceSubresourcesGVR.Group, VirtualMachineInstanceSubresourcesGVR.Version,
+		namespace, VirtualMachineInstanceSubresourcesGVR.Resource, name)
+	info := &ConsoleInfo{
+		Namespace: namespace,
+		Name:      name,
+		Phase:     phase,
+		VNC: ConsoleEndpoint{
+			Available: true,
+			URL:       subresourcePath + subresourceVNC,
+			Command:   fmt.Sprintf("virtctl vnc %s -n %s", name, namespace),
+		},
+		Serial: ConsoleEndpoint{
+			Available: true,
+			URL:       subresourcePath + subresourceConsole,
+			Command:   fmt.Sprintf("virtctl console %s -n %s", name, namespace),
+		},
+	}
+	// Graphics and serial console devices are attached unless explicitly disabled
+	if attached, found, _ := unstructured.NestedBool(vmi.Object, "spec", "domain", "devices", "autoattachGraphicsDevice"); found && !attached {
+		info.VNC.Available = false
+		info.VNC.Reason = "the graphics device is disabled (spec.domain.devices.autoattachGraphicsDevice: false)"
+	}
+	if attached, found, _ := unstructured.NestedBool(vmi.Object, "spec", "domain", "devices", "autoattachSerialConsole"); found && !attached {
+		info.Serial.Available = false
+		info.Serial.Reason = "the serial console is disabled (spec.domain.devices.autoattachSerialConsole: false)"
+	}
+	if isPaused(vmi) {
+		info.Notes = append(info.Notes, "The VirtualMachineInstance is paused, the consoles won't respond until it's unpaused")
+	}
+	return info, nil
+}
+
+// isPaused returns true if the VirtualMachineInstance has a true Paused condition
+func isPaused(vmi *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(vmi.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if ok && condition["type"] == "Paused" && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
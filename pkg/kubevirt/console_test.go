@@ -0,0 +1,124 @@
+package kubevirt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+// createTestVMI creates a test VirtualMachineInstance with the given phase and devices
+func createTestVMI(name, namespace, phase string, devices map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachineInstance",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"domain": map[string]interface{}{
+				"devices": devices,
+			},
+		},
+		"status": map[string]interface{}{
+			"phase": phase,
+		},
+	}}
+}
+
+func TestGetConsoleInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		vmi           *unstructured.Unstructured
+		host          string
+		wantError     string
+		wantVNC       bool
+		wantSerial    bool
+		wantVNCURL    string
+		wantSerialURL string
+		wantVNCReason string
+		wantSerialCmd string
+	}{
+		{
+			name:          "running VMI with default devices",
+			vmi:           createTestVMI("test-vm", "default", "Running", map[string]interface{}{}),
+			host:          "https://api.example.com:6443/",
+			wantVNC:       true,
+			wantSerial:    true,
+			wantVNCURL:    "https://api.example.com:6443/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/test-vm/vnc",
+			wantSerialURL: "https://api.example.com:6443/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/test-vm/console",
+			wantSerialCmd: "virtctl console test-vm -n default",
+		},
+		{
+			name:          "running VMI without graphics device",
+			vmi:           createTestVMI("test-vm", "default", "Running", map[string]interface{}{"autoattachGraphicsDevice": false}),
+			host:          "https://api.example.com:6443",
+			wantVNC:       false,
+			wantSerial:    true,
+			wantVNCURL:    "https://api.example.com:6443/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/test-vm/vnc",
+			wantSerialURL: "https://api.example.com:6443/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/test-vm/console",
+			wantVNCReason: "the graphics device is disabled (spec.domain.devices.autoattachGraphicsDevice: false)",
+			wantSerialCmd: "virtctl console test-vm -n default",
+		},
+		{
+			name:      "scheduling VMI",
+			vmi:       createTestVMI("test-vm", "default", "Scheduling", map[string]interface{}{}),
+			host:      "https://api.example.com:6443",
+			wantError: "VirtualMachineInstance default/test-vm is not running (phase: Scheduling) - consoles are only available for running VMs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			client := fake.NewSimpleDynamicClient(scheme, tt.vmi)
+
+			info, err := GetConsoleInfo(context.Background(), client, tt.host, "default", "test-vm")
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Errorf("Expected error %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if info.VNC.Available != tt.wantVNC {
+				t.Errorf("VNC available = %v, want %v", info.VNC.Available, tt.wantVNC)
+			}
+			if info.Serial.Available != tt.wantSerial {
+				t.Errorf("Serial available = %v, want %v", info.Serial.Available, tt.wantSerial)
+			}
+			if info.VNC.URL != tt.wantVNCURL {
+				t.Errorf("VNC URL = %q, want %q", info.VNC.URL, tt.wantVNCURL)
+			}
+			if info.Serial.URL != tt.wantSerialURL {
+				t.Errorf("Serial URL = %q, want %q", info.Serial.URL, tt.wantSerialURL)
+			}
+			if info.VNC.Reason != tt.wantVNCReason {
+				t.Errorf("VNC reason = %q, want %q", info.VNC.Reason, tt.wantVNCReason)
+			}
+			if info.Serial.Command != tt.wantSerialCmd {
+				t.Errorf("Serial command = %q, want %q", info.Serial.Command, tt.wantSerialCmd)
+			}
+		})
+	}
+}
+
+func TestGetConsoleInfoNotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	client := fake.NewSimpleDynamicClient(scheme)
+
+	_, err := GetConsoleInfo(context.Background(), client, "https://api.example.com:6443", "default", "non-existent-vm")
+	if err == nil {
+		t.Errorf("Expected error for non-existent VMI, got nil")
+		return
+	}
+	if want := "VirtualMachineInstance not found - VM may not be running"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected error containing %q, got %v", want, err)
+	}
+}
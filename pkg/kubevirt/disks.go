@@ -0,0 +1,152 @@
+package kubevirt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// DiskExpansion holds the result of the expansion of a VirtualMachine disk
+type DiskExpansion struct {
+	Namespace             string   `json:"namespace" yaml:"namespace"`
+	Name                  string   `json:"name" yaml:"name"`
+	DiskName              string   `json:"diskName" yaml:"diskName"`
+	PersistentVolumeClaim string   `json:"persistentVolumeClaim" yaml:"persistentVolumeClaim"`
+	StorageClass          string   `json:"storageClass" yaml:"storageClass"`
+	PreviousSize          string   `json:"previousSize" yaml:"previousSize"`
+	NewSize               string   `json:"newSize" yaml:"newSize"`
+	Notes                 []string `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// ExpandDisk expands the disk of the VirtualMachine by resizing the PersistentVolumeClaim backing it.
+// Only disks backed by a PersistentVolumeClaim or a DataVolume can be expanded, and the StorageClass
+// of the claim must allow volume expansion. Disks can't be shrunk.
+func ExpandDisk(ctx context.Context, dynamicClient dynamic.Interface, namespace, name, diskName, newSize string) (*DiskExpansion, error) {
+	size, err := resource.ParseQuantity(newSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid newSize '%s': %w", newSize, err)
+	}
+
+	vm, err := GetVirtualMachine(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachine: %w", err)
+	}
+	claimName, err := diskClaimName(vm, diskName)
+	if err != nil {
+		return nil, err
+	}
+
+	pvc, err := dynamicClient.Resource(PersistentVolumeClaimGVR).Namespace(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PersistentVolumeClaim %s backing disk %s: %w", claimName, diskName, err)
+	}
+	currentSize, _, _ := unstructured.NestedString(pvc.Object, "spec", "resources", "requests", "storage")
+	current, err := resource.ParseQuantity(currentSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the requested storage '%s' of PersistentVolumeClaim %s: %w", currentSize, claimName, err)
+	}
+	if size.Cmp(current) <= 0 {
+		return nil, fmt.Errorf("newSize %s must be greater than the current size %s of disk %s - disks can't be shrunk", newSize, currentSize, diskName)
+	}
+
+	storageClassName, err := expandableStorageClass(ctx, dynamicClient, pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"resources": map[string]any{"requests": map[string]any{"storage": size.String()}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PersistentVolumeClaim patch: %w", err)
+	}
+	if _, err = dynamicClient.Resource(PersistentVolumeClaimGVR).Namespace(namespace).
+		Patch(ctx, claimName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to resize PersistentVolumeClaim %s: %w", claimName, err)
+	}
+
+	expansion := &DiskExpansion{
+		Namespace:             namespace,
+		Name:                  name,
+		DiskName:              diskName,
+		PersistentVolumeClaim: claimName,
+		StorageClass:          storageClassName,
+		PreviousSize:          currentSize,
+		NewSize:               size.String(),
+	}
+	expansion.Notes = expansionNotes(ctx, dynamicClient, namespace, name)
+	return expansion, nil
+}
+
+// diskClaimName returns the name of the PersistentVolumeClaim backing the VirtualMachine disk.
+// DataVolumes are backed by a PersistentVolumeClaim with the same name.
+func diskClaimName(vm *unstructured.Unstructured, diskName string) (string, error) {
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	var volumeNames []string
+	for _, v := range volumes {
+		volume, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		volumeName, _, _ := unstructured.NestedString(volume, "name")
+		volumeNames = append(volumeNames, volumeName)
+		if volumeName != diskName {
+			continue
+		}
+		if claimName, found, _ := unstructured.NestedString(volume, "persistentVolumeClaim", "claimName"); found {
+			return claimName, nil
+		}
+		if dataVolumeName, found, _ := unstructured.NestedString(volume, "dataVolume", "name"); found {
+			return dataVolumeName, nil
+		}
+		return "", fmt.Errorf("disk %s is not backed by a PersistentVolumeClaim or a DataVolume and can't be expanded", diskName)
+	}
+	return "", fmt.Errorf("disk %s not found in VirtualMachine %s/%s (available disks: %s)",
+		diskName, vm.GetNamespace(), vm.GetName(), strings.Join(volumeNames, ", "))
+}
+
+// expandableStorageClass returns the StorageClass name of the PersistentVolumeClaim, failing if it doesn't allow volume expansion
+func expandableStorageClass(ctx context.Context, dynamicClient dynamic.Interface, pvc *unstructured.Unstructured) (string, error) {
+	storageClassName, _, _ := unstructured.NestedString(pvc.Object, "spec", "storageClassName")
+	if storageClassName == "" {
+		return "", fmt.Errorf("PersistentVolumeClaim %s has no StorageClass - volume expansion requires a StorageClass with allowVolumeExpansion enabled", pvc.GetName())
+	}
+	storageClass, err := dynamicClient.Resource(StorageClassGVR).Get(ctx, storageClassName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get StorageClass %s of PersistentVolumeClaim %s: %w", storageClassName, pvc.GetName(), err)
+	}
+	if allowed, _, _ := unstructured.NestedBool(storageClass.Object, "allowVolumeExpansion"); !allowed {
+		return "", fmt.Errorf("StorageClass %s of PersistentVolumeClaim %s doesn't allow volume expansion (allowVolumeExpansion is not true)", storageClassName, pvc.GetName())
+	}
+	return storageClassName, nil
+}
+
+// expansionNotes returns the hints to complete the expansion depending on whether the VirtualMachine is running
+func expansionNotes(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) []string {
+	notes := []string{"The storage provisioner resizes the volume asynchronously, check the PersistentVolumeClaim status.capacity for the actual size"}
+	vmi, err := dynamicClient.Resource(VirtualMachineInstanceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return notes
+	}
+	running := false
+	if err == nil {
+		phase, _, _ := unstructured.NestedString(vmi.Object, "status", "phase")
+		running = phase == "Running"
+	}
+	if running {
+		notes = append(notes,
+			"The VM is running: with the ExpandDisks feature gate enabled the disk image is expanded online, otherwise restart the VM to pick up the new size",
+			"Grow the partition and filesystem inside the guest to use the new space (e.g. growpart, resize2fs or xfs_growfs)")
+	} else {
+		notes = append(notes, "The VM is not running: the new size is available on the next start, grow the partition and filesystem inside the guest if it's not done automatically (e.g. cloud-init growpart)")
+	}
+	return notes
+}
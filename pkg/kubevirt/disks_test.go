@@ -0,0 +1,182 @@
+package kubevirt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+// createTestDiskVM creates a test VirtualMachine with a containerDisk, a DataVolume and a PersistentVolumeClaim backed disk
+func createTestDiskVM() *unstructured.Unstructured {
+	vm := createTestVM("test-vm", "default", RunStrategyHalted)
+	_ = unstructured.SetNestedSlice(vm.Object, []interface{}{
+		map[string]interface{}{"name": "rootdisk", "dataVolume": map[string]interface{}{"name": "test-vm-rootdisk"}},
+		map[string]interface{}{"name": "data", "persistentVolumeClaim": map[string]interface{}{"claimName": "data-pvc"}},
+		map[string]interface{}{"name": "scratch", "containerDisk": map[string]interface{}{"image": "quay.io/containerdisks/fedora:latest"}},
+	}, "spec", "template", "spec", "volumes")
+	return vm
+}
+
+// createTestPVC creates a test PersistentVolumeClaim with the given storage request and StorageClass
+func createTestPVC(name, storage, storageClassName string) *unstructured.Unstructured {
+	pvc := &unstructured.Unstructured{}
+	pvc.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"storageClassName": storageClassName,
+			"resources": map[string]interface{}{
+				"requests": map[string]interface{}{"storage": storage},
+			},
+		},
+	})
+	return pvc
+}
+
+// createTestStorageClass creates a test StorageClass
+func createTestStorageClass(name string, allowVolumeExpansion bool) *unstructured.Unstructured {
+	sc := &unstructured.Unstructured{}
+	sc.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion":           "storage.k8s.io/v1",
+		"kind":                 "StorageClass",
+		"metadata":             map[string]interface{}{"name": name},
+		"provisioner":          "test.csi.k8s.io",
+		"allowVolumeExpansion": allowVolumeExpansion,
+	})
+	return sc
+}
+
+func TestExpandDisk(t *testing.T) {
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		diskName      string
+		newSize       string
+		wantClaim     string
+		wantPrevious  string
+		wantNote      string
+		errorContains string
+	}{
+		{
+			name: "expands DataVolume backed disk of a stopped VM",
+			objects: []runtime.Object{
+				createTestDiskVM(),
+				createTestPVC("test-vm-rootdisk", "10Gi", "expandable"),
+				createTestStorageClass("expandable", true),
+			},
+			diskName:     "rootdisk",
+			newSize:      "20Gi",
+			wantClaim:    "test-vm-rootdisk",
+			wantPrevious: "10Gi",
+			wantNote:     "The VM is not running",
+		},
+		{
+			name: "expands PersistentVolumeClaim backed disk of a running VM",
+			objects: []runtime.Object{
+				createTestDiskVM(),
+				createTestHotplugVMI("test-vm", "default"),
+				createTestPVC("data-pvc", "1Gi", "expandable"),
+				createTestStorageClass("expandable", true),
+			},
+			diskName:     "data",
+			newSize:      "1536Mi",
+			wantClaim:    "data-pvc",
+			wantPrevious: "1Gi",
+			wantNote:     "Grow the partition and filesystem inside the guest",
+		},
+		{
+			name: "fails when the StorageClass doesn't allow volume expansion",
+			objects: []runtime.Object{
+				createTestDiskVM(),
+				createTestPVC("data-pvc", "1Gi", "fixed"),
+				createTestStorageClass("fixed", false),
+			},
+			diskName:      "data",
+			newSize:       "2Gi",
+			errorContains: "StorageClass fixed of PersistentVolumeClaim data-pvc doesn't allow volume expansion",
+		},
+		{
+			name:          "fails when the PersistentVolumeClaim has no StorageClass",
+			objects:       []runtime.Object{createTestDiskVM(), createTestPVC("data-pvc", "1Gi", "")},
+			diskName:      "data",
+			newSize:       "2Gi",
+			errorContains: "PersistentVolumeClaim data-pvc has no StorageClass",
+		},
+		{
+			name: "fails when shrinking the disk",
+			objects: []runtime.Object{
+				createTestDiskVM(),
+				createTestPVC("data-pvc", "1Gi", "expandable"),
+				createTestStorageClass("expandable", true),
+			},
+			diskName:      "data",
+			newSize:       "512Mi",
+			errorContains: "newSize 512Mi must be greater than the current size 1Gi of disk data",
+		},
+		{
+			name:          "fails for disk not backed by a PersistentVolumeClaim",
+			objects:       []runtime.Object{createTestDiskVM()},
+			diskName:      "scratch",
+			newSize:       "2Gi",
+			errorContains: "disk scratch is not backed by a PersistentVolumeClaim or a DataVolume and can't be expanded",
+		},
+		{
+			name:          "fails for non-existent disk",
+			objects:       []runtime.Object{createTestDiskVM()},
+			diskName:      "missing",
+			newSize:       "2Gi",
+			errorContains: "disk missing not found in VirtualMachine default/test-vm (available disks: rootdisk, data, scratch)",
+		},
+		{
+			name:          "fails for invalid size",
+			objects:       []runtime.Object{createTestDiskVM()},
+			diskName:      "data",
+			newSize:       "big",
+			errorContains: "invalid newSize 'big'",
+		},
+		{
+			name:          "fails for non-existent VM",
+			diskName:      "data",
+			newSize:       "2Gi",
+			errorContains: "failed to get VirtualMachine",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), tt.objects...)
+			result, err := ExpandDisk(context.Background(), client, "default", "test-vm", tt.diskName, tt.newSize)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.PersistentVolumeClaim != tt.wantClaim || result.PreviousSize != tt.wantPrevious || result.NewSize != tt.newSize {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if !strings.Contains(strings.Join(result.Notes, "\n"), tt.wantNote) {
+				t.Errorf("expected notes to contain %q, got %v", tt.wantNote, result.Notes)
+			}
+			pvc, err := client.Resource(PersistentVolumeClaimGVR).Namespace("default").Get(context.Background(), tt.wantClaim, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get PersistentVolumeClaim: %v", err)
+			}
+			if storage, _, _ := unstructured.NestedString(pvc.Object, "spec", "resources", "requests", "storage"); storage != tt.newSize {
+				t.Errorf("expected PersistentVolumeClaim storage request %s, got %s", tt.newSize, storage)
+			}
+		})
+	}
+}
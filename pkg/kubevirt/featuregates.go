@@ -0,0 +1,88 @@
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// featureGatesPath is the path of the feature gates in the KubeVirt resource
+var featureGatesPath = []string{"spec", "configuration", "developerConfiguration", "featureGates"}
+
+// FindKubeVirt returns the KubeVirt resource of the cluster, failing if there isn't exactly one
+func FindKubeVirt(ctx context.Context, client dynamic.Interface) (*unstructured.Unstructured, error) {
+	list, err := client.Resource(KubeVirtGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KubeVirt resources: %w", err)
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no KubeVirt resource found - KubeVirt may not be installed")
+	case 1:
+		return &list.Items[0], nil
+	default:
+		return nil, fmt.Errorf("found %d KubeVirt resources - namespace and name must be provided", len(list.Items))
+	}
+}
+
+// GetFeatureGates returns the feature gates enabled in the KubeVirt resource
+func GetFeatureGates(ctx context.Context, client dynamic.Interface, namespace, name string) ([]string, error) {
+	kv, err := client.Resource(KubeVirtGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KubeVirt: %w", err)
+	}
+	gates, _, err := unstructured.NestedStringSlice(kv.Object, featureGatesPath...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature gates: %w", err)
+	}
+	return gates, nil
+}
+
+// EnableFeatureGate enables the feature gate in the KubeVirt resource
+// Returns true if the feature gate was enabled, false if it was already enabled
+func EnableFeatureGate(ctx context.Context, client dynamic.Interface, namespace, name, gate string) (bool, error) {
+	return updateFeatureGates(ctx, client, namespace, name, func(gates []string) ([]string, bool) {
+		if slices.Contains(gates, gate) {
+			return gates, false
+		}
+		return append(gates, gate), true
+	})
+}
+
+// DisableFeatureGate disables the feature gate in the KubeVirt resource
+// Returns true if the feature gate was disabled, false if it wasn't enabled
+func DisableFeatureGate(ctx context.Context, client dynamic.Interface, namespace, name, gate string) (bool, error) {
+	return updateFeatureGates(ctx, client, namespace, name, func(gates []string) ([]string, bool) {
+		if !slices.Contains(gates, gate) {
+			return gates, false
+		}
+		return slices.DeleteFunc(gates, func(g string) bool { return g == gate }), true
+	})
+}
+
+// updateFeatureGates applies the mutation to the feature gates of the KubeVirt resource, updating it only if they changed
+func updateFeatureGates(ctx context.Context, client dynamic.Interface, namespace, name string, mutate func([]string) ([]string, bool)) (bool, error) {
+	kv, err := client.Resource(KubeVirtGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get KubeVirt: %w", err)
+	}
+	gates, _, err := unstructured.NestedStringSlice(kv.Object, featureGatesPath...)
+	if err != nil {
+		return false, fmt.Errorf("failed to read feature gates: %w", err)
+	}
+	gates, changed := mutate(gates)
+	if !changed {
+		return false, nil
+	}
+	if err = unstructured.SetNestedStringSlice(kv.Object, gates, featureGatesPath...); err != nil {
+		return false, fmt.Errorf("failed to set feature gates: %w", err)
+	}
+	if _, err = client.Resource(KubeVirtGVR).Namespace(namespace).Update(ctx, kv, metav1.UpdateOptions{}); err != nil {
+		return false, fmt.Errorf("failed to update KubeVirt: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,215 @@
+package kubevirt
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+// createTestKubeVirt creates a test KubeVirt resource with the given enabled feature gates
+func createTestKubeVirt(name, namespace string, gates ...string) *unstructured.Unstructured {
+	kv := &unstructured.Unstructured{}
+	kv.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "KubeVirt",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{},
+	})
+	if gates != nil {
+		_ = unstructured.SetNestedStringSlice(kv.Object, gates, featureGatesPath...)
+	}
+	return kv
+}
+
+func newFeatureGatesClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{KubeVirtGVR: "KubeVirtList"}, objects...)
+}
+
+func TestEnableFeatureGate(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialKV     *unstructured.Unstructured
+		gate          string
+		wantChanged   bool
+		wantGates     []string
+		errorContains string
+	}{
+		{
+			name:        "Enable LiveMigration without feature gates",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt"),
+			gate:        "LiveMigration",
+			wantChanged: true,
+			wantGates:   []string{"LiveMigration"},
+		},
+		{
+			name:        "Enable HotplugVolumes with other feature gates",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt", "Snapshot", "ExpandDisks"),
+			gate:        "HotplugVolumes",
+			wantChanged: true,
+			wantGates:   []string{"Snapshot", "ExpandDisks", "HotplugVolumes"},
+		},
+		{
+			name:        "Enable already enabled Snapshot",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt", "Snapshot"),
+			gate:        "Snapshot",
+			wantChanged: false,
+			wantGates:   []string{"Snapshot"},
+		},
+		{
+			name:          "Enable in non-existent KubeVirt",
+			initialKV:     createTestKubeVirt("other", "kubevirt"),
+			gate:          "Snapshot",
+			errorContains: "failed to get KubeVirt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFeatureGatesClient(tt.initialKV)
+			changed, err := EnableFeatureGate(context.Background(), client, "kubevirt", "kubevirt", tt.gate)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("expected changed=%v, got %v", tt.wantChanged, changed)
+			}
+			gates, err := GetFeatureGates(context.Background(), client, "kubevirt", "kubevirt")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(gates, tt.wantGates) {
+				t.Errorf("expected feature gates %v, got %v", tt.wantGates, gates)
+			}
+		})
+	}
+}
+
+func TestDisableFeatureGate(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialKV     *unstructured.Unstructured
+		gate          string
+		wantChanged   bool
+		wantGates     []string
+		errorContains string
+	}{
+		{
+			name:        "Disable LiveMigration",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt", "Snapshot", "LiveMigration", "HotplugVolumes"),
+			gate:        "LiveMigration",
+			wantChanged: true,
+			wantGates:   []string{"Snapshot", "HotplugVolumes"},
+		},
+		{
+			name:        "Disable the only enabled Snapshot",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt", "Snapshot"),
+			gate:        "Snapshot",
+			wantChanged: true,
+			wantGates:   []string{},
+		},
+		{
+			name:        "Disable not enabled HotplugVolumes",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt", "Snapshot"),
+			gate:        "HotplugVolumes",
+			wantChanged: false,
+			wantGates:   []string{"Snapshot"},
+		},
+		{
+			name:        "Disable without feature gates",
+			initialKV:   createTestKubeVirt("kubevirt", "kubevirt"),
+			gate:        "Snapshot",
+			wantChanged: false,
+			wantGates:   nil,
+		},
+		{
+			name:          "Disable in non-existent KubeVirt",
+			initialKV:     createTestKubeVirt("other", "kubevirt"),
+			gate:          "Snapshot",
+			errorContains: "failed to get KubeVirt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFeatureGatesClient(tt.initialKV)
+			changed, err := DisableFeatureGate(context.Background(), client, "kubevirt", "kubevirt", tt.gate)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("expected changed=%v, got %v", tt.wantChanged, changed)
+			}
+			gates, err := GetFeatureGates(context.Background(), client, "kubevirt", "kubevirt")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(gates, tt.wantGates) {
+				t.Errorf("expected feature gates %v, got %v", tt.wantGates, gates)
+			}
+		})
+	}
+}
+
+func TestFindKubeVirt(t *testing.T) {
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		wantName      string
+		errorContains string
+	}{
+		{
+			name:     "Finds the only KubeVirt",
+			objects:  []runtime.Object{createTestKubeVirt("kubevirt-kubevirt-hyperconverged", "openshift-cnv")},
+			wantName: "kubevirt-kubevirt-hyperconverged",
+		},
+		{
+			name:          "Fails without KubeVirt",
+			errorContains: "no KubeVirt resource found",
+		},
+		{
+			name:          "Fails with multiple KubeVirt",
+			objects:       []runtime.Object{createTestKubeVirt("kubevirt", "kubevirt"), createTestKubeVirt("kubevirt", "other")},
+			errorContains: "found 2 KubeVirt resources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kv, err := FindKubeVirt(context.Background(), newFeatureGatesClient(tt.objects...))
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if kv.GetName() != tt.wantName {
+				t.Errorf("expected KubeVirt %s, got %s", tt.wantName, kv.GetName())
+			}
+		})
+	}
+}
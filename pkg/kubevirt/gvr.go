@@ -31,6 +31,13 @@ var (
 		Resource: "virtualmachineinstances",
 	}
 
+	// KubeVirtGVR is the GroupVersionResource for the KubeVirt resources configuring the KubeVirt installation
+	KubeVirtGVR = schema.GroupVersionResource{
+		Group:    "kubevirt.io",
+		Version:  "v1",
+		Resource: "kubevirts",
+	}
+
 	// VirtualMachineInstanceSubresourcesGVR is the GroupVersionResource for VirtualMachineInstance subresources
 	VirtualMachineInstanceSubresourcesGVR = schema.GroupVersionResource{
 		Group:    "subresources.kubevirt.io",
@@ -115,4 +122,11 @@ var (
 		Version:  "v1",
 		Resource: "pods",
 	}
+
+	// StorageClassGVR is the GroupVersionResource for cluster-scoped StorageClass resources
+	StorageClassGVR = schema.GroupVersionResource{
+		Group:    "storage.k8s.io",
+		Version:  "v1",
+		Resource: "storageclasses",
+	}
 )
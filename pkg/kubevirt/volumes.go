@@ -0,0 +1,180 @@
+package kubevirt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// VM subresource names for volume hotplug
+	subresourceAddVolume    = "addvolume"
+	subresourceRemoveVolume = "removevolume"
+
+	// defaultHotplugDiskBus is the disk bus used for hotplugged disks
+	defaultHotplugDiskBus = "scsi"
+)
+
+// HotplugVolumeSource references the DataVolume or PersistentVolumeClaim backing a hotplugged volume.
+// Exactly one of them must be provided.
+type HotplugVolumeSource struct {
+	DataVolume            string
+	PersistentVolumeClaim string
+}
+
+// HotplugVolumeStatus holds the hotplug state of a volume of a VirtualMachine
+type HotplugVolumeStatus struct {
+	Namespace  string `json:"namespace" yaml:"namespace"`
+	Name       string `json:"name" yaml:"name"`
+	VolumeName string `json:"volumeName" yaml:"volumeName"`
+	// Pending is true while the hotplug request hasn't been processed by the VirtualMachine controller
+	Pending bool `json:"pending" yaml:"pending"`
+	// Status is the volume status reported by the VirtualMachineInstance, if any
+	Status map[string]any `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// AddVolume hotplugs the volume backed by the provided DataVolume or PersistentVolumeClaim to the VirtualMachine.
+// The volume is added to the VirtualMachine spec, so it persists across restarts.
+func AddVolume(ctx context.Context, restConfig *rest.Config, dynamicClient dynamic.Interface, namespace, name, volumeName string, source HotplugVolumeSource) (*HotplugVolumeStatus, error) {
+	volumeSource, err := hotplugVolumeSource(source)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = GetVirtualMachine(ctx, dynamicClient, namespace, name); err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachine: %w", err)
+	}
+	body := map[string]any{
+		"name": volumeName,
+		"disk": map[string]any{
+			"name": volumeName,
+			"disk": map[string]any{"bus": defaultHotplugDiskBus},
+		},
+		"volumeSource": volumeSource,
+	}
+	if err = putVMSubresource(ctx, restConfig, namespace, name, subresourceAddVolume, body); err != nil {
+		return nil, hotplugError("add", volumeName, namespace, name, err)
+	}
+	return GetVolumeStatus(ctx, dynamicClient, namespace, name, volumeName)
+}
+
+// RemoveVolume unplugs the hotplugged volume from the VirtualMachine.
+// Only hotplugged volumes can be removed, volumes defined in the VirtualMachine template are rejected by KubeVirt.
+func RemoveVolume(ctx context.Context, restConfig *rest.Config, dynamicClient dynamic.Interface, namespace, name, volumeName string) (*HotplugVolumeStatus, error) {
+	if _, err := GetVirtualMachine(ctx, dynamicClient, namespace, name); err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachine: %w", err)
+	}
+	if err := putVMSubresource(ctx, restConfig, namespace, name, subresourceRemoveVolume, map[string]any{"name": volumeName}); err != nil {
+		return nil, hotplugError("remove", volumeName, namespace, name, err)
+	}
+	return GetVolumeStatus(ctx, dynamicClient, namespace, name, volumeName)
+}
+
+// GetVolumeStatus returns the hotplug state of the volume: whether the VirtualMachine has a pending request for it
+// and the status reported by the VirtualMachineInstance (only available while the VM is running).
+func GetVolumeStatus(ctx context.Context, dynamicClient dynamic.Interface, namespace, name, volumeName string) (*HotplugVolumeStatus, error) {
+	vm, err := GetVirtualMachine(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachine: %w", err)
+	}
+	status := &HotplugVolumeStatus{Namespace: namespace, Name: name, VolumeName: volumeName}
+	requests, _, _ := unstructured.NestedSlice(vm.Object, "status", "volumeRequests")
+	for _, r := range requests {
+		request, ok := r.(map[string]any)
+		if ok && volumeRequestName(request) == volumeName {
+			status.Pending = true
+		}
+	}
+	vmi, err := dynamicClient.Resource(VirtualMachineInstanceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return status, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachineInstance: %w", err)
+	}
+	volumeStatuses, _, _ := unstructured.NestedSlice(vmi.Object, "status", "volumeStatus")
+	for _, vs := range volumeStatuses {
+		volumeStatus, ok := vs.(map[string]any)
+		if ok && volumeStatus["name"] == volumeName {
+			status.Status = volumeStatus
+		}
+	}
+	return status, nil
+}
+
+// hotplugVolumeSource returns the hotpluggable volume source of the AddVolumeOptions
+func hotplugVolumeSource(source HotplugVolumeSource) (map[string]any, error) {
+	switch {
+	case source.DataVolume != "" && source.PersistentVolumeClaim != "":
+		return nil, fmt.Errorf("only one of dataVolume or persistentVolumeClaim can be provided")
+	case source.DataVolume != "":
+		return map[string]any{
+			"dataVolume": map[string]any{"name": source.DataVolume, "hotpluggable": true},
+		}, nil
+	case source.PersistentVolumeClaim != "":
+		return map[string]any{
+			"persistentVolumeClaim": map[string]any{"claimName": source.PersistentVolumeClaim, "hotpluggable": true},
+		}, nil
+	default:
+		return nil, fmt.Errorf("either dataVolume or persistentVolumeClaim must be provided")
+	}
+}
+
+// volumeRequestName returns the name of the volume of an add or remove volume request
+func volumeRequestName(request map[string]any) string {
+	if name, found, _ := unstructured.NestedString(request, "addVolumeOptions", "name"); found {
+		return name
+	}
+	name, _, _ := unstructured.NestedString(request, "removeVolumeOptions", "name")
+	return name
+}
+
+// putVMSubresource sends the body to a VirtualMachine subresource using the REST client
+func putVMSubresource(ctx context.Context, restConfig *rest.Config, namespace, vmName, subresource string, body map[string]any) error {
+	// Create a copy to avoid mutating the original config
+	config := rest.CopyConfig(restConfig)
+
+	gv := schema.GroupVersion{Group: VirtualMachineInstanceSubresourcesGVR.Group, Version: VirtualMachineInstanceSubresourcesGVR.Version}
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	// The Kubernetes scheme decodes the metav1.Status of the subresource errors
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	restClient, err := rest.RESTClientFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to create REST client for subresources: %w", err)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", subresource, err)
+	}
+
+	return restClient.Put().
+		Namespace(namespace).
+		Resource(VirtualMachineGVR.Resource).
+		Name(vmName).
+		SubResource(subresource).
+		SetHeader("Content-Type", "application/json").
+		Body(data).
+		Do(ctx).
+		Error()
+}
+
+// hotplugError wraps the subresource error, explaining when the cluster doesn't support volume hotplug.
+// The VirtualMachine is retrieved before calling the subresource, a not found error means the subresource isn't served.
+func hotplugError(action, volumeName, namespace, name string, err error) error {
+	if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) ||
+		strings.Contains(strings.ToLower(err.Error()), "feature gate") {
+		return fmt.Errorf("failed to %s volume %s: volume hotplug is not supported for VirtualMachine %s/%s - ensure the HotplugVolumes feature gate is enabled: %w",
+			action, volumeName, namespace, name, err)
+	}
+	return fmt.Errorf("failed to %s volume %s for VirtualMachine %s/%s: %w", action, volumeName, namespace, name, err)
+}
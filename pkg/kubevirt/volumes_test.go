@@ -0,0 +1,196 @@
+package kubevirt
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+// createTestHotplugVMI creates a test VirtualMachineInstance with the given volume statuses
+func createTestHotplugVMI(name, namespace string, volumeStatus ...interface{}) *unstructured.Unstructured {
+	vmi := &unstructured.Unstructured{}
+	vmi.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachineInstance",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"status": map[string]interface{}{
+			"phase":        "Running",
+			"volumeStatus": volumeStatus,
+		},
+	})
+	return vmi
+}
+
+// newHotplugServer creates a test HTTP server for the VM volume subresources recording the received request bodies
+func newHotplugServer(t *testing.T, status int, response string, bodies map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT request, got %s", r.Method)
+		}
+		body := map[string]interface{}{}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		bodies[r.URL.Path] = body
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+}
+
+func TestAddVolume(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        HotplugVolumeSource
+		objects       []runtime.Object
+		status        int
+		response      string
+		wantBody      string
+		wantPending   bool
+		wantPhase     string
+		errorContains string
+	}{
+		{
+			name:   "adds DataVolume and returns the VMI volume status",
+			source: HotplugVolumeSource{DataVolume: "data-dv"},
+			objects: []runtime.Object{
+				createTestVM("test-vm", "default", RunStrategyAlways),
+				createTestHotplugVMI("test-vm", "default", map[string]interface{}{"name": "data", "phase": "Bound"}),
+			},
+			status:    http.StatusAccepted,
+			wantBody:  `{"disk":{"disk":{"bus":"scsi"},"name":"data"},"name":"data","volumeSource":{"dataVolume":{"hotpluggable":true,"name":"data-dv"}}}`,
+			wantPhase: "Bound",
+		},
+		{
+			name:   "adds PersistentVolumeClaim to a VM with a pending request",
+			source: HotplugVolumeSource{PersistentVolumeClaim: "data-pvc"},
+			objects: []runtime.Object{
+				func() *unstructured.Unstructured {
+					vm := createTestVM("test-vm", "default", RunStrategyAlways)
+					_ = unstructured.SetNestedSlice(vm.Object, []interface{}{
+						map[string]interface{}{"addVolumeOptions": map[string]interface{}{"name": "data"}},
+					}, "status", "volumeRequests")
+					return vm
+				}(),
+			},
+			status:      http.StatusAccepted,
+			wantBody:    `{"disk":{"disk":{"bus":"scsi"},"name":"data"},"name":"data","volumeSource":{"persistentVolumeClaim":{"claimName":"data-pvc","hotpluggable":true}}}`,
+			wantPending: true,
+		},
+		{
+			name:          "fails without volume source",
+			objects:       []runtime.Object{createTestVM("test-vm", "default", RunStrategyAlways)},
+			errorContains: "either dataVolume or persistentVolumeClaim must be provided",
+		},
+		{
+			name:          "fails with both volume sources",
+			source:        HotplugVolumeSource{DataVolume: "data-dv", PersistentVolumeClaim: "data-pvc"},
+			objects:       []runtime.Object{createTestVM("test-vm", "default", RunStrategyAlways)},
+			errorContains: "only one of dataVolume or persistentVolumeClaim can be provided",
+		},
+		{
+			name:          "fails for non-existent VM",
+			source:        HotplugVolumeSource{DataVolume: "data-dv"},
+			errorContains: "failed to get VirtualMachine",
+		},
+		{
+			name:          "fails gracefully when hotplug is not supported",
+			source:        HotplugVolumeSource{DataVolume: "data-dv"},
+			objects:       []runtime.Object{createTestVM("test-vm", "default", RunStrategyAlways)},
+			status:        http.StatusBadRequest,
+			response:      `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Unable to Add Volume, HotplugVolumes feature gate is not enabled.","reason":"BadRequest","code":400}`,
+			errorContains: "volume hotplug is not supported for VirtualMachine default/test-vm",
+		},
+		{
+			name:          "fails gracefully when the subresource is not served",
+			source:        HotplugVolumeSource{DataVolume: "data-dv"},
+			objects:       []runtime.Object{createTestVM("test-vm", "default", RunStrategyAlways)},
+			status:        http.StatusNotFound,
+			response:      `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"the server could not find the requested resource","reason":"NotFound","code":404}`,
+			errorContains: "volume hotplug is not supported for VirtualMachine default/test-vm",
+		},
+		{
+			name:          "fails with the API error",
+			source:        HotplugVolumeSource{DataVolume: "data-dv"},
+			objects:       []runtime.Object{createTestVM("test-vm", "default", RunStrategyAlways)},
+			status:        http.StatusConflict,
+			response:      `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Unable to add volume [data] because volume with that name already exists","reason":"Conflict","code":409}`,
+			errorContains: "failed to add volume data for VirtualMachine default/test-vm: Unable to add volume [data] because volume with that name already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies := map[string]map[string]interface{}{}
+			server := newHotplugServer(t, tt.status, tt.response, bodies)
+			defer server.Close()
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), tt.objects...)
+
+			result, err := AddVolume(context.Background(), createTestRESTConfig(server), client, "default", "test-vm", "data", tt.source)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			body, _ := json.Marshal(bodies["/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/test-vm/addvolume"])
+			if string(body) != tt.wantBody {
+				t.Errorf("expected body %s, got %s", tt.wantBody, body)
+			}
+			if result.VolumeName != "data" || result.Pending != tt.wantPending {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if phase, _ := result.Status["phase"].(string); phase != tt.wantPhase {
+				t.Errorf("expected volume status phase %q, got %q", tt.wantPhase, phase)
+			}
+		})
+	}
+}
+
+func TestRemoveVolume(t *testing.T) {
+	t.Run("removes the volume", func(t *testing.T) {
+		bodies := map[string]map[string]interface{}{}
+		server := newHotplugServer(t, http.StatusAccepted, "", bodies)
+		defer server.Close()
+		client := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+			createTestVM("test-vm", "default", RunStrategyAlways),
+			createTestHotplugVMI("test-vm", "default", map[string]interface{}{"name": "data", "phase": "Detaching"}))
+
+		result, err := RemoveVolume(context.Background(), createTestRESTConfig(server), client, "default", "test-vm", "data")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body := bodies["/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/test-vm/removevolume"]
+		if body["name"] != "data" {
+			t.Errorf("expected removevolume request for volume data, got %v", body)
+		}
+		if phase, _ := result.Status["phase"].(string); phase != "Detaching" {
+			t.Errorf("expected volume status phase Detaching, got %q", phase)
+		}
+	})
+	t.Run("fails for non hotplugged volume", func(t *testing.T) {
+		server := newHotplugServer(t, http.StatusBadRequest,
+			`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Unable to remove volume [rootdisk] because it is not hotpluggable","reason":"BadRequest","code":400}`,
+			map[string]map[string]interface{}{})
+		defer server.Close()
+		client := fake.NewSimpleDynamicClient(runtime.NewScheme(), createTestVM("test-vm", "default", RunStrategyAlways))
+
+		_, err := RemoveVolume(context.Background(), createTestRESTConfig(server), client, "default", "test-vm", "rootdisk")
+		if err == nil || !strings.Contains(err.Error(), "failed to remove volume rootdisk for VirtualMachine default/test-vm: Unable to remove volume [rootdisk] because it is not hotpluggable") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the HTTP header carrying the request ID used to correlate the log lines of a request.
+// A valid ID provided by the client (or a reverse proxy) is reused, otherwise the server generates one.
+const RequestIDHeader = "X-Request-Id"
+
+// validRequestID prevents log injection and unbounded values from client provided IDs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID returns the request ID of the HTTP headers if valid, a new random ID otherwise.
+func RequestID(header http.Header) string {
+	if id := HeaderRequestID(header); id != "" {
+		return id
+	}
+	return NewRequestID()
+}
+
+// HeaderRequestID returns the request ID of the HTTP headers, empty if missing or invalid.
+func HeaderRequestID(header http.Header) string {
+	if header != nil {
+		if id := header.Get(RequestIDHeader); validRequestID.MatchString(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// NewRequestID returns a new random request ID.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, read by the tracing, the Kubernetes client
+// (Audit-ID header) and the tool call error results so that they can be correlated with the server logs.
+// The ID must be generated by the server (see NewRequestID), client provided IDs must never reach the audit events.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID of the context, empty if none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
@@ -6,8 +6,8 @@
 // that "modifying the logger is not thread-safe and should be done while no
 // other goroutines invoke log calls, usually during program initialization."
 // We honor that by configuring klog exactly once in New and routing every
-// subsequent reload through a mutex-guarded writer that the textlogger
-// writes to.
+// subsequent reload through a mutex-guarded writer that the textlogger (or
+// the JSON logger, with log_format = "json") writes to.
 package logging
 
 import (
@@ -107,6 +107,11 @@ type Sink struct {
 //
 // On error, the caller does not need to Close — no file is opened.
 func New(cfg *config.StaticConfig, httpOut, errOut io.Writer) (*Sink, error) {
+	switch cfg.LogFormat {
+	case "", config.LogFormatText, config.LogFormatJSON:
+	default:
+		return nil, fmt.Errorf("invalid log_format %q: valid values are %s, %s", cfg.LogFormat, config.LogFormatText, config.LogFormatJSON)
+	}
 	s := &Sink{
 		httpOut:  httpOut,
 		errOut:   errOut,
@@ -123,16 +128,39 @@ func New(cfg *config.StaticConfig, httpOut, errOut io.Writer) (*Sink, error) {
 		_ = s.klogFlags.Set("v", strconv.Itoa(cfg.LogLevel))
 	}
 
-	cfgOpts := []textlogger.ConfigOption{
-		textlogger.Output(s),
-		textlogger.Verbosity(maxTextloggerVerbosity),
+	if cfg.LogFormat == config.LogFormatJSON {
+		klog.SetLoggerWithOptions(newJSONLogger(s))
+	} else {
+		cfgOpts := []textlogger.ConfigOption{
+			textlogger.Output(s),
+			textlogger.Verbosity(maxTextloggerVerbosity),
+		}
+		klog.SetLoggerWithOptions(textlogger.NewLogger(textlogger.NewConfig(cfgOpts...)))
 	}
-	klog.SetLoggerWithOptions(textlogger.NewLogger(textlogger.NewConfig(cfgOpts...)))
 
 	s.sdkLogger = slog.New(logr.ToSlogHandler(klog.Background()))
 	return s, nil
 }
 
+// newJSONLogger returns a logger writing one JSON object per line to w. Like the textlogger, it passes every
+// verbosity through (klog's -v flag is the gate). The slog level is replaced by the klog verbosity ("v"), errors
+// keep "level":"ERROR" and carry the error in "err".
+func newJSONLogger(w io.Writer) logr.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: slog.Level(-maxTextloggerVerbosity),
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) > 0 || attr.Key != slog.LevelKey {
+				return attr
+			}
+			if level, ok := attr.Value.Any().(slog.Level); ok && level < slog.LevelError {
+				return slog.Int("v", max(-int(level), 0))
+			}
+			return attr
+		},
+	})
+	return logr.FromSlogHandler(handler)
+}
+
 // Write implements io.Writer. It routes to whichever writer Reload most
 // recently installed, holding mu's read lock so a reload cannot close the
 // file mid-write (see mu).
@@ -1,10 +1,13 @@
 package logging_test
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -108,6 +111,47 @@ func (s *SinkSuite) TestNewWithBadLogFilePathFails() {
 	})
 }
 
+func (s *SinkSuite) TestNewWithJSONLogFormat() {
+	s.Run("writes one JSON object per line", func() {
+		s.newSink(&config.StaticConfig{LogLevel: 2, Port: "8080", LogFormat: config.LogFormatJSON})
+		klog.V(2).InfoS("hello-json", "key", "value")
+		klog.ErrorS(errors.New("boom"), "failed-json")
+		klog.Flush()
+		lines := strings.Split(strings.TrimSpace(s.httpOut.String()), "\n")
+		s.Require().Len(lines, 2)
+		var info, failure map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(lines[0]), &info))
+		s.Require().NoError(json.Unmarshal([]byte(lines[1]), &failure))
+		s.Run("info lines carry the message, the verbosity and the key values", func() {
+			s.Equal("hello-json", info["msg"])
+			s.Equal(float64(2), info["v"])
+			s.Equal("value", info["key"])
+			s.NotContains(info, "level")
+		})
+		s.Run("error lines carry the error level and the error", func() {
+			s.Equal("failed-json", failure["msg"])
+			s.Equal("ERROR", failure["level"])
+			s.Equal("boom", failure["err"])
+		})
+	})
+
+	s.Run("klog verbosity is still honored", func() {
+		s.newSink(&config.StaticConfig{LogLevel: 1, Port: "8080", LogFormat: config.LogFormatJSON})
+		s.httpOut.Reset()
+		klog.V(2).Info("should-be-filtered")
+		klog.Flush()
+		s.NotContains(s.httpOut.String(), "should-be-filtered")
+	})
+
+	s.Run("unknown log format fails before opening the log file", func() {
+		path := filepath.Join(s.tempDir, "never-created.log")
+		_, err := logging.New(&config.StaticConfig{LogFile: path, LogFormat: "logfmt"}, s.httpOut, s.errOut)
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid log_format "logfmt"`)
+		s.NoFileExists(path)
+	})
+}
+
 func (s *SinkSuite) TestReloadSwitchesLogFile() {
 	pathA := filepath.Join(s.tempDir, "a.log")
 	pathB := filepath.Join(s.tempDir, "b.log")
@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type AdmissionWebhooksSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *AdmissionWebhooksSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "admissionregistration.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "validatingwebhookconfigurations", Kind: "ValidatingWebhookConfiguration", Verbs: metav1.Verbs{"get", "list"}},
+			{Name: "mutatingwebhookconfigurations", Kind: "MutatingWebhookConfiguration", Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "discovery.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/admissionregistration.k8s.io/v1/validatingwebhookconfigurations":
+			_, _ = w.Write([]byte(`{"apiVersion":"admissionregistration.k8s.io/v1","kind":"ValidatingWebhookConfigurationList","items":[` +
+				`{"metadata":{"name":"policy"},"webhooks":[{"name":"validate.policy.example.com","failurePolicy":"Fail","timeoutSeconds":5,` +
+				`"namespaceSelector":{"matchLabels":{"policy":"enforced"}},` +
+				`"clientConfig":{"service":{"namespace":"policy-system","name":"policy-webhook","path":"/validate"}},` +
+				`"rules":[{"operations":["CREATE","UPDATE"],"apiGroups":["apps"],"apiVersions":["v1"],"resources":["deployments"],"scope":"Namespaced"}]}]}]}`))
+		case "/apis/admissionregistration.k8s.io/v1/mutatingwebhookconfigurations":
+			_, _ = w.Write([]byte(`{"apiVersion":"admissionregistration.k8s.io/v1","kind":"MutatingWebhookConfigurationList","items":[` +
+				`{"metadata":{"name":"injector"},"webhooks":[{"name":"inject.example.com","failurePolicy":"Ignore",` +
+				`"clientConfig":{"service":{"namespace":"injector-system","name":"injector","port":8443}},` +
+				`"rules":[{"operations":["CREATE"],"apiGroups":[""],"apiVersions":["v1"],"resources":["pods"]}]},` +
+				`{"name":"external.example.com","clientConfig":{"url":"https://webhook.example.com/mutate"},` +
+				`"rules":[{"operations":["*"],"apiGroups":["*"],"apiVersions":["*"],"resources":["*"]}]}]}]}`))
+		case "/api/v1/namespaces/policy-system/services/policy-webhook":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"policy-webhook","namespace":"policy-system"}}`))
+		case "/apis/discovery.k8s.io/v1/namespaces/policy-system/endpointslices":
+			_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[` +
+				`{"metadata":{"name":"policy-webhook-1"},"addressType":"IPv4","endpoints":[{"addresses":["10.0.0.1"],"conditions":{"ready":false}}]}]}`))
+		case "/api/v1/namespaces/injector-system/services/injector":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"injector","namespace":"injector-system"}}`))
+		case "/apis/discovery.k8s.io/v1/namespaces/injector-system/endpointslices":
+			_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[` +
+				`{"metadata":{"name":"injector-1"},"addressType":"IPv4","endpoints":[{"addresses":["10.0.0.2"],"conditions":{"ready":true}}]}]}`))
+		}
+	}))
+}
+
+func (s *AdmissionWebhooksSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *AdmissionWebhooksSuite) TestAdmissionWebhooks() {
+	s.InitMcpClient()
+	s.Run("admission_webhooks()", func() {
+		toolResult, err := s.CallTool("admission_webhooks", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the number of webhooks and flagged webhooks", func() {
+			s.Contains(text, "# The following 3 admission webhooks were found, 1 of them flagged with a Warning (YAML format):")
+		})
+		s.Run("returns the validating webhooks with their rules, failurePolicy and selectors", func() {
+			s.Contains(text, "Configuration: ValidatingWebhookConfiguration/policy")
+			s.Contains(text, "Webhook: validate.policy.example.com")
+			s.Contains(text, "- CREATE,UPDATE apps/v1/deployments (Namespaced)")
+			s.Contains(text, "FailurePolicy: Fail")
+			s.Contains(text, "TimeoutSeconds: 5")
+			s.Contains(text, "NamespaceSelector: policy=enforced")
+			s.Contains(text, "Service: policy-system/policy-webhook:443/validate")
+		})
+		s.Run("flags the webhooks whose service has no ready endpoints", func() {
+			s.Regexp(`Warning: Service policy-system/policy-webhook has no ready endpoints, calls to the\s+webhook fail, `+
+				`the requests matching its rules are rejected \(failurePolicy Fail\)`, text)
+		})
+		s.Run("returns the mutating webhooks", func() {
+			s.Contains(text, "Configuration: MutatingWebhookConfiguration/injector")
+			s.Contains(text, "- CREATE core/v1/pods")
+			s.Contains(text, "FailurePolicy: Ignore")
+			s.Contains(text, "Service: injector-system/injector:8443")
+		})
+		s.Run("returns the webhooks called by URL with the defaults", func() {
+			s.Contains(text, "URL: https://webhook.example.com/mutate")
+			s.Contains(text, "- '* */*/*'")
+			s.Contains(text, "TimeoutSeconds: 10")
+		})
+	})
+	s.Run("admission_webhooks(name=inject.example.com) returns the webhook", func() {
+		toolResult, err := s.CallTool("admission_webhooks", map[string]interface{}{"name": "inject.example.com"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 1 admission webhooks were found, 0 of them flagged with a Warning (YAML format):")
+		s.Contains(text, "Webhook: inject.example.com")
+		s.NotContains(text, "external.example.com")
+	})
+	s.Run("admission_webhooks(name=injector) returns the webhooks of the configuration", func() {
+		toolResult, err := s.CallTool("admission_webhooks", map[string]interface{}{"name": "injector"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 2 admission webhooks were found")
+		s.NotContains(text, "validate.policy.example.com")
+	})
+	s.Run("admission_webhooks(name=not-found) returns error", func() {
+		toolResult, _ := s.CallTool("admission_webhooks", map[string]interface{}{"name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "no webhook configuration or webhook named not-found found")
+	})
+}
+
+func (s *AdmissionWebhooksSuite) TestAdmissionWebhooksDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "admissionregistration.k8s.io", version = "v1" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("admission_webhooks (denied)", func() {
+		toolResult, err := s.CallTool("admission_webhooks", map[string]interface{}{})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: admissionregistration.k8s.io/v1, Kind=ValidatingWebhookConfiguration")
+		})
+	})
+}
+
+func TestAdmissionWebhooks(t *testing.T) {
+	suite.Run(t, new(AdmissionWebhooksSuite))
+}
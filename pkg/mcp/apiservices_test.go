@@ -0,0 +1,127 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type APIServicesSuite struct {
+	BaseMcpSuite
+	mockServer  *test.MockServer
+	apiServices string
+}
+
+func (s *APIServicesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "apiregistration.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "apiservices", Kind: "APIService", Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	s.apiServices = `{"apiVersion":"apiregistration.k8s.io/v1","kind":"APIServiceList","items":[` +
+		`{"metadata":{"name":"v1.apps"},"spec":{"group":"apps","version":"v1"},` +
+		`"status":{"conditions":[{"type":"Available","status":"True","reason":"Local","message":"Local APIServices are always available"}]}},` +
+		`{"metadata":{"name":"v1beta1.metrics.k8s.io"},"spec":{"group":"metrics.k8s.io","version":"v1beta1","service":{"namespace":"kube-system","name":"metrics-server"}},` +
+		`"status":{"conditions":[{"type":"Available","status":"False","reason":"MissingEndpoints","message":"endpoints for service/metrics-server in \"kube-system\" have no addresses","lastTransitionTime":"2025-10-29T09:00:00Z"}]}}` +
+		`]}`
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apiregistration.k8s.io/v1/apiservices":
+			_, _ = w.Write([]byte(s.apiServices))
+		case "/apis/apiregistration.k8s.io/v1/apiservices/v1.apps":
+			_, _ = w.Write([]byte(`{"apiVersion":"apiregistration.k8s.io/v1","kind":"APIService","metadata":{"name":"v1.apps"},"spec":{"group":"apps","version":"v1"},` +
+				`"status":{"conditions":[{"type":"Available","status":"True","reason":"Local","message":"Local APIServices are always available"}]}}`))
+		case "/apis/apiregistration.k8s.io/v1/apiservices/not-found":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"apiservices.apiregistration.k8s.io \"not-found\" not found","reason":"NotFound","code":404}`))
+		}
+	}))
+}
+
+func (s *APIServicesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *APIServicesSuite) TestAPIServicesStatus() {
+	s.InitMcpClient()
+	s.Run("apiservices_status()", func() {
+		toolResult, err := s.CallTool("apiservices_status", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the number of APIServices not Available", func() {
+			s.Contains(text, "# The following 1 of 2 APIServices are not Available (YAML format):")
+		})
+		s.Run("returns the APIServices not Available with their backing Service", func() {
+			s.Contains(text, "Name: v1beta1.metrics.k8s.io")
+			s.Contains(text, "Service: kube-system/metrics-server")
+			s.Contains(text, "Available: false")
+		})
+		s.Run("returns conditions", func() {
+			s.Contains(text, "Reason: MissingEndpoints")
+			s.Contains(text, "LastTransitionTime: \"2025-10-29T09:00:00Z\"")
+		})
+		s.Run("omits the Available APIServices", func() {
+			s.NotContains(text, "v1.apps")
+		})
+	})
+	s.Run("apiservices_status() with all APIServices Available", func() {
+		s.apiServices = `{"apiVersion":"apiregistration.k8s.io/v1","kind":"APIServiceList","items":[` +
+			`{"metadata":{"name":"v1.apps"},"spec":{"group":"apps","version":"v1"},"status":{"conditions":[{"type":"Available","status":"True"}]}}` +
+			`]}`
+		toolResult, err := s.CallTool("apiservices_status", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# All the 1 APIServices are Available", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("apiservices_status(name=v1.apps) returns status of Available APIService", func() {
+		toolResult, err := s.CallTool("apiservices_status", map[string]interface{}{"name": "v1.apps"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following APIService status (YAML format) was found:")
+		s.Contains(text, "Available: true")
+		s.Contains(text, "Service: Local")
+	})
+	s.Run("apiservices_status(name=not-found) returns error", func() {
+		toolResult, _ := s.CallTool("apiservices_status", map[string]interface{}{"name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get apiservices status: apiservices.apiregistration.k8s.io \"not-found\" not found")
+	})
+}
+
+func (s *APIServicesSuite) TestAPIServicesStatusDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apiregistration.k8s.io", version = "v1" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("apiservices_status (denied)", func() {
+		toolResult, err := s.CallTool("apiservices_status", map[string]interface{}{})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apiregistration.k8s.io/v1, Kind=APIService")
+		})
+	})
+}
+
+func TestAPIServices(t *testing.T) {
+	suite.Run(t, new(APIServicesSuite))
+}
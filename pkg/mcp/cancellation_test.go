@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type CancellationSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	listed     chan struct{}
+	aborted    chan struct{}
+}
+
+func (s *CancellationSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.listed = make(chan struct{})
+	s.aborted = make(chan struct{})
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/default/pods" {
+			return
+		}
+		// Hang the list until the client gives up
+		close(s.listed)
+		<-req.Context().Done()
+		close(s.aborted)
+	}))
+}
+
+func (s *CancellationSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CancellationSuite) TestCancelledToolCallAbortsKubernetesRequest() {
+	s.InitMcpClient()
+	ctx, cancel := context.WithCancel(s.T().Context())
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		_, err := s.Session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "pods_list_in_namespace",
+			Arguments: map[string]any{"namespace": "default"},
+		})
+		errs <- err
+	}()
+	select {
+	case <-s.listed:
+	case <-time.After(5 * time.Second):
+		s.FailNow("pods list request was not received")
+	}
+	cancel()
+	s.Run("client call returns cancellation error", func() {
+		select {
+		case err := <-errs:
+			s.ErrorIs(err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			s.Fail("tool call did not return after being cancelled")
+		}
+	})
+	s.Run("pods list request is aborted", func() {
+		select {
+		case <-s.aborted:
+		case <-time.After(5 * time.Second):
+			s.Fail("pods list request was not aborted after the tool call was cancelled")
+		}
+	})
+}
+
+func TestCancellation(t *testing.T) {
+	suite.Run(t, new(CancellationSuite))
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CapabilitiesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *CapabilitiesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		toolsets = [ "core", "kubevirt" ]
+	`), s.Cfg), "Expected to parse toolsets config")
+}
+
+func (s *CapabilitiesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CapabilitiesSuite) registered() (tools []string, prompts []string) {
+	toolsResult, err := s.ListTools()
+	s.Require().NoError(err, "Expected no error from ListTools")
+	for _, tool := range toolsResult.Tools {
+		tools = append(tools, tool.Name)
+	}
+	promptsResult, err := s.ListPrompts()
+	s.Require().NoError(err, "Expected no error from ListPrompts")
+	for _, prompt := range promptsResult.Prompts {
+		prompts = append(prompts, prompt.Name)
+	}
+	return tools, prompts
+}
+
+func (s *CapabilitiesSuite) TestWithoutKubeVirt() {
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.InitMcpClient()
+	tools, prompts := s.registered()
+	s.Run("doesn't register KubeVirt tools", func() {
+		s.NotContains(tools, "vm_create")
+		s.NotContains(tools, "vm_lifecycle")
+	})
+	s.Run("doesn't register KubeVirt prompts", func() {
+		s.NotContains(prompts, "vm-troubleshoot")
+	})
+	s.Run("registers tools and prompts not requiring capabilities", func() {
+		s.Contains(tools, "pods_list")
+		s.Contains(prompts, "cluster-health-check")
+	})
+}
+
+func (s *CapabilitiesSuite) TestWithKubeVirt() {
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "kubevirt.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "virtualmachineinstances", Kind: "VirtualMachineInstance", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	s.InitMcpClient()
+	tools, prompts := s.registered()
+	s.Run("registers KubeVirt tools", func() {
+		s.Contains(tools, "vm_create")
+		s.Contains(tools, "vm_lifecycle")
+	})
+	s.Run("registers KubeVirt prompts", func() {
+		s.Contains(prompts, "vm-troubleshoot")
+	})
+}
+
+func (s *CapabilitiesSuite) TestWithKubeVirtForceEnabled() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		enabled_capabilities = [ "kubevirt" ]
+	`), s.Cfg), "Expected to parse enabled capabilities config")
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.InitMcpClient()
+	tools, prompts := s.registered()
+	s.Run("registers KubeVirt tools", func() {
+		s.Contains(tools, "vm_create")
+	})
+	s.Run("registers KubeVirt prompts", func() {
+		s.Contains(prompts, "vm-troubleshoot")
+	})
+}
+
+func (s *CapabilitiesSuite) TestWithKubeVirtUninstalled() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		enabled_capabilities = [ "kubevirt" ]
+	`), s.Cfg), "Expected to parse enabled capabilities config")
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.InitMcpClient()
+	s.Run("vm_lifecycle explains that KubeVirt is required", func() {
+		toolResult, err := s.CallTool("vm_lifecycle", map[string]interface{}{"namespace": "ns-1", "name": "vm-1", "action": "start"})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "virtualmachines.kubevirt.io requires KubeVirt to be installed in the cluster: ")
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	suite.Run(t, new(CapabilitiesSuite))
+}
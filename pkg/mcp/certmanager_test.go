@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+const certificatesList = `{"apiVersion":"cert-manager.io/v1","kind":"CertificateList","items":[
+  {"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"web-tls","namespace":"default"},
+   "spec":{"secretName":"web-tls","dnsNames":["web.example.com"],"issuerRef":{"name":"letsencrypt","kind":"ClusterIssuer","group":"cert-manager.io"}},
+   "status":{"notAfter":"2099-01-01T00:00:00Z","renewalTime":"2098-12-01T00:00:00Z",
+     "conditions":[{"type":"Ready","status":"True","reason":"Ready","message":"Certificate is up to date and has not expired"}]}},
+  {"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"api-tls","namespace":"default"},
+   "spec":{"secretName":"api-tls","issuerRef":{"name":"internal-ca"}},
+   "status":{"notAfter":"2020-01-01T00:00:00Z","failedIssuanceAttempts":3,"lastFailureTime":"2020-01-02T00:00:00Z",
+     "conditions":[{"type":"Ready","status":"False","reason":"Expired","message":"Certificate expired on 2020-01-01"},
+       {"type":"Issuing","status":"True","reason":"Failed","message":"The certificate request has failed to complete"}]}}
+]}`
+
+const certificateRequestsList = `{"apiVersion":"cert-manager.io/v1","kind":"CertificateRequestList","items":[
+  {"apiVersion":"cert-manager.io/v1","kind":"CertificateRequest","metadata":{"name":"api-tls-1","namespace":"default","annotations":{"cert-manager.io/certificate-name":"api-tls"}},
+   "spec":{"issuerRef":{"name":"internal-ca"}},
+   "status":{"conditions":[{"type":"Approved","status":"True","reason":"cert-manager.io"},
+     {"type":"Ready","status":"False","reason":"Pending","message":"Referenced issuer does not have a Ready status condition"}]}},
+  {"apiVersion":"cert-manager.io/v1","kind":"CertificateRequest","metadata":{"name":"web-tls-1","namespace":"default","annotations":{"cert-manager.io/certificate-name":"web-tls"}},
+   "spec":{"issuerRef":{"name":"letsencrypt","kind":"ClusterIssuer"}},
+   "status":{"conditions":[{"type":"Ready","status":"True","reason":"Issued"}]}}
+]}`
+
+type CertManagerSuite struct {
+	BaseMcpSuite
+	mockServer       *test.MockServer
+	discoveryHandler *test.DiscoveryClientHandler
+}
+
+func (s *CertManagerSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.Cfg.Toolsets = []string{"cert-manager"}
+	s.discoveryHandler = test.NewDiscoveryClientHandler()
+	s.mockServer.Handle(s.discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/cert-manager.io/v1/certificates", "/apis/cert-manager.io/v1/namespaces/default/certificates":
+			_, _ = w.Write([]byte(certificatesList))
+		case "/apis/cert-manager.io/v1/certificaterequests":
+			_, _ = w.Write([]byte(certificateRequestsList))
+		}
+	}))
+}
+
+func (s *CertManagerSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CertManagerSuite) installCertManager() {
+	s.discoveryHandler.APIResourceLists = append(s.discoveryHandler.APIResourceLists, metav1.APIResourceList{
+		GroupVersion: "cert-manager.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "watch"}},
+			{Name: "certificaterequests", Kind: "CertificateRequest", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "watch"}},
+		},
+	})
+}
+
+func (s *CertManagerSuite) TestCertificatesList() {
+	s.installCertManager()
+	s.InitMcpClient()
+	s.Run("certmanager_certificates_list(namespace=default)", func() {
+		toolResult, err := s.CallTool("certmanager_certificates_list", map[string]interface{}{"namespace": "default"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the certificates", func() {
+			s.Contains(text, "# The following Certificates (YAML format) were found:")
+			s.Contains(text, "name: web-tls")
+			s.Contains(text, "name: api-tls")
+		})
+		s.Run("returns the issuer", func() {
+			s.Contains(text, "issuer: ClusterIssuer/letsencrypt")
+			s.Contains(text, "issuer: Issuer/internal-ca")
+		})
+		s.Run("returns the expiration and renewal times", func() {
+			s.Contains(text, "notAfter: \"2099-01-01T00:00:00Z\"")
+			s.Contains(text, "renewalTime: \"2098-12-01T00:00:00Z\"")
+		})
+		s.Run("returns the issuing state", func() {
+			s.Contains(text, "issuing:\n    message: The certificate request has failed to complete\n    reason: Failed\n    status: \"True\"")
+			s.Contains(text, "failedIssuanceAttempts: 3")
+		})
+		s.Run("flags expired certificates", func() {
+			s.Regexp(`warning: EXPIRED \d+y\d*d? ago`, text)
+		})
+	})
+}
+
+func (s *CertManagerSuite) TestCertificateRequestsList() {
+	s.installCertManager()
+	s.InitMcpClient()
+	s.Run("certmanager_certificaterequests_list()", func() {
+		toolResult, err := s.CallTool("certmanager_certificaterequests_list", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the certificate requests with their certificate", func() {
+			s.Contains(text, "certificate: api-tls")
+			s.Contains(text, "certificate: web-tls")
+		})
+		s.Run("returns the ready condition", func() {
+			s.Contains(text, "message: Referenced issuer does not have a Ready status condition")
+		})
+	})
+	s.Run("certmanager_certificaterequests_list(certificate=web-tls) filters by certificate", func() {
+		toolResult, err := s.CallTool("certmanager_certificaterequests_list", map[string]interface{}{"certificate": "web-tls"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "name: web-tls-1")
+		s.NotContains(text, "name: api-tls-1")
+	})
+}
+
+func (s *CertManagerSuite) TestNotInstalled() {
+	s.InitMcpClient()
+	for _, tool := range []string{"certmanager_certificates_list", "certmanager_certificaterequests_list"} {
+		s.Run(tool+" reports cert-manager is not installed", func() {
+			toolResult, err := s.CallTool(tool, map[string]interface{}{})
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+			s.Equal("cert-manager is not installed in the cluster (the cert-manager.io/v1 API is not available)",
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	}
+}
+
+func (s *CertManagerSuite) TestCertificatesListDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "cert-manager.io", version = "v1", kind = "Certificate" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.installCertManager()
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("certmanager_certificates_list", map[string]interface{}{})
+	s.Run("returns error", func() {
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: cert-manager.io/v1, Kind=Certificate")
+	})
+}
+
+func TestCertManager(t *testing.T) {
+	suite.Run(t, new(CertManagerSuite))
+}
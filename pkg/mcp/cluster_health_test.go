@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ClusterHealthSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ClusterHealthSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+}
+
+func (s *ClusterHealthSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ClusterHealthSuite) TestClusterComponentsHealth() {
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "componentstatuses", Kind: "ComponentStatus", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/readyz":
+			if _, verbose := req.URL.Query()["verbose"]; !verbose {
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("[+]ping ok\n[+]log ok\n[-]etcd failed: reason withheld\n[+]informer-sync ok\nreadyz check failed\n"))
+		case "/livez":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("[+]ping ok\n[+]log ok\n[+]etcd ok\nlivez check passed\n"))
+		case "/api/v1/componentstatuses":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ComponentStatusList","items":[` +
+				`{"metadata":{"name":"scheduler"},"conditions":[{"type":"Healthy","status":"True","message":"ok"}]},` +
+				`{"metadata":{"name":"etcd-0"},"conditions":[{"type":"Healthy","status":"False","error":"context deadline exceeded"}]}]}`))
+		}
+	}))
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("cluster_components_health", map[string]interface{}{})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("summarizes the failing checks", func() {
+		s.Contains(text, "# The following control-plane checks are failing:\n- /readyz: etcd failed: reason withheld\n- component etcd-0\n")
+	})
+	s.Run("returns the health endpoints checks", func() {
+		s.Regexp(`- Checks: 4\s+Endpoint: /readyz\s+Failing:\s+- 'etcd failed: reason withheld'\s+Healthy: false`, text)
+		s.Regexp(`- Checks: 3\s+Endpoint: /livez\s+Healthy: true`, text)
+	})
+	s.Run("returns the control-plane components status", func() {
+		s.Regexp(`- Healthy: true\s+Message: ok\s+Name: scheduler`, text)
+		s.Regexp(`- Error: context deadline exceeded\s+Healthy: false\s+Name: etcd-0`, text)
+	})
+}
+
+func (s *ClusterHealthSuite) TestClusterComponentsHealthRestricted() {
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/readyz" || req.URL.Path == "/livez" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"forbidden: User \"system:anonymous\" cannot get path \"` + req.URL.Path + `\""}`))
+		}
+	}))
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("cluster_components_health", map[string]interface{}{})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("reports the control-plane health as unknown", func() {
+		s.Contains(text, "# The API server health endpoints could not be queried, the control-plane health is unknown\n")
+	})
+	s.Run("explains the endpoints are restricted", func() {
+		s.Regexp(`Endpoint: /readyz\s+Healthy: false\s+Unavailable: access is restricted, as is common in managed clusters`, text)
+		s.Contains(text, "# Some health endpoints are restricted or unavailable, in managed clusters check the control-plane health in the provider console\n")
+	})
+	s.Run("reports the components status as unavailable", func() {
+		s.Contains(text, "# Control-plane components status is unavailable: not served by the API server\n")
+	})
+}
+
+func (s *ClusterHealthSuite) TestClusterInventory() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ConfigMap" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get"}},
+		metav1.APIResource{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+	)
+	discoveryHandler.AddAPIResourceList(metav1.APIResourceList{
+		GroupVersion: "events.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	s.mockServer.Handle(discoveryHandler)
+	var mu sync.Mutex
+	var accept []string
+	var requested []string
+	item := func(name string) string {
+		return `{"apiVersion":"meta.k8s.io/v1","kind":"PartialObjectMetadata","metadata":{"name":"` + name + `"}}`
+	}
+	list := func(w http.ResponseWriter, listMeta string, items ...string) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"PartialObjectMetadataList","metadata":{` + listMeta + `},"items":[` + strings.Join(items, ",") + `]}`))
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Only the list requests are limited, discovery requests are served by the discovery handler
+		if !req.URL.Query().Has("limit") {
+			return
+		}
+		mu.Lock()
+		accept = append(accept, req.Header.Get("Accept"))
+		requested = append(requested, req.URL.Path)
+		mu.Unlock()
+		switch req.URL.Path {
+		case "/api/v1/nodes":
+			list(w, `"continue":"next","remainingItemCount":2`, item("node-1"))
+		case "/api/v1/pods":
+			// No remainingItemCount, the objects are paged through
+			if req.URL.Query().Get("continue") == "" {
+				list(w, `"continue":"page-2"`, item("pod-1"))
+			} else {
+				list(w, ``, item("pod-2"), item("pod-3"), item("pod-4"), item("pod-5"))
+			}
+		case "/api/v1/secrets", "/api/v1/events", "/apis/events.k8s.io/v1/events":
+			list(w, ``)
+		case "/apis/apps/v1/deployments":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"deployments.apps is forbidden","reason":"Forbidden","code":403}`))
+		}
+	}))
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("cluster_inventory", map[string]interface{}{})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("summarizes the cluster size", func() {
+		s.Contains(text, "The cluster has 8 object(s) of 2 kind(s), 2 other kind(s) have no objects\n")
+	})
+	s.Run("returns the counts sorted by count", func() {
+		s.Regexp(`KIND\s+APIVERSION\s+NAMESPACED\s+COUNT\nPod\s+v1\s+true\s+5\nNode\s+v1\s+false\s+3\n`, text)
+	})
+	s.Run("reports the kinds that couldn't be counted", func() {
+		s.Contains(text, "Kinds that couldn't be counted:\n- Deployment.apps: deployments.apps is forbidden\n")
+	})
+	s.Run("skips the denied kinds", func() {
+		s.Contains(text, "Kinds skipped because they are denied by the server configuration: ConfigMap\n")
+		s.NotContains(requested, "/api/v1/configmaps")
+	})
+	s.Run("counts a kind served in several API groups once", func() {
+		s.Contains(requested, "/api/v1/events")
+		s.NotContains(requested, "/apis/events.k8s.io/v1/events")
+	})
+	s.Run("skips the subresources and the kinds that can't be listed", func() {
+		s.NotContains(requested, "/api/v1/pods/log")
+		s.NotContains(requested, "/api/v1/bindings")
+	})
+	s.Run("lists metadata only", func() {
+		s.Require().NotEmpty(accept)
+		for _, a := range accept {
+			s.Contains(a, "as=PartialObjectMetadataList")
+		}
+	})
+}
+
+func TestClusterHealth(t *testing.T) {
+	suite.Run(t, new(ClusterHealthSuite))
+}
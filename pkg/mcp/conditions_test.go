@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConditionsSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ConditionsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/ns-1/deployments/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns-1","generation":3},` +
+				`"status":{"observedGeneration":2,"conditions":[` +
+				`{"type":"Available","status":"False","reason":"MinimumReplicasUnavailable","message":"Deployment does not have minimum availability."},` +
+				`{"type":"Progressing","status":"True","reason":"ReplicaSetUpdated","message":"ReplicaSet \"web-1\" is progressing."},` +
+				`{"type":"ReplicaFailure","status":"True","reason":"FailedCreate","message":"pods \"web-1-x\" is forbidden: exceeded quota"}]}}`))
+		case "/apis/apps/v1/namespaces/ns-1/deployments/no-conditions":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"no-conditions","namespace":"ns-1"}}`))
+		case "/api/v1/nodes/node-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-1"},"status":{"conditions":[` +
+				`{"type":"MemoryPressure","status":"False","reason":"KubeletHasSufficientMemory"},` +
+				`{"type":"DiskPressure","status":"Unknown","reason":"NodeStatusUnknown","message":"Kubelet stopped posting node status."},` +
+				`{"type":"CustomCheck","status":"False"},` +
+				`{"type":"Ready","status":"True","reason":"KubeletReady"}]}}`))
+		}
+	}))
+}
+
+func (s *ConditionsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ConditionsSuite) TestConditionsExplain() {
+	s.InitMcpClient()
+	s.Run("conditions_explain with a Deployment", func() {
+		toolResult, err := s.CallTool("conditions_explain", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "ns-1", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns a summary", func() {
+			s.Contains(text, "# Deployment web: 2 of 3 conditions indicate a problem\n")
+		})
+		s.Run("flags False on a positive condition", func() {
+			s.Regexp(`polarity: Positive\n\s+problem: true\n\s+reason: MinimumReplicasUnavailable\n\s+status: "False"\n\s+type: Available\n`, text)
+		})
+		s.Run("flags True on a negative condition", func() {
+			s.Regexp(`polarity: Negative\n\s+problem: true\n\s+reason: FailedCreate\n\s+status: "True"\n\s+type: ReplicaFailure\n`, text)
+		})
+		s.Run("doesn't flag True on a positive condition", func() {
+			s.Regexp(`polarity: Positive\n\s+reason: ReplicaSetUpdated\n`, text)
+		})
+		s.Run("returns the next actions", func() {
+			s.Contains(text, "check the events with events_list")
+			s.Contains(text, "latest generation (3) yet (observed 2)")
+		})
+	})
+	s.Run("conditions_explain with a Node", func() {
+		toolResult, err := s.CallTool("conditions_explain", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Node", "name": "node-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("flags Unknown on a negative condition", func() {
+			s.Contains(text, "# Node node-1: 1 of 4 conditions indicate a problem\n")
+			s.Regexp(`polarity: Negative\n\s+problem: true\n\s+reason: NodeStatusUnknown\n\s+status: Unknown\n\s+type: DiskPressure\n`, text)
+			s.Contains(text, "DiskPressure is Unknown")
+		})
+		s.Run("doesn't flag conditions of unknown polarity", func() {
+			s.Regexp(`polarity: Unknown\n\s+status: "False"\n\s+type: CustomCheck\n`, text)
+		})
+	})
+	s.Run("conditions_explain with a resource without conditions", func() {
+		toolResult, err := s.CallTool("conditions_explain", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "ns-1", "name": "no-conditions"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("Deployment no-conditions has no status conditions", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("conditions_explain with missing name", func() {
+		toolResult, _ := s.CallTool("conditions_explain", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"})
+		s.True(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to explain conditions: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ConditionsSuite) TestConditionsExplainDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apps", version = "v1", kind = "Deployment" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("conditions_explain", map[string]interface{}{
+		"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "ns-1", "name": "web"})
+	s.True(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=Deployment")
+}
+
+func TestConditions(t *testing.T) {
+	suite.Run(t, new(ConditionsSuite))
+}
@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+var configArchiveObjects = map[string]string{
+	"/api/v1/namespaces/source/configmaps": `{"apiVersion":"v1","kind":"ConfigMapList","metadata":{},"items":[
+	  {"metadata":{"name":"kube-root-ca.crt","namespace":"source","uid":"1"},"data":{"ca.crt":"a-ca"}},
+	  {"metadata":{"name":"app-config","namespace":"source","uid":"2","resourceVersion":"7","creationTimestamp":"2025-10-29T09:00:00Z",
+	    "annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"},"managedFields":[{"manager":"kubectl"}]},
+	    "data":{"level":"debug"}}]}`,
+	"/api/v1/namespaces/source/secrets": `{"apiVersion":"v1","kind":"SecretList","metadata":{},"items":[
+	  {"type":"kubernetes.io/service-account-token","metadata":{"name":"default-token","namespace":"source","uid":"3"},"data":{"token":"dG9rZW4="}},
+	  {"type":"Opaque","metadata":{"name":"app-credentials","namespace":"source","uid":"4","resourceVersion":"8"},"data":{"password":"czNjcjN0"}}]}`,
+}
+
+type ConfigArchiveSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// applied records the paths and bodies of the apply (PATCH) requests received by the mock server
+	applied map[string]string
+}
+
+func (s *ConfigArchiveSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.applied = map[string]string{}
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if object, ok := configArchiveObjects[req.URL.Path]; ok && req.Method == http.MethodGet {
+			_, _ = w.Write([]byte(object))
+			return
+		}
+		if req.Method == http.MethodPatch && strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/target/") {
+			body, _ := io.ReadAll(req.Body)
+			s.applied[req.URL.Path] = string(body)
+			_, _ = w.Write(body)
+		}
+	}))
+}
+
+func (s *ConfigArchiveSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ConfigArchiveSuite) TestNamespaceConfigExport() {
+	s.InitMcpClient()
+	s.Run("namespace_config_export(namespace=source)", func() {
+		toolResult, err := s.CallTool("namespace_config_export", map[string]interface{}{"namespace": "source"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the ConfigMaps", func() {
+			s.Contains(text, "# The following 1 resources have been exported (multi-document YAML archive):")
+			s.Contains(text, "kind: ConfigMap")
+			s.Contains(text, "name: app-config")
+			s.Contains(text, "level: debug")
+		})
+		s.Run("skips the ConfigMaps generated by the cluster", func() {
+			s.NotContains(text, "kube-root-ca.crt")
+		})
+		s.Run("cleans the cluster-specific fields and the namespace", func() {
+			s.NotContains(text, "namespace:")
+			s.NotContains(text, "uid:")
+			s.NotContains(text, "resourceVersion:")
+			s.NotContains(text, "creationTimestamp:")
+			s.NotContains(text, "managedFields")
+			s.NotContains(text, "last-applied-configuration")
+		})
+		s.Run("does not export Secrets", func() {
+			s.NotContains(text, "app-credentials")
+		})
+	})
+	s.Run("namespace_config_export(includeSecrets=true) with default configuration returns error", func() {
+		toolResult, err := s.CallTool("namespace_config_export", map[string]interface{}{"namespace": "source", "includeSecrets": true})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "exporting Secrets is disabled")
+		s.NotContains(text, "czNjcjN0")
+	})
+}
+
+func (s *ConfigArchiveSuite) TestNamespaceConfigExportWithSecretsGetEnabled() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("namespace_config_export", map[string]interface{}{"namespace": "source", "includeSecrets": true})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("returns the ConfigMaps and Secrets as a multi-document YAML", func() {
+		s.Contains(text, "# The following 2 resources have been exported (multi-document YAML archive):")
+		s.Contains(text, "\n---\n")
+		s.Contains(text, "name: app-credentials")
+		s.Contains(text, "password: czNjcjN0")
+	})
+	s.Run("skips the Secrets generated by the cluster", func() {
+		s.NotContains(text, "default-token")
+	})
+}
+
+func (s *ConfigArchiveSuite) TestNamespaceConfigImport() {
+	s.InitMcpClient()
+	s.Run("namespace_config_import(namespace=target) rewrites the namespace", func() {
+		toolResult, err := s.CallTool("namespace_config_import", map[string]interface{}{
+			"namespace": "target",
+			"archive": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n  namespace: source\ndata:\n  level: debug\n" +
+				"---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: app-credentials\ntype: Opaque\ndata:\n  password: czNjcjN0\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the imported resources", func() {
+			s.Contains(text, "# The following 2 resources have been created or updated in namespace target:")
+			s.Contains(text, "- ConfigMap app-config")
+			s.Contains(text, "- Secret app-credentials")
+		})
+		s.Run("does not expose Secret values", func() {
+			s.NotContains(text, "czNjcjN0")
+		})
+		s.Run("applies the resources to the target namespace", func() {
+			s.Require().Contains(s.applied, "/api/v1/namespaces/target/configmaps/app-config")
+			s.Contains(s.applied["/api/v1/namespaces/target/configmaps/app-config"], `"namespace":"target"`)
+			s.Require().Contains(s.applied, "/api/v1/namespaces/target/secrets/app-credentials")
+			s.Contains(s.applied["/api/v1/namespaces/target/secrets/app-credentials"], `"namespace":"target"`)
+		})
+	})
+	s.Run("namespace_config_import with other resources returns error", func() {
+		s.applied = map[string]string{}
+		toolResult, err := s.CallTool("namespace_config_import", map[string]interface{}{
+			"namespace": "target",
+			"archive": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n" +
+				"---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: a-pod\n",
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "unsupported resource v1 Pod a-pod in archive, only v1 ConfigMaps and Secrets can be imported")
+		s.Empty(s.applied, "no resources should be applied")
+	})
+	s.Run("namespace_config_import(missing archive) returns error", func() {
+		toolResult, _ := s.CallTool("namespace_config_import", map[string]interface{}{"namespace": "target"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "archive")
+	})
+}
+
+func (s *ConfigArchiveSuite) TestNamespaceConfigDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ConfigMap" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("namespace_config_export (denied)", func() {
+		toolResult, err := s.CallTool("namespace_config_export", map[string]interface{}{"namespace": "source"})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=ConfigMap")
+	})
+	s.Run("namespace_config_import (denied)", func() {
+		toolResult, err := s.CallTool("namespace_config_import", map[string]interface{}{
+			"namespace": "target",
+			"archive":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\ndata:\n  level: debug\n",
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=ConfigMap")
+		s.Empty(s.applied, "no resources should be applied")
+	})
+}
+
+func TestConfigArchive(t *testing.T) {
+	suite.Run(t, new(ConfigArchiveSuite))
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ConfigMapsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *ConfigMapsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_ = client.CoreV1().ConfigMaps("default").Delete(s.T().Context(), "a-configmap-to-patch", metav1.DeleteOptions{})
+	_, err := client.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-configmap-to-patch"},
+		Data:       map[string]string{"existing": "untouched", "to-remove": "value"},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create configmap")
+}
+
+func (s *ConfigMapsSuite) TestConfigMapSetKey() {
+	s.InitMcpClient()
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	s.Run("configmap_set_key with missing key", func() {
+		toolResult, _ := s.CallTool("configmap_set_key", map[string]interface{}{"name": "a-configmap-to-patch"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to set configmap key: key parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("configmap_set_key with missing value", func() {
+		toolResult, _ := s.CallTool("configmap_set_key", map[string]interface{}{"name": "a-configmap-to-patch", "key": "new"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "value parameter required")
+	})
+	s.Run("configmap_set_key with not found name", func() {
+		toolResult, _ := s.CallTool("configmap_set_key", map[string]interface{}{"namespace": "default", "name": "not-found", "key": "k", "value": "v"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "configmaps \"not-found\" not found")
+	})
+	s.Run("configmap_set_key(key=new, value=added)", func() {
+		toolResult, err := s.CallTool("configmap_set_key", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-configmap-to-patch",
+			"key":       "new",
+			"value":     "added",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns updated configmap", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "new: added")
+		})
+		s.Run("sets key and preserves other keys", func() {
+			cm, _ := client.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-configmap-to-patch", metav1.GetOptions{})
+			s.Equal(map[string]string{"existing": "untouched", "to-remove": "value", "new": "added"}, cm.Data)
+		})
+	})
+	s.Run("configmap_set_key(key=to-remove, remove=true)", func() {
+		toolResult, err := s.CallTool("configmap_set_key", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-configmap-to-patch",
+			"key":       "to-remove",
+			"remove":    true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("removes key and preserves other keys", func() {
+			cm, _ := client.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-configmap-to-patch", metav1.GetOptions{})
+			s.Equal(map[string]string{"existing": "untouched", "new": "added"}, cm.Data)
+		})
+	})
+}
+
+func (s *ConfigMapsSuite) TestConfigMapSetKeyDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ConfigMap" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("configmap_set_key (denied)", func() {
+		toolResult, err := s.CallTool("configmap_set_key", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-configmap-to-patch",
+			"key":       "new",
+			"value":     "added",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=ConfigMap")
+		})
+	})
+}
+
+func TestConfigMaps(t *testing.T) {
+	suite.Run(t, new(ConfigMapsSuite))
+}
@@ -0,0 +1,258 @@
+package mcp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConnectivitySuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	commands   [][]string
+}
+
+func (s *ConnectivitySuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.commands = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "networkpolicies", Kind: "NetworkPolicy", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	// The probe reaches the server Pod and Service, everything else times out
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/namespaces/ns-1/pods/client/exec" {
+			return
+		}
+		command := req.URL.Query()["command"]
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		s.mu.Unlock()
+		var stdout, stderr bytes.Buffer
+		ctx, err := test.CreateHTTPStreams(w, req, &test.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		defer func() { _ = ctx.Close() }()
+		switch target := command[4]; target {
+		case "10.0.0.2":
+			_, _ = io.WriteString(ctx.StdoutStream, "connectivity-test-exit-code=0\n")
+		case "http://10.96.0.10:80/":
+			_, _ = io.WriteString(ctx.StdoutStream, "HTTP 200\nconnectivity-test-exit-code=0\n")
+		default:
+			_, _ = io.WriteString(ctx.StderrStream, "nc: "+target+" ("+target+":"+command[5]+"): Connection timed out\n")
+			_, _ = io.WriteString(ctx.StdoutStream, "connectivity-test-exit-code=1\n")
+		}
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		write := func(body string) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(body))
+		}
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1/pods/client":
+			write(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"client","namespace":"ns-1","labels":{"app":"client"}},` +
+				`"spec":{"containers":[{"name":"client"}]},"status":{"phase":"Running","podIP":"10.0.0.1"}}`)
+		case "/api/v1/namespaces/ns-1/pods/completed":
+			write(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"completed","namespace":"ns-1"},` +
+				`"spec":{"containers":[{"name":"job"}]},"status":{"phase":"Succeeded"}}`)
+		case "/api/v1/namespaces/ns-1/pods/server":
+			write(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"server","namespace":"ns-1","labels":{"app":"server"}},` +
+				`"status":{"phase":"Running","podIP":"10.0.0.2"}}`)
+		case "/api/v1/namespaces/ns-2/pods/db":
+			write(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"db","namespace":"ns-2","labels":{"app":"db"}},` +
+				`"status":{"phase":"Running","podIP":"10.0.0.3"}}`)
+		case "/api/v1/namespaces/ns-1/services/server":
+			write(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"server","namespace":"ns-1"},` +
+				`"spec":{"clusterIP":"10.96.0.10","selector":{"app":"server"},"ports":[{"port":80,"targetPort":8080}]}}`)
+		case "/api/v1/namespaces/ns-1/pods":
+			write(`{"apiVersion":"v1","kind":"PodList","items":[]}`)
+		case "/apis/networking.k8s.io/v1/namespaces/ns-1/networkpolicies":
+			write(`{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicyList","items":[]}`)
+		case "/apis/networking.k8s.io/v1/namespaces/ns-2/networkpolicies":
+			write(`{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicyList","items":[` +
+				`{"metadata":{"name":"deny-db-ingress","namespace":"ns-2"},"spec":{"podSelector":{"matchLabels":{"app":"db"}},"policyTypes":["Ingress"]}}` +
+				`]}`)
+		}
+	}))
+}
+
+func (s *ConnectivitySuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+// lastCommand returns the command of the last probe run in the source Pod
+func (s *ConnectivitySuite) lastCommand() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.commands) == 0 {
+		return nil
+	}
+	return s.commands[len(s.commands)-1]
+}
+
+func (s *ConnectivitySuite) TestConnectivityTest() {
+	s.InitMcpClient()
+	s.Run("connectivity_test(target=pod/server) reachable", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "pod/server", "port": 8080,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the target is reachable", func() {
+			s.Contains(text, "# ns-1/client can reach pod/server (tcp 10.0.0.2:8080)\n")
+			s.Contains(text, "result: Reachable\n")
+		})
+		s.Run("probes the Pod IP from the source Pod with the target passed as arguments", func() {
+			command := s.lastCommand()
+			s.Equal([]string{"sh", "-c"}, command[:2])
+			s.Contains(command[2], "nc -z -w")
+			s.Equal([]string{"sh", "10.0.0.2", "8080", "5"}, command[3:])
+		})
+		s.Run("doesn't analyze the NetworkPolicies", func() {
+			s.NotContains(text, "sourceEgress")
+			s.NotContains(text, "targetIngress")
+		})
+	})
+	s.Run("connectivity_test(target=pod/db, targetNamespace=ns-2) blocked by a NetworkPolicy", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "pod/db", "targetNamespace": "ns-2", "port": 5432, "timeout": "2s",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the target is unreachable with the probe output", func() {
+			s.Contains(text, "# ns-1/client can't reach pod/db (tcp 10.0.0.3:5432), the NetworkPolicies that apply are below\n")
+			s.Contains(text, "result: Unreachable\n")
+			s.Contains(text, "output: 'nc: 10.0.0.3 (10.0.0.3:5432): Connection timed out'\n")
+			s.NotContains(text, "connectivity-test-exit-code")
+		})
+		s.Run("uses the provided timeout", func() {
+			s.Equal("2", s.lastCommand()[6])
+		})
+		s.Run("explains the NetworkPolicies of the source and the target", func() {
+			s.Contains(text, "sourceEgress:\n  Isolated: false\n")
+			s.Contains(text, "targetIngress:\n  DefaultDeny: true\n  Isolated: true\n  IsolatedBy:\n  - deny-db-ingress\n")
+			s.Contains(text, "The ingress of the target Pod is restricted by NetworkPolicies")
+			s.Contains(text, "targetPod: db\n")
+		})
+	})
+	s.Run("connectivity_test(target=service/server, protocol=http) reachable", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "service/server", "protocol": "http",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# ns-1/client can reach service/server (http 10.96.0.10:80)\n")
+		s.Contains(text, "output: HTTP 200\n")
+		command := s.lastCommand()
+		s.Contains(command[2], "curl")
+		s.Equal("http://10.96.0.10:80/", command[4])
+		s.Run("reports the wget status line as curl does", func() {
+			s.Contains(command[2], `wget -S -q -T "$3" -O /dev/null "$1" 2>&1`)
+			s.Contains(command[2], `if [ -n "$status" ]; then echo "$status"; else echo "$out" >&2; exit $rc; fi`)
+		})
+	})
+	s.Run("connectivity_test(target=example.com:443) without restricting NetworkPolicies", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "example.com:443",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# ns-1/client can't reach example.com:443 (tcp example.com:443)")
+		s.Contains(text, "No NetworkPolicy restricts this traffic")
+		s.NotContains(text, "targetIngress")
+	})
+	s.Run("connectivity_test from a completed pod can't run the probe", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "completed", "target": "pod/server", "port": 8080,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The connectivity of ns-1/completed to pod/server (tcp 10.0.0.2:8080) couldn't be tested")
+		s.Contains(text, "result: Unknown\n")
+		s.Contains(text, "cannot exec into a container in a completed pod")
+	})
+	s.Run("connectivity_test(target=pod/server) without port returns error", func() {
+		toolResult, _ := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "pod/server",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "port is required for a pod target")
+	})
+	s.Run("connectivity_test(target=deployment/server) returns error", func() {
+		toolResult, _ := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "deployment/server", "port": 80,
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "unsupported target \"deployment/server\"")
+	})
+	s.Run("connectivity_test(target=-oProxyCommand:22) returns error", func() {
+		command := s.lastCommand()
+		toolResult, _ := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "-oProxyCommand:22",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid host \"-oProxyCommand\" in target \"-oProxyCommand:22\"")
+		s.Equal(command, s.lastCommand(), "the probe should not run")
+	})
+	s.Run("connectivity_test(timeout=invalid) returns error", func() {
+		toolResult, _ := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "pod/server", "port": 80, "timeout": "soon",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid timeout \"soon\"")
+	})
+}
+
+func (s *ConnectivitySuite) TestConnectivityTestDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "networking.k8s.io", version = "v1", kind = "NetworkPolicy" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("connectivity_test of an unreachable target (denied)", func() {
+		toolResult, err := s.CallTool("connectivity_test", map[string]interface{}{
+			"namespace": "ns-1", "pod": "client", "target": "pod/db", "targetNamespace": "ns-2", "port": 5432,
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: networking.k8s.io/v1, Kind=NetworkPolicy")
+		})
+	})
+}
+
+func TestConnectivity(t *testing.T) {
+	suite.Run(t, new(ConnectivitySuite))
+}
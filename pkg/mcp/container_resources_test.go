@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+const containerResourcesDeployment = `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"},` +
+	`"spec":{"paused":%PAUSED%,"selector":{"matchLabels":{"app":"web"}},"template":{"spec":{` +
+	`"initContainers":[{"name":"init","image":"busybox"}],` +
+	`"containers":[{"name":"app","image":"nginx","resources":{"requests":{"cpu":"100m","memory":"128Mi"},"limits":{"memory":"256Mi"}}},` +
+	`{"name":"sidecar","image":"envoy"}]}}}}`
+
+type ContainerResourcesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	patches    []string
+	paused     string
+}
+
+func (s *ContainerResourcesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.patches = nil
+	s.paused = "false"
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/apis/apps/v1/namespaces/default/deployments/web" {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if req.Method == http.MethodPatch {
+			body, _ := io.ReadAll(req.Body)
+			s.patches = append(s.patches, req.Header.Get("Content-Type")+" "+string(body))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(strings.ReplaceAll(containerResourcesDeployment, "%PAUSED%", s.paused)))
+	}))
+}
+
+func (s *ContainerResourcesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ContainerResourcesSuite) recordedPatches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.patches...)
+}
+
+func (s *ContainerResourcesSuite) TestSetContainerResources() {
+	s.InitMcpClient()
+	s.Run("resources_set_container_resources with unknown container returns error", func() {
+		toolResult, _ := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "missing", "cpuLimit": "1",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to set container resources of Deployment web: container missing not found in Deployment web (available containers: init, app, sidecar)",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_set_container_resources with invalid quantity returns error", func() {
+		toolResult, _ := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "app", "memoryLimit": "2 gigs",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `invalid memory limit "2 gigs"`)
+	})
+	s.Run("resources_set_container_resources with request exceeding the limit returns error", func() {
+		toolResult, _ := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "app", "memoryRequest": "512Mi",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "the memory request (512Mi) can't exceed the memory limit (256Mi)")
+	})
+	s.Run("resources_set_container_resources without values returns the current resources", func() {
+		toolResult, err := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "deployment", "name": "web", "container": "app",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# Resources of container app of deployment web (unchanged)\n")
+		s.Contains(text, "requests:\n    cpu: 100m\n    memory: 128Mi\n")
+	})
+	s.Run("resources_set_container_resources with the current values doesn't patch", func() {
+		toolResult, err := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "app", "cpuRequest": "0.1",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "(unchanged)")
+	})
+	s.Run("no patch sent for invalid or unchanged values", func() {
+		s.Empty(s.recordedPatches())
+	})
+	s.Run("resources_set_container_resources patches the provided values", func() {
+		toolResult, err := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "app", "cpuLimit": "500m", "memoryRequest": "200Mi", "memoryLimit": "none",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the previous and current resources", func() {
+			s.Contains(text, "# Resources of container app of Deployment web updated\n")
+			s.Contains(text, "Previous:\n  limits:\n    memory: 256Mi\n")
+			s.Contains(text, "Resources:\n  limits:\n    cpu: 500m\n  requests:\n    cpu: 100m\n    memory: 200Mi\n")
+			s.Contains(text, "Rollout: triggered")
+		})
+		s.Run("sends a strategic merge patch of the container values", func() {
+			patches := s.recordedPatches()
+			s.Require().Len(patches, 1)
+			s.Equal("application/strategic-merge-patch+json "+
+				`{"spec":{"template":{"spec":{"containers":[{"name":"app","resources":{"limits":{"cpu":"500m","memory":null},"requests":{"memory":"200Mi"}}}]}}}}`,
+				patches[0])
+		})
+	})
+	s.Run("resources_set_container_resources of an init container", func() {
+		toolResult, err := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "init", "memoryLimit": "64Mi",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		patches := s.recordedPatches()
+		s.Require().Len(patches, 2)
+		s.Contains(patches[1], `{"initContainers":[{"name":"init","resources":{"limits":{"memory":"64Mi"}}}]}`)
+	})
+	s.Run("resources_set_container_resources of a paused Deployment", func() {
+		s.mu.Lock()
+		s.paused = "true"
+		s.mu.Unlock()
+		toolResult, err := s.CallTool("resources_set_container_resources", map[string]interface{}{
+			"kind": "Deployment", "name": "web", "container": "sidecar", "cpuRequest": "50m",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "Rollout: pending, the Deployment is paused")
+	})
+}
+
+func TestContainerResources(t *testing.T) {
+	suite.Run(t, new(ContainerResourcesSuite))
+}
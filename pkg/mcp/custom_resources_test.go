@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CustomResourcesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// listRequests records the list requests (query) received by the mock server
+	listRequests []string
+}
+
+func (s *CustomResourcesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.listRequests = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "widgets", SingularName: "widget", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	widget := func(namespace, name, phase string) string {
+		return `{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"namespace":"` + namespace + `","name":"` + name + `","labels":{"app":"widgets"}},` +
+			`"status":{"phase":"` + phase + `","conditions":[{"type":"Ready","status":"` + map[bool]string{true: "True", false: "False"}[phase == "Running"] + `"}]}}`
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/example.com/v1/widgets":
+			s.listRequests = append(s.listRequests, req.URL.RawQuery)
+			// Two pages to verify the query keeps listing until the end of the collection
+			if req.URL.Query().Get("continue") == "" {
+				_, _ = w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"WidgetList","metadata":{"continue":"page-2"},"items":[` +
+					widget("ns-1", "widget-1", "Running") + `,` + widget("ns-1", "widget-2", "Failed") + `]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"WidgetList","metadata":{},"items":[` +
+				widget("ns-2", "widget-3", "Failed") + `,` + widget("ns-2", "widget-4", "Running") + `]}`))
+		case "/apis/example.com/v1/namespaces/ns-2/widgets":
+			s.listRequests = append(s.listRequests, req.URL.RawQuery)
+			_, _ = w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"WidgetList","metadata":{},"items":[` +
+				widget("ns-2", "widget-3", "Failed") + `,` + widget("ns-2", "widget-4", "Running") + `]}`))
+		}
+	}))
+}
+
+func (s *CustomResourcesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *CustomResourcesSuite) TestCustomResourcesQuery() {
+	s.InitMcpClient()
+	s.Run("custom_resources_query(resource=widgets) returns all resources across pages", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"version":  "v1",
+			"resource": "widgets",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the number of matching resources", func() {
+			s.Contains(text, "# The following 4 widgets.example.com match the query (YAML format):")
+		})
+		s.Run("returns the resources of every page", func() {
+			s.Contains(text, "name: widget-1")
+			s.Contains(text, "name: widget-4")
+		})
+		s.Run("lists the resources in pages", func() {
+			s.Require().Len(s.listRequests, 2)
+			s.Contains(s.listRequests[0], "limit=500")
+			s.Contains(s.listRequests[1], "continue=page-2")
+		})
+	})
+	s.Run("custom_resources_query(filter=.status.phase==Failed) returns matching resources", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "widget",
+			"filter":   ".status.phase==Failed",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 2 widgets.example.com match the query (YAML format):")
+		s.Contains(text, "name: widget-2")
+		s.Contains(text, "name: widget-3")
+		s.NotContains(text, "name: widget-1")
+		s.NotContains(text, "name: widget-4")
+	})
+	s.Run("custom_resources_query(filter={.status.conditions[?(@.type==\"Ready\")].status}!=True) returns matching resources", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "widgets",
+			"filter":   `{.status.conditions[?(@.type=="Ready")].status}!=True`,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 2 widgets.example.com match the query (YAML format):")
+		s.Contains(text, "name: widget-2")
+		s.Contains(text, "name: widget-3")
+	})
+	s.Run("custom_resources_query(namespace=ns-2, labelSelector=app=widgets) lists the namespace with the selector", func() {
+		s.listRequests = nil
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":         "example.com",
+			"resource":      "widgets",
+			"namespace":     "ns-2",
+			"labelSelector": "app=widgets",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 2 widgets.example.com match the query (YAML format):")
+		s.NotContains(text, "namespace: ns-1")
+		s.Require().Len(s.listRequests, 1)
+		s.Contains(s.listRequests[0], "labelSelector=app%3Dwidgets")
+	})
+	s.Run("custom_resources_query(limit=1) caps the returned resources", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "widgets",
+			"filter":   ".status.phase==Failed",
+			"limit":    1,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 1 widgets.example.com match the query (YAML format):")
+		s.Contains(text, "name: widget-2")
+		s.NotContains(text, "name: widget-3")
+		s.Contains(text, "# More widgets.example.com match the query but were not shown (limit of 1 reached)")
+	})
+	s.Run("custom_resources_query(filter=.status.phase==Unknown) returns no resources", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "widgets",
+			"filter":   ".status.phase==Unknown",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# No widgets.example.com matching the query were found (4 scanned)", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("custom_resources_query(filter=.status.phase=Failed) returns error", func() {
+		toolResult, _ := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "widgets",
+			"filter":   ".status.phase=Failed",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to query custom resources: invalid filter .status.phase=Failed")
+	})
+	s.Run("custom_resources_query(resource=gadgets) returns error", func() {
+		toolResult, _ := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"resource": "gadgets",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to query custom resources:")
+	})
+	s.Run("custom_resources_query(missing resource) returns error", func() {
+		toolResult, _ := s.CallTool("custom_resources_query", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource")
+	})
+}
+
+func (s *CustomResourcesSuite) TestCustomResourcesQueryDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "example.com", version = "v1", kind = "Widget" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("custom_resources_query (denied)", func() {
+		toolResult, err := s.CallTool("custom_resources_query", map[string]interface{}{
+			"group":    "example.com",
+			"version":  "v1",
+			"resource": "widgets",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: example.com/v1, Kind=Widget")
+		})
+		s.Run("does not list the resources", func() {
+			s.Empty(s.listRequests)
+		})
+	})
+}
+
+func TestCustomResources(t *testing.T) {
+	suite.Run(t, new(CustomResourcesSuite))
+}
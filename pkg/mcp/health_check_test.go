@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HealthCheckSuite struct {
+	BaseMcpSuite
+	mockServer       *test.MockServer
+	discoveryHandler *test.DiscoveryClientHandler
+}
+
+func (s *HealthCheckSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.discoveryHandler = test.NewDiscoveryClientHandler()
+	s.discoveryHandler.APIResourceLists[0].APIResources = append(s.discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.discoveryHandler.APIResourceLists[1].APIResources = append(s.discoveryHandler.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "daemonsets", Kind: "DaemonSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(s.discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns-1"}}`))
+		case "/api/v1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[{"metadata":{"name":"node-1"},` +
+				`"status":{"conditions":[{"type":"Ready","status":"True"}]}}]}`))
+		case "/api/v1/namespaces/ns-1/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[{"metadata":{"name":"web","namespace":"ns-1"},"status":{"phase":"Running"}}]}`))
+		case "/apis/apps/v1/namespaces/ns-1/deployments":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"DeploymentList","items":[]}`))
+		case "/apis/apps/v1/namespaces/ns-1/statefulsets":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"StatefulSetList","items":[]}`))
+		case "/apis/apps/v1/namespaces/ns-1/daemonsets":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"DaemonSetList","items":[]}`))
+		case "/api/v1/namespaces/ns-1/persistentvolumeclaims":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PersistentVolumeClaimList","items":[]}`))
+		case "/api/v1/namespaces/ns-1/events":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"events is forbidden: User \"test\" cannot list resource \"events\" in API group \"\" in the namespace \"ns-1\""}`))
+		}
+	}))
+}
+
+func (s *HealthCheckSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *HealthCheckSuite) TestClusterHealthCheckPartialFailures() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apps", version = "v1", kind = "Deployment" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	result, err := s.GetPrompt("cluster-health-check", map[string]string{"namespace": "ns-1"})
+	s.Run("prompt executes without error", func() {
+		s.Require().NoError(err)
+		s.Require().NotEmpty(result.Messages)
+	})
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	s.Run("returns the collected sections", func() {
+		s.Contains(text, "## 1. Nodes\n\n**Total:** 1 | **Healthy:** 1\n")
+		s.Contains(text, "## 3. Pods\n\n**Total:** 1 | **With Issues:** 0\n")
+		s.Contains(text, "### StatefulSets\n\nNo StatefulSets found\n")
+	})
+	s.Run("reports the sections that couldn't be gathered", func() {
+		s.Contains(text, "**Partial results:** the following sections couldn't be gathered")
+		s.Regexp(`- \*\*Deployments\*\*: .*resource not allowed: apps/v1, Kind=Deployment\n`, text)
+		s.Contains(text, "- **Events in namespace ns-1**: events is forbidden")
+		s.Contains(text, "6. **Coverage**")
+	})
+	s.Run("doesn't report the missing OpenShift cluster operators as a failure", func() {
+		s.NotContains(text, "Cluster Operators")
+	})
+}
+
+func (s *HealthCheckSuite) TestClusterHealthCheckWithoutFailures() {
+	s.InitMcpClient()
+	result, err := s.GetPrompt("cluster-health-check", map[string]string{"namespace": "ns-1", "check_events": "false"})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(result.Messages)
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	s.Contains(text, "### Deployments\n\n")
+	s.NotContains(text, "Partial results")
+	s.NotContains(text, "Coverage")
+}
+
+func (s *HealthCheckSuite) TestClusterHealthCheckClusterOperators() {
+	s.discoveryHandler.AddAPIResourceList(metav1.APIResourceList{
+		GroupVersion: "config.openshift.io/v1",
+		APIResources: []metav1.APIResource{{Name: "clusteroperators", Kind: "ClusterOperator", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}}},
+	})
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/apis/config.openshift.io/v1/clusteroperators" {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"config.openshift.io/v1","kind":"ClusterOperatorList","items":[` +
+			`{"apiVersion":"config.openshift.io/v1","kind":"ClusterOperator","metadata":{"name":"healthy"},"status":{"conditions":[` +
+			`{"type":"Available","status":"True"},{"type":"Degraded","status":"False"},{"type":"Progressing","status":"True"},` +
+			`{"type":"Upgradeable","status":"False","message":"Upgrades are blocked by the admin"}]}},` +
+			`{"apiVersion":"config.openshift.io/v1","kind":"ClusterOperator","metadata":{"name":"degraded"},"status":{"conditions":[` +
+			`{"type":"Available","status":"True"},{"type":"Degraded","status":"True","message":"Pods are crash looping"}]}},` +
+			`{"apiVersion":"config.openshift.io/v1","kind":"ClusterOperator","metadata":{"name":"unavailable"},"status":{"conditions":[` +
+			`{"type":"Available","status":"False","message":"No replicas available"},{"type":"Degraded","status":"Unknown"}]}}` +
+			`]}`))
+	}))
+	s.InitMcpClient()
+	result, err := s.GetPrompt("cluster-health-check", map[string]string{"namespace": "ns-1", "check_events": "false"})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(result.Messages)
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	s.Run("reports the operators with issues", func() {
+		s.Contains(text, "## 2. Cluster Operators (OpenShift)\n\n**Operators with Issues:** 2\n")
+		s.Contains(text, "- **degraded** (Available: True, Degraded: True)\n  - Degraded: Pods are crash looping")
+		s.Contains(text, "- **unavailable** (Available: False, Degraded: Unknown)\n  - Not available: No replicas available")
+	})
+	s.Run("doesn't report Progressing or Upgradeable=False as issues", func() {
+		s.NotContains(text, "**healthy**")
+		s.NotContains(text, "Upgrades are blocked")
+	})
+	s.Run("doesn't report Degraded=Unknown as an issue", func() {
+		s.NotContains(text, "Degraded: \n")
+		s.NotContains(text, "Degraded=Unknown")
+	})
+}
+
+func TestHealthCheck(t *testing.T) {
+	suite.Run(t, new(HealthCheckSuite))
+}
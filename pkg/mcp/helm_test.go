@@ -91,6 +91,38 @@ func (s *HelmSuite) TestHelmInstall() {
 	})
 }
 
+func (s *HelmSuite) TestHelmInstallWaitOptions() {
+	s.InitMcpClient()
+	_, file, _, _ := runtime.Caller(0)
+	chartPath := filepath.Join(filepath.Dir(file), "testdata", "helm-chart-no-op")
+	s.Run("helm_install(chart=helm-chart-no-op, timeout=invalid) returns error", func() {
+		toolResult, _ := s.CallTool("helm_install", map[string]interface{}{
+			"chart":   chartPath,
+			"timeout": "invalid",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to install helm chart, invalid timeout 'invalid' (expected a positive duration such as 30s or 5m)",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("helm_install(chart=helm-chart-no-op, wait=false)", func() {
+		toolResult, err := s.CallTool("helm_install", map[string]interface{}{
+			"chart": chartPath,
+			"wait":  false,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("helm_install(chart=helm-chart-no-op, wait=true, timeout=30s)", func() {
+		toolResult, err := s.CallTool("helm_install", map[string]interface{}{
+			"chart":   chartPath,
+			"wait":    true,
+			"timeout": "30s",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+}
+
 func (s *HelmSuite) TestHelmInstallDenied() {
 	s.Require().NoError(toml.Unmarshal([]byte(`
 		denied_resources = [ { version = "v1", kind = "Secret" } ]
@@ -0,0 +1,212 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HPASuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	patches    []string
+}
+
+func (s *HPASuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.patches = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "autoscaling/v2",
+		APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/autoscaling/v2/namespaces/default/horizontalpodautoscalers":
+			if req.URL.Query().Get("fieldSelector") == "metadata.name=not-found" {
+				_, _ = w.Write([]byte(`{"apiVersion":"autoscaling/v2","kind":"HorizontalPodAutoscalerList","items":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"autoscaling/v2","kind":"HorizontalPodAutoscalerList","items":[{` +
+				`"metadata":{"name":"an-hpa","namespace":"default"},` +
+				`"spec":{"scaleTargetRef":{"apiVersion":"apps/v1","kind":"Deployment","name":"a-deployment"},"minReplicas":1,"maxReplicas":5,"metrics":[` +
+				`{"type":"Resource","resource":{"name":"cpu","target":{"type":"Utilization","averageUtilization":80}}},` +
+				`{"type":"Pods","pods":{"metric":{"name":"requests_per_second"},"target":{"type":"AverageValue","averageValue":"10"}}}` +
+				`]},` +
+				`"status":{"currentReplicas":5,"desiredReplicas":5,"currentMetrics":[` +
+				`{"type":"Resource","resource":{"name":"cpu","current":{"averageUtilization":95,"averageValue":"950m"}}}` +
+				`],"conditions":[` +
+				`{"type":"ScalingLimited","status":"True","reason":"TooManyReplicas","message":"the desired replica count is more than the maximum replica count"}` +
+				`]}}]}`))
+		case "/apis/autoscaling/v2/namespaces/default/horizontalpodautoscalers/an-hpa":
+			spec := `{"minReplicas":1,"maxReplicas":5}`
+			if req.Method == http.MethodPatch {
+				body, _ := io.ReadAll(req.Body)
+				s.mu.Lock()
+				s.patches = append(s.patches, string(body))
+				s.mu.Unlock()
+				spec = `{"minReplicas":2,"maxReplicas":10}`
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"autoscaling/v2","kind":"HorizontalPodAutoscaler","metadata":{"name":"an-hpa","namespace":"default"},` +
+				`"spec":` + strings.Replace(spec, `{`, `{"scaleTargetRef":{"apiVersion":"apps/v1","kind":"Deployment","name":"a-deployment"},`, 1) + `,` +
+				`"status":{"currentReplicas":5,"desiredReplicas":5}}`))
+		case "/api/v1/namespaces/default/events":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[{` +
+				`"metadata":{"name":"an-hpa.1","namespace":"default"},` +
+				`"involvedObject":{"apiVersion":"autoscaling/v2","kind":"HorizontalPodAutoscaler","name":"an-hpa"},` +
+				`"type":"Normal","reason":"SuccessfulRescale","message":"New size: 5; reason: cpu resource utilization (percentage of request) above target"}]}`))
+		}
+	}))
+}
+
+func (s *HPASuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *HPASuite) TestHPAStatus() {
+	s.InitMcpClient()
+	s.Run("hpa_status(namespace=default)", func() {
+		toolResult, err := s.CallTool("hpa_status", map[string]interface{}{"namespace": "default"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns replicas", func() {
+			s.Contains(text, "ScaleTargetRef: Deployment/a-deployment")
+			s.Contains(text, "CurrentReplicas: 5")
+			s.Contains(text, "MaxReplicas: 5")
+		})
+		s.Run("returns per-metric target and current values", func() {
+			s.Contains(text, "Current: 95% (average utilization)\n    Name: cpu\n    Target: 80% (average utilization)\n    Type: Resource")
+			s.Contains(text, "Current: <unknown>\n    Name: requests_per_second\n    Target: 10 (average value)\n    Type: Pods")
+		})
+		s.Run("returns conditions", func() {
+			s.Contains(text, "Reason: TooManyReplicas")
+		})
+		s.Run("returns events", func() {
+			s.Contains(text, "Reason: SuccessfulRescale")
+		})
+	})
+	s.Run("hpa_status(name=not-found) returns error", func() {
+		toolResult, _ := s.CallTool("hpa_status", map[string]interface{}{"namespace": "default", "name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get horizontalpodautoscalers status: horizontalpodautoscaler not-found not found in namespace default",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *HPASuite) recordedPatches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.patches...)
+}
+
+func (s *HPASuite) TestHPASet() {
+	s.InitMcpClient()
+	s.Run("hpa_set(min=2, max=10)", func() {
+		toolResult, err := s.CallTool("hpa_set", map[string]interface{}{"namespace": "default", "name": "an-hpa", "min": 2, "max": 10})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("patches minReplicas and maxReplicas", func() {
+			s.Equal([]string{`{"spec":{"maxReplicas":10,"minReplicas":2}}`}, s.recordedPatches())
+		})
+		s.Run("returns the updated HorizontalPodAutoscaler", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# The HorizontalPodAutoscaler default/an-hpa has been updated successfully (YAML format):\n")
+			s.Contains(text, "MaxReplicas: 10\n")
+			s.Contains(text, "MinReplicas: 2\n")
+		})
+	})
+	s.Run("hpa_set(max=8) only patches maxReplicas", func() {
+		s.mu.Lock()
+		s.patches = nil
+		s.mu.Unlock()
+		toolResult, err := s.CallTool("hpa_set", map[string]interface{}{"namespace": "default", "name": "an-hpa", "max": 8})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal([]string{`{"spec":{"maxReplicas":8}}`}, s.recordedPatches())
+	})
+}
+
+func (s *HPASuite) TestHPASetValidation() {
+	s.InitMcpClient()
+	for _, tc := range []struct {
+		name      string
+		arguments map[string]interface{}
+		expected  string
+	}{
+		{"min greater than max", map[string]interface{}{"min": 6, "max": 3}, "invalid min 6: must not be greater than max 3"},
+		{"min greater than current max", map[string]interface{}{"min": 6}, "invalid min 6: must not be greater than max 5"},
+		{"max lower than current min", map[string]interface{}{"max": 0}, "invalid min 1: must not be greater than max 0"},
+		{"min lower than 1", map[string]interface{}{"min": 0}, "invalid min 0: must be at least 1"},
+		{"no min nor max", map[string]interface{}{}, "at least one of min or max is required"},
+		{"non integer min", map[string]interface{}{"min": "two"}, "min parameter must be an integer"},
+	} {
+		s.Run("hpa_set with "+tc.name+" returns error", func() {
+			tc.arguments["namespace"] = "default"
+			tc.arguments["name"] = "an-hpa"
+			toolResult, _ := s.CallTool("hpa_set", tc.arguments)
+			s.Truef(toolResult.IsError, "call tool should fail")
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, tc.expected)
+		})
+	}
+	s.Run("doesn't patch invalid bounds", func() {
+		s.Empty(s.recordedPatches())
+	})
+}
+
+func (s *HPASuite) TestHPAStatusDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "autoscaling", version = "v2" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("hpa_status (denied)", func() {
+		toolResult, err := s.CallTool("hpa_status", map[string]interface{}{"namespace": "default"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: autoscaling/v2, Kind=HorizontalPodAutoscaler")
+		})
+	})
+	s.Run("hpa_set (denied)", func() {
+		toolResult, err := s.CallTool("hpa_set", map[string]interface{}{"namespace": "default", "name": "an-hpa", "max": 10})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: autoscaling/v2, Kind=HorizontalPodAutoscaler")
+		})
+		s.Run("doesn't patch the HorizontalPodAutoscaler", func() {
+			s.Empty(s.recordedPatches())
+		})
+	})
+}
+
+func TestHPA(t *testing.T) {
+	suite.Run(t, new(HPASuite))
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImagesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ImagesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"web-5d8f7c-aaaaa","namespace":"ns-1","labels":{"pod-template-hash":"5d8f7c"},` +
+				`"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-5d8f7c","uid":"rs-1","controller":true}]},` +
+				`"spec":{"initContainers":[{"name":"init","image":"busybox"}],"containers":[{"name":"web","image":"quay.io/my-org/web:1.2.0"},` +
+				`{"name":"sidecar","image":"quay.io/my-org/web:1.2.0"}]}},` +
+				`{"metadata":{"name":"web-5d8f7c-bbbbb","namespace":"ns-1","labels":{"pod-template-hash":"5d8f7c"},` +
+				`"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web-5d8f7c","uid":"rs-1","controller":true}]},` +
+				`"spec":{"initContainers":[{"name":"init","image":"busybox"}],"containers":[{"name":"web","image":"quay.io/my-org/web:1.2.0"}]}},` +
+				`{"metadata":{"name":"debug","namespace":"ns-1"},"spec":{"containers":[{"name":"debug","image":"busybox"}]}}` +
+				`]}`))
+		case "/api/v1/namespaces/empty/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		}
+	}))
+}
+
+func (s *ImagesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ImagesSuite) TestImagesInventory() {
+	s.InitMcpClient()
+	s.Run("images_inventory(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("images_inventory", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the distinct images", func() {
+			s.Contains(text, "# The following 2 container images are in use by 3 Pods (YAML format):\n")
+		})
+		s.Run("returns the pod count and workloads of each image", func() {
+			s.Regexp(`- Image: busybox\s+Issues:\s+- uses the latest tag \(not pinned to a version or digest\)\s+Pods: 3\s+`+
+				`Registry: docker.io\s+Workloads:\s+- Deployment/web\s+- Pod/debug`, text)
+			s.Regexp(`- Image: quay.io/my-org/web:1.2.0\s+Pods: 2\s+Registry: quay.io\s+Workloads:\s+- Deployment/web\n`, text)
+		})
+	})
+	s.Run("images_inventory(limit=1) caps the output", func() {
+		toolResult, err := s.CallTool("images_inventory", map[string]interface{}{"namespace": "ns-1", "limit": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "- Image: busybox")
+		s.NotContains(text, "quay.io/my-org/web")
+		s.Contains(text, "# Only the first 1 images were shown (limit reached), increase the limit to show more")
+	})
+	s.Run("images_inventory(namespace=empty)", func() {
+		toolResult, err := s.CallTool("images_inventory", map[string]interface{}{"namespace": "empty"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("# No container images found (0 Pods scanned)", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ImagesSuite) TestImagesInventoryAllowedRegistries() {
+	kubeConfig := s.Cfg.KubeConfig
+	cfg, err := config.ReadToml([]byte(`
+		[toolset_configs.core]
+		allowed_registries = [ "quay.io/my-org" ]
+	`))
+	s.Require().NoError(err, "failed to parse core toolset config")
+	s.Cfg = cfg
+	s.Cfg.KubeConfig = kubeConfig
+	s.InitMcpClient()
+	s.Run("images_inventory flags images from registries not allowed", func() {
+		toolResult, err := s.CallTool("images_inventory", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`- Image: busybox\s+Issues:\s+- uses the latest tag \(not pinned to a version or digest\)\s+- registry docker.io is not in the allowed registries`, text)
+		s.Regexp(`- Image: quay.io/my-org/web:1.2.0\s+Pods: 2`, text)
+	})
+}
+
+func (s *ImagesSuite) TestImagesInventoryDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("images_inventory (denied)", func() {
+		toolResult, err := s.CallTool("images_inventory", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+		})
+	})
+}
+
+func TestImages(t *testing.T) {
+	suite.Run(t, new(ImagesSuite))
+}
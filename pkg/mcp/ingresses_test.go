@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type IngressesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *IngressesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "ingresses", Kind: "Ingress", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "discovery.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/networking.k8s.io/v1/namespaces/default/ingresses/an-ingress":
+			_, _ = w.Write([]byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"an-ingress","namespace":"default"},"spec":{` +
+				`"ingressClassName":"nginx","tls":[{"hosts":["a.example.com"]}],"rules":[` +
+				`{"host":"a.example.com","http":{"paths":[` +
+				`{"path":"/","pathType":"Prefix","backend":{"service":{"name":"web","port":{"name":"http"}}}},` +
+				`{"path":"/api","pathType":"Prefix","backend":{"service":{"name":"missing","port":{"number":80}}}},` +
+				`{"path":"/wrong","pathType":"Exact","backend":{"service":{"name":"web","port":{"number":9090}}}}]}},` +
+				`{"host":"b.example.com","http":{"paths":[` +
+				`{"path":"/","pathType":"Prefix","backend":{"service":{"name":"not-ready","port":{"number":80}}}}]}}` +
+				`]},"status":{"loadBalancer":{"ingress":[{"ip":"192.168.1.10"}]}}}`))
+		case "/apis/networking.k8s.io/v1/namespaces/default/ingresses/an-empty-ingress":
+			_, _ = w.Write([]byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"an-empty-ingress","namespace":"default"},"spec":{}}`))
+		case "/api/v1/namespaces/default/services/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"web","namespace":"default"},` +
+				`"spec":{"type":"ClusterIP","selector":{"app":"web"},"ports":[{"name":"http","port":80,"targetPort":8080,"protocol":"TCP"}]}}`))
+		case "/api/v1/namespaces/default/services/not-ready":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"not-ready","namespace":"default"},` +
+				`"spec":{"type":"ClusterIP","selector":{"app":"not-ready"},"ports":[{"port":80,"targetPort":8080,"protocol":"TCP"}]}}`))
+		case "/api/v1/namespaces/default/services/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+		case "/apis/discovery.k8s.io/v1/namespaces/default/endpointslices":
+			switch req.URL.Query().Get("labelSelector") {
+			case "kubernetes.io/service-name=web":
+				_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[{` +
+					`"metadata":{"name":"web-abc12","namespace":"default"},"addressType":"IPv4","ports":[{"name":"http","port":8080,"protocol":"TCP"}],` +
+					`"endpoints":[{"addresses":["10.1.0.1"],"conditions":{"ready":true}},{"addresses":["10.1.0.2"]}]}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[{` +
+					`"metadata":{"name":"not-ready-abc12","namespace":"default"},"addressType":"IPv4","ports":[{"name":"","port":8080,"protocol":"TCP"}],` +
+					`"endpoints":[{"addresses":["10.1.0.3"],"conditions":{"ready":false}}]}]}`))
+			}
+		}
+	}))
+}
+
+func (s *IngressesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *IngressesSuite) TestIngressDescribe() {
+	s.InitMcpClient()
+	s.Run("ingress_describe with missing name returns error", func() {
+		toolResult, _ := s.CallTool("ingress_describe", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to describe ingress: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("ingress_describe(name=an-ingress)", func() {
+		toolResult, err := s.CallTool("ingress_describe", map[string]interface{}{"name": "an-ingress"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns ingress details", func() {
+			s.Contains(text, "# Ingress default/an-ingress routing")
+			s.Contains(text, "Ingress class: nginx")
+			s.Contains(text, "Addresses: 192.168.1.10")
+			s.Contains(text, "TLS hosts: a.example.com")
+		})
+		s.Run("resolves healthy backend to ready endpoints", func() {
+			s.Regexp(`a\.example\.com\s+/\s+Prefix\s+web:http\s+2\s+OK`, text)
+		})
+		s.Run("flags missing service", func() {
+			s.Regexp(`a\.example\.com\s+/api\s+Prefix\s+missing:80\s+0\s+BROKEN: Service missing not found`, text)
+		})
+		s.Run("flags wrong port", func() {
+			s.Regexp(`a\.example\.com\s+/wrong\s+Exact\s+web:9090\s+0\s+BROKEN: Service web has no port 9090`, text)
+		})
+		s.Run("flags no ready endpoints", func() {
+			s.Regexp(`b\.example\.com\s+/\s+Prefix\s+not-ready:80\s+0\s+BROKEN: Service has zero ready endpoints`, text)
+		})
+		s.Run("summarizes broken routes", func() {
+			s.Contains(text, "3 of 4 routes have broken backends")
+		})
+	})
+	s.Run("ingress_describe(name=an-empty-ingress) reports no rules", func() {
+		toolResult, err := s.CallTool("ingress_describe", map[string]interface{}{"name": "an-empty-ingress"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "The Ingress has no rules or default backend")
+	})
+}
+
+func TestIngresses(t *testing.T) {
+	suite.Run(t, new(IngressesSuite))
+}
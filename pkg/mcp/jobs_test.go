@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type JobsSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// created records the Jobs created in the mock server
+	created []*batchv1.Job
+}
+
+func (s *JobsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.created = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "batch/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "jobs", Kind: "Job", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "watch", "create"}},
+		},
+	}))
+	jobSelector := `"selector":{"matchLabels":{"batch.kubernetes.io/controller-uid":"uid-1"}}`
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/apis/batch/v1/namespaces/default/jobs":
+			// Typed clients send protobuf request bodies
+			body, _ := io.ReadAll(req.Body)
+			obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), body)
+			s.Require().NoError(err, "failed to decode Job")
+			job := obj.(*batchv1.Job)
+			s.created = append(s.created, job)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"` + job.Name + `","namespace":"default","resourceVersion":"1"},` +
+				`"spec":{` + jobSelector + `}}`))
+		case req.URL.Path == "/apis/batch/v1/namespaces/default/jobs" && req.URL.Query().Get("watch") == "true":
+			status := ""
+			switch req.URL.Query().Get("fieldSelector") {
+			case "metadata.name=a-succeeding-job":
+				status = `{"succeeded":1,"conditions":[{"type":"SuccessCriteriaMet","status":"True"},{"type":"Complete","status":"True"}]}`
+			case "metadata.name=a-failing-job":
+				status = `{"failed":1,"conditions":[{"type":"FailureTarget","status":"True"},{"type":"Failed","status":"True","reason":"BackoffLimitExceeded"}]}`
+			default:
+				// Keep the watch open until the client gives up (Job still running)
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				<-req.Context().Done()
+				return
+			}
+			name := strings.TrimPrefix(req.URL.Query().Get("fieldSelector"), "metadata.name=")
+			_, _ = w.Write([]byte(`{"type":"MODIFIED","object":{"apiVersion":"batch/v1","kind":"Job",` +
+				`"metadata":{"name":"` + name + `","namespace":"default","resourceVersion":"2"},"spec":{` + jobSelector + `},"status":` + status + `}}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("labelSelector") == "batch.kubernetes.io/controller-uid=uid-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{},"items":[` +
+				`{"metadata":{"name":"job-pod-old","namespace":"default","creationTimestamp":"2025-10-29T09:00:00Z"},"spec":{"containers":[{"name":"job","image":"busybox"}]}},` +
+				`{"metadata":{"name":"job-pod-latest","namespace":"default","creationTimestamp":"2025-10-29T09:05:00Z"},"spec":{"containers":[{"name":"job","image":"busybox"}]}}` +
+				`]}`))
+		case req.URL.Path == "/api/v1/namespaces/default/pods/job-pod-latest":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"job-pod-latest","namespace":"default"},"spec":{"containers":[{"name":"job","image":"busybox"}]}}`))
+		case req.URL.Path == "/api/v1/namespaces/default/pods/job-pod-latest/log":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("hello from the job\n"))
+		}
+	}))
+}
+
+func (s *JobsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *JobsSuite) TestJobsRun() {
+	s.InitMcpClient()
+	s.Run("jobs_run(image=busybox, command) with completed Job", func() {
+		toolResult, err := s.CallTool("jobs_run", map[string]interface{}{
+			"name":    "a-succeeding-job",
+			"image":   "busybox",
+			"command": []interface{}{"sh", "-c", "echo hello from the job"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the Job completed", func() {
+			s.Contains(text, "# Job a-succeeding-job completed successfully in namespace default")
+			s.Contains(text, "succeeded: 1")
+		})
+		s.Run("returns the logs of the most recent Pod", func() {
+			s.Contains(text, "# Logs of the Job Pod job-pod-latest:\nhello from the job")
+		})
+		s.Run("creates a Job with the image and command that doesn't retry", func() {
+			s.Require().Len(s.created, 1)
+			job := s.created[0]
+			s.Equal("a-succeeding-job", job.Name)
+			s.Require().Len(job.Spec.Template.Spec.Containers, 1)
+			s.Equal("busybox", job.Spec.Template.Spec.Containers[0].Image)
+			s.Equal([]string{"sh", "-c", "echo hello from the job"}, job.Spec.Template.Spec.Containers[0].Command)
+			s.Equal("Never", string(job.Spec.Template.Spec.RestartPolicy))
+			s.Require().NotNil(job.Spec.BackoffLimit)
+			s.Equal(int32(0), *job.Spec.BackoffLimit)
+			s.NotNil(job.Spec.TTLSecondsAfterFinished)
+		})
+	})
+	s.Run("jobs_run(job=manifest) with failed Job", func() {
+		s.created = nil
+		toolResult, err := s.CallTool("jobs_run", map[string]interface{}{
+			"job": "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: a-failing-job\nspec:\n  backoffLimit: 2\n  template:\n    spec:\n" +
+				"      restartPolicy: OnFailure\n      containers:\n      - name: task\n        image: alpine\n        command: [\"false\"]\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the Job failed", func() {
+			s.Contains(text, "# Job a-failing-job failed in namespace default")
+			s.Contains(text, "reason: BackoffLimitExceeded")
+		})
+		s.Run("creates the Job from the manifest", func() {
+			s.Require().Len(s.created, 1)
+			s.Equal(int32(2), *s.created[0].Spec.BackoffLimit)
+			s.Equal("alpine", s.created[0].Spec.Template.Spec.Containers[0].Image)
+		})
+	})
+	s.Run("jobs_run(timeout=1s) with a Job still running", func() {
+		toolResult, err := s.CallTool("jobs_run", map[string]interface{}{
+			"name":    "a-long-running-job",
+			"image":   "busybox",
+			"timeout": "1s",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Job a-long-running-job in namespace default didn't finish within the timeout, it's still running")
+	})
+	s.Run("jobs_run without image or job returns error", func() {
+		toolResult, _ := s.CallTool("jobs_run", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to run job: either a Job manifest or an image must be provided", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("jobs_run with image and job returns error", func() {
+		toolResult, _ := s.CallTool("jobs_run", map[string]interface{}{"image": "busybox", "job": "apiVersion: batch/v1\nkind: Job\n"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "not both")
+	})
+	s.Run("jobs_run with a manifest of another kind returns error", func() {
+		toolResult, _ := s.CallTool("jobs_run", map[string]interface{}{"job": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: a-pod\n"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid Job manifest: expected apiVersion batch/v1 and kind Job, got v1 Pod")
+	})
+	s.Run("jobs_run with invalid timeout returns error", func() {
+		toolResult, _ := s.CallTool("jobs_run", map[string]interface{}{"image": "busybox", "timeout": "soon"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid timeout 'soon'")
+	})
+}
+
+func (s *JobsSuite) TestJobsRunDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "batch", version = "v1", kind = "Job" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("jobs_run (denied)", func() {
+		toolResult, err := s.CallTool("jobs_run", map[string]interface{}{"image": "busybox"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: batch/v1, Kind=Job")
+		})
+		s.Run("does not create the Job", func() {
+			s.Empty(s.created)
+		})
+	})
+}
+
+func TestJobs(t *testing.T) {
+	suite.Run(t, new(JobsSuite))
+}
@@ -112,6 +112,83 @@ func (s *KialiSuite) TestGetMeshTrafficGraph() {
 	})
 }
 
+func (s *KialiSuite) TestMeshRequestPath() {
+	var mu sync.Mutex
+	capturedBodies := map[string]string{}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		capturedBodies[r.URL.Path] = string(body)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/chat/mcp/get_mesh_traffic_graph":
+			_, _ = w.Write([]byte(`{"elements":{"nodes":[
+				{"data":{"id":"n1","namespace":"bookinfo","service":"productpage"}},
+				{"data":{"id":"n2","namespace":"bookinfo","service":"reviews"}},
+				{"data":{"id":"n3","namespace":"bookinfo","service":"ratings"}},
+				{"data":{"id":"n4","namespace":"bookinfo","service":"details"}}],
+			  "edges":[
+				{"data":{"source":"n1","target":"n4","traffic":{"protocol":"http","rates":{"http":"2.00"}}}},
+				{"data":{"source":"n1","target":"n2","responseTime":"45","isMTLS":"100","traffic":{"protocol":"http","rates":{"http":"1.50","httpPercentErr":"2.5"}}}},
+				{"data":{"source":"n2","target":"n3","responseTime":"120","traffic":{"protocol":"grpc","rates":{"grpc":"0.75"}}}}]}}`))
+		case "/api/chat/mcp/list_traces":
+			_, _ = w.Write([]byte(`{"summary":{"total_found":3,"avg_duration_ms":80.5},"traces":[
+				{"id":"t1","duration_ms":50,"root_op":"GET /productpage"},
+				{"id":"t2","duration_ms":150,"root_op":"GET /productpage","slowest_service":"ratings","has_errors":true},
+				{"id":"t3","duration_ms":40,"has_errors":true}]}`))
+		}
+	}))
+	s.InitMcpClient()
+
+	s.Run("mesh_request_path returns the path with the hop metrics", func() {
+		toolResult, err := s.CallTool(fmt.Sprintf("%s_mesh_request_path", s.toolsetName), map[string]interface{}{
+			"namespace":   "bookinfo",
+			"source":      "productpage",
+			"destination": "ratings",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("requests the service graph of the namespaces", func() {
+			mu.Lock()
+			defer mu.Unlock()
+			s.Contains(capturedBodies["/api/chat/mcp/get_mesh_traffic_graph"], `"graphType":"service"`)
+			s.Contains(capturedBodies["/api/chat/mcp/get_mesh_traffic_graph"], `"namespaces":"bookinfo"`)
+			s.Contains(capturedBodies["/api/chat/mcp/list_traces"], `"serviceName":"ratings"`)
+		})
+		s.Run("returns the hops from the source to the destination", func() {
+			s.Contains(text, "path:\n"+
+				"- errorRate: 2.5%\n  from: bookinfo/productpage\n  mTLS: true\n  protocol: http\n  requestRate: \"1.50\"\n  responseTimeMs: \"45\"\n  to: bookinfo/reviews\n"+
+				"- from: bookinfo/reviews\n  mTLS: false\n  protocol: grpc\n  requestRate: \"0.75\"\n  responseTimeMs: \"120\"\n  to: bookinfo/ratings\n")
+			s.NotContains(text, "details")
+		})
+		s.Run("returns the representative traces", func() {
+			s.Contains(text, "- durationMs: 150\n    reason: slowest, has errors\n    rootOperation: GET /productpage\n    slowestService: ratings\n    traceId: t2\n")
+			s.Contains(text, "totalFound: 3\n  withErrors: 2\n")
+		})
+	})
+	s.Run("mesh_request_path without observed traffic returns a note", func() {
+		toolResult, err := s.CallTool(fmt.Sprintf("%s_mesh_request_path", s.toolsetName), map[string]interface{}{
+			"namespace":   "bookinfo",
+			"source":      "ratings",
+			"destination": "productpage",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "No traffic from bookinfo/ratings to bookinfo/productpage was observed")
+	})
+	s.Run("mesh_request_path with missing destination returns error", func() {
+		toolResult, _ := s.CallTool(fmt.Sprintf("%s_mesh_request_path", s.toolsetName), map[string]interface{}{
+			"namespace": "bookinfo",
+			"source":    "productpage",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to trace mesh request path: destination parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
 func (s *KialiSuite) TestGetMeshStatus() {
 	var capturedURL *url.URL
 	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type KubevirtConsoleSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *KubevirtConsoleSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		toolsets = [ "kubevirt" ]
+	`), s.Cfg), "Expected to parse toolsets config")
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "kubevirt.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "virtualmachineinstances", Kind: "VirtualMachineInstance", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/kubevirt.io/v1/namespaces/default/virtualmachineinstances/running-vm":
+			_, _ = w.Write([]byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachineInstance","metadata":{"name":"running-vm","namespace":"default"},` +
+				`"spec":{"domain":{"devices":{"autoattachSerialConsole":false}}},"status":{"phase":"Running"}}`))
+		case "/apis/kubevirt.io/v1/namespaces/default/virtualmachineinstances/pending-vm":
+			_, _ = w.Write([]byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachineInstance","metadata":{"name":"pending-vm","namespace":"default"},` +
+				`"status":{"phase":"Pending"}}`))
+		}
+	}))
+}
+
+func (s *KubevirtConsoleSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *KubevirtConsoleSuite) TestVMConsoleInfo() {
+	s.InitMcpClient()
+	s.Run("vm_console_info(name=running-vm)", func() {
+		toolResult, err := s.CallTool("vm_console_info", map[string]interface{}{"namespace": "default", "name": "running-vm"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the VNC connection details", func() {
+			s.Contains(text, "# Console Information for VM: default/running-vm")
+			s.Contains(text, "vnc:\n  available: true\n  command: virtctl vnc running-vm -n default\n")
+			s.Contains(text, "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/running-vm/vnc")
+		})
+		s.Run("returns the unavailable serial console with the reason", func() {
+			s.Contains(text, "serial:\n  available: false\n  command: virtctl console running-vm -n default\n")
+			s.Contains(text, "reason: 'the serial console is disabled")
+		})
+	})
+	s.Run("vm_console_info(name=pending-vm) returns not running error", func() {
+		toolResult, _ := s.CallTool("vm_console_info", map[string]interface{}{"namespace": "default", "name": "pending-vm"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("VirtualMachineInstance default/pending-vm is not running (phase: Pending) - consoles are only available for running VMs",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("vm_console_info(name=not-found) returns not found error", func() {
+		toolResult, _ := s.CallTool("vm_console_info", map[string]interface{}{"namespace": "default", "name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "VirtualMachineInstance not found - VM may not be running")
+	})
+}
+
+func (s *KubevirtConsoleSuite) TestVMConsoleInfoDenied() {
+	s.Run("denied subresources", func() {
+		s.Require().NoError(toml.Unmarshal([]byte(`
+			denied_resources = [ { group = "subresources.kubevirt.io", version = "v1" } ]
+		`), s.Cfg), "Expected to parse denied resources config")
+		s.InitMcpClient()
+		toolResult, _ := s.CallTool("vm_console_info", map[string]interface{}{"namespace": "default", "name": "running-vm"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("resource not allowed: subresources.kubevirt.io/v1, Kind=VirtualMachineInstance", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("denied virtual machine instances", func() {
+		s.Require().NoError(toml.Unmarshal([]byte(`
+			denied_resources = [ { group = "kubevirt.io", version = "v1", kind = "VirtualMachineInstance" } ]
+		`), s.Cfg), "Expected to parse denied resources config")
+		s.InitMcpClient()
+		toolResult, _ := s.CallTool("vm_console_info", map[string]interface{}{"namespace": "default", "name": "running-vm"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: kubevirt.io/v1, Kind=VirtualMachineInstance")
+	})
+}
+
+func TestKubevirtConsole(t *testing.T) {
+	suite.Run(t, new(KubevirtConsoleSuite))
+}
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type KubevirtVolumeSuite struct {
+	BaseMcpSuite
+	mockServer   *test.MockServer
+	requestPaths []string
+	requestBody  string
+}
+
+func (s *KubevirtVolumeSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requestPaths = nil
+	s.requestBody = ""
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		toolsets = [ "kubevirt" ]
+	`), s.Cfg), "Expected to parse toolsets config")
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(
+		metav1.APIResourceList{
+			GroupVersion: "kubevirt.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "virtualmachines", Kind: "VirtualMachine", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "virtualmachineinstances", Kind: "VirtualMachineInstance", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		metav1.APIResourceList{
+			GroupVersion: "subresources.kubevirt.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "virtualmachines", Kind: "VirtualMachine", Namespaced: true, Verbs: metav1.Verbs{"update"}},
+			},
+		},
+	))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/kubevirt.io/v1/namespaces/default/virtualmachines/vm-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachine","metadata":{"name":"vm-1","namespace":"default"},` +
+				`"spec":{"runStrategy":"Always"}}`))
+		case "/apis/kubevirt.io/v1/namespaces/default/virtualmachineinstances/vm-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"kubevirt.io/v1","kind":"VirtualMachineInstance","metadata":{"name":"vm-1","namespace":"default"},` +
+				`"status":{"phase":"Running","volumeStatus":[{"name":"data","phase":"Ready","hotplugVolume":{"attachPodName":"hp-volume-1"}}]}}`))
+		case "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/vm-1/addvolume",
+			"/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/vm-1/removevolume":
+			body, _ := io.ReadAll(req.Body)
+			s.requestPaths = append(s.requestPaths, req.Method+" "+req.URL.Path)
+			s.requestBody = string(body)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+}
+
+func (s *KubevirtVolumeSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *KubevirtVolumeSuite) TestVMAddVolume() {
+	s.InitMcpClient()
+	s.Run("vm_add_volume(dataVolume=data-dv)", func() {
+		toolResult, err := s.CallTool("vm_add_volume", map[string]interface{}{
+			"namespace": "default", "name": "vm-1", "volumeName": "data", "dataVolume": "data-dv",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("calls the addvolume subresource", func() {
+			s.Equal([]string{"PUT /apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/vm-1/addvolume"}, s.requestPaths)
+			s.JSONEq(`{"name":"data","disk":{"name":"data","disk":{"bus":"scsi"}},"volumeSource":{"dataVolume":{"name":"data-dv","hotpluggable":true}}}`, s.requestBody)
+		})
+		s.Run("returns the volume status", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# Volume data added to VirtualMachine default/vm-1\n")
+			s.Contains(text, "phase: Ready")
+			s.Contains(text, "attachPodName: hp-volume-1")
+		})
+	})
+	s.Run("vm_add_volume without volume source returns error", func() {
+		toolResult, _ := s.CallTool("vm_add_volume", map[string]interface{}{"namespace": "default", "name": "vm-1", "volumeName": "data"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("either dataVolume or persistentVolumeClaim must be provided", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("vm_add_volume for non-existent VM returns error", func() {
+		toolResult, _ := s.CallTool("vm_add_volume", map[string]interface{}{
+			"namespace": "default", "name": "not-found", "volumeName": "data", "persistentVolumeClaim": "data-pvc",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get VirtualMachine")
+	})
+}
+
+func (s *KubevirtVolumeSuite) TestVMRemoveVolume() {
+	s.InitMcpClient()
+	s.Run("vm_remove_volume(volumeName=data)", func() {
+		toolResult, err := s.CallTool("vm_remove_volume", map[string]interface{}{"namespace": "default", "name": "vm-1", "volumeName": "data"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("calls the removevolume subresource", func() {
+			s.Equal([]string{"PUT /apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachines/vm-1/removevolume"}, s.requestPaths)
+			s.JSONEq(`{"name":"data"}`, s.requestBody)
+		})
+		s.Run("returns the volume status", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Volume data removed from VirtualMachine default/vm-1\n")
+		})
+	})
+}
+
+func (s *KubevirtVolumeSuite) TestVMVolumeDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "subresources.kubevirt.io", version = "v1" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("vm_add_volume", map[string]interface{}{
+		"namespace": "default", "name": "vm-1", "volumeName": "data", "dataVolume": "data-dv",
+	})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: subresources.kubevirt.io/v1, Kind=VirtualMachine")
+	s.Empty(s.requestPaths, "subresource should not be called")
+}
+
+func TestKubevirtVolume(t *testing.T) {
+	suite.Run(t, new(KubevirtVolumeSuite))
+}
@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type LeasesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *LeasesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "coordination.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "leases", Kind: "Lease", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	renewed := func(ago time.Duration) string {
+		return time.Now().Add(-ago).UTC().Format(metav1.RFC3339Micro)
+	}
+	kubeSystemLeases := `{"metadata":{"name":"kube-controller-manager","namespace":"kube-system"},` +
+		`"spec":{"holderIdentity":"control-plane-1_abc","leaseDurationSeconds":15,"leaseTransitions":3,"renewTime":"` + renewed(10*time.Minute) + `"}},` +
+		`{"metadata":{"name":"kube-scheduler","namespace":"kube-system"},` +
+		`"spec":{"holderIdentity":"control-plane-2_def","leaseDurationSeconds":15,"leaseTransitions":1,"renewTime":"` + renewed(2*time.Second) + `"}}`
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/coordination.k8s.io/v1/leases":
+			_, _ = w.Write([]byte(`{"apiVersion":"coordination.k8s.io/v1","kind":"LeaseList","items":[` +
+				`{"metadata":{"name":"my-operator","namespace":"operators"},"spec":{"leaseDurationSeconds":137}},` + kubeSystemLeases + `]}`))
+		case "/apis/coordination.k8s.io/v1/namespaces/kube-system/leases":
+			_, _ = w.Write([]byte(`{"apiVersion":"coordination.k8s.io/v1","kind":"LeaseList","items":[` + kubeSystemLeases + `]}`))
+		case "/apis/coordination.k8s.io/v1/namespaces/empty/leases":
+			_, _ = w.Write([]byte(`{"apiVersion":"coordination.k8s.io/v1","kind":"LeaseList","items":[]}`))
+		}
+	}))
+}
+
+func (s *LeasesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *LeasesSuite) TestLeasesList() {
+	s.InitMcpClient()
+	s.Run("leases_list() in all namespaces", func() {
+		toolResult, err := s.CallTool("leases_list", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the leases sorted by namespace and name", func() {
+			s.Regexp(`NAMESPACE\s+NAME\s+HOLDER\s+RENEWED\s+DURATION\s+TRANSITIONS\s+STATUS\n`+
+				`kube-system\s+kube-controller-manager\s+control-plane-1_abc\s+10m ago\s+15s\s+3\s+STALE\n`+
+				`kube-system\s+kube-scheduler\s+control-plane-2_def\s+\ds ago\s+15s\s+1\s+Held\n`+
+				`operators\s+my-operator\s+<none>\s+<never>\s+137s\s+0\s+Released\n`, text)
+		})
+		s.Run("explains the stale leases", func() {
+			s.Regexp(`- kube-system/kube-controller-manager held by control-plane-1_abc, last renewed at \S+ \(duration 15s\)\n`, text)
+			s.NotContains(text, "- kube-system/kube-scheduler")
+			s.NotContains(text, "- operators/my-operator")
+		})
+	})
+	s.Run("leases_list(namespace=kube-system) in the provided namespace", func() {
+		toolResult, err := s.CallTool("leases_list", map[string]interface{}{"namespace": "kube-system"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns only the leases in the namespace", func() {
+			s.Contains(text, "kube-scheduler")
+			s.NotContains(text, "my-operator")
+		})
+	})
+	s.Run("leases_list(namespace=empty) with no leases", func() {
+		toolResult, err := s.CallTool("leases_list", map[string]interface{}{"namespace": "empty"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("returns no leases found", func() {
+			s.Equal("# No leases found", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+}
+
+func TestLeases(t *testing.T) {
+	suite.Run(t, new(LeasesSuite))
+}
@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type LogsSearchSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *LogsSearchSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/a-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pod","namespace":"default"},"spec":{"containers":[{"name":"app"}]}}`))
+		case "/api/v1/namespaces/default/pods/max-pod", "/api/v1/namespaces/default/pods/over-pod", "/api/v1/namespaces/default/pods/large-pod":
+			name := strings.TrimPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"` + name + `","namespace":"default"},"spec":{"containers":[{"name":"app"}]}}`))
+		case "/apis/apps/v1/namespaces/default/deployments/a-deployment", "/apis/apps/v1/namespaces/default/deployments/large-deployment":
+			name := strings.TrimPrefix(req.URL.Path, "/apis/apps/v1/namespaces/default/deployments/")
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"` + name + `","namespace":"default"},` +
+				`"spec":{"selector":{"matchLabels":{"app":"` + name + `"}}}}`))
+		case "/api/v1/namespaces/default/pods":
+			if req.URL.Query().Get("labelSelector") == "app=large-deployment" {
+				items := make([]string, 0, 15)
+				for i := range 15 {
+					items = append(items, fmt.Sprintf(`{"metadata":{"name":"large-%d","namespace":"default"},"spec":{"containers":[{"name":"app"},{"name":"sidecar"}]}}`, i))
+				}
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` + strings.Join(items, ",") + `]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"pod-1","namespace":"default"},"spec":{"containers":[{"name":"app"},{"name":"sidecar"}]}},` +
+				`{"metadata":{"name":"pod-2","namespace":"default"},"spec":{"containers":[{"name":"app"}]}}` +
+				`]}`))
+		case "/api/v1/namespaces/default/pods/a-pod/log":
+			w.Header().Set("Content-Type", "text/plain")
+			if req.URL.Query().Get("sinceSeconds") != "600" || req.URL.Query().Get("timestamps") != "true" {
+				_, _ = w.Write([]byte("ERROR outside the time window\n"))
+				return
+			}
+			for i, line := range []string{"line 1", "line 2", "line 3", "ERROR first", "line 5", "line 6", "line 7", "line 8", "ERROR second", "line 10", "ERROR third", "line 12", "line 13"} {
+				_, _ = fmt.Fprintf(w, "2026-01-01T00:00:%02d.123456789Z %s\n", i, line)
+			}
+		case "/api/v1/namespaces/default/pods/max-pod/log", "/api/v1/namespaces/default/pods/over-pod/log":
+			w.Header().Set("Content-Type", "text/plain")
+			matches := 200
+			if strings.Contains(req.URL.Path, "over-pod") {
+				matches = 201
+			}
+			for i := range matches {
+				_, _ = fmt.Fprintf(w, "ERROR %d\nline\n", i+1)
+			}
+			_, _ = w.Write([]byte("done\ndone\n"))
+		case "/api/v1/namespaces/default/pods/large-pod/log":
+			w.Header().Set("Content-Type", "text/plain")
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			filler := strings.Repeat("x", 100)
+			for i := range 100_000 {
+				_, _ = fmt.Fprintf(w, "%s %s\n", start.Add(time.Duration(i)*time.Millisecond).Format(time.RFC3339Nano), filler)
+			}
+		case "/api/v1/namespaces/default/pods/pod-1/log":
+			w.Header().Set("Content-Type", "text/plain")
+			if req.URL.Query().Get("container") == "sidecar" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte("starting\nconnection timeout\n"))
+		case "/api/v1/namespaces/default/pods/pod-2/log":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("starting\nready\n"))
+		default:
+			if strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/default/pods/large-") && strings.HasSuffix(req.URL.Path, "/log") {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte("ready\n"))
+			}
+		}
+	}))
+}
+
+func (s *LogsSearchSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *LogsSearchSuite) TestLogsSearch() {
+	s.InitMcpClient()
+	s.Run("logs_search with missing pattern returns error", func() {
+		toolResult, _ := s.CallTool("logs_search", map[string]interface{}{"target": "a-pod"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to search logs: pattern parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("logs_search with invalid pattern returns error", func() {
+		toolResult, _ := s.CallTool("logs_search", map[string]interface{}{"target": "a-pod", "pattern": "ERROR("})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to search logs: invalid pattern \"ERROR(\"")
+	})
+	s.Run("logs_search with unsupported workload kind returns error", func() {
+		toolResult, _ := s.CallTool("logs_search", map[string]interface{}{"target": "job/a-job", "pattern": "ERROR"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "unsupported workload kind job")
+	})
+	s.Run("logs_search(target=a-pod, pattern=ERROR, sinceMinutes=10, contextLines=1)", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "a-pod", "pattern": "ERROR", "sinceMinutes": 10, "contextLines": 1})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns matching lines with context and separates non-contiguous blocks", func() {
+			s.Equal("# 3 lines matching \"ERROR\" in the last 10 minutes of the logs of 1 containers of a-pod:\n"+
+				"[a-pod/app]   line 3\n"+
+				"[a-pod/app] > ERROR first\n"+
+				"[a-pod/app]   line 5\n"+
+				"--\n"+
+				"[a-pod/app]   line 8\n"+
+				"[a-pod/app] > ERROR second\n"+
+				"[a-pod/app]   line 10\n"+
+				"[a-pod/app] > ERROR third\n"+
+				"[a-pod/app]   line 12\n",
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("logs_search(target=pod/a-pod, pattern=nothing)", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "pod/a-pod", "pattern": "nothing", "sinceMinutes": 10})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("# No lines matching \"nothing\" in the last 10 minutes of the logs of 1 containers of pod/a-pod\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("logs_search(target=deployment/a-deployment, pattern=(?i)timeout|ready, contextLines=0)", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "deployment/a-deployment", "pattern": "(?i)timeout|ready", "contextLines": 0})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("searches all the pods and containers of the workload", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+				"# 2 lines matching \"(?i)timeout|ready\" in the last 60 minutes of the logs of 3 containers of deployment/a-deployment:\n"+
+					"[pod-1/app] > connection timeout\n"+
+					"--\n"+
+					"[pod-2/app] > ready\n")
+		})
+		s.Run("reports containers whose logs cannot be retrieved as warnings", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Warning: [pod-1/sidecar] error retrieving logs: ")
+		})
+	})
+	s.Run("logs_search(target=max-pod) with exactly the max matches", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "max-pod", "pattern": "ERROR", "contextLines": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Run("returns all the matches", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# 200 lines matching \"ERROR\"")
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "[max-pod/app] > ERROR 200\n[max-pod/app]   line\n")
+		})
+		s.Run("doesn't report the limit as reached", func() {
+			s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "# Search stopped")
+		})
+	})
+	s.Run("logs_search(target=over-pod) with more than the max matches", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "over-pod", "pattern": "ERROR", "contextLines": 1})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Run("returns the max matches with the context of the last one", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# 200 lines matching \"ERROR\"")
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "[over-pod/app] > ERROR 200\n[over-pod/app]   line\n")
+			s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "ERROR 201")
+		})
+		s.Run("reports the limit as reached", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Search stopped after 200 matches, further matching lines were dropped")
+		})
+	})
+	s.Run("logs_search(target=large-pod) with logs larger than the scanned bytes", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "large-pod", "pattern": "ERROR"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Run("reports the time after which the lines were not scanned", func() {
+			s.Regexp(`# Warning: \[large-pod/app\] only the first 8388608 bytes of the logs in the time window were scanned, `+
+				`the lines logged after 2026-01-01T00:01:\d\dZ were not searched, set sinceMinutes to \d+ or less to search them\n`,
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("logs_search(target=deployment/large-deployment) with more containers than the max", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "deployment/large-deployment", "pattern": "ready"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Run("searches the max containers", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# 20 lines matching \"ready\" in the last 60 minutes of the logs of 20 containers of deployment/large-deployment:\n")
+		})
+		s.Run("reports the containers left out as a warning", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Warning: only 20 of the 30 containers of deployment/large-deployment were searched, "+
+				"provide the container parameter or search a single Pod to narrow the search\n")
+		})
+	})
+	s.Run("logs_search(target=deployment/large-deployment, container=app) within the max containers", func() {
+		toolResult, err := s.CallTool("logs_search", map[string]interface{}{"target": "deployment/large-deployment", "pattern": "ready", "container": "app"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "of the logs of 15 containers of deployment/large-deployment:\n")
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "# Warning")
+	})
+}
+
+func TestLogsSearch(t *testing.T) {
+	suite.Run(t, new(LogsSearchSuite))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"reflect"
 	"slices"
 	"sync"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
+	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/metrics"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
@@ -33,9 +35,10 @@ type Configuration struct {
 	// SDKLogger is the slog.Logger handed to the underlying MCP SDK for its
 	// server-activity logs. When nil (e.g. in tests) it falls back to a
 	// klog-backed logger.
-	SDKLogger  *slog.Logger
-	listOutput output.Output
-	toolsets   []api.Toolset
+	SDKLogger     *slog.Logger
+	listOutput    output.Output
+	defaultOutput output.Output
+	toolsets      []api.Toolset
 }
 
 func (c *Configuration) Toolsets() []api.Toolset {
@@ -54,6 +57,14 @@ func (c *Configuration) ListOutput() output.Output {
 	return c.listOutput
 }
 
+// DefaultOutput returns the configured default output for resources_list and resources_get, or nil if not configured.
+func (c *Configuration) DefaultOutput() output.Output {
+	if c.defaultOutput == nil && c.StaticConfig.DefaultOutput != "" {
+		c.defaultOutput = output.FromString(c.StaticConfig.DefaultOutput)
+	}
+	return c.defaultOutput
+}
+
 // warmCaches forces every lazy cache field on Configuration to be populated.
 // Callers about to publish a *Configuration to lock-free readers MUST call
 // this first; otherwise the first concurrent readers race on the lazy
@@ -62,6 +73,7 @@ func (c *Configuration) ListOutput() output.Output {
 // race.
 func (c *Configuration) warmCaches() {
 	c.ListOutput()
+	c.DefaultOutput()
 	c.Toolsets()
 }
 
@@ -105,10 +117,13 @@ type Server struct {
 	enabledPrompts           []string
 	enabledResources         []string
 	enabledResourceTemplates []string
-	p                        internalk8s.Provider
-	metrics                  *metrics.Metrics // Metrics collection system
-	rateLimitDone            chan struct{}    // Closed to stop the rate limiter reaper goroutine
-	closeOnce                sync.Once
+	// subscribableResourceTemplates are the enabled resource templates that support resources/subscribe
+	subscribableResourceTemplates []api.ServerResourceTemplate
+	subscriptions                 *resourceSubscriptions
+	p                             internalk8s.Provider
+	metrics                       *metrics.Metrics // Metrics collection system
+	rateLimitDone                 chan struct{}    // Closed to stop the rate limiter reaper goroutine
+	closeOnce                     sync.Once
 }
 
 func NewServer(ctx context.Context, configuration Configuration, targetProvider internalk8s.Provider) (*Server, error) {
@@ -117,25 +132,33 @@ func NewServer(ctx context.Context, configuration Configuration, targetProvider
 		sdkLogger = slog.New(logr.ToSlogHandler(klog.FromContext(ctx)))
 	}
 	s := &Server{
-		server: mcp.NewServer(
-			&mcp.Implementation{
-				Name:       version.BinaryName,
-				Title:      version.BinaryName,
-				Version:    version.Version,
-				WebsiteURL: version.WebsiteURL,
-			},
-			&mcp.ServerOptions{
-				Capabilities: &mcp.ServerCapabilities{
-					Resources: &mcp.ResourceCapabilities{ListChanged: !configuration.Stateless},
-					Prompts:   &mcp.PromptCapabilities{ListChanged: !configuration.Stateless},
-					Tools:     &mcp.ToolCapabilities{ListChanged: !configuration.Stateless},
-					Logging:   &mcp.LoggingCapabilities{},
-				},
-				Instructions: configuration.ServerInstructions,
-				Logger:       sdkLogger,
-			}),
-		p: targetProvider,
-	}
+		p:             targetProvider,
+		subscriptions: newResourceSubscriptions(),
+	}
+	serverOptions := &mcp.ServerOptions{
+		Capabilities: &mcp.ServerCapabilities{
+			Resources: &mcp.ResourceCapabilities{ListChanged: !configuration.Stateless, Subscribe: !configuration.Stateless},
+			Prompts:   &mcp.PromptCapabilities{ListChanged: !configuration.Stateless},
+			Tools:     &mcp.ToolCapabilities{ListChanged: !configuration.Stateless},
+			Logging:   &mcp.LoggingCapabilities{},
+		},
+		Instructions: configuration.ServerInstructions,
+		Logger:       sdkLogger,
+	}
+	// Subscriptions are bound to a session, stateless servers can't notify the updates
+	if !configuration.Stateless {
+		serverOptions.SubscribeHandler = s.subscribeResource
+		serverOptions.UnsubscribeHandler = s.unsubscribeResource
+	}
+	s.server = mcp.NewServer(
+		&mcp.Implementation{
+			Name:       version.BinaryName,
+			Title:      version.BinaryName,
+			Version:    version.Version,
+			WebsiteURL: version.WebsiteURL,
+		},
+		serverOptions,
+	)
 	s.configuration.Store(&configuration)
 
 	// Initialize metrics system
@@ -166,9 +189,13 @@ func NewServer(ctx context.Context, configuration Configuration, targetProvider
 	)
 	s.server.AddReceivingMiddleware(tracingMiddleware(version.BinaryName + "/mcp"))
 	s.server.AddReceivingMiddleware(authHeaderPropagationMiddleware)
-	s.server.AddReceivingMiddleware(userAgentPropagationMiddleware(version.BinaryName, version.Version))
+	s.server.AddReceivingMiddleware(userAgentPropagationMiddleware(version.BinaryName, version.Version, func() string {
+		return s.configuration.Load().UserAgentSuffix
+	}))
 	s.server.AddReceivingMiddleware(protocolReceivingMiddleware)
 	s.server.AddReceivingMiddleware(s.metricsMiddleware())
+	// Outermost so that all the other middlewares (logs, traces, metrics) get the request ID
+	s.server.AddReceivingMiddleware(requestIDMiddleware)
 	// Outbound (server-initiated) frames — log notifications, list_changed
 	// notifications, progress, server-side pings — bypass the receiving
 	// path entirely; protocolSendingMiddleware makes them visible at V(6).
@@ -230,8 +257,9 @@ func (s *Server) applyToolsets(ctx context.Context, cfg *Configuration) error {
 	// Collect applicable items against cfg (NOT s.configuration) so that a
 	// pending ReloadConfiguration can probe a candidate config without making
 	// it observable to concurrent readers until the convert phase succeeds.
-	applicableTools := s.collectApplicableTools(cfg)
-	applicablePrompts := s.collectApplicablePrompts(cfg)
+	probe := s.capabilityProbe(ctx, cfg)
+	applicableTools := s.collectApplicableTools(ctx, cfg, probe)
+	applicablePrompts := s.collectApplicablePrompts(cfg, probe)
 	applicableResources := s.collectApplicableResources(cfg)
 	applicableResourceTemplates := s.collectApplicableResourceTemplates(cfg)
 
@@ -313,6 +341,7 @@ func (s *Server) applyToolsets(ctx context.Context, cfg *Configuration) error {
 	s.enabledPrompts = newPrompts
 	s.enabledResources = newResources
 	s.enabledResourceTemplates = newResourceTemplates
+	s.subscribableResourceTemplates = subscribableResourceTemplates(applicableResourceTemplates)
 	s.mu.Unlock()
 
 	// Start new watch
@@ -376,11 +405,13 @@ func commitItems[M, H any](
 	return enabled
 }
 
-// collectApplicableTools returns tools after applying filtering and mutation
-func (s *Server) collectApplicableTools(cfg *Configuration) []api.ServerTool {
+// collectApplicableTools returns tools after applying filtering and mutation.
+// Tool overrides that don't match any of the tools provided by the enabled toolsets are reported as warnings.
+func (s *Server) collectApplicableTools(ctx context.Context, cfg *Configuration, probe CapabilityProbe) []api.ServerTool {
 	filter := CompositeFilter(
 		cfg.isToolApplicable,
 		ShouldIncludeTargetListTool(s.p.GetTargetParameterName(), s.p.IsMultiTarget()),
+		HasRequiredCapabilities(probe),
 	)
 	mutator := ComposeMutators(
 		WithTargetParameter(s.p.GetDefaultTarget(), s.p.GetTargetParameterName(), s.p.IsMultiTarget()),
@@ -389,31 +420,60 @@ func (s *Server) collectApplicableTools(cfg *Configuration) []api.ServerTool {
 	)
 
 	tools := make([]api.ServerTool, 0)
+	allTools := make([]api.ServerTool, 0)
 	for _, toolset := range cfg.Toolsets() {
 		for _, tool := range toolset.GetTools(s.p) {
 			tool = mutator(tool)
+			allTools = append(allTools, tool)
 			if filter(tool) {
 				tools = append(tools, tool)
 			}
 		}
 	}
+	for _, name := range unknownToolOverrides(cfg.ToolOverrides, allTools) {
+		klogutil.LogWarn(klog.FromContext(ctx), "tool_overrides references a tool that isn't provided by the enabled toolsets",
+			klogutil.Field("tool", name))
+	}
 	return tools
 }
 
-// collectApplicablePrompts returns prompts after applying mutation and merging toolset and config prompts
-func (s *Server) collectApplicablePrompts(cfg *Configuration) []api.ServerPrompt {
+// collectApplicablePrompts returns prompts after applying mutation and merging toolset and config prompts.
+// Toolset prompts requiring cluster capabilities that aren't available are skipped.
+func (s *Server) collectApplicablePrompts(cfg *Configuration, probe CapabilityProbe) []api.ServerPrompt {
 	mutator := WithPromptTargetParameter(s.p.GetDefaultTarget(), s.p.GetTargetParameterName(), s.p.IsMultiTarget())
 
 	toolsetPrompts := make([]api.ServerPrompt, 0)
 	for _, toolset := range cfg.Toolsets() {
 		for _, prompt := range toolset.GetPrompts() {
-			toolsetPrompts = append(toolsetPrompts, mutator(prompt))
+			if hasCapabilities(probe, prompt.RequiredCapabilities) {
+				toolsetPrompts = append(toolsetPrompts, mutator(prompt))
+			}
 		}
 	}
 	configPrompts := prompts.ToServerPrompts(cfg.Prompts)
 	return prompts.MergePrompts(toolsetPrompts, configPrompts)
 }
 
+// capabilityProbe returns a CapabilityProbe reporting the capabilities force-enabled in cfg as available, and
+// probing the cluster via discovery for the rest. Probe results are cached, a new probe is created for each
+// toolset application so that capabilities installed or removed later are picked up on cluster state changes.
+func (s *Server) capabilityProbe(ctx context.Context, cfg *Configuration) CapabilityProbe {
+	available := make(map[string]bool)
+	return func(capability api.Capability) bool {
+		if slices.Contains(cfg.EnabledCapabilities, capability.Name) {
+			return true
+		}
+		if _, probed := available[capability.Name]; !probed {
+			available[capability.Name] = s.p.HasGVKs(ctx, capability.GVKs)
+			if !available[capability.Name] {
+				klog.FromContext(ctx).V(1).Info("Cluster capability not available, skipping the tools and prompts requiring it",
+					"capability", capability.Name)
+			}
+		}
+		return available[capability.Name]
+	}
+}
+
 // collectApplicableResources returns resources from all enabled toolsets after filtering and mutation
 func (s *Server) collectApplicableResources(cfg *Configuration) []api.ServerResource {
 	filter := CompositeResourceFilter()
@@ -482,10 +542,20 @@ func (s *Server) ServeStdio(ctx context.Context) error {
 	return s.server.Run(ctx, &mcp.StdioTransport{})
 }
 
-func (s *Server) ServeSse() *mcp.SSEHandler {
-	return mcp.NewSSEHandler(func(request *http.Request) *mcp.Server {
+// ServeSse returns the handler of the SSE transport, the idle event streams are kept alive with periodic comments
+func (s *Server) ServeSse() http.Handler {
+	return sseKeepAlive(mcp.NewSSEHandler(func(request *http.Request) *mcp.Server {
 		return s.server
-	}, &mcp.SSEOptions{})
+	}, &mcp.SSEOptions{}), s.sseKeepAliveInterval)
+}
+
+// sseKeepAliveInterval returns the configured interval of the SSE keep-alive comments
+func (s *Server) sseKeepAliveInterval() time.Duration {
+	interval := s.configuration.Load().HTTP.SSEKeepAliveInterval.Duration()
+	if interval == 0 {
+		interval = config.DefaultSSEKeepAliveInterval
+	}
+	return interval
 }
 
 func (s *Server) ServeHTTP() *mcp.StreamableHTTPHandler {
@@ -511,6 +581,22 @@ func (s *Server) GetTargetParameterName() string {
 	return s.p.GetTargetParameterName()
 }
 
+// CheckReadiness verifies the Kubernetes API of the configured readiness check target is reachable
+// within the configured timeout, using the server's own credentials
+func (s *Server) CheckReadiness(ctx context.Context) error {
+	if s.p == nil {
+		return fmt.Errorf("kubernetes provider not initialized")
+	}
+	cfg := s.configuration.Load()
+	timeout := cfg.HTTP.ReadinessCheckTimeout.Duration()
+	if timeout == 0 {
+		timeout = config.DefaultReadinessCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return s.p.CheckReadiness(ctx, cfg.HTTP.ReadinessCheckTarget)
+}
+
 func (s *Server) GetEnabledTools() []string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -575,6 +661,7 @@ func (s *Server) ReloadConfiguration(ctx context.Context, newConfig *config.Stat
 func (s *Server) Close() {
 	s.closeOnce.Do(func() {
 		close(s.rateLimitDone)
+		s.subscriptions.close()
 		if s.p != nil {
 			s.p.Close()
 		}
@@ -653,6 +740,17 @@ func NewStructuredResult(content string, structuredContent any, err error) *mcp.
 	return result
 }
 
+// NewAttachmentContent creates the embedded resource (base64 blob) holding a tool result returned as a file
+func NewAttachmentContent(attachment *api.ToolCallAttachment, data []byte) *mcp.EmbeddedResource {
+	return &mcp.EmbeddedResource{
+		Resource: &mcp.ResourceContents{
+			URI:      "attachment:///" + url.PathEscape(attachment.Filename),
+			MIMEType: attachment.MIMEType,
+			Blob:     data,
+		},
+	}
+}
+
 // ensureStructuredObject wraps slice/array values in a {"items": ...} object
 // because the MCP specification requires structuredContent to be a JSON object.
 // A typed nil slice (e.g. []string(nil)) returns nil to avoid {"items": null}.
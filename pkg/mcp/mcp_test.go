@@ -204,6 +204,54 @@ func (s *UserAgentPropagationSuite) TestPropagatesExplicitUserAgentWithOAuthToKu
 	})
 }
 
+func (s *UserAgentPropagationSuite) TestAppendsConfiguredUserAgentSuffix() {
+	s.Cfg.UserAgentSuffix = "team-a/prod"
+	s.InitMcpClient(test.WithHTTPHeaders(map[string]string{
+		"User-Agent": "custom-mcp-client/2.0",
+	}))
+	_, _ = s.CallTool("pods_list", map[string]any{})
+
+	s.pathHeadersMux.Lock()
+	podsHeaders := s.pathHeaders["/api/v1/namespaces/default/pods"]
+	s.pathHeadersMux.Unlock()
+
+	s.Require().NotNil(podsHeaders, "No requests were made to /api/v1/namespaces/default/pods")
+	s.Run("User-Agent has the suffix after the client segment", func() {
+		s.Equal(
+			fmt.Sprintf("kubernetes-mcp-server/0.0.0 (%s/%s) custom-mcp-client/2.0 team-a/prod", runtime.GOOS, runtime.GOARCH),
+			podsHeaders.Get("User-Agent"),
+		)
+	})
+}
+
+func (s *UserAgentPropagationSuite) TestAppendsConfiguredUserAgentSuffixWhenNoClientInfo() {
+	s.Cfg.UserAgentSuffix = "team-a/prod"
+	provider, err := internalk8s.NewProvider(s.T().Context(), s.Cfg)
+	s.Require().NoError(err)
+	s.mcpServer, err = NewServer(s.T().Context(), Configuration{StaticConfig: s.Cfg}, provider)
+	s.Require().NoError(err)
+	handler := s.mcpServer.ServeHTTP()
+	strippedHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del("User-Agent")
+		handler.ServeHTTP(w, r)
+	})
+	s.McpClient = test.NewMcpClient(s.T(), strippedHandler, test.WithEmptyClientInfo())
+
+	_, _ = s.CallTool("pods_list", map[string]any{})
+
+	s.pathHeadersMux.Lock()
+	podsHeaders := s.pathHeaders["/api/v1/namespaces/default/pods"]
+	s.pathHeadersMux.Unlock()
+
+	s.Require().NotNil(podsHeaders, "No requests were made to /api/v1/namespaces/default/pods")
+	s.Run("User-Agent has the suffix after the server prefix with a single space", func() {
+		s.Equal(
+			fmt.Sprintf("kubernetes-mcp-server/0.0.0 (%s/%s) team-a/prod", runtime.GOOS, runtime.GOARCH),
+			podsHeaders.Get("User-Agent"),
+		)
+	})
+}
+
 func (s *UserAgentPropagationSuite) TestFallsBackToMCPClientInfoForUserAgent() {
 	// Create MCP client through a handler that strips the User-Agent header,
 	// simulating a transport without HTTP User-Agent (like stdio).
@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type MeshInjectionSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func meshInjectionPod(namespace, name string, labels map[string]string, containers ...string) v1.Pod {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: container})
+	}
+	return pod
+}
+
+func (s *MeshInjectionSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	namespaces := []v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bookinfo", Labels: map[string]string{"istio-injection": "enabled"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "canary", Labels: map[string]string{"istio.io/rev": "1-24"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ambient", Labels: map[string]string{"istio.io/dataplane-mode": "ambient"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}},
+	}
+	pods := []v1.Pod{
+		meshInjectionPod("bookinfo", "productpage", nil, "productpage", "istio-proxy"),
+		meshInjectionPod("bookinfo", "reviews", nil, "reviews"),
+		meshInjectionPod("bookinfo", "batch", map[string]string{"sidecar.istio.io/inject": "false"}, "batch"),
+		meshInjectionPod("canary", "ratings", nil, "ratings"),
+		meshInjectionPod("ambient", "details", nil, "details"),
+		meshInjectionPod("legacy", "db", nil, "db"),
+		meshInjectionPod("legacy", "api", map[string]string{"sidecar.istio.io/inject": "true"}, "api"),
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/namespaces":
+			test.WriteObject(w, &v1.NamespaceList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NamespaceList"}, Items: namespaces})
+		case "/api/v1/namespaces/bookinfo":
+			test.WriteObject(w, &namespaces[0])
+		case "/api/v1/pods":
+			// Paginated in two pages
+			if req.URL.Query().Get("continue") == "" {
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+					ListMeta: metav1.ListMeta{Continue: "page-2"}, Items: pods[:4]})
+			} else {
+				test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: pods[4:]})
+			}
+		case "/api/v1/namespaces/bookinfo/pods":
+			test.WriteObject(w, &v1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: pods[:3]})
+		}
+	}))
+}
+
+func (s *MeshInjectionSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *MeshInjectionSuite) TestMeshInjectionStatus() {
+	s.InitMcpClient()
+	s.Run("mesh_injection_status reports the injection of every namespace", func() {
+		toolResult, err := s.CallTool("mesh_injection_status", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the namespaces table with the pods of all the pages", func() {
+			s.Regexp(`(?m)^ambient\s+ambient\s+1\s+0\s+0$`, text)
+			s.Regexp(`(?m)^bookinfo\s+enabled\s+3\s+1\s+1$`, text)
+			s.Regexp(`(?m)^canary\s+revision 1-24\s+1\s+0\s+1$`, text)
+			s.Regexp(`(?m)^legacy\s+none\s+2\s+0\s+1$`, text)
+		})
+		s.Run("returns the compliant and non-compliant pods", func() {
+			s.Regexp(`(?m)^bookinfo\s+productpage\s+Compliant\s+yes\s*$`, text)
+			s.Regexp(`(?m)^bookinfo\s+reviews\s+NonCompliant\s+no\s+no istio-proxy container, likely created before injection was enabled$`, text)
+			s.Regexp(`(?m)^bookinfo\s+batch\s+Excluded\s+no\s+sidecar.istio.io/inject=false$`, text)
+			s.Regexp(`(?m)^canary\s+ratings\s+NonCompliant\s+no`, text)
+			s.Regexp(`(?m)^ambient\s+details\s+Ambient\s+no\s+ambient mode, no sidecar required$`, text)
+			s.Regexp(`(?m)^legacy\s+api\s+NonCompliant\s+no\s+sidecar.istio.io/inject=true but no istio-proxy container$`, text)
+		})
+		s.Run("doesn't list the pods outside the mesh", func() {
+			s.NotRegexp(`(?m)^legacy\s+db\s+`, text)
+		})
+		s.Run("returns the number of pods missing the sidecar", func() {
+			s.Contains(text, "3 pod(s) missing the istio-proxy sidecar")
+		})
+	})
+	s.Run("mesh_injection_status(namespace=bookinfo, nonCompliantOnly=true) lists the pods missing the sidecar", func() {
+		toolResult, err := s.CallTool("mesh_injection_status", map[string]interface{}{
+			"namespace":        "bookinfo",
+			"nonCompliantOnly": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`(?m)^bookinfo\s+reviews\s+NonCompliant`, text)
+		s.NotContains(text, "productpage")
+		s.NotContains(text, "canary")
+		s.Contains(text, "1 pod(s) missing the istio-proxy sidecar")
+	})
+}
+
+func TestMeshInjection(t *testing.T) {
+	suite.Run(t, new(MeshInjectionSuite))
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
 	internalk8s "github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/logging"
 	"github.com/containers/kubernetes-mcp-server/pkg/mcplog"
 	"github.com/containers/kubernetes-mcp-server/pkg/telemetry"
 	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
@@ -153,6 +154,51 @@ func sessionInjectionMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
 	}
 }
 
+// RequestIDMetaKey is the _meta key of the failed tool call results echoing the request ID, so that users can
+// correlate a failure with the server logs, the traces and the Kubernetes audit events.
+const RequestIDMetaKey = "requestId"
+
+// requestIDMiddleware assigns a correlation ID to every MCP request (tool calls included). The ID is always generated
+// by the server since it's sent as the Audit-ID of the Kubernetes API requests, the ID of the HTTP request (X-Request-Id
+// header, possibly provided by the client) is logged next to it when available (streamable HTTP and SSE transports).
+// The ID is propagated in the context (see logging.RequestIDFromContext) and added to the logger of the context so that
+// every log line of the request can be correlated.
+func requestIDMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		requestID := logging.NewRequestID()
+		keysAndValues := []any{"mcp.request.id", requestID}
+		if req.GetExtra() != nil {
+			if httpRequestID := logging.HeaderRequestID(req.GetExtra().Header); httpRequestID != "" {
+				keysAndValues = append(keysAndValues, "http.request.id", httpRequestID)
+			}
+		}
+		if session := req.GetSession(); session != nil && session.ID() != "" {
+			keysAndValues = append(keysAndValues, "mcp.session.id", session.ID())
+		}
+		logger := klog.FromContext(ctx).WithValues(keysAndValues...)
+		ctx = klog.NewContext(logging.ContextWithRequestID(ctx, requestID), logger)
+		result, err := next(ctx, method, req)
+		if callResult, ok := result.(*mcp.CallToolResult); ok && callResult != nil && callResult.IsError {
+			if callResult.Meta == nil {
+				callResult.Meta = mcp.Meta{}
+			}
+			callResult.Meta[RequestIDMetaKey] = requestID
+			toolName := ""
+			if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+				toolName = params.Name
+			}
+			var text string
+			if len(callResult.Content) > 0 {
+				if textContent, ok := callResult.Content[0].(*mcp.TextContent); ok {
+					text = mcplog.Sanitize(textContent.Text)
+				}
+			}
+			logger.V(2).Info("Tool call failed", "gen_ai.tool.name", toolName, "error", text)
+		}
+		return result, err
+	}
+}
+
 func authHeaderPropagationMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
 	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
 		if req.GetExtra() != nil && req.GetExtra().Header != nil {
@@ -175,7 +221,10 @@ func authHeaderPropagationMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
 	}
 }
 
-func userAgentPropagationMiddleware(serverName, serverVersion string) func(mcp.MethodHandler) mcp.MethodHandler {
+// userAgentPropagationMiddleware sets the User-Agent for the Kube API requests to
+// "<serverName>/<serverVersion> (<os>/<arch>) <client> <suffix>" where the client and the
+// (configured) suffix segments are omitted when empty.
+func userAgentPropagationMiddleware(serverName, serverVersion string, suffix func() string) func(mcp.MethodHandler) mcp.MethodHandler {
 	return func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (result mcp.Result, err error) {
 			userAgentHeader := strings.TrimSpace(fmt.Sprintf(
@@ -186,6 +235,9 @@ func userAgentPropagationMiddleware(serverName, serverVersion string) func(mcp.M
 				runtime.GOARCH,
 				getMcpReqUserAgent(req),
 			))
+			if s := suffix(); s != "" {
+				userAgentHeader += " " + s
+			}
 			return next(context.WithValue(ctx, internalk8s.UserAgentHeader, userAgentHeader), method, req)
 		}
 	}
@@ -262,6 +314,7 @@ func tracingMiddleware(tracerName string) func(mcp.MethodHandler) mcp.MethodHand
 			spanName := method
 			attrs := []attribute.KeyValue{
 				attribute.String("mcp.method.name", method),
+				attribute.String("mcp.request.id", logging.RequestIDFromContext(ctx)),
 				attribute.String("rpc.jsonrpc.version", "2.0"),
 				attribute.String("network.transport", transport),
 			}
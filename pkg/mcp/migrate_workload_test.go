@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+var migrateWorkloadObjects = map[string]string{
+	"/apis/apps/v1/namespaces/source/deployments/web": `{"apiVersion":"apps/v1","kind":"Deployment",
+	  "metadata":{"name":"web","namespace":"source","uid":"1234","resourceVersion":"42","generation":3,
+	    "annotations":{"deployment.kubernetes.io/revision":"3"}},
+	  "spec":{"selector":{"matchLabels":{"app":"web"}},"template":{"metadata":{"labels":{"app":"web"}},"spec":{
+	    "serviceAccountName":"web",
+	    "containers":[{"name":"web","image":"nginx","envFrom":[{"secretRef":{"name":"web-credentials"}}]}],
+	    "volumes":[{"name":"config","configMap":{"name":"web-config"}}]}}},
+	  "status":{"replicas":1,"readyReplicas":1}}`,
+	"/api/v1/namespaces/source/configmaps/web-config": `{"apiVersion":"v1","kind":"ConfigMap",
+	  "metadata":{"name":"web-config","namespace":"source","uid":"5678","resourceVersion":"7"},"data":{"level":"debug"}}`,
+	"/api/v1/namespaces/source/secrets/web-credentials": `{"apiVersion":"v1","kind":"Secret","type":"Opaque",
+	  "metadata":{"name":"web-credentials","namespace":"source","uid":"9012","resourceVersion":"8"},"data":{"password":"czNjcjN0"}}`,
+}
+
+type MigrateWorkloadSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *MigrateWorkloadSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.Contains(req.URL.Path, "/namespaces/source/") {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if object, ok := migrateWorkloadObjects[req.URL.Path]; ok {
+			_, _ = w.Write([]byte(object))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+	}))
+}
+
+func (s *MigrateWorkloadSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *MigrateWorkloadSuite) TestMigrateWorkload() {
+	s.InitMcpClient()
+	result, err := s.GetPrompt("migrate-workload", map[string]string{
+		"namespace":        "source",
+		"kind":             "Deployment",
+		"name":             "web",
+		"target_namespace": "target",
+	})
+	s.Run("prompt executes without error", func() {
+		s.Require().NoError(err)
+		s.Require().Len(result.Messages, 2)
+		s.Equal("user", string(result.Messages[0].Role))
+	})
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	s.Run("provides the cleaned workload manifest in the target namespace", func() {
+		s.Contains(text, "### Deployment web")
+		s.Contains(text, "namespace: target")
+		s.NotContains(text, "namespace: source")
+		s.NotContains(text, "resourceVersion:")
+		s.NotContains(text, "annotations:")
+		s.NotContains(text, "uid:")
+		s.NotContains(text, "readyReplicas")
+		s.NotContains(text, "deployment.kubernetes.io/revision")
+	})
+	s.Run("provides the referenced dependencies", func() {
+		s.Contains(text, "### ConfigMap web-config")
+		s.Contains(text, "level: debug")
+		s.Contains(text, "### Secret web-credentials")
+	})
+	s.Run("redacts Secret data by default", func() {
+		s.NotContains(text, "czNjcjN0")
+		s.Contains(text, "The data of the Secret web-credentials (keys: password) has been omitted")
+	})
+	s.Run("reports missing dependencies", func() {
+		s.Contains(text, "Referenced dependency couldn't be exported: ServiceAccount web: not found")
+	})
+}
+
+func (s *MigrateWorkloadSuite) TestMigrateWorkloadWithSecretsGetEnabled() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.InitMcpClient()
+	result, err := s.GetPrompt("migrate-workload", map[string]string{
+		"namespace":        "source",
+		"kind":             "Deployment",
+		"name":             "web",
+		"target_namespace": "target",
+	})
+	s.Require().NoError(err)
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	s.Contains(text, "password: czNjcjN0")
+	s.NotContains(text, "has been omitted")
+}
+
+func (s *MigrateWorkloadSuite) TestMigrateWorkloadSameNamespace() {
+	s.InitMcpClient()
+	_, err := s.GetPrompt("migrate-workload", map[string]string{
+		"namespace":        "source",
+		"kind":             "Deployment",
+		"name":             "web",
+		"target_namespace": "source",
+	})
+	s.ErrorContains(err, "target_namespace must be different from namespace")
+}
+
+func TestMigrateWorkload(t *testing.T) {
+	suite.Run(t, new(MigrateWorkloadSuite))
+}
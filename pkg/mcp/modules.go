@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
 	_ "github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
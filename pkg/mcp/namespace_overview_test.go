@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NamespaceOverviewSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *NamespaceOverviewSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	discoveryHandler.APIResourceLists[1].APIResources = append(discoveryHandler.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1/pods":
+			if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+				_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table",` +
+					`"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Status","type":"string"}],` +
+					`"rows":[{"cells":["web-1","Running"],"object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"ns-1"}}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"ns-1"}}]}`))
+		case "/apis/apps/v1/namespaces/ns-1/deployments":
+			if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+				_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table",` +
+					`"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Ready","type":"string"}],` +
+					`"rows":[{"cells":["web","1/1"],"object":{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns-1"}}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"DeploymentList","items":[{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns-1"}}]}`))
+		case "/apis/apps/v1/namespaces/ns-1/replicasets":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"replicasets.apps is forbidden: User \"test\" cannot list resource \"replicasets\" in API group \"apps\" in the namespace \"ns-1\""}`))
+		case "/api/v1/namespaces/ns-1/services", "/api/v1/namespaces/ns-empty/services", "/api/v1/namespaces/ns-empty/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"List","items":[]}`))
+		case "/apis/apps/v1/namespaces/ns-empty/deployments", "/apis/apps/v1/namespaces/ns-empty/replicasets":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"List","items":[]}`))
+		}
+	}))
+}
+
+func (s *NamespaceOverviewSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NamespaceOverviewSuite) TestNamespaceOverview() {
+	s.InitMcpClient()
+	s.Run("namespace_overview(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("namespace_overview", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the resources grouped by kind", func() {
+			s.Contains(text, "# Pod\n")
+			s.Contains(text, "name: web-1")
+			s.Contains(text, "# Deployment.apps\n")
+			s.Contains(text, "name: web\n")
+		})
+		s.Run("omits kinds without resources", func() {
+			s.NotContains(text, "# Service")
+		})
+		s.Run("reports kinds that can't be listed", func() {
+			s.Contains(text, "# ReplicaSet.apps: failed to list resources: replicasets.apps is forbidden")
+		})
+	})
+	s.Run("namespace_overview(namespace=ns-1, output=table)", func() {
+		toolResult, err := s.CallTool("namespace_overview", map[string]interface{}{"namespace": "ns-1", "output": "table"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("renders each kind as a table", func() {
+			s.Regexp(`(?m)^# Pod\nNAMESPACE\s+APIVERSION\s+KIND\s+NAME\s+STATUS`, text)
+			s.Regexp(`(?m)^ns-1\s+v1\s+Pod\s+web-1\s+Running`, text)
+			s.Regexp(`(?m)^# Deployment.apps\nNAMESPACE\s+APIVERSION\s+KIND\s+NAME\s+READY`, text)
+			s.Regexp(`(?m)^ns-1\s+apps/v1\s+Deployment\s+web\s+1/1`, text)
+		})
+	})
+	s.Run("namespace_overview(namespace=ns-empty) without resources", func() {
+		toolResult, err := s.CallTool("namespace_overview", map[string]interface{}{"namespace": "ns-empty"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("No resources found in ns-empty namespace.", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *NamespaceOverviewSuite) TestNamespaceOverviewScanKinds() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		scan_kinds = [ "Deployment.apps" ]
+	`), s.Cfg), "Expected to parse scan_kinds config")
+	s.InitMcpClient()
+	s.Run("namespace_overview(namespace=ns-1) lists the configured kinds", func() {
+		toolResult, err := s.CallTool("namespace_overview", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# Deployment.apps\n")
+		s.NotContains(text, "# Pod")
+		s.NotContains(text, "# ReplicaSet.apps")
+	})
+}
+
+func (s *NamespaceOverviewSuite) TestNamespaceOverviewDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("namespace_overview(namespace=ns-1) omits denied kinds", func() {
+		toolResult, err := s.CallTool("namespace_overview", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.NotContains(text, "web-1")
+		s.Contains(text, "# Deployment.apps\n")
+	})
+}
+
+func TestNamespaceOverview(t *testing.T) {
+	suite.Run(t, new(NamespaceOverviewSuite))
+}
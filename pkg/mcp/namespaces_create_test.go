@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NamespacesCreateSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	created    []string
+}
+
+func (s *NamespacesCreateSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.created = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list", "create"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/v1/namespaces" && req.Method == http.MethodPost {
+			body, _ := io.ReadAll(req.Body)
+			s.mu.Lock()
+			s.created = append(s.created, string(body))
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		}
+	}))
+}
+
+func (s *NamespacesCreateSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NamespacesCreateSuite) createdNamespaces() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.created...)
+}
+
+func (s *NamespacesCreateSuite) TestNamespacesCreate() {
+	s.InitMcpClient()
+	s.Run("namespaces_create(name=bare)", func() {
+		toolResult, err := s.CallTool("namespaces_create", map[string]interface{}{"name": "bare"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("returns the created namespace", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# The Namespace (YAML) has been created successfully\n")
+			s.Contains(text, "name: bare")
+		})
+	})
+	s.Run("namespaces_create with labels, annotations and podSecurity", func() {
+		s.mu.Lock()
+		s.created = nil
+		s.mu.Unlock()
+		toolResult, err := s.CallTool("namespaces_create", map[string]interface{}{
+			"name":        "payments",
+			"labels":      map[string]interface{}{"team": "payments"},
+			"annotations": map[string]interface{}{"owner": "payments@example.com"},
+			"podSecurity": map[string]interface{}{"enforce": "baseline", "warn": "restricted"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("sets the labels, annotations and PodSecurity labels", func() {
+			s.Require().Len(s.createdNamespaces(), 1)
+			s.JSONEq(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"payments",`+
+				`"labels":{"team":"payments","pod-security.kubernetes.io/enforce":"baseline","pod-security.kubernetes.io/warn":"restricted"},`+
+				`"annotations":{"owner":"payments@example.com"}}}`, s.createdNamespaces()[0])
+		})
+	})
+}
+
+func (s *NamespacesCreateSuite) TestNamespacesCreateValidation() {
+	s.InitMcpClient()
+	s.Run("namespaces_create with invalid PodSecurity level", func() {
+		toolResult, _ := s.CallTool("namespaces_create", map[string]interface{}{
+			"name":        "payments",
+			"podSecurity": map[string]interface{}{"enforce": "strict"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "PodSecurity enforce level strict (expected privileged, baseline, restricted)")
+	})
+	s.Run("namespaces_create with PodSecurity level conflicting with labels", func() {
+		toolResult, _ := s.CallTool("namespaces_create", map[string]interface{}{
+			"name":        "payments",
+			"labels":      map[string]interface{}{"pod-security.kubernetes.io/enforce": "privileged"},
+			"podSecurity": map[string]interface{}{"enforce": "restricted"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			"PodSecurity enforce level restricted conflicts with label pod-security.kubernetes.io/enforce=privileged")
+	})
+	s.Run("namespaces_create with invalid label", func() {
+		toolResult, _ := s.CallTool("namespaces_create", map[string]interface{}{
+			"name":   "payments",
+			"labels": map[string]interface{}{"team": "payments team"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid namespace metadata: label team=payments team")
+	})
+	s.Run("namespaces_create with invalid name", func() {
+		toolResult, _ := s.CallTool("namespaces_create", map[string]interface{}{"name": "Payments"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid namespace name Payments")
+	})
+	s.Run("doesn't create invalid namespaces", func() {
+		s.Empty(s.createdNamespaces())
+	})
+}
+
+func (s *NamespacesCreateSuite) TestNamespacesCreateDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Namespace" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("namespaces_create (denied)", func() {
+		toolResult, err := s.CallTool("namespaces_create", map[string]interface{}{"name": "payments"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Namespace")
+		})
+	})
+}
+
+func TestNamespacesCreate(t *testing.T) {
+	suite.Run(t, new(NamespacesCreateSuite))
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NetworkPoliciesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *NetworkPoliciesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "networkpolicies", Kind: "NetworkPolicy", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web","namespace":"default","labels":{"app":"web"}}}`))
+		case "/api/v1/namespaces/default/pods/unlabeled":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"unlabeled","namespace":"default"}}`))
+		case "/apis/networking.k8s.io/v1/namespaces/default/networkpolicies":
+			_, _ = w.Write([]byte(`{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicyList","items":[` +
+				`{"metadata":{"name":"default-deny-ingress","namespace":"default"},"spec":{"podSelector":{},"policyTypes":["Ingress"]}},` +
+				`{"metadata":{"name":"allow-frontend","namespace":"default"},"spec":{"podSelector":{"matchLabels":{"app":"web"}},` +
+				`"ingress":[{"from":[{"podSelector":{"matchLabels":{"role":"frontend"}}},{"ipBlock":{"cidr":"10.0.0.0/8","except":["10.1.0.0/16"]}}],"ports":[{"protocol":"TCP","port":80}]}]}},` +
+				`{"metadata":{"name":"db-egress","namespace":"default"},"spec":{"podSelector":{"matchLabels":{"app":"db"}},"policyTypes":["Egress"],` +
+				`"egress":[{"to":[{"namespaceSelector":{"matchLabels":{"name":"monitoring"}}}]}]}}` +
+				`]}`))
+		}
+	}))
+}
+
+func (s *NetworkPoliciesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NetworkPoliciesSuite) TestNetworkPoliciesAnalyze() {
+	s.InitMcpClient()
+	s.Run("networkpolicies_analyze(pod=web)", func() {
+		toolResult, err := s.CallTool("networkpolicies_analyze", map[string]interface{}{"pod": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns only the selecting policies", func() {
+			s.Contains(text, "Name: default-deny-ingress")
+			s.Contains(text, "Name: allow-frontend")
+			s.NotContains(text, "db-egress")
+		})
+		s.Run("summarizes the allowed ingress rules", func() {
+			s.Contains(text, "allow-frontend: from pods (role=frontend) in the same namespace or ipBlock 10.0.0.0/8")
+			s.Contains(text, "except 10.1.0.0/16 on TCP/80")
+			s.Contains(text, "DefaultDeny: false")
+		})
+		s.Run("egress is not isolated", func() {
+			s.Contains(text, "Egress:\n  Isolated: false")
+			s.Contains(text, "No NetworkPolicy of type Egress selects the Pod")
+		})
+	})
+	s.Run("networkpolicies_analyze(pod=unlabeled) highlights default deny", func() {
+		toolResult, err := s.CallTool("networkpolicies_analyze", map[string]interface{}{"pod": "unlabeled"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "DefaultDeny: true")
+		s.Contains(text, "Default deny: the Pod is selected by Ingress policies without rules")
+		s.NotContains(text, "allow-frontend")
+	})
+	s.Run("networkpolicies_analyze() returns all policies in namespace", func() {
+		toolResult, err := s.CallTool("networkpolicies_analyze", map[string]interface{}{})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "Target: Namespace default")
+		s.Contains(text, "Name: db-egress")
+		s.Contains(text, "to all pods in namespaces (name=monitoring) on all ports")
+		s.Contains(text, "NamespaceDefaultDeny:\n  Ingress:\n  - default-deny-ingress")
+	})
+	s.Run("networkpolicies_analyze(pod=not-found) returns error", func() {
+		toolResult, _ := s.CallTool("networkpolicies_analyze", map[string]interface{}{"pod": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+	})
+}
+
+func TestNetworkPolicies(t *testing.T) {
+	suite.Run(t, new(NetworkPoliciesSuite))
+}
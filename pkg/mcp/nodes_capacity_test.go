@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type NodesCapacitySuite struct {
+	BaseMcpSuite
+	mockServer    *test.MockServer
+	mu            sync.Mutex
+	fieldSelector string
+}
+
+func nodesCapacityNode(name, cpu, memory string, unschedulable bool) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.NodeSpec{Unschedulable: unschedulable},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func nodesCapacityPod(name, node string, requests, limits v1.ResourceList) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PodSpec{NodeName: node, Containers: []v1.Container{
+			{Name: "app", Resources: v1.ResourceRequirements{Requests: requests, Limits: limits}},
+		}},
+	}
+}
+
+func (s *NodesCapacitySuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.fieldSelector = ""
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/nodes":
+			nodes := &v1.NodeList{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "NodeList"},
+				Items: []v1.Node{
+					nodesCapacityNode("node-2", "2", "4Gi", false),
+					nodesCapacityNode("node-1", "4", "8Gi", false),
+					nodesCapacityNode("node-3", "4", "8Gi", true),
+				},
+			}
+			if req.URL.Query().Get("labelSelector") == "pool=small" {
+				nodes.Items = nodes.Items[:1]
+			}
+			test.WriteObject(w, nodes)
+		case "/api/v1/pods":
+			s.mu.Lock()
+			s.fieldSelector = req.URL.Query().Get("fieldSelector")
+			s.mu.Unlock()
+			test.WriteObject(w, &v1.PodList{
+				TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+				Items: []v1.Pod{
+					nodesCapacityPod("web", "node-1", v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("2Gi")}, nil),
+					nodesCapacityPod("db", "node-2",
+						v1.ResourceList{v1.ResourceCPU: resource.MustParse("1500m"), v1.ResourceMemory: resource.MustParse("1Gi")},
+						v1.ResourceList{v1.ResourceMemory: resource.MustParse("6Gi")}),
+					nodesCapacityPod("pending", "", v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")}, nil),
+				},
+			})
+		}
+	}))
+}
+
+func (s *NodesCapacitySuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NodesCapacitySuite) podsFieldSelector() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fieldSelector
+}
+
+func (s *NodesCapacitySuite) TestNodesCapacity() {
+	s.InitMcpClient()
+	s.Run("nodes_capacity returns the capacity of every node", func() {
+		toolResult, err := s.CallTool("nodes_capacity", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("lists only the non-terminated pods", func() {
+			s.Equal("status.phase!=Succeeded,status.phase!=Failed", s.podsFieldSelector())
+		})
+		s.Run("returns the requests and headroom of each node sorted by name", func() {
+			s.Regexp(`(?m)^node-1\s+Schedulable\s+1/110\s+1000m/4000m\s+25%\s+3000m\s+0%\s+2048Mi/8192Mi\s+25%\s+6144Mi\s+0%$`, text)
+			s.Regexp(`(?m)^node-3\s+Unschedulable\s+0/110\s+0m/4000m\s+0%\s+4000m`, text)
+			s.Less(strings.Index(text, "node-1"), strings.Index(text, "node-2"))
+		})
+		s.Run("flags the over-committed nodes", func() {
+			s.Regexp(`(?m)^node-2\s+Schedulable,OverCommitted\s+1/110\s+1500m/2000m\s+75%\s+500m\s+0%\s+1024Mi/4096Mi\s+25%\s+3072Mi\s+150%$`, text)
+			s.Contains(text, "Over-committed nodes (requests or limits above the allocatable resources): node-2 (memory)\n")
+		})
+		s.Run("returns the total of the schedulable nodes", func() {
+			s.Regexp(`(?m)^total\s+2/220\s+2500m/6000m\s+41%\s+3500m\s+0%\s+3072Mi/12288Mi\s+25%\s+9216Mi\s+50%$`, text)
+		})
+		s.Run("returns the largest headroom on a single node", func() {
+			s.Contains(text, "Largest headroom on a single schedulable node: cpu 3000m (node-1), memory 6144Mi (node-1).\n")
+		})
+	})
+	s.Run("nodes_capacity with a single node lists the pods of the node", func() {
+		toolResult, err := s.CallTool("nodes_capacity", map[string]interface{}{"label_selector": "pool=small"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("spec.nodeName=node-2,status.phase!=Succeeded,status.phase!=Failed", s.podsFieldSelector())
+	})
+}
+
+func (s *NodesCapacitySuite) TestNodesCapacityDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("nodes_capacity", map[string]interface{}{})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+}
+
+func (s *NodesCapacitySuite) TestCapacityPlanningPrompt() {
+	s.InitMcpClient()
+	s.Run("capacity-planning provides the capacity of the nodes", func() {
+		result, err := s.GetPrompt("capacity-planning", map[string]string{})
+		s.Require().NoError(err)
+		s.Require().Len(result.Messages, 2)
+		text := result.Messages[0].Content.(*mcp.TextContent).Text
+		s.Contains(text, "# Cluster Capacity Planning")
+		s.Contains(text, "Largest headroom on a single schedulable node")
+		s.NotContains(text, "## Workload to fit")
+	})
+	s.Run("capacity-planning with a workload reports the replicas that fit on each node", func() {
+		result, err := s.GetPrompt("capacity-planning", map[string]string{"cpu": "1", "memory": "2Gi", "replicas": "5"})
+		s.Require().NoError(err)
+		text := result.Messages[0].Content.(*mcp.TextContent).Text
+		s.Contains(text, "## Workload to fit: 5 replica(s) requesting cpu 1 and memory 2Gi each\n")
+		s.Contains(text, "- node-1: 3 replica(s)\n- node-2: 0 replica(s)\n- node-3: 0 replica(s)\n")
+		s.Contains(text, "can only accommodate 3 of the 5 requested replica(s)")
+	})
+	s.Run("capacity-planning with an invalid quantity returns error", func() {
+		_, err := s.GetPrompt("capacity-planning", map[string]string{"memory": "lots"})
+		s.ErrorContains(err, `invalid memory argument "lots"`)
+	})
+}
+
+func TestNodesCapacity(t *testing.T) {
+	suite.Run(t, new(NodesCapacitySuite))
+}
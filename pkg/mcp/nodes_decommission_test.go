@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+var nodesDecommissionPods = map[string]string{
+	"node-1": `{"apiVersion":"v1","kind":"PodList","items":[
+	  {"metadata":{"name":"web-1","namespace":"ns-1","uid":"1","ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"web","uid":"10","controller":true}]},"status":{"phase":"Running"}},
+	  {"metadata":{"name":"agent-1","namespace":"kube-system","uid":"2","ownerReferences":[{"apiVersion":"apps/v1","kind":"DaemonSet","name":"agent","uid":"20","controller":true}]},"status":{"phase":"Running"}},
+	  {"metadata":{"name":"static-1","namespace":"kube-system","uid":"3","annotations":{"kubernetes.io/config.mirror":"abc"}},"status":{"phase":"Running"}}]}`,
+	"node-unmanaged": `{"apiVersion":"v1","kind":"PodList","items":[
+	  {"metadata":{"name":"standalone","namespace":"ns-1","uid":"4"},"status":{"phase":"Running"}}]}`,
+	"node-pdb": `{"apiVersion":"v1","kind":"PodList","items":[
+	  {"metadata":{"name":"db-0","namespace":"ns-1","uid":"5","ownerReferences":[{"apiVersion":"apps/v1","kind":"StatefulSet","name":"db","uid":"50","controller":true}]},"status":{"phase":"Running"}}]}`,
+}
+
+type NodesDecommissionSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	requests   []string
+}
+
+func (s *NodesDecommissionSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requests = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := req.URL.Path
+		switch {
+		case strings.HasPrefix(path, "/api/v1/nodes/") && req.Method == http.MethodGet:
+			name := strings.TrimPrefix(path, "/api/v1/nodes/")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"` + name + `"}}`))
+		case strings.HasPrefix(path, "/api/v1/nodes/") && (req.Method == http.MethodPatch || req.Method == http.MethodDelete):
+			s.record(req.Method + " " + path)
+			name := strings.TrimPrefix(path, "/api/v1/nodes/")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"` + name + `"},"spec":{"unschedulable":true}}`))
+		case path == "/api/v1/pods":
+			node := strings.TrimPrefix(req.URL.Query().Get("fieldSelector"), "spec.nodeName=")
+			_, _ = w.Write([]byte(nodesDecommissionPods[node]))
+		case strings.HasSuffix(path, "/eviction") && req.Method == http.MethodPost:
+			if strings.Contains(path, "/pods/db-0/") {
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"TooManyRequests","code":429,` +
+					`"message":"Cannot evict pod as it would violate the pod's disruption budget."}`))
+				return
+			}
+			s.record(req.Method + " " + path)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Success","code":201}`))
+		case strings.HasPrefix(path, "/api/v1/namespaces/") && req.Method == http.MethodGet:
+			// evicted Pods are gone
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+		}
+	}))
+}
+
+func (s *NodesDecommissionSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NodesDecommissionSuite) record(request string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *NodesDecommissionSuite) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *NodesDecommissionSuite) TestNodesDecommission() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("nodes_decommission", map[string]interface{}{"name": "node-1", "confirm": true})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("cordons, drains and deletes the node in order", func() {
+		s.Equal([]string{
+			"PATCH /api/v1/nodes/node-1",
+			"POST /api/v1/namespaces/ns-1/pods/web-1/eviction",
+			"DELETE /api/v1/nodes/node-1",
+		}, s.recorded())
+	})
+	s.Run("reports the performed steps", func() {
+		s.Equal("# Node node-1 decommissioned\n"+
+			"- Cordoned node node-1\n"+
+			"- Evicted pod ns-1/web-1\n"+
+			"- Skipped pod kube-system/agent-1 (DaemonSet)\n"+
+			"- Skipped pod kube-system/static-1 (static Pod)\n"+
+			"- Deleted node node-1\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *NodesDecommissionSuite) TestNodesDecommissionUnmanagedPods() {
+	s.InitMcpClient()
+	s.Run("nodes_decommission with Pods not managed by a controller", func() {
+		toolResult, err := s.CallTool("nodes_decommission", map[string]interface{}{"name": "node-unmanaged", "confirm": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "the following Pods are not managed by a controller and won't be recreated on another node, set force to evict them anyway: ns-1/standalone")
+		s.Contains(text, "The node node-unmanaged is left cordoned")
+		s.Equal([]string{"PATCH /api/v1/nodes/node-unmanaged"}, s.recorded())
+	})
+	s.Run("nodes_decommission with Pods not managed by a controller and force", func() {
+		toolResult, err := s.CallTool("nodes_decommission", map[string]interface{}{"name": "node-unmanaged", "confirm": true, "force": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "- Evicted pod ns-1/standalone\n")
+	})
+}
+
+func (s *NodesDecommissionSuite) TestNodesDecommissionPodDisruptionBudget() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("nodes_decommission", map[string]interface{}{"name": "node-pdb", "confirm": true, "timeout": "1s"})
+	s.Run("fails when the eviction is blocked by a PodDisruptionBudget", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			"timed out evicting pod ns-1/db-0: Cannot evict pod as it would violate the pod's disruption budget.")
+	})
+	s.Run("doesn't delete the node", func() {
+		s.Equal([]string{"PATCH /api/v1/nodes/node-pdb"}, s.recorded())
+	})
+}
+
+func (s *NodesDecommissionSuite) TestNodesDecommissionRequiresConfirmation() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("nodes_decommission", map[string]interface{}{"name": "node-1"})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "decommissioning a node requires explicit confirmation (confirm=true)")
+	s.Empty(s.recorded())
+}
+
+func TestNodesDecommission(t *testing.T) {
+	suite.Run(t, new(NodesDecommissionSuite))
+}
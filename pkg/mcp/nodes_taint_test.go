@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type NodesTaintSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	node       *v1.Node
+	patches    int
+}
+
+func (s *NodesTaintSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.patches = 0
+	s.node = &v1.Node{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", ResourceVersion: "1"},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}}},
+	}
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/nodes/node-1" {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if req.Method == http.MethodPatch {
+			s.patches++
+			patch := &v1.Node{}
+			if err := json.NewDecoder(req.Body).Decode(patch); err != nil || patch.ResourceVersion != s.node.ResourceVersion {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Conflict","code":409}`))
+				return
+			}
+			s.node.Spec.Taints = patch.Spec.Taints
+			version, _ := strconv.Atoi(s.node.ResourceVersion)
+			s.node.ResourceVersion = strconv.Itoa(version + 1)
+		}
+		test.WriteObject(w, s.node)
+	}))
+}
+
+func (s *NodesTaintSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *NodesTaintSuite) patchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.patches
+}
+
+func (s *NodesTaintSuite) TestNodesTaint() {
+	s.InitMcpClient()
+	s.Run("nodes_taint with invalid effect returns error", func() {
+		toolResult, _ := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "dedicated", "effect": "NoWay"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to taint node node-1: invalid taint effect \"NoWay\", must be one of NoSchedule, PreferNoSchedule, NoExecute",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("nodes_taint with invalid key returns error", func() {
+		toolResult, _ := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "not a key", "effect": "NoSchedule"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid taint key \"not a key\"")
+	})
+	s.Run("nodes_taint adds the taint", func() {
+		toolResult, err := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "maintenance", "effect": "NoExecute"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# Node node-1 tainted with maintenance:NoExecute\nTaints:\n- dedicated=gpu:NoSchedule\n- maintenance:NoExecute\n",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(1, s.patchCount())
+	})
+	s.Run("nodes_taint with a taint already present is a no-op", func() {
+		toolResult, err := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "dedicated", "value": "gpu", "effect": "NoSchedule"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Node node-1 already has the taint dedicated=gpu:NoSchedule, nothing changed\n")
+		s.Equal(1, s.patchCount())
+	})
+	s.Run("nodes_taint with a different value requires overwrite", func() {
+		toolResult, _ := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "dedicated", "value": "db", "effect": "NoSchedule"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to taint node node-1: node node-1 already has the taint dedicated=gpu:NoSchedule with a different value, set overwrite to replace it",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("nodes_taint(overwrite=true) replaces the value", func() {
+		toolResult, err := s.CallTool("nodes_taint", map[string]interface{}{
+			"name": "node-1", "key": "dedicated", "value": "db", "effect": "NoSchedule", "overwrite": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# Node node-1 tainted with dedicated=db:NoSchedule\nTaints:\n- dedicated=db:NoSchedule\n- maintenance:NoExecute\n",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *NodesTaintSuite) TestNodesUntaint() {
+	s.InitMcpClient()
+	s.Run("nodes_untaint of a taint not present is a no-op", func() {
+		toolResult, err := s.CallTool("nodes_untaint", map[string]interface{}{"name": "node-1", "key": "dedicated", "effect": "NoExecute"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# Node node-1 doesn't have the taint dedicated:NoExecute, nothing changed\nTaints:\n- dedicated=gpu:NoSchedule\n",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(0, s.patchCount())
+	})
+	s.Run("nodes_untaint removes the taints with the key", func() {
+		toolResult, err := s.CallTool("nodes_untaint", map[string]interface{}{"name": "node-1", "key": "dedicated"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# Taint dedicated removed from node node-1\nThe node has no taints\n", toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Equal(1, s.patchCount())
+	})
+}
+
+func (s *NodesTaintSuite) TestNodesTaintDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Node" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("nodes_taint", map[string]interface{}{"name": "node-1", "key": "maintenance", "effect": "NoSchedule"})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Node")
+	s.Equal(0, s.patchCount())
+}
+
+func TestNodesTaint(t *testing.T) {
+	suite.Run(t, new(NodesTaintSuite))
+}
@@ -63,6 +63,9 @@ func (s *NodesSuite) TestNodesLog() {
 			if err == nil {
 				logContent = "Line 4\nLine 5\n"
 			}
+			if pattern := req.URL.Query().Get("pattern"); pattern != "" {
+				logContent = "Lines matching " + pattern + "\n"
+			}
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte(logContent))
 			return
@@ -198,6 +201,61 @@ func (s *NodesSuite) TestNodesLog() {
 			})
 		})
 	}
+	s.Run("nodes_log(name=existing-node, query=/kubelet.log, pattern=Line [45])", func() {
+		toolResult, err := s.CallTool("nodes_log", map[string]interface{}{
+			"name":    "existing-node",
+			"query":   "/kubelet.log",
+			"pattern": "Line [45]",
+		})
+		s.Run("no error", func() {
+			s.Falsef(toolResult.IsError, "call tool should succeed")
+			s.Nilf(err, "call tool should not return error object")
+		})
+		s.Run("forwards pattern to the kubelet", func() {
+			s.Equal("Lines matching Line [45]\n", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("nodes_log(name=existing-node, pattern=invalid)", func() {
+		toolResult, _ := s.CallTool("nodes_log", map[string]interface{}{
+			"name":    "existing-node",
+			"query":   "/kubelet.log",
+			"pattern": "Line [",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get node log, invalid pattern: error parsing regexp")
+	})
+	s.Run("nodes_log(name=existing-node, queries=[/kubelet.log, /empty.log])", func() {
+		toolResult, err := s.CallTool("nodes_log", map[string]interface{}{
+			"name":      "existing-node",
+			"query":     "/kubelet.log",
+			"queries":   []interface{}{"/kubelet.log", "/empty.log"},
+			"tailLines": 0,
+		})
+		s.Run("no error", func() {
+			s.Falsef(toolResult.IsError, "call tool should succeed")
+			s.Nilf(err, "call tool should not return error object")
+		})
+		s.Run("returns delimited logs for each query", func() {
+			expectedMessage := "==> /kubelet.log <==\nLine 1\nLine 2\nLine 3\nLine 4\nLine 5\n\n==> /empty.log <==\n"
+			s.Equal(expectedMessage, toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("nodes_log(name=existing-node, queries=[/kubelet.log, /missing.log])", func() {
+		toolResult, _ := s.CallTool("nodes_log", map[string]interface{}{
+			"name":    "existing-node",
+			"queries": []interface{}{"/kubelet.log", "/missing.log"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get node log for existing-node: query /missing.log: failed to get node logs: the server could not find the requested resource",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("nodes_log(name=existing-node, queries=invalid)", func() {
+		toolResult, _ := s.CallTool("nodes_log", map[string]interface{}{
+			"name":    "existing-node",
+			"queries": "/kubelet.log",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+	})
 }
 
 func (s *NodesSuite) TestNodesLogDenied() {
@@ -259,7 +317,18 @@ func (s *NodesSuite) TestNodesStatsSummary() {
 						"workingSetBytes": 3500000000
 					}
 				},
-				"pods": []
+				"pods": [
+					{
+						"podRef": {"name": "a-pod", "namespace": "default"},
+						"containers": [
+							{
+								"name": "app",
+								"cpu": {"usageNanoCores": 250000000},
+								"memory": {"workingSetBytes": 100000000}
+							}
+						]
+					}
+				]
 			}`))
 			return
 		}
@@ -310,6 +379,48 @@ func (s *NodesSuite) TestNodesStatsSummary() {
 			s.Containsf(content, "usageBytes", "expected stats to contain memory metrics, got %v", content)
 		})
 	})
+	s.Run("nodes_stats_summary(name=existing-node, fields=[node.cpu, pods[].podRef.name, pods[].containers[].cpu])", func() {
+		toolResult, err := s.CallTool("nodes_stats_summary", map[string]interface{}{
+			"name":   "existing-node",
+			"fields": []interface{}{"node.cpu", "pods[].podRef.name", "pods[].containers[].cpu"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("returns only the selected fields", func() {
+			s.JSONEq(`{
+				"node": {"cpu": {"time": "2025-10-27T00:00:00Z", "usageNanoCores": 1000000000, "usageCoreNanoSeconds": 5000000000}},
+				"pods": [{"podRef": {"name": "a-pod"}, "containers": [{"cpu": {"usageNanoCores": 250000000}}]}]
+			}`, toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("nodes_stats_summary(name=existing-node, fields=[node.memory.workingSetBytes, node.memory])", func() {
+		toolResult, err := s.CallTool("nodes_stats_summary", map[string]interface{}{
+			"name":   "existing-node",
+			"fields": []interface{}{"node.memory.workingSetBytes", "node.memory"},
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "availableBytes", "expected the parent path to select the whole value")
+	})
+	s.Run("nodes_stats_summary(name=existing-node, fields=[node.gpu]) with unknown fields returns error", func() {
+		toolResult, _ := s.CallTool("nodes_stats_summary", map[string]interface{}{
+			"name":   "existing-node",
+			"fields": []interface{}{"node.gpu"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get node stats summary for existing-node: none of the fields node.gpu were found in the node stats summary",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("nodes_stats_summary(name=existing-node, fields=node.cpu) with invalid fields returns error", func() {
+		toolResult, _ := s.CallTool("nodes_stats_summary", map[string]interface{}{
+			"name":   "existing-node",
+			"fields": "node.cpu",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get node stats summary, fields parameter must be an array of strings", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
 }
 
 func (s *NodesSuite) TestNodesStatsSummaryDenied() {
@@ -196,6 +196,134 @@ func (s *NodesTopSuite) TestNodesTop() {
 	})
 }
 
+func (s *NodesTopSuite) TestNodesTopGroupByLabel() {
+	s.WithMetricsServer()
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[` +
+				`{"metadata":{"name":"control-plane","labels":{"node-role.kubernetes.io/control-plane":""}},"status":{"allocatable":{"cpu":"2","memory":"4Gi"}}},` +
+				`{"metadata":{"name":"worker-a","labels":{"topology.kubernetes.io/zone":"zone-a"}},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}},` +
+				`{"metadata":{"name":"worker-b","labels":{"topology.kubernetes.io/zone":"zone-a"}},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}}` +
+				`]}`))
+		case "/apis/metrics.k8s.io/v1beta1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"metrics.k8s.io/v1beta1","kind":"NodeMetricsList","items":[` +
+				`{"metadata":{"name":"control-plane"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"1","memory":"2Gi"}},` +
+				`{"metadata":{"name":"worker-a"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"500m","memory":"2Gi"}},` +
+				`{"metadata":{"name":"worker-b"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"1500m","memory":"6Gi"}}` +
+				`]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	s.InitMcpClient()
+	s.Run("nodes_top(groupByLabel=topology.kubernetes.io/zone)", func() {
+		toolResult, err := s.CallTool("nodes_top", map[string]interface{}{
+			"groupByLabel": "topology.kubernetes.io/zone",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns metrics for all nodes", func() {
+			s.Contains(content, "worker-a")
+			s.Contains(content, "control-plane")
+		})
+		s.Run("returns totals and averages per label value", func() {
+			s.Regexp(`TOPOLOGY.KUBERNETES.IO/ZONE\s+NODES\s+CPU\(cores\)\s+CPU\(%\)\s+AVG CPU\(cores\)\s+MEMORY\(bytes\)\s+MEMORY\(%\)\s+AVG MEMORY\(bytes\)`, content)
+			s.Regexp(`<none>\s+1\s+1000m\s+50%\s+1000m\s+2048Mi\s+50%\s+2048Mi`, content)
+			s.Regexp(`zone-a\s+2\s+2000m\s+25%\s+1000m\s+8192Mi\s+25%\s+4096Mi`, content)
+		})
+	})
+	s.Run("nodes_top(groupByLabel=node-role.kubernetes.io/control-plane) groups empty label values", func() {
+		toolResult, err := s.CallTool("nodes_top", map[string]interface{}{
+			"groupByLabel": "node-role.kubernetes.io/control-plane",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`""\s+1\s+1000m`, content)
+		s.Regexp(`<none>\s+2\s+2000m`, content)
+	})
+	s.Run("nodes_top(groupByLabel) with invalid label returns error", func() {
+		toolResult, _ := s.CallTool("nodes_top", map[string]interface{}{
+			"groupByLabel": "not a label",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get nodes top: invalid groupByLabel \"not a label\"")
+	})
+}
+
+func (s *NodesTopSuite) TestNodesTopSortAndLimit() {
+	s.WithMetricsServer()
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[` +
+				`{"metadata":{"name":"node-small"},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}},` +
+				`{"metadata":{"name":"node-large"},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}},` +
+				`{"metadata":{"name":"node-medium"},"status":{"allocatable":{"cpu":"4","memory":"16Gi"}}}` +
+				`]}`))
+		case "/apis/metrics.k8s.io/v1beta1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"metrics.k8s.io/v1beta1","kind":"NodeMetricsList","items":[` +
+				`{"metadata":{"name":"node-small"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"3","memory":"1Gi"}},` +
+				`{"metadata":{"name":"node-large"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"1","memory":"8Gi"}},` +
+				`{"metadata":{"name":"node-medium"},"timestamp":"2025-10-29T09:00:00Z","window":"30s","usage":{"cpu":"2","memory":"4Gi"}}` +
+				`]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	s.InitMcpClient()
+	s.Run("nodes_top(sortBy=memory)", func() {
+		toolResult, err := s.CallTool("nodes_top", map[string]interface{}{
+			"sortBy": "memory",
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Regexp(`(?s)node-large.*node-medium.*node-small`, toolResult.Content[0].(*mcp.TextContent).Text,
+			"expected nodes sorted by memory in descending order")
+	})
+	s.Run("nodes_top(sortBy=cpu, descending=false, limit=2)", func() {
+		toolResult, err := s.CallTool("nodes_top", map[string]interface{}{
+			"sortBy":     "cpu",
+			"descending": false,
+			"limit":      2,
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`(?s)node-medium.*node-large`, content, "expected the nodes with the lowest cpu printed from the highest consumption")
+		s.NotContains(content, "node-small")
+		s.Contains(content, "# 1 more nodes not shown (limit reached)")
+	})
+	s.Run("nodes_top(sortBy=memory, limit=1)", func() {
+		toolResult, err := s.CallTool("nodes_top", map[string]interface{}{
+			"sortBy": "memory",
+			"limit":  1,
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the node with the highest consumption", func() {
+			s.Contains(content, "node-large")
+			s.NotContains(content, "node-medium")
+			s.NotContains(content, "node-small")
+		})
+		s.Run("returns the number of nodes not shown", func() {
+			s.Contains(content, "# 2 more nodes not shown (limit reached)")
+		})
+	})
+	s.Run("nodes_top(sortBy) with invalid value returns error", func() {
+		toolResult, _ := s.CallTool("nodes_top", map[string]interface{}{
+			"sortBy": "disk",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get nodes top: invalid sort by \"disk\"")
+	})
+}
+
 func (s *NodesTopSuite) TestNodesTopMetricsUnavailable() {
 	s.InitMcpClient()
 
@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PodsEnvSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsEnvSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/a-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pod","namespace":"default"},"spec":{"nodeName":"node-1","containers":[` +
+				`{"name":"app","image":"app","envFrom":[{"configMapRef":{"name":"app-config"}},{"prefix":"OPT_","configMapRef":{"name":"missing-config","optional":true}}],"env":[` +
+				`{"name":"LOG_LEVEL","value":"debug"},` +
+				`{"name":"DB_PASSWORD","valueFrom":{"secretKeyRef":{"name":"db","key":"password"}}},` +
+				`{"name":"DB_USER","valueFrom":{"secretKeyRef":{"name":"db","key":"user"}}},` +
+				`{"name":"DB_URL","value":"postgres://$(DB_HOST):5432"},` +
+				`{"name":"FEATURE","valueFrom":{"configMapKeyRef":{"name":"app-config","key":"feature"}}},` +
+				`{"name":"NODE_NAME","valueFrom":{"fieldRef":{"fieldPath":"spec.nodeName"}}}` +
+				`]},{"name":"sidecar","image":"sidecar","env":[{"name":"SIDECAR","value":"true"}]}]}}`))
+		case "/api/v1/namespaces/default/configmaps/app-config":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"app-config","namespace":"default"},"data":{"DB_HOST":"db.default.svc","LOG_LEVEL":"info"}}`))
+		case "/api/v1/namespaces/default/secrets/db":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"db","namespace":"default"},"data":{"password":"czNjcjN0"}}`))
+		case "/api/v1/namespaces/default/configmaps/missing-config":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"not found"}`))
+		}
+	}))
+}
+
+func (s *PodsEnvSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsEnvSuite) TestPodsEnv() {
+	s.InitMcpClient()
+	s.Run("pods_env with missing name returns error", func() {
+		toolResult, _ := s.CallTool("pods_env", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get pod environment: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_env(name=a-pod)", func() {
+		toolResult, err := s.CallTool("pods_env", map[string]interface{}{"namespace": "default", "name": "a-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the environment of the first container", func() {
+			s.Contains(text, "# The following environment (YAML format) was resolved for container app of Pod a-pod in namespace default")
+			s.NotContains(text, "SIDECAR")
+		})
+		s.Run("resolves envFrom and overrides it with env", func() {
+			s.Contains(text, "- name: DB_HOST\n  source: envFrom configMap app-config\n  value: db.default.svc\n")
+			s.Contains(text, "- name: LOG_LEVEL\n  source: value\n  value: debug\n")
+		})
+		s.Run("expands variable references", func() {
+			s.Contains(text, "- name: DB_URL\n  source: value\n  value: postgres://db.default.svc:5432\n")
+		})
+		s.Run("resolves fieldRef", func() {
+			s.Contains(text, "- name: NODE_NAME\n  source: fieldRef spec.nodeName\n  value: node-1\n")
+		})
+		s.Run("redacts secret values", func() {
+			s.Contains(text, "- name: DB_PASSWORD\n  redacted: true\n  source: secretKeyRef db/password\n")
+			s.NotContains(text, "s3cr3t")
+		})
+		s.Run("flags missing references", func() {
+			s.Contains(text, "- 'DB_USER: key user not found in Secret db, the container will fail to start'")
+			s.Contains(text, "- 'FEATURE: key feature not found in ConfigMap app-config, the container will fail")
+			s.Contains(text, "- 'envFrom configMap missing-config (optional): not found'")
+		})
+	})
+	s.Run("pods_env(container=sidecar)", func() {
+		toolResult, err := s.CallTool("pods_env", map[string]interface{}{"namespace": "default", "name": "a-pod", "container": "sidecar"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "- name: SIDECAR\n  source: value\n  value: \"true\"\n")
+	})
+	s.Run("pods_env with not found container returns error", func() {
+		toolResult, _ := s.CallTool("pods_env", map[string]interface{}{"namespace": "default", "name": "a-pod", "container": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get pod a-pod environment in namespace default: container not-found not found in pod a-pod", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *PodsEnvSuite) TestPodsEnvSecretsEnabled() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("pods_env", map[string]interface{}{"namespace": "default", "name": "a-pod"})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "- name: DB_PASSWORD\n  source: secretKeyRef db/password\n  value: s3cr3t\n")
+}
+
+func (s *PodsEnvSuite) TestPodsEnvDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("pods_env", map[string]interface{}{"namespace": "default", "name": "a-pod"})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Contains(text, "DB_PASSWORD: Secret db could not be retrieved")
+	s.Contains(text, "resource not allowed: /v1, Kind=Secret")
+	s.NotContains(text, "s3cr3t")
+}
+
+func TestPodsEnv(t *testing.T) {
+	suite.Run(t, new(PodsEnvSuite))
+}
@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/confirmation"
+)
+
+type PodsEvictSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	evictions  []string
+}
+
+func (s *PodsEvictSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.evictions = nil
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "policy/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/web-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"default","labels":{"app":"web"}}}`))
+		case "/api/v1/namespaces/default/pods/db-0":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"db-0","namespace":"default","labels":{"app":"db"}}}`))
+		case "/api/v1/namespaces/default/pods/shared-0":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"shared-0","namespace":"default","labels":{"app":"shared"}}}`))
+		case "/api/v1/namespaces/default/pods/web-1/eviction":
+			s.recordEviction(req)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success","code":201}`))
+		case "/api/v1/namespaces/default/pods/db-0/eviction":
+			s.recordEviction(req)
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"TooManyRequests","code":429,` +
+				`"message":"Cannot evict pod as it would violate the pod's disruption budget.",` +
+				`"details":{"causes":[{"reason":"DisruptionBudget","message":"The disruption budget db-pdb needs 3 healthy pods and has 3 currently"}]}}`))
+		case "/api/v1/namespaces/default/pods/shared-0/eviction":
+			s.recordEviction(req)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"InternalError","code":500,` +
+				`"message":"Internal error occurred: This pod has more than one PodDisruptionBudget, which the eviction subresource does not support."}`))
+		case "/apis/policy/v1/namespaces/default/poddisruptionbudgets":
+			_, _ = w.Write([]byte(`{"apiVersion":"policy/v1","kind":"PodDisruptionBudgetList","items":[` +
+				`{"metadata":{"name":"db-pdb","namespace":"default"},"spec":{"selector":{"matchLabels":{"app":"db"}}},` +
+				`"status":{"disruptionsAllowed":0,"currentHealthy":3,"desiredHealthy":3,"expectedPods":3}},` +
+				`{"metadata":{"name":"web-pdb","namespace":"default"},"spec":{"selector":{"matchLabels":{"app":"web"}}},"status":{"disruptionsAllowed":1}},` +
+				`{"metadata":{"name":"shared-pdb-a","namespace":"default"},"spec":{"selector":{"matchLabels":{"app":"shared"}}},"status":{"disruptionsAllowed":1}},` +
+				`{"metadata":{"name":"shared-pdb-b","namespace":"default"},"spec":{"selector":{"matchLabels":{"app":"shared"}}},"status":{"disruptionsAllowed":1}}]}`))
+		}
+	}))
+}
+
+func (s *PodsEvictSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsEvictSuite) recordEviction(req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictions = append(s.evictions, req.Method+" "+req.URL.Path)
+}
+
+func (s *PodsEvictSuite) recordedEvictions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.evictions...)
+}
+
+func (s *PodsEvictSuite) TestPodsEvict() {
+	s.InitMcpClient()
+	s.Run("pods_evict with missing name returns error", func() {
+		toolResult, _ := s.CallTool("pods_evict", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to evict pod: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_evict(name=web-1) evicts the pod", func() {
+		toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"namespace": "default", "name": "web-1"})
+		s.Require().NoError(err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("Pod web-1 in namespace default evicted, it's being terminated gracefully", toolResult.Content[0].(*mcp.TextContent).Text)
+		s.Contains(s.recordedEvictions(), "POST /api/v1/namespaces/default/pods/web-1/eviction")
+	})
+	s.Run("pods_evict(name=db-0) reports the eviction blocked by a PodDisruptionBudget", func() {
+		toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"name": "db-0"})
+		s.Require().NoError(err)
+		s.Falsef(toolResult.IsError, "a blocked eviction is not an error: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "Eviction of Pod db-0 in namespace default BLOCKED by a PodDisruptionBudget, the Pod was not evicted: "+
+			"The disruption budget db-pdb needs 3 healthy pods and has 3 currently\n")
+		s.Contains(text, "- db-pdb (disruptions allowed: 0, healthy pods: 3, desired healthy: 3, expected pods: 3)\n")
+		s.NotContains(text, "web-pdb")
+	})
+	s.Run("pods_evict(name=shared-0) reports the eviction blocked by multiple PodDisruptionBudgets", func() {
+		toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"name": "shared-0"})
+		s.Require().NoError(err)
+		s.Falsef(toolResult.IsError, "a blocked eviction is not an error: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "BLOCKED by a PodDisruptionBudget")
+		s.Contains(text, "more than one PodDisruptionBudget")
+		s.Contains(text, "- shared-pdb-a (")
+		s.Contains(text, "- shared-pdb-b (")
+	})
+	s.Run("pods_evict(name=missing) returns error", func() {
+		toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"namespace": "default", "name": "missing"})
+		s.Require().NoError(err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to evict pod missing in namespace default: ")
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "BLOCKED")
+	})
+}
+
+func (s *PodsEvictSuite) TestPodsEvictDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("pods_evict", map[string]interface{}{"namespace": "default", "name": "web-1"})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+	s.Empty(s.recordedEvictions())
+}
+
+func (s *PodsEvictSuite) TestPodsEvictDestructiveConfirmation() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+[[confirmation_rules]]
+destructive = true
+message = "Destructive operation."
+`), s.Cfg), "Expected to parse confirmation rules config")
+	s.InitMcpClient(test.WithElicitationHandler(
+		func(_ context.Context, _ *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	))
+	toolResult, err := s.CallTool("pods_evict", map[string]interface{}{"namespace": "default", "name": "web-1"})
+	s.Require().NoError(err)
+	s.True(toolResult.IsError)
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, confirmation.ErrConfirmationDenied.Error())
+	s.Empty(s.recordedEvictions())
+}
+
+func TestPodsEvict(t *testing.T) {
+	suite.Run(t, new(PodsEvictSuite))
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type PodsOnNodeSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsOnNodeSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "selfsubjectaccessreviews", Kind: "SelfSubjectAccessReview", Verbs: metav1.Verbs{"create"}},
+		},
+	}))
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews":
+			_, _ = w.Write([]byte(`{"apiVersion":"authorization.k8s.io/v1","kind":"SelfSubjectAccessReview","status":{"allowed":true}}`))
+		case "/api/v1/nodes/node-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-1"}}`))
+		case "/api/v1/nodes/missing-node":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"nodes \"missing-node\" not found"}`))
+		case "/api/v1/pods":
+			fieldSelector := req.URL.Query().Get("fieldSelector")
+			if !strings.HasPrefix(fieldSelector, "spec.nodeName=node-1") {
+				_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table","rows":[]}`))
+				return
+			}
+			rows := `{"cells":["web-1","1/1","Running","0","1h","10.0.0.1","node-1"],"object":{"apiVersion":"meta.k8s.io/v1","kind":"PartialObjectMetadata","metadata":{"name":"web-1","namespace":"ns-1"}}}`
+			if fieldSelector == "spec.nodeName=node-1" {
+				rows += `,{"cells":["db-0","0/1","Pending","0","5m","","node-1"],"object":{"apiVersion":"meta.k8s.io/v1","kind":"PartialObjectMetadata","metadata":{"name":"db-0","namespace":"ns-2"}}}`
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table","columnDefinitions":[` +
+				`{"name":"Name","type":"string"},{"name":"Ready","type":"string"},{"name":"Status","type":"string"},` +
+				`{"name":"Restarts","type":"string"},{"name":"Age","type":"string"},{"name":"IP","type":"string"},{"name":"Node","type":"string"}],` +
+				`"rows":[` + rows + `]}`))
+		}
+	}))
+}
+
+func (s *PodsOnNodeSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsOnNodeSuite) TestPodsOnNode() {
+	s.Cfg.ListOutput = "table"
+	s.InitMcpClient()
+	s.Run("pods_on_node with missing node returns error", func() {
+		toolResult, _ := s.CallTool("pods_on_node", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to list pods on node: node parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_on_node(node=missing-node) returns not found error", func() {
+		toolResult, _ := s.CallTool("pods_on_node", map[string]interface{}{"node": "missing-node"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to list pods on node missing-node: node missing-node not found")
+	})
+	s.Run("pods_on_node(node=node-1) lists the pods of the node in all namespaces", func() {
+		toolResult, err := s.CallTool("pods_on_node", map[string]interface{}{"node": "node-1"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the pod table columns", func() {
+			s.Regexp(`NAMESPACE\s+APIVERSION\s+KIND\s+NAME\s+READY\s+STATUS\s+RESTARTS\s+AGE\s+IP\s+NODE`, text)
+		})
+		s.Run("returns the pods of every namespace", func() {
+			s.Regexp(`ns-1\s+v1\s+Pod\s+web-1\s+1/1\s+Running`, text)
+			s.Regexp(`ns-2\s+v1\s+Pod\s+db-0\s+0/1\s+Pending`, text)
+		})
+	})
+	s.Run("pods_on_node(node=node-1, fieldSelector=status.phase=Running) combines the field selectors", func() {
+		toolResult, err := s.CallTool("pods_on_node", map[string]interface{}{"node": "node-1", "fieldSelector": "status.phase=Running"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "web-1")
+		s.NotContains(text, "db-0")
+	})
+}
+
+func (s *PodsOnNodeSuite) TestPodsOnNodeDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("pods_on_node", map[string]interface{}{"node": "node-1"})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+}
+
+func TestPodsOnNode(t *testing.T) {
+	suite.Run(t, new(PodsOnNodeSuite))
+}
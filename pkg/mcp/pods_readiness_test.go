@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PodsReadinessSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsReadinessSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/a-ready-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-ready-pod","namespace":"default","uid":"ready-uid"},` +
+				`"spec":{"nodeName":"node-1","containers":[{"name":"app","image":"nginx"}]},` +
+				`"status":{"phase":"Running","conditions":[{"type":"PodScheduled","status":"True"},{"type":"Initialized","status":"True"},` +
+				`{"type":"ContainersReady","status":"True"},{"type":"Ready","status":"True"}],` +
+				`"containerStatuses":[{"name":"app","ready":true,"started":true,"restartCount":0,"state":{"running":{}}}]}}`))
+		case "/api/v1/namespaces/default/pods/an-unready-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"an-unready-pod","namespace":"default","uid":"unready-uid"},` +
+				`"spec":{"nodeName":"node-1","containers":[{"name":"app","image":"nginx",` +
+				`"readinessProbe":{"httpGet":{"path":"/ready","port":8080,"scheme":"HTTP"},"periodSeconds":5,"failureThreshold":3}}]},` +
+				`"status":{"phase":"Running","conditions":[{"type":"PodScheduled","status":"True"},{"type":"Initialized","status":"True"},` +
+				`{"type":"ContainersReady","status":"False","reason":"ContainersNotReady","message":"containers with unready status: [app]"},` +
+				`{"type":"Ready","status":"False","reason":"ContainersNotReady","message":"containers with unready status: [app]"}],` +
+				`"containerStatuses":[{"name":"app","ready":false,"started":true,"restartCount":0,"state":{"running":{}}}]}}`))
+		case "/api/v1/namespaces/default/events":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[` +
+				`{"metadata":{"name":"old","namespace":"default"},"reason":"Unhealthy","message":"Readiness probe failed: connection refused",` +
+				`"involvedObject":{"kind":"Pod","name":"an-unready-pod","uid":"unready-uid","fieldPath":"spec.containers{app}"},"lastTimestamp":"2026-01-01T00:00:00Z"},` +
+				`{"metadata":{"name":"new","namespace":"default"},"reason":"Unhealthy","message":"Readiness probe failed: HTTP probe failed with statuscode: 503",` +
+				`"involvedObject":{"kind":"Pod","name":"an-unready-pod","uid":"unready-uid","fieldPath":"spec.containers{app}"},"lastTimestamp":"2026-01-01T00:05:00Z"},` +
+				`{"metadata":{"name":"previous-pod","namespace":"default"},"reason":"Unhealthy","message":"Readiness probe failed: from a previous Pod",` +
+				`"involvedObject":{"kind":"Pod","name":"an-unready-pod","uid":"previous-uid","fieldPath":"spec.containers{app}"},"lastTimestamp":"2026-01-01T00:10:00Z"}` +
+				`]}`))
+		}
+	}))
+}
+
+func (s *PodsReadinessSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsReadinessSuite) TestPodsReadinessExplain() {
+	s.InitMcpClient()
+	s.Run("pods_readiness_explain with missing name returns error", func() {
+		toolResult, _ := s.CallTool("pods_readiness_explain", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to explain pod readiness: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_readiness_explain(name=a-ready-pod)", func() {
+		toolResult, err := s.CallTool("pods_readiness_explain", map[string]interface{}{"namespace": "default", "name": "a-ready-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("reports the Pod is Ready", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Pod a-ready-pod in namespace default is Ready\n")
+		})
+	})
+	s.Run("pods_readiness_explain(name=an-unready-pod)", func() {
+		toolResult, err := s.CallTool("pods_readiness_explain", map[string]interface{}{"namespace": "default", "name": "an-unready-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("explains the failing readiness probe with the latest failure of the Pod", func() {
+			s.Contains(text, "# Pod an-unready-pod in namespace default is not Ready:\n"+
+				"- failing readiness probe on container app (HTTP GET http://:8080/ready, period 5s, failure threshold 3), "+
+				"last failure: Readiness probe failed: HTTP probe failed with statuscode: 503\n")
+		})
+		s.Run("returns the conditions and containers", func() {
+			s.Regexp(`- message: 'containers with unready status: \[app\]'\s+reason: ContainersNotReady\s+status: "False"\s+type: Ready`, text)
+			s.Regexp(`- lastProbeFailure: 'Readiness probe failed: HTTP probe failed with statuscode: 503'\s+name: app`, text)
+		})
+	})
+	s.Run("pods_readiness_explain(name=not-found) returns error", func() {
+		toolResult, _ := s.CallTool("pods_readiness_explain", map[string]interface{}{"namespace": "default", "name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to explain pod not-found readiness in namespace default")
+	})
+}
+
+func TestPodsReadiness(t *testing.T) {
+	suite.Run(t, new(PodsReadinessSuite))
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type PodsSchedulingSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsSchedulingSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/a-pending-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pending-pod","namespace":"default"},` +
+				`"spec":{"nodeSelector":{"disktype":"ssd"},"containers":[{"name":"app","image":"nginx","resources":{"requests":{"cpu":"2","memory":"1Gi"}}}]},` +
+				`"status":{"phase":"Pending","conditions":[{"type":"PodScheduled","status":"False","reason":"Unschedulable",` +
+				`"message":"0/3 nodes are available: 1 Insufficient cpu, 1 node(s) didn't match Pod's node affinity/selector, 1 node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }."}]}}`))
+		case "/api/v1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[` +
+				`{"metadata":{"name":"control-plane","labels":{"disktype":"ssd"}},"spec":{"taints":[{"key":"node-role.kubernetes.io/control-plane","effect":"NoSchedule"}]},` +
+				`"status":{"allocatable":{"cpu":"4","memory":"8Gi","pods":"110"}}},` +
+				`{"metadata":{"name":"worker-hdd","labels":{"disktype":"hdd"}},"status":{"allocatable":{"cpu":"8","memory":"16Gi","pods":"110"}}},` +
+				`{"metadata":{"name":"worker-ssd","labels":{"disktype":"ssd"}},"status":{"allocatable":{"cpu":"4","memory":"16Gi","pods":"110"}}}` +
+				`]}`))
+		case "/api/v1/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"busy","namespace":"other"},"spec":{"nodeName":"worker-ssd","containers":[{"name":"app","resources":{"requests":{"cpu":"3"}}}]},"status":{"phase":"Running"}}` +
+				`]}`))
+		}
+	}))
+}
+
+func (s *PodsSchedulingSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsSchedulingSuite) TestPodsSchedulingInfo() {
+	s.InitMcpClient()
+	s.Run("pods_scheduling_info with missing name returns error", func() {
+		toolResult, _ := s.CallTool("pods_scheduling_info", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get pod scheduling info: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("pods_scheduling_info(name=a-pending-pod)", func() {
+		toolResult, err := s.CallTool("pods_scheduling_info", map[string]interface{}{"namespace": "default", "name": "a-pending-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the pod requirements", func() {
+			s.Contains(text, "# The following scheduling information (YAML format) was collected for Pod a-pending-pod in namespace default")
+			s.Contains(text, "requests:\n  cpu: \"2\"\n  memory: 1Gi")
+			s.Contains(text, "nodeSelector:\n  disktype: ssd")
+			s.Contains(text, "schedulerMessage: 'Unschedulable: 0/3 nodes are available")
+		})
+		s.Run("returns the summary", func() {
+			s.Contains(text, "summary: '0/3 nodes can accommodate the Pod: 1 Insufficient cpu, 1 node(s) didn''t\n  match Pod''s node selector, 1 node(s) had untolerated taint")
+		})
+		s.Run("returns the reasons for each node", func() {
+			s.Contains(text, "- untolerated taint node-role.kubernetes.io/control-plane:NoSchedule")
+			s.Contains(text, "- node labels don't match the Pod nodeSelector")
+			s.Contains(text, "- 'insufficient cpu: requests 2, available 1 (allocatable 4)'")
+		})
+	})
+	s.Run("pods_scheduling_info with not found pod returns error", func() {
+		toolResult, _ := s.CallTool("pods_scheduling_info", map[string]interface{}{"namespace": "default", "name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get pod not-found scheduling info in namespace default")
+	})
+}
+
+func (s *PodsSchedulingSuite) TestPodsSchedulingInfoDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Node" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("pods_scheduling_info", map[string]interface{}{"namespace": "default", "name": "a-pending-pod"})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to list nodes")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Node")
+}
+
+func TestPodsScheduling(t *testing.T) {
+	suite.Run(t, new(PodsSchedulingSuite))
+}
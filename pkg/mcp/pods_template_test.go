@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podTemplateDriftPod returns a Pod owned by the provided ReplicaSet with the ServiceAccount token volume injected by the API server
+func podTemplateDriftPod(name, replicaSet, image string) string {
+	return `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"` + name + `","namespace":"default","labels":{"app":"web","pod-template-hash":"hash"},` +
+		`"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"` + replicaSet + `","uid":"uid-` + replicaSet + `","controller":true}]},` +
+		`"spec":{"serviceAccountName":"default","containers":[{"name":"web","image":"` + image + `","ports":[{"containerPort":80,"protocol":"TCP"}],` +
+		`"volumeMounts":[{"name":"kube-api-access-abcde","mountPath":"/var/run/secrets/kubernetes.io/serviceaccount","readOnly":true}]}],` +
+		`"volumes":[{"name":"kube-api-access-abcde","projected":{"sources":[{"serviceAccountToken":{"path":"token"}}]}}]}}`
+}
+
+// podTemplateDriftReplicaSet returns a ReplicaSet owned by the web Deployment
+func podTemplateDriftReplicaSet(name, image string) string {
+	return `{"apiVersion":"apps/v1","kind":"ReplicaSet","metadata":{"name":"` + name + `","namespace":"default","uid":"uid-` + name + `",` +
+		`"ownerReferences":[{"apiVersion":"apps/v1","kind":"Deployment","name":"web","uid":"uid-web","controller":true}]},` +
+		`"spec":{"template":{"metadata":{"labels":{"app":"web","pod-template-hash":"hash"}},"spec":{"containers":[{"name":"web","image":"` + image + `","ports":[{"containerPort":80,"protocol":"TCP"}]}]}}}}`
+}
+
+type PodsTemplateDriftSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodsTemplateDriftSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[1].APIResources = append(discoveryHandler.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/current-pod":
+			_, _ = w.Write([]byte(podTemplateDriftPod("current-pod", "web-new", "nginx:1.26")))
+		case "/api/v1/namespaces/default/pods/stale-pod":
+			_, _ = w.Write([]byte(podTemplateDriftPod("stale-pod", "web-old", "nginx:1.25")))
+		case "/api/v1/namespaces/default/pods/orphan-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"orphan-pod","namespace":"default"},"spec":{"containers":[{"name":"web","image":"nginx"}]}}`))
+		case "/apis/apps/v1/namespaces/default/replicasets/web-new":
+			_, _ = w.Write([]byte(podTemplateDriftReplicaSet("web-new", "nginx:1.26")))
+		case "/apis/apps/v1/namespaces/default/replicasets/web-old":
+			_, _ = w.Write([]byte(podTemplateDriftReplicaSet("web-old", "nginx:1.25")))
+		case "/apis/apps/v1/namespaces/default/deployments/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default","uid":"uid-web"},` +
+				`"spec":{"template":{"metadata":{"labels":{"app":"web"}},"spec":{"containers":[{"name":"web","image":"nginx:1.26","ports":[{"containerPort":80,"protocol":"TCP"}]}]}}}}`))
+		}
+	}))
+}
+
+func (s *PodsTemplateDriftSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodsTemplateDriftSuite) TestPodsTemplateDrift() {
+	s.InitMcpClient()
+	s.Run("pods_template_drift(name=current-pod) with up-to-date Pod", func() {
+		toolResult, err := s.CallTool("pods_template_drift", map[string]interface{}{"name": "current-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the Pod matches the Deployment template", func() {
+			s.Contains(text, "# Pod current-pod in namespace default matches the current Pod template of Deployment/web")
+			s.Contains(text, "upToDate: true")
+		})
+		s.Run("resolves the controller chain", func() {
+			s.Contains(text, "controllers:\n- ReplicaSet/web-new\n- Deployment/web\n")
+			s.Contains(text, "template: Deployment/web")
+		})
+		s.Run("ignores the ServiceAccount token volume", func() {
+			s.NotContains(text, "kube-api-access")
+		})
+	})
+	s.Run("pods_template_drift(name=stale-pod) with Pod left behind by a rollout", func() {
+		toolResult, err := s.CallTool("pods_template_drift", map[string]interface{}{"name": "stale-pod"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the Pod doesn't match the Deployment template", func() {
+			s.Contains(text, "# Pod stale-pod in namespace default doesn't match the current Pod template of Deployment/web")
+			s.Contains(text, "upToDate: false")
+			s.Contains(text, "container web image differs (template nginx:1.26, Pod nginx:1.25)")
+		})
+		s.Run("returns a diff between the Pod and the template", func() {
+			s.Contains(text, "--- live/Pod/stale-pod\n+++ desired/Deployment/web\n")
+			s.Contains(text, "-- image: nginx:1.25\n+- image: nginx:1.26\n")
+		})
+	})
+	s.Run("pods_template_drift(name=orphan-pod) without controller returns error", func() {
+		toolResult, _ := s.CallTool("pods_template_drift", map[string]interface{}{"name": "orphan-pod"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "pod orphan-pod is not managed by a controller")
+	})
+	s.Run("pods_template_drift(missing name) returns error", func() {
+		toolResult, _ := s.CallTool("pods_template_drift", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check pod template drift: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *PodsTemplateDriftSuite) TestPodsTemplateDriftDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apps", version = "v1", kind = "Deployment" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("pods_template_drift (denied)", func() {
+		toolResult, err := s.CallTool("pods_template_drift", map[string]interface{}{"name": "current-pod"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=Deployment")
+		})
+	})
+}
+
+func TestPodsTemplateDrift(t *testing.T) {
+	suite.Run(t, new(PodsTemplateDriftSuite))
+}
@@ -189,6 +189,82 @@ func (s *PodsTopSuite) TestPodsTopMetricsAvailable() {
 	})
 }
 
+func (s *PodsTopSuite) TestPodsTopSortAndLimit() {
+	s.discoveryHandler.AddAPIResourceList(metav1.APIResourceList{
+		GroupVersion: "metrics.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "PodMetrics", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/apis/metrics.k8s.io/v1beta1/pods" {
+			_, _ = w.Write([]byte(`{"kind":"PodMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+				`{"metadata":{"name":"pod-small","namespace":"default"},"containers":[{"name":"container-1","usage":{"cpu":"900m","memory":"100Mi"}}]},` +
+				`{"metadata":{"name":"pod-large","namespace":"ns-1"},"containers":[{"name":"container-1","usage":{"cpu":"100m","memory":"300Mi"}},{"name":"container-2","usage":{"cpu":"100m","memory":"500Mi"}}]},` +
+				`{"metadata":{"name":"pod-medium","namespace":"ns-2"},"containers":[{"name":"container-1","usage":{"cpu":"500m","memory":"400Mi"}}]}` +
+				`]}`))
+		}
+	}))
+	s.InitMcpClient()
+	s.Run("pods_top(sortBy=memory)", func() {
+		toolResult, err := s.CallTool("pods_top", map[string]interface{}{
+			"sortBy": "memory",
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Regexp(`(?s)pod-large.*pod-medium.*pod-small`, toolResult.Content[0].(*mcp.TextContent).Text,
+			"expected pods sorted by the memory of all their containers in descending order")
+	})
+	s.Run("pods_top(sortBy=cpu, descending=false, limit=1)", func() {
+		toolResult, err := s.CallTool("pods_top", map[string]interface{}{
+			"sortBy":     "cpu",
+			"descending": false,
+			"limit":      1,
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(content, "pod-large", "expected the pod with the lowest cpu of all its containers")
+		s.NotContains(content, "pod-medium")
+		s.NotContains(content, "pod-small")
+	})
+	s.Run("pods_top(sortBy=cpu, limit=2)", func() {
+		toolResult, err := s.CallTool("pods_top", map[string]interface{}{
+			"sortBy": "cpu",
+			"limit":  2,
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the pods with the highest consumption", func() {
+			s.Regexp(`(?s)pod-small.*pod-medium`, content)
+			s.NotContains(content, "pod-large")
+		})
+		s.Run("returns the number of pods not shown", func() {
+			s.Contains(content, "# 1 more pods not shown (limit reached)")
+		})
+	})
+	s.Run("pods_top(limit) without sortBy keeps the Metrics API order", func() {
+		toolResult, err := s.CallTool("pods_top", map[string]interface{}{
+			"limit": 1,
+		})
+		s.Require().NoErrorf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		content := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(content, "pod-small")
+		s.NotContains(content, "pod-medium")
+		s.Contains(content, "# 2 more pods not shown (limit reached)")
+	})
+	s.Run("pods_top(limit) with negative value returns error", func() {
+		toolResult, _ := s.CallTool("pods_top", map[string]interface{}{
+			"limit": -1,
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get pods top: invalid limit -1")
+	})
+}
+
 func (s *PodsTopSuite) TestPodsTopDenied() {
 	s.Require().NoError(toml.Unmarshal([]byte(`
 		denied_resources = [ { group = "metrics.k8s.io", version = "v1beta1" } ]
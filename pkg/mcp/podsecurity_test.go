@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PodSecuritySuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *PodSecuritySuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns-1","labels":{` +
+				`"pod-security.kubernetes.io/enforce":"baseline","pod-security.kubernetes.io/enforce-version":"v1.30",` +
+				`"pod-security.kubernetes.io/warn":"restricted"}}}`))
+		case "/api/v1/namespaces/ns-1/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"agent","namespace":"ns-1","ownerReferences":[{"apiVersion":"apps/v1","kind":"DaemonSet","name":"agent","uid":"ds-1","controller":true}]},` +
+				`"spec":{"hostNetwork":true,"containers":[{"name":"agent","image":"agent","securityContext":{"privileged":true}}]}},` +
+				`{"metadata":{"name":"web","namespace":"ns-1"},"spec":{"containers":[{"name":"web","image":"web"}]}}` +
+				`]}`))
+		case "/api/v1/namespaces/unlabeled":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"unlabeled"}}`))
+		case "/api/v1/namespaces/unlabeled/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		}
+	}))
+}
+
+func (s *PodSecuritySuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *PodSecuritySuite) TestPodSecurityCheck() {
+	s.InitMcpClient()
+	s.Run("podsecurity_check(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("podsecurity_check", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the namespace levels", func() {
+			s.Regexp(`# Pod Security admission levels of namespace ns-1 \(YAML format\):\s+enforce:\s+Level: baseline\s+Version: v1.30\s+`+
+				`warn:\s+Level: restricted\s+Version: latest`, text)
+		})
+		s.Run("evaluates the pods against the enforced level", func() {
+			s.Contains(text, "# 1 of 2 Pods violate the baseline level (YAML format):\n")
+			s.Regexp(`- Pod: agent\s+Violations:\s+- host namespaces \(hostNetwork=true\)\s+`+
+				`- privileged \(container "agent" must not set securityContext.privileged=true\)\s+Workload: DaemonSet/agent`, text)
+			s.NotContains(text, "Pod: web")
+		})
+	})
+	s.Run("podsecurity_check(namespace=ns-1, level=restricted)", func() {
+		toolResult, err := s.CallTool("podsecurity_check", map[string]interface{}{"namespace": "ns-1", "level": "restricted"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# 2 of 2 Pods violate the restricted level (YAML format):\n")
+		s.Regexp(`- Pod: web\s+Violations:\s+- allowPrivilegeEscalation != false`, text)
+	})
+	s.Run("podsecurity_check(namespace=unlabeled)", func() {
+		toolResult, err := s.CallTool("podsecurity_check", map[string]interface{}{"namespace": "unlabeled"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Equal("# No Pod Security admission levels are configured in namespace unlabeled\n# All 0 Pods comply with the privileged level\n",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("podsecurity_check(level=invalid)", func() {
+		toolResult, err := s.CallTool("podsecurity_check", map[string]interface{}{"namespace": "ns-1", "level": "invalid"})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to check pod security: invalid level invalid (expected privileged, baseline, restricted)", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *PodSecuritySuite) TestPodSecurityCheckDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("podsecurity_check (denied)", func() {
+		toolResult, err := s.CallTool("podsecurity_check", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+		})
+	})
+}
+
+func TestPodSecurity(t *testing.T) {
+	suite.Run(t, new(PodSecuritySuite))
+}
@@ -0,0 +1,34 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/klogutil"
+)
+
+// sessionProgressReporter sends progress notifications for the tool call identified by the progress token
+type sessionProgressReporter struct {
+	session       *mcp.ServerSession
+	progressToken any
+}
+
+var _ api.ProgressReporter = &sessionProgressReporter{}
+
+func (s *sessionProgressReporter) ReportProgress(ctx context.Context, progress, total float64, message string) {
+	// The client didn't request progress notifications for this call
+	if s.session == nil || s.progressToken == nil {
+		return
+	}
+	if err := s.session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: s.progressToken,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	}); err != nil {
+		klogutil.LogInfo(klog.FromContext(ctx).V(5), "Failed to send progress notification", klogutil.Err(err))
+	}
+}
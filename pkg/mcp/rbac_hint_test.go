@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type RBACHintSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *RBACHintSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v1/namespaces/team-a/pods/web" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"pods \"web\" is forbidden: User \"alice\" cannot get resource \"pods\" in API group \"\" in the namespace \"team-a\""}`))
+		}
+	}))
+}
+
+func (s *RBACHintSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *RBACHintSuite) TestForbiddenToolCall() {
+	s.InitMcpClient()
+	s.Run("pods_get(name=web, namespace=team-a) forbidden", func() {
+		toolResult, err := s.CallTool("pods_get", map[string]interface{}{"name": "web", "namespace": "team-a"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("describes the Forbidden error", func() {
+			s.Contains(text, `pods "web" is forbidden: User "alice" cannot get resource "pods" in API group "" in the namespace "team-a"`)
+		})
+		s.Run("includes the Role and RoleBinding granting the missing permission", func() {
+			s.Contains(text, "The following RBAC resources grant the missing permission:\n")
+			s.Contains(text, "kind: Role\nmetadata:\n  name: mcp-get-pods\n  namespace: team-a\n")
+			s.Contains(text, "kind: RoleBinding\nmetadata:\n  name: mcp-get-pods\n  namespace: team-a\n")
+			s.Contains(text, "subjects:\n- apiGroup: rbac.authorization.k8s.io\n  kind: User\n  name: alice\n")
+		})
+	})
+}
+
+func TestRBACHint(t *testing.T) {
+	suite.Run(t, new(RBACHintSuite))
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"flag"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type RequestIDSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	klogState  klog.State
+	logBuffer  test.SyncBuffer
+	mu         sync.Mutex
+	auditIDs   []string
+}
+
+func (s *RequestIDSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.klogState = klog.CaptureState()
+	s.auditIDs = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			s.mu.Lock()
+			s.auditIDs = append(s.auditIDs, req.Header.Get("Audit-ID"))
+			s.mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+		case "/api/v1/namespaces/forbidden/pods":
+			s.mu.Lock()
+			s.auditIDs = append(s.auditIDs, req.Header.Get("Audit-ID"))
+			s.mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,"message":"pods is forbidden"}`))
+		}
+	}))
+}
+
+func (s *RequestIDSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	s.klogState.Restore()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *RequestIDSuite) kubeAuditIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.auditIDs...)
+}
+
+func (s *RequestIDSuite) TestDoesntPropagateClientRequestIDToKubeAPI() {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	_ = flags.Set("v", strconv.Itoa(2))
+	klog.SetLogger(textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(2), textlogger.Output(&s.logBuffer))))
+	s.InitMcpClient(test.WithHTTPHeaders(map[string]string{"X-Request-Id": "req-1234"}))
+	toolResult, err := s.CallTool("pods_list_in_namespace", map[string]any{"namespace": "forbidden"})
+	s.Require().NoError(err)
+	s.Require().True(toolResult.IsError)
+	auditIDs := s.kubeAuditIDs()
+	s.Require().Len(auditIDs, 1)
+	s.Run("Audit-ID is generated by the server", func() {
+		s.NotEmpty(auditIDs[0])
+		s.NotEqual("req-1234", auditIDs[0], "Audit-ID should never be the request ID provided by the client")
+		s.Equal(auditIDs[0], toolResult.Meta[RequestIDMetaKey])
+	})
+	s.Run("client request ID is logged next to the server request ID", func() {
+		s.Contains(s.logBuffer.String(), `"Tool call failed" mcp.request.id="`+auditIDs[0]+`" http.request.id="req-1234"`)
+	})
+}
+
+func (s *RequestIDSuite) TestGeneratesRequestIDPerToolCall() {
+	s.InitMcpClient()
+	_, err := s.CallTool("pods_list_in_namespace", map[string]any{"namespace": "default"})
+	s.Require().NoError(err)
+	_, err = s.CallTool("pods_list_in_namespace", map[string]any{"namespace": "default"})
+	s.Require().NoError(err)
+	auditIDs := s.kubeAuditIDs()
+	s.Require().Len(auditIDs, 2)
+	s.NotEmpty(auditIDs[0])
+	s.NotEmpty(auditIDs[1])
+	s.NotEqual(auditIDs[0], auditIDs[1], "each tool call should get its own request ID")
+}
+
+func (s *RequestIDSuite) TestEchoesRequestIDInErrorResults() {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	_ = flags.Set("v", strconv.Itoa(2))
+	klog.SetLogger(textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(2), textlogger.Output(&s.logBuffer))))
+	s.InitMcpClient()
+
+	s.Run("successful tool call results have no request ID", func() {
+		toolResult, err := s.CallTool("pods_list_in_namespace", map[string]any{"namespace": "default"})
+		s.Require().NoError(err)
+		s.False(toolResult.IsError)
+		s.NotContains(toolResult.Meta, RequestIDMetaKey)
+	})
+	s.Run("failed tool call results echo the request ID", func() {
+		toolResult, err := s.CallTool("pods_list_in_namespace", map[string]any{"namespace": "forbidden"})
+		s.Require().NoError(err)
+		s.Require().True(toolResult.IsError)
+		requestID, ok := toolResult.Meta[RequestIDMetaKey].(string)
+		s.Require().True(ok, "expected %s in the result _meta, got %v", RequestIDMetaKey, toolResult.Meta)
+		auditIDs := s.kubeAuditIDs()
+		s.Equal(auditIDs[len(auditIDs)-1], requestID, "the request ID should match the Audit-ID sent to the Kube API")
+		s.Run("and log the failure with the request ID", func() {
+			s.Contains(s.logBuffer.String(), `"Tool call failed" mcp.request.id="`+requestID+`"`)
+			s.Contains(s.logBuffer.String(), `gen_ai.tool.name="pods_list_in_namespace"`)
+		})
+	})
+	s.Run("invalid arguments errors echo the request ID", func() {
+		toolResult, err := s.CallTool("pods_list_in_namespace", map[string]any{})
+		s.Require().NoError(err)
+		s.Require().True(toolResult.IsError)
+		s.IsType("", toolResult.Meta[RequestIDMetaKey])
+	})
+}
+
+func TestRequestID(t *testing.T) {
+	suite.Run(t, new(RequestIDSuite))
+}
@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceTemplatesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourceTemplatesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1/pods/a-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pod","namespace":"ns-1","managedFields":[{"manager":"kubectl"}]},` +
+				`"spec":{"containers":[{"name":"app","image":"nginx"}]}}`))
+		case "/api/v1/nodes/node-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-1"}}`))
+		case "/api/v1/namespaces/ns-1/pods":
+			if req.URL.Query().Get("watch") != "true" {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"1"},"items":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"type":"MODIFIED","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pod","namespace":"ns-1","resourceVersion":"2"}}}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+		case "/api/v1/namespaces/ns-1/pods/missing-pod":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"pods \"missing-pod\" not found"}`))
+		}
+	}))
+}
+
+func (s *ResourceTemplatesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourceTemplatesSuite) TestResourceTemplates() {
+	s.InitMcpClient()
+	s.Run("resources/templates/list returns the cluster object templates", func() {
+		result, err := s.ListResourceTemplates()
+		s.Require().NoError(err)
+		uriTemplates := make([]string, 0, len(result.ResourceTemplates))
+		for _, template := range result.ResourceTemplates {
+			uriTemplates = append(uriTemplates, template.URITemplate)
+		}
+		s.Subset(uriTemplates, []string{
+			"k8s://namespaces/{namespace}/pods/{name}",
+			"k8s://namespaces/{namespace}/deployments/{name}",
+			"k8s://namespaces/{name}",
+			"k8s://nodes/{name}",
+		})
+	})
+	s.Run("resources/read(k8s://namespaces/ns-1/pods/a-pod)", func() {
+		result, err := s.ReadResource("k8s://namespaces/ns-1/pods/a-pod")
+		s.Run("no error", func() {
+			s.Require().NoError(err)
+			s.Require().Len(result.Contents, 1)
+		})
+		s.Run("returns the Pod in YAML format", func() {
+			s.Equal("k8s://namespaces/ns-1/pods/a-pod", result.Contents[0].URI)
+			s.Equal("application/yaml", result.Contents[0].MIMEType)
+			s.Contains(result.Contents[0].Text, "kind: Pod")
+			s.Contains(result.Contents[0].Text, "name: a-pod")
+			s.Contains(result.Contents[0].Text, "namespace: ns-1")
+		})
+		s.Run("omits managedFields", func() {
+			s.NotContains(result.Contents[0].Text, "managedFields")
+		})
+	})
+	s.Run("resources/read(k8s://nodes/node-1) returns cluster-scoped resource", func() {
+		result, err := s.ReadResource("k8s://nodes/node-1")
+		s.Require().NoError(err)
+		s.Require().Len(result.Contents, 1)
+		s.Contains(result.Contents[0].Text, "kind: Node")
+		s.Contains(result.Contents[0].Text, "name: node-1")
+	})
+	s.Run("resources/read(k8s://namespaces/ns-1/pods/missing-pod) returns error", func() {
+		_, err := s.ReadResource("k8s://namespaces/ns-1/pods/missing-pod")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "failed to read resource k8s://namespaces/ns-1/pods/missing-pod")
+	})
+}
+
+func (s *ResourceTemplatesSuite) TestResourceTemplatesSubscriptions() {
+	s.InitMcpClient()
+	s.Run("initialize advertises resource subscriptions", func() {
+		s.True(s.InitializeResult.Capabilities.Resources.Subscribe)
+	})
+	s.Run("resources/subscribe(k8s://namespaces/ns-1/pods/a-pod)", func() {
+		capture := s.StartCapturingNotifications()
+		err := s.SubscribeResource("k8s://namespaces/ns-1/pods/a-pod")
+		s.Run("no error", func() {
+			s.Require().NoError(err)
+		})
+		s.Run("notifies the resource updates", func() {
+			notification := capture.RequireNotification(s.T(), 5*time.Second, "notifications/resources/updated")
+			params, ok := notification.Params.(*mcp.ResourceUpdatedNotificationParams)
+			s.Require().True(ok, "invalid notification params %T", notification.Params)
+			s.Equal("k8s://namespaces/ns-1/pods/a-pod", params.URI)
+		})
+	})
+	s.Run("resources/unsubscribe(k8s://namespaces/ns-1/pods/a-pod) stops the watch", func() {
+		s.Require().NoError(s.UnsubscribeResource("k8s://namespaces/ns-1/pods/a-pod"))
+		s.False(s.mcpServer.subscriptions.watched("k8s://namespaces/ns-1/pods/a-pod"))
+	})
+	s.Run("resources/subscribe(k8s://namespaces/ns-1/pods/missing-pod) returns error", func() {
+		err := s.SubscribeResource("k8s://namespaces/ns-1/pods/missing-pod")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "failed to read resource k8s://namespaces/ns-1/pods/missing-pod")
+		s.False(s.mcpServer.subscriptions.watched("k8s://namespaces/ns-1/pods/missing-pod"))
+	})
+	s.Run("resources/subscribe(k8s://namespaces/ns-1/secrets/a-secret) without template returns error", func() {
+		err := s.SubscribeResource("k8s://namespaces/ns-1/secrets/a-secret")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "resource k8s://namespaces/ns-1/secrets/a-secret doesn't support subscriptions")
+	})
+}
+
+func (s *ResourceTemplatesSuite) TestResourceTemplatesSubscriptionsMaxConcurrentWatches() {
+	s.Cfg.MaxConcurrentWatches = 1
+	s.InitMcpClient()
+	s.Require().NoError(s.SubscribeResource("k8s://namespaces/ns-1/pods/a-pod"))
+	s.Run("resources/subscribe to the same URI shares the running watch", func() {
+		s.Require().NoError(s.SubscribeResource("k8s://namespaces/ns-1/pods/a-pod"))
+	})
+	s.Run("resources/subscribe above max_concurrent_watches returns error", func() {
+		err := s.SubscribeResource("k8s://nodes/node-1")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "too many concurrent watches: all the 1 watches allowed by the server are in use")
+		s.False(s.mcpServer.subscriptions.watched("k8s://nodes/node-1"))
+	})
+	s.Run("resources/unsubscribe releases the watch", func() {
+		s.Require().NoError(s.UnsubscribeResource("k8s://namespaces/ns-1/pods/a-pod"))
+		s.Eventually(func() bool {
+			return s.SubscribeResource("k8s://nodes/node-1") == nil
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func (s *ResourceTemplatesSuite) TestResourceTemplatesSubscriptionsStateless() {
+	s.Cfg.Stateless = true
+	s.InitMcpClient()
+	s.Run("initialize doesn't advertise resource subscriptions", func() {
+		s.False(s.InitializeResult.Capabilities.Resources.Subscribe)
+	})
+}
+
+func (s *ResourceTemplatesSuite) TestResourceTemplatesDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("resources/read(k8s://namespaces/ns-1/pods/a-pod) (denied)", func() {
+		_, err := s.ReadResource("k8s://namespaces/ns-1/pods/a-pod")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "resource not allowed: /v1, Kind=Pod")
+	})
+	s.Run("resources/subscribe(k8s://namespaces/ns-1/pods/a-pod) (denied)", func() {
+		err := s.SubscribeResource("k8s://namespaces/ns-1/pods/a-pod")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "resource not allowed: /v1, Kind=Pod")
+	})
+}
+
+func TestResourceTemplates(t *testing.T) {
+	suite.Run(t, new(ResourceTemplatesSuite))
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+type ResourceQuotasSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	requests   []string
+}
+
+func (s *ResourceQuotasSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.requests = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "resourcequotas", Kind: "ResourceQuota", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.URL.Path == "/api/v1/namespaces/ns-1/resourcequotas/compute" && req.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ResourceQuota","metadata":{"name":"compute","namespace":"ns-1"},` +
+				`"spec":{"hard":{"pods":"10"}},"status":{"hard":{"pods":"10"},"used":{"pods":"3"}}}`))
+		case req.URL.Path == "/api/v1/namespaces/ns-1/resourcequotas/compute" && req.Method == http.MethodPatch:
+			body, _ := io.ReadAll(req.Body)
+			s.record(req.Method + " " + string(body))
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ResourceQuota","metadata":{"name":"compute","namespace":"ns-1"},` +
+				`"spec":{"hard":{"pods":"20","requests.cpu":"4"}},"status":{"hard":{"pods":"10"},"used":{"pods":"3"}}}`))
+		case req.URL.Path == "/api/v1/namespaces/ns-1/resourcequotas/new-quota" && req.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+		case req.URL.Path == "/api/v1/namespaces/ns-1/resourcequotas" && req.Method == http.MethodPost:
+			body, _ := io.ReadAll(req.Body)
+			obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+			s.Require().NoError(err, "failed to decode resourcequota")
+			created, _ := json.Marshal(obj.(*v1.ResourceQuota))
+			s.record(req.Method + " " + string(created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(created)
+		}
+	}))
+}
+
+func (s *ResourceQuotasSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourceQuotasSuite) record(request string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *ResourceQuotasSuite) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *ResourceQuotasSuite) TestResourceQuotaSet() {
+	s.InitMcpClient()
+	s.Run("resourcequota_set with existing quota", func() {
+		toolResult, err := s.CallTool("resourcequota_set", map[string]interface{}{
+			"namespace": "ns-1",
+			"name":      "compute",
+			"hard":      map[string]interface{}{"pods": 20, "requests.cpu": "4"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("patches the provided hard limits", func() {
+			s.Equal([]string{`PATCH {"spec":{"hard":{"pods":"20","requests.cpu":"4"}}}`}, s.recorded())
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the hard limits and current usage", func() {
+			s.Contains(text, "# The ResourceQuota ns-1/compute has been updated successfully")
+			s.Regexp(`- Hard: "20"\s+Resource: pods\s+Used: "3"`, text)
+			s.Regexp(`- Hard: "4"\s+Resource: requests.cpu\s+Used: <pending>`, text)
+		})
+	})
+	s.Run("resourcequota_set with new quota creates it", func() {
+		s.requests = nil
+		toolResult, err := s.CallTool("resourcequota_set", map[string]interface{}{
+			"namespace": "ns-1",
+			"name":      "new-quota",
+			"hard":      map[string]interface{}{"limits.memory": "8Gi"},
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Require().Len(s.recorded(), 1)
+		s.Contains(s.recorded()[0], `"hard":{"limits.memory":"8Gi"}`)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# The ResourceQuota ns-1/new-quota has been created successfully")
+	})
+}
+
+func (s *ResourceQuotasSuite) TestResourceQuotaSetValidation() {
+	s.InitMcpClient()
+	s.Run("resourcequota_set with invalid resource names and quantities", func() {
+		toolResult, err := s.CallTool("resourcequota_set", map[string]interface{}{
+			"namespace": "ns-1",
+			"name":      "compute",
+			"hard":      map[string]interface{}{"cpus": "4", "pods": "many"},
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "invalid hard limits: cpus (not a resource tracked by ResourceQuotas")
+		s.Contains(text, "pods=many (invalid quantity")
+		s.Empty(s.recorded())
+	})
+	s.Run("resourcequota_set without hard limits", func() {
+		toolResult, _ := s.CallTool("resourcequota_set", map[string]interface{}{"namespace": "ns-1", "name": "compute"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "hard parameter required")
+	})
+}
+
+func (s *ResourceQuotasSuite) TestResourceQuotaSetDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ResourceQuota" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("resourcequota_set (denied)", func() {
+		toolResult, err := s.CallTool("resourcequota_set", map[string]interface{}{
+			"namespace": "ns-1",
+			"name":      "compute",
+			"hard":      map[string]interface{}{"pods": "20"},
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=ResourceQuota")
+		})
+		s.Run("doesn't change the quota", func() {
+			s.Empty(s.recorded())
+		})
+	})
+}
+
+func TestResourceQuotas(t *testing.T) {
+	suite.Run(t, new(ResourceQuotasSuite))
+}
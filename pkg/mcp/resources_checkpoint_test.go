@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ResourcesCheckpointSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	configMaps []string
+	secrets    []string
+}
+
+func (s *ResourcesCheckpointSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.setState(
+		[]string{
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"unchanged","namespace":"default","resourceVersion":"1"},"data":{"key":"value"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"modified","namespace":"default","resourceVersion":"2"},"data":{"replicas":"1"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"deleted","namespace":"default","resourceVersion":"3"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"relabeled","namespace":"default","resourceVersion":"4","managedFields":[{"manager":"kubectl"}]}}`,
+		},
+		[]string{`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"credentials","namespace":"default","resourceVersion":"5"},"data":{"password":"c2VjcmV0"}}`},
+	)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/configmaps":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMapList","items":[` + strings.Join(s.configMaps, ",") + `]}`))
+		case "/api/v1/namespaces/default/secrets":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"SecretList","items":[` + strings.Join(s.secrets, ",") + `]}`))
+		case "/api/v1/namespaces/default/pods":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,"message":"pods is forbidden"}`))
+		}
+	}))
+}
+
+func (s *ResourcesCheckpointSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesCheckpointSuite) setState(configMaps, secrets []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configMaps, s.secrets = configMaps, secrets
+}
+
+var checkpointToken = regexp.MustCompile(`Checkpoint ([0-9a-f]{32}) captured`)
+
+func (s *ResourcesCheckpointSuite) checkpoint(args map[string]any) (string, string) {
+	toolResult, err := s.CallTool("resources_checkpoint", args)
+	s.Require().NoError(err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	match := checkpointToken.FindStringSubmatch(text)
+	s.Require().Lenf(match, 2, "expected a checkpoint token in %s", text)
+	return match[1], text
+}
+
+func (s *ResourcesCheckpointSuite) TestResourcesCheckpoint() {
+	s.InitMcpClient()
+	token, text := s.checkpoint(map[string]any{"kinds": []any{"ConfigMap", "Secret", "Pod"}})
+	s.Run("resources_checkpoint summarizes the captured resources", func() {
+		s.Contains(text, "captured 5 resource(s) in namespace default")
+		s.Contains(text, `Use resources_since_checkpoint with checkpoint "`+token+`"`)
+		s.Regexp(`ConfigMap\s+4`, text)
+		s.Regexp(`Secret\s+1`, text)
+	})
+	s.Run("resources_checkpoint reports the resources that couldn't be listed", func() {
+		s.Contains(text, "Resource types that couldn't be listed (not captured): pods")
+	})
+	s.Run("resources_since_checkpoint without changes", func() {
+		toolResult, err := s.CallTool("resources_since_checkpoint", map[string]any{"checkpoint": token})
+		s.Require().NoError(err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "No changes in namespace default since checkpoint "+token)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "5 resource(s) unchanged")
+	})
+	s.setState(
+		[]string{
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"unchanged","namespace":"default","resourceVersion":"1"},"data":{"key":"value"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"modified","namespace":"default","resourceVersion":"12"},"data":{"replicas":"3"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"relabeled","namespace":"default","resourceVersion":"14","managedFields":[{"manager":"kubectl"},{"manager":"controller"}]}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"added","namespace":"default","resourceVersion":"15"}}`,
+		},
+		[]string{`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"credentials","namespace":"default","resourceVersion":"16"},"data":{"password":"bmV3LXNlY3JldA=="}}`},
+	)
+	toolResult, err := s.CallTool("resources_since_checkpoint", map[string]any{"checkpoint": token})
+	s.Run("resources_since_checkpoint with changes", func() {
+		s.Require().NoError(err)
+		s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text = toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("resources_since_checkpoint summarizes the changes", func() {
+		s.Contains(text, "1 added, 1 deleted, 2 modified, 1 status changed, 1 unchanged")
+	})
+	s.Run("resources_since_checkpoint lists the changes sorted by kind and name", func() {
+		s.Regexp(`(?s)Added\s+v1\s+ConfigMap\s+added\n`+
+			`Deleted\s+v1\s+ConfigMap\s+deleted\n`+
+			`Modified\s+v1\s+ConfigMap\s+modified\n`+
+			`StatusChanged\s+v1\s+ConfigMap\s+relabeled\n`+
+			`Modified\s+v1\s+Secret\s+credentials\n`, text)
+		s.NotRegexp(`ConfigMap\s+unchanged`, text)
+	})
+	s.Run("resources_since_checkpoint shows the diff of modified resources", func() {
+		s.Contains(text, "--- checkpoint/ConfigMap.default.modified")
+		s.Contains(text, "+++ current/ConfigMap.default.modified")
+		s.Contains(text, "-  replicas: \"1\"")
+		s.Contains(text, "+  replicas: \"3\"")
+	})
+	s.Run("resources_since_checkpoint doesn't reveal Secret values", func() {
+		s.Contains(text, "--- checkpoint/Secret.default.credentials")
+		s.Contains(text, "password: hmac-sha256:")
+		s.NotContains(text, "c2VjcmV0")
+		s.NotContains(text, "bmV3LXNlY3JldA==")
+	})
+	s.Run("resources_since_checkpoint with unknown checkpoint returns error", func() {
+		toolResult, err := s.CallTool("resources_since_checkpoint", map[string]any{"checkpoint": "0123456789abcdef0123456789abcdef"})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get changes since checkpoint: checkpoint 0123456789abcdef0123456789abcdef not found: it expired")
+	})
+	s.Run("resources_since_checkpoint with missing checkpoint returns error", func() {
+		toolResult, err := s.CallTool("resources_since_checkpoint", map[string]any{})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "checkpoint")
+	})
+}
+
+func (s *ResourcesCheckpointSuite) TestResourcesCheckpointLabelSelector() {
+	s.InitMcpClient()
+	var labelSelector string
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v1/namespaces/default/configmaps" {
+			s.mu.Lock()
+			labelSelector = req.URL.Query().Get("labelSelector")
+			s.mu.Unlock()
+		}
+	}))
+	token, _ := s.checkpoint(map[string]any{"kinds": []any{"ConfigMap"}, "labelSelector": "app=web"})
+	_, err := s.CallTool("resources_since_checkpoint", map[string]any{"checkpoint": token})
+	s.Require().NoError(err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal("app=web", labelSelector, "the changes should be listed with the label selector of the checkpoint")
+}
+
+func (s *ResourcesCheckpointSuite) TestResourcesCheckpointInvalidKinds() {
+	s.InitMcpClient()
+	s.Run("resources_checkpoint with non-string kinds returns error", func() {
+		toolResult, err := s.CallTool("resources_checkpoint", map[string]any{"kinds": []any{1}})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+	})
+	s.Run("resources_checkpoint with unknown kinds returns error", func() {
+		toolResult, err := s.CallTool("resources_checkpoint", map[string]any{"kinds": []any{"Unknown.example.com"}})
+		s.Require().NoError(err)
+		s.True(toolResult.IsError)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to capture checkpoint: none of the kinds [Unknown.example.com] is a namespaced resource served by the cluster")
+	})
+}
+
+func TestResourcesCheckpoint(t *testing.T) {
+	suite.Run(t, new(ResourcesCheckpointSuite))
+}
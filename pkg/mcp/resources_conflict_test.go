@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+const resourcesConflictStatus = `{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"Conflict","code":409,` +
+	`"details":{"name":"web","kind":"configmaps"},` +
+	`"message":"Operation cannot be fulfilled on configmaps \"web\": the object has been modified; please apply your changes to the latest version and try again"}`
+
+type ResourcesConflictSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	// scaleConflicts is the number of scale updates to reject with a Conflict
+	scaleConflicts atomic.Int32
+	scaleUpdates   atomic.Int32
+}
+
+func (s *ResourcesConflictSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.scaleConflicts.Store(0)
+	s.scaleUpdates.Store(0)
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.URL.Path == "/api/v1/namespaces/ns-1/configmaps/web" && req.Method == http.MethodPatch:
+			body, _ := io.ReadAll(req.Body)
+			// the stored resourceVersion is 2, applying a manifest based on an older version is rejected
+			if strings.Contains(string(body), `"resourceVersion":"1"`) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(resourcesConflictStatus))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"ns-1","resourceVersion":"3","labels":{"app":"web"}}}`))
+		case req.URL.Path == "/apis/apps/v1/namespaces/ns-1/deployments/web/scale" && req.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"web","namespace":"ns-1","resourceVersion":"2"},"spec":{"replicas":1}}`))
+		case req.URL.Path == "/apis/apps/v1/namespaces/ns-1/deployments/web/scale" && req.Method == http.MethodPut:
+			s.scaleUpdates.Add(1)
+			if s.scaleConflicts.Add(-1) >= 0 {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(resourcesConflictStatus))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"autoscaling/v1","kind":"Scale","metadata":{"name":"web","namespace":"ns-1","resourceVersion":"3"},"spec":{"replicas":3}}`))
+		}
+	}))
+}
+
+func (s *ResourcesConflictSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesConflictSuite) TestResourcesCreateOrUpdateConflict() {
+	s.InitMcpClient()
+	s.Run("resources_create_or_update with a stale resourceVersion", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"ns-1","resourceVersion":"1"},"data":{"key":"value"}}`,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			"the resource was modified since you read it; re-fetch it and retry the change: Operation cannot be fulfilled on configmaps \"web\"")
+	})
+	s.Run("resources_create_or_update with a stale resourceVersion and only labels is re-applied", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"ns-1","resourceVersion":"1","labels":{"app":"web"}}}`,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `resourceVersion: "3"`)
+	})
+}
+
+func (s *ResourcesConflictSuite) TestResourcesScaleConflict() {
+	s.InitMcpClient()
+	s.Run("resources_scale is retried when the resource was modified meanwhile", func() {
+		s.scaleConflicts.Store(1)
+		toolResult, err := s.CallTool("resources_scale", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "ns-1", "name": "web", "scale": 3,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal(int32(2), s.scaleUpdates.Load())
+	})
+	s.Run("resources_scale reports the conflict when the retries are exhausted", func() {
+		s.scaleUpdates.Store(0)
+		s.scaleConflicts.Store(100)
+		toolResult, err := s.CallTool("resources_scale", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "ns-1", "name": "web", "scale": 3,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "the resource was modified since you read it; re-fetch it and retry the change")
+	})
+}
+
+func TestResourcesConflict(t *testing.T) {
+	suite.Run(t, new(ResourcesConflictSuite))
+}
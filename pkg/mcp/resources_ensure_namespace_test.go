@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+type ResourcesEnsureNamespaceSuite struct {
+	BaseMcpSuite
+	mockServer        *test.MockServer
+	namespaces        map[string]bool
+	createdNamespaces []string
+	createRequests    int
+	namespaceGets     int
+}
+
+func (s *ResourcesEnsureNamespaceSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.namespaces = map[string]bool{"default": true}
+	s.createdNamespaces = nil
+	s.createRequests, s.namespaceGets = 0, 0
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list", "create", "patch"}},
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.URL.Path == "/api/v1/namespaces" && req.Method == http.MethodPost:
+			body, _ := io.ReadAll(req.Body)
+			obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+			s.Require().NoError(err, "failed to decode namespace")
+			ns := obj.(*v1.Namespace)
+			s.createRequests++
+			if s.namespaces[ns.Name] {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"AlreadyExists","code":409,"message":"namespaces \"` + ns.Name + `\" already exists"}`))
+				return
+			}
+			s.namespaces[ns.Name] = true
+			s.createdNamespaces = append(s.createdNamespaces, ns.Name)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"` + ns.Name + `"}}`))
+		case req.URL.Path == "/api/v1/namespaces/default" || req.URL.Path == "/api/v1/namespaces/new-ns":
+			name := req.URL.Path[len("/api/v1/namespaces/"):]
+			s.namespaceGets++
+			if !s.namespaces[name] {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"namespaces \"` + name + `\" not found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"` + name + `"}}`))
+		case req.URL.Path == "/api/v1/namespaces/new-ns/configmaps/a-cm" || req.URL.Path == "/api/v1/namespaces/default/configmaps/a-cm":
+			if req.Method != http.MethodPatch {
+				return
+			}
+			if !s.namespaces[req.URL.Path[len("/api/v1/namespaces/"):len(req.URL.Path)-len("/configmaps/a-cm")]] {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"namespaces not found"}`))
+				return
+			}
+			body, _ := io.ReadAll(req.Body)
+			_, _ = w.Write(body)
+		}
+	}))
+}
+
+func (s *ResourcesEnsureNamespaceSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesEnsureNamespaceSuite) TestResourcesCreateOrUpdateEnsureNamespace() {
+	s.InitMcpClient()
+	configMapYaml := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm\n  namespace: new-ns\n"
+	s.Run("resources_create_or_update without ensureNamespace fails for missing namespace", func() {
+		toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to create or update resources")
+		s.Empty(s.createdNamespaces)
+	})
+	s.Run("resources_create_or_update(ensureNamespace=true) with missing namespace", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{"resource": configMapYaml, "ensureNamespace": true})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("creates the namespace", func() {
+			s.Equal([]string{"new-ns"}, s.createdNamespaces)
+		})
+		s.Run("notes the namespace creation", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Namespace new-ns didn't exist and has been created\n")
+		})
+	})
+	s.Run("resources_create_or_update(ensureNamespace=true) with existing namespace", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm\n  namespace: default\n",
+			"ensureNamespace": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "has been created")
+		s.Equal([]string{"new-ns"}, s.createdNamespaces)
+	})
+	s.Run("resources_create_or_update(ensureNamespace=true) with several resources in an existing namespace", func() {
+		s.createRequests, s.namespaceGets = 0, 0
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm\n  namespace: default\n---\n" +
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm\n  namespace: default\n",
+			"ensureNamespace": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Run("doesn't report the existing namespace as created", func() {
+			s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "has been created")
+		})
+		s.Run("tries to create the namespace once, without reading it", func() {
+			s.Equal(1, s.createRequests)
+			s.Equal(0, s.namespaceGets)
+		})
+	})
+}
+
+func (s *ResourcesEnsureNamespaceSuite) TestResourcesCreateOrUpdateEnsureNamespaceDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Namespace" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{
+		"resource":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm\n  namespace: new-ns\n",
+		"ensureNamespace": true,
+	})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Namespace")
+	s.Empty(s.createdNamespaces)
+}
+
+func TestResourcesEnsureNamespace(t *testing.T) {
+	suite.Run(t, new(ResourcesEnsureNamespaceSuite))
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ResourcesFieldOwnershipSuite struct {
+	BaseMcpSuite
+	mockServer    *test.MockServer
+	mu            sync.Mutex
+	fieldManagers []string
+}
+
+func (s *ResourcesFieldOwnershipSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.fieldManagers = nil
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/default/deployments/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default","managedFields":[` +
+				`{"manager":"kubectl","operation":"Apply","apiVersion":"apps/v1","time":"2024-01-01T10:00:00Z","fieldsType":"FieldsV1","fieldsV1":` +
+				`{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:image":{},"f:name":{}}}}}}}},` +
+				`{"manager":"autoscaler","operation":"Update","apiVersion":"apps/v1","time":"2024-01-01T11:00:00Z","fieldsType":"FieldsV1","fieldsV1":{"f:spec":{"f:replicas":{}}}},` +
+				`{"manager":"kube-controller-manager","operation":"Update","apiVersion":"apps/v1","time":"2024-01-01T11:00:01Z","fieldsType":"FieldsV1","subresource":"status",` +
+				`"fieldsV1":{"f:status":{"f:replicas":{}}}}` +
+				`]},"spec":{"replicas":3}}`))
+		case "/api/v1/namespaces/default/configmaps/untracked":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"untracked","namespace":"default"}}`))
+		case "/api/v1/namespaces/default/configmaps/applied":
+			s.mu.Lock()
+			s.fieldManagers = append(s.fieldManagers, req.URL.Query().Get("fieldManager"))
+			s.mu.Unlock()
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"applied","namespace":"default"}}`))
+		}
+	}))
+}
+
+func (s *ResourcesFieldOwnershipSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesFieldOwnershipSuite) TestResourcesFieldOwnership() {
+	s.InitMcpClient()
+	s.Run("resources_field_ownership with missing name returns error", func() {
+		toolResult, _ := s.CallTool("resources_field_ownership", map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get field ownership: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_field_ownership(name=web)", func() {
+		toolResult, err := s.CallTool("resources_field_ownership", map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "Deployment", "namespace": "default", "name": "web",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the fields owned by each manager", func() {
+			s.Contains(text, "# Field ownership of Deployment web (metadata.managedFields)\n")
+			s.Contains(text, "- apiVersion: apps/v1\n"+
+				"  fields:\n"+
+				"  - .metadata.labels.app\n"+
+				"  - .spec.replicas\n"+
+				"  - .spec.template.spec.containers[name=\"app\"].image\n"+
+				"  - .spec.template.spec.containers[name=\"app\"].name\n"+
+				"  manager: kubectl\n"+
+				"  operation: Apply\n"+
+				"  time: \"2024-01-01T10:00:00Z\"\n")
+			s.Contains(text, "  manager: autoscaler\n  operation: Update\n")
+		})
+		s.Run("returns the subresource of the manager", func() {
+			s.Contains(text, "  - .status.replicas\n  manager: kube-controller-manager\n  operation: Update\n  subresource: status\n")
+		})
+		s.Run("returns the fields owned by several managers", func() {
+			s.Contains(text, "sharedFields:\n  .spec.replicas:\n  - kubectl\n  - autoscaler\n")
+			s.NotContains(text, "  .status.replicas:\n")
+			s.Contains(text, "# sharedFields are owned by several managers")
+		})
+	})
+	s.Run("resources_field_ownership(name=untracked) without managedFields", func() {
+		toolResult, err := s.CallTool("resources_field_ownership", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "untracked",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("ConfigMap untracked has no managedFields, field ownership is not tracked for it", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ResourcesFieldOwnershipSuite) TestResourcesCreateOrUpdateFieldManager() {
+	s.InitMcpClient()
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: applied\n  namespace: default\n"
+	s.Run("resources_create_or_update applies with the server field manager by default", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{"resource": manifest})
+		s.Require().NoError(err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("resources_create_or_update applies with the provided field manager", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{"resource": manifest, "fieldManager": "team-a"})
+		s.Require().NoError(err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Equal([]string{"kubernetes-mcp-server", "team-a"}, s.fieldManagers)
+}
+
+func TestResourcesFieldOwnership(t *testing.T) {
+	suite.Run(t, new(ResourcesFieldOwnershipSuite))
+}
@@ -55,10 +55,16 @@ func ServerResourceToGoSdkResource(_ *Server, res api.ServerResource) (*mcp.Reso
 // ServerResourceTemplateToGoSdkResourceTemplate converts an api.ServerResourceTemplate to MCP SDK types.
 // It validates the URITemplate upfront so callers can surface a wrapped error instead of letting
 // the SDK panic during registration on hot reload.
-func ServerResourceTemplateToGoSdkResourceTemplate(_ *Server, rt api.ServerResourceTemplate) (*mcp.ResourceTemplate, mcp.ResourceHandler, error) {
+func ServerResourceTemplateToGoSdkResourceTemplate(s *Server, rt api.ServerResourceTemplate) (*mcp.ResourceTemplate, mcp.ResourceHandler, error) {
 	if _, err := uritemplate.New(rt.ResourceTemplate.URITemplate); err != nil {
 		return nil, nil, fmt.Errorf("invalid URITemplate %q: %w", rt.ResourceTemplate.URITemplate, err)
 	}
+	if (rt.Handler == nil) == (rt.KubernetesHandler == nil) {
+		return nil, nil, fmt.Errorf("resource template %q must have exactly one of Handler or KubernetesHandler set", rt.ResourceTemplate.URITemplate)
+	}
+	if rt.KubernetesWatcher != nil && rt.KubernetesHandler == nil {
+		return nil, nil, fmt.Errorf("resource template %q must have a KubernetesHandler set to support subscriptions", rt.ResourceTemplate.URITemplate)
+	}
 	mcpTemplate := &mcp.ResourceTemplate{
 		URITemplate: rt.ResourceTemplate.URITemplate,
 		Name:        rt.ResourceTemplate.Name,
@@ -66,7 +72,7 @@ func ServerResourceTemplateToGoSdkResourceTemplate(_ *Server, rt api.ServerResou
 		MIMEType:    rt.ResourceTemplate.MIMEType,
 	}
 	handler := func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-		content, err := rt.Handler(ctx, req.Params.URI)
+		content, err := readResourceTemplate(ctx, s, rt, req.Params.URI)
 		if err != nil {
 			return nil, err
 		}
@@ -92,6 +98,23 @@ func ServerResourceTemplateToGoSdkResourceTemplate(_ *Server, rt api.ServerResou
 	return mcpTemplate, handler, nil
 }
 
+// readResourceTemplate calls the resource template handler, the Kubernetes handlers get the (derived) client of the default cluster
+func readResourceTemplate(ctx context.Context, s *Server, rt api.ServerResourceTemplate, uri string) (*api.ResourceContent, error) {
+	if rt.KubernetesHandler == nil {
+		return rt.Handler(ctx, uri)
+	}
+	k, err := s.p.GetDerivedKubernetes(ctx, s.p.GetDefaultTarget())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+	return rt.KubernetesHandler(api.ResourceTemplateHandlerParams{
+		Context:          ctx,
+		BaseConfig:       s.configuration.Load(),
+		KubernetesClient: k,
+		URI:              uri,
+	})
+}
+
 // validateResourceContent enforces the api.ResourceContent invariant:
 // exactly one of Text or Blob must be set.
 func validateResourceContent(content *api.ResourceContent) error {
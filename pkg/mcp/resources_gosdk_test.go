@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/containers/kubernetes-mcp-server/internal/test"
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
@@ -673,6 +674,69 @@ func (s *ResourceSuite) TestResourceContentInvariant() {
 	})
 }
 
+func (s *ResourceSuite) TestResourceTemplateHandlers() {
+	s.Run("template without handler returns error", func() {
+		_, _, err := ServerResourceTemplateToGoSdkResourceTemplate(nil, api.ServerResourceTemplate{
+			ResourceTemplate: api.ResourceTemplate{URITemplate: "test://example/{name}", Name: "no-handler"},
+		})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "must have exactly one of Handler or KubernetesHandler set")
+	})
+	s.Run("template with both handlers returns error", func() {
+		_, _, err := ServerResourceTemplateToGoSdkResourceTemplate(nil, api.ServerResourceTemplate{
+			ResourceTemplate: api.ResourceTemplate{URITemplate: "test://example/{name}", Name: "both-handlers"},
+			Handler: func(_ context.Context, _ string) (*api.ResourceContent, error) {
+				return &api.ResourceContent{Text: "unreachable"}, nil
+			},
+			KubernetesHandler: func(_ api.ResourceTemplateHandlerParams) (*api.ResourceContent, error) {
+				return &api.ResourceContent{Text: "unreachable"}, nil
+			},
+		})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "must have exactly one of Handler or KubernetesHandler set")
+	})
+	s.Run("template with watcher and without Kubernetes handler returns error", func() {
+		_, _, err := ServerResourceTemplateToGoSdkResourceTemplate(nil, api.ServerResourceTemplate{
+			ResourceTemplate: api.ResourceTemplate{URITemplate: "test://example/{name}", Name: "watcher-without-kubernetes-handler"},
+			Handler: func(_ context.Context, _ string) (*api.ResourceContent, error) {
+				return &api.ResourceContent{Text: "unreachable"}, nil
+			},
+			KubernetesWatcher: func(_ api.ResourceTemplateHandlerParams, _ func()) error {
+				return nil
+			},
+		})
+		s.Require().Error(err)
+		s.Contains(err.Error(), "must have a KubernetesHandler set to support subscriptions")
+	})
+	s.Run("template with Kubernetes handler receives the URI and a Kubernetes client", func() {
+		testToolset := &mockResourceToolset{
+			resourceTemplates: []api.ServerResourceTemplate{
+				{
+					ResourceTemplate: api.ResourceTemplate{URITemplate: "test://kubernetes/{name}", Name: "kubernetes", MIMEType: "text/plain"},
+					KubernetesHandler: func(params api.ResourceTemplateHandlerParams) (*api.ResourceContent, error) {
+						if params.KubernetesClient == nil {
+							return nil, errors.New("missing Kubernetes client")
+						}
+						return &api.ResourceContent{Text: "content for: " + params.URI}, nil
+					},
+				},
+			},
+		}
+		mockServer := test.NewMockServer()
+		defer mockServer.Close()
+		mockServer.Handle(test.NewDiscoveryClientHandler())
+		s.Cfg.KubeConfig = mockServer.KubeconfigFile(s.T())
+		toolsets.Clear()
+		toolsets.Register(testToolset)
+		s.Cfg.Toolsets = []string{"resource-test"}
+		s.InitMcpClient()
+		result, err := s.ReadResource("test://kubernetes/foo")
+		s.Require().NoError(err)
+		s.Require().Len(result.Contents, 1)
+		s.Equal("content for: test://kubernetes/foo", result.Contents[0].Text)
+	})
+}
+
 type mockResourceToolset struct {
 	name              string
 	resources         []api.ServerResource
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ResourcesKustomizeSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	applied    []string
+}
+
+func (s *ResourcesKustomizeSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.applied = nil
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch || !strings.HasPrefix(req.URL.Path, "/api/v1/namespaces/") {
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		s.applied = append(s.applied, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+}
+
+func (s *ResourcesKustomizeSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesKustomizeSuite) TestResourcesApplyKustomize() {
+	s.InitMcpClient()
+	s.Run("resources_apply_kustomize(kustomization, files) applies rendered resources", func() {
+		toolResult, err := s.CallTool("resources_apply_kustomize", map[string]interface{}{
+			"kustomization": "resources:\n- configmap.yaml\nnamePrefix: dev-\nconfigMapGenerator:\n- name: generated\n  literals:\n  - level=debug\n",
+			"files": map[string]interface{}{
+				"configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  key: value\n",
+			},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("applies every rendered resource in the default namespace", func() {
+			s.Require().Len(s.applied, 2)
+			s.Equal("/api/v1/namespaces/default/configmaps/dev-settings", s.applied[0])
+			s.True(strings.HasPrefix(s.applied[1], "/api/v1/namespaces/default/configmaps/dev-generated-"), "expected hashed generated ConfigMap name, got %s", s.applied[1])
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the applied resources", func() {
+			s.Contains(text, "# Namespaced resources without metadata.namespace have been created or updated in namespace default")
+			s.Contains(text, "# The following resources (YAML) have been created or updated successfully")
+			s.Contains(text, "name: dev-settings")
+		})
+	})
+	s.Run("resources_apply_kustomize(url) with remote manifests disabled returns error", func() {
+		s.applied = nil
+		toolResult, _ := s.CallTool("resources_apply_kustomize", map[string]interface{}{
+			"url": "https://github.com/kubernetes-sigs/kustomize//examples/helloWorld",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "set remote_manifests_enabled = true in [toolset_configs.core]")
+		s.Empty(s.applied)
+	})
+	s.Run("resources_apply_kustomize() with invalid files returns error", func() {
+		toolResult, _ := s.CallTool("resources_apply_kustomize", map[string]interface{}{
+			"kustomization": "resources:\n- configmap.yaml\n",
+			"files":         map[string]interface{}{"configmap.yaml": 42},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "content of file configmap.yaml is not a string")
+	})
+}
+
+func (s *ResourcesKustomizeSuite) TestResourcesApplyKustomizeDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("resources_apply_kustomize", map[string]interface{}{
+		"kustomization": "resources:\n- configmap.yaml\nsecretGenerator:\n- name: credentials\n  literals:\n  - password=secret\n",
+		"files": map[string]interface{}{
+			"configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n",
+		},
+	})
+	s.Run("returns error", func() {
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Secret")
+	})
+	s.Run("does not apply any resource", func() {
+		s.Empty(s.applied)
+	})
+}
+
+func TestResourcesKustomize(t *testing.T) {
+	suite.Run(t, new(ResourcesKustomizeSuite))
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourcesLastAppliedSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesLastAppliedSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		lastApplied := `{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"annotations\":{},\"name\":%s,\"namespace\":\"default\"},\"data\":{\"key\":\"applied\",\"other\":\"value\"}}`
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/configmaps/drifted":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"drifted","namespace":"default","uid":"uid-1","resourceVersion":"2",` +
+				`"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"` + fmt.Sprintf(lastApplied, `\"drifted\"`) + `"},` +
+				`"managedFields":[{"manager":"kubectl-client-side-apply"}]},"data":{"key":"edited","other":"value","added":"by-someone"}}`))
+		case "/api/v1/namespaces/default/configmaps/in-sync":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"in-sync","namespace":"default","uid":"uid-2",` +
+				`"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"` + fmt.Sprintf(lastApplied, `\"in-sync\"`) + `"}},` +
+				`"data":{"key":"applied","other":"value"}}`))
+		case "/api/v1/namespaces/default/configmaps/not-applied":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"not-applied","namespace":"default"},"data":{"key":"value"}}`))
+		}
+	}))
+}
+
+func (s *ResourcesLastAppliedSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesLastAppliedSuite) TestResourcesLastApplied() {
+	s.InitMcpClient()
+	s.Run("resources_last_applied with missing name returns error", func() {
+		toolResult, _ := s.CallTool("resources_last_applied", map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get last applied configuration: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_last_applied(name=drifted)", func() {
+		toolResult, err := s.CallTool("resources_last_applied", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "drifted",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the last applied configuration as YAML", func() {
+			s.Contains(text, "# Last applied configuration of ConfigMap drifted (kubectl.kubernetes.io/last-applied-configuration annotation)\n"+
+				"apiVersion: v1\ndata:\n  key: applied\n  other: value\nkind: ConfigMap\n")
+		})
+		s.Run("returns the diff against the live resource", func() {
+			s.Contains(text, "--- last-applied/v1.ConfigMap.default.drifted\n+++ live/v1.ConfigMap.default.drifted\n")
+			s.Contains(text, "-  key: applied\n+  key: edited\n")
+		})
+		s.Run("ignores the fields not in the last applied configuration", func() {
+			s.NotContains(text, "by-someone")
+			s.NotContains(text, "managedFields")
+			s.NotContains(text, "uid-1")
+		})
+	})
+	s.Run("resources_last_applied(name=in-sync) returns no drift", func() {
+		toolResult, _ := s.CallTool("resources_last_applied", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "in-sync",
+		})
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# No drift found, the live resource matches the last applied configuration")
+	})
+	s.Run("resources_last_applied(name=not-applied) explains the missing annotation", func() {
+		toolResult, _ := s.CallTool("resources_last_applied", map[string]interface{}{
+			"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "not-applied",
+		})
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+			"ConfigMap not-applied has no kubectl.kubernetes.io/last-applied-configuration annotation, it's not managed with client-side apply (kubectl apply)")
+	})
+}
+
+func (s *ResourcesLastAppliedSuite) TestResourcesLastAppliedDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ConfigMap" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("resources_last_applied", map[string]interface{}{
+		"apiVersion": "v1", "kind": "ConfigMap", "namespace": "default", "name": "drifted",
+	})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=ConfigMap")
+}
+
+func TestResourcesLastApplied(t *testing.T) {
+	suite.Run(t, new(ResourcesLastAppliedSuite))
+}
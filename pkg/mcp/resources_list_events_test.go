@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourcesListEventsSuite struct {
+	BaseMcpSuite
+	mockServer     *test.MockServer
+	eventSelectors []string
+}
+
+func (s *ResourcesListEventsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.eventSelectors = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"failing-pod","namespace":"default","uid":"uid-failing"}},` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"healthy-pod","namespace":"default","uid":"uid-healthy"}}]}`))
+		case "/api/v1/namespaces/default/events":
+			fieldSelector := req.URL.Query().Get("fieldSelector")
+			s.eventSelectors = append(s.eventSelectors, fieldSelector)
+			if fieldSelector != "involvedObject.uid=uid-failing,type=Warning" {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[]}`))
+				return
+			}
+			items := ""
+			for i, reason := range []string{"BackOff", "Failed", "FailedMount", "Unhealthy", "FailedScheduling", "Evicted"} {
+				if i > 0 {
+					items += ","
+				}
+				// The time the events were last observed is provided by different fields
+				timestamp := `"firstTimestamp":"2024-01-01T00:0` + string(rune('0'+i)) + `:00Z"`
+				switch reason {
+				case "Failed":
+					timestamp = `"eventTime":"2024-01-01T00:01:00.500000Z"`
+				case "Unhealthy":
+					timestamp = `"count":3,"firstTimestamp":"2023-12-31T00:00:00Z","lastTimestamp":"2024-01-01T00:03:00Z"`
+				case "FailedScheduling":
+					timestamp = `"series":{"count":2,"lastObservedTime":"2024-01-01T00:04:00.000000Z"}`
+				}
+				items += `{"apiVersion":"v1","kind":"Event","metadata":{"name":"ev-` + reason + `","namespace":"default"},` +
+					`"involvedObject":{"apiVersion":"v1","kind":"Pod","name":"failing-pod","uid":"uid-failing"},` +
+					`"type":"Warning","reason":"` + reason + `","message":"` + reason + ` message",` + timestamp + `}`
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[` + items + `]}`))
+		}
+	}))
+}
+
+func (s *ResourcesListEventsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesListEventsSuite) TestResourcesListWithEvents() {
+	s.InitMcpClient()
+	s.Run("resources_list(withEvents=true)", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "default", "output": "yaml", "withEvents": true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("queries the warning events of each resource by uid", func() {
+			s.ElementsMatch([]string{"involvedObject.uid=uid-failing,type=Warning", "involvedObject.uid=uid-healthy,type=Warning"}, s.eventSelectors)
+		})
+		s.Run("includes the listed resources", func() {
+			s.Contains(text, "name: failing-pod")
+			s.Contains(text, "name: healthy-pod")
+		})
+		s.Run("includes the warning events of the resources with events", func() {
+			s.Contains(text, "\n# Warning events\n- events:\n")
+			s.Contains(text, "  kind: Pod\n  name: failing-pod\n  namespace: default\n")
+			s.NotContains(text, "  name: healthy-pod\n  namespace: default\n")
+		})
+		s.Run("includes only the most recent events", func() {
+			s.Contains(text, "Reason: Evicted")
+			s.Contains(text, "Reason: Failed\n")
+			s.NotContains(text, "Reason: BackOff")
+		})
+		s.Run("sorts the events by the time they were last observed", func() {
+			s.Regexp(`(?s)Reason: Evicted\n.*Reason: FailedScheduling\n.*Reason: Unhealthy\n.*Reason: FailedMount\n.*Reason: Failed\n`, text)
+		})
+	})
+	s.Run("resources_list(withEvents=false) doesn't include events", func() {
+		s.eventSelectors = nil
+		toolResult, _ := s.CallTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "output": "yaml"})
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "Warning events")
+		s.Empty(s.eventSelectors)
+	})
+}
+
+func (s *ResourcesListEventsSuite) TestResourcesListWithEventsDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Event" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("resources_list", map[string]interface{}{
+		"apiVersion": "v1", "kind": "Pod", "namespace": "default", "output": "yaml", "withEvents": true,
+	})
+	s.Falsef(toolResult.IsError, "call tool should still list the resources: %v", toolResult.Content)
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Contains(text, "name: failing-pod")
+	s.Contains(text, "# Warning events could not be retrieved: ")
+	s.Contains(text, "resource not allowed: /v1, Kind=Event")
+	s.Empty(s.eventSelectors, "events should not be requested")
+}
+
+func TestResourcesListEvents(t *testing.T) {
+	suite.Run(t, new(ResourcesListEventsSuite))
+}
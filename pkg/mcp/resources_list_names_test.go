@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesListNamesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesListNamesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1/pods":
+			if req.URL.Query().Get("labelSelector") == "app=web" {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+					`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"ns-1","labels":{"app":"web"}}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web-1","namespace":"ns-1","labels":{"app":"web"}}},` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"db-0","namespace":"ns-1"},"spec":{"containers":[{"name":"db","image":"postgres"}]}}]}`))
+		case "/api/v1/nodes":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NodeList","items":[` +
+				`{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-1"}},{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-2"}}]}`))
+		case "/api/v1/namespaces/empty/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		}
+	}))
+}
+
+func (s *ResourcesListNamesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesListNamesSuite) TestResourcesListNamesOnly() {
+	s.Cfg.ListOutput = "table"
+	s.InitMcpClient()
+	s.Run("resources_list(namesOnly=true) returns the namespace and name of namespaced resources", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "namesOnly": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("ns-1/web-1\nns-1/db-0\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_list(namesOnly=true) returns the name of cluster-scoped resources", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Node", "namesOnly": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("node-1\nnode-2\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_list(namesOnly=true, labelSelector=app=web) filters the resources", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "namesOnly": true, "labelSelector": "app=web", "output": "yaml",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("ns-1/web-1\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_list(namesOnly=true) with no resources", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "empty", "namesOnly": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# No resources found\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func TestResourcesListNames(t *testing.T) {
+	suite.Run(t, new(ResourcesListNamesSuite))
+}
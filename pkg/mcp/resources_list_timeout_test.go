@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesListTimeoutSuite struct {
+	BaseMcpSuite
+	mockServer     *test.MockServer
+	mu             sync.Mutex
+	timeoutSeconds []string
+}
+
+func (s *ResourcesListTimeoutSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.timeoutSeconds = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path != "/api/v1/namespaces/default/pods" {
+			return
+		}
+		s.mu.Lock()
+		s.timeoutSeconds = append(s.timeoutSeconds, req.URL.Query().Get("timeoutSeconds"))
+		s.mu.Unlock()
+		if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table",` +
+				`"columnDefinitions":[{"name":"Name","type":"string"}],"rows":[{"cells":["pod-1"]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+			`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","namespace":"default"}}]}`))
+	}))
+}
+
+func (s *ResourcesListTimeoutSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesListTimeoutSuite) recordedTimeoutSeconds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.timeoutSeconds...)
+}
+
+func (s *ResourcesListTimeoutSuite) TestResourcesListTimeoutSeconds() {
+	s.InitMcpClient()
+	for _, output := range []string{"yaml", "table"} {
+		s.Run("resources_list(timeoutSeconds=15, output="+output+") sends timeoutSeconds to the API server", func() {
+			s.mu.Lock()
+			s.timeoutSeconds = nil
+			s.mu.Unlock()
+			toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+				"apiVersion": "v1", "kind": "Pod", "namespace": "default", "output": output, "timeoutSeconds": 15,
+			})
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+			s.Equal([]string{"15"}, s.recordedTimeoutSeconds())
+		})
+	}
+	s.Run("resources_list without timeoutSeconds doesn't bound the call", func() {
+		s.mu.Lock()
+		s.timeoutSeconds = nil
+		s.mu.Unlock()
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal([]string{""}, s.recordedTimeoutSeconds())
+	})
+}
+
+func TestResourcesListTimeout(t *testing.T) {
+	suite.Run(t, new(ResourcesListTimeoutSuite))
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesOutputSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesOutputSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			if strings.Contains(req.Header.Get("Accept"), "as=Table") {
+				_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table",` +
+					`"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Status","type":"string"}],` +
+					`"rows":[{"cells":["pod-1","Running"],"object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","namespace":"default"}}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","namespace":"default"}}` +
+				`]}`))
+		case "/api/v1/namespaces/default/pods/pod-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","namespace":"default"}}`))
+		}
+	}))
+}
+
+func (s *ResourcesOutputSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesOutputSuite) callResourcesTool(name string, args map[string]interface{}) string {
+	args["apiVersion"] = "v1"
+	args["kind"] = "Pod"
+	args["namespace"] = "default"
+	toolResult, err := s.CallTool(name, args)
+	s.Require().Nilf(err, "call tool failed %v", err)
+	s.Require().Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	return toolResult.Content[0].(*mcp.TextContent).Text
+}
+
+func (s *ResourcesOutputSuite) TestWithoutDefaultOutput() {
+	s.InitMcpClient()
+	s.Run("resources_list uses list_output", func() {
+		text := s.callResourcesTool("resources_list", map[string]interface{}{})
+		s.Contains(text, "- apiVersion: v1\n  kind: Pod\n")
+	})
+	s.Run("resources_get uses yaml", func() {
+		text := s.callResourcesTool("resources_get", map[string]interface{}{"name": "pod-1"})
+		s.Contains(text, "apiVersion: v1\nkind: Pod\n")
+	})
+}
+
+func (s *ResourcesOutputSuite) TestDefaultOutput() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		default_output = "json"
+	`), s.Cfg), "Expected to parse default_output config")
+	s.InitMcpClient()
+	s.Run("resources_list honors default_output", func() {
+		text := s.callResourcesTool("resources_list", map[string]interface{}{})
+		var items []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &items), "expected JSON array, got %s", text)
+		s.Require().Len(items, 1)
+		s.Equal("Pod", items[0]["kind"])
+	})
+	s.Run("resources_get honors default_output", func() {
+		text := s.callResourcesTool("resources_get", map[string]interface{}{"name": "pod-1"})
+		var pod map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(text), &pod), "expected JSON object, got %s", text)
+		s.Equal("pod-1", pod["metadata"].(map[string]any)["name"])
+	})
+	s.Run("resources_list output argument overrides default_output", func() {
+		text := s.callResourcesTool("resources_list", map[string]interface{}{"output": "yaml"})
+		s.Contains(text, "- apiVersion: v1\n  kind: Pod\n")
+	})
+	s.Run("resources_get output argument overrides default_output", func() {
+		text := s.callResourcesTool("resources_get", map[string]interface{}{"name": "pod-1", "output": "table"})
+		s.Regexp(`NAMESPACE\s+APIVERSION\s+KIND\s+NAME\s+STATUS`, text)
+		s.Regexp(`default\s+v1\s+Pod\s+pod-1\s+Running`, text)
+	})
+	s.Run("resources_get with invalid output returns error", func() {
+		toolResult, _ := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "name": "pod-1", "output": "xml",
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get resource, invalid output xml, valid outputs are: yaml, table, json", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func TestResourcesOutput(t *testing.T) {
+	suite.Run(t, new(ResourcesOutputSuite))
+}
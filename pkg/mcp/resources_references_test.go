@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+var resourcesReferencesObjects = map[string]string{
+	"/api/v1/namespaces/ns-1/pods/web": `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web","namespace":"ns-1"},
+	  "spec":{"serviceAccountName":"web","imagePullSecrets":[{"name":"registry"}],
+	    "containers":[{"name":"web","image":"nginx","envFrom":[{"configMapRef":{"name":"web-env"}},{"secretRef":{"name":"web-credentials"}}]}],
+	    "volumes":[{"name":"config","configMap":{"name":"web-config"}},{"name":"data","persistentVolumeClaim":{"claimName":"web-data"}}]}}`,
+	"/api/v1/namespaces/ns-1/pods/plain": `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"plain","namespace":"ns-1"},
+	  "spec":{"serviceAccountName":"default","containers":[{"name":"plain","image":"nginx"}]}}`,
+	"/api/v1/namespaces/ns-1/serviceaccounts/web":   `{"apiVersion":"v1","kind":"ServiceAccount","metadata":{"name":"web","namespace":"ns-1"}}`,
+	"/api/v1/namespaces/ns-1/configmaps/web-env":    `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web-env","namespace":"ns-1"},"data":{"LEVEL":"debug"}}`,
+	"/api/v1/namespaces/ns-1/configmaps/web-config": `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web-config","namespace":"ns-1"},"data":{"app.properties":"a=b"}}`,
+	"/api/v1/namespaces/ns-1/secrets/web-credentials": `{"apiVersion":"v1","kind":"Secret","type":"Opaque",
+	  "metadata":{"name":"web-credentials","namespace":"ns-1"},"data":{"password":"czNjcjN0","username":"YWRtaW4="}}`,
+	"/api/v1/nodes/node-1": `{"apiVersion":"v1","kind":"Node","metadata":{"name":"node-1"}}`,
+}
+
+type ResourcesReferencesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesReferencesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.URL.Path, "/api/v1/") {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/v1/namespaces/ns-1/pods" && strings.Contains(req.Header.Get("Accept"), "as=Table") {
+			_, _ = w.Write([]byte(`{"apiVersion":"meta.k8s.io/v1","kind":"Table",` +
+				`"columnDefinitions":[{"name":"Name","type":"string"},{"name":"Status","type":"string"}],` +
+				`"rows":[{"cells":["web","Running"],"object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"web","namespace":"ns-1"}}}]}`))
+			return
+		}
+		if object, ok := resourcesReferencesObjects[req.URL.Path]; ok {
+			_, _ = w.Write([]byte(object))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+	}))
+}
+
+func (s *ResourcesReferencesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesReferencesSuite) TestResourcesGetExpandRefs() {
+	s.InitMcpClient()
+	s.Run("resources_get(kind=Pod, expandRefs=true)", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "web", "expandRefs": true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		resource, references, _ := strings.Cut(text, "# Referenced objects\n")
+		s.Run("returns the resource", func() {
+			s.Contains(resource, "kind: Pod")
+			s.Contains(resource, "name: web\n")
+		})
+		s.Run("returns the referenced service account", func() {
+			s.Contains(references, "kind: ServiceAccount")
+		})
+		s.Run("returns the configmaps referenced by volumes and envFrom", func() {
+			s.Contains(references, "name: web-config")
+			s.Contains(references, "app.properties: a=b")
+			s.Contains(references, "name: web-env")
+		})
+		s.Run("returns the referenced secrets with redacted values", func() {
+			s.Contains(references, "# Secret values are redacted, keys: password, username\n")
+			s.Contains(references, "name: web-credentials")
+			s.NotContains(references, "czNjcjN0")
+		})
+		s.Run("reports the references that can't be retrieved", func() {
+			s.Contains(references, "# Referenced object couldn't be retrieved: PersistentVolumeClaim web-data: not found\n")
+			s.Contains(references, "# Referenced object couldn't be retrieved: Secret registry: not found\n")
+		})
+	})
+	s.Run("resources_get(kind=Pod, expandRefs=true, output=table)", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "web", "expandRefs": true, "output": "table",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`(?m)^ns-1\s+v1\s+Pod\s+web\s+Running`, text)
+		s.Contains(text, "# Referenced objects\n---\napiVersion: v1\nkind: ServiceAccount")
+	})
+	s.Run("resources_get(kind=Pod, expandRefs=true) without references", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "plain", "expandRefs": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Referenced objects\n# The resource doesn't reference any object\n")
+	})
+	s.Run("resources_get(kind=Node, expandRefs=true) for an unsupported kind", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Node", "name": "node-1", "expandRefs": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Referenced objects are not expanded for Node resources\n")
+	})
+	s.Run("resources_get(kind=Pod) without expandRefs", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "web",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "# Referenced objects")
+	})
+}
+
+func (s *ResourcesReferencesSuite) TestResourcesGetExpandRefsSecretsEnabled() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.InitMcpClient()
+	s.Run("resources_get(kind=Pod, expandRefs=true) returns the secret values", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "web", "expandRefs": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "password: czNjcjN0")
+		s.NotContains(text, "# Secret values are redacted")
+	})
+}
+
+func (s *ResourcesReferencesSuite) TestResourcesGetExpandRefsDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("resources_get(kind=Pod, expandRefs=true) reports denied references", func() {
+		toolResult, err := s.CallTool("resources_get", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "ns-1", "name": "web", "expandRefs": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`# Referenced object couldn't be retrieved: Secret web-credentials: .*resource not allowed: /v1, Kind=Secret`, text)
+		s.NotContains(text, "czNjcjN0")
+		s.Contains(text, "name: web-config")
+	})
+}
+
+func TestResourcesReferences(t *testing.T) {
+	suite.Run(t, new(ResourcesReferencesSuite))
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourcesRolloutHistorySuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesRolloutHistorySuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[1].APIResources = append(discoveryHandler.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	replicaSet := func(name, revision, owner, containers string) string {
+		return `{"metadata":{"name":"` + name + `","namespace":"ns-1","creationTimestamp":"2026-01-0` + revision + `T10:00:00Z",` +
+			`"annotations":{"deployment.kubernetes.io/revision":"` + revision + `"},` +
+			`"ownerReferences":[{"apiVersion":"apps/v1","kind":"Deployment","name":"web","uid":"` + owner + `","controller":true}]},` +
+			`"spec":{"template":{"metadata":{"labels":{"app":"web","pod-template-hash":"` + name + `"}},"spec":{"containers":[` + containers + `]}}},` +
+			`"status":{"replicas":0,"readyReplicas":0}}`
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/ns-1/deployments/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns-1","uid":"uid-web",` +
+				`"annotations":{"deployment.kubernetes.io/revision":"3"}},"spec":{"revisionHistoryLimit":10,"selector":{"matchLabels":{"app":"web"}}}}`))
+		case "/apis/apps/v1/namespaces/ns-1/deployments/empty":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"empty","namespace":"ns-1","uid":"uid-empty"},` +
+				`"spec":{"selector":{"matchLabels":{"app":"empty"}}}}`))
+		case "/apis/apps/v1/namespaces/ns-1/replicasets":
+			if req.URL.Query().Get("labelSelector") != "app=web" {
+				_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"ReplicaSetList","items":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"ReplicaSetList","items":[` +
+				replicaSet("web-3", "3", "uid-web", `{"name":"app","image":"nginx:1.26","resources":{"requests":{"cpu":"200m","memory":"128Mi"}}},{"name":"sidecar","image":"envoy:1.30"}`) + `,` +
+				replicaSet("web-1", "1", "uid-web", `{"name":"app","image":"nginx:1.24","resources":{"requests":{"cpu":"100m","memory":"128Mi"}}}`) + `,` +
+				replicaSet("web-2", "2", "uid-web", `{"name":"app","image":"nginx:1.25","resources":{"requests":{"cpu":"100m","memory":"128Mi"}}}`) + `,` +
+				replicaSet("web-other", "4", "uid-other", `{"name":"app","image":"nginx:latest"}`) +
+				`]}`))
+		}
+	}))
+}
+
+func (s *ResourcesRolloutHistorySuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesRolloutHistorySuite) TestResourcesRolloutHistory() {
+	s.InitMcpClient()
+	s.Run("resources_rollout_history", func() {
+		toolResult, err := s.CallTool("resources_rollout_history", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns a summary", func() {
+			s.Contains(text, "# Deployment ns-1/web has 3 revision(s), current revision is 3 (YAML, oldest revision first)\n")
+		})
+		s.Run("returns the revisions of the owned replicasets sorted by revision", func() {
+			s.Regexp(`(?s)replicaSet: web-1\n.*replicaSet: web-2\n.*replicaSet: web-3\n`, text)
+			s.NotContains(text, "web-other")
+		})
+		s.Run("returns the images and resources of the revisions", func() {
+			s.Contains(text, "image: nginx:1.24\n")
+			s.Contains(text, "    requests:\n      cpu: 100m\n      memory: 128Mi\n")
+		})
+		s.Run("flags the current revision", func() {
+			s.Regexp(`current: true\n\s+readyReplicas: 0\n\s+replicaSet: web-3\n`, text)
+		})
+		s.Run("compares each revision with the previous one", func() {
+			s.Contains(text, "- 'container app image changed: nginx:1.24 -> nginx:1.25'\n")
+			s.Contains(text, "- 'container app image changed: nginx:1.25 -> nginx:1.26'\n")
+			s.Contains(text, "- 'container app requests changed: cpu=100m, memory=128Mi -> cpu=200m, memory=128Mi'\n")
+			s.Contains(text, "- container sidecar added (image envoy:1.30)\n")
+			s.NotContains(text, "Pod template labels or annotations changed")
+		})
+	})
+	s.Run("resources_rollout_history of a deployment without replicasets", func() {
+		toolResult, err := s.CallTool("resources_rollout_history", map[string]interface{}{"namespace": "ns-1", "name": "empty"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("# Deployment ns-1/empty has no ReplicaSets, no revisions found", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_rollout_history of a missing deployment returns error", func() {
+		toolResult, _ := s.CallTool("resources_rollout_history", map[string]interface{}{"namespace": "ns-1", "name": "missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get rollout history of deployment missing")
+	})
+}
+
+func (s *ResourcesRolloutHistorySuite) TestResourcesRolloutHistoryDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apps", version = "v1", kind = "ReplicaSet" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("resources_rollout_history (denied)", func() {
+		toolResult, err := s.CallTool("resources_rollout_history", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=ReplicaSet")
+		})
+	})
+}
+
+func TestResourcesRolloutHistory(t *testing.T) {
+	suite.Run(t, new(ResourcesRolloutHistorySuite))
+}
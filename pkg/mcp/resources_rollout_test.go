@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesRolloutSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	paused     bool
+	patches    []string
+}
+
+func (s *ResourcesRolloutSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.paused = false
+	s.patches = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path != "/apis/apps/v1/namespaces/ns-1/deployments/web" {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if req.Method == http.MethodPatch {
+			body, _ := io.ReadAll(req.Body)
+			s.patches = append(s.patches, string(body))
+			s.paused = strings.Contains(string(body), `"paused":true`)
+		}
+		paused := "false"
+		if s.paused {
+			paused = "true"
+		}
+		_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"ns-1"},` +
+			`"spec":{"paused":` + paused + `},"status":{"replicas":3,"updatedReplicas":2}}`))
+	}))
+}
+
+func (s *ResourcesRolloutSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesRolloutSuite) recordedPatches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.patches...)
+}
+
+func (s *ResourcesRolloutSuite) TestResourcesRolloutPauseResume() {
+	s.InitMcpClient()
+	s.Run("resources_rollout_pause", func() {
+		toolResult, err := s.CallTool("resources_rollout_pause", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("sets spec.paused", func() {
+			s.Equal([]string{`{"spec":{"paused":true}}`}, s.recordedPatches())
+		})
+		s.Run("returns the new paused state", func() {
+			s.Equal("# Deployment ns-1/web paused\nPaused: true\nReplicas: 3\nUpdatedReplicas: 2\n", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("resources_rollout_pause of a paused deployment doesn't change it", func() {
+		toolResult, err := s.CallTool("resources_rollout_pause", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Deployment ns-1/web was already paused, nothing changed\n")
+		s.Len(s.recordedPatches(), 1)
+	})
+	s.Run("resources_rollout_resume", func() {
+		toolResult, err := s.CallTool("resources_rollout_resume", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("unsets spec.paused", func() {
+			s.Equal(`{"spec":{"paused":false}}`, s.recordedPatches()[1])
+		})
+		s.Run("returns the new paused state", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "# Deployment ns-1/web resumed\n")
+			s.Contains(text, "Paused: false\n")
+		})
+	})
+	s.Run("resources_rollout_resume of a missing deployment returns error", func() {
+		toolResult, _ := s.CallTool("resources_rollout_resume", map[string]interface{}{"namespace": "ns-1", "name": "missing"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to resume rollout of deployment missing")
+	})
+}
+
+func (s *ResourcesRolloutSuite) TestResourcesRolloutPauseDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "apps", version = "v1", kind = "Deployment" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("resources_rollout_pause (denied)", func() {
+		toolResult, err := s.CallTool("resources_rollout_pause", map[string]interface{}{"namespace": "ns-1", "name": "web"})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: apps/v1, Kind=Deployment")
+		})
+		s.Run("doesn't patch the deployment", func() {
+			s.Empty(s.recordedPatches())
+		})
+	})
+}
+
+func TestResourcesRollout(t *testing.T) {
+	suite.Run(t, new(ResourcesRolloutSuite))
+}
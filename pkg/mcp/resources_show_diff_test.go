@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ResourcesShowDiffSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesShowDiffSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == http.MethodPatch:
+			body, _ := io.ReadAll(req.Body)
+			_, _ = w.Write(body)
+		case req.URL.Path == "/api/v1/namespaces/default/configmaps/existing":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"existing","namespace":"default",` +
+				`"resourceVersion":"1","managedFields":[{"manager":"kubectl"}]},"data":{"key":"previous-value"}}`))
+		case req.URL.Path == "/api/v1/namespaces/default/configmaps/new":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+		}
+	}))
+}
+
+func (s *ResourcesShowDiffSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesShowDiffSuite) TestResourcesCreateOrUpdateShowDiff() {
+	s.InitMcpClient()
+	s.Run("resources_create_or_update(showDiff=true) with existing resource", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: existing\n  namespace: default\ndata:\n  key: applied-value\n",
+			"showDiff": true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the success message", func() {
+			s.Contains(text, "# The following resources (YAML) have been created or updated successfully\n")
+		})
+		s.Run("returns the unified diff of the changes", func() {
+			s.Contains(text, "# The following unified diff shows the changes applied to the previous resources\n")
+			s.Contains(text, "--- previous/v1.ConfigMap.default.existing\n+++ applied/v1.ConfigMap.default.existing\n")
+			s.Contains(text, "-  key: previous-value\n+  key: applied-value\n")
+			s.NotContains(text, "managedFields")
+		})
+	})
+	s.Run("resources_create_or_update(showDiff=true) with new resource notes its creation", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: new\n  namespace: default\n",
+			"showDiff": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# v1.ConfigMap.default.new didn't exist and has been created\n")
+	})
+	s.Run("resources_create_or_update without showDiff doesn't return the diff", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: existing\n  namespace: default\ndata:\n  key: applied-value\n",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "unified diff")
+	})
+}
+
+func TestResourcesShowDiff(t *testing.T) {
+	suite.Run(t, new(ResourcesShowDiffSuite))
+}
@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+	"k8s.io/klog/v2"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/mcplog"
+)
+
+// resourceSubscriptions keeps track of the watches backing the resources/subscribe requests.
+// A watch runs with the credentials of its subscribers, it's shared by all the sessions subscribed to the same URI
+// with the same identity and it's stopped once the last of them unsubscribes or disconnects.
+type resourceSubscriptions struct {
+	mu      sync.Mutex
+	watches map[resourceWatchKey]*resourceWatch
+	// sessions that have (or had) a subscription, their disconnection releases their subscriptions
+	sessions map[*mcp.ServerSession]bool
+	closed   bool
+}
+
+// resourceWatchKey identifies a watch by the subscribed URI and the identity (derived client cache key) it runs with
+type resourceWatchKey struct {
+	uri      string
+	identity string
+}
+
+type resourceWatch struct {
+	cancel   context.CancelFunc
+	sessions map[*mcp.ServerSession]bool
+}
+
+func newResourceSubscriptions() *resourceSubscriptions {
+	return &resourceSubscriptions{
+		watches:  make(map[resourceWatchKey]*resourceWatch),
+		sessions: make(map[*mcp.ServerSession]bool),
+	}
+}
+
+// add subscribes the session to the URI with the identity.
+// If the URI isn't watched yet for the identity, a watch slot is reserved with acquire (an error is returned if none
+// is available) and watch is started in its own goroutine with a context derived from ctx that is cancelled once there
+// are no subscribers left. The slot is released when watch returns.
+// If watch fails (or returns while there are still subscribers), the subscription is cleared and its sessions are notified so that they can subscribe again.
+func (r *resourceSubscriptions) add(ctx context.Context, key resourceWatchKey, session *mcp.ServerSession, acquire func() (func(), error), watch func(ctx context.Context) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	w, ok := r.watches[key]
+	if !ok {
+		release, err := acquire()
+		if err != nil {
+			return err
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		w = &resourceWatch{cancel: cancel, sessions: make(map[*mcp.ServerSession]bool)}
+		r.watches[key] = w
+		go func() {
+			defer release()
+			err := watch(watchCtx)
+			if watchCtx.Err() != nil {
+				// Stopped because there are no subscribers left
+				return
+			}
+			if err == nil {
+				err = errors.New("watch closed by the server")
+			}
+			klog.FromContext(watchCtx).Error(err, "failed to watch subscribed resource", "uri", key.uri)
+			for _, subscriber := range r.release(key, w) {
+				mcplog.SendMCPLog(context.WithValue(watchCtx, mcplog.MCPSessionContextKey, subscriber), mcplog.LevelWarning,
+					fmt.Sprintf("The subscription to %s was cancelled, subscribe again to keep receiving its updates: %v", key.uri, err))
+			}
+		}()
+	}
+	w.sessions[session] = true
+	if session != nil && !r.sessions[session] {
+		r.sessions[session] = true
+		go func() {
+			_ = session.Wait()
+			r.removeSession(session)
+		}()
+	}
+	return nil
+}
+
+// remove unsubscribes the session from the URI (whichever identity it was subscribed with)
+func (r *resourceSubscriptions) remove(uri string, session *mcp.ServerSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, w := range r.watches {
+		if key.uri == uri {
+			r.unsubscribe(key, w, session)
+		}
+	}
+}
+
+// removeSession unsubscribes the (disconnected) session from all the URIs
+func (r *resourceSubscriptions) removeSession(session *mcp.ServerSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, session)
+	for key, w := range r.watches {
+		r.unsubscribe(key, w, session)
+	}
+}
+
+// unsubscribe removes the session from the watch and stops it if there are no subscribers left, r.mu must be held
+func (r *resourceSubscriptions) unsubscribe(key resourceWatchKey, w *resourceWatch, session *mcp.ServerSession) {
+	delete(w.sessions, session)
+	if len(w.sessions) == 0 {
+		w.cancel()
+		delete(r.watches, key)
+	}
+}
+
+// release forgets the (failed) watch so that the next subscription to the URI starts a new one,
+// it returns the sessions that were subscribed to it
+func (r *resourceSubscriptions) release(key resourceWatchKey, w *resourceWatch) []*mcp.ServerSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w.cancel()
+	if r.watches[key] != w {
+		return nil
+	}
+	delete(r.watches, key)
+	sessions := make([]*mcp.ServerSession, 0, len(w.sessions))
+	for session := range w.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// watched returns true if the URI is currently being watched (with any identity)
+func (r *resourceSubscriptions) watched(uri string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.watches {
+		if key.uri == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// close stops all the watches, subsequent subscriptions are ignored
+func (r *resourceSubscriptions) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for key, w := range r.watches {
+		w.cancel()
+		delete(r.watches, key)
+	}
+}
+
+// subscribeResource handles the resources/subscribe requests.
+// The resource is read first so that the subscription fails if it doesn't exist or the user can't access it,
+// the watch runs with the credentials of the subscriber and is only shared with the sessions of the same identity.
+func (s *Server) subscribeResource(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	rt, ok := s.subscribableResourceTemplate(uri)
+	if !ok {
+		return fmt.Errorf("resource %s doesn't support subscriptions", uri)
+	}
+	if _, err := readResourceTemplate(ctx, s, rt, uri); err != nil {
+		return err
+	}
+	k, err := s.p.GetDerivedKubernetes(ctx, s.p.GetDefaultTarget())
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+	// The watch outlives the request, keep the request values (credentials, logger) but not its cancellation
+	return s.subscriptions.add(context.WithoutCancel(ctx), resourceWatchKey{uri: uri, identity: k.Identity()}, req.Session, k.AcquireWatch, func(watchCtx context.Context) error {
+		return rt.KubernetesWatcher(api.ResourceTemplateHandlerParams{
+			Context:          watchCtx,
+			BaseConfig:       s.configuration.Load(),
+			KubernetesClient: k,
+			URI:              uri,
+		}, func() {
+			if err := s.server.ResourceUpdated(watchCtx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+				klog.FromContext(watchCtx).V(3).Info("failed to notify resource update", "uri", uri, "error", err)
+			}
+		})
+	})
+}
+
+// unsubscribeResource handles the resources/unsubscribe requests
+func (s *Server) unsubscribeResource(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	s.subscriptions.remove(req.Params.URI, req.Session)
+	return nil
+}
+
+// subscribableResourceTemplate returns the enabled resource template with a watcher that matches the URI
+func (s *Server) subscribableResourceTemplate(uri string) (api.ServerResourceTemplate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rt := range s.subscribableResourceTemplates {
+		if template, err := uritemplate.New(rt.ResourceTemplate.URITemplate); err == nil && template.Regexp().MatchString(uri) {
+			return rt, true
+		}
+	}
+	return api.ServerResourceTemplate{}, false
+}
+
+// subscribableResourceTemplates returns the resource templates that provide a watcher
+func subscribableResourceTemplates(templates []api.ServerResourceTemplate) []api.ServerResourceTemplate {
+	ret := make([]api.ServerResourceTemplate, 0)
+	for _, rt := range templates {
+		if rt.KubernetesWatcher != nil {
+			ret = append(ret, rt)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceSubscriptionsSuite struct {
+	suite.Suite
+	subscriptions *resourceSubscriptions
+}
+
+func (s *ResourceSubscriptionsSuite) SetupTest() {
+	s.subscriptions = newResourceSubscriptions()
+}
+
+func (s *ResourceSubscriptionsSuite) TearDownTest() {
+	s.subscriptions.close()
+}
+
+// session returns a session that is already tracked so that its disconnection isn't awaited
+func (s *ResourceSubscriptionsSuite) session() *mcp.ServerSession {
+	session := &mcp.ServerSession{}
+	s.subscriptions.sessions[session] = true
+	return session
+}
+
+func noopAcquire() (func(), error) { return func() {}, nil }
+
+// blockingWatch returns a watch that runs until its context is cancelled, the context is sent to started
+func blockingWatch(started chan<- context.Context) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		started <- ctx
+		<-ctx.Done()
+		return nil
+	}
+}
+
+func (s *ResourceSubscriptionsSuite) TestIdentities() {
+	alice, bob, carol := s.session(), s.session(), s.session()
+	aliceStarted, bobStarted := make(chan context.Context, 1), make(chan context.Context, 1)
+	aliceKey := resourceWatchKey{uri: "k8s://nodes/node-1", identity: "alice"}
+	bobKey := resourceWatchKey{uri: "k8s://nodes/node-1", identity: "bob"}
+	s.Require().NoError(s.subscriptions.add(s.T().Context(), aliceKey, alice, noopAcquire, blockingWatch(aliceStarted)))
+	s.Require().NoError(s.subscriptions.add(s.T().Context(), bobKey, bob, noopAcquire, blockingWatch(bobStarted)))
+	aliceCtx, bobCtx := <-aliceStarted, <-bobStarted
+	s.Run("starts a watch per identity", func() {
+		s.Len(s.subscriptions.watches, 2)
+	})
+	s.Run("shares the watch with the sessions of the same identity", func() {
+		s.Require().NoError(s.subscriptions.add(s.T().Context(), aliceKey, carol, noopAcquire, blockingWatch(aliceStarted)))
+		s.Len(s.subscriptions.watches, 2)
+		s.Len(s.subscriptions.watches[aliceKey].sessions, 2)
+	})
+	s.Run("unsubscribing a session doesn't stop the watch of another identity", func() {
+		s.subscriptions.remove("k8s://nodes/node-1", bob)
+		s.Eventually(func() bool { return bobCtx.Err() != nil }, time.Second, 10*time.Millisecond)
+		s.NoError(aliceCtx.Err())
+		s.True(s.subscriptions.watched("k8s://nodes/node-1"))
+	})
+	s.Run("disconnecting the last session of an identity stops its watch", func() {
+		s.subscriptions.removeSession(alice)
+		s.NoError(aliceCtx.Err())
+		s.subscriptions.removeSession(carol)
+		s.Eventually(func() bool { return aliceCtx.Err() != nil }, time.Second, 10*time.Millisecond)
+		s.False(s.subscriptions.watched("k8s://nodes/node-1"))
+	})
+}
+
+func (s *ResourceSubscriptionsSuite) TestWatchFailure() {
+	key := resourceWatchKey{uri: "k8s://nodes/node-1", identity: "alice"}
+	released := make(chan struct{})
+	acquire := func() (func(), error) { return func() { close(released) }, nil }
+	s.Require().NoError(s.subscriptions.add(s.T().Context(), key, nil, acquire, func(ctx context.Context) error {
+		return errors.New("forbidden")
+	}))
+	s.Run("releases the watch slot", func() {
+		s.Eventually(func() bool {
+			select {
+			case <-released:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+	})
+	s.Run("clears the stale subscription", func() {
+		s.Eventually(func() bool { return !s.subscriptions.watched("k8s://nodes/node-1") }, time.Second, 10*time.Millisecond)
+	})
+	s.Run("subscribing again starts a new watch", func() {
+		started := make(chan context.Context, 1)
+		s.Require().NoError(s.subscriptions.add(s.T().Context(), key, nil, noopAcquire, blockingWatch(started)))
+		ctx := <-started
+		s.NoError(ctx.Err())
+		s.True(s.subscriptions.watched("k8s://nodes/node-1"))
+	})
+}
+
+func (s *ResourceSubscriptionsSuite) TestWatchClosed() {
+	key := resourceWatchKey{uri: "k8s://nodes/node-1"}
+	s.Require().NoError(s.subscriptions.add(s.T().Context(), key, nil, noopAcquire, func(ctx context.Context) error {
+		return nil
+	}))
+	s.Run("clears the subscription of a watch that returned while subscribed", func() {
+		s.Eventually(func() bool { return !s.subscriptions.watched("k8s://nodes/node-1") }, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestResourceSubscriptions(t *testing.T) {
+	suite.Run(t, new(ResourceSubscriptionsSuite))
+}
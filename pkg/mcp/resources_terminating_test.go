@@ -0,0 +1,289 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ResourcesTerminatingSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	patches    []string
+}
+
+func (s *ResourcesTerminatingSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.patches = nil
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "patch"}})
+	s.mockServer.Handle(discoveryHandler)
+	anHourAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	justNow := time.Now().UTC().Format(time.RFC3339)
+	stuckNamespace := `{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"stuck","deletionTimestamp":"` + anHourAgo + `"},` +
+		`"spec":{"finalizers":["kubernetes"]},"status":{"phase":"Terminating","conditions":[` +
+		`{"type":"NamespaceContentRemaining","status":"True","message":"Some resources are remaining: pods. has 1 resource instances"},` +
+		`{"type":"NamespaceDeletionDiscoveryFailure","status":"False","message":"All resources successfully discovered"}]}}`
+	stuckPod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"stuck-pod","namespace":"stuck","resourceVersion":"42","deletionTimestamp":"` + anHourAgo + `",` +
+		`"finalizers":["example.com/cleanup","example.com/other"]}}`
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"NamespaceList","items":[` + stuckNamespace + `,` +
+				`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"default"}},` +
+				`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"just-deleted","deletionTimestamp":"` + justNow + `"}}]}`))
+		case "/api/v1/namespaces/stuck":
+			_, _ = w.Write([]byte(stuckNamespace))
+		case "/api/v1/namespaces/stuck/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` + stuckPod + `,` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"running-pod","namespace":"stuck"}},` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"just-deleted-pod","namespace":"stuck","deletionTimestamp":"` + justNow + `","finalizers":["example.com/cleanup"]}}]}`))
+		case "/api/v1/namespaces/stuck/configmaps":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMapList","items":[` +
+				`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"stuck-configmap","namespace":"stuck","deletionTimestamp":"` + anHourAgo + `","finalizers":["example.com/cleanup"]}}]}`))
+		case "/api/v1/namespaces/stuck/pods/running-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"running-pod","namespace":"stuck"}}`))
+		case "/api/v1/namespaces/stuck/pods/last-finalizer-pod":
+			// The resource pending deletion is deleted as soon as its last finalizer is removed
+			if req.Method == http.MethodPatch {
+				body, _ := io.ReadAll(req.Body)
+				s.patches = append(s.patches, string(body))
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"last-finalizer-pod","namespace":"stuck","resourceVersion":"43","deletionTimestamp":"` + anHourAgo + `"}}`))
+				return
+			}
+			if len(s.patches) > 0 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404,"message":"pods \"last-finalizer-pod\" not found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"last-finalizer-pod","namespace":"stuck","resourceVersion":"42","deletionTimestamp":"` + anHourAgo + `",` +
+				`"finalizers":["example.com/cleanup"]}}`))
+		case "/api/v1/namespaces/stuck/pods/stuck-pod":
+			if req.Method == http.MethodPatch {
+				body, _ := io.ReadAll(req.Body)
+				s.patches = append(s.patches, string(body))
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"stuck-pod","namespace":"stuck","resourceVersion":"43","deletionTimestamp":"` + anHourAgo + `",` +
+					`"finalizers":["example.com/other"]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(stuckPod))
+		}
+	}))
+}
+
+func (s *ResourcesTerminatingSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesTerminating() {
+	s.InitMcpClient()
+	s.Run("resources_terminating() lists stuck namespaces", func() {
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns namespace stuck for longer than the threshold", func() {
+			s.Contains(text, "name: stuck\n")
+			s.Contains(text, "- spec:kubernetes")
+			s.Contains(text, "NamespaceContentRemaining: Some resources are remaining")
+			s.Contains(text, "terminatingFor: 60m")
+		})
+		s.Run("omits false conditions", func() {
+			s.NotContains(text, "NamespaceDeletionDiscoveryFailure")
+		})
+		s.Run("omits namespaces terminating for less than the threshold", func() {
+			s.NotContains(text, "just-deleted")
+		})
+	})
+	s.Run("resources_terminating(namespace=stuck) scans the namespace resources", func() {
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "stuck"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "kind: Namespace")
+		s.Contains(text, "name: stuck-pod")
+		s.Contains(text, "- example.com/other")
+		s.Contains(text, "name: stuck-configmap")
+		s.NotContains(text, "running-pod")
+		s.NotContains(text, "just-deleted-pod")
+	})
+	s.Run("resources_terminating(namespace=stuck, threshold=0s) includes recently deleted resources", func() {
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "stuck", "threshold": "0s"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: just-deleted-pod")
+	})
+	s.Run("resources_terminating(namespace=default) reports nothing stuck", func() {
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "default"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("No resources stuck pending deletion were found", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_terminating(threshold=invalid) returns error", func() {
+		toolResult, _ := s.CallTool("resources_terminating", map[string]interface{}{"threshold": "invalid"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid threshold 'invalid'")
+	})
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesTerminatingDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "ConfigMap" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "stuck"})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	s.Run("skips denied resources", func() {
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "stuck-configmap")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: stuck-pod")
+	})
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesTerminatingScanKinds() {
+	s.Run("scan_kinds restricts the scanned kinds", func() {
+		s.Require().NoError(toml.Unmarshal([]byte(`
+			scan_kinds = [ "Pod", "Certificate.cert-manager.io" ]
+		`), s.Cfg), "Expected to parse scan kinds config")
+		s.InitMcpClient()
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "stuck"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: stuck-pod")
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "stuck-configmap")
+	})
+	s.Run("scan_kinds=all scans every namespaced kind", func() {
+		s.Require().NoError(toml.Unmarshal([]byte(`
+			scan_kinds = [ "all" ]
+		`), s.Cfg), "Expected to parse scan kinds config")
+		s.InitMcpClient()
+		toolResult, err := s.CallTool("resources_terminating", map[string]interface{}{"namespace": "stuck"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: stuck-pod")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "name: stuck-configmap")
+	})
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesRemoveFinalizers() {
+	s.InitMcpClient()
+	s.Run("resources_remove_finalizers without confirmation returns error", func() {
+		toolResult, _ := s.CallTool("resources_remove_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "stuck-pod", "confirm": false,
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "requires explicit confirmation (confirm=true)")
+		s.Empty(s.patches)
+	})
+	s.Run("resources_remove_finalizers with unknown finalizer returns error", func() {
+		toolResult, _ := s.CallTool("resources_remove_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "stuck-pod", "confirm": true,
+			"finalizers": []interface{}{"example.com/unknown"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "Pod stuck-pod has none of the finalizers to remove")
+		s.Empty(s.patches)
+	})
+	s.Run("resources_remove_finalizers(finalizers=[example.com/cleanup], confirm=true)", func() {
+		toolResult, err := s.CallTool("resources_remove_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "stuck-pod", "confirm": true,
+			"finalizers": []interface{}{"example.com/cleanup"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("patches only the selected finalizers with the observed resourceVersion", func() {
+			s.Require().Len(s.patches, 1)
+			var patch map[string]any
+			s.Require().NoError(json.Unmarshal([]byte(s.patches[0]), &patch))
+			s.Equal(map[string]any{"metadata": map[string]any{"finalizers": []any{"example.com/other"}, "resourceVersion": "42"}}, patch)
+		})
+		s.Run("returns removed finalizers", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Finalizers [example.com/cleanup] removed")
+		})
+	})
+	s.Run("resources_remove_finalizers of the last finalizer of a resource pending deletion", func() {
+		s.patches = nil
+		toolResult, err := s.CallTool("resources_remove_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "last-finalizer-pod", "confirm": true,
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("reports the resource as deleted", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text,
+				"# Finalizers [example.com/cleanup] removed, the resource was pending deletion and has been deleted")
+		})
+	})
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesFinalizers() {
+	s.InitMcpClient()
+	s.Run("resources_finalizers(name=stuck-pod)", func() {
+		toolResult, err := s.CallTool("resources_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "stuck-pod",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the finalizers", func() {
+			s.Contains(text, "# The finalizers of Pod stuck-pod (YAML) are below")
+			s.Contains(text, "finalizers:\n- example.com/cleanup\n- example.com/other\n")
+		})
+		s.Run("returns the deletion status with a warning", func() {
+			s.Contains(text, "terminatingFor: 60m")
+			s.Contains(text, "# WARNING: the resource is pending deletion and blocked by its finalizers")
+		})
+		s.Run("doesn't patch the resource", func() {
+			s.Empty(s.patches)
+		})
+	})
+	s.Run("resources_finalizers(name=running-pod) without finalizers", func() {
+		toolResult, err := s.CallTool("resources_finalizers", map[string]interface{}{
+			"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "running-pod",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("Pod running-pod has no finalizers", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ResourcesTerminatingSuite) TestResourcesFinalizersDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, _ := s.CallTool("resources_finalizers", map[string]interface{}{
+		"apiVersion": "v1", "kind": "Pod", "namespace": "stuck", "name": "stuck-pod",
+	})
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Pod")
+	s.Empty(s.patches)
+}
+
+func TestResourcesTerminating(t *testing.T) {
+	suite.Run(t, new(ResourcesTerminatingSuite))
+}
@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
@@ -20,6 +21,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
 )
 
 type ResourcesSuite struct {
@@ -650,6 +653,169 @@ func (s *ResourcesSuite) TestResourcesCreateOrUpdateForcesSSA() {
 	})
 }
 
+func (s *ResourcesSuite) TestResourcesCreateOrUpdateNamespaceDefaulting() {
+	s.InitMcpClient()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	s.Run("resources_create_or_update with namespaced resource without namespace", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-without-namespace\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("notes the default namespace", func() {
+			s.Truef(strings.HasPrefix(toolResult.Content[0].(*mcp.TextContent).Text, "# Namespaced resources without metadata.namespace have been created or updated in namespace default\n"),
+				"Expected namespace note, got %v", toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+		s.Run("creates ConfigMap in default namespace", func() {
+			_, getErr := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-cm-without-namespace", metav1.GetOptions{})
+			s.NoError(getErr, "ConfigMap not found in default namespace")
+		})
+	})
+	s.Run("resources_create_or_update(namespace=ns-1) with namespaced resource without namespace", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-without-namespace-in-ns-1\n",
+			"namespace": "ns-1",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("notes the provided namespace", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "created or updated in namespace ns-1\n")
+		})
+		s.Run("creates ConfigMap in provided namespace", func() {
+			_, getErr := kc.CoreV1().ConfigMaps("ns-1").Get(s.T().Context(), "a-cm-without-namespace-in-ns-1", metav1.GetOptions{})
+			s.NoError(getErr, "ConfigMap not found in ns-1 namespace")
+		})
+	})
+	s.Run("resources_create_or_update(namespace=ns-1) with cluster-scoped resource", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource":  "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a-namespace-created-or-updated\n",
+			"namespace": "ns-1",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("doesn't add namespace note", func() {
+			s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "without metadata.namespace")
+		})
+		s.Run("creates Namespace without namespace", func() {
+			ns, getErr := kc.CoreV1().Namespaces().Get(s.T().Context(), "a-namespace-created-or-updated", metav1.GetOptions{})
+			s.Require().NoError(getErr, "Namespace not found")
+			s.Empty(ns.Namespace)
+		})
+	})
+}
+
+func (s *ResourcesSuite) TestResourcesCreateOrUpdateWithOwnerRef() {
+	s.InitMcpClient()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	owner, err := kc.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "an-owner-configmap"},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create owner ConfigMap")
+	ownedYaml := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: an-owned-configmap\n  namespace: default\n"
+
+	s.Run("resources_create_or_update with ownerRef missing name returns error", func() {
+		toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": ownedYaml,
+			"ownerRef": map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to create or update resources, missing argument ownerRef.name", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_create_or_update with not found owner returns error", func() {
+		toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": ownedYaml,
+			"ownerRef": map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "not-found"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get owner ConfigMap not-found")
+	})
+	s.Run("resources_create_or_update with owner in another namespace returns error", func() {
+		toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: an-owned-configmap\n  namespace: ns-1\n",
+			"ownerRef": map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "an-owner-configmap"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "failed to get owner ConfigMap an-owner-configmap")
+	})
+	s.Run("resources_create_or_update with mismatched uid returns error", func() {
+		toolResult, _ := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": ownedYaml,
+			"ownerRef": map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "an-owner-configmap", "uid": "not-the-uid"},
+		})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "uid mismatch")
+	})
+	s.Run("resources_create_or_update with ownerRef resolves uid", func() {
+		toolResult, err := s.CallTool("resources_create_or_update", map[string]interface{}{
+			"resource": ownedYaml,
+			"ownerRef": map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "an-owner-configmap"},
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("sets owner reference", func() {
+			owned, getErr := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "an-owned-configmap", metav1.GetOptions{})
+			s.Require().NoError(getErr, "failed to get owned ConfigMap")
+			s.Require().Len(owned.OwnerReferences, 1)
+			s.Equal("ConfigMap", owned.OwnerReferences[0].Kind)
+			s.Equal("an-owner-configmap", owned.OwnerReferences[0].Name)
+			s.Equal(owner.UID, owned.OwnerReferences[0].UID)
+		})
+	})
+}
+
+func (s *ResourcesSuite) TestResourcesDiff() {
+	s.InitMcpClient()
+	kc := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_, err := kc.CoreV1().ConfigMaps("default").Create(s.T().Context(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-cm-to-diff"},
+		Data:       map[string]string{"key": "live-value"},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create ConfigMap")
+
+	s.Run("resources_diff with missing resource returns error", func() {
+		toolResult, _ := s.CallTool("resources_diff", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to diff resources: resource parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_diff with changed resource", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-to-diff\ndata:\n  key: desired-value\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		s.Run("returns unified diff", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Contains(text, "--- live/v1.ConfigMap.default.a-cm-to-diff\n+++ desired/v1.ConfigMap.default.a-cm-to-diff\n")
+			s.Contains(text, "-  key: live-value\n+  key: desired-value\n")
+			s.NotContains(text, "managedFields")
+		})
+		s.Run("doesn't modify live resource", func() {
+			cm, _ := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-cm-to-diff", metav1.GetOptions{})
+			s.Equal("live-value", cm.Data["key"])
+		})
+	})
+	s.Run("resources_diff with new resource", func() {
+		toolResult, err := s.CallTool("resources_diff", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a-cm-not-yet-created\n  namespace: default\n",
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "--- /dev/null\n")
+		_, getErr := kc.CoreV1().ConfigMaps("default").Get(s.T().Context(), "a-cm-not-yet-created", metav1.GetOptions{})
+		s.Error(getErr, "ConfigMap should not be created")
+	})
+}
+
 func (s *ResourcesSuite) TestResourcesCreateOrUpdateDenied() {
 	s.Require().NoError(toml.Unmarshal([]byte(`
 		denied_resources = [
@@ -1074,3 +1240,105 @@ func (s *ResourcesSuite) TestResourcesScaleDenied() {
 func TestResources(t *testing.T) {
 	suite.Run(t, new(ResourcesSuite))
 }
+
+type ResourcesTableFallbackSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ResourcesTableFallbackSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.Cfg.ListOutput = "table"
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}, metav1.APIResourceList{
+		GroupVersion: "apiextensions.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition", Verbs: metav1.Verbs{"get", "list"}},
+		},
+	}))
+}
+
+func (s *ResourcesTableFallbackSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesTableFallbackSuite) TestResourcesListAsTableWithServerWithoutTableSupport() {
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/apis/example.com/v1/namespaces/default/widgets" {
+			// Ignores the Table media type and returns a regular list
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"WidgetList","items":[` +
+				`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"a-widget","namespace":"default"}}` +
+				`]}`))
+		}
+	}))
+	s.InitMcpClient()
+	s.Run("resources_list(apiVersion=example.com/v1, kind=Widget) (list_output=table)", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"namespace":  "default",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("formats the list client-side", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Regexpf("NAME\\s+AGE", text, "expected client-side table headers in output:\n%s", text)
+			s.Regexpf("a-widget\\s+", text, "expected row for a-widget in output:\n%s", text)
+		})
+	})
+}
+
+func (s *ResourcesTableFallbackSuite) TestResourcesListAsTableWithCustomResourcePrinterColumns() {
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/apis/apiextensions.k8s.io/v1/customresourcedefinitions/widgets.example.com":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition",` +
+				`"metadata":{"name":"widgets.example.com"},"spec":{"group":"example.com","versions":[{"name":"v1",` +
+				`"additionalPrinterColumns":[` +
+				`{"name":"Color","type":"string","jsonPath":".spec.color"},` +
+				`{"name":"Replicas","type":"integer","jsonPath":".spec.replicas"}` +
+				`]}]}}`))
+		case "/apis/example.com/v1/namespaces/default/widgets":
+			// Ignores the Table media type and returns a regular list
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"example.com/v1","kind":"WidgetList","items":[` +
+				`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"a-widget","namespace":"default"},` +
+				`"spec":{"color":"blue","replicas":3}}` +
+				`]}`))
+		}
+	}))
+	s.InitMcpClient()
+	s.Run("resources_list(apiVersion=example.com/v1, kind=Widget) (list_output=table)", func() {
+		toolResult, err := s.CallTool("resources_list", map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"namespace":  "default",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("formats the list with the CRD additionalPrinterColumns", func() {
+			text := toolResult.Content[0].(*mcp.TextContent).Text
+			s.Regexpf("NAME\\s+COLOR\\s+REPLICAS", text, "expected printer column headers in output:\n%s", text)
+			s.Regexpf("a-widget\\s+blue\\s+3", text, "expected printer column values for a-widget in output:\n%s", text)
+		})
+	})
+}
+
+func TestResourcesTableFallback(t *testing.T) {
+	suite.Run(t, new(ResourcesTableFallbackSuite))
+}
@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	openapi_v2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type ResourcesValidateSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+	mu         sync.Mutex
+	patches    []string
+}
+
+func (s *ResourcesValidateSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.patches = nil
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	openAPISchema := resourcesValidateOpenAPISchema(s.T())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/openapi/v2" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(openAPISchema)
+			return
+		}
+		if req.Method != http.MethodPatch {
+			return
+		}
+		s.mu.Lock()
+		s.patches = append(s.patches, req.URL.Path+"?"+req.URL.RawQuery)
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/pods/a-valid-pod":
+			w.Header().Add("Warning", `299 - "spec.containers[0].image: the latest tag is discouraged"`)
+			body, _ := io.ReadAll(req.Body)
+			_, _ = w.Write(body)
+		case "/api/v1/namespaces/default/pods/a-denied-pod":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"BadRequest","code":400,` +
+				`"message":"admission webhook \"policy.example.com\" denied the request: the team label is required"}`))
+		}
+	}))
+}
+
+func (s *ResourcesValidateSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesValidateSuite) TestResourcesValidate() {
+	s.InitMcpClient()
+	s.Run("resources_validate with missing resource returns error", func() {
+		toolResult, _ := s.CallTool("resources_validate", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to validate resources: resource parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_validate with valid resource", func() {
+		toolResult, err := s.CallTool("resources_validate", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: a-valid-pod\nspec:\n  containers:\n  - name: app\n    image: nginx:latest\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the resources as valid", func() {
+			s.True(strings.HasPrefix(text, "# The provided resources are valid"), "unexpected report: %s", text)
+			s.Contains(text, "Valid: true")
+			s.Contains(text, "Resource: v1.Pod.default.a-valid-pod")
+		})
+		s.Run("reports the warnings of the API server", func() {
+			s.Contains(text, "spec.containers[0].image: the latest tag is discouraged")
+		})
+		s.Run("performs a server-side apply dry-run", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Require().Len(s.patches, 1)
+			s.Contains(s.patches[0], "dryRun=All")
+		})
+	})
+	s.Run("resources_validate with invalid resources", func() {
+		s.mu.Lock()
+		s.patches = nil
+		s.mu.Unlock()
+		toolResult, err := s.CallTool("resources_validate", map[string]interface{}{
+			"resource": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: a-pod-with-schema-errors\nspec:\n  containerz: []\n  containers:\n  - image: nginx\n" +
+				"---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: a-denied-pod\nspec:\n  containers:\n  - name: app\n    image: nginx\n" +
+				"---\napiVersion: v1\nkind: Pod\nspec: {}\n" +
+				"---\napiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: an-unknown-resource\n" +
+				"---\napiVersion: v1\nkind: Pod\nmetadata: [\n",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the resources as invalid", func() {
+			s.True(strings.HasPrefix(text, "# The provided resources have errors"), "unexpected report: %s", text)
+			s.Contains(text, "Valid: false")
+		})
+		s.Run("reports the OpenAPI schema errors", func() {
+			s.Contains(text, `unknown field "containerz"`)
+			s.Contains(text, `missing required field "name"`)
+		})
+		s.Run("reports the admission errors of the dry-run", func() {
+			s.Contains(text, "dry-run: admission webhook")
+			s.Contains(text, `denied the request: the team`)
+		})
+		s.Run("reports the missing name", func() {
+			s.Contains(text, "Resource: document 3")
+			s.Contains(text, "manifest: metadata.name is required")
+		})
+		s.Run("reports the kinds not served by the cluster", func() {
+			s.Contains(text, "manifest: example.com/v1 Unknown is not served by the cluster")
+		})
+		s.Run("reports the documents that can't be parsed", func() {
+			s.Contains(text, "Resource: document 5")
+			s.Contains(text, "manifest: failed to parse the document")
+		})
+		s.Run("only performs the dry-run of the resources passing the client-side checks", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Require().Len(s.patches, 1)
+			s.Contains(s.patches[0], "/api/v1/namespaces/default/pods/a-denied-pod?")
+			s.Contains(s.patches[0], "dryRun=All")
+		})
+	})
+}
+
+// resourcesValidateOpenAPISchema returns a minimal OpenAPI v2 document for Pods where the containers require a name
+func resourcesValidateOpenAPISchema(t *testing.T) []byte {
+	stringSchema := &openapi_v2.Schema{Type: &openapi_v2.TypeItem{Value: []string{"string"}}}
+	doc := &openapi_v2.Document{
+		Swagger: "2.0",
+		Info:    &openapi_v2.Info{Title: "Test", Version: "v1"},
+		Definitions: &openapi_v2.Definitions{AdditionalProperties: []*openapi_v2.NamedSchema{
+			{Name: "io.k8s.api.core.v1.Container", Value: &openapi_v2.Schema{
+				Type:     &openapi_v2.TypeItem{Value: []string{"object"}},
+				Required: []string{"name"},
+				Properties: &openapi_v2.Properties{AdditionalProperties: []*openapi_v2.NamedSchema{
+					{Name: "name", Value: stringSchema},
+					{Name: "image", Value: stringSchema},
+				}},
+			}},
+			{Name: "io.k8s.api.core.v1.Pod", Value: &openapi_v2.Schema{
+				Type: &openapi_v2.TypeItem{Value: []string{"object"}},
+				Properties: &openapi_v2.Properties{AdditionalProperties: []*openapi_v2.NamedSchema{
+					{Name: "apiVersion", Value: stringSchema},
+					{Name: "kind", Value: stringSchema},
+					{Name: "metadata", Value: &openapi_v2.Schema{Type: &openapi_v2.TypeItem{Value: []string{"object"}}}},
+					{Name: "spec", Value: &openapi_v2.Schema{
+						Type: &openapi_v2.TypeItem{Value: []string{"object"}},
+						Properties: &openapi_v2.Properties{AdditionalProperties: []*openapi_v2.NamedSchema{
+							{Name: "containers", Value: &openapi_v2.Schema{
+								Type:  &openapi_v2.TypeItem{Value: []string{"array"}},
+								Items: &openapi_v2.ItemsItem{Schema: []*openapi_v2.Schema{{XRef: "#/definitions/io.k8s.api.core.v1.Container"}}},
+							}},
+						}},
+					}},
+				}},
+				VendorExtension: []*openapi_v2.NamedAny{{
+					Name:  "x-kubernetes-group-version-kind",
+					Value: &openapi_v2.Any{Yaml: "- group: \"\"\n  version: v1\n  kind: Pod\n"},
+				}},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal OpenAPI v2 document: %v", err)
+	}
+	return data
+}
+
+func TestResourcesValidate(t *testing.T) {
+	suite.Run(t, new(ResourcesValidateSuite))
+}
@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourcesWaitSuite struct {
+	BaseMcpSuite
+	mockServer          *test.MockServer
+	mu                  sync.Mutex
+	watchTimeoutSeconds []string
+}
+
+func (s *ResourcesWaitSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		pod := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-running-pod","namespace":"default","resourceVersion":"1"},` +
+			`"status":{"phase":"Running","conditions":[{"type":"Ready","status":"True"}]}}`
+		switch {
+		case req.URL.Path == "/api/v1/namespaces/default/pods/a-running-pod":
+			_, _ = w.Write([]byte(pod))
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("watch") == "true" &&
+			req.URL.Query().Get("fieldSelector") == "metadata.name=a-pending-pod":
+			s.mu.Lock()
+			s.watchTimeoutSeconds = append(s.watchTimeoutSeconds, req.URL.Query().Get("timeoutSeconds"))
+			s.mu.Unlock()
+			// The Pod starts running after the initial list
+			_, _ = w.Write([]byte(`{"type":"MODIFIED","object":{"apiVersion":"v1","kind":"Pod",` +
+				`"metadata":{"name":"a-pending-pod","namespace":"default","resourceVersion":"2"},"status":{"phase":"Running"}}}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("watch") == "true":
+			// Keep the watch open until the client gives up
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("fieldSelector") == "metadata.name=a-running-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"1"},"items":[` + pod + `]}`))
+		case req.URL.Path == "/api/v1/namespaces/default/pods" && req.URL.Query().Get("fieldSelector") == "metadata.name=a-pending-pod":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"1"},"items":[` +
+				`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"a-pending-pod","namespace":"default","resourceVersion":"1"},"status":{"phase":"Pending"}}]}`))
+		case req.URL.Path == "/api/v1/namespaces/default/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"1"},"items":[]}`))
+		case req.URL.Path == "/api/v1/namespaces/default/pods/not-found":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Status","status":"Failure","reason":"NotFound","code":404,"message":"pods \"not-found\" not found"}`))
+		}
+	}))
+}
+
+func (s *ResourcesWaitSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ResourcesWaitSuite) TestResourcesWait() {
+	s.InitMcpClient()
+	s.Run("resources_wait with missing for returns error", func() {
+		toolResult, _ := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to wait for resource: for parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_wait with invalid for returns error", func() {
+		toolResult, _ := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod", "for": "ready"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid wait expression ready")
+	})
+	s.Run("resources_wait with invalid timeout returns error", func() {
+		toolResult, _ := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod", "for": "delete", "timeout": "soon"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid timeout 'soon'")
+	})
+	s.Run("resources_wait(for=condition=Ready) with satisfied condition", func() {
+		toolResult, err := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod", "for": "condition=Ready"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# The resource satisfies condition=Ready")
+	})
+	s.Run("resources_wait(for=jsonpath={.status.phase}=Running) with satisfied jsonpath", func() {
+		toolResult, err := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod", "for": "jsonpath={.status.phase}=Running"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "phase: Running")
+	})
+	s.Run("resources_wait(for=jsonpath={.status.phase}=Running) with jsonpath satisfied after watch event", func() {
+		toolResult, err := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-pending-pod", "for": "jsonpath={.status.phase}=Running", "timeout": "10s"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resourceVersion: \"2\"")
+		s.Run("bounds the watch server-side with the remaining timeout", func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.Require().NotEmpty(s.watchTimeoutSeconds)
+			s.Contains([]string{"9", "10"}, s.watchTimeoutSeconds[0])
+		})
+	})
+	s.Run("resources_wait(for=jsonpath={.status.phase}=Succeeded, timeout=1s) times out", func() {
+		toolResult, _ := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-running-pod", "for": "jsonpath={.status.phase}=Succeeded", "timeout": "1s"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to wait for jsonpath={.status.phase}=Succeeded: timed out after 1s waiting for Pod a-running-pod", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("resources_wait(for=condition=Ready) with not found resource returns error", func() {
+		toolResult, _ := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "not-found", "for": "condition=Ready"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "pods \"not-found\" not found")
+	})
+	s.Run("resources_wait(for=delete) with already deleted resource", func() {
+		toolResult, err := s.CallTool("resources_wait", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "not-found", "for": "delete"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Equal("Pod not-found has been deleted", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *ResourcesWaitSuite) TestResourcesWaitProgress() {
+	s.InitMcpClient()
+	capture := s.StartCapturingNotifications()
+	toolResult, err := s.CallToolWithProgressToken("resources_wait",
+		map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "a-pending-pod", "for": "jsonpath={.status.phase}=Running", "timeout": "10s"},
+		"wait-progress")
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("sends progress notification", func() {
+		notification := capture.RequireNotification(s.T(), 2*time.Second, "notifications/progress")
+		params, ok := notification.Params.(*mcp.ProgressNotificationParams)
+		s.Require().Truef(ok, "unexpected notification params %T", notification.Params)
+		s.Equal("wait-progress", params.ProgressToken)
+		s.Equal(float64(10), params.Total)
+		s.Equal("Waiting for Pod a-pending-pod", params.Message)
+	})
+}
+
+func TestResourcesWait(t *testing.T) {
+	suite.Run(t, new(ResourcesWaitSuite))
+}
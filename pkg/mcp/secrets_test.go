@@ -0,0 +1,292 @@
+package mcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type SecretsSuite struct {
+	BaseMcpSuite
+}
+
+func (s *SecretsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	client := kubernetes.NewForConfigOrDie(envTestRestConfig)
+	_ = client.CoreV1().Secrets("default").Delete(s.T().Context(), "a-secret-to-decode", metav1.DeleteOptions{})
+	_, err := client.CoreV1().Secrets("default").Create(s.T().Context(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-secret-to-decode"},
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"binary":   {0xff, 0xfe, 0xfd},
+		},
+	}, metav1.CreateOptions{})
+	s.Require().NoError(err, "failed to create secret")
+}
+
+func (s *SecretsSuite) enableSecretsGet() {
+	enableSecretsGet(&s.BaseMcpSuite)
+}
+
+func enableSecretsGet(s *BaseMcpSuite) {
+	// toolset_configs requires the two-phase parsing performed by config.ReadToml,
+	// so we replace s.Cfg and restore the runtime fields the suite already set.
+	kubeConfig := s.Cfg.KubeConfig
+	listOutput := s.Cfg.ListOutput
+	cfg, err := config.ReadToml([]byte(`
+		[toolset_configs.core]
+		secrets_get_enabled = true
+	`))
+	s.Require().NoError(err, "failed to parse core toolset config")
+	s.Cfg = cfg
+	s.Cfg.KubeConfig = kubeConfig
+	s.Cfg.ListOutput = listOutput
+}
+
+func (s *SecretsSuite) TestSecretsGetDisabledByDefault() {
+	s.InitMcpClient()
+	s.Run("secrets_get(name=a-secret-to-decode) with default configuration", func() {
+		toolResult, err := s.CallTool("secrets_get", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-secret-to-decode",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("explains the tool is disabled", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "secrets_get is disabled")
+		})
+		s.Run("does not leak secret values", func() {
+			s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "admin")
+		})
+	})
+}
+
+func (s *SecretsSuite) TestSecretsGet() {
+	s.enableSecretsGet()
+	s.InitMcpClient()
+	s.Run("secrets_get with missing name", func() {
+		toolResult, _ := s.CallTool("secrets_get", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get secret: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("secrets_get with not found name", func() {
+		toolResult, _ := s.CallTool("secrets_get", map[string]interface{}{"namespace": "default", "name": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "secrets \"not-found\" not found")
+	})
+	s.Run("secrets_get(name=a-secret-to-decode)", func() {
+		toolResult, err := s.CallTool("secrets_get", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-secret-to-decode",
+		})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("returns decoded values", func() {
+			s.YAMLEq(""+
+				"name: a-secret-to-decode\n"+
+				"namespace: default\n"+
+				"type: Opaque\n"+
+				"data:\n"+
+				"  username: admin\n"+
+				"binaryData:\n"+
+				"  binary: //79\n",
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+}
+
+func (s *SecretsSuite) TestSecretsGetDenied() {
+	s.enableSecretsGet()
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("secrets_get (denied)", func() {
+		toolResult, err := s.CallTool("secrets_get", map[string]interface{}{
+			"namespace": "default",
+			"name":      "a-secret-to-decode",
+		})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Secret")
+		})
+	})
+}
+
+func TestSecrets(t *testing.T) {
+	suite.Run(t, new(SecretsSuite))
+}
+
+type SecretsTLSCertificatesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *SecretsTLSCertificatesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	secrets := map[string]string{
+		"/api/v1/namespaces/default/secrets/a-valid-tls-secret":     tlsSecretJSON(s.T(), "a-valid-tls-secret", "valid.example.com", time.Now().Add(365*24*time.Hour)),
+		"/api/v1/namespaces/default/secrets/an-expiring-tls-secret": tlsSecretJSON(s.T(), "an-expiring-tls-secret", "expiring.example.com", time.Now().Add(10*24*time.Hour)),
+		"/api/v1/namespaces/default/secrets/an-expired-tls-secret":  tlsSecretJSON(s.T(), "an-expired-tls-secret", "expired.example.com", time.Now().Add(-48*time.Hour)),
+		"/api/v1/namespaces/default/secrets/an-opaque-secret": `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"an-opaque-secret","namespace":"default"},` +
+			`"type":"Opaque","data":{"username":"YWRtaW4="}}`,
+	}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if secret, ok := secrets[req.URL.Path]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(secret))
+		}
+	}))
+}
+
+func (s *SecretsTLSCertificatesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *SecretsTLSCertificatesSuite) TestSecretsTLSCertificatesDisabledByDefault() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "a-valid-tls-secret"})
+	s.Nilf(err, "call tool should not return error object")
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "secrets_tls_certificates is disabled")
+}
+
+func (s *SecretsTLSCertificatesSuite) TestSecretsTLSCertificates() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.InitMcpClient()
+	s.Run("secrets_tls_certificates with missing name", func() {
+		toolResult, _ := s.CallTool("secrets_tls_certificates", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get secret certificates: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("secrets_tls_certificates with invalid expiryWindow", func() {
+		toolResult, _ := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "a-valid-tls-secret", "expiryWindow": "30d"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "invalid expiryWindow '30d'")
+	})
+	s.Run("secrets_tls_certificates(name=an-opaque-secret) returns error", func() {
+		toolResult, _ := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "an-opaque-secret"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "secret an-opaque-secret is of type Opaque, expected kubernetes.io/tls")
+	})
+	s.Run("secrets_tls_certificates(name=a-valid-tls-secret)", func() {
+		toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "a-valid-tls-secret"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns certificate details", func() {
+			s.Contains(text, "key: tls.crt")
+			s.Contains(text, "subject: CN=valid.example.com,O=kubernetes-mcp-server")
+			s.Contains(text, "issuer: CN=valid.example.com,O=kubernetes-mcp-server")
+			s.Contains(text, "- valid.example.com\n")
+			s.Contains(text, "- 10.0.0.1\n")
+			s.Contains(text, "notAfter: ")
+		})
+		s.Run("doesn't warn", func() {
+			s.NotContains(text, "warning")
+		})
+		s.Run("doesn't return the private key", func() {
+			s.NotContains(text, "PRIVATE KEY")
+		})
+	})
+	s.Run("secrets_tls_certificates(name=an-expiring-tls-secret) flags certificate expiring within the default window", func() {
+		toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "an-expiring-tls-secret"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "warning: EXPIRING SOON, expires in 9d")
+	})
+	s.Run("secrets_tls_certificates(name=an-expiring-tls-secret, expiryWindow=168h) doesn't flag certificate", func() {
+		toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "an-expiring-tls-secret", "expiryWindow": "168h"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.NotContains(toolResult.Content[0].(*mcp.TextContent).Text, "warning")
+	})
+	s.Run("secrets_tls_certificates(name=an-expired-tls-secret) flags expired certificate", func() {
+		toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "an-expired-tls-secret"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "warning: EXPIRED 2d ago")
+	})
+}
+
+func (s *SecretsTLSCertificatesSuite) TestSecretsTLSCertificatesDenied() {
+	enableSecretsGet(&s.BaseMcpSuite)
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Secret" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("secrets_tls_certificates", map[string]interface{}{"name": "a-valid-tls-secret"})
+	s.Nilf(err, "call tool should not return error object")
+	s.Truef(toolResult.IsError, "call tool should fail")
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: /v1, Kind=Secret")
+}
+
+func TestSecretsTLSCertificates(t *testing.T) {
+	suite.Run(t, new(SecretsTLSCertificatesSuite))
+}
+
+// tlsSecretJSON returns a kubernetes.io/tls Secret with a self-signed certificate for host expiring at notAfter
+func tlsSecretJSON(t *testing.T, name, host string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host, Organization: []string{"kubernetes-mcp-server"}},
+		DNSNames:     []string{host},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"` + name + `","namespace":"default"},"type":"kubernetes.io/tls",` +
+		`"data":{"tls.crt":"` + base64.StdEncoding.EncodeToString(crt) + `","tls.key":"` + base64.StdEncoding.EncodeToString(pemKey) + `"}}`
+}
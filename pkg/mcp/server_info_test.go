@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerInfoSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ServerInfoSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.Cfg.Toolsets = []string{"core", "config"}
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/version" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"34","gitVersion":"v1.34.1","platform":"linux/amd64"}`))
+		}
+	}))
+}
+
+func (s *ServerInfoSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ServerInfoSuite) TestServerInfo() {
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("server_info", map[string]interface{}{})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("returns the server version", func() {
+		s.Contains(text, "Name: kubernetes-mcp-server")
+		s.Contains(text, "Version: 0.0.0")
+	})
+	s.Run("returns the Kubernetes version", func() {
+		s.Contains(text, "Version: v1.34.1")
+		s.Contains(text, "Platform: linux/amd64")
+	})
+	s.Run("returns the cluster is not OpenShift", func() {
+		s.Contains(text, "OpenShift: false")
+	})
+	s.Run("returns the enabled toolsets", func() {
+		s.Contains(text, "Toolsets:\n- core\n- config")
+	})
+}
+
+func (s *ServerInfoSuite) TestServerInfoInOpenShift() {
+	s.mockServer.Handle(test.NewInOpenShiftHandler())
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("server_info", map[string]interface{}{})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "OpenShift: true")
+}
+
+func TestServerInfo(t *testing.T) {
+	suite.Run(t, new(ServerInfoSuite))
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ServicesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *ServicesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "discovery.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "endpointslices", Kind: "EndpointSlice", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default/services/a-service":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"a-service","namespace":"default"},` +
+				`"spec":{"type":"ClusterIP","clusterIP":"10.0.0.1","selector":{"app":"a-service"},"ports":[{"name":"http","port":80,"targetPort":8080,"protocol":"TCP"}]}}`))
+		case "/api/v1/namespaces/default/services/a-service-without-ready-endpoints":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"a-service-without-ready-endpoints","namespace":"default"},` +
+				`"spec":{"type":"ClusterIP","clusterIP":"10.0.0.2","selector":{"app":"broken"},"ports":[{"port":80,"targetPort":8080,"protocol":"TCP"}]}}`))
+		case "/apis/discovery.k8s.io/v1/namespaces/default/endpointslices":
+			switch req.URL.Query().Get("labelSelector") {
+			case "kubernetes.io/service-name=a-service":
+				_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[{` +
+					`"metadata":{"name":"a-service-abc12","namespace":"default"},"addressType":"IPv4",` +
+					`"ports":[{"name":"http","port":8080,"protocol":"TCP"}],"endpoints":[` +
+					`{"addresses":["10.1.0.1"],"conditions":{"ready":true},"targetRef":{"kind":"Pod","name":"pod-1"},"nodeName":"node-1"},` +
+					`{"addresses":["10.1.0.2"],"conditions":{"ready":false,"terminating":true},"targetRef":{"kind":"Pod","name":"pod-2"}}` +
+					`]}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"apiVersion":"discovery.k8s.io/v1","kind":"EndpointSliceList","items":[{` +
+					`"metadata":{"name":"broken-abc12","namespace":"default"},"addressType":"IPv4","endpoints":[` +
+					`{"addresses":["10.1.0.3"],"conditions":{"ready":false},"targetRef":{"kind":"Pod","name":"pod-3"}}` +
+					`]}]}`))
+			}
+		}
+	}))
+}
+
+func (s *ServicesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *ServicesSuite) TestServiceEndpoints() {
+	s.InitMcpClient()
+	s.Run("service_endpoints with missing name returns error", func() {
+		toolResult, _ := s.CallTool("service_endpoints", map[string]interface{}{})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get service endpoints: name parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("service_endpoints(name=a-service)", func() {
+		toolResult, err := s.CallTool("service_endpoints", map[string]interface{}{"name": "a-service"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns selector and ports", func() {
+			s.Contains(text, "Selector:\n  app: a-service")
+			s.Contains(text, "TargetPort: \"8080\"")
+		})
+		s.Run("returns ready and not ready endpoints", func() {
+			s.Contains(text, "ReadyEndpoints: 1")
+			s.Contains(text, "NotReadyEndpoints: 1")
+			s.Contains(text, "TargetRef: Pod/pod-1")
+			s.Contains(text, "Terminating: true")
+			s.Contains(text, "- http 8080/TCP")
+		})
+		s.Run("doesn't warn", func() {
+			s.NotContains(text, "Warning")
+		})
+	})
+	s.Run("service_endpoints(name=a-service-without-ready-endpoints) warns about zero ready endpoints", func() {
+		toolResult, err := s.CallTool("service_endpoints", map[string]interface{}{"name": "a-service-without-ready-endpoints"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "Warning: Service has zero ready endpoints")
+	})
+}
+
+func TestServices(t *testing.T) {
+	suite.Run(t, new(ServicesSuite))
+}
@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// sseKeepAliveComment is an SSE comment line, ignored by the clients, written to keep the idle streams open
+const sseKeepAliveComment = ": keep-alive\n\n"
+
+// sseKeepAlive wraps the SSE handler so that a keep-alive comment is written every interval to the event streams
+// (GET requests) to prevent proxies and load balancers from dropping the idle connections.
+// A stream that can't be written (e.g. the client is gone or the connection was dropped) is considered dead: the
+// request context is cancelled, which closes the MCP session and releases its resources (e.g. subscriptions).
+func sseKeepAlive(next http.Handler, interval func() time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			next.ServeHTTP(w, req)
+			return
+		}
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		kw := &sseKeepAliveWriter{ResponseWriter: w}
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			kw.run(ctx, interval(), cancel)
+		})
+		next.ServeHTTP(kw, req.WithContext(ctx))
+		// The response must not be written once the handler returns
+		cancel()
+		wg.Wait()
+	})
+}
+
+// sseKeepAliveWriter serializes the writes of the SSE handler and the keep-alive comments.
+// Each SSE event is written with a single Write followed by a flush, the comments can't be interleaved in an event.
+type sseKeepAliveWriter struct {
+	http.ResponseWriter
+	mu sync.Mutex
+	// started is true once the handler wrote the response headers and the first event (endpoint)
+	started bool
+}
+
+func (w *sseKeepAliveWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.started = true
+	return w.ResponseWriter.Write(b)
+}
+
+// FlushError is used by http.ResponseController to flush the handler writes
+func (w *sseKeepAliveWriter) FlushError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *sseKeepAliveWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// run writes a keep-alive comment every interval until ctx is done, dead is called if the stream can't be written
+func (w *sseKeepAliveWriter) run(ctx context.Context, interval time.Duration, dead func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.keepAlive(interval); err != nil {
+				klog.FromContext(ctx).V(2).Info("SSE stream can't be written, closing the session", "error", err)
+				dead()
+				return
+			}
+		}
+	}
+}
+
+func (w *sseKeepAliveWriter) keepAlive(timeout time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started {
+		return nil
+	}
+	rc := http.NewResponseController(w.ResponseWriter)
+	// A write to a dead connection must fail instead of blocking until the TCP timeout (not supported by all writers)
+	_ = rc.SetWriteDeadline(time.Now().Add(timeout))
+	defer func() { _ = rc.SetWriteDeadline(time.Time{}) }()
+	if _, err := io.WriteString(w.ResponseWriter, sseKeepAliveComment); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SseKeepAliveSuite struct {
+	suite.Suite
+}
+
+// streamRecorder is a ResponseWriter safe for concurrent reads, its connection is dropped after maxWrites (if set)
+type streamRecorder struct {
+	mu        sync.Mutex
+	header    http.Header
+	body      bytes.Buffer
+	writes    int
+	maxWrites int
+}
+
+func (r *streamRecorder) Header() http.Header {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.header == nil {
+		r.header = http.Header{}
+	}
+	return r.header
+}
+
+func (r *streamRecorder) WriteHeader(int) {}
+
+func (r *streamRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes++
+	if r.maxWrites > 0 && r.writes > r.maxWrites {
+		return 0, errors.New("broken pipe")
+	}
+	return r.body.Write(b)
+}
+
+func (r *streamRecorder) Flush() {}
+
+func (r *streamRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body.String()
+}
+
+// endpointHandler writes the endpoint event and waits until the request is done, like the SSE handler does
+var endpointHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	_, _ = w.Write([]byte("event: endpoint\ndata: /message?sessionid=1\n\n"))
+	_ = http.NewResponseController(w).Flush()
+	<-req.Context().Done()
+})
+
+func serveSseKeepAlive(w http.ResponseWriter, req *http.Request, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		sseKeepAlive(endpointHandler, func() time.Duration { return interval }).ServeHTTP(w, req)
+		close(done)
+	}()
+	return done
+}
+
+func (s *SseKeepAliveSuite) TestKeepAlive() {
+	s.Run("writes keep-alive comments after the handler events", func() {
+		recorder := &streamRecorder{}
+		ctx, cancel := context.WithCancel(s.T().Context())
+		done := serveSseKeepAlive(recorder, httptest.NewRequestWithContext(ctx, http.MethodGet, "/sse", nil), 10*time.Millisecond)
+		s.Eventually(func() bool {
+			return strings.Count(recorder.String(), sseKeepAliveComment) >= 2
+		}, time.Second, 10*time.Millisecond, "Expected keep-alive comments")
+		s.True(strings.HasPrefix(recorder.String(), "event: endpoint\n"), "Expected the handler event first")
+		cancel()
+		<-done
+		written := recorder.String()
+		time.Sleep(50 * time.Millisecond)
+		s.Equal(written, recorder.String(), "Expected no keep-alive comments once the handler returns")
+	})
+	s.Run("closes the stream when it can't be written", func() {
+		recorder := &streamRecorder{maxWrites: 1}
+		done := serveSseKeepAlive(recorder, httptest.NewRequestWithContext(s.T().Context(), http.MethodGet, "/sse", nil), 10*time.Millisecond)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			s.Fail("Expected the dead stream to be closed")
+		}
+	})
+	s.Run("doesn't wrap the message requests", func() {
+		sseKeepAlive(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, wrapped := w.(*sseKeepAliveWriter)
+			s.False(wrapped, "Expected the message requests not to be wrapped")
+		}), func() time.Duration { return time.Second }).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/message?sessionid=1", nil))
+	})
+}
+
+func TestSseKeepAlive(t *testing.T) {
+	suite.Run(t, new(SseKeepAliveSuite))
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type StorageOverviewSuite struct {
+	BaseMcpSuite
+	mockServer     *test.MockServer
+	storageClasses string
+}
+
+func (s *StorageOverviewSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.storageClasses = `{"metadata":{"name":"standard","annotations":{"storageclass.kubernetes.io/is-default-class":"true"}},` +
+		`"provisioner":"ebs.csi.aws.com","allowVolumeExpansion":true,"reclaimPolicy":"Retain"},` +
+		`{"metadata":{"name":"local"},"provisioner":"kubernetes.io/no-provisioner","volumeBindingMode":"WaitForFirstConsumer"}`
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler(metav1.APIResourceList{
+		GroupVersion: "storage.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "storageclasses", Kind: "StorageClass", Verbs: metav1.Verbs{"get", "list"}},
+		},
+	})
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/storage.k8s.io/v1/storageclasses":
+			_, _ = w.Write([]byte(`{"apiVersion":"storage.k8s.io/v1","kind":"StorageClassList","items":[` + s.storageClasses + `]}`))
+		case "/api/v1/persistentvolumeclaims", "/api/v1/namespaces/ns-1/persistentvolumeclaims":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PersistentVolumeClaimList","items":[` +
+				`{"metadata":{"name":"data","namespace":"ns-1"},"spec":{"storageClassName":"standard","volumeName":"pv-1","accessModes":["ReadWriteOnce"]},` +
+				`"status":{"phase":"Bound","capacity":{"storage":"10Gi"}}},` +
+				`{"metadata":{"name":"cache","namespace":"ns-1"},"spec":{"accessModes":["ReadWriteOnce"],"resources":{"requests":{"storage":"1Gi"}}},` +
+				`"status":{"phase":"Pending"}},` +
+				`{"metadata":{"name":"logs","namespace":"ns-1"},"spec":{"storageClassName":"fast","accessModes":["ReadWriteMany"]},` +
+				`"status":{"phase":"Pending"}}]}`))
+		case "/api/v1/namespaces/ns-1/events":
+			if !strings.Contains(req.URL.Query().Get("fieldSelector"), "involvedObject.name=cache") {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[` +
+				`{"metadata":{"name":"cache.1","namespace":"ns-1"},"type":"Warning","reason":"ProvisioningFailed",` +
+				`"message":"failed to provision volume: quota exceeded","lastTimestamp":"2024-01-01T00:00:00Z"}]}`))
+		}
+	}))
+}
+
+func (s *StorageOverviewSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *StorageOverviewSuite) TestStorageOverview() {
+	s.InitMcpClient()
+	s.Run("storage_overview()", func() {
+		toolResult, err := s.CallTool("storage_overview", map[string]interface{}{})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the StorageClasses", func() {
+			s.Contains(text, "# The following 2 StorageClasses were found (YAML format):")
+			s.Regexp(`AllowVolumeExpansion: true\s+Default: true\s+Name: standard\s+Provisioner: ebs.csi.aws.com\s+ReclaimPolicy: Retain\s+VolumeBindingMode: Immediate`, text)
+			s.Regexp(`AllowVolumeExpansion: false\s+Default: false\s+Name: local\s+Provisioner: kubernetes.io/no-provisioner\s+ReclaimPolicy: Delete\s+VolumeBindingMode: WaitForFirstConsumer`, text)
+		})
+		s.Run("returns the PersistentVolumeClaims with their binding status", func() {
+			s.Contains(text, "# The following 3 PersistentVolumeClaims were found, 2 of them not Bound and flagged with an Issue (YAML format):")
+			s.Regexp(`Capacity: 10Gi\s+Name: data\s+Namespace: ns-1\s+Status: Bound\s+StorageClass: standard\s+Volume: pv-1`, text)
+		})
+		s.Run("flags the Pending claims with the provisioner of the default StorageClass and the last warning", func() {
+			s.Regexp(`Issue: PVC not bound, the provisioner ebs.csi.aws.com of StorageClass standard hasn't\s+provisioned the volume`, text)
+			s.Contains(text, "LastWarning: 'ProvisioningFailed: failed to provision volume: quota exceeded'")
+			s.Contains(text, "Requested: 1Gi")
+		})
+		s.Run("flags the Pending claims with a missing StorageClass", func() {
+			s.Contains(text, "Issue: PVC not bound, StorageClass fast not found")
+		})
+	})
+	s.Run("storage_overview(namespace=ns-1) returns the claims of the namespace", func() {
+		toolResult, err := s.CallTool("storage_overview", map[string]interface{}{"namespace": "ns-1"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# The following 3 PersistentVolumeClaims were found")
+	})
+}
+
+func (s *StorageOverviewSuite) TestStorageOverviewNoDefaultStorageClass() {
+	s.storageClasses = ""
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("storage_overview", map[string]interface{}{})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("returns no StorageClasses", func() {
+		s.Contains(text, "# No StorageClasses found")
+	})
+	s.Run("flags the claims without storageClassName explaining there is no default StorageClass", func() {
+		s.Regexp(`Issue: PVC not bound, it has no storageClassName and there is no default StorageClass,\s+set a storageClassName or mark a StorageClass as default`, text)
+	})
+}
+
+func (s *StorageOverviewSuite) TestStorageOverviewDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { group = "storage.k8s.io", version = "v1" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	s.Run("storage_overview (denied)", func() {
+		toolResult, err := s.CallTool("storage_overview", map[string]interface{}{})
+		s.Run("has error", func() {
+			s.Nilf(err, "call tool should not return error object")
+			s.Truef(toolResult.IsError, "call tool should fail")
+		})
+		s.Run("describes denial", func() {
+			s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "resource not allowed: storage.k8s.io/v1, Kind=StorageClass")
+		})
+	})
+}
+
+func TestStorageOverview(t *testing.T) {
+	suite.Run(t, new(StorageOverviewSuite))
+}
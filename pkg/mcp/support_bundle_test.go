@@ -0,0 +1,186 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SupportBundleSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *SupportBundleSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "persistentvolumeclaims", Kind: "PersistentVolumeClaim", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "events", Kind: "Event", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	discoveryHandler.APIResourceLists[1].APIResources = append(discoveryHandler.APIResourceLists[1].APIResources,
+		metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "daemonsets", Kind: "DaemonSet", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/api/v1/namespaces/ns-1":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns-1"}}`))
+		case "/api/v1/namespaces/restricted":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"namespaces \"restricted\" is forbidden: User \"test\" cannot get resource \"namespaces\" in API group \"\" at the cluster scope"}`))
+		case "/api/v1/namespaces/not-found":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404,` +
+				`"message":"namespaces \"not-found\" not found"}`))
+		case "/api/v1/namespaces/restricted/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+		case "/api/v1/namespaces/ns-1/pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"healthy","namespace":"ns-1"},"spec":{"nodeName":"node-1","containers":[{"name":"app"}]},` +
+				`"status":{"phase":"Running","containerStatuses":[{"name":"app","ready":true,"restartCount":0,"state":{"running":{}}}]}},` +
+				`{"metadata":{"name":"crashing","namespace":"ns-1"},"spec":{"nodeName":"node-1","containers":[{"name":"app"},{"name":"sidecar"}]},` +
+				`"status":{"phase":"Running","containerStatuses":[` +
+				`{"name":"app","ready":false,"restartCount":7,"state":{"waiting":{"reason":"CrashLoopBackOff","message":"back-off restarting failed container"}}},` +
+				`{"name":"sidecar","ready":true,"restartCount":0,"state":{"running":{}}}]}}` +
+				`]}`))
+		case "/api/v1/namespaces/ns-1/pods/crashing/log":
+			w.Header().Set("Content-Type", "text/plain")
+			if req.URL.Query().Get("container") != "app" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if req.URL.Query().Get("previous") == "true" {
+				_, _ = w.Write([]byte("panic: database connection refused\n"))
+				return
+			}
+			_, _ = w.Write([]byte("starting app\n"))
+		case "/apis/apps/v1/namespaces/ns-1/deployments":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"DeploymentList","items":[` +
+				`{"metadata":{"name":"crashing","namespace":"ns-1"},"status":{"replicas":1,"readyReplicas":0,"unavailableReplicas":1}}` +
+				`]}`))
+		case "/apis/apps/v1/namespaces/ns-1/statefulsets":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"StatefulSetList","items":[]}`))
+		case "/apis/apps/v1/namespaces/ns-1/daemonsets":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"DaemonSetList","items":[]}`))
+		case "/api/v1/namespaces/ns-1/persistentvolumeclaims":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PersistentVolumeClaimList","items":[]}`))
+		case "/api/v1/namespaces/ns-1/events":
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"EventList","items":[]}`))
+		}
+	}))
+}
+
+func (s *SupportBundleSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *SupportBundleSuite) TestNamespaceSupportBundle() {
+	s.InitMcpClient()
+	s.Run("namespace_support_bundle(namespace=ns-1)", func() {
+		toolResult, err := s.CallTool("namespace_support_bundle", map[string]interface{}{"namespace": "ns-1"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("returns the pods with their statuses", func() {
+			s.Contains(text, "# Support Bundle for Namespace `ns-1`")
+			s.Regexp(`healthy\s+Running\s+1/1\s+0\s+node-1`, text)
+			s.Regexp(`crashing\s+Running\s+1/2\s+7\s+node-1`, text)
+			s.Contains(text, "- **ns-1/crashing** (Phase: Running, Ready: 1/2, Restarts: 7)\n  - Container waiting: CrashLoopBackOff")
+		})
+		s.Run("returns the workload statuses", func() {
+			s.Contains(text, "- **ns-1/crashing** (Ready: 0/1)\n  - 1 replicas unavailable")
+			s.Contains(text, "No StatefulSets found")
+			s.Contains(text, "No PVCs found")
+			s.Contains(text, "*No recent warning/error events*")
+		})
+		s.Run("returns the logs of the failing containers", func() {
+			s.Contains(text, "### crashing/app\n\n```\nstarting app\n```")
+			s.Contains(text, "### crashing/app (previous)\n\n```\npanic: database connection refused\n```")
+		})
+		s.Run("omits the logs of the healthy containers", func() {
+			s.NotContains(text, "### healthy/app")
+			s.NotContains(text, "### crashing/sidecar")
+		})
+	})
+	s.Run("namespace_support_bundle(namespace=not-found)", func() {
+		toolResult, _ := s.CallTool("namespace_support_bundle", map[string]interface{}{"namespace": "not-found"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `failed to collect support bundle for namespace not-found: namespaces "not-found" not found`)
+	})
+}
+
+func (s *SupportBundleSuite) TestNamespaceSupportBundleAttachment() {
+	s.Cfg.ToolOutputAttachmentThreshold = 512
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("namespace_support_bundle", map[string]interface{}{"namespace": "ns-1"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		s.Require().Len(toolResult.Content, 2)
+	})
+	s.Run("returns a summary inline", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Regexp(`^# The result \(\d+ bytes, \d+ lines\) exceeds the inline limit of 512 bytes, it is returned as the attached file support-bundle-ns-1.md \(text/markdown\)\n`, text)
+		s.Contains(text, "# Preview of the first lines:\n# Support Bundle for Namespace `ns-1`\n")
+		s.NotContains(text, "panic: database connection refused")
+	})
+	s.Run("returns the bundle as an embedded resource", func() {
+		resource, ok := toolResult.Content[1].(*mcp.EmbeddedResource)
+		s.Require().Truef(ok, "expected embedded resource, got %T", toolResult.Content[1])
+		s.Equal("attachment:///support-bundle-ns-1.md", resource.Resource.URI)
+		s.Equal("text/markdown", resource.Resource.MIMEType)
+		s.Contains(string(resource.Resource.Blob), "# Support Bundle for Namespace `ns-1`")
+		s.Contains(string(resource.Resource.Blob), "panic: database connection refused")
+	})
+}
+
+func (s *SupportBundleSuite) TestNamespaceSupportBundleDenied() {
+	s.Require().NoError(toml.Unmarshal([]byte(`
+		denied_resources = [ { version = "v1", kind = "Pod" } ]
+	`), s.Cfg), "Expected to parse denied resources config")
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("namespace_support_bundle", map[string]interface{}{"namespace": "ns-1"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("reports the denied section inline", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "## 1. Pods\n\n*Failed to collect:")
+		s.Contains(text, "resource not allowed: /v1, Kind=Pod")
+		s.Contains(text, "- **ns-1/crashing** (Ready: 0/1)")
+	})
+}
+
+func (s *SupportBundleSuite) TestNamespaceSupportBundleNamespaceScopedRBAC() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("namespace_support_bundle", map[string]interface{}{"namespace": "restricted"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	s.Run("collects the namespace that can't be read", func() {
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# Support Bundle for Namespace `restricted`")
+		s.Contains(text, "## 1. Pods\n\nNo pods found")
+	})
+}
+
+func TestSupportBundle(t *testing.T) {
+	suite.Run(t, new(SupportBundleSuite))
+}
@@ -46,3 +46,22 @@ func ShouldIncludeTargetListTool(targetName string, isMultiTarget bool) ToolFilt
 		return true
 	}
 }
+
+// CapabilityProbe reports whether the provided cluster capability is available
+type CapabilityProbe func(capability api.Capability) bool
+
+// HasRequiredCapabilities includes the tools only if all the cluster capabilities they require are available
+func HasRequiredCapabilities(probe CapabilityProbe) ToolFilter {
+	return func(tool api.ServerTool) bool {
+		return hasCapabilities(probe, tool.RequiredCapabilities)
+	}
+}
+
+func hasCapabilities(probe CapabilityProbe, capabilities []api.Capability) bool {
+	for _, capability := range capabilities {
+		if !probe(capability) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,12 +3,14 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
 )
 
 type ToolMutator func(tool api.ServerTool) api.ServerTool
@@ -134,7 +136,7 @@ func capitalizeFirst(s string) string {
 }
 
 // WithToolOverrides returns a mutator that applies per-tool configuration overrides
-// (such as custom descriptions) from the user's config file.
+// (such as custom descriptions or annotations) from the user's config file.
 func WithToolOverrides(overrides map[string]config.ToolOverride) ToolMutator {
 	return func(tool api.ServerTool) api.ServerTool {
 		if overrides == nil {
@@ -144,7 +146,28 @@ func WithToolOverrides(overrides map[string]config.ToolOverride) ToolMutator {
 			if o.Description != "" {
 				tool.Tool.Description = o.Description
 			}
+			if o.Title != "" {
+				tool.Tool.Annotations.Title = o.Title
+			}
+			if o.IdempotentHint != nil {
+				tool.Tool.Annotations.IdempotentHint = ptr.To(*o.IdempotentHint)
+			}
+			if o.OpenWorldHint != nil {
+				tool.Tool.Annotations.OpenWorldHint = ptr.To(*o.OpenWorldHint)
+			}
 		}
 		return tool
 	}
 }
+
+// unknownToolOverrides returns the sorted names of the overridden tools that aren't part of the provided tools
+func unknownToolOverrides(overrides map[string]config.ToolOverride, tools []api.ServerTool) []string {
+	unknown := make([]string, 0)
+	for name := range overrides {
+		if !slices.ContainsFunc(tools, func(tool api.ServerTool) bool { return tool.Tool.Name == name }) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -379,6 +379,53 @@ func (s *ToolOverridesMutatorSuite) TestMultipleToolsOverriddenIndependently() {
 	s.Equal("A test tool", otherResult.Tool.Description)
 }
 
+func (s *ToolOverridesMutatorSuite) TestOverridesAnnotations() {
+	overrides := map[string]config.ToolOverride{
+		"pods_list": {Title: "Custom title", IdempotentHint: ptr.To(false), OpenWorldHint: ptr.To(false)},
+	}
+	tm := WithToolOverrides(overrides)
+	tool := createTestTool("pods_list")
+	tool.Tool.Annotations = api.ToolAnnotations{
+		Title:          "Pods: List",
+		ReadOnlyHint:   ptr.To(true),
+		IdempotentHint: ptr.To(true),
+		OpenWorldHint:  ptr.To(true),
+	}
+	result := tm(tool)
+
+	s.Equal("Custom title", result.Tool.Annotations.Title)
+	s.Equal(ptr.To(false), result.Tool.Annotations.IdempotentHint)
+	s.Equal(ptr.To(false), result.Tool.Annotations.OpenWorldHint)
+	s.Equal(ptr.To(true), result.Tool.Annotations.ReadOnlyHint, "readOnlyHint should not be overridden")
+	s.Equal("A test tool", result.Tool.Description, "description should not change if not overridden")
+}
+
+func (s *ToolOverridesMutatorSuite) TestUnsetAnnotationsDoNotChangeExisting() {
+	overrides := map[string]config.ToolOverride{
+		"pods_list": {Description: "Custom description"},
+	}
+	tm := WithToolOverrides(overrides)
+	tool := createTestTool("pods_list")
+	tool.Tool.Annotations = api.ToolAnnotations{Title: "Pods: List", OpenWorldHint: ptr.To(true)}
+	result := tm(tool)
+
+	s.Equal("Pods: List", result.Tool.Annotations.Title)
+	s.Equal(ptr.To(true), result.Tool.Annotations.OpenWorldHint)
+	s.Nil(result.Tool.Annotations.IdempotentHint)
+}
+
+func (s *ToolOverridesMutatorSuite) TestUnknownToolOverrides() {
+	overrides := map[string]config.ToolOverride{
+		"pods_list":    {Description: "Custom description"},
+		"unknown_tool": {Description: "Custom description"},
+		"another_tool": {Title: "Custom title"},
+	}
+	tools := []api.ServerTool{createTestTool("pods_list"), createTestTool("resources_get")}
+
+	s.Equal([]string{"another_tool", "unknown_tool"}, unknownToolOverrides(overrides, tools))
+	s.Empty(unknownToolOverrides(nil, tools))
+}
+
 func TestToolOverridesMutator(t *testing.T) {
 	suite.Run(t, new(ToolOverridesMutatorSuite))
 }
@@ -88,15 +88,23 @@ func ServerToolToGoSdkTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolH
 			KubernetesClient: k,
 			ToolCallRequest:  toolCallRequest,
 			ListOutput:       cfg.ListOutput(),
+			DefaultOutput:    cfg.DefaultOutput(),
 			Elicitor:         &sessionElicitor{},
+			ProgressReporter: &sessionProgressReporter{session: request.Session, progressToken: request.Params.GetProgressToken()},
 		})
 		if err != nil {
 			return nil, err
 		}
 		if result.Error != nil {
+			result.Error = api.MissingDependencyError(result.Error, tool.Dependencies...)
 			mcplog.HandleK8sError(ctx, result.Error, tool.Tool.Name)
 		}
-		return NewStructuredResult(result.Content, result.StructuredContent, result.Error), nil
+		inline, attachment := result.SplitAttachment(cfg.ToolOutputAttachmentThreshold)
+		callToolResult := NewStructuredResult(inline, result.StructuredContent, result.Error)
+		if attachment != nil {
+			callToolResult.Content = append(callToolResult.Content, NewAttachmentContent(result.Attachment, attachment))
+		}
+		return callToolResult, nil
 	}
 	return goSdkTool, goSdkHandler, nil
 }
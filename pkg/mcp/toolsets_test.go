@@ -13,6 +13,7 @@ import (
 	configuration "github.com/containers/kubernetes-mcp-server/pkg/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/config"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/core"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/helm"
@@ -178,12 +179,15 @@ func (s *ToolsetsSuite) TestGranularToolsetsTools() {
 		&kiali.Toolset{},
 		&kubevirt.Toolset{},
 		&tekton.Toolset{},
+		&certmanager.Toolset{},
 	}
 	for _, testCase := range testCases {
 		s.Run("Toolset "+testCase.GetName(), func() {
 			toolsets.Clear()
 			toolsets.Register(testCase)
 			s.Cfg.Toolsets = []string{testCase.GetName()}
+			// Toolsets requiring cluster capabilities (e.g. kubevirt) need them to register their tools
+			s.Cfg.EnabledCapabilities = api.Capabilities()
 			s.InitMcpClient()
 			tools, err := s.ListTools()
 			s.Run("ListTools returns tools", func() {
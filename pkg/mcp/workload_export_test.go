@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+)
+
+type WorkloadExportSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *WorkloadExportSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	discoveryHandler := test.NewDiscoveryClientHandler()
+	discoveryHandler.APIResourceLists[0].APIResources = append(discoveryHandler.APIResourceLists[0].APIResources,
+		metav1.APIResource{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "serviceaccounts", Kind: "ServiceAccount", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+		metav1.APIResource{Name: "services", Kind: "Service", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}})
+	s.mockServer.Handle(discoveryHandler)
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.Contains(req.URL.Path, "/namespaces/source/") {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/v1/namespaces/source/services" {
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ServiceList","items":[` +
+				`{"metadata":{"name":"web","namespace":"source","uid":"3456","resourceVersion":"9"},` +
+				`"spec":{"type":"NodePort","clusterIP":"10.96.0.10","clusterIPs":["10.96.0.10"],"selector":{"app":"web"},` +
+				`"ports":[{"port":80,"targetPort":8080,"nodePort":30080}]}},` +
+				`{"metadata":{"name":"db","namespace":"source"},"spec":{"clusterIP":"None","selector":{"app":"db"},"ports":[{"port":5432}]}}` +
+				`]}`))
+			return
+		}
+		if object, ok := migrateWorkloadObjects[req.URL.Path]; ok {
+			_, _ = w.Write([]byte(object))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+	}))
+}
+
+func (s *WorkloadExportSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *WorkloadExportSuite) TestWorkloadExport() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("workload_export", map[string]interface{}{"namespace": "source", "kind": "Deployment", "name": "web"})
+	s.Run("no error", func() {
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	})
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Run("exports the dependencies, services and workload in order", func() {
+		s.Contains(text, "# The following 3 resources have been exported (multi-document YAML bundle, apply them in order):\n")
+		s.Regexp(`(?s)kind: ConfigMap\n.*name: web-config.*---\n.*kind: Service\n.*name: web.*---\n.*kind: Deployment\n`, text)
+	})
+	s.Run("only exports the services selecting the workload pods", func() {
+		s.NotContains(text, "name: db")
+	})
+	s.Run("cleans the manifests", func() {
+		s.NotContains(text, "uid:")
+		s.NotContains(text, "resourceVersion:")
+		s.NotContains(text, "readyReplicas")
+		s.NotContains(text, "10.96.0.10")
+		s.NotContains(text, "nodePort")
+	})
+	s.Run("removes the namespace", func() {
+		s.NotContains(text, "namespace:")
+	})
+	s.Run("omits the secrets", func() {
+		s.NotContains(text, "czNjcjN0")
+		s.Contains(text, "# The referenced Secret web-credentials has been omitted (includeSecrets is false), it must be created before applying the bundle\n")
+	})
+	s.Run("reports missing dependencies", func() {
+		s.Contains(text, "# Referenced dependency couldn't be exported: ServiceAccount web: not found\n")
+	})
+}
+
+func (s *WorkloadExportSuite) TestWorkloadExportTargetNamespace() {
+	s.InitMcpClient()
+	toolResult, err := s.CallTool("workload_export", map[string]interface{}{
+		"namespace": "source", "kind": "Deployment", "name": "web", "targetNamespace": "target",
+	})
+	s.Nilf(err, "call tool failed %v", err)
+	s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+	text := toolResult.Content[0].(*mcp.TextContent).Text
+	s.Equal(3, strings.Count(text, "namespace: target"))
+	s.NotContains(text, "namespace: source")
+}
+
+func (s *WorkloadExportSuite) TestWorkloadExportSecrets() {
+	s.Run("includeSecrets=true is rejected by default", func() {
+		s.InitMcpClient()
+		toolResult, err := s.CallTool("workload_export", map[string]interface{}{
+			"namespace": "source", "kind": "Deployment", "name": "web", "includeSecrets": true,
+		})
+		s.Nilf(err, "call tool should not return error object")
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "exporting Secrets is disabled")
+	})
+	s.Run("includeSecrets=true exports the secrets when secrets_get is enabled", func() {
+		enableSecretsGet(&s.BaseMcpSuite)
+		s.InitMcpClient()
+		toolResult, err := s.CallTool("workload_export", map[string]interface{}{
+			"namespace": "source", "kind": "Deployment", "name": "web", "includeSecrets": true,
+		})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed: %v", toolResult.Content)
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Contains(text, "# The following 4 resources have been exported")
+		s.Contains(text, "password: czNjcjN0")
+		s.NotContains(text, "has been omitted")
+	})
+}
+
+func TestWorkloadExport(t *testing.T) {
+	suite.Run(t, new(WorkloadExportSuite))
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+type WorkloadImagesSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *WorkloadImagesSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/default/deployments/web":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default","generation":2},` +
+				`"spec":{"selector":{"matchLabels":{"app":"web"}},"template":{"spec":{"containers":[{"name":"app","image":"quay.io/org/app:2.0"}]}}},` +
+				`"status":{"observedGeneration":2}}`))
+		case "/apis/apps/v1/namespaces/default/deployments/rolled-out":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"rolled-out","namespace":"default","generation":1},` +
+				`"spec":{"selector":{"matchLabels":{"app":"rolled-out"}},"template":{"spec":{"containers":[{"name":"app","image":"quay.io/org/app:2.0"}]}}},` +
+				`"status":{"observedGeneration":1}}`))
+		case "/apis/apps/v1/namespaces/default/deployments/unpinned":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"unpinned","namespace":"default"},` +
+				`"spec":{"selector":{"matchLabels":{"app":"unpinned"}},"template":{"spec":{"containers":[{"name":"app","image":"app:latest"}]}}}}`))
+		case "/api/v1/namespaces/default/pods":
+			if req.URL.Query().Get("labelSelector") == "app=rolled-out" {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+					`{"metadata":{"name":"rolled-out-1","namespace":"default"},"spec":{"containers":[{"name":"app","image":"quay.io/org/app:2.0"}]},` +
+					`"status":{"containerStatuses":[{"name":"app","imageID":"quay.io/org/app@sha256:new"}]}}` +
+					`]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"web-old","namespace":"default"},"spec":{"containers":[{"name":"app","image":"quay.io/org/app:1.0"}]},` +
+				`"status":{"containerStatuses":[{"name":"app","imageID":"quay.io/org/app@sha256:old"}]}},` +
+				`{"metadata":{"name":"web-new","namespace":"default"},"spec":{"containers":[{"name":"app","image":"quay.io/org/app:2.0"}]},` +
+				`"status":{"containerStatuses":[{"name":"app","imageID":"quay.io/org/app@sha256:new"}]}}` +
+				`]}`))
+		}
+	}))
+}
+
+func (s *WorkloadImagesSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *WorkloadImagesSuite) TestWorkloadImages() {
+	s.InitMcpClient()
+	s.Run("workload_images with unsupported kind returns error", func() {
+		toolResult, _ := s.CallTool("workload_images", map[string]interface{}{"kind": "Job", "name": "a-job"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "unsupported workload kind Job")
+	})
+	s.Run("workload_images(kind=Deployment, name=web) mid-rollout", func() {
+		toolResult, err := s.CallTool("workload_images", map[string]interface{}{"kind": "Deployment", "name": "web"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		text := toolResult.Content[0].(*mcp.TextContent).Text
+		s.Run("reports the rollout in progress", func() {
+			s.Contains(text, "# Deployment web rollout: in progress (1 of 2 Pods up to date) (YAML format):\n")
+			s.Contains(text, "RolledOut: false")
+		})
+		s.Run("reports the desired and running images", func() {
+			s.Contains(text, "Desired: quay.io/org/app:2.0")
+			s.Contains(text, "- 1 of 2 Pods run an image other than quay.io/org/app:2.0")
+			s.Contains(text, "Digest: sha256:old\n")
+			s.Contains(text, "Image: quay.io/org/app:1.0\n")
+		})
+	})
+	s.Run("workload_images(kind=Deployment, name=rolled-out)", func() {
+		toolResult, err := s.CallTool("workload_images", map[string]interface{}{"kind": "Deployment", "name": "rolled-out"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "# Deployment rolled-out rollout: complete (YAML format):\n")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "RolledOut: true")
+	})
+	s.Run("workload_images(checkUpdates=true) with registry lookups disabled returns error", func() {
+		toolResult, _ := s.CallTool("workload_images", map[string]interface{}{"kind": "Deployment", "name": "web", "checkUpdates": true})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get workload images: querying the image registries is disabled, registry lookups must be explicitly enabled by the server administrator (set image_registry_lookup_enabled = true in [toolset_configs.core])",
+			toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+}
+
+func (s *WorkloadImagesSuite) TestWorkloadImagesRegistryLookupEnabled() {
+	kubeConfig := s.Cfg.KubeConfig
+	cfg, err := config.ReadToml([]byte(`
+		[toolset_configs.core]
+		image_registry_lookup_enabled = true
+	`))
+	s.Require().NoError(err, "failed to parse core toolset config")
+	s.Cfg = cfg
+	s.Cfg.KubeConfig = kubeConfig
+	s.InitMcpClient()
+	s.Run("workload_images(checkUpdates=true) reports registry lookup errors per container", func() {
+		toolResult, err := s.CallTool("workload_images", map[string]interface{}{"kind": "Deployment", "name": "unpinned", "checkUpdates": true})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, `RegistryLookupError: tag "latest" is not a version, newer tags can't be determined`)
+	})
+}
+
+func TestWorkloadImages(t *testing.T) {
+	suite.Run(t, new(WorkloadImagesSuite))
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/internal/test"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkloadLogsSuite struct {
+	BaseMcpSuite
+	mockServer *test.MockServer
+}
+
+func (s *WorkloadLogsSuite) SetupTest() {
+	s.BaseMcpSuite.SetupTest()
+	s.mockServer = test.NewMockServer()
+	s.Cfg.KubeConfig = s.mockServer.KubeconfigFile(s.T())
+	s.mockServer.Handle(test.NewDiscoveryClientHandler())
+	s.mockServer.Handle(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/apps/v1/namespaces/default/deployments/a-deployment":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"a-deployment","namespace":"default"},` +
+				`"spec":{"selector":{"matchLabels":{"app":"a-deployment"}}}}`))
+		case "/apis/apps/v1/namespaces/default/deployments/no-pods":
+			_, _ = w.Write([]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"no-pods","namespace":"default"},` +
+				`"spec":{"selector":{"matchLabels":{"app":"no-pods"}}}}`))
+		case "/api/v1/namespaces/default/pods":
+			if req.URL.Query().Get("labelSelector") != "app=a-deployment" {
+				_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","items":[` +
+				`{"metadata":{"name":"pod-1","namespace":"default"},"spec":{"containers":[{"name":"app"},{"name":"sidecar"}]}},` +
+				`{"metadata":{"name":"pod-2","namespace":"default"},"spec":{"containers":[{"name":"app"}]}}` +
+				`]}`))
+		case "/api/v1/namespaces/default/pods/pod-1/log":
+			w.Header().Set("Content-Type", "text/plain")
+			if req.URL.Query().Get("container") == "sidecar" {
+				_, _ = w.Write([]byte("2025-01-01T00:00:02Z sidecar started\n"))
+				return
+			}
+			_, _ = w.Write([]byte("2025-01-01T00:00:01Z pod-1 first\n2025-01-01T00:00:04Z pod-1 last\n"))
+		case "/api/v1/namespaces/default/pods/pod-2/log":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("2025-01-01T00:00:03Z pod-2 only\n"))
+		}
+	}))
+}
+
+func (s *WorkloadLogsSuite) TearDownTest() {
+	s.BaseMcpSuite.TearDownTest()
+	if s.mockServer != nil {
+		s.mockServer.Close()
+	}
+}
+
+func (s *WorkloadLogsSuite) TestWorkloadLogs() {
+	s.InitMcpClient()
+	s.Run("workload_logs with missing kind returns error", func() {
+		toolResult, _ := s.CallTool("workload_logs", map[string]interface{}{"name": "a-deployment"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Equal("failed to get workload logs: kind parameter required", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("workload_logs with unsupported kind returns error", func() {
+		toolResult, _ := s.CallTool("workload_logs", map[string]interface{}{"kind": "Job", "name": "a-job"})
+		s.Truef(toolResult.IsError, "call tool should fail")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "unsupported workload kind Job")
+	})
+	s.Run("workload_logs(kind=Deployment, name=a-deployment)", func() {
+		toolResult, err := s.CallTool("workload_logs", map[string]interface{}{"kind": "Deployment", "name": "a-deployment"})
+		s.Run("no error", func() {
+			s.Nilf(err, "call tool failed %v", err)
+			s.Falsef(toolResult.IsError, "call tool failed")
+		})
+		s.Run("interleaves prefixed lines from all pods and containers by timestamp", func() {
+			s.Equal("[pod-1/app] 2025-01-01T00:00:01Z pod-1 first\n"+
+				"[pod-1/sidecar] 2025-01-01T00:00:02Z sidecar started\n"+
+				"[pod-2/app] 2025-01-01T00:00:03Z pod-2 only\n"+
+				"[pod-1/app] 2025-01-01T00:00:04Z pod-1 last\n",
+				toolResult.Content[0].(*mcp.TextContent).Text)
+		})
+	})
+	s.Run("workload_logs(kind=Deployment, name=a-deployment, container=sidecar)", func() {
+		toolResult, err := s.CallTool("workload_logs", map[string]interface{}{"kind": "Deployment", "name": "a-deployment", "container": "sidecar"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Equal("[pod-1/sidecar] 2025-01-01T00:00:02Z sidecar started\n", toolResult.Content[0].(*mcp.TextContent).Text)
+	})
+	s.Run("workload_logs(kind=Deployment, name=no-pods)", func() {
+		toolResult, err := s.CallTool("workload_logs", map[string]interface{}{"kind": "Deployment", "name": "no-pods"})
+		s.Nilf(err, "call tool failed %v", err)
+		s.Falsef(toolResult.IsError, "call tool failed")
+		s.Contains(toolResult.Content[0].(*mcp.TextContent).Text, "has no pods with logged messages yet")
+	})
+}
+
+func TestWorkloadLogs(t *testing.T) {
+	suite.Run(t, new(WorkloadLogsSuite))
+}
@@ -2,6 +2,8 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -14,6 +16,12 @@ var Yaml = &yaml{}
 
 var Table = &table{}
 
+var Json = &jsonOutput{}
+
+// NamesOnly prints just the (namespace/)name of the objects, one per line.
+// It's the minimal output to enumerate resources and isn't selectable as the default output of the server.
+var NamesOnly = &namesOnly{}
+
 // PrintResult holds both the text representation and optional structured data
 // extracted from a Kubernetes object.
 type PrintResult struct {
@@ -21,7 +29,7 @@ type PrintResult struct {
 	Text string
 	// Structured is an optional JSON-serializable value extracted from the object.
 	// For Table output, this is []map[string]any with column headers as keys.
-	// For YAML output, this is the cleaned-up object items as []map[string]any (lists)
+	// For YAML and JSON output, this is the cleaned-up object items as []map[string]any (lists)
 	// or a single map[string]any (individual objects).
 	Structured any
 }
@@ -40,6 +48,7 @@ type Output interface {
 var Outputs = []Output{
 	Yaml,
 	Table,
+	Json,
 }
 
 var Names []string
@@ -69,19 +78,91 @@ func (p *yaml) PrintObjStructured(obj runtime.Unstructured) (*PrintResult, error
 	if err != nil {
 		return nil, err
 	}
+	return &PrintResult{Text: text, Structured: structuredObject(obj)}, nil
+}
+
+type jsonOutput struct{}
+
+func (p *jsonOutput) GetName() string {
+	return "json"
+}
+func (p *jsonOutput) AsTable() bool {
+	return false
+}
+func (p *jsonOutput) PrintObj(obj runtime.Unstructured) (string, error) {
+	return MarshalJson(obj)
+}
+func (p *jsonOutput) PrintObjStructured(obj runtime.Unstructured) (*PrintResult, error) {
+	text, err := p.PrintObj(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &PrintResult{Text: text, Structured: structuredObject(obj)}, nil
+}
+
+// structuredObject returns the object items as []map[string]any (lists) or a single map[string]any (individual objects).
+// Returns an untyped nil for any other object so that the result can be safely checked against nil.
+func structuredObject(obj runtime.Unstructured) any {
 	switch t := obj.(type) {
 	case *unstructured.UnstructuredList:
 		items := make([]map[string]any, 0, len(t.Items))
 		for _, item := range t.Items {
 			items = append(items, item.DeepCopy().Object)
 		}
-		return &PrintResult{Text: text, Structured: items}, nil
+		return items
 	case *unstructured.Unstructured:
-		return &PrintResult{Text: text, Structured: t.DeepCopy().Object}, nil
+		return t.DeepCopy().Object
+	}
+	return nil
+}
+
+type namesOnly struct{}
+
+func (p *namesOnly) GetName() string {
+	return "name"
+}
+func (p *namesOnly) AsTable() bool {
+	return false
+}
+func (p *namesOnly) PrintObj(obj runtime.Unstructured) (string, error) {
+	names := objectNames(obj)
+	if len(names) == 0 {
+		return "", nil
+	}
+	return strings.Join(names, "\n") + "\n", nil
+}
+func (p *namesOnly) PrintObjStructured(obj runtime.Unstructured) (*PrintResult, error) {
+	text, err := p.PrintObj(obj)
+	if err != nil {
+		return nil, err
+	}
+	// Guard against typed nil leaking into the any interface (see table.PrintObjStructured)
+	if names := objectNames(obj); names != nil {
+		return &PrintResult{Text: text, Structured: names}, nil
 	}
 	return &PrintResult{Text: text}, nil
 }
 
+// objectNames returns the (namespace/)name of the object or of the list items
+func objectNames(obj runtime.Unstructured) []string {
+	var items []unstructured.Unstructured
+	switch t := obj.(type) {
+	case *unstructured.UnstructuredList:
+		items = t.Items
+	case *unstructured.Unstructured:
+		items = []unstructured.Unstructured{*t}
+	}
+	var names []string
+	for _, item := range items {
+		if item.GetNamespace() != "" {
+			names = append(names, item.GetNamespace()+"/"+item.GetName())
+		} else {
+			names = append(names, item.GetName())
+		}
+	}
+	return names
+}
+
 type table struct{}
 
 func (p *table) GetName() string {
@@ -176,6 +257,14 @@ func tableToStructured(t *metav1.Table) []map[string]any {
 }
 
 func MarshalYaml(v any) (string, error) {
+	ret, err := yml.Marshal(withoutManagedFields(v))
+	if err != nil {
+		return "", err
+	}
+	return string(ret), nil
+}
+
+func withoutManagedFields(v any) any {
 	switch t := v.(type) {
 	//case unstructured.UnstructuredList:
 	//	for i := range t.Items {
@@ -192,7 +281,12 @@ func MarshalYaml(v any) (string, error) {
 	case *unstructured.Unstructured:
 		t.SetManagedFields(nil)
 	}
-	ret, err := yml.Marshal(v)
+	return v
+}
+
+// MarshalJson marshals the provided value into indented JSON, lists are marshalled as an array of their items
+func MarshalJson(v any) (string, error) {
+	ret, err := json.MarshalIndent(withoutManagedFields(v), "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -108,6 +108,66 @@ func (s *OutputSuite) TestYamlPrintObjStructured() {
 	})
 }
 
+func (s *OutputSuite) TestJsonPrintObj() {
+	s.Run("prints list items as a JSON array", func() {
+		podList := s.podList()
+		podList.Items[0].SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+		out, err := Json.PrintObj(podList)
+		s.Require().NoError(err)
+		var items []map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(out), &items), "expected valid JSON array, got %s", out)
+		s.Require().Len(items, 1)
+		s.Equal("pod-1", items[0]["metadata"].(map[string]any)["name"])
+		s.NotContains(out, "managedFields")
+	})
+	s.Run("prints indented JSON", func() {
+		out, _ := Json.PrintObj(s.podList())
+		s.Contains(out, "\n    \"apiVersion\": \"v1\"")
+	})
+	s.Run("structured contains list items", func() {
+		result, err := Json.PrintObjStructured(s.podList())
+		s.NoError(err)
+		items, ok := result.Structured.([]map[string]any)
+		s.Require().True(ok, "expected []map[string]any, got %T", result.Structured)
+		s.Len(items, 1)
+	})
+	s.Run("is available by name", func() {
+		s.Equal(Json, FromString("json"))
+		s.Contains(Names, "json")
+	})
+}
+
+func (s *OutputSuite) TestNamesOnlyPrintObj() {
+	s.Run("prints the namespace and name of the list items", func() {
+		podList := s.podList()
+		clusterScoped := unstructured.Unstructured{}
+		clusterScoped.SetName("node-1")
+		podList.Items = append(podList.Items, clusterScoped)
+		out, err := NamesOnly.PrintObj(podList)
+		s.Require().NoError(err)
+		s.Equal("default/pod-1\nnode-1\n", out)
+	})
+	s.Run("prints nothing for empty lists", func() {
+		out, err := NamesOnly.PrintObj(&unstructured.UnstructuredList{})
+		s.Require().NoError(err)
+		s.Empty(out)
+	})
+	s.Run("structured contains the names", func() {
+		result, err := NamesOnly.PrintObjStructured(s.podList())
+		s.Require().NoError(err)
+		s.Equal([]string{"default/pod-1"}, result.Structured)
+	})
+	s.Run("structured is nil for empty lists", func() {
+		result, err := NamesOnly.PrintObjStructured(&unstructured.UnstructuredList{})
+		s.Require().NoError(err)
+		s.Nil(result.Structured)
+	})
+	s.Run("is not selectable by name", func() {
+		s.Nil(FromString("name"))
+		s.NotContains(Names, "name")
+	})
+}
+
 func (s *OutputSuite) TestTableToStructured() {
 	s.Run("returns nil for nil table", func() {
 		s.Nil(tableToStructured(nil))
@@ -0,0 +1,278 @@
+package certmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// certificateNameAnnotation is set by cert-manager on CertificateRequests with the name of the owning Certificate
+const certificateNameAnnotation = "cert-manager.io/certificate-name"
+
+// GroupVersionResource definitions for cert-manager resources
+var (
+	certificateGVR = schema.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificates",
+	}
+	certificateRequestGVR = schema.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificaterequests",
+	}
+)
+
+// Certificate is the status summary of a cert-manager Certificate
+type Certificate struct {
+	Namespace              string     `json:"namespace"`
+	Name                   string     `json:"name"`
+	SecretName             string     `json:"secretName,omitempty"`
+	Issuer                 string     `json:"issuer,omitempty"`
+	DNSNames               []string   `json:"dnsNames,omitempty"`
+	Ready                  *Condition `json:"ready"`
+	Issuing                *Condition `json:"issuing,omitempty"`
+	NotAfter               string     `json:"notAfter,omitempty"`
+	RenewalTime            string     `json:"renewalTime,omitempty"`
+	FailedIssuanceAttempts int64      `json:"failedIssuanceAttempts,omitempty"`
+	LastFailureTime        string     `json:"lastFailureTime,omitempty"`
+	Warning                string     `json:"warning,omitempty"`
+}
+
+// CertificateRequest is the status summary of a cert-manager CertificateRequest
+type CertificateRequest struct {
+	Namespace   string     `json:"namespace"`
+	Name        string     `json:"name"`
+	Certificate string     `json:"certificate,omitempty"`
+	Issuer      string     `json:"issuer,omitempty"`
+	Approved    *Condition `json:"approved,omitempty"`
+	Denied      *Condition `json:"denied,omitempty"`
+	Ready       *Condition `json:"ready"`
+	Created     string     `json:"created,omitempty"`
+}
+
+// Condition is the status of a cert-manager resource condition
+type Condition struct {
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func certificateTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "certmanager_certificates_list",
+				Description: "List cert-manager Certificates in the provided namespace or in all namespaces, reporting for each one its Ready condition, expiration (notAfter) and renewal time, and the state of any in-progress or failing issuance. Flags expired, not ready, and failing certificates",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Namespace to list Certificates from (Optional, all namespaces if not provided)",
+						},
+					},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "cert-manager: List Certificates",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: certificatesList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "certmanager_certificaterequests_list",
+				Description: "List cert-manager CertificateRequests in the provided namespace or in all namespaces, reporting for each one the owning Certificate, the issuer, and its Approved, Denied, and Ready conditions. Useful to find out why a Certificate is not being issued",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Namespace to list CertificateRequests from (Optional, all namespaces if not provided)",
+						},
+						"certificate": {
+							Type:        "string",
+							Description: "Only list the CertificateRequests created for the Certificate with this name (Optional)",
+						},
+					},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "cert-manager: List CertificateRequests",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: certificateRequestsList,
+		},
+	}
+}
+
+func certificatesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificates: %w", err)), nil
+	}
+	if installed, err := isInstalled(params, "Certificate"); err != nil || !installed {
+		return notInstalledResult(err)
+	}
+	list, err := params.DynamicClient().Resource(certificateGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificates: %w", err)), nil
+	}
+	now := time.Now()
+	certificates := make([]Certificate, 0, len(list.Items))
+	for i := range list.Items {
+		certificates = append(certificates, toCertificate(&list.Items[i], now))
+	}
+	return listResult("Certificates", certificates)
+}
+
+func certificateRequestsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	certificate := p.OptionalString("certificate", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificate requests: %w", err)), nil
+	}
+	if installed, err := isInstalled(params, "CertificateRequest"); err != nil || !installed {
+		return notInstalledResult(err)
+	}
+	list, err := params.DynamicClient().Resource(certificateRequestGVR).Namespace(namespace).List(params.Context, metav1.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list certificate requests: %w", err)), nil
+	}
+	requests := make([]CertificateRequest, 0, len(list.Items))
+	for i := range list.Items {
+		request := toCertificateRequest(&list.Items[i])
+		if certificate != "" && request.Certificate != certificate {
+			continue
+		}
+		requests = append(requests, request)
+	}
+	return listResult("CertificateRequests", requests)
+}
+
+// isInstalled returns whether the cert-manager.io/v1 kind is served by the cluster
+func isInstalled(params api.ToolHandlerParams, kind string) (bool, error) {
+	_, err := params.RESTMapper().RESTMapping(schema.GroupKind{Group: certificateGVR.Group, Kind: kind}, certificateGVR.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func notInstalledResult(err error) (*api.ToolCallResult, error) {
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check if cert-manager is installed: %w", err)), nil
+	}
+	return api.NewToolCallResult("cert-manager is not installed in the cluster (the cert-manager.io/v1 API is not available)", nil), nil
+}
+
+func listResult[T any](kind string, items []T) (*api.ToolCallResult, error) {
+	if len(items) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No %s found", kind), nil), nil
+	}
+	yamlItems, err := output.MarshalYaml(items)
+	if err != nil {
+		err = fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following %s (YAML format) were found:\n%s", kind, yamlItems), err), nil
+}
+
+func toCertificate(obj *unstructured.Unstructured, now time.Time) Certificate {
+	ret := Certificate{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Issuer:    issuerRef(obj),
+		Ready:     condition(obj, "Ready"),
+		Issuing:   condition(obj, "Issuing"),
+	}
+	ret.SecretName, _, _ = unstructured.NestedString(obj.Object, "spec", "secretName")
+	ret.DNSNames, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "dnsNames")
+	ret.NotAfter, _, _ = unstructured.NestedString(obj.Object, "status", "notAfter")
+	ret.RenewalTime, _, _ = unstructured.NestedString(obj.Object, "status", "renewalTime")
+	ret.FailedIssuanceAttempts, _, _ = unstructured.NestedInt64(obj.Object, "status", "failedIssuanceAttempts")
+	ret.LastFailureTime, _, _ = unstructured.NestedString(obj.Object, "status", "lastFailureTime")
+	if ret.Ready == nil {
+		ret.Ready = &Condition{Status: string(metav1.ConditionUnknown)}
+	}
+	notAfter, err := time.Parse(time.RFC3339, ret.NotAfter)
+	switch {
+	case err == nil && now.After(notAfter):
+		ret.Warning = fmt.Sprintf("EXPIRED %s ago", duration.HumanDuration(now.Sub(notAfter)))
+	case ret.FailedIssuanceAttempts > 0:
+		ret.Warning = fmt.Sprintf("ISSUANCE FAILING, %d failed attempts", ret.FailedIssuanceAttempts)
+	case ret.Ready.Status != string(metav1.ConditionTrue):
+		ret.Warning = "NOT READY"
+	}
+	return ret
+}
+
+func toCertificateRequest(obj *unstructured.Unstructured) CertificateRequest {
+	ret := CertificateRequest{
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Certificate: obj.GetAnnotations()[certificateNameAnnotation],
+		Issuer:      issuerRef(obj),
+		Approved:    condition(obj, "Approved"),
+		Denied:      condition(obj, "Denied"),
+		Ready:       condition(obj, "Ready"),
+	}
+	if created := obj.GetCreationTimestamp(); !created.IsZero() {
+		ret.Created = created.UTC().Format(time.RFC3339)
+	}
+	if ret.Ready == nil {
+		ret.Ready = &Condition{Status: string(metav1.ConditionUnknown)}
+	}
+	return ret
+}
+
+// issuerRef returns the spec.issuerRef of a Certificate or CertificateRequest as Kind/name
+func issuerRef(obj *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "issuerRef", "name")
+	if name == "" {
+		return ""
+	}
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "issuerRef", "kind")
+	if kind == "" {
+		kind = "Issuer"
+	}
+	if group, _, _ := unstructured.NestedString(obj.Object, "spec", "issuerRef", "group"); group != "" && group != certificateGVR.Group {
+		kind = kind + "." + group
+	}
+	return kind + "/" + name
+}
+
+// condition returns the status.conditions entry with the provided type (nil if not found)
+func condition(obj *unstructured.Unstructured, conditionType string) *Condition {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cMap, ok := c.(map[string]any)
+		if !ok || cMap["type"] != conditionType {
+			continue
+		}
+		ret := &Condition{}
+		ret.Status, _, _ = unstructured.NestedString(cMap, "status")
+		ret.Reason, _, _ = unstructured.NestedString(cMap, "reason")
+		ret.Message, _, _ = unstructured.NestedString(cMap, "message")
+		return ret
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package certmanager_test
+
+import (
+	"testing"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/certmanager"
+	"github.com/stretchr/testify/suite"
+)
+
+type CertManagerSuite struct {
+	suite.Suite
+}
+
+func TestCertManager(t *testing.T) {
+	suite.Run(t, new(CertManagerSuite))
+}
+
+func (s *CertManagerSuite) TestToolset() {
+	ts := &certmanager.Toolset{}
+	s.Equal("cert-manager", ts.GetName())
+	s.NotEmpty(ts.GetDescription())
+	tools := ts.GetTools(nil)
+	s.NotEmpty(tools)
+	s.Nil(ts.GetPrompts())
+}
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
@@ -36,6 +39,36 @@ type ContextsListResult struct {
 	Contexts       []ContextInfo `json:"contexts"`
 }
 
+// ContextDetails describes a single kubeconfig context entry in the
+// configuration_contexts_describe tool's structured content payload. It never
+// includes credentials, only the names of the authentication methods.
+//
+// This is part of the tool's public wire contract: the JSON field tags are
+// observed by MCP clients and must not be renamed without a coordinated
+// migration. See docs/specs/structured-output.md.
+type ContextDetails struct {
+	Name        string   `json:"name"`
+	Current     bool     `json:"current"`
+	Cluster     string   `json:"cluster"`
+	Server      string   `json:"server"`
+	Namespace   string   `json:"namespace,omitempty"`
+	User        string   `json:"user"`
+	AuthMethods []string `json:"authMethods"`
+	Source      string   `json:"source,omitempty"`
+}
+
+// ContextsDescribeResult is the structured-content payload returned by the
+// configuration_contexts_describe tool.
+//
+// This is part of the tool's public wire contract: the JSON field tags are
+// observed by MCP clients and must not be renamed without a coordinated
+// migration. See docs/specs/structured-output.md.
+type ContextsDescribeResult struct {
+	CurrentContext string           `json:"currentContext"`
+	InCluster      bool             `json:"inCluster"`
+	Contexts       []ContextDetails `json:"contexts"`
+}
+
 func initConfiguration() []api.ServerTool {
 	tools := []api.ServerTool{
 		{
@@ -81,6 +114,26 @@ func initConfiguration() []api.ServerTool {
 			TargetListProvider: ptr.To(true),
 			Handler:            nil,
 		},
+		{
+			Tool: api.Tool{
+				Name: "configuration_contexts_describe",
+				Description: "Describe the contexts of the loaded kubeconfig (the effective merged view of the kubeconfig files) to confirm what the server can reach: " +
+					"for each context its cluster server URL, default namespace, user, authentication method (token, client certificate, exec plugin...) and source kubeconfig file. " +
+					"Credentials (tokens, passwords, keys, exec arguments) are never returned. In-cluster deployments report a single context for the service account of the server Pod",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Configuration: Contexts Describe",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			ClusterAware: ptr.To(false),
+			Handler:      contextsDescribe,
+		},
 		{
 			Tool: api.Tool{
 				Name:        "configuration_view",
@@ -170,6 +223,53 @@ func contextsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResultFull(result, structured, nil), nil
 }
 
+func contextsDescribe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	contexts, err := kubernetes.NewCore(params).ConfigurationContextsDescribe()
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe contexts: %w", err)), nil
+	}
+	if len(contexts) == 0 {
+		return api.NewToolCallResult("No contexts found in kubeconfig", nil), nil
+	}
+
+	structured := ContextsDescribeResult{Contexts: make([]ContextDetails, 0, len(contexts))}
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "CURRENT\tNAME\tCLUSTER\tSERVER\tNAMESPACE\tUSER\tAUTH\tSOURCE")
+	for _, context := range contexts {
+		current := ""
+		if context.Current {
+			current = "*"
+			structured.CurrentContext = context.Name
+		}
+		structured.InCluster = structured.InCluster || context.InCluster
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", current, context.Name, context.Cluster, context.Server,
+			valueOrNone(context.Namespace), context.User, strings.Join(context.AuthMethods, ", "), valueOrNone(context.Source))
+		structured.Contexts = append(structured.Contexts, ContextDetails{
+			Name:        context.Name,
+			Current:     context.Current,
+			Cluster:     context.Cluster,
+			Server:      context.Server,
+			Namespace:   context.Namespace,
+			User:        context.User,
+			AuthMethods: context.AuthMethods,
+			Source:      context.Source,
+		})
+	}
+	_ = w.Flush()
+	if structured.InCluster {
+		buf.WriteString("\nThe server runs in-cluster without a kubeconfig file: the in-cluster context is synthetic and authenticates with the service account token of the server Pod\n")
+	}
+	return api.NewToolCallResultFull(buf.String(), structured, nil), nil
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
 func configurationView(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	minify := p.OptionalBool("minified", true)
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initAdmissionWebhooks() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "admission_webhooks",
+			Description: "List the admission webhooks of the ValidatingWebhookConfigurations and MutatingWebhookConfigurations (admissionregistration.k8s.io/v1) with their target rules (operations and resources), failurePolicy, timeout, selectors and backing Service or URL. Webhooks whose Service doesn't exist or has no ready endpoints are flagged with a Warning. Misbehaving webhooks are a common cause of create/update requests timing out or being rejected with 'failed calling webhook' errors",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the webhook configuration or of the webhook (e.g. validate.example.com) to describe (Optional, all the webhooks if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Admission Webhooks",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: admissionWebhooks},
+	}
+}
+
+func admissionWebhooks(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list admission webhooks: %w", err)), nil
+	}
+	webhooks, err := kubernetes.NewCore(params).AdmissionWebhooks(params, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list admission webhooks: %w", err)), nil
+	}
+	if len(webhooks) == 0 && name != "" {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list admission webhooks: no webhook configuration or webhook named %s found", name)), nil
+	}
+	if len(webhooks) == 0 {
+		return api.NewToolCallResult("# No admission webhooks found", nil), nil
+	}
+	flagged := 0
+	for _, webhook := range webhooks {
+		if _, ok := webhook["Warning"]; ok {
+			flagged++
+		}
+	}
+	yamlWebhooks, err := output.MarshalYaml(webhooks)
+	if err != nil {
+		err = fmt.Errorf("failed to list admission webhooks: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following %d admission webhooks were found, %d of them flagged with a Warning (YAML format):\n%s",
+		len(webhooks), flagged, yamlWebhooks), err), nil
+}
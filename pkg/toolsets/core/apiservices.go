@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initAPIServices() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "apiservices_status",
+			Description: "Get the aggregated APIServices (apiregistration.k8s.io/v1) that are not Available along with their backing Service and status conditions. Unavailable APIServices are a common cause of partial discovery failures and of 'metrics API is not available' errors (e.g. v1beta1.metrics.k8s.io when the Metrics Server is down)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the APIService (e.g. v1beta1.metrics.k8s.io) to get the status from, regardless of its availability (Optional, all the APIServices that are not Available if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "APIServices: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: apiServicesStatus},
+	}
+}
+
+func apiServicesStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get apiservices status: %w", err)), nil
+	}
+	apiServices, total, err := kubernetes.NewCore(params).APIServicesStatus(params, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get apiservices status: %w", err)), nil
+	}
+	if len(apiServices) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# All the %d APIServices are Available", total), nil), nil
+	}
+	yamlAPIServices, err := output.MarshalYaml(apiServices)
+	if err != nil {
+		err = fmt.Errorf("failed to get apiservices status: %w", err)
+	}
+	if name != "" {
+		return api.NewToolCallResult(fmt.Sprintf("# The following APIService status (YAML format) was found:\n%s", yamlAPIServices), err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following %d of %d APIServices are not Available (YAML format):\n%s", len(apiServices), total, yamlAPIServices), err), nil
+}
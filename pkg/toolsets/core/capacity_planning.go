@@ -0,0 +1,199 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// initCapacityPlanning initializes the capacity planning prompt
+func initCapacityPlanning() []api.ServerPrompt {
+	return []api.ServerPrompt{
+		{
+			Prompt: api.Prompt{
+				Name:        "capacity-planning",
+				Title:       "Capacity Planning",
+				Description: "Analyze the scheduling capacity of the cluster nodes (requested vs. allocatable CPU and memory, headroom and over-committed nodes) and whether more workloads fit",
+				Arguments: []api.PromptArgument{
+					{
+						Name:        "label_selector",
+						Description: "Kubernetes label selector to restrict the analysis to a pool of nodes (e.g. 'node-role.kubernetes.io/worker=')",
+						Required:    false,
+					},
+					{
+						Name:        "cpu",
+						Description: "CPU request of each replica of the workload to fit (e.g. 500m)",
+						Required:    false,
+					},
+					{
+						Name:        "memory",
+						Description: "Memory request of each replica of the workload to fit (e.g. 512Mi)",
+						Required:    false,
+					},
+					{
+						Name:        "replicas",
+						Description: "Number of replicas of the workload to fit (defaults to 1 when cpu or memory are provided)",
+						Required:    false,
+					},
+				},
+			},
+			Handler: capacityPlanningHandler,
+		},
+	}
+}
+
+// capacityWorkload is the workload the user wants to fit in the cluster
+type capacityWorkload struct {
+	cpu      *resource.Quantity
+	memory   *resource.Quantity
+	replicas int64
+}
+
+// capacityPlanningHandler implements the capacity planning prompt
+func capacityPlanningHandler(params api.PromptHandlerParams) (*api.PromptCallResult, error) {
+	args := params.GetArguments()
+	workload, err := parseCapacityWorkload(args["cpu"], args["memory"], args["replicas"])
+	if err != nil {
+		return nil, err
+	}
+
+	capacity, err := kubernetes.NewCore(params).NodesCapacity(params.Context, args["label_selector"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes capacity: %w", err)
+	}
+
+	return api.NewPromptCallResult(
+		"Nodes capacity gathered successfully",
+		[]api.PromptMessage{
+			{
+				Role: "user",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: formatCapacityPlanningPrompt(capacity, workload),
+				},
+			},
+			{
+				Role: "assistant",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: "I'll analyze the capacity of the nodes and report the available headroom, the over-committed nodes and my recommendations.",
+				},
+			},
+		},
+		nil,
+	), nil
+}
+
+// parseCapacityWorkload validates the prompt arguments describing the workload to fit, nil if none was provided
+func parseCapacityWorkload(cpu, memory, replicas string) (*capacityWorkload, error) {
+	if cpu == "" && memory == "" {
+		if replicas != "" {
+			return nil, fmt.Errorf("replicas requires the cpu or memory argument")
+		}
+		return nil, nil
+	}
+	workload := &capacityWorkload{replicas: 1}
+	for _, arg := range []struct {
+		name     string
+		value    string
+		quantity **resource.Quantity
+	}{{"cpu", cpu, &workload.cpu}, {"memory", memory, &workload.memory}} {
+		if arg.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(arg.value)
+		if err != nil || quantity.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid %s argument %q, must be a positive quantity (e.g. 500m or 1 for cpu, 512Mi or 2Gi for memory)", arg.name, arg.value)
+		}
+		*arg.quantity = &quantity
+	}
+	if replicas != "" {
+		value, err := strconv.ParseInt(replicas, 10, 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid replicas argument %q, must be a positive integer", replicas)
+		}
+		workload.replicas = value
+	}
+	return workload, nil
+}
+
+// replicasFit is the number of replicas of the workload that fit in the headroom of the node
+func (w *capacityWorkload) replicasFit(node *kubernetes.NodeCapacity) int64 {
+	if !node.Schedulable {
+		return 0
+	}
+	fit := max(node.AllocatablePods-node.Pods, 0)
+	if w.cpu != nil {
+		headroom := node.CPU.Headroom()
+		fit = min(fit, headroom.MilliValue()/w.cpu.MilliValue())
+	}
+	if w.memory != nil {
+		headroom := node.Memory.Headroom()
+		fit = min(fit, headroom.Value()/w.memory.Value())
+	}
+	return fit
+}
+
+func (w *capacityWorkload) String() string {
+	var requests []string
+	if w.cpu != nil {
+		requests = append(requests, "cpu "+w.cpu.String())
+	}
+	if w.memory != nil {
+		requests = append(requests, "memory "+w.memory.String())
+	}
+	return fmt.Sprintf("%d replica(s) requesting %s each", w.replicas, strings.Join(requests, " and "))
+}
+
+// formatCapacityPlanningPrompt builds the capacity analysis guide with the capacity of the nodes
+func formatCapacityPlanningPrompt(capacity *kubernetes.NodesCapacityResult, workload *capacityWorkload) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Cluster Capacity Planning\n\n")
+	sb.WriteString("The capacity of the nodes has been gathered below. The requests are the sum of the resource requests of the ")
+	sb.WriteString("non-terminated Pods bound to each node, the same accounting the scheduler uses to decide whether a new Pod fits. ")
+	sb.WriteString("The headroom is the allocatable amount that is not requested yet, the limits percentage shows how much the node ")
+	sb.WriteString("is over-committed if all the Pods use their limits.\n\n")
+	sb.WriteString("```\n")
+	sb.WriteString(formatNodesCapacity(capacity))
+	sb.WriteString("```\n\n")
+
+	if workload != nil {
+		sb.WriteString(fmt.Sprintf("## Workload to fit: %s\n\n", workload))
+		var total int64
+		for i := range capacity.Nodes {
+			fit := workload.replicasFit(&capacity.Nodes[i])
+			total += fit
+			sb.WriteString(fmt.Sprintf("- %s: %d replica(s)\n", capacity.Nodes[i].Name, fit))
+		}
+		if total >= workload.replicas {
+			sb.WriteString(fmt.Sprintf("\nThe headroom of the nodes can accommodate %d replica(s), the %d requested replica(s) fit ", total, workload.replicas))
+			sb.WriteString("as long as the node selectors, affinities, taints and topology spread constraints of the workload allow them on these nodes.\n\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("\nThe headroom of the nodes can only accommodate %d of the %d requested replica(s), ", total, workload.replicas))
+			sb.WriteString("the remaining replicas would stay Pending until capacity is freed or added.\n\n")
+		}
+	}
+
+	sb.WriteString("## Instructions\n\n")
+	sb.WriteString("Analyze the capacity and report to the user:\n\n")
+	sb.WriteString("1. **Headroom**: how much CPU and memory can still be requested in the cluster, and the largest Pod that fits on a single node ")
+	sb.WriteString("(the total headroom is fragmented across the nodes, a Pod can't use the headroom of several nodes).\n")
+	sb.WriteString("2. **Over-committed nodes**: nodes whose limits exceed the allocatable resources can run out of memory (OOM kills, evictions) ")
+	sb.WriteString("or CPU (throttling) under load. Use `pods_on_node` to identify the Pods with the largest limits on them.\n")
+	sb.WriteString("3. **Imbalances**: nodes much more requested than others, nodes with many Pods close to the allocatable Pods limit, ")
+	sb.WriteString("and unschedulable nodes whose capacity is not available (cordoned or not Ready).\n")
+	sb.WriteString("4. **Requests vs. usage**: compare with the actual usage (`nodes_top`, `pods_top`) to find Pods whose requests are much higher ")
+	sb.WriteString("than what they use (wasted capacity) or much lower (risk of contention).\n")
+	if workload != nil {
+		sb.WriteString("5. **Workload fit**: whether the workload fits, on which nodes, and what to do if it doesn't ")
+		sb.WriteString("(right-size the requests of other workloads, add nodes, scale the node pool).\n")
+	}
+	sb.WriteString("\nDo NOT change any resource, only provide recommendations unless the user explicitly asks to apply them.\n")
+	return sb.String()
+}
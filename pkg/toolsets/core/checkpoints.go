@@ -0,0 +1,170 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initCheckpoints() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "resources_checkpoint",
+			Description: "Capture a checkpoint of the resources of a namespace (their resourceVersion and manifest) to later answer \"what changed since then?\" with resources_since_checkpoint, " +
+				"e.g. at the start of an incident investigation or before a rollout. The checkpoint is kept in the server memory for one hour and returns a token to reference it. " +
+				"The resource types configured by the server (scan_kinds) are captured unless kinds are provided. Secret values are never kept, only their digest",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the resources to capture (Optional, the configured namespace if not provided)",
+					},
+					"kinds": {
+						Type:        "array",
+						Description: "Kinds of the resources to capture, as Kind or Kind.group (e.g. Deployment.apps, ConfigMap, Certificate.cert-manager.io) (Optional, the server scan_kinds if not provided)",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)') to only capture the matching resources",
+						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Checkpoint",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesCheckpoint},
+		{Tool: api.Tool{
+			Name: "resources_since_checkpoint",
+			Description: "List the resources added, deleted, modified (with the diff of their manifest) or whose status changed since a checkpoint captured with resources_checkpoint, " +
+				"the resources are listed again with the namespace, kinds and label selector of the checkpoint",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"checkpoint": {
+						Type:        "string",
+						Description: "Token of the checkpoint returned by resources_checkpoint",
+					},
+				},
+				Required: []string{"checkpoint"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Changes Since Checkpoint",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesSinceCheckpoint},
+	}
+}
+
+func resourcesCheckpoint(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	labelSelector := p.OptionalString("labelSelector", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to capture checkpoint: %w", err)), nil
+	}
+	kinds := params.GetScanKinds()
+	if k, ok := params.GetArguments()["kinds"]; ok && k != nil {
+		kindSlice, ok := k.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to capture checkpoint, kinds must be an array of strings")), nil
+		}
+		kinds = nil
+		for _, kind := range kindSlice {
+			kindString, ok := kind.(string)
+			if !ok || strings.TrimSpace(kindString) == "" {
+				return api.NewToolCallResult("", errors.New("failed to capture checkpoint, kinds must be an array of strings")), nil
+			}
+			kinds = append(kinds, strings.TrimSpace(kindString))
+		}
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesCheckpoint(params, namespace, kinds, labelSelector)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to capture checkpoint: %w", err)), nil
+	}
+	total := 0
+	for _, count := range ret.Counts {
+		total += count
+	}
+	buf := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(buf, "Checkpoint %s captured %d resource(s) in namespace %s, it expires at %s.\n",
+		ret.Token, total, ret.Namespace, ret.Expires.UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(buf, "Use resources_since_checkpoint with checkpoint %q to list the changes since now.\n", ret.Token)
+	if total > 0 {
+		buf.WriteString("\n")
+		w := printers.GetNewTabWriter(buf)
+		_, _ = fmt.Fprintln(w, "KIND\tRESOURCES")
+		kindNames := make([]string, 0, len(ret.Counts))
+		for kind := range ret.Counts {
+			kindNames = append(kindNames, kind)
+		}
+		slices.Sort(kindNames)
+		for _, kind := range kindNames {
+			_, _ = fmt.Fprintf(w, "%s\t%d\n", kind, ret.Counts[kind])
+		}
+		_ = w.Flush()
+	}
+	if len(ret.Skipped) > 0 {
+		_, _ = fmt.Fprintf(buf, "\nResource types that couldn't be listed (not captured): %s\n", strings.Join(ret.Skipped, ", "))
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
+
+func resourcesSinceCheckpoint(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	token := p.RequiredString("checkpoint")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get changes since checkpoint: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesSinceCheckpoint(params, token)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get changes since checkpoint: %w", err)), nil
+	}
+	since := fmt.Sprintf("since checkpoint %s (captured at %s, %s ago)", token,
+		ret.Created.UTC().Format(time.RFC3339), duration.HumanDuration(time.Since(ret.Created)))
+	if len(ret.Changes) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No changes in namespace %s %s, %d resource(s) unchanged\n", ret.Namespace, since, ret.Unchanged), nil), nil
+	}
+	counts := map[string]int{}
+	for _, change := range ret.Changes {
+		counts[change.Change]++
+	}
+	buf := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(buf, "Changes in namespace %s %s: %d added, %d deleted, %d modified, %d status changed, %d unchanged\n\n",
+		ret.Namespace, since, counts[kubernetes.ResourceAdded], counts[kubernetes.ResourceDeleted],
+		counts[kubernetes.ResourceModified], counts[kubernetes.ResourceStatusChanged], ret.Unchanged)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "CHANGE\tAPIVERSION\tKIND\tNAME")
+	for _, change := range ret.Changes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", change.Change, change.APIVersion, change.Kind, change.Name)
+	}
+	_ = w.Flush()
+	for _, change := range ret.Changes {
+		if change.Diff != "" {
+			buf.WriteString("\n" + change.Diff)
+		}
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initClusterHealth() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "cluster_components_health",
+			Description: "Check the health of the Kubernetes control plane: queries the API server health endpoints (/readyz and /livez in verbose mode) and, where available, the control-plane components status (scheduler, controller-manager, etcd), returning which subsystems are failing. " +
+				"Useful to diagnose control-plane issues distinct from workload issues. In managed clusters these endpoints may be restricted, in which case they are reported as unavailable",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Cluster: Components Health",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterComponentsHealth},
+		{Tool: api.Tool{
+			Name: "cluster_inventory",
+			Description: "Summarize the size of the cluster: the number of objects of every kind served by the cluster (all namespaces), sorted by count. " +
+				"The objects are counted with lightweight metadata-only list requests, so it is cheap even for large clusters. " +
+				"Useful to get an overview of an unfamiliar cluster or to spot kinds with an unusual number of objects (e.g. leaked Jobs, ReplicaSets or Events). " +
+				"Kinds that can't be listed with the current permissions are reported separately",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Cluster: Inventory",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: clusterInventory},
+	}
+}
+
+func clusterComponentsHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ret, err := kubernetes.NewCore(params).ClusterComponentsHealth(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check cluster components health: %w", err)), nil
+	}
+	var failing, unavailable []string
+	for _, endpoint := range ret.Endpoints {
+		if endpoint.Unavailable != "" {
+			unavailable = append(unavailable, endpoint.Endpoint)
+		}
+		for _, check := range endpoint.Failing {
+			failing = append(failing, fmt.Sprintf("%s: %s", endpoint.Endpoint, check))
+		}
+	}
+	for _, component := range ret.Components {
+		if healthy, _ := component["Healthy"].(bool); !healthy {
+			failing = append(failing, fmt.Sprintf("component %s", component["Name"]))
+		}
+	}
+	yamlEndpoints, err := output.MarshalYaml(ret.Endpoints)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check cluster components health: %w", err)), nil
+	}
+	sb := strings.Builder{}
+	switch {
+	case len(failing) > 0:
+		sb.WriteString(fmt.Sprintf("# The following control-plane checks are failing:\n- %s\n", strings.Join(failing, "\n- ")))
+	case len(unavailable) == len(ret.Endpoints):
+		sb.WriteString("# The API server health endpoints could not be queried, the control-plane health is unknown\n")
+	default:
+		sb.WriteString("# All the control-plane checks are passing\n")
+	}
+	sb.WriteString(fmt.Sprintf("# API server health endpoints (YAML format):\n%s", yamlEndpoints))
+	if ret.ComponentsUnavailable != "" {
+		sb.WriteString(fmt.Sprintf("# Control-plane components status is unavailable: %s\n", ret.ComponentsUnavailable))
+	} else if len(ret.Components) > 0 {
+		yamlComponents, marshalErr := output.MarshalYaml(ret.Components)
+		if marshalErr != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to check cluster components health: %w", marshalErr)), nil
+		}
+		sb.WriteString(fmt.Sprintf("# Control-plane components status (YAML format, ComponentStatus API is deprecated and may be inaccurate):\n%s", yamlComponents))
+	}
+	if len(unavailable) > 0 {
+		sb.WriteString("# Some health endpoints are restricted or unavailable, in managed clusters check the control-plane health in the provider console\n")
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func clusterInventory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	ret, err := kubernetes.NewCore(params).ClusterInventory(params.Context, params)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster inventory: %w", err)), nil
+	}
+	var total int64
+	var empty int
+	var failed []string
+	for _, count := range ret.Counts {
+		switch {
+		case count.Err != nil:
+			failed = append(failed, fmt.Sprintf("%s: %v", count.GroupVersionKind.GroupKind(), count.Err))
+		case count.Count == 0:
+			empty++
+		default:
+			total += count.Count
+		}
+	}
+	buf := new(bytes.Buffer)
+	_, _ = fmt.Fprintf(buf, "The cluster has %d object(s) of %d kind(s), %d other kind(s) have no objects\n\n",
+		total, len(ret.Counts)-empty-len(failed), empty)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "KIND\tAPIVERSION\tNAMESPACED\tCOUNT")
+	for _, count := range ret.Counts {
+		if count.Err != nil || count.Count == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%t\t%d\n",
+			count.GroupVersionKind.Kind, count.GroupVersionKind.GroupVersion().String(), count.Namespaced, count.Count)
+	}
+	_ = w.Flush()
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintf(buf, "\nKinds that couldn't be counted:\n- %s\n", strings.Join(failed, "\n- "))
+	}
+	if len(ret.Denied) > 0 {
+		_, _ = fmt.Fprintf(buf, "\nKinds skipped because they are denied by the server configuration: %s\n", strings.Join(ret.Denied, ", "))
+	}
+	if len(ret.FailedGroups) > 0 {
+		_, _ = fmt.Fprintf(buf, "\nAPI groups whose discovery failed (not counted): %s\n", strings.Join(ret.FailedGroups, ", "))
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
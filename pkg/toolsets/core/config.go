@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/config"
+)
+
+// Config holds core toolset configuration
+type Config struct {
+	// SecretsGetEnabled allows the secrets_get tool to return decoded Secret values.
+	// Disabled by default since the tool exposes sensitive data to the MCP client.
+	SecretsGetEnabled bool `toml:"secrets_get_enabled,omitempty"`
+	// RemoteManifestsEnabled allows the resources_apply_kustomize tool to load remote kustomizations and resources.
+	// Disabled by default since it makes the server fetch content from arbitrary URLs.
+	RemoteManifestsEnabled bool `toml:"remote_manifests_enabled,omitempty"`
+	// AllowedRegistries are the registries (e.g. quay.io) or repository prefixes (e.g. quay.io/my-org) expected for
+	// the container images, the images_inventory tool flags the images from other registries.
+	AllowedRegistries []string `toml:"allowed_registries,omitempty"`
+	// ImageRegistryLookupEnabled allows the workload_images tool to query the image registries for newer tags.
+	// Disabled by default since it makes the server send requests to external registries.
+	ImageRegistryLookupEnabled bool `toml:"image_registry_lookup_enabled,omitempty"`
+}
+
+var _ api.ExtendedConfig = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	if c == nil {
+		return fmt.Errorf("core config is nil")
+	}
+	return nil
+}
+
+// coreConfig returns the core toolset configuration or an empty (all features disabled) one if not provided.
+func coreConfig(params api.BaseConfig) *Config {
+	if c, ok := params.GetToolsetConfig("core"); ok {
+		if cc, ok := c.(*Config); ok {
+			return cc
+		}
+	}
+	return &Config{}
+}
+
+func coreToolsetParser(_ context.Context, primitive toml.Primitive, md toml.MetaData) (api.ExtendedConfig, error) {
+	var cfg Config
+	if err := md.PrimitiveDecode(primitive, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func init() {
+	config.RegisterToolsetConfig("core", coreToolsetParser)
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initConfigArchive() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "namespace_config_export",
+			Description: "Export all the ConfigMaps (and optionally the Secrets) of a Kubernetes namespace into a single multi-document YAML archive, " +
+				"cleaned of cluster-specific fields (status, uid, resourceVersion, managedFields...) and of their namespace so that it can be restored to any namespace with namespace_config_import (backup and clone workflows). " +
+				"ConfigMaps and Secrets generated by the cluster (e.g. kube-root-ca.crt, ServiceAccount tokens) are skipped",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to export the ConfigMaps and Secrets from (Optional, current namespace if not provided)",
+					},
+					"includeSecrets": {
+						Type:        "boolean",
+						Description: "If true, the Secrets (including their data) are exported too. Disabled unless Secret access (secrets_get) is explicitly enabled in the server configuration (Optional, default false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespace Config: Export",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceConfigExport},
+		{Tool: api.Tool{
+			Name: "namespace_config_import",
+			Description: "Import a multi-document YAML archive of ConfigMaps and Secrets (as returned by namespace_config_export) into a Kubernetes namespace, " +
+				"rewriting the namespace of every resource in the archive. Existing ConfigMaps and Secrets with the same name are updated. " +
+				"Archives containing other kinds of resources are rejected",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to import the ConfigMaps and Secrets to (Optional, current namespace if not provided)",
+					},
+					"archive": {
+						Type:        "string",
+						Description: "Multi-document YAML archive with the v1 ConfigMaps and Secrets to import",
+					},
+				},
+				Required: []string{"archive"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespace Config: Import",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceConfigImport},
+	}
+}
+
+func namespaceConfigExport(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	includeSecrets := p.OptionalBool("includeSecrets", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to export namespace config: %w", err)), nil
+	}
+	if includeSecrets && !coreConfig(params).SecretsGetEnabled {
+		return api.NewToolCallResult("", errors.New("failed to export namespace config: exporting Secrets is disabled, Secret access must be explicitly enabled by the server administrator (set secrets_get_enabled = true in [toolset_configs.core])")), nil
+	}
+	resources, err := kubernetes.NewCore(params).ConfigArchiveExport(params, namespace, includeSecrets)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to export namespace config: %w", err)), nil
+	}
+	if len(resources) == 0 {
+		return api.NewToolCallResult("# No ConfigMaps or Secrets to export were found", nil), nil
+	}
+	documents := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		document, marshalErr := output.MarshalYaml(resource)
+		if marshalErr != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to export namespace config: %w", marshalErr)), nil
+		}
+		documents = append(documents, document)
+	}
+	return api.NewToolCallResultAttachable(fmt.Sprintf("# The following %d resources have been exported (multi-document YAML archive):\n", len(resources))+
+		strings.Join(documents, "---\n"), "config-"+params.NamespaceOrDefault(namespace)+".yaml", "application/yaml", nil), nil
+}
+
+func namespaceConfigImport(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	archive := p.RequiredString("archive")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to import namespace config: %w", err)), nil
+	}
+	resources, err := kubernetes.NewCore(params).ConfigArchiveImport(params, archive, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to import namespace config: %w", err)), nil
+	}
+	// Only the imported resource names are returned, Secret values must not be exposed by the import
+	imported := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		imported = append(imported, fmt.Sprintf("- %s %s", resource.GetKind(), resource.GetName()))
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following %d resources have been created or updated in namespace %s:\n%s\n",
+		len(resources), resources[0].GetNamespace(), strings.Join(imported, "\n")), nil), nil
+}
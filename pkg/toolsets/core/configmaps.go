@@ -0,0 +1,80 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initConfigMaps() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "configmap_set_key",
+			Description: "Set or remove a single data key of a Kubernetes ConfigMap in the current or provided namespace without rewriting the rest of the ConfigMap (uses a merge patch). Returns the updated ConfigMap",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ConfigMap (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ConfigMap",
+					},
+					"key": {
+						Type:        "string",
+						Description: "Data key to set or remove",
+					},
+					"value": {
+						Type:        "string",
+						Description: "Value to set for the key (required unless remove is true)",
+					},
+					"remove": {
+						Type:        "boolean",
+						Description: "If true, removes the key from the ConfigMap data instead of setting it (Optional, default false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ConfigMap: Set Key",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: configMapSetKey},
+	}
+}
+
+func configMapSetKey(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	key := p.RequiredString("key")
+	remove := p.OptionalBool("remove", false)
+	value, hasValue := params.GetArguments()["value"]
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set configmap key: %w", err)), nil
+	}
+	v, ok := value.(string)
+	if !remove && (!hasValue || !ok) {
+		return api.NewToolCallResult("", errors.New("failed to set configmap key: value parameter required (string) unless remove is true")), nil
+	}
+	ret, err := kubernetes.NewCore(params).ConfigMapsSetKey(params, namespace, name, key, v, remove)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set key %s in configmap %s: %w", key, name, err)), nil
+	}
+	printed, err := output.Yaml.PrintObjStructured(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format configmap: %w", err)), nil
+	}
+	return api.NewToolCallResultFull("# The ConfigMap (YAML) has been updated successfully\n"+printed.Text, printed.Structured, nil), nil
+}
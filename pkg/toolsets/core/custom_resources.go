@@ -0,0 +1,115 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initCustomResources() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "custom_resources_query",
+			Description: "Query the instances of a Custom Resource Definition (or any other resource type) by group, version and resource across namespaces, " +
+				"filtering them by label selector and by a JSONPath predicate on any field (e.g. .status.phase==Failed), which is not possible with the field selectors of resources_list. " +
+				"Returns the matching resources (YAML), the number of returned resources is capped",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"group": {
+						Type:        "string",
+						Description: "API group of the resource (e.g. cert-manager.io, argoproj.io) (Optional, empty for the core API group)",
+					},
+					"version": {
+						Type:        "string",
+						Description: "API version of the resource (e.g. v1, v1alpha1) (Optional, the preferred version of the group is used if not provided)",
+					},
+					"resource": {
+						Type:        "string",
+						Description: "Plural (or singular) name of the resource (e.g. certificates, applications)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to query the namespaced resources from (ignored in case of cluster scoped resources). If not provided, will query resources from all namespaces",
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)')",
+						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
+					},
+					"filter": {
+						Type: "string",
+						Description: "Optional JSONPath predicate that the returned resources must satisfy, one of: '<jsonpath>==<value>', '<jsonpath>!=<value>' or '<jsonpath>' (the field must be set and not empty). " +
+							"If the JSONPath resolves to multiple values, == matches if any of them is equal and != if none of them is. " +
+							"Enclose the JSONPath in braces when it contains operators (e.g. .status.phase==Failed, .spec.suspend==true, {.status.conditions[?(@.type==\"Ready\")].status}==False)",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of resources to return (Optional, defaults to %d, capped to %d)", kubernetes.DefaultCustomResourcesQueryLimit, kubernetes.MaxCustomResourcesQueryLimit),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Custom Resources: Query",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: customResourcesQuery},
+	}
+}
+
+func customResourcesQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	gvr := schema.GroupVersionResource{
+		Group:    p.OptionalString("group", ""),
+		Version:  p.OptionalString("version", ""),
+		Resource: p.RequiredString("resource"),
+	}
+	namespace := p.OptionalString("namespace", "")
+	labelSelector := p.OptionalString("labelSelector", "")
+	filterExpression := p.OptionalString("filter", "")
+	limit := p.OptionalInt64("limit", kubernetes.DefaultCustomResourcesQueryLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query custom resources: %w", err)), nil
+	}
+	if limit < 1 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query custom resources: invalid limit %d, must be greater than 0", limit)), nil
+	}
+	if err := validateLabelSelector(labelSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query custom resources: %w", err)), nil
+	}
+	var filter *kubernetes.QueryFilter
+	if filterExpression != "" {
+		var err error
+		if filter, err = kubernetes.ParseQueryFilter(filterExpression); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to query custom resources: %w", err)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).CustomResourcesQuery(params, gvr, namespace, labelSelector, filter, int(limit))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to query custom resources: %w", err)), nil
+	}
+	resource := ret.Resource.GroupResource().String()
+	if len(ret.Items.Items) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# No %s matching the query were found (%d scanned)", resource, ret.Scanned), nil), nil
+	}
+	printed, err := output.Yaml.PrintObjStructured(ret.Items)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format resources: %w", err)), nil
+	}
+	text := fmt.Sprintf("# The following %d %s match the query (YAML format):\n", len(ret.Items.Items), resource) + printed.Text
+	if ret.Truncated {
+		text += fmt.Sprintf("\n# More %s match the query but were not shown (limit of %d reached), refine the query or increase the limit\n", resource, len(ret.Items.Items))
+	}
+	return api.NewToolCallResultFull(text, printed.Structured, nil), nil
+}
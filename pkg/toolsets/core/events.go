@@ -48,6 +48,9 @@ func eventsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err := p.Err(); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list events in all namespaces: %w", err)), nil
 	}
+	if err := validateFieldSelector(options.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list events in all namespaces: %w", err)), nil
+	}
 	eventMap, err := kubernetes.NewCore(params).EventsList(params, namespace, options)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list events in all namespaces: %w", err)), nil
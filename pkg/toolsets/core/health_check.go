@@ -1,11 +1,14 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
@@ -73,7 +76,7 @@ func clusterHealthCheckHandler(params api.PromptHandlerParams) (*api.PromptCallR
 		logger.Info("Performing cluster-wide health check")
 	}
 
-	diagnostics, err := gatherClusterDiagnostics(params, namespace, checkEvents)
+	diagnostics, err := gatherClusterDiagnostics(params.Context, params.KubernetesClient, namespace, checkEvents)
 	if err != nil {
 		return nil, fmt.Errorf("failed to gather cluster diagnostics: %w", err)
 	}
@@ -125,102 +128,117 @@ type clusterDiagnostics struct {
 	NamespaceScoped  bool
 	TargetNamespace  string
 	NamespaceWarning string
+	// PartialFailures are the sections that couldn't be gathered, reported so that they aren't assessed as healthy
+	PartialFailures api.PartialFailures
 }
 
 // gatherClusterDiagnostics collects comprehensive diagnostic data from the cluster
-func gatherClusterDiagnostics(params api.PromptHandlerParams, namespace string, checkEvents bool) (*clusterDiagnostics, error) {
+func gatherClusterDiagnostics(ctx context.Context, client api.KubernetesClient, namespace string, checkEvents bool) (*clusterDiagnostics, error) {
 	diag := &clusterDiagnostics{
 		CollectionTime:  time.Now(),
 		NamespaceScoped: namespace != "",
 		TargetNamespace: namespace,
 	}
 
-	logger := klog.FromContext(params.Context)
+	logger := klog.FromContext(ctx)
 
 	// Gather node diagnostics using ResourcesList
 	logger.Info("Collecting node diagnostics...")
-	nodeDiag, err := gatherNodeDiagnostics(params)
+	nodeDiag, err := gatherNodeDiagnostics(ctx, client)
 	if err == nil {
 		diag.Nodes = nodeDiag
 		logger.Info("Node diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect node diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("Nodes", err)
 	}
 
 	// Gather pod diagnostics
 	logger.Info("Collecting pod diagnostics...")
-	podDiag, err := gatherPodDiagnostics(params, namespace)
+	podDiag, err := gatherPodDiagnostics(ctx, client, namespace)
 	if err == nil {
 		diag.Pods = podDiag
 		logger.Info("Pod diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect pod diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("Pods", err)
 	}
 
 	// Gather workload diagnostics
 	logger.Info("Collecting deployment diagnostics...")
-	deployDiag, err := gatherWorkloadDiagnostics(params, "Deployment", namespace)
+	deployDiag, err := gatherWorkloadDiagnostics(ctx, client, "Deployment", namespace)
 	if err == nil {
 		diag.Deployments = deployDiag
 		logger.Info("Deployment diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect deployment diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("Deployments", err)
 	}
 
 	logger.Info("Collecting statefulset diagnostics...")
-	stsDiag, err := gatherWorkloadDiagnostics(params, "StatefulSet", namespace)
+	stsDiag, err := gatherWorkloadDiagnostics(ctx, client, "StatefulSet", namespace)
 	if err == nil {
 		diag.StatefulSets = stsDiag
 		logger.Info("StatefulSet diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect statefulset diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("StatefulSets", err)
 	}
 
 	logger.Info("Collecting daemonset diagnostics...")
-	dsDiag, err := gatherWorkloadDiagnostics(params, "DaemonSet", namespace)
+	dsDiag, err := gatherWorkloadDiagnostics(ctx, client, "DaemonSet", namespace)
 	if err == nil {
 		diag.DaemonSets = dsDiag
 		logger.Info("DaemonSet diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect daemonset diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("DaemonSets", err)
 	}
 
 	// Gather PVC diagnostics
 	logger.Info("Collecting PVC diagnostics...")
-	pvcDiag, err := gatherPVCDiagnostics(params, namespace)
+	pvcDiag, err := gatherPVCDiagnostics(ctx, client, namespace)
 	if err == nil {
 		diag.PVCs = pvcDiag
 		logger.Info("PVC diagnostics collected")
 	} else {
 		klogutil.LogWarn(logger, "Failed to collect PVC diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("Persistent Volume Claims", err)
 	}
 
 	// Gather cluster operator diagnostics (OpenShift only)
 	logger.Info("Checking for cluster operators (OpenShift)...")
-	operatorDiag, err := gatherClusterOperatorDiagnostics(params)
+	operatorDiag, err := gatherClusterOperatorDiagnostics(ctx, client)
 	if err == nil {
 		diag.ClusterOperators = operatorDiag
 		logger.Info("Cluster operator diagnostics collected")
+	} else if !meta.IsNoMatchError(err) {
+		// ClusterOperators only exist in OpenShift, their absence is not a failure
+		klogutil.LogWarn(logger, "Failed to collect cluster operator diagnostics", klogutil.Err(err))
+		diag.PartialFailures.Add("Cluster Operators", err)
 	}
 
 	// Gather recent events if requested
 	if checkEvents {
 		logger.Info("Collecting recent events...")
-		eventDiag, err := gatherEventDiagnostics(params, namespace)
+		eventDiag, err := gatherEventDiagnostics(ctx, client, namespace, &diag.PartialFailures)
 		if err == nil {
 			diag.Events = eventDiag
 			logger.Info("Event diagnostics collected")
 		} else {
 			klogutil.LogWarn(logger, "Failed to collect event diagnostics", klogutil.Err(err))
+			diag.PartialFailures.Add("Events", err)
 		}
 	}
 
 	// Count namespaces
 	logger.Info("Counting namespaces...")
-	namespaceList, err := params.CoreV1().Namespaces().List(params.Context, metav1.ListOptions{})
+	namespaceList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err == nil {
 		diag.TotalNamespaces = len(namespaceList.Items)
 		logger.Info("Found namespaces", "kubernetes.namespaces.count", diag.TotalNamespaces)
+	} else if namespace == "" {
+		diag.PartialFailures.Add("Namespaces count", err)
 	}
 
 	logger.Info("Cluster health check data collection completed")
@@ -228,8 +246,8 @@ func gatherClusterDiagnostics(params api.PromptHandlerParams, namespace string,
 }
 
 // gatherNodeDiagnostics collects node status using CoreV1 clientset
-func gatherNodeDiagnostics(params api.PromptHandlerParams) (string, error) {
-	nodeList, err := params.CoreV1().Nodes().List(params.Context, metav1.ListOptions{})
+func gatherNodeDiagnostics(ctx context.Context, client api.KubernetesClient) (string, error) {
+	nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -280,58 +298,31 @@ func gatherNodeDiagnostics(params api.PromptHandlerParams) (string, error) {
 }
 
 // gatherPodDiagnostics collects pod status using CoreV1 clientset
-func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
-	podList, err := params.CoreV1().Pods(namespace).List(params.Context, metav1.ListOptions{})
+func gatherPodDiagnostics(ctx context.Context, client api.KubernetesClient, namespace string) (string, error) {
+	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "", err
 	}
 
-	if len(podList.Items) == 0 {
-		return "No pods found", nil
+	return formatPodDiagnostics(podList.Items), nil
+}
+
+// formatPodDiagnostics summarizes the pods with issues or a high restart count
+func formatPodDiagnostics(pods []v1.Pod) string {
+	if len(pods) == 0 {
+		return "No pods found"
 	}
 
-	totalPods := len(podList.Items)
+	totalPods := len(pods)
 	var problemPods []string
 
-	for _, pod := range podList.Items {
-		var issues []string
-		restarts := int32(0)
-		readyCount := 0
-		totalContainers := len(pod.Status.ContainerStatuses)
-
-		// Check container statuses
-		for _, cs := range pod.Status.ContainerStatuses {
-			if cs.Ready {
-				readyCount++
-			}
-			restarts += cs.RestartCount
-
-			// Check waiting state
-			if cs.State.Waiting != nil {
-				reason := cs.State.Waiting.Reason
-				if reason == "CrashLoopBackOff" || reason == "ImagePullBackOff" || reason == "ErrImagePull" {
-					issues = append(issues, fmt.Sprintf("Container waiting: %s - %s", reason, cs.State.Waiting.Message))
-				}
-			}
-
-			// Check terminated state
-			if cs.State.Terminated != nil {
-				reason := cs.State.Terminated.Reason
-				if reason == "Error" || reason == "OOMKilled" {
-					issues = append(issues, fmt.Sprintf("Container terminated: %s", reason))
-				}
-			}
-		}
-
-		// Check pod phase
-		if pod.Status.Phase != v1.PodRunning && pod.Status.Phase != v1.PodSucceeded {
-			issues = append(issues, fmt.Sprintf("Pod in %s phase", pod.Status.Phase))
-		}
+	for _, pod := range pods {
+		issues, readyCount, restarts := podIssues(&pod)
 
 		// Report pods with issues or high restart count
 		if len(issues) > 0 || restarts > podHighRestartThreshold {
 			problemPods = append(problemPods, fmt.Sprintf("- **%s/%s** (Phase: %s, Ready: %d/%d, Restarts: %d)\n  - %s",
-				pod.Namespace, pod.Name, pod.Status.Phase, readyCount, totalContainers, restarts, strings.Join(issues, "\n  - ")))
+				pod.Namespace, pod.Name, pod.Status.Phase, readyCount, len(pod.Status.ContainerStatuses), restarts, strings.Join(issues, "\n  - ")))
 		}
 	}
 
@@ -343,16 +334,49 @@ func gatherPodDiagnostics(params api.PromptHandlerParams, namespace string) (str
 		sb.WriteString("*No pod issues detected*")
 	}
 
-	return sb.String(), nil
+	return sb.String()
+}
+
+// podIssues returns the issues detected in the pod status along with its number of ready containers and restarts
+func podIssues(pod *v1.Pod) (issues []string, readyCount int, restarts int32) {
+	// Check container statuses
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			readyCount++
+		}
+		restarts += cs.RestartCount
+
+		// Check waiting state
+		if cs.State.Waiting != nil {
+			reason := cs.State.Waiting.Reason
+			if reason == "CrashLoopBackOff" || reason == "ImagePullBackOff" || reason == "ErrImagePull" {
+				issues = append(issues, fmt.Sprintf("Container waiting: %s - %s", reason, cs.State.Waiting.Message))
+			}
+		}
+
+		// Check terminated state
+		if cs.State.Terminated != nil {
+			reason := cs.State.Terminated.Reason
+			if reason == "Error" || reason == "OOMKilled" {
+				issues = append(issues, fmt.Sprintf("Container terminated: %s", reason))
+			}
+		}
+	}
+
+	// Check pod phase
+	if pod.Status.Phase != v1.PodRunning && pod.Status.Phase != v1.PodSucceeded {
+		issues = append(issues, fmt.Sprintf("Pod in %s phase", pod.Status.Phase))
+	}
+	return issues, readyCount, restarts
 }
 
 // gatherWorkloadDiagnostics collects workload controller status using AppsV1 clientset
-func gatherWorkloadDiagnostics(params api.PromptHandlerParams, kind string, namespace string) (string, error) {
+func gatherWorkloadDiagnostics(ctx context.Context, client api.KubernetesClient, kind string, namespace string) (string, error) {
 	var workloadsWithIssues []string
 
 	switch kind {
 	case "Deployment":
-		deploymentList, err := params.AppsV1().Deployments(namespace).List(params.Context, metav1.ListOptions{})
+		deploymentList, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return "", err
 		}
@@ -375,7 +399,7 @@ func gatherWorkloadDiagnostics(params api.PromptHandlerParams, kind string, name
 		}
 
 	case "StatefulSet":
-		statefulSetList, err := params.AppsV1().StatefulSets(namespace).List(params.Context, metav1.ListOptions{})
+		statefulSetList, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return "", err
 		}
@@ -402,7 +426,7 @@ func gatherWorkloadDiagnostics(params api.PromptHandlerParams, kind string, name
 		}
 
 	case "DaemonSet":
-		daemonSetList, err := params.AppsV1().DaemonSets(namespace).List(params.Context, metav1.ListOptions{})
+		daemonSetList, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			return "", err
 		}
@@ -440,8 +464,8 @@ func gatherWorkloadDiagnostics(params api.PromptHandlerParams, kind string, name
 }
 
 // gatherPVCDiagnostics collects PVC status using CoreV1 clientset
-func gatherPVCDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
-	pvcList, err := params.CoreV1().PersistentVolumeClaims(namespace).List(params.Context, metav1.ListOptions{})
+func gatherPVCDiagnostics(ctx context.Context, client api.KubernetesClient, namespace string) (string, error) {
+	pvcList, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -450,12 +474,18 @@ func gatherPVCDiagnostics(params api.PromptHandlerParams, namespace string) (str
 		return "No PVCs found", nil
 	}
 
+	// StorageClasses explain why a claim is Pending, the diagnosis is generic if they can't be listed
+	var storageClasses []storagev1.StorageClass
+	if storageClassList, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{}); err == nil {
+		storageClasses = append([]storagev1.StorageClass{}, storageClassList.Items...)
+	}
+
 	var pvcsWithIssues []string
 
 	for _, pvc := range pvcList.Items {
-		if pvc.Status.Phase != v1.ClaimBound {
-			pvcsWithIssues = append(pvcsWithIssues, fmt.Sprintf("- **%s/%s** (Status: %s)\n  - PVC not bound",
-				pvc.Namespace, pvc.Name, pvc.Status.Phase))
+		if issue := kubernetes.PersistentVolumeClaimIssue(&pvc, storageClasses); issue != "" {
+			pvcsWithIssues = append(pvcsWithIssues, fmt.Sprintf("- **%s/%s** (Status: %s)\n  - %s",
+				pvc.Namespace, pvc.Name, pvc.Status.Phase, issue))
 		}
 	}
 
@@ -471,14 +501,14 @@ func gatherPVCDiagnostics(params api.PromptHandlerParams, namespace string) (str
 }
 
 // gatherClusterOperatorDiagnostics collects ClusterOperator status (OpenShift only)
-func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, error) {
+func gatherClusterOperatorDiagnostics(ctx context.Context, client api.KubernetesClient) (string, error) {
 	gvk := &schema.GroupVersionKind{
 		Group:   "config.openshift.io",
 		Version: "v1",
 		Kind:    "ClusterOperator",
 	}
 
-	operatorList, err := kubernetes.NewCore(params).ResourcesList(params, gvk, "", api.ListOptions{})
+	operatorList, err := kubernetes.NewCore(client).ResourcesList(ctx, gvk, "", api.ListOptions{})
 	if err != nil {
 		// Not an OpenShift cluster
 		return "", err
@@ -544,8 +574,9 @@ func gatherClusterOperatorDiagnostics(params api.PromptHandlerParams) (string, e
 	return sb.String(), nil
 }
 
-// gatherEventDiagnostics collects recent warning and error events
-func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (string, error) {
+// gatherEventDiagnostics collects recent warning and error events, the namespaces whose events can't be listed are
+// recorded as partial failures
+func gatherEventDiagnostics(ctx context.Context, client api.KubernetesClient, namespace string, failures *api.PartialFailures) (string, error) {
 	var namespaces []string
 
 	if namespace != "" {
@@ -555,13 +586,15 @@ func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (s
 		namespaces = []string{"default", "kube-system"}
 
 		// Add OpenShift namespaces using typed clientset
-		nsList, err := params.CoreV1().Namespaces().List(params.Context, metav1.ListOptions{})
+		nsList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 		if err == nil {
 			for _, ns := range nsList.Items {
 				if strings.HasPrefix(ns.Name, "openshift-") {
 					namespaces = append(namespaces, ns.Name)
 				}
 			}
+		} else {
+			failures.Add("Events in openshift-* namespaces", err)
 		}
 	}
 
@@ -571,8 +604,9 @@ func gatherEventDiagnostics(params api.PromptHandlerParams, namespace string) (s
 	var recentEvents []string
 
 	for _, ns := range namespaces {
-		eventList, err := params.CoreV1().Events(ns).List(params.Context, metav1.ListOptions{})
+		eventList, err := client.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
 		if err != nil {
+			failures.Add(fmt.Sprintf("Events in namespace %s", ns), err)
 			continue
 		}
 
@@ -646,13 +680,22 @@ func formatHealthCheckPrompt(diag *clusterDiagnostics) string {
 	}
 	sb.WriteString("\n")
 
+	if len(diag.PartialFailures) > 0 {
+		sb.WriteString(diag.PartialFailures.Markdown())
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("## Your Task\n\n")
 	sb.WriteString("Analyze the following cluster diagnostic data and provide:\n")
 	sb.WriteString("1. **Overall Health Status**: Healthy, Warning, or Critical\n")
 	sb.WriteString("2. **Critical Issues**: Issues requiring immediate attention\n")
 	sb.WriteString("3. **Warnings**: Non-critical issues that should be addressed\n")
 	sb.WriteString("4. **Recommendations**: Suggested actions to improve cluster health\n")
-	sb.WriteString("5. **Summary**: Brief overview of findings by component\n\n")
+	sb.WriteString("5. **Summary**: Brief overview of findings by component\n")
+	if len(diag.PartialFailures) > 0 {
+		sb.WriteString("6. **Coverage**: The sections that couldn't be gathered (listed above) and how to check them manually\n")
+	}
+	sb.WriteString("\n")
 
 	sb.WriteString("---\n\n")
 
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initHPA() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "hpa_status",
+			Description: "Get the status of the Kubernetes HorizontalPodAutoscalers (autoscaling/v2) in the current or provided namespace: target and current value of each metric, current/desired replicas, scaling conditions (e.g. AbleToScale, ScalingActive, ScalingLimited) and recent events. Useful to understand why a workload is or isn't scaling",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get the HorizontalPodAutoscalers from (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the HorizontalPodAutoscaler (Optional, all the HorizontalPodAutoscalers in the namespace if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "HorizontalPodAutoscalers: Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: hpaStatus},
+		{Tool: api.Tool{
+			Name:        "hpa_set",
+			Description: "Set the minReplicas and/or maxReplicas of a Kubernetes HorizontalPodAutoscaler (autoscaling/v2) in the current or provided namespace with a merge patch (the bound not provided is left untouched). The resulting bounds are validated before applying (min must be at least 1 and not greater than max). Returns the updated HorizontalPodAutoscaler status",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the HorizontalPodAutoscaler (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the HorizontalPodAutoscaler",
+					},
+					"min": {
+						Type:        "integer",
+						Description: "Minimum number of replicas (minReplicas) the HorizontalPodAutoscaler can scale down to (Optional, left untouched if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"max": {
+						Type:        "integer",
+						Description: "Maximum number of replicas (maxReplicas) the HorizontalPodAutoscaler can scale up to (Optional, left untouched if not provided)",
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "HorizontalPodAutoscalers: Set",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: hpaSet},
+	}
+}
+
+func hpaStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.OptionalString("name", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get horizontalpodautoscalers status: %w", err)), nil
+	}
+	hpas, err := kubernetes.NewCore(params).HPAStatus(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get horizontalpodautoscalers status: %w", err)), nil
+	}
+	if len(hpas) == 0 {
+		return api.NewToolCallResult("# No HorizontalPodAutoscalers found", nil), nil
+	}
+	yamlHpas, err := output.MarshalYaml(hpas)
+	if err != nil {
+		err = fmt.Errorf("failed to get horizontalpodautoscalers status: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following HorizontalPodAutoscalers status (YAML format) was found:\n%s", yamlHpas), err), nil
+}
+
+func hpaSet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	minReplicas := optionalReplicas(p, "min")
+	maxReplicas := optionalReplicas(p, "max")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set horizontalpodautoscaler: %w", err)), nil
+	}
+	for key, replicas := range map[string]*int64{"min": minReplicas, "max": maxReplicas} {
+		if replicas != nil && (*replicas < 0 || *replicas > math.MaxInt32) {
+			return api.NewToolCallResult("", fmt.Errorf("failed to set horizontalpodautoscaler: invalid %s %d", key, *replicas)), nil
+		}
+	}
+	hpa, err := kubernetes.NewCore(params).HPASet(params, namespace, name, toInt32(minReplicas), toInt32(maxReplicas))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set horizontalpodautoscaler %s: %w", name, err)), nil
+	}
+	yamlHpa, err := output.MarshalYaml(hpa)
+	if err != nil {
+		err = fmt.Errorf("failed to set horizontalpodautoscaler %s: %w", name, err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The HorizontalPodAutoscaler %s/%s has been updated successfully (YAML format):\n%s",
+		hpa["Namespace"], hpa["Name"], yamlHpa), err), nil
+}
+
+// optionalReplicas returns the integer argument or nil if it wasn't provided
+func optionalReplicas(p *api.Params, key string) *int64 {
+	if _, ok := p.GetArguments()[key]; !ok {
+		return nil
+	}
+	return ptr.To(p.OptionalInt64(key, 0))
+}
+
+func toInt32(v *int64) *int32 {
+	if v == nil {
+		return nil
+	}
+	return ptr.To(int32(*v))
+}
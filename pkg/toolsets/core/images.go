@@ -0,0 +1,71 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initImages() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "images_inventory",
+			Description: "List the distinct container images (including init and ephemeral containers) in use by the Pods in the current or provided namespace, with the number of Pods and the workloads (e.g. Deployment/web) using each of them. Images using the latest tag (explicitly or implicitly) are flagged, and so are the images from registries not in the configured allowed registries (if any). Useful for security and compliance reviews",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to scan the Pods from (Optional, current namespace if not provided)",
+					},
+					"limit": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Maximum number of images to return (Optional, defaults to %d, capped to %d)", kubernetes.DefaultImagesInventoryLimit, kubernetes.MaxImagesInventoryLimit),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Images: Inventory",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: imagesInventory},
+	}
+}
+
+func imagesInventory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	limit := p.OptionalInt64("limit", kubernetes.DefaultImagesInventoryLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get images inventory: %w", err)), nil
+	}
+	if limit < 1 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get images inventory: invalid limit %d, must be greater than 0", limit)), nil
+	}
+	limit = min(limit, kubernetes.MaxImagesInventoryLimit)
+	ret, err := kubernetes.NewCore(params).ImagesInventory(params, namespace, coreConfig(params).AllowedRegistries, int(limit))
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get images inventory: %w", err)), nil
+	}
+	if ret.Total == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# No container images found (%d Pods scanned)", ret.Pods), nil), nil
+	}
+	yamlImages, err := output.MarshalYaml(ret.Images)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get images inventory: %w", err)), nil
+	}
+	text := fmt.Sprintf("# The following %d container images are in use by %d Pods (YAML format):\n%s", ret.Total, ret.Pods, yamlImages)
+	if len(ret.Images) < ret.Total {
+		text += fmt.Sprintf("\n# Only the first %d images were shown (limit reached), increase the limit to show more\n", len(ret.Images))
+	}
+	return api.NewToolCallResult(text, nil), nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initIngresses() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "ingress_describe",
+			Description: "Describe the routing of a Kubernetes Ingress in the current or provided namespace: resolves each host/path to the backing Service and its ready endpoints, returning a routing table. Flags broken backends (missing Service, wrong Service port, no ready endpoints), common causes of Ingress 404/503 errors",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Ingress (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Ingress",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Ingress: Describe",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: ingressDescribe},
+	}
+}
+
+func ingressDescribe(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe ingress: %w", err)), nil
+	}
+	ingress, err := kubernetes.NewCore(params).IngressDescribe(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to describe ingress %s in namespace %s: %w", name, namespace, err)), nil
+	}
+	return api.NewToolCallResult(formatIngressRouting(ingress), nil), nil
+}
+
+func formatIngressRouting(ingress *kubernetes.IngressDescription) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Ingress %s/%s routing\n", ingress.Namespace, ingress.Name))
+	if ingress.IngressClass != "" {
+		sb.WriteString(fmt.Sprintf("Ingress class: %s\n", ingress.IngressClass))
+	}
+	if len(ingress.Addresses) > 0 {
+		sb.WriteString(fmt.Sprintf("Addresses: %s\n", strings.Join(ingress.Addresses, ", ")))
+	} else {
+		sb.WriteString("Addresses: <none> (the Ingress controller hasn't admitted this Ingress yet)\n")
+	}
+	if len(ingress.TLSHosts) > 0 {
+		sb.WriteString(fmt.Sprintf("TLS hosts: %s\n", strings.Join(ingress.TLSHosts, ", ")))
+	}
+	if len(ingress.Routes) == 0 {
+		sb.WriteString("The Ingress has no rules or default backend, all requests will return 404\n")
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "HOST\tPATH\tPATH TYPE\tBACKEND\tREADY ENDPOINTS\tSTATUS")
+	broken := 0
+	for _, route := range ingress.Routes {
+		status := "OK"
+		if route.Problem != "" {
+			status = "BROKEN: " + route.Problem
+			broken++
+		}
+		pathType := route.PathType
+		if pathType == "" {
+			pathType = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			route.Host, route.Path, pathType, route.Backend, strconv.Itoa(route.ReadyEndpoints), status)
+	}
+	_ = w.Flush()
+	if broken > 0 {
+		sb.WriteString(fmt.Sprintf("\n%d of %d routes have broken backends, requests to them will fail (e.g. 503 errors)\n", broken, len(ingress.Routes)))
+	}
+	return sb.String()
+}
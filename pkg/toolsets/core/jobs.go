@@ -0,0 +1,133 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initJobs() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "jobs_run",
+			Description: "Run a one-off task as a Kubernetes Job in the current or provided namespace and get its output: " +
+				"creates the Job from a container image and command (or from an inline Job manifest), waits for it to complete or fail (bounded by the timeout), " +
+				"and returns the Job status along with the logs of its Pod. Jobs created from an image don't retry failures and are deleted one hour after they finish",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to run the Job in (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Job (Optional, random name if not provided)",
+					},
+					"image": {
+						Type:        "string",
+						Description: "Container image to run (required unless job is provided)",
+					},
+					"command": {
+						Type:        "array",
+						Description: "Command to run in the container, overriding the image entrypoint (Optional, e.g. [\"sh\", \"-c\", \"echo hello\"])",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"job": {
+						Type:        "string",
+						Description: "Inline batch/v1 Job manifest (YAML or JSON) to create instead of a Job from image and command (Optional)",
+					},
+					"timeout": {
+						Type:        "string",
+						Description: fmt.Sprintf("Maximum time to wait for the Job to complete or fail as a duration (e.g. 30s, 5m) (Optional, default %s, max %s)", kubernetes.DefaultJobRunTimeout, kubernetes.MaxJobRunTimeout),
+						Default:     api.ToRawMessage(kubernetes.DefaultJobRunTimeout.String()),
+					},
+					"tail": {
+						Type:        "integer",
+						Description: "Number of lines to retrieve from the end of the Job Pod logs (Optional, default: 100)",
+						Default:     api.ToRawMessage(kubernetes.DefaultTailLines),
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Jobs: Run",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: jobsRun},
+	}
+}
+
+func jobsRun(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	opts := kubernetes.JobRunOptions{
+		Namespace: p.OptionalString("namespace", ""),
+		Name:      p.OptionalString("name", ""),
+		Image:     p.OptionalString("image", ""),
+		Manifest:  p.OptionalString("job", ""),
+		Tail:      p.OptionalInt64("tail", kubernetes.DefaultTailLines),
+	}
+	timeout := p.OptionalString("timeout", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run job: %w", err)), nil
+	}
+	if command, ok := params.GetArguments()["command"]; ok && command != nil {
+		commandSlice, ok := command.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to run job, command must be an array of strings")), nil
+		}
+		for _, arg := range commandSlice {
+			argString, ok := arg.(string)
+			if !ok {
+				return api.NewToolCallResult("", errors.New("failed to run job, command must be an array of strings")), nil
+			}
+			opts.Command = append(opts.Command, argString)
+		}
+	}
+	if len(opts.Command) > 0 && opts.Manifest != "" {
+		return api.NewToolCallResult("", errors.New("failed to run job, command can't be provided along with a job manifest")), nil
+	}
+	if timeout != "" {
+		var err error
+		if opts.Timeout, err = time.ParseDuration(timeout); err != nil || opts.Timeout <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to run job, invalid timeout '%s' (expected a positive duration such as 30s or 5m)", timeout)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).JobsRun(params, opts, params.Progress())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run job: %w", err)), nil
+	}
+	var sb strings.Builder
+	switch ret.Status {
+	case kubernetes.JobStatusComplete:
+		sb.WriteString(fmt.Sprintf("# Job %s completed successfully in namespace %s\n", ret.Job.Name, ret.Job.Namespace))
+	case kubernetes.JobStatusFailed:
+		sb.WriteString(fmt.Sprintf("# Job %s failed in namespace %s\n", ret.Job.Name, ret.Job.Namespace))
+	default:
+		sb.WriteString(fmt.Sprintf("# Job %s in namespace %s didn't finish within the timeout, it's still running "+
+			"(check it later with resources_wait or resources_get)\n", ret.Job.Name, ret.Job.Namespace))
+	}
+	status, err := output.MarshalYaml(ret.Job.Status)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to run job: %w", err)), nil
+	}
+	sb.WriteString("# Job status (YAML):\n" + status)
+	if ret.LogsError != nil {
+		sb.WriteString(fmt.Sprintf("# The logs of the Job Pod %s could not be retrieved: %s\n", ret.Pod, ret.LogsError))
+	} else if ret.Logs == "" {
+		sb.WriteString(fmt.Sprintf("# The Job Pod %s didn't log any messages\n", ret.Pod))
+	} else {
+		sb.WriteString(fmt.Sprintf("# Logs of the Job Pod %s:\n%s", ret.Pod, ret.Logs))
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
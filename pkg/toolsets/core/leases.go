@@ -0,0 +1,97 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initLeases() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "leases_list",
+			Description: "List the Kubernetes Leases (coordination.k8s.io/v1) with their holder identity, last renew time, duration and number of leader transitions to debug the leader election of controllers and control-plane components " +
+				"(e.g. a component that lost leadership, frequent leadership changes or a holder that stopped renewing its lease). " +
+				"Leases held but not renewed within their leaseDurationSeconds are flagged as STALE. " +
+				"Note that the kube-node-lease namespace contains the heartbeat Lease of every Node",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the Leases from (e.g. kube-system). If not provided, will list Leases from all namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Leases: List",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: leasesList},
+	}
+}
+
+func leasesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list leases: %w", err)), nil
+	}
+	leases, err := kubernetes.NewCore(params).LeasesList(params, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list leases: %w", err)), nil
+	}
+	if len(leases) == 0 {
+		return api.NewToolCallResult("# No leases found", nil), nil
+	}
+	now := time.Now()
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tNAME\tHOLDER\tRENEWED\tDURATION\tTRANSITIONS\tSTATUS")
+	var stale []kubernetes.LeaseStatus
+	for _, lease := range leases {
+		holder, renewed, leaseDuration, status := "<none>", "<never>", "<none>", "Held"
+		if lease.HolderIdentity != "" {
+			holder = lease.HolderIdentity
+		} else {
+			status = "Released"
+		}
+		if lease.RenewTime != nil {
+			renewed = duration.HumanDuration(now.Sub(*lease.RenewTime)) + " ago"
+		}
+		if lease.LeaseDurationSeconds != nil {
+			leaseDuration = fmt.Sprintf("%ds", *lease.LeaseDurationSeconds)
+		}
+		if lease.Stale {
+			status = "STALE"
+			stale = append(stale, lease)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			lease.Namespace, lease.Name, holder, renewed, leaseDuration, lease.LeaseTransitions, status)
+	}
+	_ = w.Flush()
+	if len(stale) > 0 {
+		buf.WriteString("\nThe following Leases are held but weren't renewed within their duration, " +
+			"their holder might have lost the leadership or stopped renewing them (e.g. hung, crashed or unable to reach the API server):\n")
+		for _, lease := range stale {
+			renewed := "never renewed"
+			if lease.RenewTime != nil {
+				renewed = fmt.Sprintf("last renewed at %s", lease.RenewTime.UTC().Format(time.RFC3339))
+			}
+			_, _ = fmt.Fprintf(buf, "- %s/%s held by %s, %s (duration %ds)\n",
+				lease.Namespace, lease.Name, lease.HolderIdentity, renewed, *lease.LeaseDurationSeconds)
+		}
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
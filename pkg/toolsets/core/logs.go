@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initLogs() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "logs_search",
+			Description: "Search the logs of a Kubernetes Pod, or of all the Pods of a workload, within a time window and return only the lines matching a regular expression with their surrounding context lines. " +
+				"Matching lines are marked with > and every line is prefixed with [pod/container], non-contiguous blocks are separated by --. " +
+				fmt.Sprintf("At most %d containers are searched, the logs of each container are scanned up to %d bytes and at most %d matches are returned",
+					kubernetes.MaxLogsSearchContainers, kubernetes.MaxLogsSearchScanBytes, kubernetes.MaxLogsSearchMatches),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod or workload (Optional, current namespace if not provided)",
+					},
+					"target": {
+						Type:        "string",
+						Description: "Pod name (e.g. my-pod or pod/my-pod) or workload whose Pods are searched (deployment/name, statefulset/name or daemonset/name)",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the container to search the logs of (Optional, all containers if not provided)",
+					},
+					"pattern": {
+						Type:        "string",
+						Description: "Regular expression (RE2 syntax) the returned lines must match (e.g. (?i)error|timeout)",
+					},
+					"sinceMinutes": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Only search the logs of the last sinceMinutes minutes (Optional, default: %d)", kubernetes.DefaultLogsSearchSinceMinutes),
+						Default:     api.ToRawMessage(kubernetes.DefaultLogsSearchSinceMinutes),
+						Minimum:     ptr.To(float64(1)),
+					},
+					"contextLines": {
+						Type:        "integer",
+						Description: fmt.Sprintf("Number of lines to return before and after each matching line (Optional, default: %d, max: %d)", kubernetes.DefaultLogsSearchContextLines, kubernetes.MaxLogsSearchContextLines),
+						Default:     api.ToRawMessage(kubernetes.DefaultLogsSearchContextLines),
+						Minimum:     ptr.To(float64(0)),
+						Maximum:     ptr.To(float64(kubernetes.MaxLogsSearchContextLines)),
+					},
+				},
+				Required: []string{"target", "pattern"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Logs: Search",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: logsSearch},
+	}
+}
+
+func logsSearch(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	target := p.RequiredString("target")
+	container := p.OptionalString("container", "")
+	pattern := p.RequiredString("pattern")
+	sinceMinutes := p.OptionalInt64("sinceMinutes", kubernetes.DefaultLogsSearchSinceMinutes)
+	contextLines := p.OptionalInt64("contextLines", kubernetes.DefaultLogsSearchContextLines)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search logs: %w", err)), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search logs: invalid pattern %q: %w", pattern, err)), nil
+	}
+	if sinceMinutes < 1 {
+		return api.NewToolCallResult("", errors.New("failed to search logs: sinceMinutes must be greater than 0")), nil
+	}
+	ret, err := kubernetes.NewCore(params).LogsSearch(params, ns, target, kubernetes.LogsSearchOptions{
+		Container:    container,
+		Pattern:      re,
+		SinceMinutes: sinceMinutes,
+		ContextLines: int(min(max(contextLines, 0), kubernetes.MaxLogsSearchContextLines)),
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to search logs of %s: %w", target, err)), nil
+	}
+	text := strings.Builder{}
+	if ret.Matches == 0 {
+		text.WriteString(fmt.Sprintf("# No lines matching %q in the last %d minutes of the logs of %d containers of %s\n", pattern, sinceMinutes, ret.Containers, target))
+	} else {
+		text.WriteString(fmt.Sprintf("# %d lines matching %q in the last %d minutes of the logs of %d containers of %s:\n", ret.Matches, pattern, sinceMinutes, ret.Containers, target))
+		text.WriteString(ret.Output)
+	}
+	if ret.LimitReached {
+		text.WriteString(fmt.Sprintf("# Search stopped after %d matches, further matching lines were dropped, use a more specific pattern or a shorter time window\n", kubernetes.MaxLogsSearchMatches))
+	}
+	for _, warning := range ret.Warnings {
+		text.WriteString(fmt.Sprintf("# Warning: %s\n", warning))
+	}
+	return api.NewToolCallResult(text.String(), nil), nil
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+func initMesh() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "mesh_injection_status",
+			Description: "Reports the Istio sidecar injection status of the namespaces (istio-injection label, istio.io/rev revision label or ambient mode) and of their pods, flagging the pods missing the istio-proxy sidecar despite being in an injected namespace (a common cause of missing mesh telemetry, e.g. pods created before the namespace was labeled). Returns a table of namespaces and a table of compliant vs. non-compliant pods.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional namespace to check. Defaults to all namespaces.",
+					},
+					"nonCompliantOnly": {
+						Type:        "boolean",
+						Description: "If true, only list the pods missing the sidecar. Default false.",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Mesh: Injection Status",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: meshInjectionStatus},
+	}
+}
+
+func meshInjectionStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	nonCompliantOnly := p.OptionalBool("nonCompliantOnly", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh injection status: %w", err)), nil
+	}
+	status, err := kubernetes.NewCore(params).MeshInjectionStatus(params, namespace, nonCompliantOnly)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get mesh injection status: %w", err)), nil
+	}
+
+	buf := new(bytes.Buffer)
+	nsWriter := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(nsWriter, "NAMESPACE\tINJECTION\tPODS\tWITH SIDECAR\tMISSING SIDECAR")
+	for _, ns := range status.Namespaces {
+		_, _ = fmt.Fprintf(nsWriter, "%s\t%s\t%d\t%d\t%d\n", ns.Name, ns.Injection, ns.Pods, ns.WithSidecar, ns.MissingSidecar)
+	}
+	_ = nsWriter.Flush()
+
+	buf.WriteString("\n")
+	switch {
+	case len(status.Pods) == 0 && nonCompliantOnly:
+		buf.WriteString("No pods missing the sidecar\n")
+	case len(status.Pods) == 0:
+		buf.WriteString("No pods in namespaces with sidecar injection enabled\n")
+	default:
+		podWriter := printers.GetNewTabWriter(buf)
+		_, _ = fmt.Fprintln(podWriter, "NAMESPACE\tPOD\tSTATUS\tSIDECAR\tREASON")
+		for _, pod := range status.Pods {
+			sidecar := "no"
+			if pod.Sidecar {
+				sidecar = "yes"
+			}
+			_, _ = fmt.Fprintf(podWriter, "%s\t%s\t%s\t%s\t%s\n", pod.Namespace, pod.Name, pod.Status, sidecar, pod.Reason)
+		}
+		_ = podWriter.Flush()
+	}
+	if status.Missing > 0 {
+		fmt.Fprintf(buf, "\n%d pod(s) missing the %s sidecar: they don't report mesh telemetry and aren't protected by mTLS. "+
+			"Restart their workloads (e.g. kubectl rollout restart) so that the pods are recreated with the sidecar.\n", status.Missing, kubernetes.IstioProxyContainer)
+	}
+	return api.NewToolCallResult(buf.String(), nil), nil
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// initMigrateWorkload initializes the workload migration prompt
+func initMigrateWorkload() []api.ServerPrompt {
+	return []api.ServerPrompt{
+		{
+			Prompt: api.Prompt{
+				Name:        "migrate-workload",
+				Title:       "Migrate Workload",
+				Description: "Guide the migration of a Deployment, StatefulSet or DaemonSet and its ConfigMaps, Secrets, PersistentVolumeClaims and ServiceAccount to another namespace",
+				Arguments: []api.PromptArgument{
+					{
+						Name:        "namespace",
+						Description: "The namespace the workload is currently deployed to",
+						Required:    true,
+					},
+					{
+						Name:        "kind",
+						Description: "The kind of the workload (Deployment, StatefulSet or DaemonSet)",
+						Required:    true,
+					},
+					{
+						Name:        "name",
+						Description: "The name of the workload to migrate",
+						Required:    true,
+					},
+					{
+						Name:        "target_namespace",
+						Description: "The namespace to migrate the workload to",
+						Required:    true,
+					},
+				},
+			},
+			Handler: migrateWorkloadHandler,
+		},
+	}
+}
+
+// migrateWorkloadHandler implements the workload migration prompt
+func migrateWorkloadHandler(params api.PromptHandlerParams) (*api.PromptCallResult, error) {
+	args := params.GetArguments()
+	namespace := args["namespace"]
+	kind := args["kind"]
+	name := args["name"]
+	targetNamespace := args["target_namespace"]
+	for _, arg := range []string{"namespace", "kind", "name", "target_namespace"} {
+		if args[arg] == "" {
+			return nil, fmt.Errorf("%s argument is required", arg)
+		}
+	}
+	if namespace == targetNamespace {
+		return nil, fmt.Errorf("target_namespace must be different from namespace")
+	}
+
+	export, err := kubernetes.NewCore(params).WorkloadExport(params.Context, namespace, kind, name, targetNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export %s %s in namespace %s: %w", kind, name, namespace, err)
+	}
+	var redactedSecrets []string
+	if !coreConfig(params).SecretsGetEnabled {
+		for _, dependency := range export.Dependencies {
+			if dependency.GetKind() == "Secret" {
+				redactedSecrets = append(redactedSecrets, fmt.Sprintf("%s (keys: %s)", dependency.GetName(), strings.Join(redactSecret(dependency), ", ")))
+			}
+		}
+	}
+
+	promptText, err := formatMigrateWorkloadPrompt(export, namespace, targetNamespace, redactedSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewPromptCallResult(
+		"Workload manifests exported successfully",
+		[]api.PromptMessage{
+			{
+				Role: "user",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: promptText,
+				},
+			},
+			{
+				Role: "assistant",
+				Content: api.PromptContent{
+					Type: "text",
+					Text: fmt.Sprintf("I'll review the exported manifests and migrate the %s %s to the %s namespace step by step.", export.Workload.GetKind(), name, targetNamespace),
+				},
+			},
+		},
+		nil,
+	), nil
+}
+
+// redactSecret removes the data of the Secret and returns its keys
+func redactSecret(secret *unstructured.Unstructured) []string {
+	var keys []string
+	for _, field := range []string{"data", "stringData"} {
+		values, _, _ := unstructured.NestedMap(secret.Object, field)
+		for key := range values {
+			keys = append(keys, key)
+		}
+		delete(secret.Object, field)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// formatMigrateWorkloadPrompt builds the migration guide with the exported manifests
+func formatMigrateWorkloadPrompt(export *kubernetes.WorkloadExport, namespace, targetNamespace string, redactedSecrets []string) (string, error) {
+	kind := export.Workload.GetKind()
+	name := export.Workload.GetName()
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Migrate %s %s from namespace %s to namespace %s\n\n", kind, name, namespace, targetNamespace))
+	sb.WriteString("The manifests of the workload and of the resources it depends on have been exported below. ")
+	sb.WriteString("They have been cleaned of cluster-specific fields (status, uid, resourceVersion, managedFields, ownerReferences, ")
+	sb.WriteString(fmt.Sprintf("bound volume names...) and their namespace has been set to %s.\n\n", targetNamespace))
+	sb.WriteString("Follow these steps, explaining each one to the user before making changes:\n\n")
+	sb.WriteString(fmt.Sprintf("1. Verify the %s namespace exists (`namespaces_list`), create it with `namespaces_create` if it doesn't (with the labels, annotations and PodSecurity levels of the source namespace).\n", targetNamespace))
+	sb.WriteString("2. Recreate the dependencies in the target namespace with `resources_create_or_update`, in the order they are listed (ServiceAccount, ConfigMaps, Secrets, PersistentVolumeClaims). ")
+	sb.WriteString("Skip the ones that already exist in the target namespace after confirming with the user that they are equivalent.\n")
+	sb.WriteString("3. Apply the workload manifest to the target namespace with `resources_create_or_update`.\n")
+	sb.WriteString(fmt.Sprintf("4. Wait for the workload to become available (`resources_wait`) and check its Pods (`pods_list_in_namespace`) and logs (`workload_logs`) in the %s namespace.\n", targetNamespace))
+	sb.WriteString(fmt.Sprintf("5. Do NOT delete or scale down the original workload in the %s namespace unless the user explicitly confirms the migration succeeded and asks for it.\n\n", namespace))
+
+	sb.WriteString("## Considerations\n\n")
+	sb.WriteString("- PersistentVolumeClaims are recreated empty: the data stored in the source volumes is NOT migrated. Tell the user before applying them if the workload relies on persisted data.\n")
+	sb.WriteString("- Services, Ingresses, Routes, NetworkPolicies, RBAC bindings and other resources selecting or granting access to the workload are not included and may need to be recreated.\n")
+	sb.WriteString("- References to the source namespace inside the manifests (e.g. service DNS names such as `<service>." + namespace + ".svc`) may need to be updated.\n")
+	for _, secret := range redactedSecrets {
+		sb.WriteString(fmt.Sprintf("- The data of the Secret %s has been omitted because reading Secret values is disabled on this server. Ask the user to provide the values or to create the Secret in the target namespace.\n", secret))
+	}
+	for _, missing := range export.Missing {
+		sb.WriteString(fmt.Sprintf("- Referenced dependency couldn't be exported: %s. Check whether it's optional or must be created in the target namespace.\n", missing))
+	}
+
+	sb.WriteString("\n## Dependencies\n\n")
+	if len(export.Dependencies) == 0 {
+		sb.WriteString("The workload doesn't reference any ConfigMap, Secret, PersistentVolumeClaim or ServiceAccount.\n")
+	}
+	for _, dependency := range export.Dependencies {
+		yamlDependency, err := output.MarshalYaml(dependency)
+		if err != nil {
+			return "", fmt.Errorf("failed to format %s %s: %w", dependency.GetKind(), dependency.GetName(), err)
+		}
+		sb.WriteString(fmt.Sprintf("### %s %s\n\n```yaml\n%s```\n\n", dependency.GetKind(), dependency.GetName(), yamlDependency))
+	}
+
+	yamlWorkload, err := output.MarshalYaml(export.Workload)
+	if err != nil {
+		return "", fmt.Errorf("failed to format %s %s: %w", kind, name, err)
+	}
+	sb.WriteString(fmt.Sprintf("## Workload\n\n### %s %s\n\n```yaml\n%s```\n", kind, name, yamlWorkload))
+	return sb.String(), nil
+}
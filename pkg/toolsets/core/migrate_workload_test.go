@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrateWorkloadSuite struct {
+	suite.Suite
+}
+
+func (s *MigrateWorkloadSuite) TestPromptIsRegistered() {
+	var found bool
+	for _, prompt := range (&Toolset{}).GetPrompts() {
+		if prompt.Prompt.Name != "migrate-workload" {
+			continue
+		}
+		found = true
+		s.Equal("Migrate Workload", prompt.Prompt.Title)
+		s.Require().Len(prompt.Prompt.Arguments, 4, "should have 4 arguments")
+		for i, name := range []string{"namespace", "kind", "name", "target_namespace"} {
+			s.Equal(name, prompt.Prompt.Arguments[i].Name)
+			s.True(prompt.Prompt.Arguments[i].Required)
+		}
+		s.NotNil(prompt.Handler, "handler should be set")
+	}
+	s.True(found, "migrate-workload prompt should be registered")
+}
+
+func TestMigrateWorkloadSuite(t *testing.T) {
+	suite.Run(t, new(MigrateWorkloadSuite))
+}
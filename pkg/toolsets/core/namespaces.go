@@ -3,12 +3,14 @@ package core
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
 func initNamespaces(o api.Openshift) []api.ServerTool {
@@ -35,6 +37,77 @@ func initNamespaces(o api.Openshift) []api.ServerTool {
 			},
 		}, Handler: namespacesList,
 	})
+	podSecurityLevel := func(mode string) *jsonschema.Schema {
+		return &jsonschema.Schema{
+			Type:        "string",
+			Description: "Pod Security Standards level to " + mode + " (sets the pod-security.kubernetes.io/" + mode + " label)",
+			Enum:        []any{"privileged", "baseline", "restricted"},
+		}
+	}
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        "namespaces_create",
+			Description: "Create a Kubernetes namespace with the provided labels and annotations (e.g. labels selected by NetworkPolicies). The podSecurity shorthand sets the standard pod-security.kubernetes.io/<mode> labels configuring the PodSecurity admission. Labels and PodSecurity levels are validated before creating the namespace",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the namespace",
+					},
+					"labels": {
+						Type:                 "object",
+						Description:          "Optional labels to set on the namespace (e.g. {\"team\": \"payments\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"annotations": {
+						Type:                 "object",
+						Description:          "Optional annotations to set on the namespace",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"podSecurity": {
+						Type:        "object",
+						Description: "Optional PodSecurity admission levels of the namespace per mode (e.g. {\"enforce\": \"baseline\", \"warn\": \"restricted\"})",
+						Properties: map[string]*jsonschema.Schema{
+							"enforce": podSecurityLevel("enforce, Pods violating it are rejected"),
+							"audit":   podSecurityLevel("audit, violations are recorded in the audit log"),
+							"warn":    podSecurityLevel("warn, violations are returned as warnings to the user"),
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespaces: Create",
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespacesCreate,
+	})
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name: "namespace_overview",
+			Description: "Get an overview of the resources in a Kubernetes namespace, equivalent to kubectl get all. " +
+				"Lists the Pods, Services, DaemonSets, Deployments, ReplicaSets, StatefulSets and Jobs (or the resource types configured by the server with scan_kinds) grouped by kind, " +
+				"kinds without resources are omitted",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get the overview for (Optional, current namespace if not provided)",
+					},
+					"output": outputSchema(),
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespace: Overview",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceOverview,
+	})
 	if o.IsOpenShift(context.Background()) {
 		ret = append(ret, api.ServerTool{
 			Tool: api.Tool{
@@ -62,6 +135,9 @@ func namespacesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err := p.Err(); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %w", err)), nil
 	}
+	if err := validateFieldSelector(options.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %w", err)), nil
+	}
 	ret, err := kubernetes.NewCore(params).NamespacesList(params, options)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list namespaces: %w", err)), nil
@@ -69,6 +145,83 @@ func namespacesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
+func namespacesCreate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create namespace: %w", err)), nil
+	}
+	opts := kubernetes.NamespacesCreateOptions{}
+	var err error
+	for argument, value := range map[string]*map[string]string{"labels": &opts.Labels, "annotations": &opts.Annotations, "podSecurity": &opts.PodSecurity} {
+		if *value, err = parseStringMap(params.GetArguments()[argument], argument); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to create namespace: %w", err)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).NamespacesCreate(params, name, opts)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create namespace %s: %w", name, err)), nil
+	}
+	printed, err := output.Yaml.PrintObjStructured(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format namespace: %w", err)), nil
+	}
+	return api.NewToolCallResultFull("# The Namespace (YAML) has been created successfully\n"+printed.Text, printed.Structured, nil), nil
+}
+
+// parseStringMap returns the object argument as a map of strings (nil if not provided)
+func parseStringMap(value interface{}, argument string) (map[string]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", argument)
+	}
+	ret := make(map[string]string, len(m))
+	for key, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of %s %s is not a string", argument, key)
+		}
+		ret[key] = s
+	}
+	return ret, nil
+}
+
+func namespaceOverview(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace overview: %w", err)), nil
+	}
+	out, err := resolveOutput(params, params.ListOutput)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get namespace overview: %w", err)), nil
+	}
+	core := kubernetes.NewCore(params)
+	namespace = core.NamespaceOrDefault(namespace)
+	lists := core.NamespaceOverview(params, namespace, params.GetScanKinds(), api.ListOptions{AsTable: out.AsTable()})
+	if len(lists) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No resources found in %s namespace.", namespace), nil), nil
+	}
+	text := strings.Builder{}
+	for _, list := range lists {
+		kind := list.GroupVersionKind.GroupKind().String()
+		if list.Err != nil {
+			fmt.Fprintf(&text, "# %s: failed to list resources: %s\n\n", kind, list.Err)
+			continue
+		}
+		printed, printErr := out.PrintObj(list.List)
+		if printErr != nil {
+			fmt.Fprintf(&text, "# %s: failed to format resources: %s\n\n", kind, printErr)
+			continue
+		}
+		fmt.Fprintf(&text, "# %s\n%s\n", kind, printed)
+	}
+	return api.NewToolCallResult(text.String(), nil), nil
+}
+
 func projectsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	ret, err := kubernetes.NewCore(params).ProjectsList(params, api.ListOptions{AsTable: params.ListOutput.AsTable()})
 	if err != nil {
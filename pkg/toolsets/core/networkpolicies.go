@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initNetworkPolicies() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "networkpolicies_analyze",
+			Description: "Analyze the Kubernetes NetworkPolicies affecting a Pod (or a whole namespace if no Pod is provided): lists the NetworkPolicies whose podSelector selects the target with a summary of their ingress/egress rules, and reports the effective isolation of the Pod, highlighting when a default-deny is in effect. Useful to troubleshoot connectivity issues between Pods",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to analyze (Optional, current namespace if not provided)",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Name of the Pod to analyze (Optional, all the NetworkPolicies in the namespace are analyzed if not provided)",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "NetworkPolicies: Analyze",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: networkPoliciesAnalyze},
+		{Tool: api.Tool{
+			Name: "connectivity_test",
+			Description: "Test if a Pod can reach another Pod, a Service or a host on a port, and explain why not: runs a TCP (nc or bash) or HTTP (curl or wget) probe from the source Pod container (like pods_exec) and reports whether the target was reached. " +
+				"If the target isn't reached, the NetworkPolicies restricting the egress of the source Pod and the ingress of the target Pod (a backend Pod for a Service) are analyzed (like networkpolicies_analyze). " +
+				"The probe requires a shell and one of the probe tools in the source container",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the source Pod (Optional, current namespace if not provided)",
+					},
+					"pod": {
+						Type:        "string",
+						Description: "Name of the source Pod where the probe runs",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the source Pod container where the probe runs (Optional, the default container if not provided)",
+					},
+					"target": {
+						Type:        "string",
+						Description: "Target to reach: pod/<name>, service/<name> or a host name or IP address (e.g. example.com or example.com:443)",
+					},
+					"targetNamespace": {
+						Type:        "string",
+						Description: "Namespace of the pod/<name> or service/<name> target (Optional, the namespace of the source Pod if not provided)",
+					},
+					"port": {
+						Type:        "integer",
+						Description: "Port of the target (Optional for a host:port target or a Service with a single port)",
+						Minimum:     ptr.To(float64(1)),
+						Maximum:     ptr.To(float64(65535)),
+					},
+					"protocol": {
+						Type:        "string",
+						Description: "Probe protocol: tcp (the connection is established) or http (an HTTP GET request gets a response) (Optional, default tcp)",
+						Enum:        []any{kubernetes.ConnectivityProtocolTCP, kubernetes.ConnectivityProtocolHTTP},
+						Default:     api.ToRawMessage(kubernetes.ConnectivityProtocolTCP),
+					},
+					"timeout": {
+						Type:        "string",
+						Description: fmt.Sprintf("Timeout of the probe as a duration (e.g. 5s, 1m) (Optional, default %s)", kubernetes.DefaultConnectivityTestTimeout),
+						Default:     api.ToRawMessage(kubernetes.DefaultConnectivityTestTimeout.String()),
+					},
+				},
+				Required: []string{"pod", "target"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Connectivity: Test",
+				ReadOnlyHint:    ptr.To(false),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: connectivityTest},
+	}
+}
+
+func networkPoliciesAnalyze(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	pod := p.OptionalString("pod", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to analyze network policies: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NetworkPoliciesAnalyze(params, namespace, pod)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to analyze network policies in namespace %s: %w", namespace, err)), nil
+	}
+	yamlAnalysis, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to analyze network policies: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following NetworkPolicies analysis (YAML format) was generated:\n%s", yamlAnalysis), err), nil
+}
+
+func connectivityTest(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	options := kubernetes.ConnectivityTestOptions{
+		Namespace:       p.OptionalString("namespace", ""),
+		Pod:             p.RequiredString("pod"),
+		Container:       p.OptionalString("container", ""),
+		Target:          p.RequiredString("target"),
+		TargetNamespace: p.OptionalString("targetNamespace", ""),
+		Protocol:        p.OptionalString("protocol", kubernetes.ConnectivityProtocolTCP),
+	}
+	port := p.OptionalInt64("port", 0)
+	timeout := p.OptionalString("timeout", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to test connectivity: %w", err)), nil
+	}
+	if port < 0 || port > 65535 {
+		return api.NewToolCallResult("", fmt.Errorf("failed to test connectivity: port must be between 1 and 65535")), nil
+	}
+	if timeout != "" {
+		var err error
+		if options.Timeout, err = time.ParseDuration(timeout); err != nil || options.Timeout <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to test connectivity: invalid timeout %q, must be a positive duration (e.g. 5s)", timeout)), nil
+		}
+	}
+	options.Port = int32(port)
+	ret, err := kubernetes.NewCore(params).ConnectivityTest(params, options)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to test connectivity from pod %s to %s: %w", options.Pod, options.Target, err)), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to test connectivity from pod %s to %s: %w", options.Pod, options.Target, err)), nil
+	}
+	var note string
+	switch ret.Result {
+	case kubernetes.ConnectivityReachable:
+		note = fmt.Sprintf("# %s can reach %s (%s %s)\n", ret.Source, ret.Target, ret.Protocol, ret.Address)
+	case kubernetes.ConnectivityUnreachable:
+		note = fmt.Sprintf("# %s can't reach %s (%s %s), the NetworkPolicies that apply are below\n", ret.Source, ret.Target, ret.Protocol, ret.Address)
+	default:
+		note = fmt.Sprintf("# The connectivity of %s to %s (%s %s) couldn't be tested, the NetworkPolicies that apply are below\n", ret.Source, ret.Target, ret.Protocol, ret.Address)
+	}
+	return api.NewToolCallResult(note+marshalled, nil), nil
+}
@@ -4,15 +4,24 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/metricsutil"
+	"k8s.io/metrics/pkg/apis/metrics"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/confirmation"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 )
 
@@ -30,7 +39,18 @@ func initNodes() []api.ServerTool {
 					},
 					"query": {
 						Type:        "string",
-						Description: `query specifies services(s) or files from which to return logs (required). Example: "kubelet" to fetch kubelet logs, "/<log-file-name>" to fetch a specific log file from the node (e.g., "/var/log/kubelet.log" or "/var/log/kube-proxy.log")`,
+						Description: `query specifies services(s) or files from which to return logs (required unless queries is provided). Example: "kubelet" to fetch kubelet logs, "/<log-file-name>" to fetch a specific log file from the node (e.g., "/var/log/kubelet.log" or "/var/log/kube-proxy.log")`,
+					},
+					"queries": {
+						Type:        "array",
+						Description: `List of services (journal units) or files from which to return logs, each one is queried separately and the output is concatenated delimited by a "==> <query> <==" header (e.g. ["kubelet", "containerd", "/var/log/kube-proxy.log"]). Can be combined with query (Optional)`,
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"pattern": {
+						Type:        "string",
+						Description: "Regular expression to filter the log lines, applied by the kubelet on the node (Optional, requires a kubelet with node log query support)",
 					},
 					"tailLines": {
 						Type:        "integer",
@@ -39,7 +59,7 @@ func initNodes() []api.ServerTool {
 						Minimum:     ptr.To(float64(0)),
 					},
 				},
-				Required: []string{"name", "query"},
+				Required: []string{"name"},
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Node: Log",
@@ -58,6 +78,13 @@ func initNodes() []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the node to get stats from",
 					},
+					"fields": {
+						Type:        "array",
+						Description: "Dot-separated paths of the stats summary fields to return (e.g. 'node.cpu', 'node.memory.workingSetBytes', 'pods[].podRef.name', 'pods[].containers[].cpu'), arrays are traversed with the [] suffix. Use it to reduce the size of the output (Optional, the full stats summary is returned if not provided)",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -70,10 +97,10 @@ func initNodes() []api.ServerTool {
 		}, Handler: nodesStatsSummary},
 		{Tool: api.Tool{
 			Name:        "nodes_top",
-			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Nodes or all nodes in the cluster",
+			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Nodes or all nodes in the cluster, optionally sorted by consumption and limited (e.g. top 10 nodes by memory)",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
-				Properties: map[string]*jsonschema.Schema{
+				Properties: mergeProperties(map[string]*jsonschema.Schema{
 					"name": {
 						Type:        "string",
 						Description: "Name of the Node to get the resource consumption from (Optional, all Nodes if not provided)",
@@ -83,7 +110,11 @@ func initNodes() []api.ServerTool {
 						Description: "Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker=') to filter nodes by label (Optional, only applicable when name is not provided)",
 						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
 					},
-				},
+					"groupByLabel": {
+						Type:        "string",
+						Description: "Node label key (e.g. 'topology.kubernetes.io/zone' or 'node-role.kubernetes.io/control-plane') to additionally summarize the resource consumption by the label value, printing the number of nodes and the total and average usage per group (Optional, only the returned nodes are summarized when limit is provided)",
+					},
+				}, topOptionsProperties("nodes")),
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Nodes: Top",
@@ -93,6 +124,133 @@ func initNodes() []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: nodesTop},
+		{Tool: api.Tool{
+			Name:        "nodes_capacity",
+			Description: "Show the scheduling capacity of the Kubernetes Nodes: for every Node, the CPU and memory requested by its Pods compared to the Node allocatable resources, the headroom still available to new Pods and the Nodes that are over-committed (requests or limits above the allocatable resources). Unlike nodes_top, which reports the actual usage from the Metrics Server, this is the accounting the scheduler uses to decide whether new Pods fit",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"label_selector": {
+						Type:        "string",
+						Description: "Kubernetes label selector (e.g. 'node-role.kubernetes.io/worker=') to filter nodes by label (Optional, all Nodes if not provided)",
+						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Capacity",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesCapacity},
+		{Tool: api.Tool{
+			Name:        "nodes_decommission",
+			Description: "Decommission a Kubernetes Node end-to-end: cordon it (mark it unschedulable), drain it by evicting its Pods through the Eviction API (PodDisruptionBudgets are honored, DaemonSet and static Pods are left on the Node) and, once all the evicted Pods are gone, delete the Node object. Intended for the cleanup of nodes removed from the cluster (e.g. scaled down by an autoscaler or replaced). If a step fails the Node is left cordoned and the completed steps are reported. Requires explicit confirmation (confirm=true), the user is prompted for confirmation when supported by the client",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Node to decommission",
+					},
+					"gracePeriodSeconds": {
+						Type:        "integer",
+						Description: "Termination grace period in seconds of the evicted Pods (Optional, the Pods' own grace period is used if not provided or negative)",
+						Default:     api.ToRawMessage(-1),
+					},
+					"timeout": {
+						Type:        "string",
+						Description: fmt.Sprintf("Maximum time to wait for the Pods to be evicted as a duration (e.g. 30s, 5m), evictions blocked by a PodDisruptionBudget are retried until it expires (Optional, default %s, max %s)", kubernetes.DefaultNodesDecommissionTimeout, kubernetes.MaxNodesDecommissionTimeout),
+						Default:     api.ToRawMessage(kubernetes.DefaultNodesDecommissionTimeout.String()),
+					},
+					"force": {
+						Type:        "boolean",
+						Description: "Evict the Pods that are not managed by a controller too, they won't be recreated on another Node (Optional, the decommission fails if there are such Pods otherwise)",
+						Default:     api.ToRawMessage(false),
+					},
+					"confirm": {
+						Type:        "boolean",
+						Description: "Must be true to confirm the decommission of the Node",
+					},
+				},
+				Required: []string{"name", "confirm"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Decommission",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesDecommission},
+		{Tool: api.Tool{
+			Name:        "nodes_taint",
+			Description: "Add a taint to a Kubernetes Node (same as kubectl taint nodes <name> key=value:effect), e.g. to dedicate the Node to the workloads that tolerate it. Adding a taint that is already present is a no-op, a taint with the same key and effect but a different value is only replaced if overwrite is true. Returns the taints of the Node. Note that the NoExecute effect evicts the running Pods that don't tolerate the taint",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Node to taint",
+					},
+					"key": {
+						Type:        "string",
+						Description: "Key of the taint (e.g. dedicated or example.com/gpu)",
+					},
+					"value": {
+						Type:        "string",
+						Description: "Value of the taint (Optional)",
+					},
+					"effect": {
+						Type:        "string",
+						Description: "Effect of the taint on the Pods that don't tolerate it: NoSchedule (not scheduled on the Node), PreferNoSchedule (avoid scheduling on the Node) or NoExecute (not scheduled and evicted if running)",
+						Enum:        []any{"NoSchedule", "PreferNoSchedule", "NoExecute"},
+					},
+					"overwrite": {
+						Type:        "boolean",
+						Description: "Replace the value of an existing taint with the same key and effect (Optional, fails if there is such a taint otherwise)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"name", "key", "effect"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Taint",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesTaint},
+		{Tool: api.Tool{
+			Name:        "nodes_untaint",
+			Description: "Remove a taint from a Kubernetes Node (same as kubectl taint nodes <name> key[:effect]-). All the taints with the provided key are removed unless an effect is provided. Removing a taint that is not present is a no-op. Returns the remaining taints of the Node",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"name": {
+						Type:        "string",
+						Description: "Name of the Node to remove the taint from",
+					},
+					"key": {
+						Type:        "string",
+						Description: "Key of the taint to remove",
+					},
+					"effect": {
+						Type:        "string",
+						Description: "Effect of the taint to remove (Optional, the taints with the key and any effect are removed if not provided)",
+						Enum:        []any{"NoSchedule", "PreferNoSchedule", "NoExecute"},
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Nodes: Untaint",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: nodesUntaint},
 	}
 }
 
@@ -101,10 +259,34 @@ func nodesLog(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if !ok || name == "" {
 		return api.NewToolCallResult("", errors.New("failed to get node log, missing argument name")), nil
 	}
-	query, ok := params.GetArguments()["query"].(string)
-	if !ok || query == "" {
+	var queries []string
+	if query, ok := params.GetArguments()["query"].(string); ok && query != "" {
+		queries = append(queries, query)
+	}
+	if v, ok := params.GetArguments()["queries"]; ok && v != nil {
+		querySlice, ok := v.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to get node log, queries parameter must be an array of strings")), nil
+		}
+		for _, q := range querySlice {
+			query, ok := q.(string)
+			if !ok {
+				return api.NewToolCallResult("", errors.New("failed to get node log, queries parameter must be an array of strings")), nil
+			}
+			if query != "" && !slices.Contains(queries, query) {
+				queries = append(queries, query)
+			}
+		}
+	}
+	if len(queries) == 0 {
 		return api.NewToolCallResult("", errors.New("failed to get node log, missing argument query")), nil
 	}
+	pattern, _ := params.GetArguments()["pattern"].(string)
+	if pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get node log, invalid pattern: %w", err)), nil
+		}
+	}
 	tailLines := params.GetArguments()["tailLines"]
 	var tailInt int64
 	if tailLines != nil {
@@ -114,7 +296,11 @@ func nodesLog(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 			return api.NewToolCallResult("", fmt.Errorf("failed to parse tailLines parameter: %w", err)), nil
 		}
 	}
-	ret, err := kubernetes.NewCore(params).NodesLog(params, name, query, tailInt)
+	ret, err := kubernetes.NewCore(params).NodesLog(params, name, api.NodesLogOptions{
+		Queries:   queries,
+		Pattern:   pattern,
+		TailLines: tailInt,
+	})
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get node log for %s: %w", name, err)), nil
 	} else if ret == "" {
@@ -128,7 +314,21 @@ func nodesStatsSummary(params api.ToolHandlerParams) (*api.ToolCallResult, error
 	if !ok || name == "" {
 		return api.NewToolCallResult("", errors.New("failed to get node stats summary, missing argument name")), nil
 	}
-	ret, err := kubernetes.NewCore(params).NodesStatsSummary(params, name)
+	var fields []string
+	if v, ok := params.GetArguments()["fields"]; ok && v != nil {
+		fieldSlice, ok := v.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to get node stats summary, fields parameter must be an array of strings")), nil
+		}
+		for _, field := range fieldSlice {
+			f, ok := field.(string)
+			if !ok || strings.TrimSpace(f) == "" {
+				return api.NewToolCallResult("", errors.New("failed to get node stats summary, fields parameter must be an array of strings")), nil
+			}
+			fields = append(fields, f)
+		}
+	}
+	ret, err := kubernetes.NewCore(params).NodesStatsSummary(params, name, fields)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get node stats summary for %s: %w", name, err)), nil
 	}
@@ -136,13 +336,23 @@ func nodesStatsSummary(params api.ToolHandlerParams) (*api.ToolCallResult, error
 }
 
 func nodesTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	nodesTopOptions := api.NodesTopOptions{}
+	p := api.WrapParams(params)
+	nodesTopOptions := api.NodesTopOptions{TopOptions: topOptions(p)}
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top: %w", err)), nil
+	}
 	if v, ok := params.GetArguments()["name"].(string); ok {
 		nodesTopOptions.Name = v
 	}
 	if v, ok := params.GetArguments()["label_selector"].(string); ok {
 		nodesTopOptions.LabelSelector = v
 	}
+	groupByLabel, _ := params.GetArguments()["groupByLabel"].(string)
+	if groupByLabel != "" {
+		if errs := validation.IsQualifiedName(groupByLabel); len(errs) > 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get nodes top: invalid groupByLabel %q: %s", groupByLabel, strings.Join(errs, ", "))), nil
+		}
+	}
 
 	nodeMetrics, err := kubernetes.NewCore(params).NodesTop(params, nodesTopOptions)
 	if err != nil {
@@ -158,8 +368,19 @@ func nodesTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	}
 
 	// Build availableResources map
+	// When the metrics were limited, the nodes left out must not be printed (the printer lists nodes without metrics)
+	var returnedNodes map[string]bool
+	if nodeMetrics.RemainingItemCount != nil {
+		returnedNodes = make(map[string]bool, len(nodeMetrics.Items))
+		for _, m := range nodeMetrics.Items {
+			returnedNodes[m.Name] = true
+		}
+	}
 	availableResources := make(map[string]v1.ResourceList)
 	for _, n := range nodeList.Items {
+		if returnedNodes != nil && !returnedNodes[n.Name] {
+			continue
+		}
 		availableResources[n.Name] = n.Status.Allocatable
 
 		// Handle swap if available
@@ -172,10 +393,259 @@ func nodesTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	// Print the metrics
 	buf := new(bytes.Buffer)
 	printer := metricsutil.NewTopCmdPrinter(buf, true)
-	err = printer.PrintNodeMetrics(nodeMetrics.Items, availableResources, false, "")
+	err = printer.PrintNodeMetrics(nodeMetrics.Items, availableResources, false, nodesTopOptions.SortBy)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to print node metrics: %w", err)), nil
 	}
+	buf.WriteString(topRemainingNote(nodeMetrics.RemainingItemCount, "nodes"))
+	if groupByLabel != "" {
+		buf.WriteString("\n")
+		printNodeMetricsByLabel(buf, groupByLabel, nodeMetrics.Items, nodeList.Items)
+	}
 
 	return api.NewToolCallResult(buf.String(), nil), nil
 }
+
+// nodeMetricsGroup aggregates the resource consumption of the nodes sharing the same label value
+type nodeMetricsGroup struct {
+	nodes             int64
+	cpuUsage          resource.Quantity
+	memoryUsage       resource.Quantity
+	cpuAllocatable    resource.Quantity
+	memoryAllocatable resource.Quantity
+}
+
+// printNodeMetricsByLabel prints the total and average resource consumption of the nodes grouped by the value of the label.
+// Nodes without the label are grouped as <none>.
+func printNodeMetricsByLabel(buf *bytes.Buffer, label string, nodeMetrics []metrics.NodeMetrics, nodes []v1.Node) {
+	nodesByName := make(map[string]*v1.Node, len(nodes))
+	for i := range nodes {
+		nodesByName[nodes[i].Name] = &nodes[i]
+	}
+	groups := map[string]*nodeMetricsGroup{}
+	for _, m := range nodeMetrics {
+		node, ok := nodesByName[m.Name]
+		if !ok {
+			continue
+		}
+		value, ok := node.Labels[label]
+		switch {
+		case !ok:
+			value = "<none>"
+		case value == "":
+			value = `""`
+		}
+		group, ok := groups[value]
+		if !ok {
+			group = &nodeMetricsGroup{}
+			groups[value] = group
+		}
+		group.nodes++
+		group.cpuUsage.Add(m.Usage[v1.ResourceCPU])
+		group.memoryUsage.Add(m.Usage[v1.ResourceMemory])
+		group.cpuAllocatable.Add(node.Status.Allocatable[v1.ResourceCPU])
+		group.memoryAllocatable.Add(node.Status.Allocatable[v1.ResourceMemory])
+	}
+	w := printers.GetNewTabWriter(buf)
+	defer func() { _ = w.Flush() }()
+	_, _ = fmt.Fprintf(w, "%s\tNODES\tCPU(cores)\tCPU(%%)\tAVG CPU(cores)\tMEMORY(bytes)\tMEMORY(%%)\tAVG MEMORY(bytes)\n", strings.ToUpper(label))
+	for _, value := range slices.Sorted(maps.Keys(groups)) {
+		group := groups[value]
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%vm\t%s\t%vm\t%vMi\t%s\t%vMi\n", value, group.nodes,
+			group.cpuUsage.MilliValue(), usagePercentage(group.cpuUsage.MilliValue(), group.cpuAllocatable.MilliValue()),
+			group.cpuUsage.MilliValue()/group.nodes,
+			group.memoryUsage.Value()/(1024*1024), usagePercentage(group.memoryUsage.Value(), group.memoryAllocatable.Value()),
+			group.memoryUsage.Value()/group.nodes/(1024*1024))
+	}
+}
+
+func usagePercentage(usage, allocatable int64) string {
+	if allocatable == 0 {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%d%%", usage*100/allocatable)
+}
+
+func nodesCapacity(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	labelSelector := p.OptionalString("label_selector", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes capacity: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NodesCapacity(params, labelSelector)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get nodes capacity: %w", err)), nil
+	}
+	return api.NewToolCallResult(formatNodesCapacity(ret), nil), nil
+}
+
+// formatNodesCapacity prints the requests, headroom and limits of every node followed by the total of the schedulable
+// nodes, the over-committed nodes and the largest headroom available on a single node (the biggest Pod that fits)
+func formatNodesCapacity(ret *kubernetes.NodesCapacityResult) string {
+	if len(ret.Nodes) == 0 {
+		return "No nodes found\n"
+	}
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NODE\tSTATUS\tPODS\tCPU REQUESTS\tCPU(%)\tCPU HEADROOM\tCPU LIMITS(%)\tMEMORY REQUESTS\tMEMORY(%)\tMEMORY HEADROOM\tMEMORY LIMITS(%)")
+	printRow := func(status string, n *kubernetes.NodeCapacity) {
+		cpuHeadroom, memoryHeadroom := n.CPU.Headroom(), n.Memory.Headroom()
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d/%d\t%vm/%vm\t%s\t%vm\t%s\t%vMi/%vMi\t%s\t%vMi\t%s\n", n.Name, status, n.Pods, n.AllocatablePods,
+			n.CPU.Requests.MilliValue(), n.CPU.Allocatable.MilliValue(), usagePercentage(n.CPU.Requests.MilliValue(), n.CPU.Allocatable.MilliValue()),
+			cpuHeadroom.MilliValue(), usagePercentage(n.CPU.Limits.MilliValue(), n.CPU.Allocatable.MilliValue()),
+			n.Memory.Requests.Value()/(1024*1024), n.Memory.Allocatable.Value()/(1024*1024), usagePercentage(n.Memory.Requests.Value(), n.Memory.Allocatable.Value()),
+			memoryHeadroom.Value()/(1024*1024), usagePercentage(n.Memory.Limits.Value(), n.Memory.Allocatable.Value()))
+	}
+	var overCommitted []string
+	var largestCPU, largestMemory *kubernetes.NodeCapacity
+	for i := range ret.Nodes {
+		n := &ret.Nodes[i]
+		status := "Schedulable"
+		if !n.Schedulable {
+			status = "Unschedulable"
+		}
+		if len(n.OverCommitted) > 0 {
+			status += ",OverCommitted"
+			overCommitted = append(overCommitted, fmt.Sprintf("%s (%s)", n.Name, strings.Join(n.OverCommitted, ", ")))
+		}
+		printRow(status, n)
+		if !n.Schedulable || n.Pods >= n.AllocatablePods {
+			continue
+		}
+		if cpu := n.CPU.Headroom(); largestCPU == nil || cpu.Cmp(largestCPU.CPU.Headroom()) > 0 {
+			largestCPU = n
+		}
+		if memory := n.Memory.Headroom(); largestMemory == nil || memory.Cmp(largestMemory.Memory.Headroom()) > 0 {
+			largestMemory = n
+		}
+	}
+	printRow("", &ret.Total)
+	_ = w.Flush()
+	buf.WriteString("\nThe total only includes the schedulable nodes (not cordoned and Ready).\n")
+	if len(overCommitted) > 0 {
+		buf.WriteString(fmt.Sprintf("Over-committed nodes (requests or limits above the allocatable resources): %s\n", strings.Join(overCommitted, "; ")))
+	} else {
+		buf.WriteString("No node is over-committed.\n")
+	}
+	if largestCPU == nil {
+		buf.WriteString("No schedulable node can accept new Pods.\n")
+	} else {
+		cpuHeadroom, memoryHeadroom := largestCPU.CPU.Headroom(), largestMemory.Memory.Headroom()
+		buf.WriteString(fmt.Sprintf("Largest headroom on a single schedulable node: cpu %vm (%s), memory %vMi (%s).\n",
+			cpuHeadroom.MilliValue(), largestCPU.Name, memoryHeadroom.Value()/(1024*1024), largestMemory.Name))
+	}
+	return buf.String()
+}
+
+func nodesDecommission(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	opts := kubernetes.NodesDecommissionOptions{
+		GracePeriodSeconds: p.OptionalInt64("gracePeriodSeconds", -1),
+		Force:              p.OptionalBool("force", false),
+	}
+	timeout := p.OptionalString("timeout", "")
+	confirm := p.OptionalBool("confirm", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to decommission node: %w", err)), nil
+	}
+	if timeout != "" {
+		var err error
+		if opts.Timeout, err = time.ParseDuration(timeout); err != nil || opts.Timeout <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to decommission node, invalid timeout '%s' (expected a positive duration such as 30s or 5m)", timeout)), nil
+		}
+	}
+	if !confirm {
+		return api.NewToolCallResult("", fmt.Errorf("failed to decommission node: decommissioning a node requires explicit confirmation (confirm=true)")), nil
+	}
+	message := fmt.Sprintf("Decommission node %s? It will be cordoned, its Pods evicted and the Node deleted", name)
+	if err := confirmation.CheckConfirmation(params, params.Elicitor, message, params.GetConfirmationFallback()); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to decommission node: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NodesDecommission(params, name, opts, params.Progress())
+	report := formatNodesDecommission(name, ret)
+	if err != nil {
+		if ret.Cordoned || len(ret.Evicted) > 0 {
+			err = fmt.Errorf("%w\n\nThe node %s is left cordoned, the following steps were completed:\n%s", err, name, report)
+		}
+		return api.NewToolCallResult("", err), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# Node %s decommissioned\n", name)+report, nil), nil
+}
+
+// formatNodesDecommission returns the steps performed by the decommission of the Node
+func formatNodesDecommission(name string, ret *kubernetes.NodesDecommissionResult) string {
+	sb := strings.Builder{}
+	if ret.Cordoned {
+		sb.WriteString(fmt.Sprintf("- Cordoned node %s\n", name))
+	}
+	for _, pod := range ret.Evicted {
+		sb.WriteString(fmt.Sprintf("- Evicted pod %s\n", pod))
+	}
+	for _, pod := range ret.Skipped {
+		sb.WriteString(fmt.Sprintf("- Skipped pod %s\n", pod))
+	}
+	if ret.Deleted {
+		sb.WriteString(fmt.Sprintf("- Deleted node %s\n", name))
+	}
+	return sb.String()
+}
+
+func nodesTaint(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	taint := v1.Taint{
+		Key:    p.RequiredString("key"),
+		Value:  p.OptionalString("value", ""),
+		Effect: v1.TaintEffect(p.RequiredString("effect")),
+	}
+	overwrite := p.OptionalBool("overwrite", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NodesTaint(params, name, taint, overwrite)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to taint node %s: %w", name, err)), nil
+	}
+	header := fmt.Sprintf("# Node %s tainted with %s\n", name, kubernetes.FormatNodeTaint(taint))
+	if !ret.Changed {
+		header = fmt.Sprintf("# Node %s already has the taint %s, nothing changed\n", name, kubernetes.FormatNodeTaint(taint))
+	}
+	return api.NewToolCallResult(header+formatNodeTaints(ret.Taints), nil), nil
+}
+
+func nodesUntaint(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	name := p.RequiredString("name")
+	key := p.RequiredString("key")
+	effect := p.OptionalString("effect", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to untaint node: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).NodesUntaint(params, name, key, effect)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to untaint node %s: %w", name, err)), nil
+	}
+	taint := key
+	if effect != "" {
+		taint += ":" + effect
+	}
+	header := fmt.Sprintf("# Taint %s removed from node %s\n", taint, name)
+	if !ret.Changed {
+		header = fmt.Sprintf("# Node %s doesn't have the taint %s, nothing changed\n", name, taint)
+	}
+	return api.NewToolCallResult(header+formatNodeTaints(ret.Taints), nil), nil
+}
+
+// formatNodeTaints lists the taints of the Node in the kubectl format (key=value:effect)
+func formatNodeTaints(taints []v1.Taint) string {
+	if len(taints) == 0 {
+		return "The node has no taints\n"
+	}
+	sb := strings.Builder{}
+	sb.WriteString("Taints:\n")
+	for _, taint := range taints {
+		sb.WriteString("- " + kubernetes.FormatNodeTaint(taint) + "\n")
+	}
+	return sb.String()
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/kubectl/pkg/metricsutil"
@@ -71,6 +72,36 @@ func initPods() []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: podsListInNamespace},
+		{Tool: api.Tool{
+			Name:        "pods_on_node",
+			Description: "List all the Kubernetes pods scheduled on the specified node from all namespaces (e.g. to find out what is running on an unhealthy node, pairs with nodes_top and nodes_stats_summary)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"node": {
+						Type:        "string",
+						Description: "Name of the node to list the pods from",
+					},
+					"labelSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label",
+						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
+					},
+					"fieldSelector": {
+						Type:        "string",
+						Description: "Optional Kubernetes field selector to further filter the pods by field values (e.g. 'status.phase=Running'). Supported fields: metadata.name, metadata.namespace, spec.restartPolicy, spec.schedulerName, spec.serviceAccountName, status.phase (Pending/Running/Succeeded/Failed/Unknown), status.podIP, status.nominatedNodeName",
+						Pattern:     REGEX_FIELDSELECTOR,
+					},
+				},
+				Required: []string{"node"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: List on Node",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsListOnNode},
 		{Tool: api.Tool{
 			Name:        "pods_get",
 			Description: "Get a Kubernetes Pod in the current or provided namespace with the provided name",
@@ -120,11 +151,35 @@ func initPods() []api.ServerTool {
 			},
 		}, Handler: podsDelete},
 		{Tool: api.Tool{
-			Name:        "pods_top",
-			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Pods in the all namespaces, the provided namespace, or the current namespace",
+			Name:        "pods_evict",
+			Description: "Evict a Kubernetes Pod in the current or provided namespace through the Eviction API (policy/v1), the Pod is only deleted if its PodDisruptionBudgets allow the disruption. Returns whether the eviction was allowed or blocked, and by which PodDisruptionBudget. Useful to rebalance workloads or clear a stuck Pod safely (a Pod managed by a controller is recreated, possibly on another Node)",
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod to evict",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Evict",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsEvict},
+		{Tool: api.Tool{
+			Name:        "pods_top",
+			Description: "List the resource consumption (CPU and memory) as recorded by the Kubernetes Metrics Server for the specified Kubernetes Pods in the all namespaces, the provided namespace, or the current namespace, optionally sorted by consumption and limited (e.g. top 10 pods by memory)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: mergeProperties(map[string]*jsonschema.Schema{
 					"all_namespaces": {
 						Type:        "boolean",
 						Description: "If true, list the resource consumption for all Pods in all namespaces. If false, list the resource consumption for Pods in the provided namespace or the current namespace",
@@ -143,7 +198,7 @@ func initPods() []api.ServerTool {
 						Description: "Kubernetes label selector (e.g. 'app=myapp,env=prod' or 'app in (myapp,yourapp)'), use this option when you want to filter the pods by label (Optional, only applicable when name is not provided)",
 						Pattern:     REGEX_LABELSELECTOR_VALID_CHARS,
 					},
-				},
+				}, topOptionsProperties("pods")),
 			},
 			Annotations: api.ToolAnnotations{
 				Title:           "Pods: Top",
@@ -225,6 +280,110 @@ func initPods() []api.ServerTool {
 				OpenWorldHint:   ptr.To(true),
 			},
 		}, Handler: podsLog},
+		{Tool: api.Tool{
+			Name:        "pods_scheduling_info",
+			Description: "Explain why a Kubernetes Pod is Pending (or can't be scheduled) in the current or provided namespace by comparing its scheduling requirements against every node in the cluster. Reports the Pod resource requests and limits, nodeSelector, required node affinity and tolerations, and for each node its allocatable and available resources, its taints, and the reasons it can't accommodate the Pod (insufficient resources, untolerated taints, nodeSelector or node affinity mismatches, unschedulable nodes)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Scheduling Info",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsSchedulingInfo},
+		{Tool: api.Tool{
+			Name:        "pods_readiness_explain",
+			Description: "Explain in plain language why a Kubernetes Pod in the current or provided namespace isn't Ready (e.g. not scheduled, init container still running or failing, container waiting in CrashLoopBackOff or ImagePullBackOff, startup probe not passed yet, failing readiness probe, unsatisfied readiness gates). Interprets the Pod conditions (PodScheduled, Initialized, ContainersReady, Ready) and the state, probes and recent probe failure events of every container",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Readiness Explain",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsReadinessExplain},
+		{Tool: api.Tool{
+			Name:        "pods_env",
+			Description: "Get the effective environment variables of a Kubernetes Pod container in the current or provided namespace, as seen by the container: resolves envFrom sources, values referencing ConfigMap and Secret keys (valueFrom), downward API fieldRef and resourceFieldRef values, and $(VAR_NAME) references, reporting the source of each variable and flagging the missing references. Secret values are redacted (only the keys are shown) unless Secret access (secrets_get) is explicitly enabled in the server configuration",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the Pod container (or init container) to get the environment for (Optional, the first container if not provided)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Environment",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsEnv},
+		{Tool: api.Tool{
+			Name:        "pods_template_drift",
+			Description: "Check whether a Kubernetes Pod in the current or provided namespace still matches the current Pod template of the controller that manages it, to detect stale Pods left behind by a rollout or Pods edited manually. Follows the Pod controller chain (e.g. ReplicaSet and Deployment) up to the top-level controller defining a Pod template and compares the fields propagated from the template (labels, ServiceAccount, nodeSelector, volumes and the containers image, command, args, environment, ports, resources and volume mounts), reporting the differences and a unified diff. Containers or defaults injected by admission controllers (e.g. sidecars, LimitRange resources) are reported as differences too",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Pod",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Pod",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pods: Template Drift",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podsTemplateDrift},
 		{Tool: api.Tool{
 			Name:        "pods_run",
 			Description: "Run a Kubernetes Pod in the current or provided namespace with the provided container image and optional name",
@@ -269,6 +428,9 @@ func podsListInAllNamespaces(params api.ToolHandlerParams) (*api.ToolCallResult,
 	if err := p.Err(); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %w", err)), nil
 	}
+	if err := validateSelectors(resourceListOptions.LabelSelector, resourceListOptions.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %w", err)), nil
+	}
 	ret, err := kubernetes.NewCore(params).PodsListInAllNamespaces(params, resourceListOptions)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in all namespaces: %w", err)), nil
@@ -287,6 +449,9 @@ func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, err
 	if err := p.Err(); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace: %w", err)), nil
 	}
+	if err := validateSelectors(resourceListOptions.LabelSelector, resourceListOptions.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s: %w", ns, err)), nil
+	}
 	ret, err := kubernetes.NewCore(params).PodsListInNamespace(params, ns, resourceListOptions)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list pods in namespace %s: %w", ns, err)), nil
@@ -294,6 +459,27 @@ func podsListInNamespace(params api.ToolHandlerParams) (*api.ToolCallResult, err
 	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
 }
 
+func podsListOnNode(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	node := p.RequiredString("node")
+	resourceListOptions := api.ListOptions{
+		AsTable: params.ListOutput.AsTable(),
+	}
+	resourceListOptions.LabelSelector = p.OptionalString("labelSelector", "")
+	resourceListOptions.FieldSelector = p.OptionalString("fieldSelector", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods on node: %w", err)), nil
+	}
+	if err := validateSelectors(resourceListOptions.LabelSelector, resourceListOptions.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods on node %s: %w", node, err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).PodsListOnNode(params, node, resourceListOptions)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods on node %s: %w", node, err)), nil
+	}
+	return api.NewToolCallResult(params.ListOutput.PrintObj(ret)), nil
+}
+
 func podsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	ns := p.OptionalString("namespace", "")
@@ -322,9 +508,37 @@ func podsDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult(ret, err), nil
 }
 
+func podsEvict(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to evict pod: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).PodsEvict(params, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to evict pod %s in namespace %s: %w", name, ns, err)), nil
+	}
+	if ret.Evicted {
+		return api.NewToolCallResult(fmt.Sprintf("Pod %s in namespace %s evicted, it's being terminated gracefully", ret.Name, ret.Namespace), nil), nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Eviction of Pod %s in namespace %s BLOCKED by a PodDisruptionBudget, the Pod was not evicted: %s\n", ret.Name, ret.Namespace, ret.Message))
+	if len(ret.BlockedBy) > 0 {
+		sb.WriteString("\nPodDisruptionBudgets selecting the Pod:\n")
+		for _, pdb := range ret.BlockedBy {
+			sb.WriteString(fmt.Sprintf("- %s\n", pdb))
+		}
+	}
+	sb.WriteString("\nRetry once more Pods are healthy (e.g. after the workload is scaled up or the other disruptions complete), " +
+		"or review the PodDisruptionBudget if it can never allow a disruption")
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
 func podsTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	podsTopOptions := api.PodsTopOptions{
+		TopOptions:    topOptions(p),
 		AllNamespaces: p.OptionalBool("all_namespaces", true),
 		Namespace:     p.OptionalString("namespace", ""),
 		Name:          p.OptionalString("name", ""),
@@ -339,10 +553,11 @@ func podsTop(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	}
 	buf := new(bytes.Buffer)
 	printer := metricsutil.NewTopCmdPrinter(buf, true)
-	err = printer.PrintPodMetrics(ret.Items, true, true, false, "", true)
+	err = printer.PrintPodMetrics(ret.Items, true, true, false, podsTopOptions.SortBy, true)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get pods top: %w", err)), nil
 	}
+	buf.WriteString(topRemainingNote(ret.RemainingItemCount, "pods"))
 	return api.NewToolCallResult(buf.String(), nil), nil
 }
 
@@ -401,6 +616,91 @@ func podsLog(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult(ret, err), nil
 }
 
+func podsSchedulingInfo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod scheduling info: %w", err)), nil
+	}
+	info, err := kubernetes.NewCore(params).PodsSchedulingInfo(params, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s scheduling info in namespace %s: %w", name, ns, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(info)
+	if err != nil {
+		err = fmt.Errorf("failed to get pod scheduling info: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following scheduling information (YAML format) was collected for Pod %s in namespace %s\n%s", info.Pod, info.Namespace, marshalledYaml), err), nil
+}
+
+func podsReadinessExplain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain pod readiness: %w", err)), nil
+	}
+	readiness, err := kubernetes.NewCore(params).PodsReadiness(params, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain pod %s readiness in namespace %s: %w", name, ns, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(readiness)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain pod readiness: %w", err)), nil
+	}
+	sb := strings.Builder{}
+	if readiness.Ready {
+		sb.WriteString(fmt.Sprintf("# Pod %s in namespace %s is Ready\n", readiness.Pod, readiness.Namespace))
+	} else {
+		sb.WriteString(fmt.Sprintf("# Pod %s in namespace %s is not Ready:\n- %s\n", readiness.Pod, readiness.Namespace, strings.Join(readiness.Blockers, "\n- ")))
+	}
+	sb.WriteString(fmt.Sprintf("# Pod conditions and containers readiness (YAML format):\n%s", marshalledYaml))
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func podsEnv(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	container := p.OptionalString("container", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod environment: %w", err)), nil
+	}
+	env, err := kubernetes.NewCore(params).PodsEnv(params, ns, name, container, coreConfig(params).SecretsGetEnabled)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get pod %s environment in namespace %s: %w", name, ns, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(env)
+	if err != nil {
+		err = fmt.Errorf("failed to get pod environment: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following environment (YAML format) was resolved for container %s of Pod %s in namespace %s\n%s", env.Container, env.Pod, env.Namespace, marshalledYaml), err), nil
+}
+
+func podsTemplateDrift(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod template drift: %w", err)), nil
+	}
+	drift, err := kubernetes.NewCore(params).PodsTemplateDrift(params, ns, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod %s template drift in namespace %s: %w", name, ns, err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(drift)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod template drift: %w", err)), nil
+	}
+	if drift.UpToDate {
+		return api.NewToolCallResult(fmt.Sprintf("# Pod %s in namespace %s matches the current Pod template of %s (YAML format)\n%s",
+			drift.Pod, drift.Namespace, drift.Template, marshalledYaml), nil), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# Pod %s in namespace %s doesn't match the current Pod template of %s, it must be recreated to apply the template (YAML format)\n%s"+
+		"# Diff between the live Pod and the Pod template:\n%s", drift.Pod, drift.Namespace, drift.Template, marshalledYaml, drift.Diff), nil), nil
+}
+
 func podsRun(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	p := api.WrapParams(params)
 	ns := p.OptionalString("namespace", "")
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initPodSecurity() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "podsecurity_check",
+			Description: "Report the Pod Security admission levels (pod-security.kubernetes.io enforce, audit and warn labels) of the current or provided namespace and evaluate its existing Pods against the Pod Security Standards level, listing the Pods that violate it and why (e.g. privileged, hostNetwork, runAsNonRoot). Useful to check which workloads would be rejected before enforcing a stricter level",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to check (Optional, current namespace if not provided)",
+					},
+					"level": {
+						Type:        "string",
+						Description: "Pod Security Standards level to evaluate the Pods against (Optional, defaults to the level enforced in the namespace, privileged if not set)",
+						Enum:        []any{"privileged", "baseline", "restricted"},
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Pod Security: Check",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: podSecurityCheck},
+	}
+}
+
+func podSecurityCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	level := p.OptionalString("level", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod security: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).PodSecurityCheck(params, namespace, level)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod security: %w", err)), nil
+	}
+	text := strings.Builder{}
+	if len(ret.Modes) == 0 {
+		text.WriteString(fmt.Sprintf("# No Pod Security admission levels are configured in namespace %s\n", ret.Namespace))
+	} else {
+		yamlModes, err := output.MarshalYaml(ret.Modes)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to check pod security: %w", err)), nil
+		}
+		text.WriteString(fmt.Sprintf("# Pod Security admission levels of namespace %s (YAML format):\n%s", ret.Namespace, yamlModes))
+	}
+	if len(ret.Violations) == 0 {
+		text.WriteString(fmt.Sprintf("# All %d Pods comply with the %s level\n", ret.Pods, ret.Level))
+		return api.NewToolCallResult(text.String(), nil), nil
+	}
+	yamlViolations, err := output.MarshalYaml(ret.Violations)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to check pod security: %w", err)), nil
+	}
+	text.WriteString(fmt.Sprintf("# %d of %d Pods violate the %s level (YAML format):\n%s", len(ret.Violations), ret.Pods, ret.Level, yamlViolations))
+	return api.NewToolCallResult(text.String(), nil), nil
+}
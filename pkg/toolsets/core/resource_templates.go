@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/yosida95/uritemplate/v3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+// clusterObjectTemplates are the cluster objects exposed as MCP resources (Secrets are intentionally not exposed)
+var clusterObjectTemplates = []struct {
+	name        string
+	uriTemplate string
+	gvk         schema.GroupVersionKind
+}{
+	{"pod", "k8s://namespaces/{namespace}/pods/{name}", schema.GroupVersionKind{Version: "v1", Kind: "Pod"}},
+	{"service", "k8s://namespaces/{namespace}/services/{name}", schema.GroupVersionKind{Version: "v1", Kind: "Service"}},
+	{"configmap", "k8s://namespaces/{namespace}/configmaps/{name}", schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}},
+	{"deployment", "k8s://namespaces/{namespace}/deployments/{name}", schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}},
+	{"namespace", "k8s://namespaces/{name}", schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}},
+	{"node", "k8s://nodes/{name}", schema.GroupVersionKind{Version: "v1", Kind: "Node"}},
+}
+
+func initResourceTemplates() []api.ServerResourceTemplate {
+	ret := make([]api.ServerResourceTemplate, 0, len(clusterObjectTemplates))
+	for _, t := range clusterObjectTemplates {
+		template := uritemplate.MustNew(t.uriTemplate)
+		gvk := t.gvk
+		ret = append(ret, api.ServerResourceTemplate{
+			ResourceTemplate: api.ResourceTemplate{
+				URITemplate: t.uriTemplate,
+				Name:        t.name,
+				Description: fmt.Sprintf("Kubernetes %s read from the cluster (YAML format, managedFields are omitted)", t.gvk.Kind),
+				MIMEType:    "application/yaml",
+			},
+			KubernetesHandler: func(params api.ResourceTemplateHandlerParams) (*api.ResourceContent, error) {
+				return clusterObjectRead(params, template, &gvk)
+			},
+			KubernetesWatcher: func(params api.ResourceTemplateHandlerParams, onChange func()) error {
+				return clusterObjectWatch(params, template, &gvk, onChange)
+			},
+		})
+	}
+	return ret
+}
+
+// clusterObjectRead returns the cluster object identified by the URI, retrieved with the access-controlled client
+func clusterObjectRead(params api.ResourceTemplateHandlerParams, template *uritemplate.Template, gvk *schema.GroupVersionKind) (*api.ResourceContent, error) {
+	namespace, name, err := clusterObjectMatch(params.URI, template)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesGet(params, gvk, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", params.URI, err)
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resource %s: %w", params.URI, err)
+	}
+	return &api.ResourceContent{Text: marshalledYaml}, nil
+}
+
+// clusterObjectWatch watches the cluster object identified by the URI with the access-controlled client
+func clusterObjectWatch(params api.ResourceTemplateHandlerParams, template *uritemplate.Template, gvk *schema.GroupVersionKind, onChange func()) error {
+	namespace, name, err := clusterObjectMatch(params.URI, template)
+	if err != nil {
+		return err
+	}
+	return kubernetes.NewCore(params).ResourcesWatch(params, gvk, namespace, name, func(watch.EventType) { onChange() })
+}
+
+// clusterObjectMatch returns the namespace (empty for cluster-scoped objects) and the name of the object identified by the URI
+func clusterObjectMatch(uri string, template *uritemplate.Template) (string, string, error) {
+	values := template.Match(uri)
+	name := values.Get("name").String()
+	if name == "" {
+		return "", "", fmt.Errorf("invalid resource URI %s, expected %s", uri, template.Raw())
+	}
+	return values.Get("namespace").String(), name, nil
+}
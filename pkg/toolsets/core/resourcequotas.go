@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initResourceQuotas() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "resourcequota_set",
+			Description: "Create a Kubernetes ResourceQuota in the current or provided namespace with the provided hard limits, or set them in the existing ResourceQuota (the hard limits not provided are left untouched, uses a merge patch). The resource names and quantities are validated before applying. Returns the hard limit and current usage of every resource of the quota",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the ResourceQuota (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the ResourceQuota",
+					},
+					"hard": {
+						Type:                 "object",
+						Description:          "Hard limits to set keyed by resource name (e.g. {\"pods\": \"10\", \"requests.cpu\": \"4\", \"limits.memory\": \"8Gi\", \"count/deployments.apps\": \"5\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"name", "hard"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "ResourceQuota: Set",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourceQuotaSet},
+	}
+}
+
+func resourceQuotaSet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set resourcequota: %w", err)), nil
+	}
+	hard, err := parseResourceQuotaHard(params.GetArguments()["hard"])
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set resourcequota: %w", err)), nil
+	}
+	result, err := kubernetes.NewCore(params).ResourceQuotaSet(params, namespace, name, hard)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set resourcequota %s: %w", name, err)), nil
+	}
+	action := "updated"
+	if result.Created {
+		action = "created"
+	}
+	yamlUsage, err := output.MarshalYaml(kubernetes.ResourceQuotaUsage(result.ResourceQuota))
+	if err != nil {
+		err = fmt.Errorf("failed to set resourcequota %s: %w", name, err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The ResourceQuota %s/%s has been %s successfully, hard limits and current usage "+
+		"(YAML format, usage is <pending> until computed by the quota controller):\n%s",
+		result.ResourceQuota.Namespace, result.ResourceQuota.Name, action, yamlUsage), err), nil
+}
+
+// parseResourceQuotaHard returns the hard limits argument, numeric values (e.g. {"pods": 10}) are accepted too
+func parseResourceQuotaHard(hard interface{}) (map[string]string, error) {
+	h, ok := hard.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("hard parameter required (object of resource name to quantity)")
+	}
+	ret := make(map[string]string, len(h))
+	for name, value := range h {
+		switch v := value.(type) {
+		case string:
+			ret[name] = v
+		case float64:
+			ret[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("quantity of %s is not a string", name)
+		}
+	}
+	return ret, nil
+}
@@ -4,16 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/confirmation"
 	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
 	"github.com/containers/kubernetes-mcp-server/pkg/output"
 )
 
+const (
+	// resourcesListMaxObjectsWithEvents bounds the number of listed resources whose events are retrieved (one request per resource)
+	resourcesListMaxObjectsWithEvents = 50
+	// resourcesListEventsPerObject bounds the number of events included for each listed resource
+	resourcesListEventsPerObject = 5
+)
+
 func initResources(o api.Openshift) []api.ServerTool {
 	commonApiVersion := "v1 Pod, v1 Service, v1 Node, apps/v1 Deployment, networking.k8s.io/v1 Ingress"
 	if o.IsOpenShift(context.Background()) {
@@ -49,6 +63,22 @@ func initResources(o api.Openshift) []api.ServerTool {
 						Description: "Optional Kubernetes field selector to filter resources by field values (e.g. 'status.phase=Running', 'metadata.name=myresource'). Supported fields vary by resource type. For Pods: metadata.name, metadata.namespace, spec.nodeName, spec.restartPolicy, spec.schedulerName, spec.serviceAccountName, status.phase (Pending/Running/Succeeded/Failed/Unknown), status.podIP, status.nominatedNodeName. See https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/",
 						Pattern:     REGEX_FIELDSELECTOR,
 					},
+					"output": outputSchema(),
+					"withEvents": {
+						Type:        "boolean",
+						Description: fmt.Sprintf("Optional flag to include the %d most recent Warning events of each listed resource (for the first %d resources), useful to triage failing Pods, Deployments, etc. (defaults to false)", resourcesListEventsPerObject, resourcesListMaxObjectsWithEvents),
+						Default:     api.ToRawMessage(false),
+					},
+					"timeoutSeconds": {
+						Type:        "integer",
+						Description: "Optional maximum duration in seconds of the list call enforced by the API server (timeoutSeconds list option), bounds slow lists of large collections server-side",
+						Minimum:     ptr.To(float64(1)),
+					},
+					"namesOnly": {
+						Type:        "boolean",
+						Description: "Optional flag to only return the (namespace/)name of the resources, one per line, instead of the full objects or table (overrides output). Use it to cheaply enumerate resources before acting on one of them (defaults to false)",
+						Default:     api.ToRawMessage(false),
+					},
 				},
 				Required: []string{"apiVersion", "kind"},
 			},
@@ -81,6 +111,12 @@ func initResources(o api.Openshift) []api.ServerTool {
 						Type:        "string",
 						Description: "Name of the resource",
 					},
+					"expandRefs": {
+						Type:        "boolean",
+						Description: "Optional flag to also return the objects referenced by the resource (supported for Pods and workloads with a Pod template: the ServiceAccount, and the ConfigMaps, Secrets and PersistentVolumeClaims referenced by volumes, envFrom, env and imagePullSecrets). Secret values are redacted (only the keys are shown) unless Secret access (secrets_get) is explicitly enabled in the server configuration",
+						Default:     api.ToRawMessage(false),
+					},
+					"output": outputSchema(),
 				},
 				Required: []string{"apiVersion", "kind", "name"},
 			},
@@ -93,7 +129,7 @@ func initResources(o api.Openshift) []api.ServerTool {
 		}, Handler: resourcesGet},
 		{Tool: api.Tool{
 			Name:        "resources_create_or_update",
-			Description: "Create or update a Kubernetes resource via Server-Side Apply. The manifest is the complete desired state: any field this tool previously set and the new manifest omits is removed. To edit an existing resource, fetch it with resources_get, modify it, then re-apply the full resource.\n" + commonApiVersion,
+			Description: "Create or update a Kubernetes resource via Server-Side Apply. The manifest is the complete desired state: any field this tool previously set and the new manifest omits is removed. To edit an existing resource, fetch it with resources_get, modify it, then re-apply the full resource. If the manifest includes metadata.resourceVersion and the resource was modified since it was read, the change is rejected and the resource must be re-fetched (manifests that only set labels and annotations are re-applied on top of the latest version).\n" + commonApiVersion,
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -101,19 +137,531 @@ func initResources(o api.Openshift) []api.ServerTool {
 						Type:        "string",
 						Description: "Complete YAML or JSON representation of the Kubernetes resource (full desired state, not a partial patch). Include apiVersion, kind, metadata, and the full spec.",
 					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace for namespaced resources whose manifest doesn't specify metadata.namespace (ignored for cluster-scoped resources and resources that specify one). If not provided, the configured namespace is used",
+					},
+					"ownerRef": {
+						Type:        "object",
+						Description: "Optional owner to add to metadata.ownerReferences of every provided resource so that they are garbage collected when the owner is deleted. The owner must exist and, if namespaced, be in the same namespace as the resources",
+						Properties: map[string]*jsonschema.Schema{
+							"apiVersion": {
+								Type:        "string",
+								Description: "apiVersion of the owner (e.g. apps/v1)",
+							},
+							"kind": {
+								Type:        "string",
+								Description: "kind of the owner (e.g. Deployment)",
+							},
+							"name": {
+								Type:        "string",
+								Description: "Name of the owner",
+							},
+							"uid": {
+								Type:        "string",
+								Description: "UID of the owner (Optional, resolved from the cluster if not provided)",
+							},
+						},
+						Required: []string{"apiVersion", "kind", "name"},
+					},
+					"ensureNamespace": {
+						Type:        "boolean",
+						Description: "Create the target namespace of the namespaced resources first if it doesn't exist (Optional, default false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"showDiff": {
+						Type:        "boolean",
+						Description: "Return a unified diff between the previous and the applied state of every resource alongside the result, fields managed by the server such as status and managedFields are ignored (Optional, default false)",
+						Default:     api.ToRawMessage(false),
+					},
+					"fieldManager": {
+						Type:        "string",
+						Description: "Optional Server-Side Apply field manager that owns the applied fields (e.g. the name of the tool or team managing the resource), conflicting fields owned by other managers are taken over. See resources_field_ownership. If not provided, the server name is used",
+					},
+				},
+				Required: []string{"resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Create or Update",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesCreateOrUpdate},
+		{Tool: api.Tool{
+			Name:        "resources_apply_kustomize",
+			Description: "Render a kustomization (kustomize) and create or update the resulting Kubernetes resources via Server-Side Apply (same as resources_create_or_update for each rendered resource). Provide either an inline kustomization with the files it references, or the URL of a remote kustomization directory (only if remote manifests are enabled in the server configuration). No resource is applied if any of the rendered resources is not allowed",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kustomization": {
+						Type:        "string",
+						Description: "Inline content of the kustomization.yaml file (e.g. 'resources:\n- deployment.yaml\nnamePrefix: dev-'). Either kustomization or url must be provided",
+					},
+					"files": {
+						Type:                 "object",
+						Description:          "Optional files referenced by the inline kustomization (resources, patches, generator files, components...) keyed by their path relative to the kustomization (e.g. {\"deployment.yaml\": \"apiVersion: apps/v1...\"})",
+						AdditionalProperties: &jsonschema.Schema{Type: "string"},
+					},
+					"url": {
+						Type:        "string",
+						Description: "URL of a remote kustomization directory (e.g. https://github.com/org/repo//path?ref=v1.0.0). Either kustomization or url must be provided",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace for namespaced resources whose rendered manifest doesn't specify metadata.namespace. If not provided, the configured namespace is used",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Apply Kustomize",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesApplyKustomize},
+		{Tool: api.Tool{
+			Name:        "resources_diff",
+			Description: "Show what would change if the provided Kubernetes resource manifest was applied with resources_create_or_update. Returns a unified diff between the live resource and the result of a server-side apply dry-run (nothing is modified in the cluster, fields managed by the server such as status and managedFields are ignored)\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"resource": {
+						Type:        "string",
+						Description: "Complete YAML or JSON representation of the Kubernetes resource to compare with the live resource (same format as resources_create_or_update)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace for namespaced resources whose manifest doesn't specify metadata.namespace (ignored for cluster-scoped resources and resources that specify one). If not provided, the configured namespace is used",
+					},
+				},
+				Required: []string{"resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Diff",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDiff},
+		{Tool: api.Tool{
+			Name: "resources_validate",
+			Description: "Check whether the provided Kubernetes resource manifest would be accepted by the cluster, without applying it (\"will this manifest work?\"). " +
+				"Each resource is validated client-side (YAML/JSON parsing, apiVersion/kind served by the cluster, name, and the OpenAPI schema of the cluster: unknown fields, missing required fields, wrong types) " +
+				"and then with a server-side apply dry-run that runs the API server validation and admission (webhooks, policies, quotas) without persisting anything. " +
+				"Returns a report with the errors and warnings (e.g. deprecated APIs, admission warnings) of each resource\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"resource": {
+						Type:        "string",
+						Description: "Complete YAML or JSON representation of the Kubernetes resources to validate (same format as resources_create_or_update, multiple YAML documents can be separated by ---)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace for namespaced resources whose manifest doesn't specify metadata.namespace (ignored for cluster-scoped resources and resources that specify one). If not provided, the configured namespace is used",
+					},
+				},
+				Required: []string{"resource"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Validate",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesValidate},
+		{Tool: api.Tool{
+			Name: "resources_last_applied",
+			Description: "Get the last configuration applied to a Kubernetes resource with client-side apply (kubectl apply), stored in the kubectl.kubernetes.io/last-applied-configuration annotation, " +
+				"and a unified diff against the live resource showing the fields changed since (only the fields of the last applied configuration are compared). " +
+				"Useful for drift analysis and \"who changed this?\" investigations\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Last Applied Configuration",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesLastApplied},
+		{Tool: api.Tool{
+			Name: "resources_field_ownership",
+			Description: "Get which field manager owns which fields of a Kubernetes resource, parsed from its metadata.managedFields (Server-Side Apply field ownership): " +
+				"for each manager (e.g. kubectl, helm, a controller or operator) its operation (Apply or Update), the time of its last change and the paths of the fields it owns, " +
+				"and the fields owned by more than one manager. Useful to diagnose \"my change keeps getting reverted\" by a controller and to understand Server-Side Apply conflicts\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Field Ownership",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesFieldOwnership},
+		{Tool: api.Tool{
+			Name:        "resources_delete",
+			Description: "Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to delete the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will delete resource from configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"gracePeriodSeconds": {
+						Type:        "integer",
+						Description: "Optional duration in seconds before the object should be deleted. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period for the specified type will be used",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Delete",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesDelete},
+		{Tool: api.Tool{
+			Name:        "resources_scale",
+			Description: "Get or update the scale of a Kubernetes resource in the current cluster by providing its apiVersion, kind, name, and optionally the namespace. If the scale is set in the tool call, the scale will be updated to that value. Always returns the current scale of the resource",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are apps/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: StatefulSet, Deployment)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to get/update the namespaced resource scale from (ignored in case of cluster scoped resources). If not provided, will get/update resource scale from configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"scale": {
+						Type:        "integer",
+						Description: "Optional scale to update the resources scale to. If not provided, will return the current scale of the resource, and not update it",
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Scale",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesScale},
+		{Tool: api.Tool{
+			Name:        "resources_rollout_pause",
+			Description: "Pause the rollout of a Kubernetes Deployment in the current or provided namespace by setting spec.paused (same as kubectl rollout pause). Changes to the Pod template of a paused Deployment don't trigger a rollout, several changes can be staged and rolled out at once with resources_rollout_resume. Returns the new paused state",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Deployment (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Deployment",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Rollout Pause",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesRolloutPause(true)},
+		{Tool: api.Tool{
+			Name:        "resources_rollout_resume",
+			Description: "Resume the rollout of a paused Kubernetes Deployment in the current or provided namespace by unsetting spec.paused (same as kubectl rollout resume). The changes staged to the Pod template while it was paused are rolled out at once. Returns the new paused state",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Deployment (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Deployment",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Rollout Resume",
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesRolloutPause(false)},
+		{Tool: api.Tool{
+			Name: "resources_rollout_history",
+			Description: "List the revisions of a Kubernetes Deployment in the current or provided namespace (the ReplicaSets it owns, ordered by their revision) with the images and resource requests and limits of their Pod template, " +
+				"and the changes of every revision compared to the previous one (e.g. image or resources changed, container added or removed, restart). " +
+				"Useful to find what a previous revision was running, e.g. to roll back the Deployment to the image of a previous revision. " +
+				"Only the revisions kept by the Deployment revisionHistoryLimit are returned",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Deployment (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Deployment",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Rollout History",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesRolloutHistory},
+		{Tool: api.Tool{
+			Name: "resources_set_container_resources",
+			Description: "Get or set the CPU and memory requests and limits of a container in the Pod template of a Kubernetes Deployment, StatefulSet or DaemonSet in the current or provided namespace, without regenerating the whole manifest. " +
+				"Only the provided values are patched (the quantities are validated and requests can't exceed limits), the other values and containers are left unchanged. " +
+				"If no value is provided the current resources are returned. Changing the resources triggers a rollout of the workload Pods. Returns the previous and current resources of the container",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the workload",
+						Enum:        []any{"Deployment", "StatefulSet", "DaemonSet"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the workload",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the workload (Optional, current namespace if not provided)",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the container (or init container) in the Pod template",
+					},
+					"cpuRequest": {
+						Type:        "string",
+						Description: "CPU request (Optional, e.g. 250m or 1, unchanged if not provided, none removes it)",
+					},
+					"cpuLimit": {
+						Type:        "string",
+						Description: "CPU limit (Optional, e.g. 500m or 2, unchanged if not provided, none removes it)",
+					},
+					"memoryRequest": {
+						Type:        "string",
+						Description: "Memory request (Optional, e.g. 128Mi or 1Gi, unchanged if not provided, none removes it)",
+					},
+					"memoryLimit": {
+						Type:        "string",
+						Description: "Memory limit (Optional, e.g. 256Mi or 2Gi, unchanged if not provided, none removes it)",
+					},
+				},
+				Required: []string{"kind", "name", "container"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Set Container Resources",
+				DestructiveHint: ptr.To(true),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesSetContainerResources},
+		{Tool: api.Tool{
+			Name:        "resources_wait",
+			Description: "Wait (like kubectl wait) until a Kubernetes resource in the current cluster satisfies a condition, is deleted, or the timeout expires. Useful to sequence operations (e.g. create a Deployment, wait for it to be Available, then proceed)\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"for": {
+						Type: "string",
+						Description: "Condition to wait for, same syntax as kubectl wait --for: " +
+							"'delete' (wait for the resource to be deleted), " +
+							"'condition=<type>[=<status>]' (e.g. condition=Available, condition=Ready=False; status defaults to True), " +
+							"'jsonpath=<expression>[=<value>]' (e.g. jsonpath={.status.phase}=Running)",
+					},
+					"timeout": {
+						Type:        "string",
+						Description: "Maximum time to wait as a duration (e.g. 30s, 5m) (Optional, default 30s)",
+						Default:     api.ToRawMessage(kubernetes.DefaultWaitTimeout.String()),
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name", "for"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Wait",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesWait},
+		{Tool: api.Tool{
+			Name:        "resources_terminating",
+			Description: "List the Kubernetes resources stuck pending deletion (terminating) for longer than a threshold, with the finalizers blocking their deletion. If a namespace is provided, the resource types configured by the server (scan_kinds) in that namespace are scanned (useful to diagnose a namespace stuck in Terminating), otherwise the cluster Namespaces stuck in Terminating are listed with the reason (remaining content or finalizers)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to scan for terminating resources (Optional, if not provided the terminating Namespaces in the cluster are listed)",
+					},
+					"threshold": {
+						Type:        "string",
+						Description: "Only report resources pending deletion for longer than this duration (e.g. 1m, 1h) (Optional, default 5m)",
+						Default:     api.ToRawMessage(kubernetes.DefaultTerminatingThreshold.String()),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Resources: Terminating",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: resourcesTerminating},
+		{Tool: api.Tool{
+			Name: "resources_remove_finalizers",
+			Description: "Remove finalizers from a Kubernetes resource stuck pending deletion (see resources_terminating). " +
+				"WARNING: removing finalizers skips the cleanup performed by their controllers and can leave orphaned external resources behind, " +
+				"only use it when the controller responsible for the finalizer is gone. Requires explicit confirmation (confirm=true), the user is prompted for confirmation when supported by the client\n" + commonApiVersion,
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"apiVersion": {
+						Type:        "string",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "kind of the resource (examples of valid kind are: Pod, Service, Deployment, Ingress)",
+					},
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the resource",
+					},
+					"finalizers": {
+						Type:        "array",
+						Description: "Finalizers to remove (Optional, all the finalizers are removed if not provided)",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"confirm": {
+						Type:        "boolean",
+						Description: "Must be true to confirm the removal of the finalizers",
+					},
 				},
-				Required: []string{"resource"},
+				Required: []string{"apiVersion", "kind", "name", "confirm"},
 			},
 			Annotations: api.ToolAnnotations{
-				Title:           "Resources: Create or Update",
+				Title:           "Resources: Remove Finalizers",
 				DestructiveHint: ptr.To(true),
-				IdempotentHint:  ptr.To(true),
+				IdempotentHint:  ptr.To(false),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesCreateOrUpdate},
+		}, Handler: resourcesRemoveFinalizers},
 		{Tool: api.Tool{
-			Name:        "resources_delete",
-			Description: "Delete a Kubernetes resource in the current cluster by providing its apiVersion, kind, optionally the namespace, and its name\n" + commonApiVersion,
+			Name: "resources_finalizers",
+			Description: "List the finalizers (metadata.finalizers) of a Kubernetes resource and whether it's pending deletion. " +
+				"Finalizers are removed by their controllers once their cleanup is done, " +
+				"use resources_remove_finalizers to unstick the deletion of a resource whose finalizer controller is gone\n" + commonApiVersion,
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
@@ -127,63 +675,90 @@ func initResources(o api.Openshift) []api.ServerTool {
 					},
 					"namespace": {
 						Type:        "string",
-						Description: "Optional Namespace to delete the namespaced resource from (ignored in case of cluster scoped resources). If not provided, will delete resource from configured namespace",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
 					},
 					"name": {
 						Type:        "string",
 						Description: "Name of the resource",
 					},
-					"gracePeriodSeconds": {
-						Type:        "integer",
-						Description: "Optional duration in seconds before the object should be deleted. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period for the specified type will be used",
-					},
 				},
 				Required: []string{"apiVersion", "kind", "name"},
 			},
 			Annotations: api.ToolAnnotations{
-				Title:           "Resources: Delete",
-				DestructiveHint: ptr.To(true),
+				Title:           "Resources: Finalizers",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesDelete},
+		}, Handler: resourcesFinalizers},
 		{Tool: api.Tool{
-			Name:        "resources_scale",
-			Description: "Get or update the scale of a Kubernetes resource in the current cluster by providing its apiVersion, kind, name, and optionally the namespace. If the scale is set in the tool call, the scale will be updated to that value. Always returns the current scale of the resource",
+			Name: "conditions_explain",
+			Description: "Explain the status.conditions of any Kubernetes resource (e.g. Pod, Node, Deployment, Job, Namespace, PersistentVolumeClaim, custom resources managed by operators) " +
+				"summarizing the type, status, reason and message of each condition and flagging the ones indicating a problem. " +
+				"The polarity of the well-known condition types is taken into account: a positive condition (e.g. Ready, Available) is a problem when False or Unknown, " +
+				"a negative one (e.g. Degraded, MemoryPressure, ReplicaFailure) is a problem when True or Unknown. " +
+				"Conditions computed for an older generation of the resource are flagged as stale. " +
+				"Returns the next actions to investigate the flagged conditions\n" + commonApiVersion,
 			InputSchema: &jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"apiVersion": {
 						Type:        "string",
-						Description: "apiVersion of the resource (examples of valid apiVersion are apps/v1)",
+						Description: "apiVersion of the resource (examples of valid apiVersion are: v1, apps/v1, networking.k8s.io/v1)",
 					},
 					"kind": {
 						Type:        "string",
-						Description: "kind of the resource (examples of valid kind are: StatefulSet, Deployment)",
+						Description: "kind of the resource (examples of valid kind are: Pod, Node, Deployment, Job)",
 					},
 					"namespace": {
 						Type:        "string",
-						Description: "Optional Namespace to get/update the namespaced resource scale from (ignored in case of cluster scoped resources). If not provided, will get/update resource scale from configured namespace",
+						Description: "Optional Namespace of the namespaced resource (ignored in case of cluster scoped resources). If not provided, will use the configured namespace",
 					},
 					"name": {
 						Type:        "string",
 						Description: "Name of the resource",
 					},
-					"scale": {
-						Type:        "integer",
-						Description: "Optional scale to update the resources scale to. If not provided, will return the current scale of the resource, and not update it",
-					},
 				},
 				Required: []string{"apiVersion", "kind", "name"},
 			},
 			Annotations: api.ToolAnnotations{
-				Title:           "Resources: Scale",
-				DestructiveHint: ptr.To(true),
+				Title:           "Conditions: Explain",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
 				IdempotentHint:  ptr.To(true),
 				OpenWorldHint:   ptr.To(true),
 			},
-		}, Handler: resourcesScale},
+		}, Handler: conditionsExplain},
+	}
+}
+
+// outputSchema returns the schema of the output argument of the tools that print Kubernetes resources
+func outputSchema() *jsonschema.Schema {
+	enum := make([]any, 0, len(output.Names))
+	for _, name := range output.Names {
+		enum = append(enum, name)
+	}
+	return &jsonschema.Schema{
+		Type:        "string",
+		Description: "Optional output format (one of: " + strings.Join(output.Names, ", ") + "). If not provided, the default output format configured in the server is used",
+		Enum:        enum,
+	}
+}
+
+// resolveOutput returns the output requested in the tool call arguments, the configured default output, or fallback (in that order)
+func resolveOutput(params api.ToolHandlerParams, fallback output.Output) (output.Output, error) {
+	if name, ok := params.GetArguments()["output"].(string); ok && name != "" {
+		out := output.FromString(name)
+		if out == nil {
+			return nil, fmt.Errorf("invalid output %s, valid outputs are: %s", name, strings.Join(output.Names, ", "))
+		}
+		return out, nil
+	}
+	if params.DefaultOutput != nil {
+		return params.DefaultOutput, nil
 	}
+	return fallback, nil
 }
 
 func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -191,9 +766,13 @@ func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if namespace == nil {
 		namespace = ""
 	}
+	out, err := resolveOutput(params, params.ListOutput)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources, %s", err)), nil
+	}
 	labelSelector := params.GetArguments()["labelSelector"]
 	resourceListOptions := api.ListOptions{
-		AsTable: params.ListOutput.AsTable(),
+		AsTable: out.AsTable(),
 	}
 
 	if labelSelector != nil {
@@ -221,17 +800,58 @@ func resourcesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		return api.NewToolCallResult("", fmt.Errorf("namespace is not a string")), nil
 	}
 
-	ret, err := kubernetes.NewCore(params).ResourcesList(params, gvk, ns, resourceListOptions)
+	p := api.WrapParams(params)
+	withEvents := p.OptionalBool("withEvents", false)
+	namesOnly := p.OptionalBool("namesOnly", false)
+	if namesOnly {
+		out = output.NamesOnly
+		resourceListOptions.AsTable = out.AsTable()
+	}
+	if timeoutSeconds := p.OptionalInt64("timeoutSeconds", 0); timeoutSeconds > 0 {
+		resourceListOptions.TimeoutSeconds = &timeoutSeconds
+	}
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %w", err)), nil
+	}
+	if err = validateSelectors(resourceListOptions.LabelSelector, resourceListOptions.FieldSelector); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %w", err)), nil
+	}
+
+	core := kubernetes.NewCore(params)
+	ret, err := core.ResourcesList(params, gvk, ns, resourceListOptions)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to list resources: %w", err)), nil
 	}
-	printed, err := params.ListOutput.PrintObjStructured(ret)
+	printed, err := out.PrintObjStructured(ret)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to format resources: %w", err)), nil
 	}
+	if namesOnly && printed.Text == "" {
+		printed.Text = "# No resources found\n"
+	}
+	if withEvents {
+		printed.Text += resourcesListEvents(params, core, gvk, ret)
+	}
 	return api.NewToolCallResultFull(printed.Text, printed.Structured, nil), nil
 }
 
+// resourcesListEvents returns the section with the most recent warning events of the listed resources.
+// Events are best effort (e.g. Events might be denied), the listing is still useful without them.
+func resourcesListEvents(ctx context.Context, core *kubernetes.Core, gvk *schema.GroupVersionKind, list runtime.Unstructured) string {
+	events, err := core.ResourcesWarningEvents(ctx, gvk, list, resourcesListMaxObjectsWithEvents, resourcesListEventsPerObject)
+	if err != nil {
+		return fmt.Sprintf("\n# Warning events could not be retrieved: %s\n", err)
+	}
+	if len(events) == 0 {
+		return "\n# No Warning events found for the listed resources\n"
+	}
+	marshalledYaml, err := output.MarshalYaml(events)
+	if err != nil {
+		return fmt.Sprintf("\n# Warning events could not be formatted: %s\n", err)
+	}
+	return "\n# Warning events\n" + marshalledYaml
+}
+
 func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	namespace := params.GetArguments()["namespace"]
 	if namespace == nil {
@@ -241,6 +861,10 @@ func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %s", err)), nil
 	}
+	out, err := resolveOutput(params, output.Yaml)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %s", err)), nil
+	}
 	name := params.GetArguments()["name"]
 	if name == nil {
 		return api.NewToolCallResult("", errors.New("failed to get resource, missing argument name")), nil
@@ -256,15 +880,75 @@ func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		return api.NewToolCallResult("", fmt.Errorf("name is not a string")), nil
 	}
 
-	ret, err := kubernetes.NewCore(params).ResourcesGet(params, gvk, ns, n)
+	p := api.WrapParams(params)
+	expandRefs := p.OptionalBool("expandRefs", false)
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get resource, %s", err)), nil
+	}
+
+	var ret runtime.Unstructured
+	if out.AsTable() {
+		ret, err = kubernetes.NewCore(params).ResourcesGetAsTable(params, gvk, ns, n)
+	} else {
+		ret, err = kubernetes.NewCore(params).ResourcesGet(params, gvk, ns, n)
+	}
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to get resource: %w", err)), nil
 	}
-	printed, err := output.Yaml.PrintObjStructured(ret)
+	printed, err := out.PrintObjStructured(ret)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to format resource: %w", err)), nil
 	}
-	return api.NewToolCallResultFull(printed.Text, printed.Structured, nil), nil
+	if !expandRefs {
+		return api.NewToolCallResultFull(printed.Text, printed.Structured, nil), nil
+	}
+	obj, ok := ret.(*unstructured.Unstructured)
+	if out.AsTable() || !ok {
+		// the Table doesn't include the full object the references are extracted from
+		if obj, err = kubernetes.NewCore(params).ResourcesGet(params, gvk, ns, n); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get resource: %w", err)), nil
+		}
+	}
+	references, err := resourcesGetReferences(params, obj, out)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to expand the references of the resource: %w", err)), nil
+	}
+	return api.NewToolCallResultFull(printed.Text+references, printed.Structured, nil), nil
+}
+
+// resourcesGetReferences returns the objects referenced by the resource, formatted for the resources_get output.
+// Secret values are redacted (only the keys are shown) unless Secret access is enabled.
+func resourcesGetReferences(params api.ToolHandlerParams, obj *unstructured.Unstructured, out output.Output) (string, error) {
+	if !kubernetes.ResourcesReferencesSupported(obj.GroupVersionKind()) {
+		return fmt.Sprintf("\n# Referenced objects are not expanded for %s resources\n", obj.GetKind()), nil
+	}
+	references, err := kubernetes.NewCore(params).ResourcesReferences(params, obj)
+	if err != nil {
+		return "", err
+	}
+	if out.AsTable() {
+		out = output.Yaml
+	}
+	sb := strings.Builder{}
+	sb.WriteString("\n# Referenced objects\n")
+	if len(references.Objects) == 0 && len(references.Missing) == 0 {
+		sb.WriteString("# The resource doesn't reference any object\n")
+	}
+	for _, referenced := range references.Objects {
+		sb.WriteString("---\n")
+		if referenced.GetKind() == "Secret" && !coreConfig(params).SecretsGetEnabled {
+			sb.WriteString(fmt.Sprintf("# Secret values are redacted, keys: %s\n", strings.Join(redactSecret(referenced), ", ")))
+		}
+		printed, err := out.PrintObj(referenced)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(strings.TrimSuffix(printed, "\n") + "\n")
+	}
+	for _, missing := range references.Missing {
+		sb.WriteString(fmt.Sprintf("# Referenced object couldn't be retrieved: %s\n", missing))
+	}
+	return sb.String(), nil
 }
 
 func resourcesCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -278,15 +962,205 @@ func resourcesCreateOrUpdate(params api.ToolHandlerParams) (*api.ToolCallResult,
 		return api.NewToolCallResult("", fmt.Errorf("resource is not a string")), nil
 	}
 
-	resources, err := kubernetes.NewCore(params).ResourcesCreateOrUpdate(params, r)
+	owner, err := parseOwnerReference(params.GetArguments()["ownerRef"])
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources, %s", err)), nil
+	}
+
+	namespace, _ := params.GetArguments()["namespace"].(string)
+	p := api.WrapParams(params)
+	ensureNamespace := p.OptionalBool("ensureNamespace", false)
+	showDiff := p.OptionalBool("showDiff", false)
+	fieldManager := p.OptionalString("fieldManager", "")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %w", err)), nil
+	}
+
+	result, err := kubernetes.NewCore(params).ResourcesCreateOrUpdateWithOptions(params, r, kubernetes.ResourcesCreateOrUpdateOptions{
+		Namespace:       namespace,
+		Owner:           owner,
+		EnsureNamespace: ensureNamespace,
+		ShowDiff:        showDiff,
+		FieldManager:    fieldManager,
+	})
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to create or update resources: %w", err)), nil
 	}
-	marshalledYaml, err := output.MarshalYaml(resources)
+	marshalledYaml, err := output.MarshalYaml(result.Resources)
 	if err != nil {
 		err = fmt.Errorf("failed to create or update resources: %w", err)
 	}
-	return api.NewToolCallResult("# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+	note := ""
+	for _, createdNamespace := range result.CreatedNamespaces {
+		note += fmt.Sprintf("# Namespace %s didn't exist and has been created\n", createdNamespace)
+	}
+	if result.InjectedNamespace != "" {
+		note += fmt.Sprintf("# Namespaced resources without metadata.namespace have been created or updated in namespace %s\n", result.InjectedNamespace)
+	}
+	if showDiff {
+		marshalledYaml += "# The following unified diff shows the changes applied to the previous resources\n" + result.Diff
+	}
+	return api.NewToolCallResult(note+"# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+}
+
+func resourcesApplyKustomize(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	kustomization := p.OptionalString("kustomization", "")
+	url := p.OptionalString("url", "")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply kustomization: %w", err)), nil
+	}
+	files, err := parseKustomizeFiles(params.GetArguments()["files"])
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply kustomization: %w", err)), nil
+	}
+	allowRemote := coreConfig(params).RemoteManifestsEnabled
+	if url != "" && !allowRemote {
+		return api.NewToolCallResult("", errors.New("failed to apply kustomization: loading remote kustomizations is disabled, "+
+			"set remote_manifests_enabled = true in [toolset_configs.core] to enable it")), nil
+	}
+	resources, injectedNamespace, err := kubernetes.NewCore(params).ResourcesApplyKustomize(params, kubernetes.KustomizeOptions{
+		Kustomization:   kustomization,
+		Files:           files,
+		URL:             url,
+		AllowRemote:     allowRemote,
+		Namespace:       namespace,
+		DeniedResources: params,
+	})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to apply kustomization: %w", err)), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(resources)
+	if err != nil {
+		err = fmt.Errorf("failed to apply kustomization: %w", err)
+	}
+	note := ""
+	if injectedNamespace != "" {
+		note = fmt.Sprintf("# Namespaced resources without metadata.namespace have been created or updated in namespace %s\n", injectedNamespace)
+	}
+	return api.NewToolCallResult(note+"# The following resources (YAML) have been created or updated successfully\n"+marshalledYaml, err), nil
+}
+
+func parseKustomizeFiles(files interface{}) (map[string]string, error) {
+	if files == nil {
+		return nil, nil
+	}
+	f, ok := files.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("files is not an object")
+	}
+	ret := make(map[string]string, len(f))
+	for name, content := range f {
+		c, ok := content.(string)
+		if !ok {
+			return nil, fmt.Errorf("content of file %s is not a string", name)
+		}
+		ret[name] = c
+	}
+	return ret, nil
+}
+
+func resourcesDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	resource := p.RequiredString("resource")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources: %w", err)), nil
+	}
+	diff, err := kubernetes.NewCore(params).ResourcesDiff(params, resource, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to diff resources: %w", err)), nil
+	}
+	if diff == "" {
+		return api.NewToolCallResult("No differences found, applying the provided resources wouldn't change the live state", nil), nil
+	}
+	return api.NewToolCallResult("# The following unified diff shows the changes that would be applied to the live resources\n"+diff, nil), nil
+}
+
+func resourcesValidate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	resource := p.RequiredString("resource")
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to validate resources: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesValidate(params, resource, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to validate resources: %w", err)), nil
+	}
+	report, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to validate resources: %w", err)), nil
+	}
+	if ret.Valid {
+		return api.NewToolCallResult("# The provided resources are valid, applying them with resources_create_or_update should succeed (nothing was modified in the cluster)\n"+report, nil), nil
+	}
+	return api.NewToolCallResult("# The provided resources have errors and would be rejected by the cluster (nothing was modified in the cluster)\n"+report, nil), nil
+}
+
+func resourcesLastApplied(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get last applied configuration, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get last applied configuration: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesLastApplied(params, gvk, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get last applied configuration: %w", err)), nil
+	}
+	if ret == nil {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s has no %s annotation, it's not managed with client-side apply (kubectl apply). "+
+			"It might be managed with server-side apply (see metadata.managedFields) or created/updated by other means (e.g. kubectl create, a controller)",
+			gvk.Kind, name, kubernetes.LastAppliedConfigAnnotation), nil), nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# Last applied configuration of %s %s (%s annotation)\n", gvk.Kind, name, kubernetes.LastAppliedConfigAnnotation))
+	sb.WriteString(ret.Applied)
+	if ret.Diff == "" {
+		sb.WriteString("\n# No drift found, the live resource matches the last applied configuration\n")
+	} else {
+		sb.WriteString("\n# The following unified diff shows the changes of the live resource since the last applied configuration\n")
+		sb.WriteString(ret.Diff)
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
+
+func resourcesFieldOwnership(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get field ownership, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get field ownership: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesFieldOwnership(params, gvk, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get field ownership: %w", err)), nil
+	}
+	if len(ret.Managers) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s has no managedFields, field ownership is not tracked for it", gvk.Kind, name), nil), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get field ownership: %w", err)), nil
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("# Field ownership of %s %s (metadata.managedFields)\n", gvk.Kind, name))
+	sb.WriteString(marshalledYaml)
+	if len(ret.SharedFields) > 0 {
+		sb.WriteString("\n# sharedFields are owned by several managers: a manager that writes a different value takes the field over with an Update, " +
+			"or fails with a conflict with a Server-Side Apply unless forced. A controller (Update operation) that owns a field you apply will keep reverting it\n")
+	}
+	return api.NewToolCallResult(sb.String(), nil), nil
 }
 
 func resourcesDelete(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
@@ -377,6 +1251,269 @@ func resourcesScale(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	return api.NewToolCallResult("# Current resource scale (YAML) is below\n"+marshalled, err), nil
 }
 
+// resourcesSetContainerResources updates the requests and limits of a container of a workload, the values not provided are kept
+func resourcesSetContainerResources(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	namespace := p.OptionalString("namespace", "")
+	container := p.RequiredString("container")
+	update := &kubernetes.ContainerResourcesUpdate{
+		CPURequest:    p.OptionalString("cpuRequest", ""),
+		CPULimit:      p.OptionalString("cpuLimit", ""),
+		MemoryRequest: p.OptionalString("memoryRequest", ""),
+		MemoryLimit:   p.OptionalString("memoryLimit", ""),
+	}
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set container resources: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).WorkloadContainerResources(params, namespace, kind, name, container, update)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set container resources of %s %s: %w", kind, name, err)), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to set container resources of %s %s: %w", kind, name, err)), nil
+	}
+	note := fmt.Sprintf("# Resources of container %s of %s %s updated\n", container, kind, name)
+	if !ret.Changed {
+		note = fmt.Sprintf("# Resources of container %s of %s %s (unchanged)\n", container, kind, name)
+	}
+	return api.NewToolCallResult(note+marshalled, nil), nil
+}
+
+// resourcesRolloutPause returns the handler pausing (paused true) or resuming (paused false) a Deployment rollout
+func resourcesRolloutPause(paused bool) api.ToolHandlerFunc {
+	action, state := "pause", "paused"
+	if !paused {
+		action, state = "resume", "resumed"
+	}
+	return func(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+		p := api.WrapParams(params)
+		namespace := p.OptionalString("namespace", "")
+		name := p.RequiredString("name")
+		if err := p.Err(); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to %s rollout: %w", action, err)), nil
+		}
+		deployment, changed, err := kubernetes.NewCore(params).ResourcesRolloutPause(params, namespace, name, paused)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to %s rollout of deployment %s: %w", action, name, err)), nil
+		}
+		note := fmt.Sprintf("# Deployment %s/%s %s\n", deployment.GetNamespace(), deployment.GetName(), state)
+		if !changed {
+			note = fmt.Sprintf("# Deployment %s/%s was already %s, nothing changed\n", deployment.GetNamespace(), deployment.GetName(), state)
+		}
+		isPaused, _, _ := unstructured.NestedBool(deployment.Object, "spec", "paused")
+		replicas, _, _ := unstructured.NestedInt64(deployment.Object, "status", "replicas")
+		updatedReplicas, _, _ := unstructured.NestedInt64(deployment.Object, "status", "updatedReplicas")
+		marshalled, err := output.MarshalYaml(map[string]any{
+			"Paused":          isPaused,
+			"Replicas":        replicas,
+			"UpdatedReplicas": updatedReplicas,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to %s rollout of deployment %s: %w", action, name, err)
+		}
+		return api.NewToolCallResult(note+marshalled, err), nil
+	}
+}
+
+func resourcesRolloutHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history: %w", err)), nil
+	}
+	history, err := kubernetes.NewCore(params).ResourcesRolloutHistory(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history of deployment %s: %w", name, err)), nil
+	}
+	if len(history.Revisions) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("# Deployment %s/%s has no ReplicaSets, no revisions found", history.Namespace, history.Deployment), nil), nil
+	}
+	marshalled, err := output.MarshalYaml(history)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get rollout history of deployment %s: %w", name, err)), nil
+	}
+	note := fmt.Sprintf("# Deployment %s/%s has %d revision(s), current revision is %d (YAML, oldest revision first)\n",
+		history.Namespace, history.Deployment, len(history.Revisions), history.CurrentRevision)
+	return api.NewToolCallResult(note+marshalled, nil), nil
+}
+
+func resourcesWait(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to wait for resource, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	forExpression := p.RequiredString("for")
+	timeout := p.OptionalString("timeout", "")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to wait for resource: %w", err)), nil
+	}
+	waitFor, err := kubernetes.ParseWaitFor(forExpression)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to wait for resource: %w", err)), nil
+	}
+	var timeoutDuration time.Duration
+	if timeout != "" {
+		if timeoutDuration, err = time.ParseDuration(timeout); err != nil || timeoutDuration <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to wait for resource, invalid timeout '%s' (expected a positive duration such as 30s or 5m)", timeout)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesWait(params, gvk, namespace, name, waitFor, timeoutDuration, params.Progress())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to wait for %s: %w", forExpression, err)), nil
+	}
+	if ret == nil {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s has been deleted", gvk.Kind, name), nil), nil
+	}
+	printed, err := output.Yaml.PrintObjStructured(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format resource: %w", err)), nil
+	}
+	return api.NewToolCallResultFull(fmt.Sprintf("# The resource satisfies %s, its current state (YAML) is below\n", forExpression)+printed.Text, printed.Structured, nil), nil
+}
+
+func resourcesTerminating(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	threshold := p.OptionalString("threshold", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list terminating resources: %w", err)), nil
+	}
+	thresholdDuration := kubernetes.DefaultTerminatingThreshold
+	if threshold != "" {
+		var err error
+		if thresholdDuration, err = time.ParseDuration(threshold); err != nil || thresholdDuration < 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list terminating resources, invalid threshold '%s' (expected a duration such as 5m or 1h)", threshold)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesTerminating(params, namespace, params.GetScanKinds(), thresholdDuration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list terminating resources: %w", err)), nil
+	}
+	if len(ret) == 0 {
+		return api.NewToolCallResult("No resources stuck pending deletion were found", nil), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to list terminating resources: %w", err)
+	}
+	return api.NewToolCallResult("# The following resources (YAML) are stuck pending deletion\n"+marshalled, err), nil
+}
+
+func resourcesRemoveFinalizers(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to remove finalizers, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	confirm := p.OptionalBool("confirm", false)
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to remove finalizers: %w", err)), nil
+	}
+	var finalizers []string
+	if f, ok := params.GetArguments()["finalizers"]; ok && f != nil {
+		finalizerSlice, ok := f.([]interface{})
+		if !ok {
+			return api.NewToolCallResult("", errors.New("failed to remove finalizers, finalizers must be an array of strings")), nil
+		}
+		for _, finalizer := range finalizerSlice {
+			finalizerString, ok := finalizer.(string)
+			if !ok {
+				return api.NewToolCallResult("", errors.New("failed to remove finalizers, finalizers must be an array of strings")), nil
+			}
+			finalizers = append(finalizers, finalizerString)
+		}
+	}
+	if !confirm {
+		return api.NewToolCallResult("", fmt.Errorf("failed to remove finalizers: removing finalizers requires explicit confirmation (confirm=true)")), nil
+	}
+	message := fmt.Sprintf("Remove the finalizers %v from %s %s? Their cleanup logic will be skipped", finalizers, gvk.Kind, name)
+	if len(finalizers) == 0 {
+		message = fmt.Sprintf("Remove all the finalizers from %s %s? Their cleanup logic will be skipped", gvk.Kind, name)
+	}
+	if err = confirmation.CheckConfirmation(params, params.Elicitor, message, params.GetConfirmationFallback()); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to remove finalizers: %w", err)), nil
+	}
+	updated, removed, err := kubernetes.NewCore(params).ResourcesRemoveFinalizers(params, gvk, namespace, name, finalizers)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to remove finalizers: %w", err)), nil
+	}
+	printed, err := output.Yaml.PrintObjStructured(updated)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format resource: %w", err)), nil
+	}
+	header := fmt.Sprintf("# Finalizers %v removed, the current state of the resource (YAML) is below\n", removed)
+	if updated.GetDeletionTimestamp() != nil && len(updated.GetFinalizers()) == 0 {
+		// The API server deletes the resource pending deletion as soon as its last finalizer is removed
+		header = fmt.Sprintf("# Finalizers %v removed, the resource was pending deletion and has been deleted, its last state (YAML) is below\n", removed)
+	}
+	return api.NewToolCallResultFull(header+printed.Text, printed.Structured, nil), nil
+}
+
+func resourcesFinalizers(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get finalizers, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get finalizers: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ResourcesFinalizers(params, gvk, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get finalizers: %w", err)), nil
+	}
+	if len(ret.Finalizers) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s has no finalizers", gvk.Kind, name), nil), nil
+	}
+	header := ""
+	if ret.DeletionTimestamp != "" {
+		header = "# WARNING: the resource is pending deletion and blocked by its finalizers, they should be removed by their controllers. " +
+			"Only remove a finalizer (resources_remove_finalizers) if its controller is gone, its cleanup logic will be skipped\n"
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to get finalizers: %w", err)
+	}
+	return api.NewToolCallResult(header+fmt.Sprintf("# The finalizers of %s %s (YAML) are below\n", gvk.Kind, name)+marshalled, err), nil
+}
+
+func conditionsExplain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	gvk, err := parseGroupVersionKind(params.GetArguments())
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain conditions, %s", err)), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err = p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain conditions: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ConditionsExplain(params, gvk, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to explain conditions: %w", err)), nil
+	}
+	if len(ret.Conditions) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("%s %s has no status conditions", gvk.Kind, name), nil), nil
+	}
+	header := fmt.Sprintf("# %s %s: %d of %d conditions indicate a problem\n", gvk.Kind, name, ret.Problems, len(ret.Conditions))
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to explain conditions: %w", err)
+	}
+	return api.NewToolCallResult(header+marshalled, err), nil
+}
+
 func parseScaleValue(desiredScale interface{}) (int64, error) {
 	v, err := api.ParseInt64(desiredScale)
 	if err != nil {
@@ -410,3 +1547,29 @@ func parseGroupVersionKind(arguments map[string]interface{}) (*schema.GroupVersi
 	}
 	return &schema.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: k}, nil
 }
+
+// parseOwnerReference parses the optional ownerRef argument, returns nil if not provided
+func parseOwnerReference(ownerRef interface{}) (*metav1.OwnerReference, error) {
+	if ownerRef == nil {
+		return nil, nil
+	}
+	o, ok := ownerRef.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("ownerRef is not an object")
+	}
+	fields := map[string]string{}
+	for _, field := range []string{"apiVersion", "kind", "name"} {
+		value, ok := o[field].(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("missing argument ownerRef.%s", field)
+		}
+		fields[field] = value
+	}
+	uid, _ := o["uid"].(string)
+	return &metav1.OwnerReference{
+		APIVersion: fields["apiVersion"],
+		Kind:       fields["kind"],
+		Name:       fields["name"],
+		UID:        types.UID(uid),
+	}, nil
+}
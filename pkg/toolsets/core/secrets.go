@@ -0,0 +1,120 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initSecrets() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "secrets_get",
+			Description: "Get a Kubernetes Secret in the current or provided namespace with its data values base64-decoded into readable form (useful to debug TLS or configuration issues). Disabled unless explicitly enabled in the server configuration",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get the Secret from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Secret",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Secrets: Get",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: secretsGet},
+		{Tool: api.Tool{
+			Name:        "secrets_tls_certificates",
+			Description: "Decode the x509 certificates of a Kubernetes TLS Secret (type kubernetes.io/tls) in the current or provided namespace: reports the subject, issuer, SANs and validity (notBefore/notAfter) of each certificate in tls.crt (and ca.crt), flagging expired certificates and those expiring within the expiry window (useful to debug TLS and Ingress certificate issues). Private keys are never returned. Disabled unless Secret access (secrets_get) is explicitly enabled in the server configuration",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to get the Secret from",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the TLS Secret",
+					},
+					"expiryWindow": {
+						Type:        "string",
+						Description: "Flag certificates expiring within this duration (Optional, e.g. 168h, defaults to 720h which is 30 days)",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Secrets: TLS Certificates",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: secretsTLSCertificates},
+	}
+}
+
+func secretsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	if !coreConfig(params).SecretsGetEnabled {
+		return api.NewToolCallResult("", fmt.Errorf("secrets_get is disabled: decoding Secret values must be explicitly enabled by the server administrator (set secrets_get_enabled = true in [toolset_configs.core])")), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).SecretsGetDecoded(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret %s in namespace %s: %w", name, namespace, err)), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format secret: %w", err)), nil
+	}
+	return api.NewToolCallResult("# The following Secret (YAML) has its data values decoded\n"+marshalled, nil), nil
+}
+
+func secretsTLSCertificates(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	if !coreConfig(params).SecretsGetEnabled {
+		return api.NewToolCallResult("", fmt.Errorf("secrets_tls_certificates is disabled: reading Secret values must be explicitly enabled by the server administrator (set secrets_get_enabled = true in [toolset_configs.core])")), nil
+	}
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	expiryWindow := p.OptionalString("expiryWindow", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret certificates: %w", err)), nil
+	}
+	var expiryWindowDuration time.Duration
+	if expiryWindow != "" {
+		var err error
+		if expiryWindowDuration, err = time.ParseDuration(expiryWindow); err != nil || expiryWindowDuration <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get secret certificates, invalid expiryWindow '%s' (expected a positive duration such as 168h)", expiryWindow)), nil
+		}
+	}
+	ret, err := kubernetes.NewCore(params).SecretsTLSCertificates(params, namespace, name, expiryWindowDuration)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get secret %s certificates in namespace %s: %w", name, namespace, err)), nil
+	}
+	marshalled, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to format secret certificates: %w", err)), nil
+	}
+	return api.NewToolCallResult("# The following certificates (YAML) were decoded from the Secret\n"+marshalled, nil), nil
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	labelSelectorSyntax = "label selectors are comma-separated requirements: key=value, key==value, key!=value, " +
+		"key in (value1,value2), key notin (value1,value2), key (the label exists) or !key (the label doesn't exist), " +
+		"e.g. 'app=myapp,env in (prod,qa)'"
+	fieldSelectorSyntax = "field selectors are comma-separated requirements: field=value, field==value or field!=value, " +
+		"e.g. 'status.phase=Running,spec.nodeName=node-1'"
+)
+
+var (
+	// labelSelectorFoundToken matches the offending token of the labels.Parse errors (found 'x', expected: y)
+	labelSelectorFoundToken = regexp.MustCompile(`found '([^']*)',? expected:? (.*)$`)
+	// fieldSelectorFoundToken matches the offending token of the fields.ParseSelector errors (can't understand 'x')
+	fieldSelectorFoundToken = regexp.MustCompile(`can't understand '([^']*)'`)
+)
+
+// validateLabelSelector parses the label selector (if provided) and explains the syntax errors
+// with the offending token and a short syntax hint instead of the raw parser error
+func validateLabelSelector(selector string) error {
+	if selector == "" {
+		return nil
+	}
+	_, err := labels.Parse(selector)
+	if err == nil {
+		return nil
+	}
+	reason := strings.TrimPrefix(err.Error(), "unable to parse requirement: ")
+	if match := labelSelectorFoundToken.FindStringSubmatch(reason); match != nil {
+		reason = fmt.Sprintf("unexpected %s (expected %s)", selectorToken(match[1]), match[2])
+	}
+	return fmt.Errorf("invalid label selector %q: %s, %s", selector, reason, labelSelectorSyntax)
+}
+
+// validateFieldSelector parses the field selector (if provided) and explains the syntax errors
+// with the offending token and a short syntax hint instead of the raw parser error
+func validateFieldSelector(selector string) error {
+	if selector == "" {
+		return nil
+	}
+	_, err := fields.ParseSelector(selector)
+	if err == nil {
+		return nil
+	}
+	reason := err.Error()
+	if match := fieldSelectorFoundToken.FindStringSubmatch(reason); match != nil {
+		reason = fmt.Sprintf("unexpected %s (expected field=value, field==value or field!=value)", selectorToken(match[1]))
+	}
+	return fmt.Errorf("invalid field selector %q: %s, %s", selector, reason, fieldSelectorSyntax)
+}
+
+// validateSelectors validates the label and field selectors (if provided)
+func validateSelectors(labelSelector, fieldSelector string) error {
+	if err := validateLabelSelector(labelSelector); err != nil {
+		return err
+	}
+	return validateFieldSelector(fieldSelector)
+}
+
+func selectorToken(token string) string {
+	if token == "" {
+		return "end of selector"
+	}
+	return fmt.Sprintf("'%s'", token)
+}
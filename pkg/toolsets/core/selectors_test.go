@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SelectorsSuite struct {
+	suite.Suite
+}
+
+func (s *SelectorsSuite) TestValidateLabelSelector() {
+	s.Run("accepts valid selectors", func() {
+		for _, selector := range []string{"", "app", "!app", "app=myapp,env!=prod", "app in (a,b),tier notin (frontend)"} {
+			s.NoError(validateLabelSelector(selector), selector)
+		}
+	})
+	for _, tc := range []struct {
+		selector, reason string
+	}{
+		{"app in myapp", `invalid label selector "app in myapp": unexpected 'myapp' (expected '(')`},
+		{"app in (a b)", `invalid label selector "app in (a b)": unexpected 'b' (expected ',' or ')')`},
+		{"app in (a,b", `invalid label selector "app in (a,b": unexpected end of selector (expected ',' or ')')`},
+		{"env exists", `invalid label selector "env exists": unexpected 'exists' (expected in, notin, =, ==, !=, gt, lt)`},
+		{"a=b,,c=d", `invalid label selector "a=b,,c=d": unexpected ',' (expected identifier after ',')`},
+	} {
+		s.Run("explains "+tc.selector, func() {
+			err := validateLabelSelector(tc.selector)
+			s.Require().Error(err)
+			s.Contains(err.Error(), tc.reason+", label selectors are comma-separated requirements: ")
+		})
+	}
+	s.Run("keeps the parser reason for invalid values", func() {
+		err := validateLabelSelector("app=@x")
+		s.Require().Error(err)
+		s.Contains(err.Error(), `invalid label selector "app=@x": values[0][app]: Invalid value: "@x"`)
+	})
+}
+
+func (s *SelectorsSuite) TestValidateFieldSelector() {
+	s.Run("accepts valid selectors", func() {
+		for _, selector := range []string{"", "status.phase=Running", "status.phase!=Failed,spec.nodeName==node-1"} {
+			s.NoError(validateFieldSelector(selector), selector)
+		}
+	})
+	s.Run("explains invalid selectors", func() {
+		err := validateFieldSelector("status.phase=Running,spec.nodeName")
+		s.Require().Error(err)
+		s.Equal(`invalid field selector "status.phase=Running,spec.nodeName": unexpected 'spec.nodeName' (expected field=value, field==value or field!=value), `+
+			fieldSelectorSyntax, err.Error())
+	})
+}
+
+func (s *SelectorsSuite) TestValidateSelectors() {
+	s.NoError(validateSelectors("app=myapp", "status.phase=Running"))
+	s.ErrorContains(validateSelectors("app in myapp", "status.phase=Running"), "invalid label selector")
+	s.ErrorContains(validateSelectors("app=myapp", "status.phase"), "invalid field selector")
+}
+
+func TestSelectors(t *testing.T) {
+	suite.Run(t, new(SelectorsSuite))
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/openshift"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/version"
+)
+
+func initServer() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "server_info",
+			Description: "Get information about this MCP server and the Kubernetes cluster it is connected to: the server version, the Kubernetes version of the cluster, whether the cluster is OpenShift, and the list of enabled toolsets. Useful to confirm the environment before tailoring further actions (e.g. using OpenShift-specific tools)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Server: Info",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serverInfo},
+	}
+}
+
+func serverInfo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	kubernetesVersion, err := kubernetes.NewCore(params).ServerVersion(params.Context)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get Kubernetes version: %w", err)), nil
+	}
+	toolsets := make([]string, 0)
+	if params.BaseConfig != nil {
+		toolsets = append(toolsets, params.GetToolsets()...)
+	}
+	info := map[string]any{
+		"Server": map[string]any{
+			"Name":       version.BinaryName,
+			"Version":    version.Version,
+			"CommitHash": version.CommitHash,
+			"BuildTime":  version.BuildTime,
+		},
+		"Kubernetes": map[string]any{
+			"Version":   kubernetesVersion.GitVersion,
+			"Platform":  kubernetesVersion.Platform,
+			"OpenShift": openshift.IsOpenshift(params.DiscoveryClient()),
+		},
+		"Toolsets": toolsets,
+	}
+	yamlInfo, err := output.MarshalYaml(info)
+	if err != nil {
+		err = fmt.Errorf("failed to get server info: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following server information (YAML format) was retrieved:\n%s", yamlInfo), err), nil
+}
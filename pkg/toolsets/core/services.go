@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initServices() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "service_endpoints",
+			Description: "Describe the endpoints of a Kubernetes Service in the current or provided namespace: its selector, ports and backing EndpointSlices with the ready and not-ready addresses (and the Pods behind them). Flags Services with zero ready endpoints, a common cause of 503 errors and connection failures",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the Service (Optional, current namespace if not provided)",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the Service",
+					},
+				},
+				Required: []string{"name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Service: Endpoints",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: serviceEndpoints},
+	}
+}
+
+func serviceEndpoints(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	name := p.RequiredString("name")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get service endpoints: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).ServiceEndpoints(params, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get service %s endpoints in namespace %s: %w", name, namespace, err)), nil
+	}
+	yamlEndpoints, err := output.MarshalYaml(ret)
+	if err != nil {
+		err = fmt.Errorf("failed to get service endpoints: %w", err)
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# The following Service endpoints (YAML format) were found:\n%s", yamlEndpoints), err), nil
+}
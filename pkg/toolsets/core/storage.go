@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initStorage() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "storage_overview",
+			Description: "Get an overview of the storage of the cluster: the StorageClasses with their provisioner, default flag, allowVolumeExpansion and reclaimPolicy, and the PersistentVolumeClaims with their binding status. PersistentVolumeClaims stuck Pending (or Lost) are flagged with an Issue explaining the likely cause (no default StorageClass, StorageClass not found, provisioner not provisioning the volume) and their latest Warning event",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Optional Namespace to list the PersistentVolumeClaims from. If not provided, will list the PersistentVolumeClaims from all namespaces",
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Storage: Overview",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: storageOverview},
+	}
+}
+
+func storageOverview(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.OptionalString("namespace", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get storage overview: %w", err)), nil
+	}
+	storageClasses, claims, err := kubernetes.NewCore(params).StorageOverview(params, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get storage overview: %w", err)), nil
+	}
+	var sb strings.Builder
+	if len(storageClasses) == 0 {
+		sb.WriteString("# No StorageClasses found\n")
+	} else {
+		yamlStorageClasses, err := output.MarshalYaml(storageClasses)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to get storage overview: %w", err)), nil
+		}
+		fmt.Fprintf(&sb, "# The following %d StorageClasses were found (YAML format):\n%s", len(storageClasses), yamlStorageClasses)
+	}
+	if len(claims) == 0 {
+		sb.WriteString("# No PersistentVolumeClaims found\n")
+		return api.NewToolCallResult(sb.String(), nil), nil
+	}
+	flagged := 0
+	for _, claim := range claims {
+		if _, ok := claim["Issue"]; ok {
+			flagged++
+		}
+	}
+	yamlClaims, err := output.MarshalYaml(claims)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get storage overview: %w", err)), nil
+	}
+	fmt.Fprintf(&sb, "# The following %d PersistentVolumeClaims were found, %d of them not Bound and flagged with an Issue (YAML format):\n%s",
+		len(claims), flagged, yamlClaims)
+	return api.NewToolCallResult(sb.String(), nil), nil
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+const (
+	// supportBundleDefaultTailLines is the default number of log lines collected for each failing container
+	supportBundleDefaultTailLines = 50
+	// supportBundleMaxFailingPods is the maximum number of failing pods whose logs are collected
+	supportBundleMaxFailingPods = 10
+	// supportBundleMaxSize is the maximum size in bytes of the whole support bundle output, the logs are truncated first
+	supportBundleMaxSize = 256 * 1024
+)
+
+func initSupportBundle() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name:        "namespace_support_bundle",
+			Description: "Collect a support bundle for a Kubernetes namespace in a single output: the Pods with their statuses, the status of the workload controllers (Deployments, StatefulSets, DaemonSets) and PersistentVolumeClaims, the recent warning events, and the recent logs of the failing Pods. Useful to capture everything needed to triage or share a namespace issue in one call",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace to collect the support bundle for (Optional, current namespace if not provided)",
+					},
+					"tail": {
+						Type:        "integer",
+						Description: "Number of lines to retrieve from the end of the logs of each failing container (Optional, 50 by default)",
+						Default:     api.ToRawMessage(supportBundleDefaultTailLines),
+						Minimum:     ptr.To(float64(1)),
+					},
+				},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Namespace: Support Bundle",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: namespaceSupportBundle},
+	}
+}
+
+func namespaceSupportBundle(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := params.NamespaceOrDefault(p.OptionalString("namespace", ""))
+	tail := p.OptionalInt64("tail", supportBundleDefaultTailLines)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to collect support bundle: %w", err)), nil
+	}
+	// Users with namespace-scoped RBAC can't get the Namespace, only a missing namespace prevents collecting the bundle
+	if _, err := params.CoreV1().Namespaces().Get(params, namespace, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+		return api.NewToolCallResult("", fmt.Errorf("failed to collect support bundle for namespace %s: %w", namespace, err)), nil
+	}
+	return api.NewToolCallResultAttachable(gatherSupportBundle(params, params.KubernetesClient, namespace, tail),
+		"support-bundle-"+namespace+".md", "text/markdown", nil), nil
+}
+
+// gatherSupportBundle collects the diagnostic data of the namespace reusing the cluster health check helpers.
+// Failures to collect a section are reported inline so that the rest of the bundle is still returned.
+func gatherSupportBundle(ctx context.Context, client api.KubernetesClient, namespace string, tail int64) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Support Bundle for Namespace `%s`\n\n", namespace)
+	fmt.Fprintf(&sb, "**Collection Time:** %s\n\n", time.Now().Format(time.RFC3339))
+
+	section := func(title, content string, err error) {
+		sb.WriteString(title + "\n\n")
+		if err != nil {
+			fmt.Fprintf(&sb, "*Failed to collect: %s*", err)
+		} else {
+			sb.WriteString(content)
+		}
+		sb.WriteString("\n\n")
+	}
+
+	var failingPods []v1.Pod
+	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err == nil {
+		section("## 1. Pods", formatPodTable(podList.Items)+"\n\n"+formatPodDiagnostics(podList.Items), nil)
+		for _, pod := range podList.Items {
+			if issues, _, restarts := podIssues(&pod); len(issues) > 0 || restarts > podHighRestartThreshold {
+				failingPods = append(failingPods, pod)
+			}
+		}
+	} else {
+		section("## 1. Pods", "", err)
+	}
+
+	sb.WriteString("## 2. Workload Controllers\n\n")
+	for _, kind := range []string{"Deployment", "StatefulSet", "DaemonSet"} {
+		content, err := gatherWorkloadDiagnostics(ctx, client, kind, namespace)
+		section("### "+kind+"s", content, err)
+	}
+
+	content, err := gatherPVCDiagnostics(ctx, client, namespace)
+	section("## 3. Persistent Volume Claims", content, err)
+
+	var eventFailures api.PartialFailures
+	content, err = gatherEventDiagnostics(ctx, client, namespace, &eventFailures)
+	section("## 4. Recent Events (Last Hour)", eventFailures.Markdown()+content, err)
+
+	if sb.Len() > supportBundleMaxSize {
+		return truncateBundle(sb.String(), supportBundleMaxSize)
+	}
+	sb.WriteString("## 5. Logs of Failing Pods\n\n")
+	if len(failingPods) == 0 {
+		sb.WriteString("*No failing pods detected*\n")
+		return sb.String()
+	}
+	if len(failingPods) > supportBundleMaxFailingPods {
+		fmt.Fprintf(&sb, "*Showing logs of %d out of %d failing pods*\n\n", supportBundleMaxFailingPods, len(failingPods))
+		failingPods = failingPods[:supportBundleMaxFailingPods]
+	}
+	for _, pod := range failingPods {
+		for _, cs := range failingContainers(&pod) {
+			for _, previous := range []bool{false, true} {
+				title := fmt.Sprintf("### %s/%s", pod.Name, cs.Name)
+				if previous {
+					// Logs of the previous instance are only available for restarted containers
+					if cs.RestartCount == 0 {
+						continue
+					}
+					title += " (previous)"
+				}
+				log, err := kubernetes.NewCore(client).PodsLog(ctx, namespace, pod.Name, cs.Name, previous, tail)
+				if err != nil {
+					log = fmt.Sprintf("failed to get logs: %s", err)
+				} else if log == "" {
+					log = "(no logs)"
+				}
+				if !appendLogBounded(&sb, title, strings.TrimSuffix(log, "\n"), supportBundleMaxSize) {
+					fmt.Fprintf(&sb, "*Support bundle size limit of %d bytes reached, remaining logs were omitted*\n", supportBundleMaxSize)
+					return sb.String()
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatPodTable prints the status of the pods in a table
+func formatPodTable(pods []v1.Pod) string {
+	if len(pods) == 0 {
+		return "No pods found"
+	}
+	buf := new(bytes.Buffer)
+	w := printers.GetNewTabWriter(buf)
+	_, _ = fmt.Fprintln(w, "NAME\tPHASE\tREADY\tRESTARTS\tNODE")
+	for _, pod := range pods {
+		_, readyCount, restarts := podIssues(&pod)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d/%d\t%d\t%s\n", pod.Name, pod.Status.Phase, readyCount, len(pod.Status.ContainerStatuses),
+			restarts, pod.Spec.NodeName)
+	}
+	_ = w.Flush()
+	return "```\n" + strings.TrimSuffix(buf.String(), "\n") + "\n```"
+}
+
+// failingContainers returns the statuses of the containers of the pod that are not ready or have been restarted
+func failingContainers(pod *v1.Pod) []v1.ContainerStatus {
+	var ret []v1.ContainerStatus
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready || cs.RestartCount > 0 {
+			ret = append(ret, cs)
+		}
+	}
+	return ret
+}
+
+// truncateBundle cuts the bundle to the last complete line that fits in limit along with the notice of the omitted content
+func truncateBundle(bundle string, limit int) string {
+	notice := fmt.Sprintf("\n*Support bundle size limit of %d bytes reached, remaining sections were omitted*\n", limit)
+	if len(bundle) <= limit {
+		return bundle
+	}
+	bundle = bundle[:max(limit-len(notice), 0)]
+	if i := strings.LastIndex(bundle, "\n"); i >= 0 {
+		bundle = bundle[:i+1]
+	}
+	return bundle + notice
+}
+
+// appendLogBounded appends the titled log to sb as long as the resulting size doesn't exceed limit.
+// If it doesn't fit, only the last lines of the log that fit are appended and false is returned.
+func appendLogBounded(sb *strings.Builder, title, log string, limit int) bool {
+	header := title + "\n\n```\n"
+	footer := "\n```\n\n"
+	remaining := limit - sb.Len() - len(header) - len(footer)
+	if remaining <= 0 {
+		return false
+	}
+	fits := len(log) <= remaining
+	if !fits {
+		cut := len(log) - remaining
+		partial := log[cut-1] != '\n'
+		log = log[cut:]
+		// Drop the first line if it was cut
+		if i := strings.Index(log, "\n"); partial && i >= 0 {
+			log = log[i+1:]
+		}
+	}
+	sb.WriteString(header + log + footer)
+	return fits
+}
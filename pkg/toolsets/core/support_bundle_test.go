@@ -0,0 +1,54 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SupportBundleSuite struct {
+	suite.Suite
+}
+
+func (s *SupportBundleSuite) TestAppendLogBounded() {
+	s.Run("appends the whole log if it fits", func() {
+		sb := strings.Builder{}
+		s.True(appendLogBounded(&sb, "### pod/app", "line 1\nline 2", 100))
+		s.Equal("### pod/app\n\n```\nline 1\nline 2\n```\n\n", sb.String())
+	})
+	s.Run("appends the last complete lines if it doesn't fit", func() {
+		sb := strings.Builder{}
+		// header (17) + footer (6) leaves 12 bytes for the log
+		s.False(appendLogBounded(&sb, "### pod/app", "line 1\nline 2\nline 3", 35))
+		s.Equal("### pod/app\n\n```\nline 3\n```\n\n", sb.String())
+	})
+	s.Run("keeps the first line if it wasn't cut", func() {
+		sb := strings.Builder{}
+		s.False(appendLogBounded(&sb, "### pod/app", "line 1\nline 2\nline 3", 36))
+		s.Equal("### pod/app\n\n```\nline 2\nline 3\n```\n\n", sb.String())
+	})
+	s.Run("appends nothing if the limit was already reached", func() {
+		sb := strings.Builder{}
+		sb.WriteString("previous content")
+		s.False(appendLogBounded(&sb, "### pod/app", "line 1", 20))
+		s.Equal("previous content", sb.String())
+	})
+}
+
+func (s *SupportBundleSuite) TestTruncateBundle() {
+	s.Run("returns the bundle if it fits", func() {
+		s.Equal("# Bundle\nline 1\n", truncateBundle("# Bundle\nline 1\n", 100))
+	})
+	s.Run("cuts the bundle to the last complete line that fits along with the notice", func() {
+		bundle := "# Bundle\n" + strings.Repeat("a line\n", 20)
+		truncated := truncateBundle(bundle, 100)
+		s.LessOrEqual(len(truncated), 100)
+		s.True(strings.HasPrefix(truncated, "# Bundle\na line\n"))
+		s.True(strings.HasSuffix(truncated, "a line\n\n*Support bundle size limit of 100 bytes reached, remaining sections were omitted*\n"))
+	})
+}
+
+func TestSupportBundleSuite(t *testing.T) {
+	suite.Run(t, new(SupportBundleSuite))
+}
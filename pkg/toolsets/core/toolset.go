@@ -27,17 +27,42 @@ func (t *Toolset) GetDescription() string {
 
 func (t *Toolset) GetTools(o api.Openshift) []api.ServerTool {
 	return slices.Concat(
+		initAdmissionWebhooks(),
+		initAPIServices(),
+		initCheckpoints(),
+		initClusterHealth(),
+		initConfigArchive(),
+		initConfigMaps(),
+		initCustomResources(),
 		initEvents(),
+		initHPA(),
+		initImages(),
+		initIngresses(),
+		initJobs(),
+		initLeases(),
+		initLogs(),
+		initMesh(),
 		initNamespaces(o),
+		initNetworkPolicies(),
 		initNodes(),
 		initPods(),
+		initPodSecurity(),
+		initResourceQuotas(),
 		initResources(o),
+		initSecrets(),
+		initServer(),
+		initServices(),
+		initStorage(),
+		initSupportBundle(),
+		initWorkloads(),
 	)
 }
 
 func (t *Toolset) GetPrompts() []api.ServerPrompt {
 	return slices.Concat(
 		initHealthChecks(),
+		initMigrateWorkload(),
+		initCapacityPlanning(),
 	)
 }
 
@@ -46,7 +71,7 @@ func (t *Toolset) GetResources() []api.ServerResource {
 }
 
 func (t *Toolset) GetResourceTemplates() []api.ServerResourceTemplate {
-	return nil
+	return initResourceTemplates()
 }
 
 func init() {
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+)
+
+// topOptionsProperties returns the input schema properties to sort and limit the resource consumption of the top tools
+func topOptionsProperties(resources string) map[string]*jsonschema.Schema {
+	sortByValues := make([]any, 0, len(kubernetes.TopSortByValues))
+	for _, v := range kubernetes.TopSortByValues {
+		sortByValues = append(sortByValues, v)
+	}
+	return map[string]*jsonschema.Schema{
+		"sortBy": {
+			Type:        "string",
+			Description: fmt.Sprintf("Resource to sort the %s by: 'cpu' or 'memory' (Optional, not sorted if not provided). The Metrics API doesn't support sorting, the metrics are sorted once retrieved", resources),
+			Enum:        sortByValues,
+		},
+		"descending": {
+			Type:        "boolean",
+			Description: fmt.Sprintf("If true, return the %s with the highest consumption, otherwise the ones with the lowest consumption (Optional, only applicable when sortBy is provided). The returned %s are always printed from the highest to the lowest consumption", resources, resources),
+			Default:     api.ToRawMessage(true),
+		},
+		"limit": {
+			Type:        "integer",
+			Description: fmt.Sprintf("Maximum number of %s to return (e.g. 10 along with sortBy to get the top 10 %s) (Optional, 0 means all). The Metrics API doesn't support pagination, the metrics are limited once retrieved (after sorting)", resources, resources),
+			Minimum:     ptr.To(float64(0)),
+		},
+	}
+}
+
+// topOptions returns the options to sort and limit the resource consumption of the top tools
+func topOptions(p *api.Params) api.TopOptions {
+	return api.TopOptions{
+		SortBy:     p.OptionalString("sortBy", ""),
+		Descending: p.OptionalBool("descending", true),
+		Limit:      int(p.OptionalInt64("limit", 0)),
+	}
+}
+
+// topRemainingNote returns a note with the number of items left out by the limit, if any
+func topRemainingNote(remaining *int64, resources string) string {
+	if remaining == nil || *remaining == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n# %d more %s not shown (limit reached)\n", *remaining, resources)
+}
+
+// mergeProperties returns the input schema properties of all the provided maps
+func mergeProperties(properties ...map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	merged := map[string]*jsonschema.Schema{}
+	for _, p := range properties {
+		maps.Copy(merged, p)
+	}
+	return merged
+}
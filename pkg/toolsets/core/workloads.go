@@ -0,0 +1,226 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubernetes"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+)
+
+func initWorkloads() []api.ServerTool {
+	return []api.ServerTool{
+		{Tool: api.Tool{
+			Name: "workload_logs",
+			Description: "Get the aggregated logs of all the Pods of a Kubernetes workload (Deployment, StatefulSet or DaemonSet) in the current or provided namespace. " +
+				"Log lines from every Pod and container are interleaved by timestamp and prefixed with [pod/container]. " +
+				fmt.Sprintf("Output is limited to the most recent %d bytes", kubernetes.MaxWorkloadLogBytes),
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the workload (Optional, current namespace if not provided)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the workload",
+						Enum:        []any{"Deployment", "StatefulSet", "DaemonSet"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the workload",
+					},
+					"container": {
+						Type:        "string",
+						Description: "Name of the container to get the logs from (Optional, all containers if not provided)",
+					},
+					"tail": {
+						Type:        "integer",
+						Description: "Number of lines to retrieve from the end of the logs of each container (Optional, default: 100)",
+						Default:     api.ToRawMessage(kubernetes.DefaultTailLines),
+						Minimum:     ptr.To(float64(0)),
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Logs",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadLogs},
+		{Tool: api.Tool{
+			Name: "workload_export",
+			Description: "Export a Kubernetes workload (Deployment, StatefulSet or DaemonSet) with everything required to redeploy it elsewhere into a single multi-document YAML bundle: " +
+				"the ServiceAccount, ConfigMaps, Secrets and PersistentVolumeClaims referenced by its Pod template, the Services selecting its Pods and the workload itself, in the order they must be applied. " +
+				"The manifests are cleaned of cluster-specific fields (status, uid, resourceVersion, managedFields, cluster IPs, node ports...) so that the bundle can be applied to another namespace or cluster",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the workload (Optional, current namespace if not provided)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the workload",
+						Enum:        []any{"Deployment", "StatefulSet", "DaemonSet"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the workload",
+					},
+					"targetNamespace": {
+						Type:        "string",
+						Description: "Namespace to set in the exported manifests (Optional, the namespace is removed from the manifests if not provided so that the bundle can be applied to any namespace)",
+					},
+					"includeSecrets": {
+						Type:        "boolean",
+						Description: "If true, the referenced Secrets (including their data) are exported too. Disabled unless Secret access (secrets_get) is explicitly enabled in the server configuration (Optional, default false)",
+						Default:     api.ToRawMessage(false),
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Export",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadExport},
+		{Tool: api.Tool{
+			Name: "workload_images",
+			Description: "Compare the container images in the Pod template of a Kubernetes workload (Deployment, StatefulSet or DaemonSet) with the images (tags and digests) its Pods are actually running. " +
+				"Answers whether the workload is fully rolled out to the new image: reports rollouts in progress, Pods still running an old image or failing to pull the new one (e.g. ImagePullBackOff) and Pods running different digests of the same tag. " +
+				"Optionally (if enabled by the server administrator) queries the image registries for tags with a newer version (best-effort, public registries only)",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the workload (Optional, current namespace if not provided)",
+					},
+					"kind": {
+						Type:        "string",
+						Description: "Kind of the workload",
+						Enum:        []any{"Deployment", "StatefulSet", "DaemonSet"},
+					},
+					"name": {
+						Type:        "string",
+						Description: "Name of the workload",
+					},
+					"checkUpdates": {
+						Type:        "boolean",
+						Description: fmt.Sprintf("Query the image registries for up to %d tags with a newer version than each desired image (Optional, default false, requires image_registry_lookup_enabled in the server configuration)", kubernetes.MaxImageNewerTags),
+					},
+				},
+				Required: []string{"kind", "name"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Workload: Images",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(true),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: workloadImages},
+	}
+}
+
+func workloadLogs(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	container := p.OptionalString("container", "")
+	tailInt := p.OptionalInt64("tail", 0)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload logs: %w", err)), nil
+	}
+	ret, err := kubernetes.NewCore(params).WorkloadLogs(params.Context, ns, kind, name, container, tailInt)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s logs in namespace %s: %w", kind, name, ns, err)), nil
+	} else if ret == "" {
+		ret = fmt.Sprintf("The %s %s in namespace %s has no pods with logged messages yet", kind, name, ns)
+	}
+	return api.NewToolCallResult(ret, nil), nil
+}
+
+func workloadExport(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	targetNamespace := p.OptionalString("targetNamespace", "")
+	includeSecrets := p.OptionalBool("includeSecrets", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to export workload: %w", err)), nil
+	}
+	if includeSecrets && !coreConfig(params).SecretsGetEnabled {
+		return api.NewToolCallResult("", errors.New("failed to export workload: exporting Secrets is disabled, Secret access must be explicitly enabled by the server administrator (set secrets_get_enabled = true in [toolset_configs.core])")), nil
+	}
+	bundle, err := kubernetes.NewCore(params).WorkloadBundle(params, ns, kind, name, targetNamespace, includeSecrets)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to export %s %s: %w", kind, name, err)), nil
+	}
+	documents := make([]string, 0, len(bundle.Objects))
+	for _, obj := range bundle.Objects {
+		document, marshalErr := output.MarshalYaml(obj)
+		if marshalErr != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to export %s %s: %w", kind, name, marshalErr)), nil
+		}
+		documents = append(documents, document)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# The following %d resources have been exported (multi-document YAML bundle, apply them in order):\n", len(bundle.Objects)))
+	sb.WriteString(strings.Join(documents, "---\n"))
+	for _, secret := range bundle.OmittedSecrets {
+		sb.WriteString(fmt.Sprintf("# The referenced Secret %s has been omitted (includeSecrets is false), it must be created before applying the bundle\n", secret))
+	}
+	for _, missing := range bundle.Missing {
+		sb.WriteString(fmt.Sprintf("# Referenced dependency couldn't be exported: %s\n", missing))
+	}
+	return api.NewToolCallResultAttachable(sb.String(), strings.ToLower(kind)+"-"+name+".yaml", "application/yaml", nil), nil
+}
+
+func workloadImages(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	ns := p.OptionalString("namespace", "")
+	kind := p.RequiredString("kind")
+	name := p.RequiredString("name")
+	checkUpdates := p.OptionalBool("checkUpdates", false)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get workload images: %w", err)), nil
+	}
+	if checkUpdates && !coreConfig(params).ImageRegistryLookupEnabled {
+		return api.NewToolCallResult("", errors.New("failed to get workload images: querying the image registries is disabled, registry lookups must be explicitly enabled by the server administrator (set image_registry_lookup_enabled = true in [toolset_configs.core])")), nil
+	}
+	ret, err := kubernetes.NewCore(params).WorkloadImages(params, ns, kind, name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s images: %w", kind, name, err)), nil
+	}
+	if checkUpdates {
+		for _, container := range ret.Containers {
+			newerTags, lookupErr := kubernetes.ImageNewerTags(params, container.Desired, kubernetes.ImageNewerTagsOptions{})
+			if lookupErr != nil {
+				container.RegistryLookupError = lookupErr.Error()
+			} else if len(newerTags) > 0 {
+				container.NewerTags = newerTags
+			}
+		}
+	}
+	yamlImages, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get %s %s images: %w", kind, name, err)), nil
+	}
+	return api.NewToolCallResult(fmt.Sprintf("# %s %s rollout: %s (YAML format):\n%s", kind, name, ret.Rollout, yamlImages), nil), nil
+}
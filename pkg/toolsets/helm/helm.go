@@ -2,6 +2,7 @@ package helm
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/containers/kubernetes-mcp-server/pkg/helm"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -35,6 +36,16 @@ func initHelm() []api.ServerTool {
 						Type:        "string",
 						Description: "Namespace to install the Helm chart in (Optional, current namespace if not provided)",
 					},
+					"wait": {
+						Type:        "boolean",
+						Description: "If true, waits until the release resources (Pods, Deployments, Services, etc.) are ready before returning (Optional, default true)",
+						Default:     api.ToRawMessage(true),
+					},
+					"timeout": {
+						Type:        "string",
+						Description: "Maximum time to wait for the release resources to be ready as a duration (e.g. 30s, 5m, 1h) (Optional, default 5m, ignored if wait is false)",
+						Default:     api.ToRawMessage(helm.DefaultTimeout.String()),
+					},
 				},
 				Required: []string{"chart"},
 			},
@@ -123,7 +134,20 @@ func helmInstall(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	if v, ok := params.GetArguments()["namespace"].(string); ok {
 		namespace = v
 	}
-	ret, err := newHelmClient(params).Install(params, chart, values, name, namespace)
+	p := api.WrapParams(params)
+	wait := p.OptionalBool("wait", true)
+	timeout := p.OptionalString("timeout", "")
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart: %w", err)), nil
+	}
+	opts := helm.InstallOptions{Wait: wait, Progress: params.Progress()}
+	if timeout != "" {
+		var err error
+		if opts.Timeout, err = time.ParseDuration(timeout); err != nil || opts.Timeout <= 0 {
+			return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart, invalid timeout '%s' (expected a positive duration such as 30s or 5m)", timeout)), nil
+		}
+	}
+	ret, err := newHelmClient(params).Install(params, chart, values, name, namespace, opts)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to install helm chart '%s': %w", chart, err)), nil
 	}
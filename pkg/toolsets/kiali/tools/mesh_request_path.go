@@ -0,0 +1,335 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	kialiclient "github.com/containers/kubernetes-mcp-server/pkg/kiali"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kiali/internal/defaults"
+)
+
+func InitMeshRequestPath() []api.ServerTool {
+	ret := make([]api.ServerTool, 0)
+	name := defaults.ToolsetName() + "_mesh_request_path"
+	ret = append(ret, api.ServerTool{
+		Tool: api.Tool{
+			Name:        name,
+			Description: "Traces the request path between two services of the mesh end-to-end: returns the observed call path from the source to the destination service (from the Kiali traffic graph) with the request rate, error rate and response time of every hop, and a summary of the destination service traces with representative trace ids (slowest and failed) to drill into with " + defaults.ToolsetName() + "_get_trace_details. Use this to debug the latency or errors of a specific service-to-service call.",
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"namespace": {
+						Type:        "string",
+						Description: "Namespace of the source service.",
+					},
+					"source": {
+						Type:        "string",
+						Description: "Name of the service the requests originate from.",
+					},
+					"destination": {
+						Type:        "string",
+						Description: "Name of the service the requests are sent to.",
+					},
+					"destinationNamespace": {
+						Type:        "string",
+						Description: "Namespace of the destination service. Defaults to the namespace of the source service.",
+					},
+					"namespaces": {
+						Type:        "string",
+						Description: "Comma-separated list of namespaces to include in the traffic graph, for paths crossing other namespaces. Defaults to the source and destination namespaces.",
+					},
+					"clusterName": {
+						Type:        "string",
+						Description: "Optional cluster name. Defaults to the cluster name in the Kiali configuration.",
+					},
+					"lookbackSeconds": {
+						Type:        "integer",
+						Description: "How far back to search for traces. Default 600 (10m).",
+						Default:     api.ToRawMessage(DefaultLookbackSeconds),
+					},
+					"limit": {
+						Type:        "integer",
+						Description: "Maximum number of traces to sample. Default 10.",
+						Default:     api.ToRawMessage(DefaultLimit),
+					},
+				},
+				Required: []string{"namespace", "source", "destination"},
+			},
+			Annotations: api.ToolAnnotations{
+				Title:           "Mesh Request Path",
+				ReadOnlyHint:    ptr.To(true),
+				DestructiveHint: ptr.To(false),
+				IdempotentHint:  ptr.To(false),
+				OpenWorldHint:   ptr.To(true),
+			},
+		}, Handler: meshRequestPathHandler,
+	})
+	return ret
+}
+
+// MeshRequestPath is the observed path of the requests between two services of the mesh
+type MeshRequestPath struct {
+	Source      string            `json:"source"`
+	Destination string            `json:"destination"`
+	Path        []MeshRequestHop  `json:"path,omitempty"`
+	Traces      *MeshRequestTrace `json:"traces,omitempty"`
+	Notes       []string          `json:"notes,omitempty"`
+}
+
+// MeshRequestHop is an edge of the traffic graph along the request path
+type MeshRequestHop struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	Protocol       string `json:"protocol,omitempty"`
+	RequestRate    string `json:"requestRate,omitempty"`
+	ErrorRate      string `json:"errorRate,omitempty"`
+	ResponseTimeMs string `json:"responseTimeMs,omitempty"`
+	MTLS           bool   `json:"mTLS"`
+}
+
+// MeshRequestTrace summarizes the traces of the destination service
+type MeshRequestTrace struct {
+	Service        string                    `json:"service"`
+	TotalFound     int                       `json:"totalFound"`
+	AvgDurationMs  float64                   `json:"avgDurationMs"`
+	WithErrors     int                       `json:"withErrors"`
+	Representative []MeshRequestTraceExample `json:"representative,omitempty"`
+}
+
+// MeshRequestTraceExample is a trace worth inspecting with the get_trace_details tool of the toolset
+type MeshRequestTraceExample struct {
+	TraceID        string  `json:"traceId"`
+	Reason         string  `json:"reason"`
+	DurationMs     float64 `json:"durationMs"`
+	RootOperation  string  `json:"rootOperation,omitempty"`
+	SlowestService string  `json:"slowestService,omitempty"`
+}
+
+// meshGraph is the subset of the Kiali traffic graph (cytoscape format) needed to find the request path
+type meshGraph struct {
+	Elements struct {
+		Nodes []struct {
+			Data meshGraphNode `json:"data"`
+		} `json:"nodes"`
+		Edges []struct {
+			Data meshGraphEdge `json:"data"`
+		} `json:"edges"`
+	} `json:"elements"`
+}
+
+type meshGraphNode struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+	App       string `json:"app"`
+	Workload  string `json:"workload"`
+}
+
+type meshGraphEdge struct {
+	Source       string `json:"source"`
+	Target       string `json:"target"`
+	ResponseTime string `json:"responseTime"`
+	IsMTLS       string `json:"isMTLS"`
+	Traffic      struct {
+		Protocol string            `json:"protocol"`
+		Rates    map[string]string `json:"rates"`
+	} `json:"traffic"`
+}
+
+// meshTraces is the list_traces response
+type meshTraces struct {
+	Summary struct {
+		TotalFound    int     `json:"total_found"`
+		AvgDurationMs float64 `json:"avg_duration_ms"`
+	} `json:"summary"`
+	Traces []struct {
+		ID             string  `json:"id"`
+		DurationMs     float64 `json:"duration_ms"`
+		RootOp         string  `json:"root_op"`
+		SlowestService string  `json:"slowest_service"`
+		HasErrors      bool    `json:"has_errors"`
+	} `json:"traces"`
+}
+
+func meshRequestPathHandler(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	p := api.WrapParams(params)
+	namespace := p.RequiredString("namespace")
+	source := p.RequiredString("source")
+	destination := p.RequiredString("destination")
+	destinationNamespace := p.OptionalString("destinationNamespace", namespace)
+	namespaces := p.OptionalString("namespaces", "")
+	clusterName := p.OptionalString("clusterName", "")
+	lookbackSeconds := p.OptionalInt64("lookbackSeconds", DefaultLookbackSeconds)
+	limit := p.OptionalInt64("limit", DefaultLimit)
+	if err := p.Err(); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to trace mesh request path: %w", err)), nil
+	}
+	if namespaces == "" {
+		namespaces = strings.Join(slices.Compact([]string{namespace, destinationNamespace}), ",")
+	}
+
+	kiali := kialiclient.NewKiali(params, params.RESTConfig())
+	graphArguments := map[string]any{"namespaces": namespaces, "graphType": "service"}
+	tracesArguments := map[string]any{"namespace": destinationNamespace, "serviceName": destination, "lookbackSeconds": lookbackSeconds, "limit": limit}
+	if clusterName != "" {
+		graphArguments["clusterName"] = clusterName
+		tracesArguments["clusterName"] = clusterName
+	}
+	graphContent, err := kiali.ExecuteRequest(params.Context, KialiGetMeshTrafficGraphEndpoint, graphArguments)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to retrieve mesh traffic graph: %w", err)), nil
+	}
+	graph := &meshGraph{}
+	if err = json.Unmarshal([]byte(graphContent), graph); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to parse mesh traffic graph: %w", err)), nil
+	}
+
+	ret := &MeshRequestPath{
+		Source:      namespace + "/" + source,
+		Destination: destinationNamespace + "/" + destination,
+	}
+	ret.Path = graph.requestPath(namespace, source, destinationNamespace, destination)
+	if len(ret.Path) == 0 {
+		ret.Notes = append(ret.Notes, fmt.Sprintf("No traffic from %s to %s was observed in the graph of the namespaces %s. "+
+			"Check that the services are receiving requests, or include the namespaces of the intermediate services with the namespaces argument.",
+			ret.Source, ret.Destination, namespaces))
+	}
+
+	tracesContent, err := kiali.ExecuteRequest(params.Context, KialiListTracesEndpoint, tracesArguments)
+	traces := &meshTraces{}
+	if err == nil {
+		err = json.Unmarshal([]byte(tracesContent), traces)
+	}
+	if err != nil {
+		ret.Notes = append(ret.Notes, fmt.Sprintf("The traces of %s couldn't be retrieved (is tracing configured in Kiali?): %v", ret.Destination, err))
+	} else {
+		ret.Traces = traces.summarize(destination)
+	}
+
+	yaml, err := output.MarshalYaml(ret)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal mesh request path: %w", err)), nil
+	}
+	return api.NewToolCallResult(yaml, nil), nil
+}
+
+// requestPath returns the shortest path of edges (breadth-first) from the source to the destination service nodes
+func (g *meshGraph) requestPath(sourceNamespace, source, destinationNamespace, destination string) []MeshRequestHop {
+	nodes := make(map[string]meshGraphNode, len(g.Elements.Nodes))
+	var sources []string
+	for _, n := range g.Elements.Nodes {
+		nodes[n.Data.ID] = n.Data
+		if n.Data.matches(sourceNamespace, source) {
+			sources = append(sources, n.Data.ID)
+		}
+	}
+	outgoing := map[string][]meshGraphEdge{}
+	for _, e := range g.Elements.Edges {
+		outgoing[e.Data.Source] = append(outgoing[e.Data.Source], e.Data)
+	}
+	// Each visited node keeps the edge it was reached with to rebuild the path
+	reachedBy := map[string]*meshGraphEdge{}
+	visited := map[string]bool{}
+	queue := slices.Clone(sources)
+	for _, id := range sources {
+		visited[id] = true
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if nodes[id].matches(destinationNamespace, destination) {
+			var path []MeshRequestHop
+			for edge := reachedBy[id]; edge != nil; edge = reachedBy[edge.Source] {
+				path = append([]MeshRequestHop{g.hop(nodes, edge)}, path...)
+			}
+			return path
+		}
+		for i := range outgoing[id] {
+			edge := &outgoing[id][i]
+			if visited[edge.Target] {
+				continue
+			}
+			visited[edge.Target] = true
+			reachedBy[edge.Target] = edge
+			queue = append(queue, edge.Target)
+		}
+	}
+	return nil
+}
+
+func (g *meshGraph) hop(nodes map[string]meshGraphNode, edge *meshGraphEdge) MeshRequestHop {
+	protocol := edge.Traffic.Protocol
+	hop := MeshRequestHop{
+		From:           nodes[edge.Source].String(),
+		To:             nodes[edge.Target].String(),
+		Protocol:       protocol,
+		RequestRate:    edge.Traffic.Rates[protocol],
+		ResponseTimeMs: edge.ResponseTime,
+	}
+	if errorRate, ok := edge.Traffic.Rates[protocol+"PercentErr"]; ok {
+		hop.ErrorRate = errorRate + "%"
+	}
+	if mTLS, err := strconv.ParseFloat(edge.IsMTLS, 64); err == nil && mTLS > 0 {
+		hop.MTLS = true
+	}
+	return hop
+}
+
+func (n meshGraphNode) matches(namespace, name string) bool {
+	return n.Namespace == namespace && (n.Service == name || (n.Service == "" && n.App == name))
+}
+
+func (n meshGraphNode) String() string {
+	for _, name := range []string{n.Service, n.App, n.Workload} {
+		if name != "" {
+			return n.Namespace + "/" + name
+		}
+	}
+	return n.ID
+}
+
+// summarize returns the trace statistics and the representative traces: the slowest one and the first one with errors
+func (t *meshTraces) summarize(service string) *MeshRequestTrace {
+	ret := &MeshRequestTrace{Service: service, TotalFound: t.Summary.TotalFound, AvgDurationMs: t.Summary.AvgDurationMs}
+	slowest, failed := -1, -1
+	for i, trace := range t.Traces {
+		if slowest < 0 || trace.DurationMs > t.Traces[slowest].DurationMs {
+			slowest = i
+		}
+		if trace.HasErrors {
+			ret.WithErrors++
+			if failed < 0 {
+				failed = i
+			}
+		}
+	}
+	for _, example := range []struct {
+		index  int
+		reason string
+	}{{slowest, "slowest"}, {failed, "has errors"}} {
+		if example.index < 0 {
+			continue
+		}
+		trace := t.Traces[example.index]
+		if i := slices.IndexFunc(ret.Representative, func(e MeshRequestTraceExample) bool { return e.TraceID == trace.ID }); i >= 0 {
+			ret.Representative[i].Reason += ", " + example.reason
+			continue
+		}
+		ret.Representative = append(ret.Representative, MeshRequestTraceExample{
+			TraceID:        trace.ID,
+			Reason:         example.reason,
+			DurationMs:     trace.DurationMs,
+			RootOperation:  trace.RootOp,
+			SlowestService: trace.SlowestService,
+		})
+	}
+	return ret
+}
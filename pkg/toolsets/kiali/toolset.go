@@ -23,7 +23,7 @@ func (t *Toolset) GetDescription() string {
 }
 
 func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
-	return slices.Concat(
+	tools := slices.Concat(
 		kialiTools.InitGetMeshTrafficGraph(),
 		kialiTools.InitGetMeshStatus(),
 		kialiTools.InitManageIstioConfigRead(),
@@ -34,7 +34,13 @@ func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
 		kialiTools.InitGetPodPerformance(),
 		kialiTools.InitGetLogs(),
 		kialiTools.InitGetMetrics(),
+		kialiTools.InitMeshRequestPath(),
 	)
+	// Kiali reports the errors of the Istio resources missing in the cluster
+	for i := range tools {
+		tools[i].Dependencies = []api.Dependency{api.DependencyIstio}
+	}
+	return tools
 }
 
 func (t *Toolset) GetPrompts() []api.ServerPrompt {
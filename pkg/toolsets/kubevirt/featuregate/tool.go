@@ -0,0 +1,128 @@
+package featuregate
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/internal/defaults"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+// Action represents the feature gate action to perform
+type Action string
+
+const (
+	ActionList    Action = "list"
+	ActionEnable  Action = "enable"
+	ActionDisable Action = "disable"
+)
+
+func Tools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name: "kubevirt_feature_gate",
+				Description: fmt.Sprintf("Manage the feature gates of the %s installation (spec.configuration.developerConfiguration.featureGates of the KubeVirt resource): "+
+					"list the enabled feature gates, or enable or disable any named feature gate (e.g. LiveMigration, HotplugVolumes, Snapshot, ExpandDisks). "+
+					"Enabling an enabled gate or disabling a disabled gate is a no-op. "+
+					"Note that when KubeVirt is managed by an operator (e.g. HyperConverged) the changes may be reverted by it", defaults.ProductName()),
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"action": {
+							Type:        "string",
+							Enum:        []any{string(ActionList), string(ActionEnable), string(ActionDisable)},
+							Description: "The action to perform: 'list' (show the enabled feature gates), 'enable' or 'disable' (toggle the provided gate)",
+						},
+						"gate": {
+							Type:        "string",
+							Description: "The name of the feature gate to enable or disable (e.g. 'LiveMigration', 'HotplugVolumes'). Required for the enable and disable actions",
+						},
+						"namespace": {
+							Type:        "string",
+							Description: "Optional namespace of the KubeVirt resource. Defaults to the namespace of the only KubeVirt resource in the cluster",
+						},
+						"name": {
+							Type:        "string",
+							Description: "Optional name of the KubeVirt resource. Defaults to the name of the only KubeVirt resource in the cluster",
+						},
+					},
+					Required: []string{"action"},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "KubeVirt: Feature Gate",
+					ReadOnlyHint:    ptr.To(false),
+					DestructiveHint: ptr.To(true),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: featureGate,
+		},
+	}
+}
+
+func featureGate(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	action, err := api.RequiredString(params, "action")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	gate := api.OptionalString(params, "gate", "")
+	if Action(action) != ActionList && gate == "" {
+		return api.NewToolCallResult("", fmt.Errorf("gate parameter required for the %s action", action)), nil
+	}
+
+	dynamicClient := params.DynamicClient()
+	namespace := api.OptionalString(params, "namespace", "")
+	name := api.OptionalString(params, "name", "")
+	if namespace == "" || name == "" {
+		kv, err := kubevirt.FindKubeVirt(params.Context, dynamicClient)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		namespace, name = kv.GetNamespace(), kv.GetName()
+	}
+
+	var message string
+	switch Action(action) {
+	case ActionList:
+		message = fmt.Sprintf("# Feature gates enabled in KubeVirt %s/%s\n", namespace, name)
+	case ActionEnable:
+		enabled, err := kubevirt.EnableFeatureGate(params.Context, dynamicClient, namespace, name, gate)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		if enabled {
+			message = fmt.Sprintf("# Feature gate %s enabled in KubeVirt %s/%s\n", gate, namespace, name)
+		} else {
+			message = fmt.Sprintf("# Feature gate %s was already enabled in KubeVirt %s/%s\n", gate, namespace, name)
+		}
+	case ActionDisable:
+		disabled, err := kubevirt.DisableFeatureGate(params.Context, dynamicClient, namespace, name, gate)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		if disabled {
+			message = fmt.Sprintf("# Feature gate %s disabled in KubeVirt %s/%s\n", gate, namespace, name)
+		} else {
+			message = fmt.Sprintf("# Feature gate %s was not enabled in KubeVirt %s/%s\n", gate, namespace, name)
+		}
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("invalid action '%s': must be one of 'list', 'enable', 'disable'", action)), nil
+	}
+
+	gates, err := kubevirt.GetFeatureGates(params.Context, dynamicClient, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	marshalledYaml, err := output.MarshalYaml(map[string]any{"featureGates": gates})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal feature gates: %w", err)), nil
+	}
+
+	return api.NewToolCallResult(message+marshalledYaml, nil), nil
+}
@@ -0,0 +1,39 @@
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeatureGateToolSuite struct {
+	suite.Suite
+}
+
+func (s *FeatureGateToolSuite) TestToolRegistration() {
+	s.Run("tool is registered", func() {
+		tools := Tools()
+		s.Require().Len(tools, 1, "Expected 1 feature gate tool")
+		s.Equal("kubevirt_feature_gate", tools[0].Tool.Name)
+		s.Equal("KubeVirt: Feature Gate", tools[0].Tool.Annotations.Title)
+		s.NotNil(tools[0].Tool.InputSchema)
+		s.NotNil(tools[0].Handler)
+	})
+
+	s.Run("tool has correct properties", func() {
+		tool := Tools()[0].Tool
+
+		s.False(*tool.Annotations.ReadOnlyHint, "feature gate should not be read-only")
+		s.True(*tool.Annotations.DestructiveHint, "feature gate should be destructive")
+		s.True(*tool.Annotations.IdempotentHint, "feature gate should be idempotent")
+
+		schema := tool.InputSchema
+		s.Require().NotNil(schema.Properties)
+		s.ElementsMatch([]any{"list", "enable", "disable"}, schema.Properties["action"].Enum)
+		s.ElementsMatch([]string{"action"}, schema.Required)
+	})
+}
+
+func TestFeatureGateToolSuite(t *testing.T) {
+	suite.Run(t, new(FeatureGateToolSuite))
+}
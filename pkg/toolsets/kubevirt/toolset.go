@@ -5,11 +5,15 @@ import (
 
 	"github.com/containers/kubernetes-mcp-server/pkg/api"
 	"github.com/containers/kubernetes-mcp-server/pkg/toolsets"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/featuregate"
 	kubevirtdefaults "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/internal/defaults"
 	vm_clone "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/clone"
+	vm_console "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/console"
 	vm_create "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/create"
+	vm_disk "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/disk"
 	vm_guestagent "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/guestagent"
 	vm_lifecycle "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/lifecycle"
+	vm_volume "github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/vm/volume"
 )
 
 type Toolset struct{}
@@ -25,19 +29,33 @@ func (t *Toolset) GetDescription() string {
 }
 
 func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
-	return slices.Concat(
+	tools := slices.Concat(
+		featuregate.Tools(),
 		vm_clone.Tools(),
+		vm_console.Tools(),
 		vm_create.Tools(),
+		vm_disk.Tools(),
 		vm_guestagent.Tools(),
 		vm_lifecycle.Tools(),
+		vm_volume.Tools(),
 	)
+	// KubeVirt tools are only useful in clusters with KubeVirt installed, which may be uninstalled after startup
+	for i := range tools {
+		tools[i].RequiredCapabilities = []api.Capability{api.CapabilityKubeVirt}
+		tools[i].Dependencies = []api.Dependency{api.DependencyKubeVirt, api.DependencyCDI}
+	}
+	return tools
 }
 
 func (t *Toolset) GetPrompts() []api.ServerPrompt {
-	return slices.Concat(
+	prompts := slices.Concat(
 		initVMTroubleshoot(),
 		initWindowsGoldenImage(),
 	)
+	for i := range prompts {
+		prompts[i].RequiredCapabilities = []api.Capability{api.CapabilityKubeVirt}
+	}
+	return prompts
 }
 
 func (t *Toolset) GetResources() []api.ServerResource {
@@ -0,0 +1,85 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+func Tools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "vm_console_info",
+				Description: "Get the console connection details of a running VirtualMachine: the VNC and serial console subresource URLs (served by the Kubernetes API server, clients connect with a WebSocket upgrade using the cluster credentials), the equivalent virtctl commands, and whether each console is available. Useful to connect external console clients, the console streams are not proxied by this tool.",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "The namespace of the virtual machine",
+						},
+						"name": {
+							Type:        "string",
+							Description: "The name of the virtual machine",
+						},
+					},
+					Required: []string{"namespace", "name"},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Virtual Machine: Console Info",
+					ReadOnlyHint:    ptr.To(true),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(true),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: consoleInfo,
+		},
+	}
+}
+
+// isSubresourceAllowed checks that the VirtualMachineInstance subresources (vnc, console) are not denied.
+// The console streams are not requested by the tool, so the check can't be delegated to the API client.
+func isSubresourceAllowed(params api.ToolHandlerParams) bool {
+	gv := kubevirt.VirtualMachineInstanceSubresourcesGVR.GroupVersion()
+	for _, denied := range params.GetDeniedResources() {
+		if denied.Group == gv.Group && denied.Version == gv.Version && (denied.Kind == "" || denied.Kind == "VirtualMachineInstance") {
+			return false
+		}
+	}
+	return true
+}
+
+func consoleInfo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, err := api.RequiredString(params, "namespace")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	name, err := api.RequiredString(params, "name")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	if !isSubresourceAllowed(params) {
+		gvk := kubevirt.VirtualMachineInstanceSubresourcesGVR.GroupVersion().WithKind("VirtualMachineInstance")
+		return api.NewToolCallResult("", fmt.Errorf("resource not allowed: %s", gvk.String())), nil
+	}
+
+	info, err := kubevirt.GetConsoleInfo(params.Context, params.DynamicClient(), params.RESTConfig().Host, namespace, name)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	marshalledYaml, err := output.MarshalYaml(info)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal console info: %w", err)), nil
+	}
+
+	return api.NewToolCallResult(fmt.Sprintf("# Console Information for VM: %s/%s\n\n", namespace, name)+marshalledYaml, nil), nil
+}
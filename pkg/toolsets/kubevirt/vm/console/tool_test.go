@@ -0,0 +1,40 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConsoleToolSuite struct {
+	suite.Suite
+}
+
+func (s *ConsoleToolSuite) TestToolRegistration() {
+	s.Run("tool is registered", func() {
+		tools := Tools()
+		s.Require().Len(tools, 1, "Expected 1 console tool")
+		s.Equal("vm_console_info", tools[0].Tool.Name)
+		s.Equal("Virtual Machine: Console Info", tools[0].Tool.Annotations.Title)
+		s.NotNil(tools[0].Tool.InputSchema)
+		s.NotNil(tools[0].Handler)
+	})
+
+	s.Run("tool has correct properties", func() {
+		tool := Tools()[0].Tool
+
+		s.True(*tool.Annotations.ReadOnlyHint, "console info should be read-only")
+		s.False(*tool.Annotations.DestructiveHint, "console info should not be destructive")
+		s.True(*tool.Annotations.IdempotentHint, "console info should be idempotent")
+
+		schema := tool.InputSchema
+		s.Require().NotNil(schema.Properties)
+		s.Contains(schema.Properties, "namespace")
+		s.Contains(schema.Properties, "name")
+		s.ElementsMatch([]string{"namespace", "name"}, schema.Required)
+	})
+}
+
+func TestConsoleToolSuite(t *testing.T) {
+	suite.Run(t, new(ConsoleToolSuite))
+}
@@ -0,0 +1,92 @@
+package disk
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/internal/defaults"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+func Tools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name: "vm_expand_disk",
+				Description: fmt.Sprintf("Expand the root or a data disk of a %s VirtualMachine by resizing the PersistentVolumeClaim backing it "+
+					"(only disks backed by a PersistentVolumeClaim or a DataVolume). "+
+					"The StorageClass of the claim must allow volume expansion (allowVolumeExpansion), disks can't be shrunk. "+
+					"Returns the hints to complete the expansion inside the guest", defaults.ProductName()),
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "The namespace of the virtual machine",
+						},
+						"vmName": {
+							Type:        "string",
+							Description: "The name of the virtual machine",
+						},
+						"diskName": {
+							Type:        "string",
+							Description: "The name of the disk (volume) of the virtual machine to expand (e.g. 'rootdisk')",
+						},
+						"newSize": {
+							Type:        "string",
+							Description: "The new size of the disk as a Kubernetes quantity greater than the current size (e.g. '30Gi')",
+						},
+					},
+					Required: []string{"namespace", "vmName", "diskName", "newSize"},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Virtual Machine: Expand Disk",
+					ReadOnlyHint:    ptr.To(false),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(false),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: expandDisk,
+		},
+	}
+}
+
+func expandDisk(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, err := api.RequiredString(params, "namespace")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	vmName, err := api.RequiredString(params, "vmName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	diskName, err := api.RequiredString(params, "diskName")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	newSize, err := api.RequiredString(params, "newSize")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	expansion, err := kubevirt.ExpandDisk(params.Context, params.DynamicClient(), namespace, vmName, diskName, newSize)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	marshalledYaml, err := output.MarshalYaml(expansion)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal disk expansion: %w", err)), nil
+	}
+
+	message := fmt.Sprintf("# Disk %s of VirtualMachine %s/%s expanded from %s to %s\n",
+		diskName, namespace, vmName, expansion.PreviousSize, expansion.NewSize)
+	return api.NewToolCallResult(message+marshalledYaml, nil), nil
+}
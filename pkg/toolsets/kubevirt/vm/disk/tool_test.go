@@ -0,0 +1,38 @@
+package disk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiskToolSuite struct {
+	suite.Suite
+}
+
+func (s *DiskToolSuite) TestToolRegistration() {
+	s.Run("tool is registered", func() {
+		tools := Tools()
+		s.Require().Len(tools, 1, "Expected 1 disk tool")
+		s.Equal("vm_expand_disk", tools[0].Tool.Name)
+		s.Equal("Virtual Machine: Expand Disk", tools[0].Tool.Annotations.Title)
+		s.NotNil(tools[0].Tool.InputSchema)
+		s.NotNil(tools[0].Handler)
+	})
+
+	s.Run("tool has correct properties", func() {
+		tool := Tools()[0].Tool
+
+		s.False(*tool.Annotations.ReadOnlyHint, "expand disk should not be read-only")
+		s.False(*tool.Annotations.DestructiveHint, "expand disk should not be destructive")
+		s.False(*tool.Annotations.IdempotentHint, "expand disk should not be idempotent")
+
+		schema := tool.InputSchema
+		s.Require().NotNil(schema.Properties)
+		s.ElementsMatch([]string{"namespace", "vmName", "diskName", "newSize"}, schema.Required)
+	})
+}
+
+func TestDiskToolSuite(t *testing.T) {
+	suite.Run(t, new(DiskToolSuite))
+}
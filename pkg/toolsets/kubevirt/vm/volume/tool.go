@@ -0,0 +1,150 @@
+package volume
+
+import (
+	"fmt"
+
+	"github.com/containers/kubernetes-mcp-server/pkg/api"
+	"github.com/containers/kubernetes-mcp-server/pkg/kubevirt"
+	"github.com/containers/kubernetes-mcp-server/pkg/output"
+	"github.com/containers/kubernetes-mcp-server/pkg/toolsets/kubevirt/internal/defaults"
+	"github.com/google/jsonschema-go/jsonschema"
+	"k8s.io/utils/ptr"
+)
+
+func Tools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name: "vm_add_volume",
+				Description: fmt.Sprintf("Hotplug a volume backed by a DataVolume or a PersistentVolumeClaim to a %s VirtualMachine. "+
+					"The volume is attached as a SCSI disk to the running VM without a restart and persisted in the VM spec. "+
+					"Requires volume hotplug to be enabled in the cluster (HotplugVolumes feature gate)", defaults.ProductName()),
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "The namespace of the virtual machine",
+						},
+						"name": {
+							Type:        "string",
+							Description: "The name of the virtual machine",
+						},
+						"volumeName": {
+							Type:        "string",
+							Description: "The name of the volume (and disk) to add to the virtual machine",
+						},
+						"dataVolume": {
+							Type:        "string",
+							Description: "The name of the DataVolume backing the volume (in the virtual machine namespace). Either dataVolume or persistentVolumeClaim must be provided",
+						},
+						"persistentVolumeClaim": {
+							Type:        "string",
+							Description: "The name of the PersistentVolumeClaim backing the volume (in the virtual machine namespace). Either dataVolume or persistentVolumeClaim must be provided",
+						},
+					},
+					Required: []string{"namespace", "name", "volumeName"},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Virtual Machine: Add Volume",
+					ReadOnlyHint:    ptr.To(false),
+					DestructiveHint: ptr.To(false),
+					IdempotentHint:  ptr.To(false),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: addVolume,
+		},
+		{
+			Tool: api.Tool{
+				Name: "vm_remove_volume",
+				Description: fmt.Sprintf("Unplug a hotplugged volume from a %s VirtualMachine. "+
+					"Only hotplugged volumes can be removed, the volume is detached from the running VM and removed from the VM spec. "+
+					"The backing DataVolume or PersistentVolumeClaim is not deleted", defaults.ProductName()),
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "The namespace of the virtual machine",
+						},
+						"name": {
+							Type:        "string",
+							Description: "The name of the virtual machine",
+						},
+						"volumeName": {
+							Type:        "string",
+							Description: "The name of the hotplugged volume to remove from the virtual machine",
+						},
+					},
+					Required: []string{"namespace", "name", "volumeName"},
+				},
+				Annotations: api.ToolAnnotations{
+					Title:           "Virtual Machine: Remove Volume",
+					ReadOnlyHint:    ptr.To(false),
+					DestructiveHint: ptr.To(true),
+					IdempotentHint:  ptr.To(false),
+					OpenWorldHint:   ptr.To(false),
+				},
+			},
+			Handler: removeVolume,
+		},
+	}
+}
+
+// volumeParams parses the parameters shared by the volume hotplug tools
+func volumeParams(params api.ToolHandlerParams) (namespace, name, volumeName string, err error) {
+	if namespace, err = api.RequiredString(params, "namespace"); err != nil {
+		return
+	}
+	if name, err = api.RequiredString(params, "name"); err != nil {
+		return
+	}
+	volumeName, err = api.RequiredString(params, "volumeName")
+	return
+}
+
+func addVolume(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, name, volumeName, err := volumeParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	source := kubevirt.HotplugVolumeSource{
+		DataVolume:            api.OptionalString(params, "dataVolume", ""),
+		PersistentVolumeClaim: api.OptionalString(params, "persistentVolumeClaim", ""),
+	}
+
+	status, err := kubevirt.AddVolume(params.Context, params.RESTConfig(), params.DynamicClient(), namespace, name, volumeName, source)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	return formatVolumeOutput(fmt.Sprintf("# Volume %s added to VirtualMachine %s/%s\n", volumeName, namespace, name), status)
+}
+
+func removeVolume(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace, name, volumeName, err := volumeParams(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	status, err := kubevirt.RemoveVolume(params.Context, params.RESTConfig(), params.DynamicClient(), namespace, name, volumeName)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	return formatVolumeOutput(fmt.Sprintf("# Volume %s removed from VirtualMachine %s/%s\n", volumeName, namespace, name), status)
+}
+
+// formatVolumeOutput formats the volume hotplug status as YAML output
+func formatVolumeOutput(message string, status *kubevirt.HotplugVolumeStatus) (*api.ToolCallResult, error) {
+	if status.Status == nil {
+		message += "# The VirtualMachineInstance doesn't report a status for the volume yet (the VM may not be running or the request is still being processed)\n"
+	}
+	marshalledYaml, err := output.MarshalYaml(status)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to marshal volume status: %w", err)), nil
+	}
+	return api.NewToolCallResult(message+marshalledYaml, nil), nil
+}
@@ -0,0 +1,44 @@
+package volume
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VolumeToolSuite struct {
+	suite.Suite
+}
+
+func (s *VolumeToolSuite) TestToolRegistration() {
+	tools := Tools()
+	s.Require().Len(tools, 2, "Expected 2 volume tools")
+
+	s.Run("vm_add_volume", func() {
+		tool := tools[0].Tool
+		s.Equal("vm_add_volume", tool.Name)
+		s.Equal("Virtual Machine: Add Volume", tool.Annotations.Title)
+		s.NotNil(tools[0].Handler)
+		s.False(*tool.Annotations.ReadOnlyHint, "add volume should not be read-only")
+		s.False(*tool.Annotations.DestructiveHint, "add volume should not be destructive")
+		s.Require().NotNil(tool.InputSchema)
+		s.Contains(tool.InputSchema.Properties, "dataVolume")
+		s.Contains(tool.InputSchema.Properties, "persistentVolumeClaim")
+		s.ElementsMatch([]string{"namespace", "name", "volumeName"}, tool.InputSchema.Required)
+	})
+
+	s.Run("vm_remove_volume", func() {
+		tool := tools[1].Tool
+		s.Equal("vm_remove_volume", tool.Name)
+		s.Equal("Virtual Machine: Remove Volume", tool.Annotations.Title)
+		s.NotNil(tools[1].Handler)
+		s.False(*tool.Annotations.ReadOnlyHint, "remove volume should not be read-only")
+		s.True(*tool.Annotations.DestructiveHint, "remove volume should be destructive")
+		s.Require().NotNil(tool.InputSchema)
+		s.ElementsMatch([]string{"namespace", "name", "volumeName"}, tool.InputSchema.Required)
+	})
+}
+
+func TestVolumeToolSuite(t *testing.T) {
+	suite.Run(t, new(VolumeToolSuite))
+}
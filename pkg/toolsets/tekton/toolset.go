@@ -21,12 +21,16 @@ func (t *Toolset) GetDescription() string {
 }
 
 func (t *Toolset) GetTools(_ api.Openshift) []api.ServerTool {
-	return slices.Concat(
+	tools := slices.Concat(
 		pipelineTools(),
 		pipelineRunTools(),
 		taskTools(),
 		taskRunTools(),
 	)
+	for i := range tools {
+		tools[i].Dependencies = []api.Dependency{api.DependencyTekton}
+	}
+	return tools
 }
 
 func (t *Toolset) GetPrompts() []api.ServerPrompt {